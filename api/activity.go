@@ -63,6 +63,8 @@ const (
 
 	// ActivityDatabaseRecoveryPITRDone is the type for performing PITR on the database successfully.
 	ActivityDatabaseRecoveryPITRDone ActivityType = "bb.database.recovery.pitr.done"
+	// ActivityDatabaseBackupPrune is the type for pruning the backup by the backup retention policy.
+	ActivityDatabaseBackupPrune ActivityType = "bb.database.backup.prune"
 )
 
 // ActivityLevel is the level of activities.
@@ -203,6 +205,16 @@ type ActivitySQLEditorQueryPayload struct {
 	AdviceList   []advisor.Advice `json:"adviceList"`
 }
 
+// ActivityDatabaseBackupPrunePayload is the API message payloads for pruning backups.
+type ActivityDatabaseBackupPrunePayload struct {
+	DatabaseID int        `json:"databaseId"`
+	BackupID   int        `json:"backupId"`
+	BackupType BackupType `json:"backupType"`
+	// Used by activity table to display info without paying the join cost
+	DatabaseName string `json:"databaseName"`
+	BackupName   string `json:"backupName"`
+}
+
 // Activity is the API message for an activity.
 type Activity struct {
 	ID int `jsonapi:"primary,activity"`
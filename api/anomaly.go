@@ -12,10 +12,14 @@ const (
 	AnomalyInstanceConnection AnomalyType = "bb.anomaly.instance.connection"
 	// AnomalyInstanceMigrationSchema is the anomaly type for schema migrations.
 	AnomalyInstanceMigrationSchema AnomalyType = "bb.anomaly.instance.migration-schema"
+	// AnomalyInstanceWALArchiveLag is the anomaly type for the Postgres WAL retained on the instance for the archiving.
+	AnomalyInstanceWALArchiveLag AnomalyType = "bb.anomaly.instance.wal-archive-lag"
 	// AnomalyDatabaseBackupPolicyViolation is the anomaly type for backup policy violations.
 	AnomalyDatabaseBackupPolicyViolation AnomalyType = "bb.anomaly.database.backup.policy-violation"
 	// AnomalyDatabaseBackupMissing is the anomaly type for missing backups.
 	AnomalyDatabaseBackupMissing AnomalyType = "bb.anomaly.database.backup.missing"
+	// AnomalyDatabaseBackupVerificationFailure is the anomaly type for backups failing the restore verification.
+	AnomalyDatabaseBackupVerificationFailure AnomalyType = "bb.anomaly.database.backup.verification-failure"
 	// AnomalyDatabaseConnection is the anomaly type for database connections.
 	AnomalyDatabaseConnection AnomalyType = "bb.anomaly.database.connection"
 	// AnomalyDatabaseSchemaDrift is the anomaly type for database schema drifts.
@@ -41,6 +45,10 @@ func AnomalySeverityFromType(anomalyType AnomalyType) AnomalySeverity {
 		return AnomalySeverityMedium
 	case AnomalyDatabaseBackupMissing:
 		return AnomalySeverityHigh
+	case AnomalyDatabaseBackupVerificationFailure:
+		return AnomalySeverityHigh
+	case AnomalyInstanceWALArchiveLag:
+		return AnomalySeverityHigh
 	case AnomalyInstanceConnection:
 	case AnomalyInstanceMigrationSchema:
 	case AnomalyDatabaseConnection:
@@ -56,20 +64,44 @@ type AnomalyInstanceConnectionPayload struct {
 	Detail string `json:"detail,omitempty"`
 }
 
+// AnomalyInstanceWALArchiveLagPayload is the API message for WAL archive lag payloads.
+type AnomalyInstanceWALArchiveLagPayload struct {
+	// The replication slot retaining the WAL not archived yet.
+	SlotName string `json:"slotName,omitempty"`
+	// The bytes of the WAL retained by the replication slot.
+	LagBytes int64 `json:"lagBytes,omitempty"`
+	// The error of the last failed archiving round if any.
+	Detail string `json:"detail,omitempty"`
+}
+
 // AnomalyDatabaseBackupPolicyViolationPayload is the API message for backup policy violation payloads.
 type AnomalyDatabaseBackupPolicyViolationPayload struct {
 	EnvironmentID          int                      `json:"environmentId,omitempty"`
 	ExpectedBackupSchedule BackupPlanPolicySchedule `json:"expectedSchedule,omitempty"`
 	ActualBackupSchedule   BackupPlanPolicySchedule `json:"actualSchedule,omitempty"`
+	// The cron expressions of the backup plan policy and the backup setting.
+	ExpectedCronExpression string `json:"expectedCronExpression,omitempty"`
+	ActualCronExpression   string `json:"actualCronExpression,omitempty"`
 }
 
 // AnomalyDatabaseBackupMissingPayload is the API message for missing backup payloads.
 type AnomalyDatabaseBackupMissingPayload struct {
 	ExpectedBackupSchedule BackupPlanPolicySchedule `json:"expectedSchedule,omitempty"`
+	// The cron expression of the backup setting.
+	ExpectedCronExpression string `json:"expectedCronExpression,omitempty"`
 	// Time of last successful backup created
 	LastBackupTs int64 `json:"lastBackupTs,omitempty"`
 }
 
+// AnomalyDatabaseBackupVerificationFailurePayload is the API message for backup verification failure payloads.
+type AnomalyDatabaseBackupVerificationFailurePayload struct {
+	BackupID   int    `json:"backupId,omitempty"`
+	BackupName string `json:"backupName,omitempty"`
+	VerifiedTs int64  `json:"verifiedTs,omitempty"`
+	// Verification failure detail
+	Detail string `json:"detail,omitempty"`
+}
+
 // AnomalyDatabaseConnectionPayload is the API message for database connection payloads.
 type AnomalyDatabaseConnectionPayload struct {
 	// Connection failure detail
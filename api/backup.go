@@ -3,10 +3,17 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/youzi-1122/bytebase/common/cron"
+	robfigCron "github.com/robfig/cron/v3"
 	"go.uber.org/zap/zapcore"
 )
 
+// backupScheduleHorizon is the period to evaluate the max interval between the backups of a schedule.
+const backupScheduleHorizon = 366 * 24 * time.Hour
+
 // BackupStatus is the status of a backup.
 type BackupStatus string
 
@@ -37,7 +44,7 @@ type BackupStorageBackend string
 const (
 	// BackupStorageBackendLocal is the local storage backend for a backup.
 	BackupStorageBackendLocal BackupStorageBackend = "LOCAL"
-	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup. Not used yet.
+	// BackupStorageBackendS3 is the AWS S3 or S3-compatible storage backend for a backup.
 	BackupStorageBackendS3 BackupStorageBackend = "S3"
 	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup. Not used yet.
 	BackupStorageBackendGCS BackupStorageBackend = "GCS"
@@ -45,6 +52,18 @@ const (
 	BackupStorageBackendOSS BackupStorageBackend = "OSS"
 )
 
+// BackupCompression is the compression algorithm of a backup file.
+type BackupCompression string
+
+const (
+	// BackupCompressionNone is the backup file without compression.
+	BackupCompressionNone BackupCompression = ""
+	// BackupCompressionGzip is the gzip compressed backup file.
+	BackupCompressionGzip BackupCompression = "GZIP"
+	// BackupCompressionZstd is the zstd compressed backup file.
+	BackupCompressionZstd BackupCompression = "ZSTD"
+)
+
 // BinlogInfo is the binlog coordination for MySQL.
 type BinlogInfo struct {
 	FileName string `json:"fileName"`
@@ -64,6 +83,83 @@ type BackupPayload struct {
 	// It is recorded within the same transaction as the dump so that the binlog position is consistent with the dump.
 	// Please refer to https://github.com/youzi-1122/bytebase/blob/main/docs/design/pitr-mysql.md#full-backup for details.
 	BinlogInfo BinlogInfo `json:"binlogInfo"`
+
+	// Postgres related fields
+	// PgBaseBackupID is the ID of the base backup of the Postgres instance the backup references if the PITR policy is
+	// enabled. The base backup is taken once per instance per backup schedule, and shared by the backups of the
+	// databases on the instance. It is zero for the backups taken along with their own base backups.
+	PgBaseBackupID int `json:"pgBaseBackupId,omitempty"`
+	// PgBaseBackup is the base backup of the Postgres cluster the backup restores from for PITR.
+	PgBaseBackup *PgBaseBackup `json:"pgBaseBackup,omitempty"`
+
+	// The encoding, the checksum and the size of the backup file.
+	BackupFileInfo
+
+	// Verification is the result of the latest restore verification of the backup, and nil if the backup is not verified.
+	Verification *BackupVerification `json:"verification,omitempty"`
+}
+
+// BackupFileInfo is the encoding, the checksum and the size of a backup file.
+type BackupFileInfo struct {
+	// Compression is the compression algorithm of the backup file.
+	Compression BackupCompression `json:"compression,omitempty"`
+	// Encrypted is whether the backup file is encrypted by the AES-GCM with the workspace backup encryption key.
+	Encrypted bool `json:"encrypted,omitempty"`
+	// Checksum is the hex encoded SHA-256 checksum of the backup file, which is verified before restoring.
+	// It is empty for the backups taken before the checksum is recorded.
+	Checksum string `json:"checksum,omitempty"`
+	// Size is the byte size of the backup file.
+	Size int64 `json:"size,omitempty"`
+}
+
+// PgBaseBackup is the physical base backup of the Postgres cluster, from which the archived WAL is replayed for PITR.
+// Please refer to https://www.postgresql.org/docs/current/continuous-archiving.html for details.
+type PgBaseBackup struct {
+	// StorageBackend is the storage backend of the base backup tarball, and Path is its path in the storage backend.
+	// The storage backend is empty for the base backups taken along with the backups, which are stored next to the
+	// dump files in the storage backend of the backups.
+	StorageBackend BackupStorageBackend `json:"storageBackend,omitempty"`
+	Path           string               `json:"path"`
+	// StartLSN is the WAL location where the base backup starts, and StartWALFile is the WAL segment containing it.
+	// The archived WAL segments since the start WAL file are needed to restore the base backup.
+	StartLSN     string `json:"startLsn"`
+	StartWALFile string `json:"startWalFile"`
+	Timeline     int    `json:"timeline"`
+	// EndTs is the time when the base backup finishes, so the base backup can only restore to a later point in time.
+	EndTs int64 `json:"endTs"`
+
+	// The encoding, the checksum and the size of the base backup file.
+	BackupFileInfo
+}
+
+// BackupVerificationStatus is the status of a backup restore verification.
+type BackupVerificationStatus string
+
+const (
+	// BackupVerificationStatusPassed is the status if the backup is restored and the sanity checks pass.
+	BackupVerificationStatusPassed BackupVerificationStatus = "PASSED"
+	// BackupVerificationStatusFailed is the status if the backup fails to restore or the sanity checks fail.
+	BackupVerificationStatusFailed BackupVerificationStatus = "FAILED"
+)
+
+// BackupVerification is the result of restoring a backup into a scratch database and running the sanity checks.
+type BackupVerification struct {
+	Status     BackupVerificationStatus `json:"status"`
+	VerifiedTs int64                    `json:"verifiedTs"`
+	// InstanceID is the instance where the scratch database is created.
+	InstanceID int `json:"instanceId"`
+	// Detail is the reason of the failure.
+	Detail    string                     `json:"detail,omitempty"`
+	TableList []*BackupVerificationTable `json:"tableList,omitempty"`
+}
+
+// BackupVerificationTable is the row count comparison of a table in the restored database.
+type BackupVerificationTable struct {
+	Name string `json:"name"`
+	// ExpectedRowCount is the row count of the table from the latest schema sync of the database.
+	ExpectedRowCount int64 `json:"expectedRowCount"`
+	// ActualRowCount is the row count of the table in the restored database, and -1 if the table is missing.
+	ActualRowCount int64 `json:"actualRowCount"`
 }
 
 // Backup is the API message for a backup.
@@ -121,9 +217,10 @@ type BackupCreate struct {
 	DatabaseID int `jsonapi:"attr,databaseId"`
 
 	// Domain specific fields
-	Name                    string               `jsonapi:"attr,name"`
-	Type                    BackupType           `jsonapi:"attr,type"`
-	StorageBackend          BackupStorageBackend `jsonapi:"attr,storageBackend"`
+	Name string     `jsonapi:"attr,name"`
+	Type BackupType `jsonapi:"attr,type"`
+	// StorageBackend is determined by the backup storage policy of the environment.
+	StorageBackend          BackupStorageBackend
 	MigrationHistoryVersion string
 	Path                    string
 }
@@ -162,6 +259,15 @@ type BackupPatch struct {
 	Payload string
 }
 
+// BackupDelete is the API message for deleting a backup.
+type BackupDelete struct {
+	ID int
+
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	DeleterID int
+}
+
 // BackupSetting is the backup setting for a database.
 type BackupSetting struct {
 	ID int `jsonapi:"primary,backupSetting"`
@@ -184,8 +290,34 @@ type BackupSetting struct {
 	Enabled   bool `jsonapi:"attr,enabled"`
 	Hour      int  `jsonapi:"attr,hour"`
 	DayOfWeek int  `jsonapi:"attr,dayOfWeek"`
+	// Schedule is the cron expression in UTC, which overrides the hour and the day of week if set.
+	Schedule string `jsonapi:"attr,schedule"`
 	// HookURL is the callback url to be requested (using HTTP GET) after a successful backup.
 	HookURL string `jsonapi:"attr,hookUrl"`
+	// RetentionPolicy is the JSON encoded BackupRetentionPolicy.
+	RetentionPolicy string `jsonapi:"attr,retentionPolicy"`
+	// VerificationPolicy is the JSON encoded BackupVerificationPolicy.
+	VerificationPolicy string `jsonapi:"attr,verificationPolicy"`
+	// PITRPolicy is the JSON encoded BackupPITRPolicy.
+	PITRPolicy string `jsonapi:"attr,pitrPolicy"`
+}
+
+// GetCronExpression returns the cron expression of the backup schedule.
+func (s *BackupSetting) GetCronExpression() string {
+	return getBackupCronExpression(s.Schedule, s.Hour, s.DayOfWeek)
+}
+
+// GetBackupPlanPolicySchedule returns the backup plan policy schedule which the backup setting conforms to.
+func (s *BackupSetting) GetBackupPlanPolicySchedule() BackupPlanPolicySchedule {
+	switch {
+	case !s.Enabled:
+		return BackupPlanPolicyScheduleUnset
+	case s.Schedule != "":
+		return BackupPlanPolicyScheduleCustom
+	case s.DayOfWeek == -1:
+		return BackupPlanPolicyScheduleDaily
+	}
+	return BackupPlanPolicyScheduleWeekly
 }
 
 // BackupSettingFind is the message to get a backup settings.
@@ -214,11 +346,185 @@ type BackupSettingUpsert struct {
 	Enabled   bool   `jsonapi:"attr,enabled"`
 	Hour      int    `jsonapi:"attr,hour"`
 	DayOfWeek int    `jsonapi:"attr,dayOfWeek"`
+	Schedule  string `jsonapi:"attr,schedule"`
 	HookURL   string `jsonapi:"attr,hookUrl"`
+	// RetentionPolicy is the JSON encoded BackupRetentionPolicy, and nil means the retention policy is unchanged.
+	RetentionPolicy *string `jsonapi:"attr,retentionPolicy"`
+	// VerificationPolicy is the JSON encoded BackupVerificationPolicy, and nil means the verification policy is unchanged.
+	VerificationPolicy *string `jsonapi:"attr,verificationPolicy"`
+	// PITRPolicy is the JSON encoded BackupPITRPolicy, and nil means the PITR policy is unchanged.
+	PITRPolicy *string `jsonapi:"attr,pitrPolicy"`
+}
+
+// GetCronExpression returns the cron expression of the backup schedule.
+func (upsert *BackupSettingUpsert) GetCronExpression() string {
+	return getBackupCronExpression(upsert.Schedule, upsert.Hour, upsert.DayOfWeek)
+}
+
+// getBackupCronExpression converts the hour and the day of week to the cron expression if the schedule is unset.
+func getBackupCronExpression(schedule string, hour, dayOfWeek int) string {
+	if schedule != "" {
+		return schedule
+	}
+	dayOfWeekExpr := "*"
+	if dayOfWeek != -1 {
+		dayOfWeekExpr = strconv.Itoa(dayOfWeek)
+	}
+	return fmt.Sprintf("0 %d * * %s", hour, dayOfWeekExpr)
+}
+
+// ParseBackupSchedule parses the standard cron expression of a backup schedule.
+// The backups are taken at most once an hour, so the minute field must be a single value.
+func ParseBackupSchedule(expr string) (robfigCron.Schedule, error) {
+	schedule, err := robfigCron.ParseStandard(expr)
+	if err != nil {
+		return nil, err
+	}
+	switch s := schedule.(type) {
+	case *robfigCron.SpecSchedule:
+		// The minute field is a single value if exactly one bit is set, and the bit 63 is the star bit.
+		if bits := s.Minute &^ (1 << 63); bits&(bits-1) != 0 {
+			return nil, fmt.Errorf("the minute field of backup schedule %q should be a single value", expr)
+		}
+	case robfigCron.ConstantDelaySchedule:
+		if s.Delay < time.Hour {
+			return nil, fmt.Errorf("the interval of backup schedule %q should be at least an hour", expr)
+		}
+	}
+	if schedule.Next(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero() {
+		return nil, fmt.Errorf("backup schedule %q never activates", expr)
+	}
+	return schedule, nil
+}
+
+// GetBackupScheduleMaxInterval returns the max interval between the consecutive backups of the cron expression from now.
+func GetBackupScheduleMaxInterval(expr string, now time.Time) (time.Duration, error) {
+	schedule, err := ParseBackupSchedule(expr)
+	if err != nil {
+		return 0, err
+	}
+	return cron.MaxInterval(schedule, now.UTC(), backupScheduleHorizon), nil
+}
+
+// IsBackupScheduleSatisfied returns whether the backups of the actual cron expression are taken at least as frequently as
+// the expected one.
+func IsBackupScheduleSatisfied(actualExpr, expectedExpr string, now time.Time) (bool, error) {
+	actualInterval, err := GetBackupScheduleMaxInterval(actualExpr, now)
+	if err != nil {
+		return false, err
+	}
+	expectedInterval, err := GetBackupScheduleMaxInterval(expectedExpr, now)
+	if err != nil {
+		return false, err
+	}
+	return actualInterval <= expectedInterval, nil
+}
+
+// BackupRetentionPolicy is the retention policy for the backups of a database, which is enforced by the backup runner.
+// The backups of each type are pruned separately, and the policy is empty by default to keep the backups forever.
+type BackupRetentionPolicy struct {
+	Automatic BackupRetentionRule `json:"automatic"`
+	Manual    BackupRetentionRule `json:"manual"`
+	PITR      BackupRetentionRule `json:"pitr"`
+}
+
+// BackupRetentionRule is the retention rule for the backups of a type. Zero means no limit.
+type BackupRetentionRule struct {
+	// KeepCount is the number of the latest successful backups to keep.
+	KeepCount int `json:"keepCount"`
+	// RetentionPeriodTs is the max age of the backups in seconds.
+	RetentionPeriodTs int64 `json:"retentionPeriodTs"`
+}
+
+// GetRule returns the retention rule for the backup type.
+func (p *BackupRetentionPolicy) GetRule(backupType BackupType) BackupRetentionRule {
+	switch backupType {
+	case BackupTypeAutomatic:
+		return p.Automatic
+	case BackupTypeManual:
+		return p.Manual
+	case BackupTypePITR:
+		return p.PITR
+	}
+	return BackupRetentionRule{}
+}
+
+// Validate validates the retention policy.
+func (p *BackupRetentionPolicy) Validate() error {
+	for _, backupType := range []BackupType{BackupTypeAutomatic, BackupTypeManual, BackupTypePITR} {
+		rule := p.GetRule(backupType)
+		if rule.KeepCount < 0 {
+			return fmt.Errorf("invalid keep count %d for %s backups", rule.KeepCount, backupType)
+		}
+		if rule.RetentionPeriodTs < 0 {
+			return fmt.Errorf("invalid retention period %d for %s backups", rule.RetentionPeriodTs, backupType)
+		}
+	}
+	return nil
+}
+
+// UnmarshalBackupRetentionPolicy will unmarshal payload to backup retention policy.
+func UnmarshalBackupRetentionPolicy(payload string) (*BackupRetentionPolicy, error) {
+	var p BackupRetentionPolicy
+	if payload == "" {
+		return &p, nil
+	}
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal backup retention policy %q, error: %w", payload, err)
+	}
+	return &p, nil
+}
+
+// BackupVerificationPolicy is the policy to verify the latest successful backup of a database periodically, by restoring
+// it into a scratch database and comparing the table row counts with the ones from the schema sync.
+type BackupVerificationPolicy struct {
+	Enabled bool `json:"enabled"`
+	// SandboxInstanceID is the instance to create the scratch database, and zero means the instance of the database.
+	// The sandbox instance must have the same engine as the instance of the database.
+	SandboxInstanceID int `json:"sandboxInstanceId"`
+}
+
+// Validate validates the verification policy.
+func (p *BackupVerificationPolicy) Validate() error {
+	if p.SandboxInstanceID < 0 {
+		return fmt.Errorf("invalid sandbox instance ID %d", p.SandboxInstanceID)
+	}
+	return nil
+}
+
+// UnmarshalBackupVerificationPolicy will unmarshal payload to backup verification policy.
+func UnmarshalBackupVerificationPolicy(payload string) (*BackupVerificationPolicy, error) {
+	var p BackupVerificationPolicy
+	if payload == "" {
+		return &p, nil
+	}
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal backup verification policy %q, error: %w", payload, err)
+	}
+	return &p, nil
+}
+
+// BackupPITRPolicy is the policy of the point-in-time recovery for Postgres databases. If enabled, the WAL of the
+// instance is archived continuously, and a base backup of the instance is taken along with each backup of the database.
+// MySQL databases don't need the policy, since the binlog is fetched on demand for the recovery.
+type BackupPITRPolicy struct {
+	Enabled bool `json:"enabled"`
+}
+
+// UnmarshalBackupPITRPolicy will unmarshal payload to backup PITR policy.
+func UnmarshalBackupPITRPolicy(payload string) (*BackupPITRPolicy, error) {
+	var p BackupPITRPolicy
+	if payload == "" {
+		return &p, nil
+	}
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal backup PITR policy %q, error: %w", payload, err)
+	}
+	return &p, nil
 }
 
-// BackupSettingsMatch is the message to find backup settings matching the conditions.
+// BackupSettingsMatch is the message to find the enabled backup settings scheduled in the time window (StartTs, EndTs].
 type BackupSettingsMatch struct {
-	Hour      int
-	DayOfWeek int
+	StartTs int64
+	EndTs   int64
 }
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupSettingCronExpression(t *testing.T) {
+	tests := []struct {
+		setting      BackupSetting
+		wantExpr     string
+		wantSchedule BackupPlanPolicySchedule
+	}{
+		{BackupSetting{Enabled: true, Hour: 3, DayOfWeek: -1}, "0 3 * * *", BackupPlanPolicyScheduleDaily},
+		{BackupSetting{Enabled: true, Hour: 3, DayOfWeek: 5}, "0 3 * * 5", BackupPlanPolicyScheduleWeekly},
+		{BackupSetting{Enabled: true, Hour: 3, DayOfWeek: -1, Schedule: "0 */6 * * *"}, "0 */6 * * *", BackupPlanPolicyScheduleCustom},
+		{BackupSetting{Enabled: false, Hour: 3, DayOfWeek: -1}, "0 3 * * *", BackupPlanPolicyScheduleUnset},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.wantExpr, test.setting.GetCronExpression())
+		require.Equal(t, test.wantSchedule, test.setting.GetBackupPlanPolicySchedule())
+	}
+}
+
+func TestIsBackupScheduleSatisfied(t *testing.T) {
+	now := time.Date(2022, 7, 30, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		actual   string
+		expected string
+		want     bool
+	}{
+		{"0 3 * * *", "@daily", true},
+		{"0 3 * * 5", "@daily", false},
+		{"0 3 * * 5", "@weekly", true},
+		{"0 */6 * * *", "@daily", true},
+		{"0 0 1 * *", "@weekly", false},
+		{"0 0 1 * *", "@monthly", true},
+		{"0 0 1,15 * *", "@monthly", true},
+		{"@every 12h", "@daily", true},
+	}
+
+	for _, test := range tests {
+		got, err := IsBackupScheduleSatisfied(test.actual, test.expected, now)
+		require.NoError(t, err)
+		require.Equal(t, test.want, got, "%s %s", test.actual, test.expected)
+	}
+
+	_, err := IsBackupScheduleSatisfied("*/5 * * * *", "@daily", now)
+	require.Error(t, err)
+}
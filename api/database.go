@@ -125,3 +125,12 @@ type DatabasePatch struct {
 	SyncStatus           *SyncStatus
 	LastSuccessfulSyncTs *int64
 }
+
+// DatabaseSchemaDiff is the API message for the DDL statements to migrate the target database schema to the source database schema.
+// This returns json instead of jsonapi since it's not dealing with a particular resource.
+type DatabaseSchemaDiff struct {
+	SourceDatabaseID int `json:"sourceDatabaseId"`
+	TargetDatabaseID int `json:"targetDatabaseId"`
+	// StatementList is the ordered DDL statements to be applied to the target database.
+	StatementList []string `json:"statementList"`
+}
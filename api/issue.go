@@ -184,6 +184,16 @@ type UpdateSchemaGhostContext struct {
 	VCSPushEvent *vcs.PushEvent
 }
 
+// IsGhostSupported checks the engine type if gh-ost supports it.
+// gh-ost relies on the MySQL binlog replication, which TiDB and OceanBase don't provide.
+func IsGhostSupported(dbType db.Type) bool {
+	switch dbType {
+	case db.MySQL, db.MariaDB:
+		return true
+	}
+	return false
+}
+
 // PITRContext is the issue create context for performing a PITR in a database.
 type PITRContext struct {
 	DatabaseID int `json:"databaseId"`
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// PgInstanceBaseBackup is the API message for a base backup of a Postgres instance for PITR.
+// The base backup covers the whole cluster, so it's taken once per instance per backup schedule and shared by the
+// backups of the databases on the instance, which reference it by ID.
+type PgInstanceBaseBackup struct {
+	ID int
+
+	// Standard fields
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Related fields
+	InstanceID int
+
+	// Domain specific fields
+	Payload PgBaseBackup
+}
+
+// PgInstanceBaseBackupCreate is the API message for creating a base backup of a Postgres instance.
+type PgInstanceBaseBackupCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+
+	// Related fields
+	InstanceID int
+
+	// Domain specific fields
+	Payload PgBaseBackup
+}
+
+// PgInstanceBaseBackupFind is the API message for finding the base backups of Postgres instances.
+type PgInstanceBaseBackupFind struct {
+	ID *int
+
+	// Related fields
+	InstanceID *int
+}
+
+func (find *PgInstanceBaseBackupFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// PgInstanceBaseBackupDelete is the API message for deleting a base backup of a Postgres instance.
+type PgInstanceBaseBackupDelete struct {
+	ID int
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// PgWALArchive is the API message for the WAL archiving of a Postgres instance for PITR.
+// It records the replication slot created on the instance, so the slot is always dropped after the archiving stops.
+type PgWALArchive struct {
+	ID int
+
+	// Standard fields
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Related fields
+	InstanceID int
+
+	// Domain specific fields
+	// SlotName is the physical replication slot on the instance retaining the WAL until it's archived.
+	SlotName string
+	// FailureCount is the number of the consecutive failed archiving rounds.
+	FailureCount int
+	// LastError is the error of the last failed archiving round, and empty if the last round succeeded.
+	LastError string
+}
+
+// PgWALArchiveUpsert is the API message for upserting the WAL archiving of a Postgres instance.
+type PgWALArchiveUpsert struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	UpdaterID int
+
+	// Related fields
+	InstanceID int
+
+	// Domain specific fields
+	SlotName     string
+	FailureCount int
+	LastError    string
+}
+
+// PgWALArchiveFind is the API message for finding the WAL archiving of Postgres instances.
+type PgWALArchiveFind struct {
+	// Related fields
+	InstanceID *int
+}
+
+func (find *PgWALArchiveFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// PgWALArchiveDelete is the API message for deleting the WAL archiving of a Postgres instance.
+type PgWALArchiveDelete struct {
+	// Related fields
+	InstanceID int
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// PgWALFile is the API message for an archived WAL file of a Postgres instance uploaded to the backup storage backend.
+type PgWALFile struct {
+	ID int
+
+	// Standard fields
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Related fields
+	InstanceID int
+
+	// Domain specific fields
+	// Name is the WAL segment or the timeline history file name.
+	Name           string
+	StorageBackend BackupStorageBackend
+	// Path is the path relative to the data directory for the LOCAL storage backend, and the object key for the S3
+	// storage backend.
+	Path    string
+	Payload BackupFileInfo
+}
+
+// PgWALFileUpsert is the API message for upserting an archived WAL file of a Postgres instance.
+type PgWALFileUpsert struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	UpdaterID int
+
+	// Related fields
+	InstanceID int
+
+	// Domain specific fields
+	Name           string
+	StorageBackend BackupStorageBackend
+	Path           string
+	Payload        BackupFileInfo
+}
+
+// PgWALFileFind is the API message for finding the archived WAL files of Postgres instances.
+type PgWALFileFind struct {
+	ID *int
+
+	// Related fields
+	InstanceID *int
+}
+
+func (find *PgWALFileFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// PgWALFileDelete is the API message for deleting an archived WAL file of a Postgres instance.
+type PgWALFileDelete struct {
+	ID int
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/youzi-1122/bytebase/plugin/advisor"
 )
@@ -16,6 +17,9 @@ type PipelineApprovalValue string
 // BackupPlanPolicySchedule is value for backup plan policy.
 type BackupPlanPolicySchedule string
 
+// PolicyResourceType is the type of the resource a policy is attached to.
+type PolicyResourceType string
+
 const (
 	// DefaultPolicyID is the ID of the default policy.
 	DefaultPolicyID int = 0
@@ -26,6 +30,8 @@ const (
 	PolicyTypeBackupPlan PolicyType = "bb.policy.backup-plan"
 	// PolicyTypeSchemaReview is the schema review policy type.
 	PolicyTypeSchemaReview PolicyType = "bb.policy.schema-review"
+	// PolicyTypeBackupStorage is the backup storage policy type.
+	PolicyTypeBackupStorage PolicyType = "bb.policy.backup-storage"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
@@ -38,6 +44,15 @@ const (
 	BackupPlanPolicyScheduleDaily BackupPlanPolicySchedule = "DAILY"
 	// BackupPlanPolicyScheduleWeekly is WEEKLY backup plan policy value.
 	BackupPlanPolicyScheduleWeekly BackupPlanPolicySchedule = "WEEKLY"
+	// BackupPlanPolicyScheduleCustom is CUSTOM backup plan policy value, whose schedule is the cron expression.
+	BackupPlanPolicyScheduleCustom BackupPlanPolicySchedule = "CUSTOM"
+
+	// PolicyResourceTypeEnvironment is the policy attached to an environment.
+	PolicyResourceTypeEnvironment PolicyResourceType = "ENVIRONMENT"
+	// PolicyResourceTypeProject is the policy attached to a project.
+	PolicyResourceTypeProject PolicyResourceType = "PROJECT"
+	// PolicyResourceTypeDatabase is the policy attached to a database.
+	PolicyResourceTypeDatabase PolicyResourceType = "DATABASE"
 )
 
 var (
@@ -46,6 +61,7 @@ var (
 		PolicyTypePipelineApproval: true,
 		PolicyTypeBackupPlan:       true,
 		PolicyTypeSchemaReview:     true,
+		PolicyTypeBackupStorage:    true,
 	}
 )
 
@@ -63,8 +79,14 @@ type Policy struct {
 	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
 
 	// Related fields
+	// A policy is attached to exactly one of the environment, the project and the database.
+	ResourceType  PolicyResourceType `jsonapi:"attr,resourceType"`
 	EnvironmentID int
 	Environment   *Environment `jsonapi:"relation,environment"`
+	ProjectID     int
+	Project       *Project `jsonapi:"relation,project"`
+	DatabaseID    int
+	Database      *Database `jsonapi:"relation,database"`
 
 	// Domain specific fields
 	Type    PolicyType `jsonapi:"attr,type"`
@@ -76,7 +98,11 @@ type PolicyFind struct {
 	ID *int
 
 	// Related fields
+	// ResourceType filters the policies attached to the type of resource.
+	ResourceType  *PolicyResourceType
 	EnvironmentID *int
+	ProjectID     *int
+	DatabaseID    *int
 
 	// Domain specific fields
 	Type *PolicyType `jsonapi:"attr,type"`
@@ -92,7 +118,11 @@ type PolicyUpsert struct {
 	RowStatus *string `jsonapi:"attr,rowStatus"`
 
 	// Related fields
+	// Only one of EnvironmentID, ProjectID and DatabaseID is set, depending on the ResourceType.
+	ResourceType  PolicyResourceType
 	EnvironmentID int
+	ProjectID     int
+	DatabaseID    int
 
 	// Domain specific fields
 	Type    PolicyType
@@ -106,7 +136,11 @@ type PolicyDelete struct {
 	DeleterID int
 
 	// Related fields
+	// Only one of EnvironmentID, ProjectID and DatabaseID is set, depending on the ResourceType.
+	ResourceType  PolicyResourceType
 	EnvironmentID int
+	ProjectID     int
+	DatabaseID    int
 
 	// Domain specific fields
 	// Type is the policy type.
@@ -139,6 +173,22 @@ func UnmarshalPipelineApprovalPolicy(payload string) (*PipelineApprovalPolicy, e
 // BackupPlanPolicy is the policy configuration for backup plan.
 type BackupPlanPolicy struct {
 	Schedule BackupPlanPolicySchedule `json:"schedule"`
+	// CronExpression is the cron expression in UTC for the CUSTOM schedule.
+	CronExpression string `json:"cronExpression,omitempty"`
+}
+
+// GetCronExpression returns the cron expression of the backup plan policy schedule, or empty if the schedule is unset.
+// The backups of a database should be taken at least as frequently as the schedule.
+func (bp *BackupPlanPolicy) GetCronExpression() string {
+	switch bp.Schedule {
+	case BackupPlanPolicyScheduleDaily:
+		return "@daily"
+	case BackupPlanPolicyScheduleWeekly:
+		return "@weekly"
+	case BackupPlanPolicyScheduleCustom:
+		return bp.CronExpression
+	}
+	return ""
 }
 
 func (bp BackupPlanPolicy) String() (string, error) {
@@ -158,6 +208,51 @@ func UnmarshalBackupPlanPolicy(payload string) (*BackupPlanPolicy, error) {
 	return &bp, nil
 }
 
+// BackupStoragePolicy is the policy configuration for the storage backend of the backups in an environment.
+type BackupStoragePolicy struct {
+	StorageBackend BackupStorageBackend `json:"storageBackend"`
+	// S3 is required for the S3 storage backend.
+	S3 *BackupStorageS3Config `json:"s3,omitempty"`
+	// Compression is the compression algorithm of the backup files, and the backup files are not compressed if empty.
+	Compression BackupCompression `json:"compression,omitempty"`
+	// Encrypted is whether to encrypt the backup files with the workspace backup encryption key.
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+// BackupStorageS3Config is the configuration of the S3-compatible object storage, such as AWS S3 and MinIO.
+type BackupStorageS3Config struct {
+	Region string `json:"region"`
+	// Endpoint is the custom endpoint URL for the S3-compatible services, and the AWS S3 endpoint is used if empty.
+	Endpoint string `json:"endpoint"`
+	Bucket   string `json:"bucket"`
+	// Prefix is prepended to the object keys of the backups.
+	Prefix string `json:"prefix"`
+	// AccessKeyID and SecretAccessKey are optional, and the default AWS credential chain of the Bytebase host is used if empty.
+	// SecretAccessKey is only accepted when setting the policy, and is stored in its own setting instead of the policy,
+	// so it's never returned by the policy API. The stored secret access key is kept if it's empty when setting the
+	// policy with the access key ID.
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey,omitempty"`
+}
+
+func (bs BackupStoragePolicy) String() (string, error) {
+	s, err := json.Marshal(bs)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// UnmarshalBackupStoragePolicy will unmarshal payload to backup storage policy.
+func UnmarshalBackupStoragePolicy(payload string) (*BackupStoragePolicy, error) {
+	var bs BackupStoragePolicy
+	if err := json.Unmarshal([]byte(payload), &bs); err != nil {
+		// The payload is left out, since the secret access key may be in it.
+		return nil, fmt.Errorf("failed to unmarshal backup storage policy: %w", err)
+	}
+	return &bs, nil
+}
+
 // UnmarshalSchemaReviewPolicy will unmarshal payload to schema review policy.
 func UnmarshalSchemaReviewPolicy(payload string) (*advisor.SchemaReviewPolicy, error) {
 	var sr advisor.SchemaReviewPolicy
@@ -190,7 +285,16 @@ func ValidatePolicy(pType PolicyType, payload string) error {
 		if err != nil {
 			return err
 		}
-		if bp.Schedule != BackupPlanPolicyScheduleUnset && bp.Schedule != BackupPlanPolicyScheduleDaily && bp.Schedule != BackupPlanPolicyScheduleWeekly {
+		switch bp.Schedule {
+		case BackupPlanPolicyScheduleUnset, BackupPlanPolicyScheduleDaily, BackupPlanPolicyScheduleWeekly:
+			if bp.CronExpression != "" {
+				return fmt.Errorf("cron expression is only allowed for the %s backup plan policy schedule", BackupPlanPolicyScheduleCustom)
+			}
+		case BackupPlanPolicyScheduleCustom:
+			if _, err := ParseBackupSchedule(bp.CronExpression); err != nil {
+				return fmt.Errorf("invalid backup plan policy cron expression: %w", err)
+			}
+		default:
 			return fmt.Errorf("invalid backup plan policy schedule: %q", bp.Schedule)
 		}
 	case PolicyTypeSchemaReview:
@@ -201,10 +305,47 @@ func ValidatePolicy(pType PolicyType, payload string) error {
 		if err := sr.Validate(); err != nil {
 			return fmt.Errorf("invalid schema review policy: %w", err)
 		}
+	case PolicyTypeBackupStorage:
+		bs, err := UnmarshalBackupStoragePolicy(payload)
+		if err != nil {
+			return err
+		}
+		switch bs.StorageBackend {
+		case BackupStorageBackendLocal:
+		case BackupStorageBackendS3:
+			if bs.S3 == nil || bs.S3.Bucket == "" {
+				return fmt.Errorf("bucket is required for the %s backup storage backend", bs.StorageBackend)
+			}
+			if bs.S3.SecretAccessKey != "" {
+				return fmt.Errorf("secret access key must not be stored in the backup storage policy")
+			}
+		default:
+			return fmt.Errorf("invalid backup storage backend: %q", bs.StorageBackend)
+		}
+		switch bs.Compression {
+		case BackupCompressionNone, BackupCompressionGzip, BackupCompressionZstd:
+		default:
+			return fmt.Errorf("invalid backup compression: %q", bs.Compression)
+		}
 	}
 	return nil
 }
 
+// ValidatePolicyResource will validate the policy type can be attached to the resource type.
+// The project and database policies override the environment policy, and only the schema review policy supports them for now.
+func ValidatePolicyResource(resourceType PolicyResourceType, pType PolicyType) error {
+	switch resourceType {
+	case PolicyResourceTypeEnvironment:
+		return nil
+	case PolicyResourceTypeProject, PolicyResourceTypeDatabase:
+		if pType != PolicyTypeSchemaReview {
+			return fmt.Errorf("policy type %s cannot be attached to %s", pType, strings.ToLower(string(resourceType)))
+		}
+		return nil
+	}
+	return fmt.Errorf("invalid policy resource type: %s", resourceType)
+}
+
 // GetDefaultPolicy will return the default value for the given policy type.
 // The default policy can be empty when we don't have anything to enforce at runtime.
 func GetDefaultPolicy(pType PolicyType) (string, error) {
@@ -217,6 +358,10 @@ func GetDefaultPolicy(pType PolicyType) (string, error) {
 		return BackupPlanPolicy{
 			Schedule: BackupPlanPolicyScheduleUnset,
 		}.String()
+	case PolicyTypeBackupStorage:
+		return BackupStoragePolicy{
+			StorageBackend: BackupStorageBackendLocal,
+		}.String()
 	case PolicyTypeSchemaReview:
 		// TODO(ed): we may need to define the default schema review policy payload in the PR of policy data migration.
 		return "{}", nil
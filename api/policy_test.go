@@ -0,0 +1,62 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePolicyResource(t *testing.T) {
+	tests := []struct {
+		resourceType PolicyResourceType
+		pType        PolicyType
+		wantErr      bool
+	}{
+		{PolicyResourceTypeEnvironment, PolicyTypePipelineApproval, false},
+		{PolicyResourceTypeEnvironment, PolicyTypeBackupPlan, false},
+		{PolicyResourceTypeEnvironment, PolicyTypeSchemaReview, false},
+		{PolicyResourceTypeEnvironment, PolicyTypeBackupStorage, false},
+		{PolicyResourceTypeProject, PolicyTypeSchemaReview, false},
+		{PolicyResourceTypeDatabase, PolicyTypeSchemaReview, false},
+		{PolicyResourceTypeProject, PolicyTypePipelineApproval, true},
+		{PolicyResourceTypeDatabase, PolicyTypeBackupPlan, true},
+		{PolicyResourceTypeDatabase, PolicyTypeBackupStorage, true},
+		{PolicyResourceType("INSTANCE"), PolicyTypeSchemaReview, true},
+	}
+
+	for _, test := range tests {
+		err := ValidatePolicyResource(test.resourceType, test.pType)
+		if test.wantErr {
+			require.Error(t, err, "%s %s", test.resourceType, test.pType)
+		} else {
+			require.NoError(t, err, "%s %s", test.resourceType, test.pType)
+		}
+	}
+}
+
+func TestValidateBackupPlanPolicy(t *testing.T) {
+	tests := []struct {
+		payload string
+		wantErr bool
+	}{
+		{`{"schedule":"DAILY"}`, false},
+		{`{"schedule":"CUSTOM","cronExpression":"0 */6 * * *"}`, false},
+		{`{"schedule":"CUSTOM","cronExpression":"@monthly"}`, false},
+		{`{"schedule":"CUSTOM","cronExpression":"@every 5m"}`, true},
+		{`{"schedule":"CUSTOM","cronExpression":"0 0 30 2 *"}`, true},
+		{`{"schedule":"CUSTOM"}`, true},
+		{`{"schedule":"CUSTOM","cronExpression":"*/5 * * * *"}`, true},
+		{`{"schedule":"CUSTOM","cronExpression":"0 25 * * *"}`, true},
+		{`{"schedule":"WEEKLY","cronExpression":"0 0 * * *"}`, true},
+		{`{"schedule":"MONTHLY"}`, true},
+	}
+
+	for _, test := range tests {
+		err := ValidatePolicy(PolicyTypeBackupPlan, test.payload)
+		if test.wantErr {
+			require.Error(t, err, test.payload)
+		} else {
+			require.NoError(t, err, test.payload)
+		}
+	}
+}
@@ -42,6 +42,17 @@ const (
 	TenantModeTenant ProjectTenantMode = "TENANT"
 )
 
+// ProjectSchemaChangeType is the schema change type for projects.
+type ProjectSchemaChangeType string
+
+const (
+	// ProjectSchemaChangeTypeDDL is the imperative schema change type, which applies the committed migration files.
+	ProjectSchemaChangeTypeDDL ProjectSchemaChangeType = "DDL"
+	// ProjectSchemaChangeTypeSDL is the declarative schema change type, which applies the statements diffed from
+	// the committed schema files against the latest database schema.
+	ProjectSchemaChangeTypeSDL ProjectSchemaChangeType = "SDL"
+)
+
 // Project is the API message for a project.
 type Project struct {
 	ID int `jsonapi:"primary,project"`
@@ -68,6 +79,8 @@ type Project struct {
 	// Empty value means {{DB_NAME}}.
 	DBNameTemplate string              `jsonapi:"attr,dbNameTemplate"`
 	RoleProvider   ProjectRoleProvider `jsonapi:"attr,roleProvider"`
+	// SchemaChangeType is only used when a project is in VCS workflow.
+	SchemaChangeType ProjectSchemaChangeType `jsonapi:"attr,schemaChangeType"`
 }
 
 // ProjectCreate is the API message for creating a project.
@@ -114,10 +127,11 @@ type ProjectPatch struct {
 	UpdaterID int
 
 	// Domain specific fields
-	Name         *string              `jsonapi:"attr,name"`
-	Key          *string              `jsonapi:"attr,key"`
-	WorkflowType *ProjectWorkflowType `jsonapi:"attr,workflowType"`
-	RoleProvider *string              `jsonapi:"attr,roleProvider"`
+	Name             *string                  `jsonapi:"attr,name"`
+	Key              *string                  `jsonapi:"attr,key"`
+	WorkflowType     *ProjectWorkflowType     `jsonapi:"attr,workflowType"`
+	RoleProvider     *string                  `jsonapi:"attr,roleProvider"`
+	SchemaChangeType *ProjectSchemaChangeType `jsonapi:"attr,schemaChangeType"`
 }
 
 var (
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 )
 
 // SettingName is the name of a setting.
@@ -16,8 +17,19 @@ const (
 	SettingWorkspaceID SettingName = "bb.workspace.id"
 	// SettingEnterpriseLicense is the setting name for enterprise license.
 	SettingEnterpriseLicense SettingName = "bb.enterprise.license"
+	// SettingBackupEncryptionKey is the setting name for the hex encoded AES key to encrypt the backups.
+	SettingBackupEncryptionKey SettingName = "bb.backup.encryption-key"
+	// SettingWebhookPullRequestEventsSubscribed is the setting name marking the webhooks of the repositories linked
+	// before the pull request review are subscribed to the pull request events.
+	SettingWebhookPullRequestEventsSubscribed SettingName = "bb.vcs.webhook.pull-request-events-subscribed"
 )
 
+// BackupS3SecretAccessKeySettingName returns the setting name for the secret access key of the S3 backup storage
+// backend of the environment.
+func BackupS3SecretAccessKeySettingName(environmentID int) SettingName {
+	return SettingName(fmt.Sprintf("bb.backup.s3.secret-access-key.%d", environmentID))
+}
+
 // Setting is the API message for a setting.
 type Setting struct {
 	ID int `jsonapi:"primary,setting"`
@@ -26,6 +26,10 @@ const (
 	SheetFromGitLabSelfHost SheetSource = "GITLAB_SELF_HOST"
 	// SheetFromGitHubCom is the sheet synced from github.com.
 	SheetFromGitHubCom SheetSource = "GITHUB_COM"
+	// SheetFromGiteaSelfHost is the sheet synced from self host Gitea.
+	SheetFromGiteaSelfHost SheetSource = "GITEA_SELF_HOST"
+	// SheetFromBitbucketOrg is the sheet synced from bitbucket.org.
+	SheetFromBitbucketOrg SheetSource = "BITBUCKET_ORG"
 )
 
 // SheetType is the type of sheet.
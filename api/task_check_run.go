@@ -47,6 +47,8 @@ const (
 	TaskCheckDatabaseStatementSyntax TaskCheckType = "bb.task-check.database.statement.syntax"
 	// TaskCheckDatabaseStatementCompatibility is the task check type for statement compatibility.
 	TaskCheckDatabaseStatementCompatibility TaskCheckType = "bb.task-check.database.statement.compatibility"
+	// TaskCheckDatabaseStatementAffectedRows is the task check type for the estimated affected rows of the data change statement.
+	TaskCheckDatabaseStatementAffectedRows TaskCheckType = "bb.task-check.database.statement.affected-rows"
 	// TaskCheckDatabaseStatementAdvise is the task check type for schema system review policy.
 	TaskCheckDatabaseStatementAdvise TaskCheckType = "bb.task-check.database.statement.advise"
 	// TaskCheckDatabaseConnect is the task check type for database connection.
@@ -92,6 +94,12 @@ type TaskCheckResult struct {
 	Status    TaskCheckStatus `json:"status,omitempty"`
 	Title     string          `json:"title,omitempty"`
 	Content   string          `json:"content,omitempty"`
+	// StatementIndex, Line and Column locate the statement triggering the result in the task statement, they are 1-based.
+	StatementIndex int `json:"statementIndex,omitempty"`
+	Line           int `json:"line,omitempty"`
+	Column         int `json:"column,omitempty"`
+	// Suppressed is true if the result is disabled by a "-- bytebase:disable <rule>" comment, and it's kept for audit.
+	Suppressed bool `json:"suppressed,omitempty"`
 }
 
 // TaskCheckRunResultPayload is the result payload of a task check run.
@@ -189,6 +197,10 @@ func ConvertToAdvisorDBType(dbType db.Type) (advisor.DBType, error) {
 		return advisor.Postgres, nil
 	case db.TiDB:
 		return advisor.TiDB, nil
+	case db.MariaDB:
+		return advisor.MariaDB, nil
+	case db.OceanBase:
+		return advisor.OceanBase, nil
 	}
 
 	return "", fmt.Errorf("unsupported db type %s for advisor", dbType)
@@ -196,7 +208,7 @@ func ConvertToAdvisorDBType(dbType db.Type) (advisor.DBType, error) {
 
 // IsSyntaxCheckSupported checks the engine type if syntax check supports it.
 func IsSyntaxCheckSupported(dbType db.Type, mode common.ReleaseMode) bool {
-	if mode == common.ReleaseModeDev || dbType == db.MySQL || dbType == db.TiDB {
+	if mode == common.ReleaseModeDev || dbType == db.MySQL || dbType == db.TiDB || dbType == db.MariaDB || dbType == db.OceanBase {
 		advisorDB, err := ConvertToAdvisorDBType(dbType)
 		if err != nil {
 			return false
@@ -208,9 +220,23 @@ func IsSyntaxCheckSupported(dbType db.Type, mode common.ReleaseMode) bool {
 	return false
 }
 
+// IsCompatibilityCheckSupported checks the engine type if statement compatibility check supports it.
+func IsCompatibilityCheckSupported(dbType db.Type, mode common.ReleaseMode) bool {
+	if mode == common.ReleaseModeDev || dbType == db.MySQL || dbType == db.TiDB || dbType == db.MariaDB || dbType == db.OceanBase || dbType == db.Postgres {
+		advisorDB, err := ConvertToAdvisorDBType(dbType)
+		if err != nil {
+			return false
+		}
+
+		return advisor.IsCompatibilityCheckSupported(advisorDB)
+	}
+
+	return false
+}
+
 // IsSchemaReviewSupported checks the engine type if schema review supports it.
 func IsSchemaReviewSupported(dbType db.Type, mode common.ReleaseMode) bool {
-	if mode == common.ReleaseModeDev || dbType == db.MySQL || dbType == db.TiDB {
+	if mode == common.ReleaseModeDev || dbType == db.MySQL || dbType == db.TiDB || dbType == db.MariaDB || dbType == db.OceanBase || dbType == db.Postgres {
 		advisorDB, err := ConvertToAdvisorDBType(dbType)
 		if err != nil {
 			return false
@@ -221,3 +247,13 @@ func IsSchemaReviewSupported(dbType db.Type, mode common.ReleaseMode) bool {
 
 	return false
 }
+
+// IsAffectedRowsCheckSupported checks the engine type if the affected rows check supports it.
+// The check relies on the EXPLAIN output of the engine.
+func IsAffectedRowsCheckSupported(dbType db.Type) bool {
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase, db.Postgres:
+		return true
+	}
+	return false
+}
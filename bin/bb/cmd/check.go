@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	// Register pingcap parser driver.
+	_ "github.com/pingcap/tidb/types/parser_driver"
+	// Register mysql advisor.
+	_ "github.com/youzi-1122/bytebase/plugin/advisor/mysql"
+	// Register postgresql advisor.
+	_ "github.com/youzi-1122/bytebase/plugin/advisor/pg"
+	// Register postgres parser driver
+	_ "github.com/youzi-1122/bytebase/plugin/parser/engine/pg"
+)
+
+const (
+	checkFormatText  = "text"
+	checkFormatJSON  = "json"
+	checkFormatSARIF = "sarif"
+)
+
+// errCheckFailed is returned if any file violates an ERROR level rule, so that bb exits with non-zero code.
+var errCheckFailed = errors.New("schema review check failed")
+
+func newCheckCmd() *cobra.Command {
+	var (
+		engine     string
+		policyFile string
+		format     string
+		charset    string
+		collation  string
+	)
+	checkCmd := &cobra.Command{
+		Use:   "check [flags] file...",
+		Short: "Check the SQL files against the schema review policy.",
+		Args:  cobra.MinimumNArgs(1),
+		// The advices are the output, so we don't print the usage or the error again if the check fails.
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dbType, err := getAdvisorDBType(engine)
+			if err != nil {
+				return err
+			}
+			if format != checkFormatText && format != checkFormatJSON && format != checkFormatSARIF {
+				return fmt.Errorf("invalid output format %q; supported formats: text, json, sarif", format)
+			}
+			policy, err := loadSchemaReviewPolicy(policyFile)
+			if err != nil {
+				return err
+			}
+
+			var resultList []*checkResult
+			for _, file := range args {
+				statement, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("failed to read file %s, got error: %w", file, err)
+				}
+				adviceList, err := advisor.SchemaReviewCheck(context.Background(), string(statement), policy, advisor.SchemaReviewCheckContext{
+					Charset:   charset,
+					Collation: collation,
+					DbType:    dbType,
+					Catalog:   &offlineCatalog{},
+				})
+				if err != nil {
+					return fmt.Errorf("failed to check file %s, got error: %w", file, err)
+				}
+				resultList = append(resultList, &checkResult{file: file, adviceList: adviceList})
+			}
+
+			out := cmd.OutOrStdout()
+			switch format {
+			case checkFormatJSON:
+				err = writeCheckJSON(out, resultList)
+			case checkFormatSARIF:
+				err = writeCheckSARIF(out, resultList)
+			default:
+				err = writeCheckText(out, resultList)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to write the check result, got error: %w", err)
+			}
+
+			for _, result := range resultList {
+				for _, advice := range result.adviceList {
+					if advice.Status == advisor.Error {
+						return errCheckFailed
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	checkCmd.Flags().StringVar(&engine, "engine", "mysql", "Database engine of the SQL files; supported engines: mysql, tidb, mariadb, oceanbase, postgres.")
+	checkCmd.Flags().StringVar(&policyFile, "policy", "", "YAML or JSON file of the schema review policy.")
+	checkCmd.Flags().StringVar(&format, "format", checkFormatText, "Output format; supported formats: text, json, sarif.")
+	checkCmd.Flags().StringVar(&charset, "charset", "utf8mb4", "Default charset of the database.")
+	checkCmd.Flags().StringVar(&collation, "collation", "utf8mb4_general_ci", "Default collation of the database.")
+	if err := checkCmd.MarkFlagRequired("policy"); err != nil {
+		panic(err)
+	}
+	return checkCmd
+}
+
+// checkResult is the advice list for a SQL file.
+type checkResult struct {
+	file       string
+	adviceList []advisor.Advice
+}
+
+func getAdvisorDBType(engine string) (advisor.DBType, error) {
+	switch strings.ToLower(engine) {
+	case "mysql":
+		return advisor.MySQL, nil
+	case "tidb":
+		return advisor.TiDB, nil
+	case "mariadb":
+		return advisor.MariaDB, nil
+	case "oceanbase":
+		return advisor.OceanBase, nil
+	case "postgres", "postgresql", "pg":
+		return advisor.Postgres, nil
+	}
+	return "", fmt.Errorf("database engine %q not supported; supported engines: mysql, tidb, mariadb, oceanbase, postgres", engine)
+}
+
+// policyFileRule is the rule in the policy file.
+// Different from advisor.SchemaReviewRule, the payload can be written as an object instead of the stringified JSON.
+type policyFileRule struct {
+	Type    advisor.SchemaReviewRuleType  `json:"type"`
+	Level   advisor.SchemaReviewRuleLevel `json:"level"`
+	Payload json.RawMessage               `json:"payload"`
+}
+
+type policyFile struct {
+	Name     string            `json:"name"`
+	RuleList []*policyFileRule `json:"ruleList"`
+}
+
+// loadSchemaReviewPolicy loads the schema review policy from a YAML or JSON file.
+func loadSchemaReviewPolicy(file string) (*advisor.SchemaReviewPolicy, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s, got error: %w", file, err)
+	}
+	policy, err := parseSchemaReviewPolicy(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s, got error: %w", file, err)
+	}
+	return policy, nil
+}
+
+func parseSchemaReviewPolicy(content []byte) (*advisor.SchemaReviewPolicy, error) {
+	// JSON is a subset of YAML, so we decode the file as YAML and convert it to JSON to reuse the JSON tags.
+	var value interface{}
+	if err := yaml.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var file policyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	policy := &advisor.SchemaReviewPolicy{Name: file.Name}
+	for _, rule := range file.RuleList {
+		payload := "{}"
+		if len(rule.Payload) > 0 && string(rule.Payload) != "null" {
+			// The payload exported from the Bytebase console is the stringified JSON.
+			if err := json.Unmarshal(rule.Payload, &payload); err != nil {
+				payload = string(rule.Payload)
+			}
+		}
+		policy.RuleList = append(policy.RuleList, &advisor.SchemaReviewRule{
+			Type:    rule.Type,
+			Level:   rule.Level,
+			Payload: payload,
+		})
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+var (
+	_ catalog.Catalog = (*offlineCatalog)(nil)
+)
+
+// offlineCatalog is the empty catalog as bb checks the SQL files without connecting to the database,
+// and the catalog-based checks will be skipped.
+type offlineCatalog struct{}
+
+// FindTable finds the table in the catalog.
+func (*offlineCatalog) FindTable(context.Context, *catalog.TableFind) (*catalog.Table, error) {
+	return nil, nil
+}
+
+// FindColumn finds the column in the catalog.
+func (*offlineCatalog) FindColumn(context.Context, *catalog.ColumnFind) (*catalog.Column, error) {
+	return nil, nil
+}
+
+// FindConstraint finds the constraint in the catalog.
+func (*offlineCatalog) FindConstraint(context.Context, *catalog.ConstraintFind) (*catalog.Constraint, error) {
+	return nil, nil
+}
+
+// FindIndex finds the index in the catalog.
+func (*offlineCatalog) FindIndex(context.Context, *catalog.IndexFind) (*catalog.Index, error) {
+	return nil, nil
+}
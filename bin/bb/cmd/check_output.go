@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+// isReportedAdvice returns whether the advice is reported in the output.
+// The OK advices are skipped, and the suppressed advices are kept for audit in the JSON and SARIF output.
+func isReportedAdvice(advice advisor.Advice) bool {
+	return advice.Status != advisor.Success || advice.Suppressed
+}
+
+// writeCheckText writes the advices in the "file:line:column: level: content (title)" format.
+func writeCheckText(out io.Writer, resultList []*checkResult) error {
+	errorCount, warningCount := 0, 0
+	for _, result := range resultList {
+		for _, advice := range result.adviceList {
+			var level string
+			switch advice.Status {
+			case advisor.Error:
+				level = "error"
+				errorCount++
+			case advisor.Warn:
+				level = "warning"
+				warningCount++
+			default:
+				continue
+			}
+			if _, err := fmt.Fprintf(out, "%s:%d:%d: %s: %s (%s)\n", result.file, advice.Line, advice.Column, level, advice.Content, advice.Title); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintf(out, "%d error(s), %d warning(s) in %d file(s)\n", errorCount, warningCount, len(resultList))
+	return err
+}
+
+// checkJSONAdvice is the advice in the JSON output.
+type checkJSONAdvice struct {
+	File string `json:"file"`
+	advisor.Advice
+}
+
+func writeCheckJSON(out io.Writer, resultList []*checkResult) error {
+	adviceList := []checkJSONAdvice{}
+	for _, result := range resultList {
+		for _, advice := range result.adviceList {
+			if !isReportedAdvice(advice) {
+				continue
+			}
+			adviceList = append(adviceList, checkJSONAdvice{File: result.file, Advice: advice})
+		}
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(adviceList)
+}
+
+// The SARIF types cover the subset of SARIF 2.1.0 used by the GitHub code scanning and the GitLab CI.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind string `json:"kind"`
+}
+
+func writeCheckSARIF(out io.Writer, resultList []*checkResult) error {
+	driver := sarifDriver{
+		Name:           "bb",
+		InformationURI: "https://bytebase.com",
+		Version:        version,
+		Rules:          []sarifRule{},
+	}
+	ruleSet := make(map[string]bool)
+	sarifResultList := []sarifResult{}
+	for _, result := range resultList {
+		for _, advice := range result.adviceList {
+			if !isReportedAdvice(advice) {
+				continue
+			}
+			if !ruleSet[advice.Title] {
+				ruleSet[advice.Title] = true
+				driver.Rules = append(driver.Rules, sarifRule{
+					ID:               advice.Title,
+					ShortDescription: sarifMessage{Text: advice.Title},
+				})
+			}
+
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(result.file)},
+				},
+			}
+			if advice.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{
+					StartLine:   advice.Line,
+					StartColumn: advice.Column,
+				}
+			}
+			sarifResult := sarifResult{
+				RuleID:    advice.Title,
+				Message:   sarifMessage{Text: advice.Content},
+				Locations: []sarifLocation{location},
+			}
+			switch advice.Status {
+			case advisor.Error:
+				sarifResult.Level = "error"
+			case advisor.Warn:
+				sarifResult.Level = "warning"
+			default:
+				// The suppressed advice is disabled by the "-- bytebase:disable <rule>" comment in the file.
+				sarifResult.Level = "note"
+				sarifResult.Suppressions = []sarifSuppression{{Kind: "inSource"}}
+			}
+			sarifResultList = append(sarifResultList, sarifResult)
+		}
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: driver},
+				Results: sarifResultList,
+			},
+		},
+	})
+}
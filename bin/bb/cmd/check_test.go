@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+
+	// embed expected output
+	_ "embed"
+)
+
+var (
+	//go:embed testdata/expected/check_test_TestCheck_01
+	_TestCheck01 string
+	//go:embed testdata/expected/check_test_TestCheck_02
+	_TestCheck02 string
+	//go:embed testdata/expected/check_test_TestCheck_03
+	_TestCheck03 string
+)
+
+func TestCheck(t *testing.T) {
+	tt := []testTable{
+		{
+			args: []string{
+				"check",
+				"--policy", "testdata/check/policy.yaml",
+				"testdata/check/1_create_table.sql",
+				"testdata/check/2_update.sql",
+			},
+			expectedErr: errCheckFailed,
+			expected:    _TestCheck01,
+		},
+		{
+			args: []string{
+				"check",
+				"--format", "json",
+				"--policy", "testdata/check/policy.json",
+				"testdata/check/1_create_table.sql",
+				"testdata/check/2_update.sql",
+			},
+			expected: _TestCheck02,
+		},
+		{
+			args: []string{
+				"check",
+				"--format", "sarif",
+				"--policy", "testdata/check/policy.yaml",
+				"testdata/check/2_update.sql",
+			},
+			expectedErr: errCheckFailed,
+			expected:    _TestCheck03,
+		},
+	}
+
+	tableTest(t, tt)
+}
+
+func TestParseSchemaReviewPolicy(t *testing.T) {
+	tests := []struct {
+		content string
+		want    *advisor.SchemaReviewPolicy
+		wantErr bool
+	}{
+		{
+			content: `
+name: CI
+ruleList:
+  - type: naming.table
+    level: WARNING
+    payload:
+      format: "^[a-z]+$"
+      maxLength: 64
+  - type: statement.where.require
+    level: ERROR
+`,
+			want: &advisor.SchemaReviewPolicy{
+				Name: "CI",
+				RuleList: []*advisor.SchemaReviewRule{
+					{Type: advisor.SchemaRuleTableNaming, Level: advisor.SchemaRuleLevelWarning, Payload: `{"format":"^[a-z]+$","maxLength":64}`},
+					{Type: advisor.SchemaRuleStatementRequireWhere, Level: advisor.SchemaRuleLevelError, Payload: "{}"},
+				},
+			},
+		},
+		{
+			// The payload exported from the console is the stringified JSON.
+			content: `{"name":"CI","ruleList":[{"type":"naming.table","level":"ERROR","payload":"{\"format\":\"^[a-z]+$\",\"maxLength\":64}"}]}`,
+			want: &advisor.SchemaReviewPolicy{
+				Name: "CI",
+				RuleList: []*advisor.SchemaReviewRule{
+					{Type: advisor.SchemaRuleTableNaming, Level: advisor.SchemaRuleLevelError, Payload: `{"format":"^[a-z]+$","maxLength":64}`},
+				},
+			},
+		},
+		{
+			content: `
+name: CI
+ruleList:
+  - type: naming.table
+    level: WARNING
+    payload:
+      format: "["
+`,
+			wantErr: true,
+		},
+		{
+			content: `name: CI`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		policy, err := parseSchemaReviewPolicy([]byte(test.content))
+		if test.wantErr {
+			require.Error(t, err, test.content)
+			continue
+		}
+		require.NoError(t, err, test.content)
+		require.Equal(t, test.want, policy, test.content)
+	}
+}
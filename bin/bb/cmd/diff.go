@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/differ"
+	"github.com/spf13/cobra"
+	"github.com/xo/dburl"
+)
+
+func newDiffCmd() *cobra.Command {
+	var (
+		sourceDSN string
+		targetDSN string
+	)
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Generates the DDL statements to migrate the target database schema to the source database schema.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			sourceURL, err := dburl.Parse(sourceDSN)
+			if err != nil {
+				return fmt.Errorf("failed to parse source dsn, got error: %w", err)
+			}
+			targetURL, err := dburl.Parse(targetDSN)
+			if err != nil {
+				return fmt.Errorf("failed to parse target dsn, got error: %w", err)
+			}
+			return diffDatabase(context.Background(), sourceURL, targetURL, cmd.OutOrStdout())
+		},
+	}
+
+	diffCmd.Flags().StringVar(&sourceDSN, "source-dsn", "", "Connection string of the source database with the expected schema.\n\n"+dsnUsage)
+	diffCmd.Flags().StringVar(&targetDSN, "target-dsn", "", "Connection string of the target database to migrate.")
+	if err := diffCmd.MarkFlagRequired("source-dsn"); err != nil {
+		panic(err)
+	}
+	if err := diffCmd.MarkFlagRequired("target-dsn"); err != nil {
+		panic(err)
+	}
+	return diffCmd
+}
+
+// diffDatabase writes the DDL statements to migrate the target database schema to the source database schema.
+func diffDatabase(ctx context.Context, sourceURL, targetURL *dburl.URL, out io.Writer) error {
+	sourceType, err := getDBType(sourceURL)
+	if err != nil {
+		return err
+	}
+	targetType, err := getDBType(targetURL)
+	if err != nil {
+		return err
+	}
+	if sourceType != targetType {
+		return fmt.Errorf("cannot diff the %s database against the %s database", sourceType, targetType)
+	}
+
+	sourceSchema, err := syncDatabaseSchema(ctx, sourceURL)
+	if err != nil {
+		return fmt.Errorf("failed to sync source schema, got error: %w", err)
+	}
+	targetSchema, err := syncDatabaseSchema(ctx, targetURL)
+	if err != nil {
+		return fmt.Errorf("failed to sync target schema, got error: %w", err)
+	}
+
+	statementList, err := differ.SchemaDiff(sourceType, targetSchema, sourceSchema)
+	if err != nil {
+		return err
+	}
+	if len(statementList) == 0 {
+		return nil
+	}
+	_, err = fmt.Fprintln(out, strings.Join(statementList, "\n\n"))
+	return err
+}
+
+func syncDatabaseSchema(ctx context.Context, u *dburl.URL) (*db.Schema, error) {
+	database := getDatabase(u)
+	if database == "" {
+		return nil, fmt.Errorf("database name is required in the dsn %q", u.Redacted())
+	}
+	driver, err := open(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	schemaList, err := driver.SyncSchema(ctx, database)
+	if err != nil {
+		return nil, err
+	}
+	if len(schemaList) != 1 {
+		return nil, fmt.Errorf("found %d databases with name %s, expecting one", len(schemaList), database)
+	}
+	return schemaList[0], nil
+}
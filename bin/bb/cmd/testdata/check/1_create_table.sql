@@ -0,0 +1,4 @@
+CREATE TABLE book (
+  id INT PRIMARY KEY,
+  name VARCHAR(255) NOT NULL
+) ENGINE = INNODB;
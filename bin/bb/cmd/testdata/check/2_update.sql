@@ -0,0 +1,7 @@
+CREATE TABLE bookTag (
+  id INT PRIMARY KEY
+) ENGINE = CSV;
+
+-- bytebase:disable statement.where.require
+DELETE FROM book;
+UPDATE book SET name = 'bytebase';
@@ -22,23 +22,30 @@ func getDatabase(u *dburl.URL) string {
 	return u.Path[1:]
 }
 
-func open(ctx context.Context, u *dburl.URL) (db.Driver, error) {
-	var dbType db.Type
-	var pgInstanceDir string
+func getDBType(u *dburl.URL) (db.Type, error) {
 	switch u.Driver {
 	case "mysql":
-		dbType = db.MySQL
+		return db.MySQL, nil
 	// dburl.Parse() do the job of parsing 'pg', 'postgresql' and 'pgsql' to 'postgres'.
 	// https://pkg.go.dev/github.com/xo/dburl@v0.9.1#hdr-Protocol_Schemes_and_Aliases
 	case "postgres":
-		dbType = db.Postgres
+		return db.Postgres, nil
+	}
+	return "", fmt.Errorf("database type %q not supported; supported types: mysql, pg", u.Driver)
+}
+
+func open(ctx context.Context, u *dburl.URL) (db.Driver, error) {
+	dbType, err := getDBType(u)
+	if err != nil {
+		return nil, err
+	}
+	var pgInstanceDir string
+	if dbType == db.Postgres {
 		pgInstance, err := postgres.Install(os.TempDir(), "" /* pgDataDir */, "" /* pgUser */)
 		if err != nil {
 			return nil, err
 		}
 		pgInstanceDir = pgInstance.BaseDir
-	default:
-		return nil, fmt.Errorf("database type %q not supported; supported types: mysql, pg", u.Driver)
 	}
 	passwd, _ := u.User.Password()
 	driver, err := db.Open(
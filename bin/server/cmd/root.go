@@ -18,10 +18,18 @@ import (
 
 	// Register clickhouse driver.
 	_ "github.com/youzi-1122/bytebase/plugin/db/clickhouse"
+	// Register mssql driver.
+	_ "github.com/youzi-1122/bytebase/plugin/db/mssql"
+	// Register mongodb driver.
+	_ "github.com/youzi-1122/bytebase/plugin/db/mongodb"
 	// Register mysql driver.
 	_ "github.com/youzi-1122/bytebase/plugin/db/mysql"
+	// Register oracle driver.
+	_ "github.com/youzi-1122/bytebase/plugin/db/oracle"
 	// Register postgres driver.
 	_ "github.com/youzi-1122/bytebase/plugin/db/pg"
+	// Register redis driver.
+	_ "github.com/youzi-1122/bytebase/plugin/db/redis"
 	// Register snowflake driver.
 	_ "github.com/youzi-1122/bytebase/plugin/db/snowflake"
 	// Register sqlite driver.
@@ -0,0 +1,39 @@
+// Package cron provides the helpers computing the activation times of the cron schedules parsed by
+// github.com/robfig/cron/v3.
+package cron
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Last returns the last activation time of the schedule in (start, end], or the zero time if there is none.
+func Last(schedule cron.Schedule, start, end time.Time) time.Time {
+	var last time.Time
+	for t := schedule.Next(start); !t.IsZero() && !t.After(end); t = schedule.Next(t) {
+		last = t
+	}
+	return last
+}
+
+// MaxInterval returns the max interval between the consecutive activations of the schedule in (start, start+horizon].
+// It returns the horizon if there are fewer than two activations in the period.
+func MaxInterval(schedule cron.Schedule, start time.Time, horizon time.Duration) time.Duration {
+	end := start.Add(horizon)
+	var maxInterval time.Duration
+	prev := schedule.Next(start)
+	if prev.IsZero() || prev.After(end) {
+		return horizon
+	}
+	for t := schedule.Next(prev); !t.IsZero() && !t.After(end); t = schedule.Next(t) {
+		if interval := t.Sub(prev); interval > maxInterval {
+			maxInterval = interval
+		}
+		prev = t
+	}
+	if maxInterval == 0 {
+		return horizon
+	}
+	return maxInterval
+}
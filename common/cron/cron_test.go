@@ -0,0 +1,38 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLast(t *testing.T) {
+	schedule, err := cron.ParseStandard("0 */6 * * *")
+	require.NoError(t, err)
+	start := time.Date(2022, 7, 27, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2022, 7, 27, 12, 0, 0, 0, time.UTC), Last(schedule, start, start.Add(17*time.Hour)))
+	require.True(t, Last(schedule, start, start.Add(5*time.Hour)).IsZero())
+}
+
+func TestMaxInterval(t *testing.T) {
+	start := time.Date(2022, 7, 27, 0, 0, 0, 0, time.UTC)
+	horizon := 366 * 24 * time.Hour
+	tests := []struct {
+		expr string
+		want time.Duration
+	}{
+		{expr: "0 */6 * * *", want: 6 * time.Hour},
+		{expr: "0 1,23 * * *", want: 22 * time.Hour},
+		{expr: "0 0 * * MON", want: 7 * 24 * time.Hour},
+		{expr: "0 0 1 * *", want: 31 * 24 * time.Hour},
+		{expr: "0 0 29 2 *", want: horizon},
+	}
+
+	for _, test := range tests {
+		schedule, err := cron.ParseStandard(test.expr)
+		require.NoError(t, err, test.expr)
+		require.Equal(t, test.want, MaxInterval(schedule, start, horizon), test.expr)
+	}
+}
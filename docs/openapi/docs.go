@@ -57,7 +57,9 @@ const docTemplate = `{
                         "enum": [
                             "MySQL",
                             "PostgreSQL",
-                            "TiDB"
+                            "TiDB",
+                            "MariaDB",
+                            "OceanBase"
                         ],
                         "type": "string",
                         "description": "The database type. Required if the port, host and database name is not specified.",
@@ -12,8 +12,15 @@ export const usePITRLogic = (database: Ref<Database>) => {
   const backupList = useBackupListByDatabaseId(
     computed(() => database.value.id)
   );
+  // The Postgres PITR replays the archived WAL from a base backup, which is
+  // only taken if the PITR policy of the database is enabled.
   const doneBackupList = computed(() =>
-    backupList.value.filter((backup) => backup.status === "DONE")
+    backupList.value.filter(
+      (backup) =>
+        backup.status === "DONE" &&
+        (database.value.instance.engine !== "POSTGRES" ||
+          backup.payload?.pgBaseBackup)
+    )
   );
 
   const pitrAvailable = computed((): { result: boolean; message: string } => {
@@ -30,6 +37,15 @@ export const usePITRLogic = (database: Ref<Database>) => {
         message: t("database.pitr.no-available-backup"),
       };
     }
+    if (engine === "POSTGRES") {
+      if (doneBackupList.value.length > 0) {
+        return { result: true, message: "ok" };
+      }
+      return {
+        result: false,
+        message: t("database.pitr.no-available-base-backup"),
+      };
+    }
     return {
       result: false,
       message: t("database.pitr.minimum-supported-engine-and-version", {
@@ -1,4 +1,5 @@
 import { FieldId } from "../plugins";
+import { BackupType } from "./backup";
 import { ActivityId, ContainerId, PrincipalId, TaskId } from "./id";
 import { IssueStatus } from "./issue";
 import { MemberStatus, RoleType } from "./member";
@@ -30,7 +31,9 @@ export type ProjectActivityType =
   | "bb.project.member.delete"
   | "bb.project.member.role.update";
 
-export type DatabaseActivityType = "bb.database.recovery.pitr.done";
+export type DatabaseActivityType =
+  | "bb.database.recovery.pitr.done"
+  | "bb.database.backup.prune";
 
 export type ActivityType =
   | IssueActivityType
@@ -76,6 +79,8 @@ export function activityName(type: ActivityType): string {
       return t("activity.type.project-member-role-update");
     case "bb.database.recovery.pitr.done":
       return t("activity.type.database-recovery-pitr-done");
+    case "bb.database.backup.prune":
+      return t("activity.type.database-backup-prune");
   }
 }
 
@@ -169,6 +174,14 @@ export type ActivityProjectDatabaseTransferPayload = {
   databaseName: string;
 };
 
+export type ActivityDatabaseBackupPrunePayload = {
+  databaseId: number;
+  backupId: number;
+  backupType: BackupType;
+  databaseName: string;
+  backupName: string;
+};
+
 export type ActionPayloadType =
   | ActivityIssueCreatePayload
   | ActivityIssueCommentCreatePayload
@@ -182,7 +195,8 @@ export type ActionPayloadType =
   | ActivityMemberRoleUpdatePayload
   | ActivityMemberActivateDeactivatePayload
   | ActivityProjectRepositoryPushPayload
-  | ActivityProjectDatabaseTransferPayload;
+  | ActivityProjectDatabaseTransferPayload
+  | ActivityDatabaseBackupPrunePayload;
 
 export type Activity = {
   id: ActivityId;
@@ -1,5 +1,6 @@
 import {
   AnomalyId,
+  BackupId,
   BackupPlanPolicySchedule,
   Database,
   DatabaseId,
@@ -12,8 +13,10 @@ import {
 export type AnomalyType =
   | "bb.anomaly.instance.connection"
   | "bb.anomaly.instance.migration-schema"
+  | "bb.anomaly.instance.wal-archive-lag"
   | "bb.anomaly.database.backup.policy-violation"
   | "bb.anomaly.database.backup.missing"
+  | "bb.anomaly.database.backup.verification-failure"
   | "bb.anomaly.database.connection"
   | "bb.anomaly.database.schema.drift";
 
@@ -21,17 +24,33 @@ export type AnomalyInstanceConnectionPayload = {
   detail: string;
 };
 
+export type AnomalyInstanceWALArchiveLagPayload = {
+  slotName: string;
+  lagBytes: number;
+  detail: string;
+};
+
 export type AnomalyDatabaseBackupPolicyViolationPayload = {
   environmentId: EnvironmentId;
   expectedSchedule: BackupPlanPolicySchedule;
   actualSchedule: BackupPlanPolicySchedule;
+  expectedCronExpression?: string;
+  actualCronExpression?: string;
 };
 
 export type AnomalyDatabaseBackupMissingPayload = {
   expectedSchedule: BackupPlanPolicySchedule;
+  expectedCronExpression?: string;
   lastBackupTs: number;
 };
 
+export type AnomalyDatabaseBackupVerificationFailurePayload = {
+  backupId: BackupId;
+  backupName: string;
+  verifiedTs: number;
+  detail: string;
+};
+
 export type AnomalyDatabaseConnectionPayload = {
   detail: string;
 };
@@ -43,8 +62,10 @@ export type AnomalyDatabaseSchemaDriftPayload = {
 };
 
 export type AnomalyPayload =
+  | AnomalyInstanceWALArchiveLagPayload
   | AnomalyDatabaseBackupPolicyViolationPayload
   | AnomalyDatabaseBackupMissingPayload
+  | AnomalyDatabaseBackupVerificationFailurePayload
   | AnomalyDatabaseConnectionPayload
   | AnomalyDatabaseSchemaDriftPayload;
 
@@ -1,11 +1,13 @@
-import { BackupId, BackupSettingId, DatabaseId } from "./id";
+import { BackupId, BackupSettingId, DatabaseId, InstanceId } from "./id";
 import { Principal } from "./principal";
 
 export type BackupStatus = "PENDING_CREATE" | "DONE" | "FAILED";
 
 export type BackupType = "MANUAL" | "AUTOMATIC" | "PITR";
 
-export type BackupStorageBackend = "LOCAL";
+export type BackupStorageBackend = "LOCAL" | "S3";
+
+export type BackupCompression = "" | "GZIP" | "ZSTD";
 
 // Backup
 export type Backup = {
@@ -27,6 +29,27 @@ export type Backup = {
   migrationHistoryVersion: string;
   path: string;
   comment: string;
+  payload?: BackupPayload;
+};
+
+// BackupPayload is the engine specific info of the backup.
+export type BackupPayload = {
+  // pgBaseBackupId is the ID of the Postgres base backup of the instance
+  // referenced if the PITR policy is enabled, which is shared by the backups
+  // of the databases on the instance.
+  pgBaseBackupId?: number;
+  // pgBaseBackup is the Postgres base backup the backup restores from for PITR.
+  pgBaseBackup?: PgBaseBackup;
+};
+
+// PgBaseBackup is the Postgres base backup, from which the archived WAL is replayed for PITR.
+export type PgBaseBackup = {
+  storageBackend?: BackupStorageBackend;
+  path: string;
+  startLsn: string;
+  startWalFile: string;
+  timeline: number;
+  endTs: number;
 };
 
 export type BackupCreate = {
@@ -36,7 +59,6 @@ export type BackupCreate = {
   // Domain specific fields
   name: string;
   type: BackupType;
-  storageBackend: BackupStorageBackend;
 };
 
 // Backup setting.
@@ -55,7 +77,15 @@ export type BackupSetting = {
   enabled: boolean;
   hour: number;
   dayOfWeek: number;
+  // schedule is the cron expression in UTC overriding the hour and the dayOfWeek if not empty.
+  schedule: string;
   hookUrl: string;
+  // retentionPolicy is the JSON encoded BackupRetentionPolicy.
+  retentionPolicy: string;
+  // verificationPolicy is the JSON encoded BackupVerificationPolicy.
+  verificationPolicy: string;
+  // pitrPolicy is the JSON encoded BackupPITRPolicy.
+  pitrPolicy: string;
 };
 
 export type BackupSettingUpsert = {
@@ -66,5 +96,37 @@ export type BackupSettingUpsert = {
   enabled: boolean;
   hour: number;
   dayOfWeek: number;
+  schedule: string;
   hookUrl: string;
+  // The retention policy is unchanged if not set.
+  retentionPolicy?: string;
+  // The verification policy is unchanged if not set.
+  verificationPolicy?: string;
+  // The PITR policy is unchanged if not set.
+  pitrPolicy?: string;
+};
+
+// BackupRetentionRule is the retention rule for the backups of a type. Zero means no limit.
+export type BackupRetentionRule = {
+  keepCount: number;
+  retentionPeriodTs: number;
+};
+
+export type BackupRetentionPolicy = {
+  automatic: BackupRetentionRule;
+  manual: BackupRetentionRule;
+  pitr: BackupRetentionRule;
+};
+
+// BackupVerificationPolicy verifies the latest successful backup by restoring it into a scratch database.
+// The sandbox instance is the instance of the database if sandboxInstanceId is 0.
+export type BackupVerificationPolicy = {
+  enabled: boolean;
+  sandboxInstanceId: InstanceId;
+};
+
+// BackupPITRPolicy archives the WAL of the Postgres instance continuously, and takes a base backup along with each
+// backup, so that the database can be restored to any point in time after the earliest base backup.
+export type BackupPITRPolicy = {
+  enabled: boolean;
 };
@@ -218,6 +218,7 @@ const makeUnknown = (type: ResourceType) => {
     tenantMode: "DISABLED",
     dbNameTemplate: "",
     roleProvider: "BYTEBASE",
+    schemaChangeType: "DDL",
   };
 
   const UNKNOWN_PROJECT_HOOK: ProjectWebhook = {
@@ -305,7 +306,11 @@ const makeUnknown = (type: ResourceType) => {
     enabled: false,
     hour: 0,
     dayOfWeek: 0,
+    schedule: "",
     hookUrl: "",
+    retentionPolicy: "{}",
+    verificationPolicy: "{}",
+    pitrPolicy: "{}",
   };
 
   const UNKNOWN_PIPELINE: Pipeline = {
@@ -610,6 +615,7 @@ const makeEmpty = (type: ResourceType) => {
     tenantMode: "DISABLED",
     dbNameTemplate: "",
     roleProvider: "BYTEBASE",
+    schemaChangeType: "DDL",
   };
 
   const EMPTY_PROJECT_HOOK: ProjectWebhook = {
@@ -697,7 +703,11 @@ const makeEmpty = (type: ResourceType) => {
     enabled: false,
     hour: 0,
     dayOfWeek: 0,
+    schedule: "",
     hookUrl: "",
+    retentionPolicy: "{}",
+    verificationPolicy: "{}",
+    pitrPolicy: "{}",
   };
 
   const EMPTY_PIPELINE: Pipeline = {
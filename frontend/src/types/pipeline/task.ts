@@ -202,6 +202,7 @@ export type TaskCheckType =
   | "bb.task-check.database.statement.syntax"
   | "bb.task-check.database.statement.compatibility"
   | "bb.task-check.database.statement.advise"
+  | "bb.task-check.database.statement.affected-rows"
   | "bb.task-check.database.connect"
   | "bb.task-check.instance.migration-schema"
   | "bb.task-check.general.earliest-allowed-time"
@@ -230,6 +231,10 @@ export type TaskCheckResult = {
   code: ErrorCode;
   title: string;
   content: string;
+  statementIndex?: number;
+  line?: number;
+  column?: number;
+  suppressed?: boolean;
 };
 
 export type TaskCheckRunResultPayload = {
@@ -1,4 +1,6 @@
 import {
+  BackupCompression,
+  BackupStorageBackend,
   RowStatus,
   Environment,
   PolicyId,
@@ -10,7 +12,12 @@ import {
 export type PolicyType =
   | "bb.policy.pipeline-approval"
   | "bb.policy.backup-plan"
-  | "bb.policy.schema-review";
+  | "bb.policy.schema-review"
+  | "bb.policy.backup-storage";
+
+// PolicyResourceType is the type of the resource the policy is attached to.
+// The project and database schema review policies override the environment one.
+export type PolicyResourceType = "ENVIRONMENT" | "PROJECT" | "DATABASE";
 
 export type PipelineApprovalPolicyValue =
   | "MANUAL_APPROVAL_NEVER"
@@ -23,14 +30,34 @@ export type PipelineApporvalPolicyPayload = {
 export const DefaultApporvalPolicy: PipelineApprovalPolicyValue =
   "MANUAL_APPROVAL_ALWAYS";
 
-export type BackupPlanPolicySchedule = "UNSET" | "DAILY" | "WEEKLY";
+export type BackupPlanPolicySchedule = "UNSET" | "DAILY" | "WEEKLY" | "CUSTOM";
 
+// The cronExpression in UTC is required for the CUSTOM schedule.
 export type BackupPlanPolicyPayload = {
   schedule: BackupPlanPolicySchedule;
+  cronExpression?: string;
 };
 
 export const DefaultSchedulePolicy: BackupPlanPolicySchedule = "UNSET";
 
+// BackupStoragePolicyPayload configures where the backups of the environment are stored.
+// The s3 config is required for the S3 storage backend.
+export type BackupStoragePolicyPayload = {
+  storageBackend: BackupStorageBackend;
+  s3?: {
+    region: string;
+    endpoint: string;
+    bucket: string;
+    prefix: string;
+    accessKeyId: string;
+    // secretAccessKey is write-only, and never returned by the server. Leave
+    // it empty to keep the stored one.
+    secretAccessKey?: string;
+  };
+  compression?: BackupCompression;
+  encrypted?: boolean;
+};
+
 // SchemaReviewPolicyPayload is the payload for schema review policy in the backend.
 export type SchemaReviewPolicyPayload = {
   name: string;
@@ -44,6 +71,7 @@ export type SchemaReviewPolicyPayload = {
 export type PolicyPayload =
   | PipelineApporvalPolicyPayload
   | BackupPlanPolicyPayload
+  | BackupStoragePolicyPayload
   | SchemaReviewPolicyPayload;
 
 export type Policy = {
@@ -57,6 +85,7 @@ export type Policy = {
   rowStatus: RowStatus;
 
   // Related fields
+  resourceType: PolicyResourceType;
   environment: Environment;
 
   // Domain specific fields
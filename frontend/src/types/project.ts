@@ -15,6 +15,8 @@ export type ProjectTenantMode = "DISABLED" | "TENANT";
 
 export type ProjectRoleProvider = "GITLAB_SELF_HOST" | "BYTEBASE";
 
+export type ProjectSchemaChangeType = "DDL" | "SDL";
+
 export type ProjectRoleProviderPayload = {
   vcsRole: string;
   lastSyncTs: number;
@@ -47,6 +49,7 @@ export type Project = {
   tenantMode: ProjectTenantMode;
   dbNameTemplate: string;
   roleProvider: ProjectRoleProvider;
+  schemaChangeType: ProjectSchemaChangeType;
 };
 
 export type ProjectCreate = {
@@ -66,6 +69,7 @@ export type ProjectPatch = {
   name?: string;
   key?: string;
   roleProvider?: ProjectRoleProvider;
+  schemaChangeType?: ProjectSchemaChangeType;
 };
 
 // Project Member
@@ -15,6 +15,8 @@ export type CategoryType =
   | "STATEMENT"
   | "TABLE"
   | "COLUMN"
+  | "INDEX"
+  | "SYSTEM"
   | "SCHEMA";
 
 // The rule level
@@ -38,6 +40,14 @@ interface NumberPayload {
   value?: number;
 }
 
+// BooleanPayload is the boolean type payload configuration options and default value.
+// Used by the frontend.
+interface BooleanPayload {
+  type: "BOOLEAN";
+  default: boolean;
+  value?: boolean;
+}
+
 // StringPayload is the string type payload configuration options and default value.
 // Used by the frontend.
 interface StringPayload {
@@ -67,13 +77,20 @@ interface TemplatePayload {
 // Used by the frontend.
 export interface RuleConfigComponent {
   key: string;
-  payload: StringPayload | NumberPayload | TemplatePayload | StringArrayPayload;
+  payload:
+    | StringPayload
+    | NumberPayload
+    | BooleanPayload
+    | TemplatePayload
+    | StringArrayPayload;
 }
 
 // The identifier for rule template
 export type RuleType =
   | "engine.mysql.use-innodb"
   | "table.require-pk"
+  | "table.no-duplicate-index"
+  | "table.comment"
   | "naming.table"
   | "naming.column"
   | "naming.index.uk"
@@ -81,9 +98,18 @@ export type RuleType =
   | "naming.index.idx"
   | "column.required"
   | "column.no-null"
+  | "column.comment"
+  | "column.type-disallow-list"
+  | "column.maximum-varchar-length"
+  | "column.auto-increment-must-unsigned-bigint"
+  | "index.total-number-limit"
+  | "system.charset.allowlist"
+  | "system.collation.allowlist"
+  | "custom.expression"
   | "statement.select.no-select-all"
   | "statement.where.require"
   | "statement.where.no-leading-wildcard-like"
+  | "statement.affected-row-limit"
   | "schema.backward-compatibility";
 
 // The naming format rule payload.
@@ -99,12 +125,45 @@ interface RequiredColumnPayload {
   columnList: string[];
 }
 
+// The number limit rule payload.
+// Used by the backend.
+interface NumberLimitPayload {
+  number: number;
+}
+
+// The comment convention rule payload.
+// Used by the backend.
+interface CommentFormatPayload {
+  required: boolean;
+  maxLength: number;
+}
+
+// The string array rule payload, e.g. the disallowed column types.
+// Used by the backend.
+interface StringArrayLimitPayload {
+  list: string[];
+}
+
+// The custom expression rule payload. The rule has no template, and is configured by the API.
+// Used by the backend.
+interface CustomExpressionPayload {
+  title: string;
+  expression: string;
+  message?: string;
+}
+
 // The SchemaPolicyRule stores the rule configuration by users.
 // Used by the backend
 export interface SchemaPolicyRule {
   type: RuleType;
   level: RuleLevel;
-  payload?: NamingFormatPayload | RequiredColumnPayload;
+  payload?:
+    | NamingFormatPayload
+    | RequiredColumnPayload
+    | NumberLimitPayload
+    | CommentFormatPayload
+    | StringArrayLimitPayload
+    | CustomExpressionPayload;
 }
 
 // The API for schema review policy in backend.
@@ -285,6 +344,73 @@ export const convertPolicyRuleToRuleTemplate = (
           },
         ],
       };
+    case "statement.affected-row-limit":
+    case "column.maximum-varchar-length":
+    case "index.total-number-limit":
+      const numberLimitComponent = ruleTemplate.componentList[0];
+      const numberLimitPayload = {
+        ...numberLimitComponent.payload,
+        value: (policyRule.payload as NumberLimitPayload).number,
+      } as NumberPayload;
+      return {
+        ...res,
+        componentList: [
+          {
+            ...numberLimitComponent,
+            payload: numberLimitPayload,
+          },
+        ],
+      };
+    case "table.comment":
+    case "column.comment":
+      const requiredComponent = ruleTemplate.componentList.find(
+        (c) => c.payload.type === "BOOLEAN"
+      );
+      const maxLengthComponent = ruleTemplate.componentList.find(
+        (c) => c.payload.type === "NUMBER"
+      );
+      if (!requiredComponent || !maxLengthComponent) {
+        throw new Error(`Invalid rule ${ruleTemplate.type}`);
+      }
+
+      const requiredPayload = {
+        ...requiredComponent.payload,
+        value: (policyRule.payload as CommentFormatPayload).required,
+      } as BooleanPayload;
+      const maxLengthPayload = {
+        ...maxLengthComponent.payload,
+        value: (policyRule.payload as CommentFormatPayload).maxLength,
+      } as NumberPayload;
+      return {
+        ...res,
+        componentList: [
+          {
+            ...requiredComponent,
+            payload: requiredPayload,
+          },
+          {
+            ...maxLengthComponent,
+            payload: maxLengthPayload,
+          },
+        ],
+      };
+    case "column.type-disallow-list":
+    case "system.charset.allowlist":
+    case "system.collation.allowlist":
+      const stringArrayComponent = ruleTemplate.componentList[0];
+      const stringArrayLimitPayload = {
+        ...stringArrayComponent.payload,
+        value: (policyRule.payload as StringArrayLimitPayload).list,
+      } as StringArrayPayload;
+      return {
+        ...res,
+        componentList: [
+          {
+            ...stringArrayComponent,
+            payload: stringArrayLimitPayload,
+          },
+        ],
+      };
   }
 
   throw new Error(`Invalid rule ${ruleTemplate.type}`);
@@ -352,6 +478,46 @@ export const convertRuleTemplateToPolicyRule = (
           columnList: stringArrayPayload.value ?? stringArrayPayload.default,
         },
       };
+    case "statement.affected-row-limit":
+    case "column.maximum-varchar-length":
+    case "index.total-number-limit":
+      const limitPayload = rule.componentList[0].payload as NumberPayload;
+      return {
+        ...base,
+        payload: {
+          number: limitPayload.value ?? limitPayload.default,
+        },
+      };
+    case "table.comment":
+    case "column.comment":
+      const booleanPayload = rule.componentList.find(
+        (c) => c.payload.type === "BOOLEAN"
+      )?.payload as BooleanPayload | undefined;
+      const maxLengthNumberPayload = rule.componentList.find(
+        (c) => c.payload.type === "NUMBER"
+      )?.payload as NumberPayload | undefined;
+      if (!booleanPayload || !maxLengthNumberPayload) {
+        throw new Error(`Invalid rule ${rule.type}`);
+      }
+
+      return {
+        ...base,
+        payload: {
+          required: booleanPayload.value ?? booleanPayload.default,
+          maxLength:
+            maxLengthNumberPayload.value ?? maxLengthNumberPayload.default,
+        },
+      };
+    case "column.type-disallow-list":
+    case "system.charset.allowlist":
+    case "system.collation.allowlist":
+      const listPayload = rule.componentList[0].payload as StringArrayPayload;
+      return {
+        ...base,
+        payload: {
+          list: listPayload.value ?? listPayload.default,
+        },
+      };
   }
 
   throw new Error(`Invalid rule ${rule.type}`);
@@ -28,7 +28,8 @@ func NewPolicyCountCollector(store *store.Store) metric.Collector {
 func (c *policyCountCollector) Collect(ctx context.Context) ([]*metric.Metric, error) {
 	var res []*metric.Metric
 
-	policyList, err := c.store.ListPolicy(ctx, &api.PolicyFind{})
+	resourceType := api.PolicyResourceTypeEnvironment
+	policyList, err := c.store.ListPolicy(ctx, &api.PolicyFind{ResourceType: &resourceType})
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +55,13 @@ func (c *policyCountCollector) Collect(ctx context.Context) ([]*metric.Metric, e
 			}
 			value = string(payload.Schedule)
 			key = fmt.Sprintf("%s_%s_%s", policy.Type, policy.Environment.Name, value)
+		case api.PolicyTypeBackupStorage:
+			payload, err := api.UnmarshalBackupStoragePolicy(policy.Payload)
+			if err != nil {
+				continue
+			}
+			value = string(payload.StorageBackend)
+			key = fmt.Sprintf("%s_%s_%s", policy.Type, policy.Environment.Name, value)
 		case api.PolicyTypeSchemaReview:
 			key = fmt.Sprintf("%s_%s", policy.Type, policy.Environment.Name)
 			// schema review policy don't need to set the value.
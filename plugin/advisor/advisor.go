@@ -87,6 +87,36 @@ const (
 	// MySQLTableRequirePK is an advisor type for MySQL table require primary key.
 	MySQLTableRequirePK Type = "bb.plugin.advisor.mysql.table.require-pk"
 
+	// MySQLNoDuplicateIndex is an advisor type for MySQL no duplicate index.
+	MySQLNoDuplicateIndex Type = "bb.plugin.advisor.mysql.table.no-duplicate-index"
+
+	// MySQLTableCommentConvention is an advisor type for MySQL table comment convention.
+	MySQLTableCommentConvention Type = "bb.plugin.advisor.mysql.table.comment"
+
+	// MySQLColumnCommentConvention is an advisor type for MySQL column comment convention.
+	MySQLColumnCommentConvention Type = "bb.plugin.advisor.mysql.column.comment"
+
+	// MySQLColumnDisallowType is an advisor type for MySQL column type disallow list.
+	MySQLColumnDisallowType Type = "bb.plugin.advisor.mysql.column.type-disallow-list"
+
+	// MySQLColumnMaximumVarcharLength is an advisor type for MySQL column maximum VARCHAR length.
+	MySQLColumnMaximumVarcharLength Type = "bb.plugin.advisor.mysql.column.maximum-varchar-length"
+
+	// MySQLAutoIncrementColumnMustUnsignedBigint is an advisor type for MySQL auto-increment column must be unsigned BIGINT.
+	MySQLAutoIncrementColumnMustUnsignedBigint Type = "bb.plugin.advisor.mysql.column.auto-increment-must-unsigned-bigint"
+
+	// MySQLCharsetAllowlist is an advisor type for MySQL charset allow list.
+	MySQLCharsetAllowlist Type = "bb.plugin.advisor.mysql.charset.allowlist"
+
+	// MySQLCollationAllowlist is an advisor type for MySQL collation allow list.
+	MySQLCollationAllowlist Type = "bb.plugin.advisor.mysql.collation.allowlist"
+
+	// MySQLIndexTotalNumberLimit is an advisor type for MySQL index total number limit.
+	MySQLIndexTotalNumberLimit Type = "bb.plugin.advisor.mysql.index.total-number-limit"
+
+	// MySQLCustomExpression is an advisor type for MySQL user-defined expression.
+	MySQLCustomExpression Type = "bb.plugin.advisor.mysql.custom.expression"
+
 	// PostgreSQL Advisor
 
 	// PostgreSQLSyntax is an advisor type for PostgreSQL syntax.
@@ -100,6 +130,63 @@ const (
 
 	// PostgreSQLTableRequirePK is an advisor type for PostgreSQL table require primary key.
 	PostgreSQLTableRequirePK Type = "bb.plugin.advisor.postgresql.table.require-pk"
+
+	// PostgreSQLNoDuplicateIndex is an advisor type for PostgreSQL no duplicate index.
+	PostgreSQLNoDuplicateIndex Type = "bb.plugin.advisor.postgresql.table.no-duplicate-index"
+
+	// PostgreSQLWhereRequirement is an advisor type for PostgreSQL WHERE clause requirement.
+	PostgreSQLWhereRequirement Type = "bb.plugin.advisor.postgresql.where.require"
+
+	// PostgreSQLNoLeadingWildcardLike is an advisor type for PostgreSQL no leading wildcard LIKE.
+	PostgreSQLNoLeadingWildcardLike Type = "bb.plugin.advisor.postgresql.where.no-leading-wildcard-like"
+
+	// PostgreSQLNoSelectAll is an advisor type for PostgreSQL no select all.
+	PostgreSQLNoSelectAll Type = "bb.plugin.advisor.postgresql.select.no-select-all"
+
+	// PostgreSQLNamingIndexConvention is an advisor type for PostgreSQL index naming convention.
+	PostgreSQLNamingIndexConvention Type = "bb.plugin.advisor.postgresql.naming.index"
+
+	// PostgreSQLNamingUKConvention is an advisor type for PostgreSQL unique key naming convention.
+	PostgreSQLNamingUKConvention Type = "bb.plugin.advisor.postgresql.naming.uk"
+
+	// PostgreSQLNamingFKConvention is an advisor type for PostgreSQL foreign key naming convention.
+	PostgreSQLNamingFKConvention Type = "bb.plugin.advisor.postgresql.naming.fk"
+
+	// PostgreSQLColumnRequirement is an advisor type for PostgreSQL column requirement.
+	PostgreSQLColumnRequirement Type = "bb.plugin.advisor.postgresql.column.require"
+
+	// PostgreSQLColumnNoNull is an advisor type for PostgreSQL column no NULL value.
+	PostgreSQLColumnNoNull Type = "bb.plugin.advisor.postgresql.column.no-null"
+
+	// PostgreSQLMigrationCompatibility is an advisor type for PostgreSQL migration compatibility.
+	PostgreSQLMigrationCompatibility Type = "bb.plugin.advisor.postgresql.migration-compatibility"
+
+	// PostgreSQLTableCommentConvention is an advisor type for PostgreSQL table comment convention.
+	PostgreSQLTableCommentConvention Type = "bb.plugin.advisor.postgresql.table.comment"
+
+	// PostgreSQLColumnCommentConvention is an advisor type for PostgreSQL column comment convention.
+	PostgreSQLColumnCommentConvention Type = "bb.plugin.advisor.postgresql.column.comment"
+
+	// PostgreSQLColumnDisallowType is an advisor type for PostgreSQL column type disallow list.
+	PostgreSQLColumnDisallowType Type = "bb.plugin.advisor.postgresql.column.type-disallow-list"
+
+	// PostgreSQLColumnMaximumVarcharLength is an advisor type for PostgreSQL column maximum VARCHAR length.
+	PostgreSQLColumnMaximumVarcharLength Type = "bb.plugin.advisor.postgresql.column.maximum-varchar-length"
+
+	// PostgreSQLAutoIncrementColumnMustUnsignedBigint is an advisor type for PostgreSQL auto-increment column must be BIGINT.
+	PostgreSQLAutoIncrementColumnMustUnsignedBigint Type = "bb.plugin.advisor.postgresql.column.auto-increment-must-unsigned-bigint"
+
+	// PostgreSQLCharsetAllowlist is an advisor type for PostgreSQL charset allow list.
+	PostgreSQLCharsetAllowlist Type = "bb.plugin.advisor.postgresql.charset.allowlist"
+
+	// PostgreSQLCollationAllowlist is an advisor type for PostgreSQL collation allow list.
+	PostgreSQLCollationAllowlist Type = "bb.plugin.advisor.postgresql.collation.allowlist"
+
+	// PostgreSQLIndexTotalNumberLimit is an advisor type for PostgreSQL index total number limit.
+	PostgreSQLIndexTotalNumberLimit Type = "bb.plugin.advisor.postgresql.index.total-number-limit"
+
+	// PostgreSQLCustomExpression is an advisor type for PostgreSQL user-defined expression.
+	PostgreSQLCustomExpression Type = "bb.plugin.advisor.postgresql.custom.expression"
 )
 
 // Advice is the result of an advisor.
@@ -110,6 +197,14 @@ type Advice struct {
 	Code    Code   `json:"code"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
+	// StatementIndex is the 1-based index of the statement triggering the advice, 0 if the advice is not for a specific statement.
+	StatementIndex int `json:"statementIndex,omitempty"`
+	// Line and Column are the 1-based position where the statement starts, filled by Check according to StatementIndex.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+	// Suppressed is true if the rule is disabled by a "-- bytebase:disable <rule>" comment before the statement.
+	// The suppressed advice has the SUCCESS status, and keeps the original code, title and content for audit.
+	Suppressed bool `json:"suppressed,omitempty"`
 }
 
 // MarshalLogObject constructs a field that carries Advice.
@@ -118,6 +213,14 @@ func (a Advice) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt("code", int(a.Code))
 	enc.AddString("title", a.Title)
 	enc.AddString("content", a.Content)
+	if a.StatementIndex > 0 {
+		enc.AddInt("statementIndex", a.StatementIndex)
+		enc.AddInt("line", a.Line)
+		enc.AddInt("column", a.Column)
+	}
+	if a.Suppressed {
+		enc.AddBool("suppressed", a.Suppressed)
+	}
 	return nil
 }
 
@@ -190,13 +293,26 @@ func Check(dbType DBType, advType Type, ctx Context, statement string) ([]Advice
 		return nil, fmt.Errorf("advisor: unknown advisor %v for %v", advType, dbType)
 	}
 
-	return f.Check(ctx, statement)
+	adviceList, err := f.Check(ctx, statement)
+	if err != nil {
+		return nil, err
+	}
+	return setAdvicePosition(dbType, ctx, statement, adviceList), nil
 }
 
 // IsSyntaxCheckSupported checks the engine type if syntax check supports it.
 func IsSyntaxCheckSupported(dbType DBType) bool {
 	switch dbType {
-	case MySQL, TiDB, Postgres:
+	case MySQL, TiDB, MariaDB, OceanBase, Postgres:
+		return true
+	}
+	return false
+}
+
+// IsCompatibilityCheckSupported checks the engine type if statement compatibility check supports it.
+func IsCompatibilityCheckSupported(dbType DBType) bool {
+	switch dbType {
+	case MySQL, TiDB, MariaDB, OceanBase, Postgres:
 		return true
 	}
 	return false
@@ -205,7 +321,7 @@ func IsSyntaxCheckSupported(dbType DBType) bool {
 // IsSchemaReviewSupported checks the engine type if schema review supports it.
 func IsSchemaReviewSupported(dbType DBType) bool {
 	switch dbType {
-	case MySQL, TiDB, Postgres:
+	case MySQL, TiDB, MariaDB, OceanBase, Postgres:
 		return true
 	}
 	return false
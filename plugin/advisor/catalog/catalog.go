@@ -5,20 +5,83 @@ import (
 )
 
 // Catalog is the service for catalog.
+// The finders return nil without error if the object is not found.
 type Catalog interface {
+	FindTable(ctx context.Context, find *TableFind) (*Table, error)
+	FindColumn(ctx context.Context, find *ColumnFind) (*Column, error)
+	FindConstraint(ctx context.Context, find *ConstraintFind) (*Constraint, error)
 	FindIndex(ctx context.Context, find *IndexFind) (*Index, error)
 }
 
+// Table is the API message for a table.
+type Table struct {
+	Name string
+	// RowCount is the estimated row count from the last schema sync.
+	RowCount       int64
+	ColumnList     []*Column
+	ConstraintList []*Constraint
+	IndexList      []*Index
+}
+
+// TableFind is the API message for find table.
+type TableFind struct {
+	TableName string
+}
+
+// Column is the API message for a column.
+type Column struct {
+	Name      string
+	TableName string
+	Position  int
+	Type      string
+	Nullable  bool
+	// Default is nil if the column has no default value.
+	Default *string
+}
+
+// ColumnFind is the API message for find column.
+type ColumnFind struct {
+	TableName  string
+	ColumnName string
+}
+
+// ConstraintType is the type of a constraint.
+type ConstraintType string
+
+const (
+	// ConstraintTypePrimary is the primary key constraint.
+	ConstraintTypePrimary ConstraintType = "PRIMARY KEY"
+	// ConstraintTypeUnique is the unique constraint.
+	ConstraintTypeUnique ConstraintType = "UNIQUE"
+)
+
+// Constraint is the API message for a constraint.
+// Only the constraints backed by the indexes are supported now.
+type Constraint struct {
+	Name       string
+	TableName  string
+	Type       ConstraintType
+	ColumnList []string
+}
+
+// ConstraintFind is the API message for find constraint.
+type ConstraintFind struct {
+	TableName      string
+	ConstraintName string
+}
+
 // Index is the API message for an index.
 type Index struct {
 	Name              string
 	TableName         string
 	Type              string
 	Unique            bool
+	Primary           bool
 	ColumnExpressions []string
 }
 
-// IndexFind is the API message for find index
+// IndexFind is the API message for find index.
+// TableName is optional for the engines in which the index name is unique in the schema, such as PostgreSQL.
 type IndexFind struct {
 	TableName string
 	IndexName string
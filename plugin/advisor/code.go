@@ -10,23 +10,26 @@ const (
 	NotFound Code = 2
 
 	// 101 ~ 199 compatibility error code
-	CompatibilityDropDatabase  Code = 101
-	CompatibilityRenameTable   Code = 102
-	CompatibilityDropTable     Code = 103
-	CompatibilityRenameColumn  Code = 104
-	CompatibilityDropColumn    Code = 105
-	CompatibilityAddPrimaryKey Code = 106
-	CompatibilityAddUniqueKey  Code = 107
-	CompatibilityAddForeignKey Code = 108
-	CompatibilityAddCheck      Code = 109
-	CompatibilityAlterCheck    Code = 110
-	CompatibilityAlterColumn   Code = 111
+	CompatibilityDropDatabase   Code = 101
+	CompatibilityRenameTable    Code = 102
+	CompatibilityDropTable      Code = 103
+	CompatibilityRenameColumn   Code = 104
+	CompatibilityDropColumn     Code = 105
+	CompatibilityAddPrimaryKey  Code = 106
+	CompatibilityAddUniqueKey   Code = 107
+	CompatibilityAddForeignKey  Code = 108
+	CompatibilityAddCheck       Code = 109
+	CompatibilityAlterCheck     Code = 110
+	CompatibilityAlterColumn    Code = 111
+	CompatibilityAddNotNull     Code = 112
+	CompatibilityDropConstraint Code = 113
 
 	// 201 ~ 299 statement error code
 	StatementSyntaxError         Code = 201
 	StatementNoWhere             Code = 202
 	StatementSelectAll           Code = 203
 	StatementLeadingWildcardLike Code = 204
+	StatementAffectedRowExceeded Code = 205
 
 	// 301 ～ 399 naming error code
 	// 301 table naming advisor error code
@@ -41,14 +44,30 @@ const (
 	NamingFKConventionMismatch Code = 305
 
 	// 401 ~ 499 column error code
-	NoRequiredColumn Code = 401
-	ColumnCanNotNull Code = 402
+	NoRequiredColumn                     Code = 401
+	ColumnCanNotNull                     Code = 402
+	DisabledColumnType                   Code = 403
+	VarcharLengthExceedsLimit            Code = 404
+	AutoIncrementColumnNotUnsignedBigint Code = 405
+	NoColumnComment                      Code = 406
+	ColumnCommentTooLong                 Code = 407
 
 	// 501 engine error code
 	NotInnoDBEngine Code = 501
 
 	// 601 table rule advisor error code
-	TableNoPK Code = 601
+	TableNoPK              Code = 601
+	DuplicateIndex         Code = 602
+	NoTableComment         Code = 603
+	TableCommentTooLong    Code = 604
+	IndexCountExceedsLimit Code = 605
+
+	// 701 ~ 799 system error code
+	DisabledCharset   Code = 701
+	DisabledCollation Code = 702
+
+	// 801 custom rule error code
+	CustomExpressionMatched Code = 801
 )
 
 // Int returns the int type of code.
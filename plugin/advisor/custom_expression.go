@@ -0,0 +1,174 @@
+package advisor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+)
+
+// CustomExpressionStatementType is the type of the change evaluated by the custom expression.
+type CustomExpressionStatementType string
+
+const (
+	// CustomExpressionCreateTable is the CREATE TABLE statement.
+	CustomExpressionCreateTable CustomExpressionStatementType = "CREATE_TABLE"
+	// CustomExpressionDropTable is the DROP TABLE statement, evaluated for each table.
+	CustomExpressionDropTable CustomExpressionStatementType = "DROP_TABLE"
+	// CustomExpressionRenameTable is the ALTER TABLE RENAME statement.
+	CustomExpressionRenameTable CustomExpressionStatementType = "RENAME_TABLE"
+	// CustomExpressionAddColumn is the column added by the CREATE TABLE or ALTER TABLE ADD COLUMN statement.
+	CustomExpressionAddColumn CustomExpressionStatementType = "ADD_COLUMN"
+	// CustomExpressionDropColumn is the ALTER TABLE DROP COLUMN statement.
+	CustomExpressionDropColumn CustomExpressionStatementType = "DROP_COLUMN"
+	// CustomExpressionRenameColumn is the ALTER TABLE RENAME COLUMN statement.
+	CustomExpressionRenameColumn CustomExpressionStatementType = "RENAME_COLUMN"
+	// CustomExpressionAlterColumnType is the ALTER TABLE ALTER COLUMN TYPE statement.
+	CustomExpressionAlterColumnType CustomExpressionStatementType = "ALTER_COLUMN_TYPE"
+	// CustomExpressionAddConstraint is the constraint added by the CREATE TABLE or ALTER TABLE ADD CONSTRAINT statement.
+	CustomExpressionAddConstraint CustomExpressionStatementType = "ADD_CONSTRAINT"
+	// CustomExpressionDropConstraint is the ALTER TABLE DROP CONSTRAINT statement.
+	CustomExpressionDropConstraint CustomExpressionStatementType = "DROP_CONSTRAINT"
+	// CustomExpressionCreateIndex is the CREATE INDEX statement.
+	CustomExpressionCreateIndex CustomExpressionStatementType = "CREATE_INDEX"
+	// CustomExpressionDropIndex is the DROP INDEX statement, evaluated for each index.
+	CustomExpressionDropIndex CustomExpressionStatementType = "DROP_INDEX"
+	// CustomExpressionUpdate is the UPDATE statement.
+	CustomExpressionUpdate CustomExpressionStatementType = "UPDATE"
+	// CustomExpressionDelete is the DELETE statement.
+	CustomExpressionDelete CustomExpressionStatementType = "DELETE"
+)
+
+// CustomExpressionRulePayload is the payload for the custom expression rule.
+type CustomExpressionRulePayload struct {
+	// Title is the title of the advice, e.g. "Disallow JSON column".
+	Title string `json:"title"`
+	// Expression is evaluated for each change in the statement with the CustomExpressionParameters,
+	// and the change matching it is reported. For example,
+	// `statementType == "ADD_COLUMN" && columnType == "json"`.
+	// The expression is in CEL, see https://github.com/google/cel-spec/blob/master/doc/langdef.md for the syntax.
+	Expression string `json:"expression"`
+	// Message is the content of the advice, and the default content describes the change.
+	Message string `json:"message"`
+}
+
+// CustomExpressionParameters is the parameters of a change for the custom expression.
+// The fields not related to the statement type have the zero values.
+type CustomExpressionParameters struct {
+	StatementType CustomExpressionStatementType
+	Schema        string
+	Table         string
+	// NewName is the new name of the renamed table or column.
+	NewName string
+	Column  string
+	// ColumnType is the type name in the database, e.g. "int4" for INTEGER and "varchar" for CHARACTER VARYING in PostgreSQL.
+	ColumnType string
+	// NotNull and HasDefault are the ADD_COLUMN type specific fields.
+	NotNull    bool
+	HasDefault bool
+	// ConstraintType is "PRIMARY KEY", "UNIQUE", "FOREIGN KEY" or "CHECK".
+	ConstraintType string
+	// Name is the name of the constraint or the index.
+	Name   string
+	Unique bool
+	// ColumnCount is the column count of the table, the constraint or the index.
+	ColumnCount int
+	// HasPrimaryKey is a CREATE_TABLE type specific field.
+	HasPrimaryKey bool
+	// HasWhere is an UPDATE/DELETE type specific field.
+	HasWhere bool
+}
+
+// customExpressionEnv declares the parameters in the custom expression, which are the CustomExpressionParameters by
+// the name in the expression.
+var customExpressionEnv, customExpressionEnvErr = cel.NewEnv(
+	cel.Variable("statementType", cel.StringType),
+	cel.Variable("schema", cel.StringType),
+	cel.Variable("table", cel.StringType),
+	cel.Variable("newName", cel.StringType),
+	cel.Variable("column", cel.StringType),
+	cel.Variable("columnType", cel.StringType),
+	cel.Variable("notNull", cel.BoolType),
+	cel.Variable("hasDefault", cel.BoolType),
+	cel.Variable("constraintType", cel.StringType),
+	cel.Variable("name", cel.StringType),
+	cel.Variable("unique", cel.BoolType),
+	cel.Variable("columnCount", cel.IntType),
+	cel.Variable("hasPrimaryKey", cel.BoolType),
+	cel.Variable("hasWhere", cel.BoolType),
+)
+
+// toMap returns the parameters by the name in the expression.
+func (p *CustomExpressionParameters) toMap() map[string]interface{} {
+	return map[string]interface{}{
+		"statementType":  string(p.StatementType),
+		"schema":         p.Schema,
+		"table":          p.Table,
+		"newName":        p.NewName,
+		"column":         p.Column,
+		"columnType":     p.ColumnType,
+		"notNull":        p.NotNull,
+		"hasDefault":     p.HasDefault,
+		"constraintType": p.ConstraintType,
+		"name":           p.Name,
+		"unique":         p.Unique,
+		"columnCount":    int64(p.ColumnCount),
+		"hasPrimaryKey":  p.HasPrimaryKey,
+		"hasWhere":       p.HasWhere,
+	}
+}
+
+// CustomExpression is the compiled custom expression.
+type CustomExpression struct {
+	text    string
+	program cel.Program
+}
+
+// String returns the text of the expression.
+func (e *CustomExpression) String() string {
+	return e.text
+}
+
+// UnmarshalCustomExpressionRulePayload will unmarshal payload to CustomExpressionRulePayload and compile the expression.
+func UnmarshalCustomExpressionRulePayload(payload string) (*CustomExpressionRulePayload, *CustomExpression, error) {
+	var cer CustomExpressionRulePayload
+	if err := json.Unmarshal([]byte(payload), &cer); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal custom expression rule payload %q: %q", payload, err)
+	}
+	if strings.TrimSpace(cer.Title) == "" {
+		return nil, nil, fmt.Errorf("invalid custom expression rule payload, title cannot be empty")
+	}
+	if strings.TrimSpace(cer.Expression) == "" {
+		return nil, nil, fmt.Errorf("invalid custom expression rule payload, expression cannot be empty")
+	}
+	if customExpressionEnvErr != nil {
+		return nil, nil, fmt.Errorf("failed to create custom expression environment: %v", customExpressionEnvErr)
+	}
+	// Compiling checks the syntax, the parameter names and the types.
+	ast, issues := customExpressionEnv.Compile(cer.Expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, nil, fmt.Errorf("failed to compile custom expression %q: %v", cer.Expression, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, nil, fmt.Errorf("custom expression %q should return a boolean but found %v", cer.Expression, ast.OutputType())
+	}
+	program, err := customExpressionEnv.Program(ast)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to compile custom expression %q: %v", cer.Expression, err)
+	}
+	return &cer, &CustomExpression{text: cer.Expression, program: program}, nil
+}
+
+// EvaluateCustomExpression returns whether the change matches the expression.
+func EvaluateCustomExpression(expression *CustomExpression, parameters *CustomExpressionParameters) (bool, error) {
+	result, _, err := expression.program.Eval(parameters.toMap())
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate custom expression %q: %v", expression.String(), err)
+	}
+	matched, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("custom expression %q should return a boolean but found %v", expression.String(), result)
+	}
+	return matched, nil
+}
@@ -0,0 +1,81 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalCustomExpressionRulePayload(t *testing.T) {
+	tests := []struct {
+		payload string
+		wantErr bool
+	}{
+		{
+			payload: `{"title": "Disallow JSON column", "expression": "statementType == 'ADD_COLUMN' && columnType == 'json'"}`,
+			wantErr: false,
+		},
+		{
+			payload: `{"title": "Limit index columns", "expression": "statementType == 'CREATE_INDEX' && columnCount > 3", "message": "Too many index columns"}`,
+			wantErr: false,
+		},
+		{
+			// Title is required.
+			payload: `{"expression": "statementType == 'DROP_TABLE'"}`,
+			wantErr: true,
+		},
+		{
+			// Expression is required.
+			payload: `{"title": "Empty"}`,
+			wantErr: true,
+		},
+		{
+			// Syntax error.
+			payload: `{"title": "Syntax error", "expression": "statementType == "}`,
+			wantErr: true,
+		},
+		{
+			// Unknown parameter.
+			payload: `{"title": "Unknown parameter", "expression": "engine == 'InnoDB'"}`,
+			wantErr: true,
+		},
+		{
+			// The expression must return a boolean.
+			payload: `{"title": "Not boolean", "expression": "table"}`,
+			wantErr: true,
+		},
+		{
+			// Type mismatch.
+			payload: `{"title": "Type mismatch", "expression": "columnCount > '3'"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		_, _, err := UnmarshalCustomExpressionRulePayload(test.payload)
+		if test.wantErr {
+			require.Error(t, err, test.payload)
+		} else {
+			require.NoError(t, err, test.payload)
+		}
+	}
+}
+
+func TestEvaluateCustomExpression(t *testing.T) {
+	_, expression, err := UnmarshalCustomExpressionRulePayload(`{"title": "Limit index columns", "expression": "statementType == 'CREATE_INDEX' && columnCount > 3"}`)
+	require.NoError(t, err)
+
+	matched, err := EvaluateCustomExpression(expression, &CustomExpressionParameters{StatementType: CustomExpressionCreateIndex, ColumnCount: 4})
+	require.NoError(t, err)
+	require.True(t, matched)
+
+	matched, err = EvaluateCustomExpression(expression, &CustomExpressionParameters{StatementType: CustomExpressionCreateIndex, ColumnCount: 3})
+	require.NoError(t, err)
+	require.False(t, matched)
+
+	_, expression, err = UnmarshalCustomExpressionRulePayload(`{"title": "Disallow dropping tables", "expression": "statementType == 'DROP_TABLE' && table.startsWith('prod_')"}`)
+	require.NoError(t, err)
+	matched, err = EvaluateCustomExpression(expression, &CustomExpressionParameters{StatementType: CustomExpressionDropTable, Table: "prod_order"})
+	require.NoError(t, err)
+	require.True(t, matched)
+}
@@ -4,8 +4,12 @@ package advisor
 type DBType string
 
 const (
+	// MariaDB is the database type for MARIADB.
+	MariaDB DBType = "MARIADB"
 	// MySQL is the database type for MYSQL.
 	MySQL DBType = "MYSQL"
+	// OceanBase is the database type for OceanBase in MySQL mode.
+	OceanBase DBType = "OCEANBASE"
 	// Postgres is the database type for POSTGRES.
 	Postgres DBType = "POSTGRES"
 	// TiDB is the database type for TiDB.
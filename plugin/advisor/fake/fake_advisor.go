@@ -12,6 +12,8 @@ func init() {
 	advisor.Register(advisor.MySQL, advisor.Fake, &Advisor{})
 	advisor.Register(advisor.Postgres, advisor.Fake, &Advisor{})
 	advisor.Register(advisor.TiDB, advisor.Fake, &Advisor{})
+	advisor.Register(advisor.MariaDB, advisor.Fake, &Advisor{})
+	advisor.Register(advisor.OceanBase, advisor.Fake, &Advisor{})
 }
 
 // Advisor is the fake sql advisor.
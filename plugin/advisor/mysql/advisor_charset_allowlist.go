@@ -0,0 +1,154 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CharsetAllowlistAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLCharsetAllowlist, &CharsetAllowlistAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLCharsetAllowlist, &CharsetAllowlistAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLCharsetAllowlist, &CharsetAllowlistAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLCharsetAllowlist, &CharsetAllowlistAdvisor{})
+}
+
+// CharsetAllowlistAdvisor is the advisor checking for the charset allowlist.
+type CharsetAllowlistAdvisor struct {
+}
+
+// Check checks for the charset allowlist.
+func (adv *CharsetAllowlistAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &charsetAllowlistChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		allowlist: make(map[string]bool),
+	}
+	for _, charset := range payload.List {
+		checker.allowlist[strings.ToLower(charset)] = true
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type charsetAllowlistChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	// allowlist is the lowercase charsets in the allowlist.
+	allowlist map[string]bool
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *charsetAllowlistChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE DATABASE
+	case *ast.CreateDatabaseStmt:
+		for _, option := range node.Options {
+			if option.Tp == ast.DatabaseOptionCharset {
+				checker.checkCharset(fmt.Sprintf("Database `%s`", node.Name), option.Value)
+			}
+		}
+	// ALTER DATABASE
+	case *ast.AlterDatabaseStmt:
+		for _, option := range node.Options {
+			if option.Tp == ast.DatabaseOptionCharset {
+				checker.checkCharset(fmt.Sprintf("Database `%s`", node.Name), option.Value)
+			}
+		}
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		table := node.Table.Name.O
+		checker.checkTableOptions(table, node.Options)
+		for _, column := range node.Cols {
+			checker.checkColumn(table, column)
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		table := node.Table.Name.O
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ALTER TABLE OPTION
+			case ast.AlterTableOption:
+				checker.checkTableOptions(table, spec.Options)
+			// ADD COLUMNS
+			case ast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					checker.checkColumn(table, column)
+				}
+			// CHANGE COLUMN and MODIFY COLUMN
+			case ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				checker.checkColumn(table, spec.NewColumns[0])
+			}
+		}
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *charsetAllowlistChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *charsetAllowlistChecker) checkTableOptions(table string, options []*ast.TableOption) {
+	for _, option := range options {
+		if option.Tp == ast.TableOptionCharset {
+			checker.checkCharset(fmt.Sprintf("Table `%s`", table), option.StrValue)
+		}
+	}
+}
+
+func (checker *charsetAllowlistChecker) checkColumn(table string, column *ast.ColumnDef) {
+	if column.Tp == nil {
+		return
+	}
+	checker.checkCharset(fmt.Sprintf("Column `%s`.`%s`", table, column.Name.Name.O), column.Tp.Charset)
+}
+
+// checkCharset reports the charset not in the allowlist, and the empty charset means the default one.
+func (checker *charsetAllowlistChecker) checkCharset(object string, charset string) {
+	if charset == "" || checker.allowlist[strings.ToLower(charset)] {
+		return
+	}
+	checker.adviceList = append(checker.adviceList, advisor.Advice{
+		Status:         checker.level,
+		Code:           advisor.DisabledCharset,
+		Title:          checker.title,
+		Content:        fmt.Sprintf("%s uses the charset `%s` not in the allowlist", object, charset),
+		StatementIndex: checker.statementIndex,
+	})
+}
@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharsetAllowlist(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a varchar(255) CHARACTER SET utf8mb4) CHARSET = UTF8MB4",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE DATABASE db CHARACTER SET latin1",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCharset,
+					Title:          "system.charset.allowlist",
+					Content:        "Database `db` uses the charset `latin1` not in the allowlist",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a varchar(255) CHARACTER SET latin1) CHARSET = ascii",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCharset,
+					Title:          "system.charset.allowlist",
+					Content:        "Table `t` uses the charset `ascii` not in the allowlist",
+					StatementIndex: 1,
+				},
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCharset,
+					Title:          "system.charset.allowlist",
+					Content:        "Column `t`.`a` uses the charset `latin1` not in the allowlist",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book CHARSET = utf8mb4;
+				ALTER TABLE tech_book MODIFY COLUMN name varchar(255) CHARACTER SET latin1`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCharset,
+					Title:          "system.charset.allowlist",
+					Content:        "Column `tech_book`.`name` uses the charset `latin1` not in the allowlist",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.StringArrayTypeRulePayload{
+		List: []string{"utf8mb4"},
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &CharsetAllowlistAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleCharsetAllowlist,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
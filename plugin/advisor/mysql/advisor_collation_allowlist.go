@@ -0,0 +1,160 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CollationAllowlistAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLCollationAllowlist, &CollationAllowlistAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLCollationAllowlist, &CollationAllowlistAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLCollationAllowlist, &CollationAllowlistAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLCollationAllowlist, &CollationAllowlistAdvisor{})
+}
+
+// CollationAllowlistAdvisor is the advisor checking for the collation allowlist.
+type CollationAllowlistAdvisor struct {
+}
+
+// Check checks for the collation allowlist.
+func (adv *CollationAllowlistAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &collationAllowlistChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		allowlist: make(map[string]bool),
+	}
+	for _, collation := range payload.List {
+		checker.allowlist[strings.ToLower(collation)] = true
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type collationAllowlistChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	// allowlist is the lowercase collations in the allowlist.
+	allowlist map[string]bool
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *collationAllowlistChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE DATABASE
+	case *ast.CreateDatabaseStmt:
+		for _, option := range node.Options {
+			if option.Tp == ast.DatabaseOptionCollate {
+				checker.checkCollation(fmt.Sprintf("Database `%s`", node.Name), option.Value)
+			}
+		}
+	// ALTER DATABASE
+	case *ast.AlterDatabaseStmt:
+		for _, option := range node.Options {
+			if option.Tp == ast.DatabaseOptionCollate {
+				checker.checkCollation(fmt.Sprintf("Database `%s`", node.Name), option.Value)
+			}
+		}
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		table := node.Table.Name.O
+		checker.checkTableOptions(table, node.Options)
+		for _, column := range node.Cols {
+			checker.checkColumn(table, column)
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		table := node.Table.Name.O
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ALTER TABLE OPTION
+			case ast.AlterTableOption:
+				checker.checkTableOptions(table, spec.Options)
+			// ADD COLUMNS
+			case ast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					checker.checkColumn(table, column)
+				}
+			// CHANGE COLUMN and MODIFY COLUMN
+			case ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				checker.checkColumn(table, spec.NewColumns[0])
+			}
+		}
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *collationAllowlistChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *collationAllowlistChecker) checkTableOptions(table string, options []*ast.TableOption) {
+	for _, option := range options {
+		if option.Tp == ast.TableOptionCollate {
+			checker.checkCollation(fmt.Sprintf("Table `%s`", table), option.StrValue)
+		}
+	}
+}
+
+// checkColumn checks the collation in the column type, e.g. VARCHAR(20) COLLATE utf8mb4_bin, and the COLLATE column option.
+func (checker *collationAllowlistChecker) checkColumn(table string, column *ast.ColumnDef) {
+	object := fmt.Sprintf("Column `%s`.`%s`", table, column.Name.Name.O)
+	if column.Tp != nil {
+		checker.checkCollation(object, column.Tp.Collate)
+	}
+	for _, option := range column.Options {
+		if option.Tp == ast.ColumnOptionCollate {
+			checker.checkCollation(object, option.StrValue)
+		}
+	}
+}
+
+// checkCollation reports the collation not in the allowlist, and the empty collation means the default one.
+func (checker *collationAllowlistChecker) checkCollation(object string, collation string) {
+	if collation == "" || checker.allowlist[strings.ToLower(collation)] {
+		return
+	}
+	checker.adviceList = append(checker.adviceList, advisor.Advice{
+		Status:         checker.level,
+		Code:           advisor.DisabledCollation,
+		Title:          checker.title,
+		Content:        fmt.Sprintf("%s uses the collation `%s` not in the allowlist", object, collation),
+		StatementIndex: checker.statementIndex,
+	})
+}
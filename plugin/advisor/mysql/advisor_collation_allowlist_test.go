@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollationAllowlist(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a varchar(255) COLLATE utf8mb4_general_ci) COLLATE = UTF8MB4_GENERAL_CI",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE DATABASE db COLLATE latin1_bin",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCollation,
+					Title:          "system.collation.allowlist",
+					Content:        "Database `db` uses the collation `latin1_bin` not in the allowlist",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a varchar(255) COLLATE utf8mb4_bin) COLLATE = latin1_bin",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCollation,
+					Title:          "system.collation.allowlist",
+					Content:        "Table `t` uses the collation `latin1_bin` not in the allowlist",
+					StatementIndex: 1,
+				},
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCollation,
+					Title:          "system.collation.allowlist",
+					Content:        "Column `t`.`a` uses the collation `utf8mb4_bin` not in the allowlist",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book COLLATE = utf8mb4_general_ci;
+				ALTER TABLE tech_book MODIFY COLUMN name varchar(255) COLLATE utf8mb4_bin`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCollation,
+					Title:          "system.collation.allowlist",
+					Content:        "Column `tech_book`.`name` uses the collation `utf8mb4_bin` not in the allowlist",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.StringArrayTypeRulePayload{
+		List: []string{"utf8mb4_general_ci"},
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &CollationAllowlistAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleCollationAllowlist,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
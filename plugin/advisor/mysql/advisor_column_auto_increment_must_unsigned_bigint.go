@@ -0,0 +1,120 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+var (
+	_ advisor.Advisor = (*AutoIncrementColumnMustUnsignedBigintAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLAutoIncrementColumnMustUnsignedBigint, &AutoIncrementColumnMustUnsignedBigintAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLAutoIncrementColumnMustUnsignedBigint, &AutoIncrementColumnMustUnsignedBigintAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLAutoIncrementColumnMustUnsignedBigint, &AutoIncrementColumnMustUnsignedBigintAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLAutoIncrementColumnMustUnsignedBigint, &AutoIncrementColumnMustUnsignedBigintAdvisor{})
+}
+
+// AutoIncrementColumnMustUnsignedBigintAdvisor is the advisor checking for the unsigned BIGINT auto-increment column.
+type AutoIncrementColumnMustUnsignedBigintAdvisor struct {
+}
+
+// Check checks for the unsigned BIGINT auto-increment column.
+func (adv *AutoIncrementColumnMustUnsignedBigintAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &autoIncrementColumnMustUnsignedBigintChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type autoIncrementColumnMustUnsignedBigintChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *autoIncrementColumnMustUnsignedBigintChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range node.Cols {
+			checker.checkColumn(node.Table.Name.O, column)
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ADD COLUMNS
+			case ast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					checker.checkColumn(node.Table.Name.O, column)
+				}
+			// CHANGE COLUMN and MODIFY COLUMN
+			case ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				checker.checkColumn(node.Table.Name.O, spec.NewColumns[0])
+			}
+		}
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *autoIncrementColumnMustUnsignedBigintChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *autoIncrementColumnMustUnsignedBigintChecker) checkColumn(table string, column *ast.ColumnDef) {
+	if !isAutoIncrementColumn(column) {
+		return
+	}
+	if column.Tp != nil && column.Tp.Tp == mysql.TypeLonglong && mysql.HasUnsignedFlag(column.Tp.Flag) {
+		return
+	}
+	checker.adviceList = append(checker.adviceList, advisor.Advice{
+		Status:         checker.level,
+		Code:           advisor.AutoIncrementColumnNotUnsignedBigint,
+		Title:          checker.title,
+		Content:        fmt.Sprintf("Auto-increment column `%s`.`%s` requires UNSIGNED BIGINT type", table, column.Name.Name.O),
+		StatementIndex: checker.statementIndex,
+	})
+}
+
+func isAutoIncrementColumn(column *ast.ColumnDef) bool {
+	for _, option := range column.Options {
+		if option.Tp == ast.ColumnOptionAutoIncrement {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestAutoIncrementColumnMustUnsignedBigint(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(id bigint unsigned auto_increment PRIMARY KEY, a int)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id int auto_increment PRIMARY KEY)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.AutoIncrementColumnNotUnsignedBigint,
+					Title:          "column.auto-increment-must-unsigned-bigint",
+					Content:        "Auto-increment column `t`.`id` requires UNSIGNED BIGINT type",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a bigint unsigned auto_increment;
+				ALTER TABLE tech_book MODIFY COLUMN id bigint auto_increment`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.AutoIncrementColumnNotUnsignedBigint,
+					Title:          "column.auto-increment-must-unsigned-bigint",
+					Content:        "Auto-increment column `tech_book`.`id` requires UNSIGNED BIGINT type",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &AutoIncrementColumnMustUnsignedBigintAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnAutoIncrementMustUnsignedBigint,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
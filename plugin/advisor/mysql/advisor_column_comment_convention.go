@@ -0,0 +1,137 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLColumnCommentConvention, &ColumnCommentConventionAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLColumnCommentConvention, &ColumnCommentConventionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLColumnCommentConvention, &ColumnCommentConventionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLColumnCommentConvention, &ColumnCommentConventionAdvisor{})
+}
+
+// ColumnCommentConventionAdvisor is the advisor checking for column comment convention.
+type ColumnCommentConventionAdvisor struct {
+}
+
+// Check checks for column comment convention.
+func (adv *ColumnCommentConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnCommentConventionChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		required:  payload.Required,
+		maxLength: payload.MaxLength,
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnCommentConventionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	required       bool
+	maxLength      int
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *columnCommentConventionChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range node.Cols {
+			checker.checkColumn(node.Table.Name.O, column)
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ADD COLUMNS
+			case ast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					checker.checkColumn(node.Table.Name.O, column)
+				}
+			// CHANGE COLUMN and MODIFY COLUMN redefine the column, and the comment is dropped if not specified.
+			case ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				checker.checkColumn(node.Table.Name.O, spec.NewColumns[0])
+			}
+		}
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *columnCommentConventionChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *columnCommentConventionChecker) checkColumn(table string, column *ast.ColumnDef) {
+	comment, exists := getColumnComment(column)
+	if checker.required && (!exists || comment == "") {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.NoColumnComment,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("Column `%s`.`%s` requires comments", table, column.Name.Name.O),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+	if checker.maxLength > 0 && len([]rune(comment)) > checker.maxLength {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.ColumnCommentTooLong,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("The length of column `%s`.`%s` comment should be within %d characters", table, column.Name.Name.O, checker.maxLength),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+}
+
+// getColumnComment returns the comment of the column, and false if there is no comment option.
+func getColumnComment(column *ast.ColumnDef) (string, bool) {
+	for _, option := range column.Options {
+		if option.Tp == ast.ColumnOptionComment {
+			if value, ok := option.Expr.(ast.ValueExpr); ok {
+				return value.GetString(), true
+			}
+			return "", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnCommentConvention(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a int COMMENT 'some comments')",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a int COMMENT 'some comments', b int)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoColumnComment,
+					Title:          "column.comment",
+					Content:        "Column `t`.`b` requires comments",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a int COMMENT 'this is a very long comment')",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCommentTooLong,
+					Title:          "column.comment",
+					Content:        "The length of column `t`.`a` comment should be within 20 characters",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a int COMMENT 'some comments';
+				ALTER TABLE tech_book MODIFY COLUMN name varchar(255)`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoColumnComment,
+					Title:          "column.comment",
+					Content:        "Column `tech_book`.`name` requires comments",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE tech_book CHANGE COLUMN name title varchar(255) COMMENT 'the book title'",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.CommentConventionRulePayload{
+		Required:  true,
+		MaxLength: 20,
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnCommentConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnCommentConvention,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
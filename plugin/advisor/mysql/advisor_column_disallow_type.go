@@ -0,0 +1,123 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowTypeAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLColumnDisallowType, &ColumnDisallowTypeAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLColumnDisallowType, &ColumnDisallowTypeAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLColumnDisallowType, &ColumnDisallowTypeAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLColumnDisallowType, &ColumnDisallowTypeAdvisor{})
+}
+
+// ColumnDisallowTypeAdvisor is the advisor checking for the column types in the disallow list.
+type ColumnDisallowTypeAdvisor struct {
+}
+
+// Check checks for the column types in the disallow list.
+func (adv *ColumnDisallowTypeAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnDisallowTypeChecker{
+		level:           level,
+		title:           string(ctx.Rule.Type),
+		disallowTypeSet: make(map[string]bool),
+	}
+	for _, tp := range payload.List {
+		checker.disallowTypeSet[strings.ToLower(tp)] = true
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnDisallowTypeChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	// disallowTypeSet is the lowercase type names in the disallow list.
+	disallowTypeSet map[string]bool
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *columnDisallowTypeChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range node.Cols {
+			checker.checkColumn(node.Table.Name.O, column)
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ADD COLUMNS
+			case ast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					checker.checkColumn(node.Table.Name.O, column)
+				}
+			// CHANGE COLUMN and MODIFY COLUMN
+			case ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				checker.checkColumn(node.Table.Name.O, spec.NewColumns[0])
+			}
+		}
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *columnDisallowTypeChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *columnDisallowTypeChecker) checkColumn(table string, column *ast.ColumnDef) {
+	if column.Tp == nil {
+		return
+	}
+	// The type name without the length, e.g. "varchar" for VARCHAR(255), and "blob" for the TEXT with the binary charset.
+	tp := types.TypeToStr(column.Tp.Tp, column.Tp.Charset)
+	if checker.disallowTypeSet[tp] {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.DisabledColumnType,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("Disallow column type %s but column `%s`.`%s` is", strings.ToUpper(tp), table, column.Name.Name.O),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+}
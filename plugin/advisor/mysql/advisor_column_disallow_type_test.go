@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnDisallowType(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a int, b varchar(255), c double)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a int, b enum('x', 'y'), c float)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type ENUM but column `t`.`b` is",
+					StatementIndex: 1,
+				},
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type FLOAT but column `t`.`c` is",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a int;
+				ALTER TABLE tech_book MODIFY COLUMN name enum('x', 'y')`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type ENUM but column `tech_book`.`name` is",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE tech_book CHANGE COLUMN id id FLOAT",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type FLOAT but column `tech_book`.`id` is",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.StringArrayTypeRulePayload{
+		List: []string{"ENUM", "float"},
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnDisallowTypeAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnTypeDisallowList,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
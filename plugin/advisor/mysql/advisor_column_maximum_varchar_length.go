@@ -0,0 +1,116 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnMaximumVarcharLengthAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLColumnMaximumVarcharLength, &ColumnMaximumVarcharLengthAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLColumnMaximumVarcharLength, &ColumnMaximumVarcharLengthAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLColumnMaximumVarcharLength, &ColumnMaximumVarcharLengthAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLColumnMaximumVarcharLength, &ColumnMaximumVarcharLengthAdvisor{})
+}
+
+// ColumnMaximumVarcharLengthAdvisor is the advisor checking for the maximum VARCHAR length.
+type ColumnMaximumVarcharLengthAdvisor struct {
+}
+
+// Check checks for the maximum VARCHAR length.
+func (adv *ColumnMaximumVarcharLengthAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnMaximumVarcharLengthChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		maxLength: payload.Number,
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnMaximumVarcharLengthChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	maxLength      int
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *columnMaximumVarcharLengthChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range node.Cols {
+			checker.checkColumn(node.Table.Name.O, column)
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ADD COLUMNS
+			case ast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					checker.checkColumn(node.Table.Name.O, column)
+				}
+			// CHANGE COLUMN and MODIFY COLUMN
+			case ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+				checker.checkColumn(node.Table.Name.O, spec.NewColumns[0])
+			}
+		}
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *columnMaximumVarcharLengthChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *columnMaximumVarcharLengthChecker) checkColumn(table string, column *ast.ColumnDef) {
+	if column.Tp == nil || column.Tp.Tp != mysql.TypeVarchar {
+		return
+	}
+	if column.Tp.Flen > checker.maxLength {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.VarcharLengthExceedsLimit,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("The length of the VARCHAR column `%s`.`%s` is bigger than %d", table, column.Name.Name.O, checker.maxLength),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+}
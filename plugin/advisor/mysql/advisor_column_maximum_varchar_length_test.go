@@ -0,0 +1,60 @@
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnMaximumVarcharLength(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a varchar(255), b char(3000), c text)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a varchar(3000))",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.VarcharLengthExceedsLimit,
+					Title:          "column.maximum-varchar-length",
+					Content:        "The length of the VARCHAR column `t`.`a` is bigger than 2560",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a varchar(2560);
+				ALTER TABLE tech_book MODIFY COLUMN name varchar(2561)`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.VarcharLengthExceedsLimit,
+					Title:          "column.maximum-varchar-length",
+					Content:        "The length of the VARCHAR column `tech_book`.`name` is bigger than 2560",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NumberTypeRulePayload{
+		Number: 2560,
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnMaximumVarcharLengthAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnMaximumVarcharLength,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
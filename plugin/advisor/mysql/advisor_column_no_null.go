@@ -14,6 +14,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLColumnNoNull, &ColumnNoNullAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLColumnNoNull, &ColumnNoNullAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLColumnNoNull, &ColumnNoNullAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLColumnNoNull, &ColumnNoNullAdvisor{})
 }
 
 // ColumnNoNullAdvisor is the advisor checking for column no NULL value.
@@ -36,7 +38,8 @@ func (adv *ColumnNoNullAdvisor) Check(ctx advisor.Context, statement string) ([]
 		title: string(ctx.Rule.Type),
 	}
 
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -52,9 +55,10 @@ func (adv *ColumnNoNullAdvisor) Check(ctx advisor.Context, statement string) ([]
 }
 
 type columnNoNullChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
 }
 
 type columnName struct {
@@ -104,10 +108,11 @@ func (v *columnNoNullChecker) Enter(in ast.Node) (ast.Node, bool) {
 
 	for _, column := range columns {
 		v.adviceList = append(v.adviceList, advisor.Advice{
-			Status:  v.level,
-			Code:    advisor.ColumnCanNotNull,
-			Title:   v.title,
-			Content: fmt.Sprintf("`%s`.`%s` can not have NULL value", column.tableName, column.columnName),
+			Status:         v.level,
+			Code:           advisor.ColumnCanNotNull,
+			Title:          v.title,
+			Content:        fmt.Sprintf("`%s`.`%s` can not have NULL value", column.tableName, column.columnName),
+			StatementIndex: v.statementIndex,
 		})
 	}
 
@@ -12,16 +12,18 @@ func TestColumnNoNull(t *testing.T) {
 			Statement: "CREATE TABLE book(id int, name varchar(255))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.ColumnCanNotNull,
-					Title:   "column.no-null",
-					Content: "`book`.`id` can not have NULL value",
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "`book`.`id` can not have NULL value",
+					StatementIndex: 1,
 				},
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.ColumnCanNotNull,
-					Title:   "column.no-null",
-					Content: "`book`.`name` can not have NULL value",
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "`book`.`name` can not have NULL value",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -29,10 +31,11 @@ func TestColumnNoNull(t *testing.T) {
 			Statement: "CREATE TABLE book(id int PRIMARY KEY, name varchar(255))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.ColumnCanNotNull,
-					Title:   "column.no-null",
-					Content: "`book`.`name` can not have NULL value",
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "`book`.`name` can not have NULL value",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -40,10 +43,11 @@ func TestColumnNoNull(t *testing.T) {
 			Statement: "CREATE TABLE book(id int NOT NULL, name varchar(255))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.ColumnCanNotNull,
-					Title:   "column.no-null",
-					Content: "`book`.`name` can not have NULL value",
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "`book`.`name` can not have NULL value",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -62,10 +66,11 @@ func TestColumnNoNull(t *testing.T) {
 			Statement: "ALTER TABLE book ADD COLUMN (id int, name varchar(255) NOT NULL)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.ColumnCanNotNull,
-					Title:   "column.no-null",
-					Content: "`book`.`id` can not have NULL value",
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "`book`.`id` can not have NULL value",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -84,10 +89,11 @@ func TestColumnNoNull(t *testing.T) {
 			Statement: "ALTER TABLE book CHANGE COLUMN id name varchar(255)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.ColumnCanNotNull,
-					Title:   "column.no-null",
-					Content: "`book`.`name` can not have NULL value",
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "`book`.`name` can not have NULL value",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -16,6 +16,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLColumnRequirement, &ColumnRequirementAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLColumnRequirement, &ColumnRequirementAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLColumnRequirement, &ColumnRequirementAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLColumnRequirement, &ColumnRequirementAdvisor{})
 }
 
 // ColumnRequirementAdvisor is the advisor checking for column requirement.
@@ -42,13 +44,15 @@ func (adv *ColumnRequirementAdvisor) Check(ctx advisor.Context, statement string
 		requiredColumns[column] = true
 	}
 	checker := &columnRequirementChecker{
-		level:           level,
-		title:           string(ctx.Rule.Type),
-		requiredColumns: requiredColumns,
-		tables:          make(tableState),
+		level:               level,
+		title:               string(ctx.Rule.Type),
+		requiredColumns:     requiredColumns,
+		tables:              make(tableState),
+		tableStatementIndex: make(map[string]int),
 	}
 
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -59,8 +63,11 @@ type columnRequirementChecker struct {
 	adviceList      []advisor.Advice
 	level           advisor.Status
 	title           string
+	statementIndex  int
 	requiredColumns columnSet
 	tables          tableState
+	// tableStatementIndex is the index of the last statement changing the table, and the advice is for it.
+	tableStatementIndex map[string]int
 }
 
 // Enter implements the ast.Visitor interface
@@ -68,6 +75,7 @@ func (v *columnRequirementChecker) Enter(in ast.Node) (ast.Node, bool) {
 	switch node := in.(type) {
 	// CREATE TABLE
 	case *ast.CreateTableStmt:
+		v.tableStatementIndex[node.Table.Name.O] = v.statementIndex
 		v.createTable(node)
 	// DROP TABLE
 	case *ast.DropTableStmt:
@@ -77,6 +85,7 @@ func (v *columnRequirementChecker) Enter(in ast.Node) (ast.Node, bool) {
 	// ALTER TABLE
 	case *ast.AlterTableStmt:
 		table := node.Table.Name.O
+		v.tableStatementIndex[table] = v.statementIndex
 		for _, spec := range node.Specs {
 			switch spec.Tp {
 			// RENAME COLUMN
@@ -119,10 +128,11 @@ func (v *columnRequirementChecker) generateAdviceList() []advisor.Advice {
 			// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
 			sort.Strings(missingColumns)
 			v.adviceList = append(v.adviceList, advisor.Advice{
-				Status:  v.level,
-				Code:    advisor.NoRequiredColumn,
-				Title:   v.title,
-				Content: fmt.Sprintf("Table `%s` requires columns: %s", tableName, strings.Join(missingColumns, ", ")),
+				Status:         v.level,
+				Code:           advisor.NoRequiredColumn,
+				Title:          v.title,
+				Content:        fmt.Sprintf("Table `%s` requires columns: %s", tableName, strings.Join(missingColumns, ", ")),
+				StatementIndex: v.tableStatementIndex[tableName],
 			})
 		}
 	}
@@ -14,10 +14,11 @@ func TestColumnRequirement(t *testing.T) {
 			Statement: "CREATE TABLE book(id int)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NoRequiredColumn,
-					Title:   "column.required",
-					Content: "Table `book` requires columns: created_ts, creator_id, updated_ts, updater_id",
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table `book` requires columns: created_ts, creator_id, updated_ts, updater_id",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -47,10 +48,11 @@ func TestColumnRequirement(t *testing.T) {
 						ALTER TABLE book RENAME COLUMN creator_id TO creator;`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NoRequiredColumn,
-					Title:   "column.required",
-					Content: "Table `book` requires columns: creator_id",
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table `book` requires columns: creator_id",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -81,10 +83,11 @@ func TestColumnRequirement(t *testing.T) {
 						ALTER TABLE book CHANGE COLUMN creator_id creator int;`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NoRequiredColumn,
-					Title:   "column.required",
-					Content: "Table `book` requires columns: creator_id",
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table `book` requires columns: creator_id",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -115,10 +118,11 @@ func TestColumnRequirement(t *testing.T) {
 						ALTER TABLE book DROP COLUMN creator_id;`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NoRequiredColumn,
-					Title:   "column.required",
-					Content: "Table `book` requires columns: creator_id",
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table `book` requires columns: creator_id",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -149,10 +153,11 @@ func TestColumnRequirement(t *testing.T) {
 						ALTER TABLE book ADD COLUMN content varchar(255);`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NoRequiredColumn,
-					Title:   "column.required",
-					Content: "Table `book` requires columns: updater_id",
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table `book` requires columns: updater_id",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -185,16 +190,18 @@ func TestColumnRequirement(t *testing.T) {
 							updated_ts timestamp);`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NoRequiredColumn,
-					Title:   "column.required",
-					Content: "Table `book` requires columns: creator_id",
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table `book` requires columns: creator_id",
+					StatementIndex: 1,
 				},
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NoRequiredColumn,
-					Title:   "column.required",
-					Content: "Table `student` requires columns: creator_id, updater_id",
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table `student` requires columns: creator_id, updater_id",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -0,0 +1,410 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+var (
+	_ advisor.Advisor = (*CustomExpressionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLCustomExpression, &CustomExpressionAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLCustomExpression, &CustomExpressionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLCustomExpression, &CustomExpressionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLCustomExpression, &CustomExpressionAdvisor{})
+}
+
+// CustomExpressionAdvisor is the advisor checking for the user-defined expression.
+type CustomExpressionAdvisor struct {
+}
+
+// Check checks for the user-defined expression.
+func (adv *CustomExpressionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, expression, err := advisor.UnmarshalCustomExpressionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &customExpressionChecker{
+		level:      level,
+		title:      payload.Title,
+		message:    payload.Message,
+		expression: expression,
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+		if checker.err != nil {
+			return nil, checker.err
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type customExpressionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	message        string
+	expression     *advisor.CustomExpression
+	// err is the first evaluation error, and the checker stops evaluating after it.
+	err error
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *customExpressionChecker) Enter(in ast.Node) (ast.Node, bool) {
+	for _, parameters := range getCustomExpressionParametersList(in) {
+		checker.evaluate(parameters)
+	}
+	return in, checker.err != nil
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *customExpressionChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, checker.err == nil
+}
+
+func (checker *customExpressionChecker) evaluate(parameters *advisor.CustomExpressionParameters) {
+	if checker.err != nil {
+		return
+	}
+	matched, err := advisor.EvaluateCustomExpression(checker.expression, parameters)
+	if err != nil {
+		checker.err = err
+		return
+	}
+	if !matched {
+		return
+	}
+	content := checker.message
+	if content == "" {
+		content = fmt.Sprintf("%s on table `%s` matches the expression %q", parameters.StatementType, parameters.Table, checker.expression.String())
+	}
+	checker.adviceList = append(checker.adviceList, advisor.Advice{
+		Status:         checker.level,
+		Code:           advisor.CustomExpressionMatched,
+		Title:          checker.title,
+		Content:        content,
+		StatementIndex: checker.statementIndex,
+	})
+}
+
+// getCustomExpressionParametersList returns the parameters of the changes in the node.
+func getCustomExpressionParametersList(in ast.Node) []*advisor.CustomExpressionParameters {
+	var res []*advisor.CustomExpressionParameters
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		createTable := &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionCreateTable,
+			Schema:        node.Table.Schema.O,
+			Table:         node.Table.Name.O,
+			ColumnCount:   len(node.Cols),
+		}
+		res = append(res, createTable)
+		for _, column := range node.Cols {
+			res = append(res, getColumnParametersList(node.Table, column)...)
+		}
+		for _, constraint := range node.Constraints {
+			res = append(res, getConstraintParameters(node.Table, constraint))
+		}
+		for _, parameters := range res {
+			if parameters.StatementType == advisor.CustomExpressionAddConstraint && parameters.ConstraintType == "PRIMARY KEY" {
+				createTable.HasPrimaryKey = true
+			}
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		if node.IsView {
+			break
+		}
+		for _, table := range node.Tables {
+			res = append(res, &advisor.CustomExpressionParameters{
+				StatementType: advisor.CustomExpressionDropTable,
+				Schema:        table.Schema.O,
+				Table:         table.Name.O,
+			})
+		}
+	// RENAME TABLE
+	case *ast.RenameTableStmt:
+		for _, tableToTable := range node.TableToTables {
+			res = append(res, &advisor.CustomExpressionParameters{
+				StatementType: advisor.CustomExpressionRenameTable,
+				Schema:        tableToTable.OldTable.Schema.O,
+				Table:         tableToTable.OldTable.Name.O,
+				NewName:       tableToTable.NewTable.Name.O,
+			})
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		for _, spec := range node.Specs {
+			res = append(res, getAlterTableSpecParametersList(node.Table, spec)...)
+		}
+	// CREATE INDEX
+	case *ast.CreateIndexStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionCreateIndex,
+			Schema:        node.Table.Schema.O,
+			Table:         node.Table.Name.O,
+			Name:          node.IndexName,
+			Unique:        node.KeyType == ast.IndexKeyTypeUnique,
+			ColumnCount:   len(node.IndexPartSpecifications),
+		})
+	// DROP INDEX
+	case *ast.DropIndexStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropIndex,
+			Schema:        node.Table.Schema.O,
+			Table:         node.Table.Name.O,
+			Name:          node.IndexName,
+		})
+	// UPDATE
+	case *ast.UpdateStmt:
+		for _, table := range getTableNameList(node.TableRefs.TableRefs) {
+			res = append(res, &advisor.CustomExpressionParameters{
+				StatementType: advisor.CustomExpressionUpdate,
+				Schema:        table.Schema.O,
+				Table:         table.Name.O,
+				HasWhere:      node.Where != nil,
+			})
+		}
+	// DELETE
+	case *ast.DeleteStmt:
+		// The multiple table DELETE statement deletes the rows of the tables before FROM or after USING.
+		var tableList []*ast.TableName
+		if node.IsMultiTable && node.Tables != nil {
+			tableList = node.Tables.Tables
+		} else {
+			tableList = getTableNameList(node.TableRefs.TableRefs)
+		}
+		for _, table := range tableList {
+			res = append(res, &advisor.CustomExpressionParameters{
+				StatementType: advisor.CustomExpressionDelete,
+				Schema:        table.Schema.O,
+				Table:         table.Name.O,
+				HasWhere:      node.Where != nil,
+			})
+		}
+	}
+	return res
+}
+
+// getAlterTableSpecParametersList returns the parameters of the changes in the ALTER TABLE specification.
+func getAlterTableSpecParametersList(table *ast.TableName, spec *ast.AlterTableSpec) []*advisor.CustomExpressionParameters {
+	var res []*advisor.CustomExpressionParameters
+	switch spec.Tp {
+	// ADD COLUMNS
+	case ast.AlterTableAddColumns:
+		for _, column := range spec.NewColumns {
+			res = append(res, getColumnParametersList(table, column)...)
+		}
+		// The constraints of ALTER TABLE ADD COLUMN (a INT, UNIQUE (a)).
+		for _, constraint := range spec.NewConstraints {
+			res = append(res, getConstraintParameters(table, constraint))
+		}
+	// DROP COLUMN
+	case ast.AlterTableDropColumn:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropColumn,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			Column:        spec.OldColumnName.Name.O,
+		})
+	// RENAME COLUMN
+	case ast.AlterTableRenameColumn:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionRenameColumn,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			Column:        spec.OldColumnName.Name.O,
+			NewName:       spec.NewColumnName.Name.O,
+		})
+	// CHANGE COLUMN and MODIFY COLUMN, which may rename the column and change the column type.
+	case ast.AlterTableChangeColumn, ast.AlterTableModifyColumn:
+		column := spec.NewColumns[0]
+		oldName := column.Name.Name.O
+		if spec.OldColumnName != nil {
+			oldName = spec.OldColumnName.Name.O
+		}
+		if oldName != column.Name.Name.O {
+			res = append(res, &advisor.CustomExpressionParameters{
+				StatementType: advisor.CustomExpressionRenameColumn,
+				Schema:        table.Schema.O,
+				Table:         table.Name.O,
+				Column:        oldName,
+				NewName:       column.Name.Name.O,
+			})
+		}
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionAlterColumnType,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			Column:        oldName,
+			ColumnType:    getColumnType(column),
+		})
+	// RENAME TO
+	case ast.AlterTableRenameTable:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionRenameTable,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			NewName:       spec.NewTable.Name.O,
+		})
+	// ADD CONSTRAINT and ADD INDEX
+	case ast.AlterTableAddConstraint:
+		res = append(res, getConstraintParameters(table, spec.Constraint))
+	// DROP PRIMARY KEY
+	case ast.AlterTableDropPrimaryKey:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropConstraint,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			Name:          "PRIMARY",
+		})
+	// DROP FOREIGN KEY
+	case ast.AlterTableDropForeignKey:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropConstraint,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			Name:          spec.Name,
+		})
+	// DROP CHECK
+	case ast.AlterTableDropCheck:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropConstraint,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			Name:          spec.Constraint.Name,
+		})
+	// DROP INDEX
+	case ast.AlterTableDropIndex:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropIndex,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			Name:          spec.Name,
+		})
+	}
+	return res
+}
+
+// getColumnParametersList returns the parameters of the added column and its PRIMARY KEY, UNIQUE, FOREIGN KEY and CHECK constraints.
+func getColumnParametersList(table *ast.TableName, column *ast.ColumnDef) []*advisor.CustomExpressionParameters {
+	addColumn := &advisor.CustomExpressionParameters{
+		StatementType: advisor.CustomExpressionAddColumn,
+		Schema:        table.Schema.O,
+		Table:         table.Name.O,
+		Column:        column.Name.Name.O,
+		ColumnType:    getColumnType(column),
+	}
+	res := []*advisor.CustomExpressionParameters{addColumn}
+	for _, option := range column.Options {
+		constraint := &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionAddConstraint,
+			Schema:        table.Schema.O,
+			Table:         table.Name.O,
+			ColumnCount:   1,
+		}
+		switch option.Tp {
+		case ast.ColumnOptionNotNull:
+			addColumn.NotNull = true
+			continue
+		case ast.ColumnOptionDefaultValue:
+			addColumn.HasDefault = true
+			continue
+		case ast.ColumnOptionPrimaryKey:
+			// PRIMARY KEY implies NOT NULL.
+			addColumn.NotNull = true
+			constraint.ConstraintType = "PRIMARY KEY"
+			constraint.Unique = true
+		case ast.ColumnOptionUniqKey:
+			constraint.ConstraintType = "UNIQUE"
+			constraint.Unique = true
+		case ast.ColumnOptionReference:
+			constraint.ConstraintType = "FOREIGN KEY"
+		case ast.ColumnOptionCheck:
+			constraint.ConstraintType = "CHECK"
+			constraint.Name = option.ConstraintName
+		default:
+			continue
+		}
+		res = append(res, constraint)
+	}
+	return res
+}
+
+// getConstraintParameters returns the parameters of the table constraint, and the non-unique index is a CREATE_INDEX change.
+func getConstraintParameters(table *ast.TableName, constraint *ast.Constraint) *advisor.CustomExpressionParameters {
+	parameters := &advisor.CustomExpressionParameters{
+		StatementType: advisor.CustomExpressionAddConstraint,
+		Schema:        table.Schema.O,
+		Table:         table.Name.O,
+		Name:          constraint.Name,
+		ColumnCount:   len(constraint.Keys),
+	}
+	switch constraint.Tp {
+	case ast.ConstraintPrimaryKey:
+		parameters.ConstraintType = "PRIMARY KEY"
+		parameters.Unique = true
+	case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+		parameters.ConstraintType = "UNIQUE"
+		parameters.Unique = true
+	case ast.ConstraintForeignKey:
+		parameters.ConstraintType = "FOREIGN KEY"
+	case ast.ConstraintCheck:
+		parameters.ConstraintType = "CHECK"
+	case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintFulltext:
+		parameters.StatementType = advisor.CustomExpressionCreateIndex
+	}
+	return parameters
+}
+
+// getColumnType returns the type name without the length, e.g. "varchar" for VARCHAR(255).
+func getColumnType(column *ast.ColumnDef) string {
+	if column.Tp == nil {
+		return ""
+	}
+	return types.TypeToStr(column.Tp.Tp, column.Tp.Charset)
+}
+
+// getTableNameList returns the tables in the table references, e.g. the joined tables of the UPDATE statement.
+func getTableNameList(node ast.ResultSetNode) []*ast.TableName {
+	switch n := node.(type) {
+	case *ast.Join:
+		res := getTableNameList(n.Left)
+		if n.Right != nil {
+			res = append(res, getTableNameList(n.Right)...)
+		}
+		return res
+	case *ast.TableSource:
+		return getTableNameList(n.Source)
+	case *ast.TableName:
+		return []*ast.TableName{n}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*IndexTotalNumberLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLIndexTotalNumberLimit, &IndexTotalNumberLimitAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLIndexTotalNumberLimit, &IndexTotalNumberLimitAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLIndexTotalNumberLimit, &IndexTotalNumberLimitAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLIndexTotalNumberLimit, &IndexTotalNumberLimitAdvisor{})
+}
+
+// IndexTotalNumberLimitAdvisor is the advisor checking for the index total number limit.
+type IndexTotalNumberLimitAdvisor struct {
+}
+
+// Check checks for the index total number limit.
+func (adv *IndexTotalNumberLimitAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &indexTotalNumberLimitChecker{
+		level:               level,
+		title:               string(ctx.Rule.Type),
+		max:                 payload.Number,
+		tables:              make(map[string]int),
+		tableStatementIndex: make(map[string]int),
+		catalog:             ctx.Catalog,
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+type indexTotalNumberLimitChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	max            int
+	// tables is the map from table name to its index count, including the existing ones in the catalog.
+	tables map[string]int
+	// tableStatementIndex is the index of the last statement adding indexes to the table, and the advice is for it.
+	tableStatementIndex map[string]int
+	catalog             catalog.Catalog
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *indexTotalNumberLimitChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		table := node.Table.Name.String()
+		checker.tables[table] = 0
+		for _, column := range node.Cols {
+			for _, option := range column.Options {
+				if option.Tp == ast.ColumnOptionPrimaryKey || option.Tp == ast.ColumnOptionUniqKey {
+					checker.addIndex(table)
+				}
+			}
+		}
+		for _, constraint := range node.Constraints {
+			if isIndexConstraint(constraint) {
+				checker.addIndex(table)
+			}
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range node.Tables {
+			checker.tables[table.Name.String()] = 0
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		table := node.Table.Name.String()
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ADD CONSTRAINT
+			case ast.AlterTableAddConstraint:
+				if isIndexConstraint(spec.Constraint) {
+					checker.loadIndexCount(table)
+					checker.addIndex(table)
+				}
+			// ADD COLUMNS
+			case ast.AlterTableAddColumns:
+				for _, column := range spec.NewColumns {
+					for _, option := range column.Options {
+						if option.Tp == ast.ColumnOptionPrimaryKey || option.Tp == ast.ColumnOptionUniqKey {
+							checker.loadIndexCount(table)
+							checker.addIndex(table)
+						}
+					}
+				}
+			// DROP INDEX and DROP PRIMARY KEY
+			case ast.AlterTableDropIndex, ast.AlterTableDropPrimaryKey:
+				checker.loadIndexCount(table)
+				checker.dropIndex(table)
+			}
+		}
+	// CREATE INDEX
+	case *ast.CreateIndexStmt:
+		table := node.Table.Name.String()
+		checker.loadIndexCount(table)
+		checker.addIndex(table)
+	// DROP INDEX
+	case *ast.DropIndexStmt:
+		table := node.Table.Name.String()
+		checker.loadIndexCount(table)
+		checker.dropIndex(table)
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *indexTotalNumberLimitChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *indexTotalNumberLimitChecker) generateAdviceList() []advisor.Advice {
+	var tableList []string
+	for table := range checker.tables {
+		tableList = append(tableList, table)
+	}
+	sort.Strings(tableList)
+	for _, table := range tableList {
+		if count := checker.tables[table]; count > checker.max {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.IndexCountExceedsLimit,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("The count of index in table `%s` should be no more than %d, but found %d", table, checker.max, count),
+				StatementIndex: checker.tableStatementIndex[table],
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList
+}
+
+// loadIndexCount loads the existing index count of the table from the catalog if the table is not seen before.
+func (checker *indexTotalNumberLimitChecker) loadIndexCount(table string) {
+	if _, ok := checker.tables[table]; ok {
+		return
+	}
+	checker.tables[table] = 0
+	if checker.catalog == nil {
+		return
+	}
+	tableInfo, err := checker.catalog.FindTable(context.Background(), &catalog.TableFind{
+		TableName: table,
+	})
+	if err != nil {
+		log.Printf("Cannot find table %s with error %v\n", table, err)
+		return
+	}
+	if tableInfo == nil {
+		return
+	}
+	checker.tables[table] = len(tableInfo.IndexList)
+}
+
+func (checker *indexTotalNumberLimitChecker) addIndex(table string) {
+	checker.tables[table]++
+	checker.tableStatementIndex[table] = checker.statementIndex
+}
+
+func (checker *indexTotalNumberLimitChecker) dropIndex(table string) {
+	if checker.tables[table] > 0 {
+		checker.tables[table]--
+	}
+}
+
+func isIndexConstraint(constraint *ast.Constraint) bool {
+	switch constraint.Tp {
+	case ast.ConstraintPrimaryKey, ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex, ast.ConstraintIndex, ast.ConstraintKey, ast.ConstraintFulltext:
+		return true
+	}
+	return false
+}
@@ -15,6 +15,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLMigrationCompatibility, &CompatibilityAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLMigrationCompatibility, &CompatibilityAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLMigrationCompatibility, &CompatibilityAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLMigrationCompatibility, &CompatibilityAdvisor{})
 }
 
 // CompatibilityAdvisor is the advisor checking for schema backward compatibility.
@@ -37,7 +39,8 @@ func (adv *CompatibilityAdvisor) Check(ctx advisor.Context, statement string) ([
 		level: level,
 		title: string(ctx.Rule.Type),
 	}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		c.statementIndex = i + 1
 		(stmtNode).Accept(c)
 	}
 
@@ -53,9 +56,10 @@ func (adv *CompatibilityAdvisor) Check(ctx advisor.Context, statement string) ([
 }
 
 type compatibilityChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
 }
 
 // Enter implements the ast.Visitor interface
@@ -148,10 +152,11 @@ func (v *compatibilityChecker) Enter(in ast.Node) (ast.Node, bool) {
 
 	if code != advisor.Ok {
 		v.adviceList = append(v.adviceList, advisor.Advice{
-			Status:  v.level,
-			Code:    code,
-			Title:   v.title,
-			Content: fmt.Sprintf("\"%s\" may cause incompatibility with the existing data and code", in.Text()),
+			Status:         v.level,
+			Code:           code,
+			Title:          v.title,
+			Content:        fmt.Sprintf("\"%s\" may cause incompatibility with the existing data and code", in.Text()),
+			StatementIndex: v.statementIndex,
 		})
 	}
 	return in, false
@@ -14,10 +14,11 @@ func TestBasic(t *testing.T) {
 			Statement: "DROP DATABASE d1",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityDropDatabase,
-					Title:   "schema.backward-compatibility",
-					Content: "\"DROP DATABASE d1\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropDatabase,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"DROP DATABASE d1\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -25,10 +26,11 @@ func TestBasic(t *testing.T) {
 			Statement: "DROP TABLE t1",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityDropTable,
-					Title:   "schema.backward-compatibility",
-					Content: "\"DROP TABLE t1\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"DROP TABLE t1\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -36,10 +38,11 @@ func TestBasic(t *testing.T) {
 			Statement: "RENAME TABLE t1 to t2",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityRenameTable,
-					Title:   "schema.backward-compatibility",
-					Content: "\"RENAME TABLE t1 to t2\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityRenameTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"RENAME TABLE t1 to t2\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -47,10 +50,11 @@ func TestBasic(t *testing.T) {
 			Statement: "DROP VIEW v1",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityDropTable,
-					Title:   "schema.backward-compatibility",
-					Content: "\"DROP VIEW v1\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"DROP VIEW v1\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -58,10 +62,11 @@ func TestBasic(t *testing.T) {
 			Statement: "CREATE UNIQUE INDEX idx1 ON t1 (f1)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddUniqueKey,
-					Title:   "schema.backward-compatibility",
-					Content: "\"CREATE UNIQUE INDEX idx1 ON t1 (f1)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddUniqueKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"CREATE UNIQUE INDEX idx1 ON t1 (f1)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -69,16 +74,18 @@ func TestBasic(t *testing.T) {
 			Statement: "DROP TABLE t1;DROP TABLE t2;",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityDropTable,
-					Title:   "schema.backward-compatibility",
-					Content: "\"DROP TABLE t1;\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"DROP TABLE t1;\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityDropTable,
-					Title:   "schema.backward-compatibility",
-					Content: "\"DROP TABLE t2;\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"DROP TABLE t2;\" may cause incompatibility with the existing data and code",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -108,10 +115,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 RENAME COLUMN f1 to f2",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityRenameColumn,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 RENAME COLUMN f1 to f2\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityRenameColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 RENAME COLUMN f1 to f2\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -119,10 +127,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 DROP COLUMN f1",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityDropColumn,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 DROP COLUMN f1\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 DROP COLUMN f1\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -130,10 +139,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ADD PRIMARY KEY (f1)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddPrimaryKey,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ADD PRIMARY KEY (f1)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddPrimaryKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD PRIMARY KEY (f1)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -141,10 +151,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ADD UNIQUE (f1)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddUniqueKey,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ADD UNIQUE (f1)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddUniqueKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD UNIQUE (f1)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -152,10 +163,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ADD UNIQUE KEY (f1)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddUniqueKey,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ADD UNIQUE KEY (f1)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddUniqueKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD UNIQUE KEY (f1)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -163,10 +175,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ADD UNIQUE INDEX (f1)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddUniqueKey,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ADD UNIQUE INDEX (f1)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddUniqueKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD UNIQUE INDEX (f1)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -174,10 +187,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ADD FOREIGN KEY (f1) REFERENCES t2(f2)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddForeignKey,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ADD FOREIGN KEY (f1) REFERENCES t2(f2)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddForeignKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD FOREIGN KEY (f1) REFERENCES t2(f2)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -185,10 +199,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ADD CHECK (f1 > 0)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddCheck,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ADD CHECK (f1 > 0)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddCheck,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD CHECK (f1 > 0)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -206,10 +221,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ALTER CHECK chk1 ENFORCED",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAlterCheck,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ALTER CHECK chk1 ENFORCED\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAlterCheck,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ALTER CHECK chk1 ENFORCED\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -227,10 +243,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 ADD CONSTRAINT CHECK (f1 > 0)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAddCheck,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 ADD CONSTRAINT CHECK (f1 > 0)\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddCheck,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD CONSTRAINT CHECK (f1 > 0)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -249,10 +266,11 @@ func TestAlterTable(t *testing.T) {
 			Statement: "ALTER TABLE t1 RENAME TO t2",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityRenameTable,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 RENAME TO t2\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityRenameTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 RENAME TO t2\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -271,10 +289,11 @@ func TestAlterTableChangeColumnType(t *testing.T) {
 			Statement: "ALTER TABLE t1 CHANGE f1 f2 TEXT",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAlterColumn,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 CHANGE f1 f2 TEXT\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAlterColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 CHANGE f1 f2 TEXT\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -282,10 +301,11 @@ func TestAlterTableChangeColumnType(t *testing.T) {
 			Statement: "ALTER TABLE t1 MODIFY f1 TEXT",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAlterColumn,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 MODIFY f1 TEXT\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAlterColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 MODIFY f1 TEXT\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -293,10 +313,11 @@ func TestAlterTableChangeColumnType(t *testing.T) {
 			Statement: "ALTER TABLE t1 MODIFY f1 TEXT NULL",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAlterColumn,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 MODIFY f1 TEXT NULL\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAlterColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 MODIFY f1 TEXT NULL\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -304,10 +325,11 @@ func TestAlterTableChangeColumnType(t *testing.T) {
 			Statement: "ALTER TABLE t1 MODIFY f1 TEXT NOT NULL",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAlterColumn,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 MODIFY f1 TEXT NOT NULL\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAlterColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 MODIFY f1 TEXT NOT NULL\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -315,10 +337,11 @@ func TestAlterTableChangeColumnType(t *testing.T) {
 			Statement: "ALTER TABLE t1 MODIFY f1 TEXT COMMENT 'bla'",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.CompatibilityAlterColumn,
-					Title:   "schema.backward-compatibility",
-					Content: "\"ALTER TABLE t1 MODIFY f1 TEXT COMMENT 'bla'\" may cause incompatibility with the existing data and code",
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAlterColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 MODIFY f1 TEXT COMMENT 'bla'\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
 				},
 			},
 		},
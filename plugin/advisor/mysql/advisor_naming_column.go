@@ -15,6 +15,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLNamingColumnConvention, &NamingColumnConventionAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLNamingColumnConvention, &NamingColumnConventionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNamingColumnConvention, &NamingColumnConventionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNamingColumnConvention, &NamingColumnConventionAdvisor{})
 }
 
 // NamingColumnConventionAdvisor is the advisor checking for column naming convention.
@@ -43,7 +45,8 @@ func (adv *NamingColumnConventionAdvisor) Check(ctx advisor.Context, statement s
 		tables: make(tableState),
 	}
 
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -60,11 +63,12 @@ func (adv *NamingColumnConventionAdvisor) Check(ctx advisor.Context, statement s
 }
 
 type namingColumnConventionChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
-	format     *regexp.Regexp
-	tables     tableState
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	format         *regexp.Regexp
+	tables         tableState
 }
 
 // Enter implements the ast.Visitor interface
@@ -101,10 +105,11 @@ func (v *namingColumnConventionChecker) Enter(in ast.Node) (ast.Node, bool) {
 	for _, column := range columnList {
 		if !v.format.MatchString(column) {
 			v.adviceList = append(v.adviceList, advisor.Advice{
-				Status:  v.level,
-				Code:    advisor.NamingColumnConventionMismatch,
-				Title:   v.title,
-				Content: fmt.Sprintf("`%s`.`%s` mismatches column naming convention, naming format should be %q", tableName, column, v.format),
+				Status:         v.level,
+				Code:           advisor.NamingColumnConventionMismatch,
+				Title:          v.title,
+				Content:        fmt.Sprintf("`%s`.`%s` mismatches column naming convention, naming format should be %q", tableName, column, v.format),
+				StatementIndex: v.statementIndex,
 			})
 		}
 	}
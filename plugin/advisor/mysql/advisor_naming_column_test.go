@@ -14,10 +14,11 @@ func TestNamingColumnConvention(t *testing.T) {
 			Statement: "CREATE TABLE book(id int, creatorId int)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`book`.`creatorId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`book`.`creatorId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -37,10 +38,11 @@ func TestNamingColumnConvention(t *testing.T) {
 						ALTER TABLE book RENAME COLUMN creator_id TO creatorId`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`book`.`creatorId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`book`.`creatorId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -65,10 +67,11 @@ func TestNamingColumnConvention(t *testing.T) {
 						ALTER TABLE book CHANGE COLUMN creator_id creatorId int;`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`book`.`creatorId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`book`.`creatorId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -103,10 +106,11 @@ func TestNamingColumnConvention(t *testing.T) {
 						ALTER TABLE book ADD COLUMN contentString varchar(255);`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`book`.`contentString` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`book`.`contentString` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -122,28 +126,32 @@ func TestNamingColumnConvention(t *testing.T) {
 							updatedTs timestamp);`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`book`.`createdTs` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`book`.`createdTs` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`book`.`updaterId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`book`.`updaterId` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`student`.`createdTs` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`student`.`createdTs` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "`student`.`updatedTs` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "`student`.`updatedTs` mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -15,6 +15,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLNamingFKConvention, &NamingFKConventionAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLNamingFKConvention, &NamingFKConventionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNamingFKConvention, &NamingFKConventionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNamingFKConvention, &NamingFKConventionAdvisor{})
 }
 
 // NamingFKConventionAdvisor is the advisor checking for foreign key naming convention.
@@ -43,7 +45,8 @@ func (check *NamingFKConventionAdvisor) Check(ctx advisor.Context, statement str
 		format:       format,
 		templateList: templateList,
 	}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -60,11 +63,12 @@ func (check *NamingFKConventionAdvisor) Check(ctx advisor.Context, statement str
 }
 
 type namingFKConventionChecker struct {
-	adviceList   []advisor.Advice
-	level        advisor.Status
-	title        string
-	format       string
-	templateList []string
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	format         string
+	templateList   []string
 }
 
 // Enter implements the ast.Visitor interface
@@ -75,19 +79,21 @@ func (checker *namingFKConventionChecker) Enter(in ast.Node) (ast.Node, bool) {
 		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
 		if err != nil {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.Internal,
-				Title:   "Internal error for foreign key naming convention rule",
-				Content: fmt.Sprintf("%q meet internal error %q", in.Text(), err.Error()),
+				Status:         checker.level,
+				Code:           advisor.Internal,
+				Title:          "Internal error for foreign key naming convention rule",
+				Content:        fmt.Sprintf("%q meet internal error %q", in.Text(), err.Error()),
+				StatementIndex: checker.statementIndex,
 			})
 			continue
 		}
 		if !regex.MatchString(indexData.indexName) {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.NamingFKConventionMismatch,
-				Title:   checker.title,
-				Content: fmt.Sprintf("Foreign key in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+				Status:         checker.level,
+				Code:           advisor.NamingFKConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Foreign key in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+				StatementIndex: checker.statementIndex,
 			})
 		}
 	}
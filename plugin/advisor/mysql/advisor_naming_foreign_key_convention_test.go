@@ -25,10 +25,11 @@ func TestNamingFKConvention(t *testing.T) {
 			Statement: "ALTER TABLE tech_book ADD CONSTRAINT fk_author_id FOREIGN KEY (author_id) REFERENCES author (id)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingFKConventionMismatch,
-					Title:   "naming.index.fk",
-					Content: "Foreign key in table `tech_book` mismatches the naming convention, expect \"^fk_tech_book_author_id_author_id$\" but found `fk_author_id`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingFKConventionMismatch,
+					Title:          "naming.index.fk",
+					Content:        "Foreign key in table `tech_book` mismatches the naming convention, expect \"^fk_tech_book_author_id_author_id$\" but found `fk_author_id`",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -47,10 +48,11 @@ func TestNamingFKConvention(t *testing.T) {
 			Statement: "CREATE TABLE book(id INT, author_id INT, FOREIGN KEY fk_book_author_id (author_id) REFERENCES author (id))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingFKConventionMismatch,
-					Title:   "naming.index.fk",
-					Content: "Foreign key in table `book` mismatches the naming convention, expect \"^fk_book_author_id_author_id$\" but found `fk_book_author_id`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingFKConventionMismatch,
+					Title:          "naming.index.fk",
+					Content:        "Foreign key in table `book` mismatches the naming convention, expect \"^fk_book_author_id_author_id$\" but found `fk_book_author_id`",
+					StatementIndex: 1,
 				},
 			},
 		},
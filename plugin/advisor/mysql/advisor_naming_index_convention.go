@@ -19,6 +19,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLNamingIndexConvention, &NamingIndexConventionAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLNamingIndexConvention, &NamingIndexConventionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNamingIndexConvention, &NamingIndexConventionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNamingIndexConvention, &NamingIndexConventionAdvisor{})
 }
 
 // NamingIndexConventionAdvisor is the advisor checking for index naming convention.
@@ -48,7 +50,8 @@ func (check *NamingIndexConventionAdvisor) Check(ctx advisor.Context, statement
 		templateList: templateList,
 		catalog:      ctx.Catalog,
 	}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -65,12 +68,13 @@ func (check *NamingIndexConventionAdvisor) Check(ctx advisor.Context, statement
 }
 
 type namingIndexConventionChecker struct {
-	adviceList   []advisor.Advice
-	level        advisor.Status
-	title        string
-	format       string
-	templateList []string
-	catalog      catalog.Catalog
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	format         string
+	templateList   []string
+	catalog        catalog.Catalog
 }
 
 // Enter implements the ast.Visitor interface
@@ -81,19 +85,21 @@ func (checker *namingIndexConventionChecker) Enter(in ast.Node) (ast.Node, bool)
 		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
 		if err != nil {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.Internal,
-				Title:   "Internal error for index naming convention rule",
-				Content: fmt.Sprintf("%q meet internal error %q", in.Text(), err.Error()),
+				Status:         checker.level,
+				Code:           advisor.Internal,
+				Title:          "Internal error for index naming convention rule",
+				Content:        fmt.Sprintf("%q meet internal error %q", in.Text(), err.Error()),
+				StatementIndex: checker.statementIndex,
 			})
 			continue
 		}
 		if !regex.MatchString(indexData.indexName) {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.NamingIndexConventionMismatch,
-				Title:   checker.title,
-				Content: fmt.Sprintf("Index in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+				Status:         checker.level,
+				Code:           advisor.NamingIndexConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Index in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+				StatementIndex: checker.statementIndex,
 			})
 		}
 	}
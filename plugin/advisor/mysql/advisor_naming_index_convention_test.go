@@ -27,10 +27,11 @@ func TestNamingIndexConvention(t *testing.T) {
 			Statement: "CREATE INDEX tech_book_id_name ON tech_book(id, name)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingIndexConventionMismatch,
-					Title:   "naming.index.idx",
-					Content: "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found `tech_book_id_name`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingIndexConventionMismatch,
+					Title:          "naming.index.idx",
+					Content:        "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found `tech_book_id_name`",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -56,10 +57,11 @@ func TestNamingIndexConvention(t *testing.T) {
 			),
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingIndexConventionMismatch,
-					Title:   "naming.index.idx",
-					Content: "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found `idx_tech_book`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingIndexConventionMismatch,
+					Title:          "naming.index.idx",
+					Content:        "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found `idx_tech_book`",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -78,10 +80,11 @@ func TestNamingIndexConvention(t *testing.T) {
 			Statement: "ALTER TABLE tech_book ADD INDEX tech_book_id_name (id, name)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingIndexConventionMismatch,
-					Title:   "naming.index.idx",
-					Content: "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found `tech_book_id_name`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingIndexConventionMismatch,
+					Title:          "naming.index.idx",
+					Content:        "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found `tech_book_id_name`",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -100,10 +103,11 @@ func TestNamingIndexConvention(t *testing.T) {
 			Statement: "CREATE TABLE tech_book(id INT PRIMARY KEY, name VARCHAR(20), INDEX (name))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingIndexConventionMismatch,
-					Title:   "naming.index.idx",
-					Content: "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_name$\" but found ``",
+					Status:         advisor.Error,
+					Code:           advisor.NamingIndexConventionMismatch,
+					Title:          "naming.index.idx",
+					Content:        "Index in table `tech_book` mismatches the naming convention, expect \"^idx_tech_book_name$\" but found ``",
+					StatementIndex: 1,
 				},
 			},
 		},
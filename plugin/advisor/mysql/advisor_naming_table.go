@@ -15,6 +15,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLNamingTableConvention, &NamingTableConventionAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLNamingTableConvention, &NamingTableConventionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNamingTableConvention, &NamingTableConventionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNamingTableConvention, &NamingTableConventionAdvisor{})
 }
 
 // NamingTableConventionAdvisor is the advisor checking for table naming convention.
@@ -41,7 +43,8 @@ func (adv *NamingTableConventionAdvisor) Check(ctx advisor.Context, statement st
 		title:  string(ctx.Rule.Type),
 		format: format,
 	}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -57,10 +60,11 @@ func (adv *NamingTableConventionAdvisor) Check(ctx advisor.Context, statement st
 }
 
 type namingTableConventionChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
-	format     *regexp.Regexp
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	format         *regexp.Regexp
 }
 
 // Enter implements the ast.Visitor interface
@@ -89,10 +93,11 @@ func (v *namingTableConventionChecker) Enter(in ast.Node) (ast.Node, bool) {
 	for _, tableName := range tableNames {
 		if !v.format.MatchString(tableName) {
 			v.adviceList = append(v.adviceList, advisor.Advice{
-				Status:  v.level,
-				Code:    advisor.NamingTableConventionMismatch,
-				Title:   v.title,
-				Content: fmt.Sprintf("`%s` mismatches table naming convention, naming format should be %q", tableName, v.format),
+				Status:         v.level,
+				Code:           advisor.NamingTableConventionMismatch,
+				Title:          v.title,
+				Content:        fmt.Sprintf("`%s` mismatches table naming convention, naming format should be %q", tableName, v.format),
+				StatementIndex: v.statementIndex,
 			})
 		}
 	}
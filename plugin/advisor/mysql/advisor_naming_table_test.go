@@ -14,10 +14,11 @@ func TestMySQLNamingTableConvention(t *testing.T) {
 			Statement: "CREATE TABLE techBook(id int, name varchar(255))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "`techBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "`techBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -36,10 +37,11 @@ func TestMySQLNamingTableConvention(t *testing.T) {
 			Statement: "ALTER TABLE techBook RENAME TO TechBook",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "`TechBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "`TechBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -58,10 +60,11 @@ func TestMySQLNamingTableConvention(t *testing.T) {
 			Statement: "RENAME TABLE techBook TO tech_book, literaryBook TO LiteraryBook",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "`LiteraryBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "`LiteraryBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -69,16 +72,18 @@ func TestMySQLNamingTableConvention(t *testing.T) {
 			Statement: "RENAME TABLE techBook TO TechBook, literaryBook TO LiteraryBook",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "`TechBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "`TechBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "`LiteraryBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "`LiteraryBook` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -18,6 +18,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLNamingUKConvention, &NamingUKConventionAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLNamingUKConvention, &NamingUKConventionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNamingUKConvention, &NamingUKConventionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNamingUKConvention, &NamingUKConventionAdvisor{})
 }
 
 // NamingUKConventionAdvisor is the advisor checking for unique key naming convention.
@@ -47,7 +49,8 @@ func (check *NamingUKConventionAdvisor) Check(ctx advisor.Context, statement str
 		templateList: templateList,
 		catalog:      ctx.Catalog,
 	}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -63,12 +66,13 @@ func (check *NamingUKConventionAdvisor) Check(ctx advisor.Context, statement str
 }
 
 type namingUKConventionChecker struct {
-	adviceList   []advisor.Advice
-	level        advisor.Status
-	title        string
-	format       string
-	templateList []string
-	catalog      catalog.Catalog
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	format         string
+	templateList   []string
+	catalog        catalog.Catalog
 }
 
 // Enter implements the ast.Visitor interface
@@ -79,19 +83,21 @@ func (checker *namingUKConventionChecker) Enter(in ast.Node) (ast.Node, bool) {
 		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
 		if err != nil {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.Internal,
-				Title:   "Internal error for unique key naming convention rule",
-				Content: fmt.Sprintf("%q meet internal error %q", in.Text(), err.Error()),
+				Status:         checker.level,
+				Code:           advisor.Internal,
+				Title:          "Internal error for unique key naming convention rule",
+				Content:        fmt.Sprintf("%q meet internal error %q", in.Text(), err.Error()),
+				StatementIndex: checker.statementIndex,
 			})
 			continue
 		}
 		if !regex.MatchString(indexData.indexName) {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.NamingUKConventionMismatch,
-				Title:   checker.title,
-				Content: fmt.Sprintf("Unique key in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+				Status:         checker.level,
+				Code:           advisor.NamingUKConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Unique key in table `%s` mismatches the naming convention, expect %q but found `%s`", indexData.tableName, regex, indexData.indexName),
+				StatementIndex: checker.statementIndex,
 			})
 		}
 	}
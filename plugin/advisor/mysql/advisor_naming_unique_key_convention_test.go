@@ -27,10 +27,11 @@ func TestNamingUKConvention(t *testing.T) {
 			Statement: "CREATE UNIQUE INDEX tech_book_id_name ON tech_book(id, name)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingUKConventionMismatch,
-					Title:   "naming.index.uk",
-					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found `tech_book_id_name`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found `tech_book_id_name`",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -49,10 +50,11 @@ func TestNamingUKConvention(t *testing.T) {
 			Statement: "ALTER TABLE tech_book ADD UNIQUE tech_book_id_name (id, name)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingUKConventionMismatch,
-					Title:   "naming.index.uk",
-					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found `tech_book_id_name`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found `tech_book_id_name`",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -78,10 +80,11 @@ func TestNamingUKConvention(t *testing.T) {
 			),
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingUKConventionMismatch,
-					Title:   "naming.index.uk",
-					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found `uk_tech_book`",
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found `uk_tech_book`",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -100,10 +103,11 @@ func TestNamingUKConvention(t *testing.T) {
 			Statement: "CREATE TABLE tech_book(id INT PRIMARY KEY, name VARCHAR(20), UNIQUE KEY (name))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingUKConventionMismatch,
-					Title:   "naming.index.uk",
-					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_name$\" but found ``",
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_name$\" but found ``",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -111,10 +115,11 @@ func TestNamingUKConvention(t *testing.T) {
 			Statement: "CREATE TABLE tech_book(id INT PRIMARY KEY, name VARCHAR(20), UNIQUE INDEX (name))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingUKConventionMismatch,
-					Title:   "naming.index.uk",
-					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_name$\" but found ``",
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_name$\" but found ``",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -122,10 +127,11 @@ func TestNamingUKConvention(t *testing.T) {
 			Statement: "CREATE TABLE tech_book(id INT PRIMARY KEY, name VARCHAR(20), UNIQUE KEY (name))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingUKConventionMismatch,
-					Title:   "naming.index.uk",
-					Content: "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_name$\" but found ``",
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table `tech_book` mismatches the naming convention, expect \"^uk_tech_book_name$\" but found ``",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -19,6 +19,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLNoLeadingWildcardLike, &NoLeadingWildcardLikeAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLNoLeadingWildcardLike, &NoLeadingWildcardLikeAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNoLeadingWildcardLike, &NoLeadingWildcardLikeAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNoLeadingWildcardLike, &NoLeadingWildcardLikeAdvisor{})
 }
 
 // NoLeadingWildcardLikeAdvisor is the advisor checking for no leading wildcard LIKE.
@@ -38,17 +40,19 @@ func (adv *NoLeadingWildcardLikeAdvisor) Check(ctx advisor.Context, statement st
 	}
 
 	checker := &noLeadingWildcardLikeChecker{level: level}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		checker.text = stmtNode.Text()
 		checker.leadingWildcardLike = false
 		(stmtNode).Accept(checker)
 
 		if checker.leadingWildcardLike {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.StatementLeadingWildcardLike,
-				Title:   string(ctx.Rule.Type),
-				Content: fmt.Sprintf("\"%s\" uses leading wildcard LIKE", checker.text),
+				Status:         checker.level,
+				Code:           advisor.StatementLeadingWildcardLike,
+				Title:          string(ctx.Rule.Type),
+				Content:        fmt.Sprintf("\"%s\" uses leading wildcard LIKE", checker.text),
+				StatementIndex: checker.statementIndex,
 			})
 		}
 	}
@@ -68,6 +72,7 @@ func (adv *NoLeadingWildcardLikeAdvisor) Check(ctx advisor.Context, statement st
 type noLeadingWildcardLikeChecker struct {
 	adviceList          []advisor.Advice
 	level               advisor.Status
+	statementIndex      int
 	text                string
 	leadingWildcardLike bool
 }
@@ -78,10 +83,11 @@ func (v *noLeadingWildcardLikeChecker) Enter(in ast.Node) (ast.Node, bool) {
 		pattern, err := restoreNode(node.Pattern, format.RestoreStringWithoutCharset)
 		if err != nil {
 			v.adviceList = append(v.adviceList, advisor.Advice{
-				Status:  v.level,
-				Code:    advisor.Internal,
-				Title:   "Internal error for no leading wildcard LIKE rule",
-				Content: fmt.Sprintf("\"%s\" meet internal error %q", v.text, err.Error()),
+				Status:         v.level,
+				Code:           advisor.Internal,
+				Title:          "Internal error for no leading wildcard LIKE rule",
+				Content:        fmt.Sprintf("\"%s\" meet internal error %q", v.text, err.Error()),
+				StatementIndex: v.statementIndex,
 			})
 		}
 		if len(pattern) > 0 && pattern[:1] == wildcard {
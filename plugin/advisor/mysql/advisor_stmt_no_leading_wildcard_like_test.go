@@ -23,10 +23,11 @@ func TestNoLeadingWildcardLike(t *testing.T) {
 			Statement: "SELECT * FROM t WHERE a LIKE '%abc'",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.StatementLeadingWildcardLike,
-					Title:   "statement.where.no-leading-wildcard-like",
-					Content: "\"SELECT * FROM t WHERE a LIKE '%abc'\" uses leading wildcard LIKE",
+					Status:         advisor.Error,
+					Code:           advisor.StatementLeadingWildcardLike,
+					Title:          "statement.where.no-leading-wildcard-like",
+					Content:        "\"SELECT * FROM t WHERE a LIKE '%abc'\" uses leading wildcard LIKE",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -34,10 +35,11 @@ func TestNoLeadingWildcardLike(t *testing.T) {
 			Statement: "SELECT * FROM t WHERE a LIKE 'abc' OR a LIKE '%abc'",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.StatementLeadingWildcardLike,
-					Title:   "statement.where.no-leading-wildcard-like",
-					Content: "\"SELECT * FROM t WHERE a LIKE 'abc' OR a LIKE '%abc'\" uses leading wildcard LIKE",
+					Status:         advisor.Error,
+					Code:           advisor.StatementLeadingWildcardLike,
+					Title:          "statement.where.no-leading-wildcard-like",
+					Content:        "\"SELECT * FROM t WHERE a LIKE 'abc' OR a LIKE '%abc'\" uses leading wildcard LIKE",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -45,10 +47,11 @@ func TestNoLeadingWildcardLike(t *testing.T) {
 			Statement: "SELECT * FROM t WHERE a LIKE '%acc' OR a LIKE '%abc'",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.StatementLeadingWildcardLike,
-					Title:   "statement.where.no-leading-wildcard-like",
-					Content: "\"SELECT * FROM t WHERE a LIKE '%acc' OR a LIKE '%abc'\" uses leading wildcard LIKE",
+					Status:         advisor.Error,
+					Code:           advisor.StatementLeadingWildcardLike,
+					Title:          "statement.where.no-leading-wildcard-like",
+					Content:        "\"SELECT * FROM t WHERE a LIKE '%acc' OR a LIKE '%abc'\" uses leading wildcard LIKE",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -56,10 +59,11 @@ func TestNoLeadingWildcardLike(t *testing.T) {
 			Statement: "SELECT * FROM (SELECT * FROM t WHERE a LIKE '%acc' OR a LIKE '%abc') t1",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.StatementLeadingWildcardLike,
-					Title:   "statement.where.no-leading-wildcard-like",
-					Content: "\"SELECT * FROM (SELECT * FROM t WHERE a LIKE '%acc' OR a LIKE '%abc') t1\" uses leading wildcard LIKE",
+					Status:         advisor.Error,
+					Code:           advisor.StatementLeadingWildcardLike,
+					Title:          "statement.where.no-leading-wildcard-like",
+					Content:        "\"SELECT * FROM (SELECT * FROM t WHERE a LIKE '%acc' OR a LIKE '%abc') t1\" uses leading wildcard LIKE",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -14,6 +14,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLNoSelectAll, &NoSelectAllAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLNoSelectAll, &NoSelectAllAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNoSelectAll, &NoSelectAllAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNoSelectAll, &NoSelectAllAdvisor{})
 }
 
 // NoSelectAllAdvisor is the advisor checking for no "select *".
@@ -35,7 +37,8 @@ func (adv *NoSelectAllAdvisor) Check(ctx advisor.Context, statement string) ([]a
 		level: level,
 		title: string(ctx.Rule.Type),
 	}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		checker.text = stmtNode.Text()
 		(stmtNode).Accept(checker)
 	}
@@ -52,10 +55,11 @@ func (adv *NoSelectAllAdvisor) Check(ctx advisor.Context, statement string) ([]a
 }
 
 type noSelectAllChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
-	text       string
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	text           string
 }
 
 // Enter implements the ast.Visitor interface
@@ -64,10 +68,11 @@ func (v *noSelectAllChecker) Enter(in ast.Node) (ast.Node, bool) {
 		for _, field := range node.Fields.Fields {
 			if field.WildCard != nil {
 				v.adviceList = append(v.adviceList, advisor.Advice{
-					Status:  v.level,
-					Code:    advisor.StatementSelectAll,
-					Title:   v.title,
-					Content: fmt.Sprintf("\"%s\" uses SELECT all", v.text),
+					Status:         v.level,
+					Code:           advisor.StatementSelectAll,
+					Title:          v.title,
+					Content:        fmt.Sprintf("\"%s\" uses SELECT all", v.text),
+					StatementIndex: v.statementIndex,
 				})
 				break
 			}
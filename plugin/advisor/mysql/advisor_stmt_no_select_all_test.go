@@ -12,10 +12,11 @@ func TestNoSelectAll(t *testing.T) {
 			Statement: "SELECT * FROM t",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.StatementSelectAll,
-					Title:   "statement.select.no-select-all",
-					Content: "\"SELECT * FROM t\" uses SELECT all",
+					Status:         advisor.Error,
+					Code:           advisor.StatementSelectAll,
+					Title:          "statement.select.no-select-all",
+					Content:        "\"SELECT * FROM t\" uses SELECT all",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -34,10 +35,11 @@ func TestNoSelectAll(t *testing.T) {
 			Statement: "SELECT a, b FROM (SELECT * from t1 JOIN t2) t",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.StatementSelectAll,
-					Title:   "statement.select.no-select-all",
-					Content: "\"SELECT a, b FROM (SELECT * from t1 JOIN t2) t\" uses SELECT all",
+					Status:         advisor.Error,
+					Code:           advisor.StatementSelectAll,
+					Title:          "statement.select.no-select-all",
+					Content:        "\"SELECT a, b FROM (SELECT * from t1 JOIN t2) t\" uses SELECT all",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -15,6 +15,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLWhereRequirement, &WhereRequirementAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLWhereRequirement, &WhereRequirementAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLWhereRequirement, &WhereRequirementAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLWhereRequirement, &WhereRequirementAdvisor{})
 }
 
 // WhereRequirementAdvisor is the advisor checking for the WHERE clause requirement.
@@ -36,7 +38,8 @@ func (adv *WhereRequirementAdvisor) Check(ctx advisor.Context, statement string)
 		level: level,
 		title: string(ctx.Rule.Type),
 	}
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		checker.text = stmtNode.Text()
 		(stmtNode).Accept(checker)
 	}
@@ -53,10 +56,11 @@ func (adv *WhereRequirementAdvisor) Check(ctx advisor.Context, statement string)
 }
 
 type whereRequirementChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
-	text       string
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	text           string
 }
 
 // Enter implements the ast.Visitor interface
@@ -82,10 +86,11 @@ func (v *whereRequirementChecker) Enter(in ast.Node) (ast.Node, bool) {
 
 	if code != advisor.Ok {
 		v.adviceList = append(v.adviceList, advisor.Advice{
-			Status:  v.level,
-			Code:    code,
-			Title:   v.title,
-			Content: fmt.Sprintf("\"%s\" requires WHERE clause", v.text),
+			Status:         v.level,
+			Code:           code,
+			Title:          v.title,
+			Content:        fmt.Sprintf("\"%s\" requires WHERE clause", v.text),
+			StatementIndex: v.statementIndex,
 		})
 	}
 	return in, false
@@ -12,10 +12,11 @@ func TestWhereRequirement(t *testing.T) {
 			Statement: "DELETE FROM t1",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.StatementNoWhere,
-					Title:   "statement.where.require",
-					Content: "\"DELETE FROM t1\" requires WHERE clause",
+					Status:         advisor.Warn,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"DELETE FROM t1\" requires WHERE clause",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -23,10 +24,11 @@ func TestWhereRequirement(t *testing.T) {
 			Statement: "UPDATE t1 SET a = 1",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.StatementNoWhere,
-					Title:   "statement.where.require",
-					Content: "\"UPDATE t1 SET a = 1\" requires WHERE clause",
+					Status:         advisor.Warn,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"UPDATE t1 SET a = 1\" requires WHERE clause",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -56,10 +58,11 @@ func TestWhereRequirement(t *testing.T) {
 			Statement: "SELECT a FROM t",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.StatementNoWhere,
-					Title:   "statement.where.require",
-					Content: "\"SELECT a FROM t\" requires WHERE clause",
+					Status:         advisor.Warn,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"SELECT a FROM t\" requires WHERE clause",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -78,10 +81,11 @@ func TestWhereRequirement(t *testing.T) {
 			Statement: "SELECT a FROM t WHERE a > (SELECT max(id) FROM user)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.StatementNoWhere,
-					Title:   "statement.where.require",
-					Content: "\"SELECT a FROM t WHERE a > (SELECT max(id) FROM user)\" requires WHERE clause",
+					Status:         advisor.Warn,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"SELECT a FROM t WHERE a > (SELECT max(id) FROM user)\" requires WHERE clause",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -11,6 +11,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLSyntax, &SyntaxAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLSyntax, &SyntaxAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLSyntax, &SyntaxAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLSyntax, &SyntaxAdvisor{})
 }
 
 // SyntaxAdvisor is the advisor for checking syntax.
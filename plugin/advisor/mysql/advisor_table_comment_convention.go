@@ -0,0 +1,132 @@
+package mysql
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*TableCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLTableCommentConvention, &TableCommentConventionAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLTableCommentConvention, &TableCommentConventionAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLTableCommentConvention, &TableCommentConventionAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLTableCommentConvention, &TableCommentConventionAdvisor{})
+}
+
+// TableCommentConventionAdvisor is the advisor checking for table comment convention.
+type TableCommentConventionAdvisor struct {
+}
+
+// Check checks for table comment convention.
+func (adv *TableCommentConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &tableCommentConventionChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		required:  payload.Required,
+		maxLength: payload.MaxLength,
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type tableCommentConventionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	required       bool
+	maxLength      int
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *tableCommentConventionChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		// CREATE TABLE ... LIKE copies the comment.
+		if node.ReferTable != nil {
+			break
+		}
+		comment, exists := getTableComment(node.Options)
+		checker.checkComment(node.Table.Name.O, comment, exists)
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		for _, spec := range node.Specs {
+			// TABLE OPTION
+			if spec.Tp == ast.AlterTableOption {
+				if comment, exists := getTableComment(spec.Options); exists {
+					checker.checkComment(node.Table.Name.O, comment, exists)
+				}
+			}
+		}
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *tableCommentConventionChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (checker *tableCommentConventionChecker) checkComment(table string, comment string, exists bool) {
+	if checker.required && (!exists || comment == "") {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.NoTableComment,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("Table `%s` requires comments", table),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+	if checker.maxLength > 0 && len([]rune(comment)) > checker.maxLength {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.TableCommentTooLong,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("The length of table `%s` comment should be within %d characters", table, checker.maxLength),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+}
+
+// getTableComment returns the comment in the table options, and false if there is no comment option.
+func getTableComment(options []*ast.TableOption) (string, bool) {
+	for _, option := range options {
+		if option.Tp == ast.TableOptionComment {
+			return option.StrValue, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,259 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/pingcap/tidb/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*NoDuplicateIndexAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.MySQL, advisor.MySQLNoDuplicateIndex, &NoDuplicateIndexAdvisor{})
+	advisor.Register(advisor.TiDB, advisor.MySQLNoDuplicateIndex, &NoDuplicateIndexAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLNoDuplicateIndex, &NoDuplicateIndexAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLNoDuplicateIndex, &NoDuplicateIndexAdvisor{})
+}
+
+// NoDuplicateIndexAdvisor is the advisor checking for no duplicate index.
+type NoDuplicateIndexAdvisor struct {
+}
+
+// Check checks for no duplicate index.
+func (adv *NoDuplicateIndexAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	root, errAdvice := parseStatement(statement, ctx.Charset, ctx.Collation)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &noDuplicateIndexChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		tables:  make(map[string][]*indexColumns),
+		catalog: ctx.Catalog,
+	}
+
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
+		(stmtNode).Accept(checker)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+// indexColumns is the index with its ordered column list.
+type indexColumns struct {
+	name       string
+	unique     bool
+	columnList []string
+}
+
+type noDuplicateIndexChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	// tables is the map from table name to its indexes, including the existing ones in the catalog.
+	tables  map[string][]*indexColumns
+	catalog catalog.Catalog
+}
+
+// Enter implements the ast.Visitor interface
+func (checker *noDuplicateIndexChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		table := node.Table.Name.String()
+		checker.tables[table] = nil
+		for _, column := range node.Cols {
+			for _, option := range column.Options {
+				switch option.Tp {
+				case ast.ColumnOptionPrimaryKey:
+					checker.addIndex(table, &indexColumns{name: primaryKeyName, unique: true, columnList: []string{column.Name.Name.String()}})
+				case ast.ColumnOptionUniqKey:
+					checker.addIndex(table, &indexColumns{name: column.Name.Name.String(), unique: true, columnList: []string{column.Name.Name.String()}})
+				}
+			}
+		}
+		for _, constraint := range node.Constraints {
+			if index := convertConstraintToIndex(constraint); index != nil {
+				checker.addIndex(table, index)
+			}
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range node.Tables {
+			checker.tables[table.Name.String()] = nil
+		}
+	// ALTER TABLE
+	case *ast.AlterTableStmt:
+		table := node.Table.Name.String()
+		for _, spec := range node.Specs {
+			switch spec.Tp {
+			// ADD CONSTRAINT
+			case ast.AlterTableAddConstraint:
+				if index := convertConstraintToIndex(spec.Constraint); index != nil {
+					checker.loadIndexList(table)
+					checker.addIndex(table, index)
+				}
+			// DROP INDEX
+			case ast.AlterTableDropIndex:
+				checker.loadIndexList(table)
+				checker.dropIndex(table, spec.Name)
+			// DROP PRIMARY KEY
+			case ast.AlterTableDropPrimaryKey:
+				checker.loadIndexList(table)
+				checker.dropIndex(table, primaryKeyName)
+			}
+		}
+	// CREATE INDEX
+	case *ast.CreateIndexStmt:
+		table := node.Table.Name.String()
+		columnList, ok := getIndexColumnList(node.IndexPartSpecifications)
+		if !ok {
+			break
+		}
+		checker.loadIndexList(table)
+		checker.addIndex(table, &indexColumns{
+			name:       node.IndexName,
+			unique:     node.KeyType == ast.IndexKeyTypeUnique,
+			columnList: columnList,
+		})
+	// DROP INDEX
+	case *ast.DropIndexStmt:
+		table := node.Table.Name.String()
+		checker.loadIndexList(table)
+		checker.dropIndex(table, node.IndexName)
+	}
+
+	return in, false
+}
+
+// Leave implements the ast.Visitor interface
+func (checker *noDuplicateIndexChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// loadIndexList loads the existing indexes of the table from the catalog if the table is not seen before.
+func (checker *noDuplicateIndexChecker) loadIndexList(table string) {
+	if _, ok := checker.tables[table]; ok {
+		return
+	}
+	checker.tables[table] = nil
+	if checker.catalog == nil {
+		return
+	}
+	tableInfo, err := checker.catalog.FindTable(context.Background(), &catalog.TableFind{
+		TableName: table,
+	})
+	if err != nil {
+		log.Printf("Cannot find table %s with error %v\n", table, err)
+		return
+	}
+	if tableInfo == nil {
+		return
+	}
+	for _, index := range tableInfo.IndexList {
+		checker.tables[table] = append(checker.tables[table], &indexColumns{
+			name:       index.Name,
+			unique:     index.Unique,
+			columnList: index.ColumnExpressions,
+		})
+	}
+}
+
+// addIndex adds the index to the table, and reports it if there is an index covering it already.
+// A unique index with the same columns as a non-unique index is not duplicate, because it adds the constraint.
+func (checker *noDuplicateIndexChecker) addIndex(table string, index *indexColumns) {
+	for _, existing := range checker.tables[table] {
+		if (existing.unique || !index.unique) && isSameColumnList(existing.columnList, index.columnList) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.DuplicateIndex,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Index `%s` in table `%s` duplicates the index `%s` on (%s)", index.name, table, existing.name, strings.Join(index.columnList, ", ")),
+				StatementIndex: checker.statementIndex,
+			})
+			break
+		}
+	}
+	checker.tables[table] = append(checker.tables[table], index)
+}
+
+func (checker *noDuplicateIndexChecker) dropIndex(table string, name string) {
+	var indexList []*indexColumns
+	for _, index := range checker.tables[table] {
+		if !strings.EqualFold(index.name, name) {
+			indexList = append(indexList, index)
+		}
+	}
+	checker.tables[table] = indexList
+}
+
+// convertConstraintToIndex returns the index created by the constraint, or nil if the constraint doesn't create an index on columns.
+func convertConstraintToIndex(constraint *ast.Constraint) *indexColumns {
+	name := constraint.Name
+	unique := false
+	switch constraint.Tp {
+	case ast.ConstraintPrimaryKey:
+		name = primaryKeyName
+		unique = true
+	case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+		unique = true
+	case ast.ConstraintIndex, ast.ConstraintKey:
+	default:
+		return nil
+	}
+	columnList, ok := getIndexColumnList(constraint.Keys)
+	if !ok {
+		return nil
+	}
+	return &indexColumns{
+		name:       name,
+		unique:     unique,
+		columnList: columnList,
+	}
+}
+
+// getIndexColumnList returns the column names of the index, and false if the index has expressions.
+func getIndexColumnList(keyList []*ast.IndexPartSpecification) ([]string, bool) {
+	var columnList []string
+	for _, key := range keyList {
+		if key.Column == nil {
+			return nil, false
+		}
+		columnList = append(columnList, key.Column.Name.String())
+	}
+	return columnList, true
+}
+
+func isSameColumnList(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
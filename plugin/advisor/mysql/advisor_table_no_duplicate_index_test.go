@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestNoDuplicateIndex(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(id INT PRIMARY KEY, name VARCHAR(255), INDEX idx_t_name(name))",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id INT PRIMARY KEY, name VARCHAR(255), INDEX idx_t_name(name), KEY idx_t_name_2(name))",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DuplicateIndex,
+					Title:          "table.no-duplicate-index",
+					Content:        "Index `idx_t_name_2` in table `t` duplicates the index `idx_t_name` on (name)",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id INT, name VARCHAR(255), INDEX idx_t_id(id), PRIMARY KEY (id))",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE INDEX idx_tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DuplicateIndex,
+					Title:          "table.no-duplicate-index",
+					Content:        "Index `idx_tech_book_id_name` in table `tech_book` duplicates the index `old_index` on (id, name)",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE INDEX idx_tech_book_name_id ON tech_book(name, id)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE tech_book DROP INDEX old_index, DROP INDEX old_uk, DROP PRIMARY KEY; ALTER TABLE tech_book ADD INDEX idx_tech_book_id_name(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t ADD INDEX idx_t_a(a); CREATE UNIQUE INDEX uk_t_a ON t(a); ALTER TABLE t ADD UNIQUE uk_t_a_2(a)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DuplicateIndex,
+					Title:          "table.no-duplicate-index",
+					Content:        "Index `uk_t_a_2` in table `t` duplicates the index `uk_t_a` on (a)",
+					StatementIndex: 3,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &NoDuplicateIndexAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleTableNoDuplicateIndex,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
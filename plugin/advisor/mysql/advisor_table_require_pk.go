@@ -22,6 +22,8 @@ var (
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLTableRequirePK, &TableRequirePKAdvisor{})
 	advisor.Register(advisor.TiDB, advisor.MySQLTableRequirePK, &TableRequirePKAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLTableRequirePK, &TableRequirePKAdvisor{})
+	advisor.Register(advisor.OceanBase, advisor.MySQLTableRequirePK, &TableRequirePKAdvisor{})
 }
 
 // TableRequirePKAdvisor is the advisor checking table requires PK.
@@ -40,13 +42,15 @@ func (adv *TableRequirePKAdvisor) Check(ctx advisor.Context, statement string) (
 		return nil, err
 	}
 	checker := &tableRequirePKChecker{
-		level:   level,
-		title:   string(ctx.Rule.Type),
-		tables:  make(tablePK),
-		catalog: ctx.Catalog,
+		level:               level,
+		title:               string(ctx.Rule.Type),
+		tables:              make(tablePK),
+		tableStatementIndex: make(map[string]int),
+		catalog:             ctx.Catalog,
 	}
 
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -54,11 +58,14 @@ func (adv *TableRequirePKAdvisor) Check(ctx advisor.Context, statement string) (
 }
 
 type tableRequirePKChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
-	tables     tablePK
-	catalog    catalog.Catalog
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	tables         tablePK
+	// tableStatementIndex is the index of the last statement changing the table, and the advice is for it.
+	tableStatementIndex map[string]int
+	catalog             catalog.Catalog
 }
 
 // Enter implements the ast.Visitor interface
@@ -66,6 +73,7 @@ func (v *tableRequirePKChecker) Enter(in ast.Node) (ast.Node, bool) {
 	switch node := in.(type) {
 	// CREATE TABLE
 	case *ast.CreateTableStmt:
+		v.tableStatementIndex[node.Table.Name.String()] = v.statementIndex
 		v.createTable(node)
 	// DROP TABLE
 	case *ast.DropTableStmt:
@@ -75,6 +83,7 @@ func (v *tableRequirePKChecker) Enter(in ast.Node) (ast.Node, bool) {
 	// ALTER TABLE
 	case *ast.AlterTableStmt:
 		tableName := node.Table.Name.O
+		v.tableStatementIndex[tableName] = v.statementIndex
 		for _, spec := range node.Specs {
 			switch spec.Tp {
 			// ADD CONSTRAINT
@@ -120,10 +129,11 @@ func (v *tableRequirePKChecker) generateAdviceList() []advisor.Advice {
 	for _, tableName := range tableList {
 		if len(v.tables[tableName]) == 0 {
 			v.adviceList = append(v.adviceList, advisor.Advice{
-				Status:  v.level,
-				Code:    advisor.TableNoPK,
-				Title:   v.title,
-				Content: fmt.Sprintf("Table `%s` requires PRIMARY KEY", tableName),
+				Status:         v.level,
+				Code:           advisor.TableNoPK,
+				Title:          v.title,
+				Content:        fmt.Sprintf("Table `%s` requires PRIMARY KEY", tableName),
+				StatementIndex: v.tableStatementIndex[tableName],
 			})
 		}
 	}
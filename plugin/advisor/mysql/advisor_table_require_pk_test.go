@@ -34,10 +34,11 @@ func TestRequirePK(t *testing.T) {
 			Statement: "CREATE TABLE t(id INT)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.TableNoPK,
-					Title:   "table.require-pk",
-					Content: "Table `t` requires PRIMARY KEY",
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table `t` requires PRIMARY KEY",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -70,10 +71,11 @@ func TestRequirePK(t *testing.T) {
 						ALTER TABLE t DROP PRIMARY KEY`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.TableNoPK,
-					Title:   "table.require-pk",
-					Content: "Table `t` requires PRIMARY KEY",
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table `t` requires PRIMARY KEY",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -82,10 +84,11 @@ func TestRequirePK(t *testing.T) {
 				"ALTER TABLE t DROP INDEX `PRIMARY`",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.TableNoPK,
-					Title:   "table.require-pk",
-					Content: "Table `t` requires PRIMARY KEY",
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table `t` requires PRIMARY KEY",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -166,10 +169,11 @@ func TestRequirePK(t *testing.T) {
 						ALTER TABLE t DROP COLUMN id, DROP COLUMN name`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.TableNoPK,
-					Title:   "table.require-pk",
-					Content: "Table `t` requires PRIMARY KEY",
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table `t` requires PRIMARY KEY",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -178,10 +182,11 @@ func TestRequirePK(t *testing.T) {
 			Statement: `ALTER TABLE t DROP COLUMN id, DROP COLUMN name`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.TableNoPK,
-					Title:   "table.require-pk",
-					Content: "Table `t` requires PRIMARY KEY",
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table `t` requires PRIMARY KEY",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -203,10 +208,11 @@ func TestRequirePK(t *testing.T) {
 						ALTER TABLE t DROP COLUMN uid, DROP COLUMN name`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.TableNoPK,
-					Title:   "table.require-pk",
-					Content: "Table `t` requires PRIMARY KEY",
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table `t` requires PRIMARY KEY",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -20,6 +20,7 @@ var (
 
 func init() {
 	advisor.Register(advisor.MySQL, advisor.MySQLUseInnoDB, &UseInnoDBAdvisor{})
+	advisor.Register(advisor.MariaDB, advisor.MySQLUseInnoDB, &UseInnoDBAdvisor{})
 }
 
 // UseInnoDBAdvisor is the advisor checking for using InnoDB engine.
@@ -43,7 +44,8 @@ func (adv *UseInnoDBAdvisor) Check(ctx advisor.Context, statement string) ([]adv
 		title: string(ctx.Rule.Type),
 	}
 
-	for _, stmtNode := range root {
+	for i, stmtNode := range root {
+		checker.statementIndex = i + 1
 		(stmtNode).Accept(checker)
 	}
 
@@ -59,9 +61,10 @@ func (adv *UseInnoDBAdvisor) Check(ctx advisor.Context, statement string) ([]adv
 }
 
 type useInnoDBChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
 }
 
 // Enter implements the ast.Visitor interface
@@ -97,10 +100,11 @@ func (v *useInnoDBChecker) Enter(in ast.Node) (ast.Node, bool) {
 				text, err := restoreNode(variable.Value, format.RestoreNameLowercase)
 				if err != nil {
 					v.adviceList = append(v.adviceList, advisor.Advice{
-						Status:  v.level,
-						Code:    advisor.Internal,
-						Title:   "Internal error for use InnoDB rule",
-						Content: fmt.Sprintf("\"%s\" meet internal error %q", in.Text(), err.Error()),
+						Status:         v.level,
+						Code:           advisor.Internal,
+						Title:          "Internal error for use InnoDB rule",
+						Content:        fmt.Sprintf("\"%s\" meet internal error %q", in.Text(), err.Error()),
+						StatementIndex: v.statementIndex,
 					})
 					continue
 				}
@@ -114,10 +118,11 @@ func (v *useInnoDBChecker) Enter(in ast.Node) (ast.Node, bool) {
 
 	if code != advisor.Ok {
 		v.adviceList = append(v.adviceList, advisor.Advice{
-			Status:  v.level,
-			Code:    code,
-			Title:   v.title,
-			Content: fmt.Sprintf("\"%s\" doesn't use InnoDB engine", in.Text()),
+			Status:         v.level,
+			Code:           code,
+			Title:          v.title,
+			Content:        fmt.Sprintf("\"%s\" doesn't use InnoDB engine", in.Text()),
+			StatementIndex: v.statementIndex,
 		})
 	}
 	return in, false
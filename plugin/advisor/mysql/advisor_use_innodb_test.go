@@ -34,10 +34,11 @@ func TestUseInnoDB(t *testing.T) {
 			Statement: "CREATE TABLE book(id int) ENGINE = CSV",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NotInnoDBEngine,
-					Title:   "engine.mysql.use-innodb",
-					Content: "\"CREATE TABLE book(id int) ENGINE = CSV\" doesn't use InnoDB engine",
+					Status:         advisor.Error,
+					Code:           advisor.NotInnoDBEngine,
+					Title:          "engine.mysql.use-innodb",
+					Content:        "\"CREATE TABLE book(id int) ENGINE = CSV\" doesn't use InnoDB engine",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -56,10 +57,11 @@ func TestUseInnoDB(t *testing.T) {
 			Statement: "ALTER TABLE book ENGINE = CSV",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NotInnoDBEngine,
-					Title:   "engine.mysql.use-innodb",
-					Content: "\"ALTER TABLE book ENGINE = CSV\" doesn't use InnoDB engine",
+					Status:         advisor.Error,
+					Code:           advisor.NotInnoDBEngine,
+					Title:          "engine.mysql.use-innodb",
+					Content:        "\"ALTER TABLE book ENGINE = CSV\" doesn't use InnoDB engine",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -78,10 +80,11 @@ func TestUseInnoDB(t *testing.T) {
 			Statement: "SET default_storage_engine=CSV",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NotInnoDBEngine,
-					Title:   "engine.mysql.use-innodb",
-					Content: "\"SET default_storage_engine=CSV\" doesn't use InnoDB engine",
+					Status:         advisor.Error,
+					Code:           advisor.NotInnoDBEngine,
+					Title:          "engine.mysql.use-innodb",
+					Content:        "\"SET default_storage_engine=CSV\" doesn't use InnoDB engine",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -0,0 +1,81 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CharsetAllowlistAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLCharsetAllowlist, &CharsetAllowlistAdvisor{})
+}
+
+// CharsetAllowlistAdvisor is the advisor checking for the charset allowlist.
+// PostgreSQL sets the charset by the database encoding only.
+type CharsetAllowlistAdvisor struct {
+}
+
+// Check checks for the charset allowlist.
+func (adv *CharsetAllowlistAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &charsetAllowlistChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		allowlist: payload.List,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type charsetAllowlistChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	allowlist      []string
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *charsetAllowlistChecker) Visit(node ast.Node) ast.Visitor {
+	if n, ok := node.(*ast.CreateDatabaseStmt); ok && n.Encoding != "" && !containsFold(checker.allowlist, n.Encoding) {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.DisabledCharset,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("Database %q uses the encoding %q not in the allowlist", n.DatabaseName, n.Encoding),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+
+	return checker
+}
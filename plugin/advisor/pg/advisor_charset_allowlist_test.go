@@ -0,0 +1,58 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharsetAllowlist(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE DATABASE db ENCODING 'utf8'",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE DATABASE db",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE DATABASE db ENCODING 'LATIN1'",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCharset,
+					Title:          "system.charset.allowlist",
+					Content:        "Database \"db\" uses the encoding \"LATIN1\" not in the allowlist",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.StringArrayTypeRulePayload{
+		List: []string{"UTF8"},
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &CharsetAllowlistAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleCharsetAllowlist,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
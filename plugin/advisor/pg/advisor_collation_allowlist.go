@@ -0,0 +1,103 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CollationAllowlistAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLCollationAllowlist, &CollationAllowlistAdvisor{})
+}
+
+// CollationAllowlistAdvisor is the advisor checking for the collation allowlist.
+type CollationAllowlistAdvisor struct {
+}
+
+// Check checks for the collation allowlist.
+func (adv *CollationAllowlistAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &collationAllowlistChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		allowlist: payload.List,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type collationAllowlistChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	allowlist      []string
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *collationAllowlistChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE DATABASE
+	case *ast.CreateDatabaseStmt:
+		checker.checkCollation(fmt.Sprintf("Database %q", n.DatabaseName), n.Collation)
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range n.ColumnList {
+			checker.checkCollation(fmt.Sprintf("Column %q in table %q", column.ColumnName, n.Name.Name), column.Collation)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			checker.checkCollation(fmt.Sprintf("Column %q in table %q", column.ColumnName, n.Table.Name), column.Collation)
+		}
+	// ALTER TABLE ALTER COLUMN TYPE
+	case *ast.AlterColumnTypeStmt:
+		checker.checkCollation(fmt.Sprintf("Column %q in table %q", n.ColumnName, n.Table.Name), n.Collation)
+	}
+
+	return checker
+}
+
+// checkCollation reports the collation not in the allowlist, and the empty collation means the default one.
+func (checker *collationAllowlistChecker) checkCollation(object string, collation string) {
+	if collation == "" || containsFold(checker.allowlist, collation) {
+		return
+	}
+	checker.adviceList = append(checker.adviceList, advisor.Advice{
+		Status:         checker.level,
+		Code:           advisor.DisabledCollation,
+		Title:          checker.title,
+		Content:        fmt.Sprintf("%s uses the collation %q not in the allowlist", object, collation),
+		StatementIndex: checker.statementIndex,
+	})
+}
@@ -0,0 +1,60 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollationAllowlist(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a text COLLATE \"en_US\", b text)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE DATABASE db LC_COLLATE 'C'",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCollation,
+					Title:          "system.collation.allowlist",
+					Content:        "Database \"db\" uses the collation \"C\" not in the allowlist",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a text COLLATE "C";
+				ALTER TABLE tech_book ALTER COLUMN name TYPE text COLLATE "en_US"`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledCollation,
+					Title:          "system.collation.allowlist",
+					Content:        "Column \"a\" in table \"tech_book\" uses the collation \"C\" not in the allowlist",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.StringArrayTypeRulePayload{
+		List: []string{"en_US"},
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &CollationAllowlistAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleCollationAllowlist,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
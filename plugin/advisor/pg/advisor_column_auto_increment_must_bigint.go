@@ -0,0 +1,105 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*AutoIncrementColumnMustBigintAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLAutoIncrementColumnMustUnsignedBigint, &AutoIncrementColumnMustBigintAdvisor{})
+}
+
+// AutoIncrementColumnMustBigintAdvisor is the advisor checking for the BIGINT auto-increment column.
+// PostgreSQL has no unsigned integer types, so we only require BIGINT.
+type AutoIncrementColumnMustBigintAdvisor struct {
+}
+
+// Check checks for the BIGINT auto-increment column.
+func (adv *AutoIncrementColumnMustBigintAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &autoIncrementColumnMustBigintChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type autoIncrementColumnMustBigintChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *autoIncrementColumnMustBigintChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range n.ColumnList {
+			checker.checkColumn(n.Name.Name, column)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			checker.checkColumn(n.Table.Name, column)
+		}
+	}
+
+	return checker
+}
+
+// checkColumn checks the serial column and the identity column.
+func (checker *autoIncrementColumnMustBigintChecker) checkColumn(table string, column *ast.ColumnDef) {
+	if column.Type == nil {
+		return
+	}
+	valid := true
+	switch column.Type.Name {
+	case "smallserial", "serial2", "serial", "serial4":
+		valid = false
+	default:
+		for _, constraint := range column.ConstraintList {
+			if constraint.Type == ast.ConstraintTypeIdentity && column.Type.Name != "int8" {
+				valid = false
+			}
+		}
+	}
+	if !valid {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.AutoIncrementColumnNotUnsignedBigint,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("Auto-increment column %q in table %q requires BIGINT type", column.ColumnName, table),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestAutoIncrementColumnMustBigint(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(id bigserial PRIMARY KEY, b bigint GENERATED ALWAYS AS IDENTITY)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id serial PRIMARY KEY, b int GENERATED BY DEFAULT AS IDENTITY)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.AutoIncrementColumnNotUnsignedBigint,
+					Title:          "column.auto-increment-must-unsigned-bigint",
+					Content:        "Auto-increment column \"id\" in table \"t\" requires BIGINT type",
+					StatementIndex: 1,
+				},
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.AutoIncrementColumnNotUnsignedBigint,
+					Title:          "column.auto-increment-must-unsigned-bigint",
+					Content:        "Auto-increment column \"b\" in table \"t\" requires BIGINT type",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a int;
+				ALTER TABLE tech_book ADD COLUMN b smallserial`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.AutoIncrementColumnNotUnsignedBigint,
+					Title:          "column.auto-increment-must-unsigned-bigint",
+					Content:        "Auto-increment column \"b\" in table \"tech_book\" requires BIGINT type",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &AutoIncrementColumnMustBigintAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnAutoIncrementMustUnsignedBigint,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
@@ -0,0 +1,168 @@
+package pg
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLColumnCommentConvention, &ColumnCommentConventionAdvisor{})
+}
+
+// ColumnCommentConventionAdvisor is the advisor checking for column comment convention.
+type ColumnCommentConventionAdvisor struct {
+}
+
+// Check checks for column comment convention.
+// PostgreSQL comments the column by the COMMENT ON COLUMN statement, so we check the columns added in the statement at the end.
+func (adv *ColumnCommentConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnCommentConventionChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		required:  payload.Required,
+		maxLength: payload.MaxLength,
+		columns:   make(map[columnName]*columnComment),
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+// columnComment is the comment state of the column added in the statement.
+type columnComment struct {
+	commented bool
+	// statementIndex is the index of the statement adding the column, and the advice is for it.
+	statementIndex int
+}
+
+type columnCommentConventionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	required       bool
+	maxLength      int
+	// columns is the columns added in the statement.
+	columns map[columnName]*columnComment
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *columnCommentConventionChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range n.ColumnList {
+			checker.addColumn(n.Name.Name, column.ColumnName)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			checker.addColumn(n.Table.Name, column.ColumnName)
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			for name := range checker.columns {
+				if name.tableName == table.Name {
+					delete(checker.columns, name)
+				}
+			}
+		}
+	// ALTER TABLE DROP COLUMN
+	case *ast.DropColumnStmt:
+		delete(checker.columns, columnName{tableName: n.Table.Name, columnName: n.ColumnName})
+	// ALTER TABLE RENAME COLUMN
+	case *ast.RenameColumnStmt:
+		oldName := columnName{tableName: n.Table.Name, columnName: n.ColumnName}
+		if comment, ok := checker.columns[oldName]; ok {
+			delete(checker.columns, oldName)
+			checker.columns[columnName{tableName: n.Table.Name, columnName: n.NewName}] = comment
+		}
+	// COMMENT ON COLUMN
+	case *ast.CommentStmt:
+		if n.Type != ast.CommentObjectTypeColumn {
+			break
+		}
+		if comment, ok := checker.columns[columnName{tableName: n.Table.Name, columnName: n.ColumnName}]; ok {
+			comment.commented = n.Comment != ""
+		}
+		if checker.maxLength > 0 && len(n.Comment) > checker.maxLength {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.ColumnCommentTooLong,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("The length of column %q in table %q comment should be within %d characters", n.ColumnName, n.Table.Name, checker.maxLength),
+				StatementIndex: checker.statementIndex,
+			})
+		}
+	}
+
+	return checker
+}
+
+func (checker *columnCommentConventionChecker) addColumn(table string, column string) {
+	checker.columns[columnName{tableName: table, columnName: column}] = &columnComment{
+		statementIndex: checker.statementIndex,
+	}
+}
+
+func (checker *columnCommentConventionChecker) generateAdviceList() []advisor.Advice {
+	if checker.required {
+		var columnList []columnName
+		for name, comment := range checker.columns {
+			if !comment.commented {
+				columnList = append(columnList, name)
+			}
+		}
+		// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
+		sort.Slice(columnList, func(i, j int) bool {
+			if columnList[i].tableName != columnList[j].tableName {
+				return columnList[i].tableName < columnList[j].tableName
+			}
+			return columnList[i].columnName < columnList[j].columnName
+		})
+		for _, name := range columnList {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.NoColumnComment,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Column %q in table %q requires comments", name.columnName, name.tableName),
+				StatementIndex: checker.columns[name].statementIndex,
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList
+}
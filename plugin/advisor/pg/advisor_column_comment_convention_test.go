@@ -0,0 +1,75 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnCommentConvention(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: `CREATE TABLE t(a int);
+				COMMENT ON COLUMN t.a IS 'some comments'`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(a int, b int);
+				COMMENT ON COLUMN t.a IS 'some comments'`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoColumnComment,
+					Title:          "column.comment",
+					Content:        "Column \"b\" in table \"t\" requires comments",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a int;
+				COMMENT ON COLUMN public.tech_book.a IS 'this is a very long comment'`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCommentTooLong,
+					Title:          "column.comment",
+					Content:        "The length of column \"a\" in table \"tech_book\" comment should be within 20 characters",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a int;
+				ALTER TABLE tech_book DROP COLUMN a`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.CommentConventionRulePayload{
+		Required:  true,
+		MaxLength: 20,
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnCommentConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnCommentConvention,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
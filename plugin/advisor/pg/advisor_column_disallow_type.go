@@ -0,0 +1,130 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnDisallowTypeAdvisor)(nil)
+)
+
+// typeAliasMap is the map from the internal type name of PostgreSQL to its aliases in the SQL.
+// The disallow list may use any of them.
+var typeAliasMap = map[string][]string{
+	"int2":        {"smallint"},
+	"int4":        {"integer", "int"},
+	"int8":        {"bigint"},
+	"float4":      {"real", "float"},
+	"float8":      {"double precision", "float"},
+	"bool":        {"boolean"},
+	"bpchar":      {"character", "char"},
+	"varchar":     {"character varying"},
+	"varbit":      {"bit varying"},
+	"numeric":     {"decimal"},
+	"timestamp":   {"timestamp without time zone"},
+	"timestamptz": {"timestamp with time zone"},
+	"time":        {"time without time zone"},
+	"timetz":      {"time with time zone"},
+	"serial2":     {"smallserial"},
+	"serial4":     {"serial"},
+	"serial8":     {"bigserial"},
+}
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLColumnDisallowType, &ColumnDisallowTypeAdvisor{})
+}
+
+// ColumnDisallowTypeAdvisor is the advisor checking for the column types in the disallow list.
+type ColumnDisallowTypeAdvisor struct {
+}
+
+// Check checks for the column types in the disallow list.
+func (adv *ColumnDisallowTypeAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalStringArrayTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnDisallowTypeChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		disallowList: payload.List,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnDisallowTypeChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	disallowList   []string
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *columnDisallowTypeChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range n.ColumnList {
+			checker.checkType(n.Name.Name, column.ColumnName, column.Type)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			checker.checkType(n.Table.Name, column.ColumnName, column.Type)
+		}
+	// ALTER TABLE ALTER COLUMN TYPE
+	case *ast.AlterColumnTypeStmt:
+		checker.checkType(n.Table.Name, n.ColumnName, n.Type)
+	}
+
+	return checker
+}
+
+func (checker *columnDisallowTypeChecker) checkType(table string, column string, tp *ast.DataTypeDef) {
+	if tp == nil {
+		return
+	}
+	nameList := append([]string{tp.Name}, typeAliasMap[tp.Name]...)
+	for _, disallowType := range checker.disallowList {
+		for _, name := range nameList {
+			if strings.EqualFold(disallowType, name) {
+				checker.adviceList = append(checker.adviceList, advisor.Advice{
+					Status:         checker.level,
+					Code:           advisor.DisabledColumnType,
+					Title:          checker.title,
+					Content:        fmt.Sprintf("Disallow column type %s but column %q in table %q is", strings.ToUpper(disallowType), column, table),
+					StatementIndex: checker.statementIndex,
+				})
+				return
+			}
+		}
+	}
+}
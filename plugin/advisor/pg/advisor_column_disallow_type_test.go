@@ -0,0 +1,79 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnDisallowType(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a int, b varchar(255), c real)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a int, b json, c double precision)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type JSON but column \"b\" in table \"t\" is",
+					StatementIndex: 1,
+				},
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type FLOAT8 but column \"c\" in table \"t\" is",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a int;
+				ALTER TABLE tech_book ALTER COLUMN name TYPE json`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type JSON but column \"name\" in table \"tech_book\" is",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE tech_book ADD COLUMN a float",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DisabledColumnType,
+					Title:          "column.type-disallow-list",
+					Content:        "Disallow column type FLOAT8 but column \"a\" in table \"tech_book\" is",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.StringArrayTypeRulePayload{
+		List: []string{"JSON", "float8"},
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnDisallowTypeAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnTypeDisallowList,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
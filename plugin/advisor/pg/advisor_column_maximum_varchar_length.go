@@ -0,0 +1,102 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnMaximumVarcharLengthAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLColumnMaximumVarcharLength, &ColumnMaximumVarcharLengthAdvisor{})
+}
+
+// ColumnMaximumVarcharLengthAdvisor is the advisor checking for the maximum VARCHAR length.
+type ColumnMaximumVarcharLengthAdvisor struct {
+}
+
+// Check checks for the maximum VARCHAR length.
+func (adv *ColumnMaximumVarcharLengthAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnMaximumVarcharLengthChecker{
+		level:     level,
+		title:     string(ctx.Rule.Type),
+		maxLength: payload.Number,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type columnMaximumVarcharLengthChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	maxLength      int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *columnMaximumVarcharLengthChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		for _, column := range n.ColumnList {
+			checker.checkType(n.Name.Name, column.ColumnName, column.Type)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			checker.checkType(n.Table.Name, column.ColumnName, column.Type)
+		}
+	// ALTER TABLE ALTER COLUMN TYPE
+	case *ast.AlterColumnTypeStmt:
+		checker.checkType(n.Table.Name, n.ColumnName, n.Type)
+	}
+
+	return checker
+}
+
+// checkType checks the length of the VARCHAR type, and the VARCHAR without length is unlimited in PostgreSQL.
+func (checker *columnMaximumVarcharLengthChecker) checkType(table string, column string, tp *ast.DataTypeDef) {
+	if tp == nil || tp.Name != "varchar" {
+		return
+	}
+	if len(tp.ModifierList) == 0 || tp.ModifierList[0] > checker.maxLength {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           advisor.VarcharLengthExceedsLimit,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("The length of the VARCHAR column %q in table %q is bigger than %d", column, table, checker.maxLength),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+}
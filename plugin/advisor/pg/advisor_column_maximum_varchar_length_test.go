@@ -0,0 +1,67 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnMaximumVarcharLength(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a varchar(255), b char(3000), c text)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a varchar(3000), b varchar)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.VarcharLengthExceedsLimit,
+					Title:          "column.maximum-varchar-length",
+					Content:        "The length of the VARCHAR column \"a\" in table \"t\" is bigger than 2560",
+					StatementIndex: 1,
+				},
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.VarcharLengthExceedsLimit,
+					Title:          "column.maximum-varchar-length",
+					Content:        "The length of the VARCHAR column \"b\" in table \"t\" is bigger than 2560",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a character varying(2560);
+				ALTER TABLE tech_book ALTER COLUMN name TYPE varchar(2561)`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.VarcharLengthExceedsLimit,
+					Title:          "column.maximum-varchar-length",
+					Content:        "The length of the VARCHAR column \"name\" in table \"tech_book\" is bigger than 2560",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NumberTypeRulePayload{
+		Number: 2560,
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnMaximumVarcharLengthAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnMaximumVarcharLength,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
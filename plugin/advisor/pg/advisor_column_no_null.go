@@ -0,0 +1,175 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnNoNullAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLColumnNoNull, &ColumnNoNullAdvisor{})
+}
+
+// ColumnNoNullAdvisor is the advisor checking for column no NULL value.
+type ColumnNoNullAdvisor struct {
+}
+
+// Check checks for column no NULL value.
+func (adv *ColumnNoNullAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnNoNullChecker{
+		level:                  level,
+		title:                  string(ctx.Rule.Type),
+		nullableSet:            make(map[columnName]bool),
+		nullableStatementIndex: make(map[columnName]int),
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+type columnName struct {
+	tableName  string
+	columnName string
+}
+
+type columnNoNullChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	// nullableList is the nullable columns in the order of appearance, and nullableSet is the current state of them.
+	// A column can be set to NOT NULL or be dropped by the following statements, so we generate the advice at the end.
+	nullableList []columnName
+	nullableSet  map[columnName]bool
+	// nullableStatementIndex is the index of the statement making the column nullable, and the advice is for it.
+	nullableStatementIndex map[columnName]int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *columnNoNullChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		pkColumns := make(columnSet)
+		for _, constraint := range n.ConstraintList {
+			if constraint.Type == ast.ConstraintTypePrimary {
+				for _, key := range constraint.KeyList {
+					pkColumns[key] = true
+				}
+			}
+		}
+		for _, column := range n.ColumnList {
+			if canNull(column) && !pkColumns[column.ColumnName] {
+				checker.addNullable(n.Name.Name, column.ColumnName)
+			}
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			for column := range checker.nullableSet {
+				if column.tableName == table.Name {
+					checker.nullableSet[column] = false
+				}
+			}
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			if canNull(column) {
+				checker.addNullable(n.Table.Name, column.ColumnName)
+			}
+		}
+	// ALTER TABLE ADD CONSTRAINT PRIMARY KEY
+	case *ast.AddConstraintStmt:
+		if n.Constraint.Type == ast.ConstraintTypePrimary {
+			for _, key := range n.Constraint.KeyList {
+				checker.removeNullable(n.Table.Name, key)
+			}
+		}
+	// ALTER TABLE ALTER COLUMN SET NOT NULL
+	case *ast.SetNotNullStmt:
+		checker.removeNullable(n.Table.Name, n.ColumnName)
+	// ALTER TABLE ALTER COLUMN DROP NOT NULL
+	case *ast.DropNotNullStmt:
+		checker.addNullable(n.Table.Name, n.ColumnName)
+	// ALTER TABLE DROP COLUMN
+	case *ast.DropColumnStmt:
+		checker.removeNullable(n.Table.Name, n.ColumnName)
+	// ALTER TABLE RENAME COLUMN
+	case *ast.RenameColumnStmt:
+		column := columnName{tableName: n.Table.Name, columnName: n.ColumnName}
+		if checker.nullableSet[column] {
+			checker.removeNullable(n.Table.Name, n.ColumnName)
+			checker.addNullable(n.Table.Name, n.NewName)
+		}
+	}
+
+	return checker
+}
+
+func (checker *columnNoNullChecker) generateAdviceList() []advisor.Advice {
+	for _, column := range checker.nullableList {
+		if checker.nullableSet[column] {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.ColumnCanNotNull,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("\"%s\".\"%s\" can not have NULL value", column.tableName, column.columnName),
+				StatementIndex: checker.nullableStatementIndex[column],
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList
+}
+
+func (checker *columnNoNullChecker) addNullable(table string, column string) {
+	key := columnName{tableName: table, columnName: column}
+	if _, ok := checker.nullableSet[key]; !ok {
+		checker.nullableList = append(checker.nullableList, key)
+	}
+	checker.nullableSet[key] = true
+	checker.nullableStatementIndex[key] = checker.statementIndex
+}
+
+func (checker *columnNoNullChecker) removeNullable(table string, column string) {
+	key := columnName{tableName: table, columnName: column}
+	if _, ok := checker.nullableSet[key]; ok {
+		checker.nullableSet[key] = false
+	}
+}
+
+func canNull(column *ast.ColumnDef) bool {
+	for _, constraint := range column.ConstraintList {
+		if constraint.Type == ast.ConstraintTypeNotNull || constraint.Type == ast.ConstraintTypePrimary {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestColumnNoNull(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a int, b int NOT NULL)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "\"t\".\"a\" can not have NULL value",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a int PRIMARY KEY, b int, c int NOT NULL, PRIMARY KEY (b))",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(a int NOT NULL);
+						ALTER TABLE t ADD COLUMN b int;
+						ALTER TABLE t ADD COLUMN c int NOT NULL`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "\"t\".\"b\" can not have NULL value",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(a int, b int);
+						ALTER TABLE t ALTER COLUMN a SET NOT NULL;
+						ALTER TABLE t DROP COLUMN b`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t ALTER COLUMN a DROP NOT NULL",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "\"t\".\"a\" can not have NULL value",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(a int, b int);
+						ALTER TABLE t RENAME COLUMN a TO c;
+						ALTER TABLE t ADD CONSTRAINT t_pk PRIMARY KEY (b)`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.ColumnCanNotNull,
+					Title:          "column.no-null",
+					Content:        "\"t\".\"c\" can not have NULL value",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnNoNullAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleColumnNotNull,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
@@ -0,0 +1,194 @@
+package pg
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*ColumnRequirementAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLColumnRequirement, &ColumnRequirementAdvisor{})
+}
+
+// ColumnRequirementAdvisor is the advisor checking for column requirement.
+type ColumnRequirementAdvisor struct {
+}
+
+// Check checks for the column requirement.
+func (adv *ColumnRequirementAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalRequiredColumnRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &columnRequirementChecker{
+		level:               level,
+		title:               string(ctx.Rule.Type),
+		requiredColumns:     newColumnSet(payload.ColumnList),
+		tables:              make(tableState),
+		tableStatementIndex: make(map[string]int),
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+type columnRequirementChecker struct {
+	adviceList      []advisor.Advice
+	level           advisor.Status
+	title           string
+	statementIndex  int
+	requiredColumns columnSet
+	tables          tableState
+	// tableStatementIndex is the index of the last statement changing the table, and the advice is for it.
+	tableStatementIndex map[string]int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *columnRequirementChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		table := checker.initEmptyTable(n.Name.Name)
+		checker.tableStatementIndex[n.Name.Name] = checker.statementIndex
+		for _, column := range n.ColumnList {
+			table[column.ColumnName] = true
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			delete(checker.tables, table.Name)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		checker.tableStatementIndex[n.Table.Name] = checker.statementIndex
+		for _, column := range n.ColumnList {
+			checker.addColumn(n.Table.Name, column.ColumnName)
+		}
+	// ALTER TABLE DROP COLUMN
+	case *ast.DropColumnStmt:
+		checker.tableStatementIndex[n.Table.Name] = checker.statementIndex
+		checker.dropColumn(n.Table.Name, n.ColumnName)
+	// ALTER TABLE RENAME COLUMN
+	case *ast.RenameColumnStmt:
+		checker.tableStatementIndex[n.Table.Name] = checker.statementIndex
+		checker.renameColumn(n.Table.Name, n.ColumnName, n.NewName)
+	}
+
+	return checker
+}
+
+func (checker *columnRequirementChecker) generateAdviceList() []advisor.Advice {
+	// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
+	tableList := checker.tables.tableList()
+	for _, tableName := range tableList {
+		table := checker.tables[tableName]
+		var missingColumns []string
+		for column := range checker.requiredColumns {
+			if exist, ok := table[column]; !ok || !exist {
+				missingColumns = append(missingColumns, column)
+			}
+		}
+		if len(missingColumns) > 0 {
+			// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
+			sort.Strings(missingColumns)
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.NoRequiredColumn,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Table %q requires columns: %s", tableName, strings.Join(missingColumns, ", ")),
+				StatementIndex: checker.tableStatementIndex[tableName],
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList
+}
+
+// initEmptyTable will initialize a table without any required columns.
+func (checker *columnRequirementChecker) initEmptyTable(name string) columnSet {
+	checker.tables[name] = make(columnSet)
+	return checker.tables[name]
+}
+
+// initFullTable will initialize a table with all required columns.
+func (checker *columnRequirementChecker) initFullTable(name string) columnSet {
+	table := checker.initEmptyTable(name)
+	for column := range checker.requiredColumns {
+		table[column] = true
+	}
+	return table
+}
+
+func (checker *columnRequirementChecker) renameColumn(table string, oldColumn string, newColumn string) {
+	_, oldNeed := checker.requiredColumns[oldColumn]
+	_, newNeed := checker.requiredColumns[newColumn]
+	if !oldNeed && !newNeed {
+		return
+	}
+	t, ok := checker.tables[table]
+	if !ok {
+		// We do not retrospectively check.
+		// So we assume it contains all required columns.
+		t = checker.initFullTable(table)
+	}
+	if oldNeed {
+		t[oldColumn] = false
+	}
+	if newNeed {
+		t[newColumn] = true
+	}
+}
+
+func (checker *columnRequirementChecker) dropColumn(table string, column string) {
+	if _, ok := checker.requiredColumns[column]; !ok {
+		return
+	}
+	t, ok := checker.tables[table]
+	if !ok {
+		// We do not retrospectively check.
+		// So we assume it contains all required columns.
+		t = checker.initFullTable(table)
+	}
+	t[column] = false
+}
+
+func (checker *columnRequirementChecker) addColumn(table string, column string) {
+	if _, ok := checker.requiredColumns[column]; !ok {
+		return
+	}
+	if t, ok := checker.tables[table]; !ok {
+		// We do not retrospectively check.
+		// So we assume it contains all required columns.
+		checker.initFullTable(table)
+	} else {
+		t[column] = true
+	}
+}
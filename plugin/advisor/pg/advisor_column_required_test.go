@@ -0,0 +1,131 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnRequirement(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE book(id int)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table \"book\" requires columns: created_ts, creator_id, updated_ts, updater_id",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE book(
+							id int,
+							creator_id int,
+							created_ts timestamp,
+							updater_id int,
+							updated_ts timestamp)`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE book(
+							id int,
+							creator_id int,
+							created_ts timestamp,
+							updater_id int,
+							updated_ts timestamp);
+						ALTER TABLE book RENAME COLUMN creator_id TO creator`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table \"book\" requires columns: creator_id",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE book(
+							id int,
+							creator int,
+							created_ts timestamp,
+							updater_id int,
+							updated_ts timestamp);
+						ALTER TABLE book RENAME COLUMN creator TO creator_id`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE book(id int);
+						ALTER TABLE book ADD COLUMN creator_id int;
+						ALTER TABLE book ADD COLUMN created_ts timestamp;
+						ALTER TABLE book ADD COLUMN updater_id int`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table \"book\" requires columns: updated_ts",
+					StatementIndex: 4,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE book DROP COLUMN created_ts",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoRequiredColumn,
+					Title:          "column.required",
+					Content:        "Table \"book\" requires columns: created_ts",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE book(id int);
+						DROP TABLE book`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+	payload, err := json.Marshal(advisor.RequiredColumnRulePayload{
+		ColumnList: []string{
+			"id",
+			"created_ts",
+			"updated_ts",
+			"creator_id",
+			"updater_id",
+		},
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &ColumnRequirementAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleRequiredColumn,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
@@ -0,0 +1,288 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CustomExpressionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLCustomExpression, &CustomExpressionAdvisor{})
+}
+
+// CustomExpressionAdvisor is the advisor checking for the user-defined expression.
+type CustomExpressionAdvisor struct {
+}
+
+// Check checks for the user-defined expression.
+func (adv *CustomExpressionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, expression, err := advisor.UnmarshalCustomExpressionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &customExpressionChecker{
+		level:      level,
+		title:      payload.Title,
+		message:    payload.Message,
+		expression: expression,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+		if checker.err != nil {
+			return nil, checker.err
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type customExpressionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	message        string
+	expression     *advisor.CustomExpression
+	// err is the first evaluation error, and the checker stops evaluating after it.
+	err error
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *customExpressionChecker) Visit(node ast.Node) ast.Visitor {
+	if checker.err != nil {
+		return nil
+	}
+	for _, parameters := range getCustomExpressionParametersList(node) {
+		checker.evaluate(parameters)
+	}
+	return checker
+}
+
+func (checker *customExpressionChecker) evaluate(parameters *advisor.CustomExpressionParameters) {
+	if checker.err != nil {
+		return
+	}
+	matched, err := advisor.EvaluateCustomExpression(checker.expression, parameters)
+	if err != nil {
+		checker.err = err
+		return
+	}
+	if !matched {
+		return
+	}
+	content := checker.message
+	if content == "" {
+		content = fmt.Sprintf("%s on table %q matches the expression %q", parameters.StatementType, parameters.Table, checker.expression.String())
+	}
+	checker.adviceList = append(checker.adviceList, advisor.Advice{
+		Status:         checker.level,
+		Code:           advisor.CustomExpressionMatched,
+		Title:          checker.title,
+		Content:        content,
+		StatementIndex: checker.statementIndex,
+	})
+}
+
+// getCustomExpressionParametersList returns the parameters of the changes in the node.
+func getCustomExpressionParametersList(node ast.Node) []*advisor.CustomExpressionParameters {
+	var res []*advisor.CustomExpressionParameters
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		createTable := &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionCreateTable,
+			Schema:        n.Name.Schema,
+			Table:         n.Name.Name,
+			ColumnCount:   len(n.ColumnList),
+		}
+		res = append(res, createTable)
+		for _, column := range n.ColumnList {
+			res = append(res, getColumnParametersList(n.Name, column)...)
+		}
+		for _, constraint := range n.ConstraintList {
+			res = append(res, getConstraintParameters(n.Name, constraint))
+		}
+		for _, parameters := range res {
+			if parameters.StatementType == advisor.CustomExpressionAddConstraint && parameters.ConstraintType == "PRIMARY KEY" {
+				createTable.HasPrimaryKey = true
+			}
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			res = append(res, &advisor.CustomExpressionParameters{
+				StatementType: advisor.CustomExpressionDropTable,
+				Schema:        table.Schema,
+				Table:         table.Name,
+			})
+		}
+	// ALTER TABLE RENAME
+	case *ast.RenameTableStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionRenameTable,
+			Schema:        n.Table.Schema,
+			Table:         n.Table.Name,
+			NewName:       n.NewName,
+		})
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			res = append(res, getColumnParametersList(n.Table, column)...)
+		}
+	// ALTER TABLE DROP COLUMN
+	case *ast.DropColumnStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropColumn,
+			Schema:        n.Table.Schema,
+			Table:         n.Table.Name,
+			Column:        n.ColumnName,
+		})
+	// ALTER TABLE RENAME COLUMN
+	case *ast.RenameColumnStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionRenameColumn,
+			Schema:        n.Table.Schema,
+			Table:         n.Table.Name,
+			Column:        n.ColumnName,
+			NewName:       n.NewName,
+		})
+	// ALTER TABLE ALTER COLUMN TYPE
+	case *ast.AlterColumnTypeStmt:
+		parameters := &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionAlterColumnType,
+			Schema:        n.Table.Schema,
+			Table:         n.Table.Name,
+			Column:        n.ColumnName,
+		}
+		if n.Type != nil {
+			parameters.ColumnType = n.Type.Name
+		}
+		res = append(res, parameters)
+	// ALTER TABLE ADD CONSTRAINT
+	case *ast.AddConstraintStmt:
+		res = append(res, getConstraintParameters(n.Table, n.Constraint))
+	// ALTER TABLE DROP CONSTRAINT
+	case *ast.DropConstraintStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDropConstraint,
+			Schema:        n.Table.Schema,
+			Table:         n.Table.Name,
+			Name:          n.ConstraintName,
+		})
+	// CREATE INDEX
+	case *ast.CreateIndexStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionCreateIndex,
+			Schema:        n.Index.Table.Schema,
+			Table:         n.Index.Table.Name,
+			Name:          n.Index.Name,
+			Unique:        n.Index.Unique,
+			ColumnCount:   len(n.Index.KeyList),
+		})
+	// DROP INDEX
+	case *ast.DropIndexStmt:
+		for _, index := range n.IndexList {
+			parameters := &advisor.CustomExpressionParameters{
+				StatementType: advisor.CustomExpressionDropIndex,
+				Name:          index.Name,
+			}
+			if index.Table != nil {
+				parameters.Schema = index.Table.Schema
+			}
+			res = append(res, parameters)
+		}
+	// UPDATE
+	case *ast.UpdateStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionUpdate,
+			Schema:        n.Table.Schema,
+			Table:         n.Table.Name,
+			HasWhere:      n.WhereClause != nil,
+		})
+	// DELETE
+	case *ast.DeleteStmt:
+		res = append(res, &advisor.CustomExpressionParameters{
+			StatementType: advisor.CustomExpressionDelete,
+			Schema:        n.Table.Schema,
+			Table:         n.Table.Name,
+			HasWhere:      n.WhereClause != nil,
+		})
+	}
+	return res
+}
+
+// getColumnParametersList returns the parameters of the added column and its PRIMARY KEY, UNIQUE, FOREIGN KEY and CHECK constraints.
+func getColumnParametersList(table *ast.TableDef, column *ast.ColumnDef) []*advisor.CustomExpressionParameters {
+	addColumn := &advisor.CustomExpressionParameters{
+		StatementType: advisor.CustomExpressionAddColumn,
+		Schema:        table.Schema,
+		Table:         table.Name,
+		Column:        column.ColumnName,
+	}
+	if column.Type != nil {
+		addColumn.ColumnType = column.Type.Name
+	}
+	res := []*advisor.CustomExpressionParameters{addColumn}
+	for _, constraint := range column.ConstraintList {
+		switch constraint.Type {
+		case ast.ConstraintTypeNotNull:
+			addColumn.NotNull = true
+		case ast.ConstraintTypeDefault:
+			addColumn.HasDefault = true
+		case ast.ConstraintTypePrimary:
+			// PRIMARY KEY implies NOT NULL.
+			addColumn.NotNull = true
+			res = append(res, getConstraintParameters(table, constraint))
+		case ast.ConstraintTypeUnique, ast.ConstraintTypeForeign, ast.ConstraintTypeCheck:
+			res = append(res, getConstraintParameters(table, constraint))
+		}
+	}
+	return res
+}
+
+func getConstraintParameters(table *ast.TableDef, constraint *ast.ConstraintDef) *advisor.CustomExpressionParameters {
+	parameters := &advisor.CustomExpressionParameters{
+		StatementType: advisor.CustomExpressionAddConstraint,
+		Schema:        table.Schema,
+		Table:         table.Name,
+		Name:          constraint.Name,
+		ColumnCount:   len(constraint.KeyList),
+	}
+	switch constraint.Type {
+	case ast.ConstraintTypePrimary, ast.ConstraintTypePrimaryUsingIndex:
+		parameters.ConstraintType = "PRIMARY KEY"
+		parameters.Unique = true
+	case ast.ConstraintTypeUnique, ast.ConstraintTypeUniqueUsingIndex:
+		parameters.ConstraintType = "UNIQUE"
+		parameters.Unique = true
+	case ast.ConstraintTypeForeign:
+		parameters.ConstraintType = "FOREIGN KEY"
+	case ast.ConstraintTypeCheck:
+		parameters.ConstraintType = "CHECK"
+	}
+	return parameters
+}
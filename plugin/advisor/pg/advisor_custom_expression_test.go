@@ -0,0 +1,119 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomExpression(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(id int PRIMARY KEY, data jsonb)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id int PRIMARY KEY, data json)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CustomExpressionMatched,
+					Title:          "Disallow JSON column",
+					Content:        "ADD_COLUMN on table \"t\" matches the expression \"(statementType == 'ADD_COLUMN' || statementType == 'ALTER_COLUMN_TYPE') && columnType == 'json'\"",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `ALTER TABLE tech_book ADD COLUMN a int;
+				ALTER TABLE tech_book ALTER COLUMN name TYPE json`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CustomExpressionMatched,
+					Title:          "Disallow JSON column",
+					Content:        "ALTER_COLUMN_TYPE on table \"tech_book\" matches the expression \"(statementType == 'ADD_COLUMN' || statementType == 'ALTER_COLUMN_TYPE') && columnType == 'json'\"",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.CustomExpressionRulePayload{
+		Title:      "Disallow JSON column",
+		Expression: "(statementType == 'ADD_COLUMN' || statementType == 'ALTER_COLUMN_TYPE') && columnType == 'json'",
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &CustomExpressionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleCustomExpression,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
+
+func TestCustomExpressionWithMessage(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(id int PRIMARY KEY, name text)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(id int, name text);
+				CREATE INDEX idx_t_id_name ON t(id, name);
+				DELETE FROM t`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.CustomExpressionMatched,
+					Title:          "Company convention",
+					Content:        "Tables need a primary key, indexes at most one column and DELETE a WHERE clause",
+					StatementIndex: 1,
+				},
+				{
+					Status:         advisor.Error,
+					Code:           advisor.CustomExpressionMatched,
+					Title:          "Company convention",
+					Content:        "Tables need a primary key, indexes at most one column and DELETE a WHERE clause",
+					StatementIndex: 2,
+				},
+				{
+					Status:         advisor.Error,
+					Code:           advisor.CustomExpressionMatched,
+					Title:          "Company convention",
+					Content:        "Tables need a primary key, indexes at most one column and DELETE a WHERE clause",
+					StatementIndex: 3,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.CustomExpressionRulePayload{
+		Title: "Company convention",
+		Expression: "(statementType == 'CREATE_TABLE' && !hasPrimaryKey) || " +
+			"(statementType == 'CREATE_INDEX' && columnCount > 1) || " +
+			"(statementType == 'DELETE' && !hasWhere)",
+		Message: "Tables need a primary key, indexes at most one column and DELETE a WHERE clause",
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &CustomExpressionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleCustomExpression,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
@@ -0,0 +1,206 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*IndexTotalNumberLimitAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLIndexTotalNumberLimit, &IndexTotalNumberLimitAdvisor{})
+}
+
+// IndexTotalNumberLimitAdvisor is the advisor checking for the index total number limit.
+type IndexTotalNumberLimitAdvisor struct {
+}
+
+// Check checks for the index total number limit.
+func (adv *IndexTotalNumberLimitAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &indexTotalNumberLimitChecker{
+		level:               level,
+		title:               string(ctx.Rule.Type),
+		max:                 payload.Number,
+		tables:              make(map[string]map[string]bool),
+		tableStatementIndex: make(map[string]int),
+		catalog:             ctx.Catalog,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+type indexTotalNumberLimitChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	max            int
+	// tables is the map from table name to its index name set, including the existing ones in the catalog.
+	tables map[string]map[string]bool
+	// tableStatementIndex is the index of the last statement adding indexes to the table, and the advice is for it.
+	tableStatementIndex map[string]int
+	catalog             catalog.Catalog
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *indexTotalNumberLimitChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		table := n.Name.Name
+		checker.tables[table] = make(map[string]bool)
+		for _, column := range n.ColumnList {
+			for _, constraint := range column.ConstraintList {
+				if index := convertConstraintToIndex(table, constraint); index != nil {
+					checker.addIndex(table, index.name)
+				}
+			}
+		}
+		for _, constraint := range n.ConstraintList {
+			if index := convertConstraintToIndex(table, constraint); index != nil {
+				checker.addIndex(table, index.name)
+			}
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			checker.tables[table.Name] = make(map[string]bool)
+		}
+	// ALTER TABLE ADD CONSTRAINT
+	case *ast.AddConstraintStmt:
+		table := n.Table.Name
+		if index := convertConstraintToIndex(table, n.Constraint); index != nil {
+			checker.loadIndexList(table)
+			checker.addIndex(table, index.name)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		table := n.Table.Name
+		for _, column := range n.ColumnList {
+			for _, constraint := range column.ConstraintList {
+				if index := convertConstraintToIndex(table, constraint); index != nil {
+					checker.loadIndexList(table)
+					checker.addIndex(table, index.name)
+				}
+			}
+		}
+	// ALTER TABLE DROP CONSTRAINT
+	case *ast.DropConstraintStmt:
+		checker.loadIndexList(n.Table.Name)
+		delete(checker.tables[n.Table.Name], n.ConstraintName)
+	// CREATE INDEX
+	case *ast.CreateIndexStmt:
+		table := n.Index.Table.Name
+		name := n.Index.Name
+		if name == "" {
+			var keyList []string
+			for _, key := range n.Index.KeyList {
+				keyList = append(keyList, key.Key)
+			}
+			name = fmt.Sprintf("%s_%s_idx", table, strings.Join(keyList, "_"))
+		}
+		checker.loadIndexList(table)
+		checker.addIndex(table, name)
+	// DROP INDEX
+	case *ast.DropIndexStmt:
+		for _, index := range n.IndexList {
+			// The index name is unique in the schema, so we find its table in the catalog.
+			if checker.catalog != nil {
+				if catalogIndex := findIndex(checker.catalog, index.Name); catalogIndex != nil {
+					checker.loadIndexList(catalogIndex.TableName)
+				}
+			}
+			for _, indexSet := range checker.tables {
+				delete(indexSet, index.Name)
+			}
+		}
+	}
+
+	return checker
+}
+
+func (checker *indexTotalNumberLimitChecker) generateAdviceList() []advisor.Advice {
+	var tableList []string
+	for table := range checker.tables {
+		tableList = append(tableList, table)
+	}
+	// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
+	sort.Strings(tableList)
+	for _, table := range tableList {
+		if count := len(checker.tables[table]); count > checker.max {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.IndexCountExceedsLimit,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("The count of index in table %q should be no more than %d, but found %d", table, checker.max, count),
+				StatementIndex: checker.tableStatementIndex[table],
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList
+}
+
+// loadIndexList loads the existing indexes of the table from the catalog if the table is not seen before.
+func (checker *indexTotalNumberLimitChecker) loadIndexList(table string) {
+	if _, ok := checker.tables[table]; ok {
+		return
+	}
+	checker.tables[table] = make(map[string]bool)
+	if checker.catalog == nil {
+		return
+	}
+	tableInfo, err := checker.catalog.FindTable(context.Background(), &catalog.TableFind{
+		TableName: table,
+	})
+	if err != nil {
+		log.Printf("Cannot find table %s with error %v\n", table, err)
+		return
+	}
+	if tableInfo == nil {
+		return
+	}
+	for _, index := range tableInfo.IndexList {
+		checker.tables[table][index.Name] = true
+	}
+}
+
+func (checker *indexTotalNumberLimitChecker) addIndex(table string, name string) {
+	checker.tables[table][name] = true
+	checker.tableStatementIndex[table] = checker.statementIndex
+}
@@ -0,0 +1,73 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexTotalNumberLimit(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(a int PRIMARY KEY, b int UNIQUE, c int, UNIQUE (c))",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(a int PRIMARY KEY, b int UNIQUE, c int, d int, UNIQUE (c));
+				CREATE INDEX ON t(d)`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.IndexCountExceedsLimit,
+					Title:          "index.total-number-limit",
+					Content:        "The count of index in table \"t\" should be no more than 3, but found 4",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: `CREATE INDEX idx_tech_book_id ON tech_book(id);
+				ALTER TABLE tech_book ADD COLUMN a int`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.IndexCountExceedsLimit,
+					Title:          "index.total-number-limit",
+					Content:        "The count of index in table \"tech_book\" should be no more than 3, but found 4",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `CREATE INDEX idx_tech_book_id ON tech_book(id);
+				DROP INDEX old_index`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NumberTypeRulePayload{
+		Number: 3,
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &IndexTotalNumberLimitAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleIndexTotalNumberLimit,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
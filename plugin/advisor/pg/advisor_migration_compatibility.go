@@ -0,0 +1,191 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*CompatibilityAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLMigrationCompatibility, &CompatibilityAdvisor{})
+}
+
+// CompatibilityAdvisor is the advisor checking for schema backward compatibility.
+type CompatibilityAdvisor struct {
+}
+
+// Check checks schema backward compatibility.
+func (adv *CompatibilityAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &compatibilityChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		catalog: ctx.Catalog,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		// We only report the first incompatible change for each statement.
+		checker.code = advisor.Ok
+		ast.Walk(checker, stmt)
+
+		if checker.code != advisor.Ok {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           checker.code,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("\"%s\" may cause incompatibility with the existing data and code", stmt.Text()),
+				StatementIndex: checker.statementIndex,
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type compatibilityChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	code           advisor.Code
+	catalog        catalog.Catalog
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *compatibilityChecker) Visit(node ast.Node) ast.Visitor {
+	if checker.code != advisor.Ok {
+		return nil
+	}
+
+	switch n := node.(type) {
+	// DROP DATABASE
+	case *ast.DropDatabaseStmt:
+		checker.code = advisor.CompatibilityDropDatabase
+	// ALTER TABLE RENAME TO
+	case *ast.RenameTableStmt:
+		checker.code = advisor.CompatibilityRenameTable
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		checker.code = advisor.CompatibilityDropTable
+	// ALTER TABLE RENAME COLUMN
+	case *ast.RenameColumnStmt:
+		checker.code = advisor.CompatibilityRenameColumn
+	// ALTER TABLE DROP COLUMN
+	case *ast.DropColumnStmt:
+		checker.code = advisor.CompatibilityDropColumn
+	// ALTER TABLE ALTER COLUMN TYPE
+	// Due to the limitation that we don't know the current data type of the column before the change,
+	// so we treat all as incompatible. This generates false positive when changing to a compatible data type such as INT to BIGINT.
+	case *ast.AlterColumnTypeStmt:
+		checker.code = advisor.CompatibilityAlterColumn
+	// ALTER TABLE ALTER COLUMN SET NOT NULL
+	case *ast.SetNotNullStmt:
+		if !checker.isEmptyTable(n.Table.Name) {
+			checker.code = advisor.CompatibilityAddNotNull
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			code := getAddColumnCode(column)
+			if code == advisor.CompatibilityAddNotNull && checker.isEmptyTable(n.Table.Name) {
+				continue
+			}
+			if code != advisor.Ok {
+				checker.code = code
+				break
+			}
+		}
+	// ALTER TABLE ADD CONSTRAINT
+	case *ast.AddConstraintStmt:
+		checker.code = getAddConstraintCode(n.Constraint)
+	// ALTER TABLE DROP CONSTRAINT
+	case *ast.DropConstraintStmt:
+		checker.code = advisor.CompatibilityDropConstraint
+	// CREATE UNIQUE INDEX
+	case *ast.CreateIndexStmt:
+		if n.Index.Unique {
+			checker.code = advisor.CompatibilityAddUniqueKey
+		}
+	}
+
+	return checker
+}
+
+// isEmptyTable returns whether the catalog knows the table has no row.
+// Adding NOT NULL to an empty table doesn't fail, so we don't report it.
+// The row count comes from the last schema sync, and we treat the unknown table as populated.
+func (checker *compatibilityChecker) isEmptyTable(tableName string) bool {
+	if checker.catalog == nil {
+		return false
+	}
+	table, err := checker.catalog.FindTable(context.Background(), &catalog.TableFind{
+		TableName: tableName,
+	})
+	if err != nil {
+		log.Printf("Cannot find table %s with error %v\n", tableName, err)
+		return false
+	}
+	return table != nil && table.RowCount == 0
+}
+
+// getAddColumnCode returns the incompatible code for the new column.
+// The NOT NULL column without default value fails if the table has data, and breaks the existing INSERT statements.
+func getAddColumnCode(column *ast.ColumnDef) advisor.Code {
+	notNull, hasDefault := false, false
+	for _, constraint := range column.ConstraintList {
+		switch constraint.Type {
+		case ast.ConstraintTypeNotNull:
+			notNull = true
+		case ast.ConstraintTypeDefault:
+			hasDefault = true
+		default:
+			if code := getAddConstraintCode(constraint); code != advisor.Ok {
+				return code
+			}
+		}
+	}
+	if notNull && !hasDefault {
+		return advisor.CompatibilityAddNotNull
+	}
+	return advisor.Ok
+}
+
+// getAddConstraintCode returns the incompatible code for the new constraint.
+func getAddConstraintCode(constraint *ast.ConstraintDef) advisor.Code {
+	switch constraint.Type {
+	case ast.ConstraintTypePrimary, ast.ConstraintTypePrimaryUsingIndex:
+		return advisor.CompatibilityAddPrimaryKey
+	case ast.ConstraintTypeUnique, ast.ConstraintTypeUniqueUsingIndex:
+		return advisor.CompatibilityAddUniqueKey
+	case ast.ConstraintTypeForeign:
+		return advisor.CompatibilityAddForeignKey
+	case ast.ConstraintTypeCheck:
+		return advisor.CompatibilityAddCheck
+	}
+	return advisor.Ok
+}
@@ -0,0 +1,300 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestCompatibility(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "DROP DATABASE d1",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropDatabase,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"DROP DATABASE d1\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "DROP TABLE t1",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"DROP TABLE t1\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 RENAME TO t2",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityRenameTable,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 RENAME TO t2\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 RENAME COLUMN a TO b",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityRenameColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 RENAME COLUMN a TO b\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 DROP COLUMN a",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 DROP COLUMN a\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ALTER COLUMN a TYPE BIGINT",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAlterColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ALTER COLUMN a TYPE BIGINT\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ALTER COLUMN a SET NOT NULL",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddNotNull,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ALTER COLUMN a SET NOT NULL\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD COLUMN a INT NOT NULL",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddNotNull,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD COLUMN a INT NOT NULL\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE tech_book ALTER COLUMN a SET NOT NULL",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddNotNull,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE tech_book ALTER COLUMN a SET NOT NULL\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE empty_book ALTER COLUMN a SET NOT NULL",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE empty_book ADD COLUMN a INT NOT NULL",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD COLUMN a INT NOT NULL DEFAULT 0",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD COLUMN a INT",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD COLUMN a INT PRIMARY KEY",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddPrimaryKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD COLUMN a INT PRIMARY KEY\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD CONSTRAINT t1_pk PRIMARY KEY (a)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddPrimaryKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD CONSTRAINT t1_pk PRIMARY KEY (a)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD CONSTRAINT t1_pk PRIMARY KEY USING INDEX t1_a_idx",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddPrimaryKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD CONSTRAINT t1_pk PRIMARY KEY USING INDEX t1_a_idx\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD CONSTRAINT t1_a_uk UNIQUE (a)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddUniqueKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD CONSTRAINT t1_a_uk UNIQUE (a)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD CONSTRAINT t1_a_fk FOREIGN KEY (a) REFERENCES t2 (id)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddForeignKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD CONSTRAINT t1_a_fk FOREIGN KEY (a) REFERENCES t2 (id)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD CONSTRAINT t1_a_check CHECK (a > 0)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddCheck,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD CONSTRAINT t1_a_check CHECK (a > 0)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 DROP CONSTRAINT t1_pkey",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropConstraint,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 DROP CONSTRAINT t1_pkey\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE UNIQUE INDEX t1_a_idx ON t1 (a)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityAddUniqueKey,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"CREATE UNIQUE INDEX t1_a_idx ON t1 (a)\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE INDEX t1_a_idx ON t1 (a)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t1(id INT PRIMARY KEY, a INT NOT NULL UNIQUE)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t1 ADD COLUMN a INT, DROP COLUMN b, ALTER COLUMN c TYPE TEXT",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.CompatibilityDropColumn,
+					Title:          "schema.backward-compatibility",
+					Content:        "\"ALTER TABLE t1 ADD COLUMN a INT, DROP COLUMN b, ALTER COLUMN c TYPE TEXT\" may cause incompatibility with the existing data and code",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &CompatibilityAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleSchemaBackwardCompatibility,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
@@ -41,7 +41,8 @@ func (adv *NamingColumnConventionAdvisor) Check(ctx advisor.Context, statement s
 		format: format,
 	}
 
-	for _, stmt := range stmts {
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
 		ast.Walk(checker, stmt)
 	}
 
@@ -57,10 +58,11 @@ func (adv *NamingColumnConventionAdvisor) Check(ctx advisor.Context, statement s
 }
 
 type namingColumnConventionChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
-	format     *regexp.Regexp
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	format         *regexp.Regexp
 }
 
 // Visit implements the ast.Visitor interface.
@@ -90,10 +92,11 @@ func (checker *namingColumnConventionChecker) Visit(node ast.Node) ast.Visitor {
 	for _, column := range columnList {
 		if !checker.format.MatchString(column) {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.NamingColumnConventionMismatch,
-				Title:   checker.title,
-				Content: fmt.Sprintf("\"%s\".\"%s\" mismatches column naming convention, naming format should be %q", tableName, column, checker.format),
+				Status:         checker.level,
+				Code:           advisor.NamingColumnConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("\"%s\".\"%s\" mismatches column naming convention, naming format should be %q", tableName, column, checker.format),
+				StatementIndex: checker.statementIndex,
 			})
 		}
 	}
@@ -14,10 +14,11 @@ func TestNamingColumnConvention(t *testing.T) {
 			Statement: "CREATE TABLE book(id int, \"creatorId\" int)",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "\"book\".\"creatorId\" mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "\"book\".\"creatorId\" mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -49,10 +50,11 @@ func TestNamingColumnConvention(t *testing.T) {
 						ALTER TABLE book ADD COLUMN "creatorId" int`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "\"book\".\"creatorId\" mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "\"book\".\"creatorId\" mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -73,10 +75,11 @@ func TestNamingColumnConvention(t *testing.T) {
 						ALTER TABLE book RENAME COLUMN creator_id TO "creatorId"`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Warn,
-					Code:    advisor.NamingColumnConventionMismatch,
-					Title:   "naming.column",
-					Content: "\"book\".\"creatorId\" mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Warn,
+					Code:           advisor.NamingColumnConventionMismatch,
+					Title:          "naming.column",
+					Content:        "\"book\".\"creatorId\" mismatches column naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 			},
 		},
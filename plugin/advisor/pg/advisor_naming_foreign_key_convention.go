@@ -0,0 +1,153 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*NamingFKConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLNamingFKConvention, &NamingFKConventionAdvisor{})
+}
+
+// NamingFKConventionAdvisor is the advisor checking for foreign key naming convention.
+type NamingFKConventionAdvisor struct {
+}
+
+// Check checks for foreign key naming convention.
+func (adv *NamingFKConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, templateList, err := advisor.UnmarshalNamingRulePayloadAsTemplate(ctx.Rule.Type, ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingFKConventionChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		format:       format,
+		templateList: templateList,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		checker.text = stmt.Text()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type namingFKConventionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	text           string
+	format         string
+	templateList   []string
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *namingFKConventionChecker) Visit(node ast.Node) ast.Visitor {
+	indexDataList := checker.getMetaDataList(node)
+
+	for _, indexData := range indexDataList {
+		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.Internal,
+				Title:          "Internal error for foreign key naming convention rule",
+				Content:        fmt.Sprintf("%q meet internal error %q", checker.text, err.Error()),
+				StatementIndex: checker.statementIndex,
+			})
+			continue
+		}
+		if !regex.MatchString(indexData.indexName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.NamingFKConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Foreign key in table %q mismatches the naming convention, expect %q but found %q", indexData.tableName, regex, indexData.indexName),
+				StatementIndex: checker.statementIndex,
+			})
+		}
+	}
+
+	return checker
+}
+
+// getMetaDataList returns the list of foreign key with meta data.
+func (checker *namingFKConventionChecker) getMetaDataList(in ast.Node) []*indexMetaData {
+	var res []*indexMetaData
+
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		var constraintList []*ast.ConstraintDef
+		for _, column := range node.ColumnList {
+			constraintList = append(constraintList, column.ConstraintList...)
+		}
+		constraintList = append(constraintList, node.ConstraintList...)
+		for _, constraint := range constraintList {
+			if metaData := getForeignKeyMetaData(node.Name.Name, constraint); metaData != nil {
+				res = append(res, metaData)
+			}
+		}
+	// ALTER TABLE ADD CONSTRAINT
+	case *ast.AddConstraintStmt:
+		if metaData := getForeignKeyMetaData(node.Table.Name, node.Constraint); metaData != nil {
+			res = append(res, metaData)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range node.ColumnList {
+			for _, constraint := range column.ConstraintList {
+				if metaData := getForeignKeyMetaData(node.Table.Name, constraint); metaData != nil {
+					res = append(res, metaData)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+func getForeignKeyMetaData(tableName string, constraint *ast.ConstraintDef) *indexMetaData {
+	if constraint.Type != ast.ConstraintTypeForeign {
+		return nil
+	}
+	metaData := map[string]string{
+		advisor.ReferencingTableNameTemplateToken:  tableName,
+		advisor.ReferencingColumnNameTemplateToken: strings.Join(constraint.KeyList, "_"),
+		advisor.ReferencedTableNameTemplateToken:   constraint.Foreign.Table.Name,
+		advisor.ReferencedColumnNameTemplateToken:  strings.Join(constraint.Foreign.ColumnList, "_"),
+	}
+	return &indexMetaData{
+		indexName: constraint.Name,
+		tableName: tableName,
+		metaData:  metaData,
+	}
+}
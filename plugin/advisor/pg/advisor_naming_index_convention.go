@@ -0,0 +1,163 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*NamingIndexConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLNamingIndexConvention, &NamingIndexConventionAdvisor{})
+}
+
+// NamingIndexConventionAdvisor is the advisor checking for index naming convention.
+type NamingIndexConventionAdvisor struct {
+}
+
+// Check checks for index naming convention.
+func (adv *NamingIndexConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, templateList, err := advisor.UnmarshalNamingRulePayloadAsTemplate(ctx.Rule.Type, ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingIndexConventionChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		format:       format,
+		templateList: templateList,
+		catalog:      ctx.Catalog,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		checker.text = stmt.Text()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type namingIndexConventionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	text           string
+	format         string
+	templateList   []string
+	catalog        catalog.Catalog
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *namingIndexConventionChecker) Visit(node ast.Node) ast.Visitor {
+	indexDataList := checker.getMetaDataList(node)
+
+	for _, indexData := range indexDataList {
+		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.Internal,
+				Title:          "Internal error for index naming convention rule",
+				Content:        fmt.Sprintf("%q meet internal error %q", checker.text, err.Error()),
+				StatementIndex: checker.statementIndex,
+			})
+			continue
+		}
+		if !regex.MatchString(indexData.indexName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.NamingIndexConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Index in table %q mismatches the naming convention, expect %q but found %q", indexData.tableName, regex, indexData.indexName),
+				StatementIndex: checker.statementIndex,
+			})
+		}
+	}
+
+	return checker
+}
+
+// getMetaDataList returns the list of index with meta data.
+func (checker *namingIndexConventionChecker) getMetaDataList(in ast.Node) []*indexMetaData {
+	var res []*indexMetaData
+
+	switch node := in.(type) {
+	// CREATE INDEX
+	case *ast.CreateIndexStmt:
+		if node.Index.Unique {
+			// Unique index naming convention should in advisor_naming_unique_key_convention.go
+			break
+		}
+		var columnList []string
+		for _, key := range node.Index.KeyList {
+			columnList = append(columnList, key.Key)
+		}
+		metaData := map[string]string{
+			advisor.ColumnListTemplateToken: strings.Join(columnList, "_"),
+			advisor.TableNameTemplateToken:  node.Index.Table.Name,
+		}
+		res = append(res, &indexMetaData{
+			indexName: node.Index.Name,
+			tableName: node.Index.Table.Name,
+			metaData:  metaData,
+		})
+	// ALTER INDEX RENAME TO
+	case *ast.RenameIndexStmt:
+		index := findIndex(checker.catalog, node.IndexName)
+		if index == nil || index.Unique {
+			// Unique index naming convention should in advisor_naming_unique_key_convention.go
+			break
+		}
+		metaData := map[string]string{
+			advisor.ColumnListTemplateToken: strings.Join(index.ColumnExpressions, "_"),
+			advisor.TableNameTemplateToken:  index.TableName,
+		}
+		res = append(res, &indexMetaData{
+			indexName: node.NewName,
+			tableName: index.TableName,
+			metaData:  metaData,
+		})
+	}
+
+	return res
+}
+
+// findIndex finds the index by name in the catalog, and returns nil if not found.
+// The index name is unique in the PostgreSQL schema, so we don't need the table name.
+func findIndex(c catalog.Catalog, indexName string) *catalog.Index {
+	index, err := c.FindIndex(context.Background(), &catalog.IndexFind{
+		IndexName: indexName,
+	})
+	if err != nil {
+		log.Printf("Cannot find index %s with error %v\n", indexName, err)
+		return nil
+	}
+	return index
+}
@@ -0,0 +1,91 @@
+package pg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingIndexConvention(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE INDEX idx_tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE INDEX tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.NamingIndexConventionMismatch,
+					Title:          "naming.index.idx",
+					Content:        "Index in table \"tech_book\" mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found \"tech_book_id_name\"",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			// The unique index is checked by the unique key naming convention rule.
+			Statement: "CREATE UNIQUE INDEX tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: fmt.Sprintf(
+				"ALTER INDEX %s RENAME TO idx_tech_book_%s",
+				advisor.MockOldIndexName,
+				strings.Join(advisor.MockIndexColumnList, "_"),
+			),
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: fmt.Sprintf(
+				"ALTER INDEX %s RENAME TO idx_tech_book",
+				advisor.MockOldIndexName,
+			),
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.NamingIndexConventionMismatch,
+					Title:          "naming.index.idx",
+					Content:        "Index in table \"tech_book\" mismatches the naming convention, expect \"^idx_tech_book_id_name$\" but found \"idx_tech_book\"",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NamingRulePayload{
+		Format: "^idx_{{table}}_{{column_list}}$",
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &NamingIndexConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleIDXNaming,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
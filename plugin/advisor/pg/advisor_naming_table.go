@@ -41,7 +41,8 @@ func (adv *NamingTableConventionAdvisor) Check(ctx advisor.Context, statement st
 		format: format,
 	}
 
-	for _, stmt := range stmts {
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
 		ast.Walk(checker, stmt)
 	}
 
@@ -57,10 +58,11 @@ func (adv *NamingTableConventionAdvisor) Check(ctx advisor.Context, statement st
 }
 
 type namingTableConventionChecker struct {
-	adviceList []advisor.Advice
-	level      advisor.Status
-	title      string
-	format     *regexp.Regexp
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	format         *regexp.Regexp
 }
 
 // Visit implements the ast.Visitor interface.
@@ -79,10 +81,11 @@ func (checker *namingTableConventionChecker) Visit(node ast.Node) ast.Visitor {
 	for _, tableName := range tableNames {
 		if !checker.format.MatchString(tableName) {
 			checker.adviceList = append(checker.adviceList, advisor.Advice{
-				Status:  checker.level,
-				Code:    advisor.NamingTableConventionMismatch,
-				Title:   checker.title,
-				Content: fmt.Sprintf(`"%s" mismatches table naming convention, naming format should be %q`, tableName, checker.format),
+				Status:         checker.level,
+				Code:           advisor.NamingTableConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf(`"%s" mismatches table naming convention, naming format should be %q`, tableName, checker.format),
+				StatementIndex: checker.statementIndex,
 			})
 		}
 	}
@@ -14,10 +14,11 @@ func TestPostgreSQLNamingTableConvention(t *testing.T) {
 			Statement: "CREATE TABLE \"techBook\"(id int, name varchar(255))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "\"techBook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "\"techBook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -25,10 +26,11 @@ func TestPostgreSQLNamingTableConvention(t *testing.T) {
 			Statement: "CREATE TABLE _techBook(id int, name varchar(255))",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "\"_techbook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "\"_techbook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -59,10 +61,11 @@ func TestPostgreSQLNamingTableConvention(t *testing.T) {
 			Statement: "ALTER TABLE tech_book RENAME TO \"TechBook\"",
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "\"TechBook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "\"TechBook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 			},
 		},
@@ -82,16 +85,18 @@ func TestPostgreSQLNamingTableConvention(t *testing.T) {
 						ALTER TABLE tech_book RENAME TO "TechBook";`,
 			Want: []advisor.Advice{
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "\"_techbook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "\"_techbook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 1,
 				},
 				{
-					Status:  advisor.Error,
-					Code:    advisor.NamingTableConventionMismatch,
-					Title:   "naming.table",
-					Content: "\"TechBook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					Status:         advisor.Error,
+					Code:           advisor.NamingTableConventionMismatch,
+					Title:          "naming.table",
+					Content:        "\"TechBook\" mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+					StatementIndex: 2,
 				},
 			},
 		},
@@ -0,0 +1,198 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*NamingUKConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLNamingUKConvention, &NamingUKConventionAdvisor{})
+}
+
+// NamingUKConventionAdvisor is the advisor checking for unique key naming convention.
+type NamingUKConventionAdvisor struct {
+}
+
+// Check checks for unique key naming convention.
+func (adv *NamingUKConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	format, templateList, err := advisor.UnmarshalNamingRulePayloadAsTemplate(ctx.Rule.Type, ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &namingUKConventionChecker{
+		level:        level,
+		title:        string(ctx.Rule.Type),
+		format:       format,
+		templateList: templateList,
+		catalog:      ctx.Catalog,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		checker.text = stmt.Text()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type namingUKConventionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	text           string
+	format         string
+	templateList   []string
+	catalog        catalog.Catalog
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *namingUKConventionChecker) Visit(node ast.Node) ast.Visitor {
+	indexDataList := checker.getMetaDataList(node)
+
+	for _, indexData := range indexDataList {
+		regex, err := getTemplateRegexp(checker.format, checker.templateList, indexData.metaData)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.Internal,
+				Title:          "Internal error for unique key naming convention rule",
+				Content:        fmt.Sprintf("%q meet internal error %q", checker.text, err.Error()),
+				StatementIndex: checker.statementIndex,
+			})
+			continue
+		}
+		if !regex.MatchString(indexData.indexName) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.NamingUKConventionMismatch,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Unique key in table %q mismatches the naming convention, expect %q but found %q", indexData.tableName, regex, indexData.indexName),
+				StatementIndex: checker.statementIndex,
+			})
+		}
+	}
+
+	return checker
+}
+
+// getMetaDataList returns the list of unique key with meta data.
+func (checker *namingUKConventionChecker) getMetaDataList(in ast.Node) []*indexMetaData {
+	var res []*indexMetaData
+
+	switch node := in.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		var constraintList []*ast.ConstraintDef
+		for _, column := range node.ColumnList {
+			constraintList = append(constraintList, column.ConstraintList...)
+		}
+		constraintList = append(constraintList, node.ConstraintList...)
+		for _, constraint := range constraintList {
+			if metaData := getUniqueKeyMetaData(node.Name.Name, constraint); metaData != nil {
+				res = append(res, metaData)
+			}
+		}
+	// ALTER TABLE ADD CONSTRAINT
+	case *ast.AddConstraintStmt:
+		if metaData := getUniqueKeyMetaData(node.Table.Name, node.Constraint); metaData != nil {
+			res = append(res, metaData)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range node.ColumnList {
+			for _, constraint := range column.ConstraintList {
+				if metaData := getUniqueKeyMetaData(node.Table.Name, constraint); metaData != nil {
+					res = append(res, metaData)
+				}
+			}
+		}
+	// CREATE UNIQUE INDEX
+	case *ast.CreateIndexStmt:
+		if node.Index.Unique {
+			var columnList []string
+			for _, key := range node.Index.KeyList {
+				columnList = append(columnList, key.Key)
+			}
+			metaData := map[string]string{
+				advisor.ColumnListTemplateToken: strings.Join(columnList, "_"),
+				advisor.TableNameTemplateToken:  node.Index.Table.Name,
+			}
+			res = append(res, &indexMetaData{
+				indexName: node.Index.Name,
+				tableName: node.Index.Table.Name,
+				metaData:  metaData,
+			})
+		}
+	// ALTER INDEX RENAME TO
+	case *ast.RenameIndexStmt:
+		if metaData := checker.getRenamedUniqueKeyMetaData(node.IndexName, node.NewName); metaData != nil {
+			res = append(res, metaData)
+		}
+	// ALTER TABLE RENAME CONSTRAINT
+	case *ast.RenameConstraintStmt:
+		// The unique constraint is backed by the unique index with the same name.
+		if metaData := checker.getRenamedUniqueKeyMetaData(node.ConstraintName, node.NewName); metaData != nil {
+			res = append(res, metaData)
+		}
+	}
+
+	return res
+}
+
+func (checker *namingUKConventionChecker) getRenamedUniqueKeyMetaData(oldName string, newName string) *indexMetaData {
+	index := findIndex(checker.catalog, oldName)
+	if index == nil || !index.Unique {
+		return nil
+	}
+	metaData := map[string]string{
+		advisor.ColumnListTemplateToken: strings.Join(index.ColumnExpressions, "_"),
+		advisor.TableNameTemplateToken:  index.TableName,
+	}
+	return &indexMetaData{
+		indexName: newName,
+		tableName: index.TableName,
+		metaData:  metaData,
+	}
+}
+
+func getUniqueKeyMetaData(tableName string, constraint *ast.ConstraintDef) *indexMetaData {
+	if constraint.Type != ast.ConstraintTypeUnique {
+		return nil
+	}
+	metaData := map[string]string{
+		advisor.ColumnListTemplateToken: strings.Join(constraint.KeyList, "_"),
+		advisor.TableNameTemplateToken:  tableName,
+	}
+	return &indexMetaData{
+		indexName: constraint.Name,
+		tableName: tableName,
+		metaData:  metaData,
+	}
+}
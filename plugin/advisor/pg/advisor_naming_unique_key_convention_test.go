@@ -0,0 +1,125 @@
+package pg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamingUKConvention(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE UNIQUE INDEX uk_tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE UNIQUE INDEX tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table \"tech_book\" mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found \"tech_book_id_name\"",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE tech_book ADD CONSTRAINT uk_tech_book_id_name UNIQUE (id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE tech_book ADD CONSTRAINT tech_book_id_name UNIQUE (id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table \"tech_book\" mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found \"tech_book_id_name\"",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE tech_book(id INT PRIMARY KEY, name VARCHAR(20) CONSTRAINT uk_tech_book_name UNIQUE, CONSTRAINT uk_tech_book_id_name UNIQUE (id, name))",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE tech_book(id INT PRIMARY KEY, name VARCHAR(20) UNIQUE)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table \"tech_book\" mismatches the naming convention, expect \"^uk_tech_book_name$\" but found \"\"",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: fmt.Sprintf(
+				"ALTER INDEX %s RENAME TO uk_tech_book_%s",
+				advisor.MockOldUKName,
+				strings.Join(advisor.MockIndexColumnList, "_"),
+			),
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: fmt.Sprintf(
+				"ALTER TABLE tech_book RENAME CONSTRAINT %s TO uk_tech_book",
+				advisor.MockOldUKName,
+			),
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.NamingUKConventionMismatch,
+					Title:          "naming.index.uk",
+					Content:        "Unique key in table \"tech_book\" mismatches the naming convention, expect \"^uk_tech_book_id_name$\" but found \"uk_tech_book\"",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NamingRulePayload{
+		Format: "^uk_{{table}}_{{column_list}}$",
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &NamingUKConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleUKNaming,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
@@ -0,0 +1,89 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+const (
+	wildcard = "%"
+)
+
+var (
+	_ advisor.Advisor = (*NoLeadingWildcardLikeAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLNoLeadingWildcardLike, &NoLeadingWildcardLikeAdvisor{})
+}
+
+// NoLeadingWildcardLikeAdvisor is the advisor checking for no leading wildcard LIKE.
+type NoLeadingWildcardLikeAdvisor struct {
+}
+
+// Check checks for no leading wildcard LIKE.
+func (adv *NoLeadingWildcardLikeAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &noLeadingWildcardLikeChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		checker.text = stmt.Text()
+		checker.leadingWildcardLike = false
+		ast.Walk(checker, stmt)
+
+		if checker.leadingWildcardLike {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.StatementLeadingWildcardLike,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("\"%s\" uses leading wildcard LIKE", checker.text),
+				StatementIndex: checker.statementIndex,
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type noLeadingWildcardLikeChecker struct {
+	adviceList          []advisor.Advice
+	level               advisor.Status
+	title               string
+	statementIndex      int
+	text                string
+	leadingWildcardLike bool
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *noLeadingWildcardLikeChecker) Visit(node ast.Node) ast.Visitor {
+	if n, ok := node.(*ast.PatternLikeDef); !checker.leadingWildcardLike && ok {
+		// We can only check the constant pattern.
+		if pattern, ok := n.Pattern.(*ast.StringDef); ok && strings.HasPrefix(pattern.Value, wildcard) {
+			checker.leadingWildcardLike = true
+		}
+	}
+	return checker
+}
@@ -0,0 +1,88 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestNoSelectAll(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "SELECT * FROM t",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementSelectAll,
+					Title:          "statement.select.no-select-all",
+					Content:        "\"SELECT * FROM t\" uses SELECT all",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "SELECT t.* FROM t",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementSelectAll,
+					Title:          "statement.select.no-select-all",
+					Content:        "\"SELECT t.* FROM t\" uses SELECT all",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "SELECT a, b FROM t",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "SELECT a, b FROM (SELECT * FROM t1, t2) t",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "SELECT a FROM t WHERE a IN (SELECT * FROM t2)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementSelectAll,
+					Title:          "statement.select.no-select-all",
+					Content:        "\"SELECT a FROM t WHERE a IN (SELECT * FROM t2)\" uses SELECT all",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "INSERT INTO t2 SELECT * FROM t1",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementSelectAll,
+					Title:          "statement.select.no-select-all",
+					Content:        "\"INSERT INTO t2 SELECT * FROM t1\" uses SELECT all",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &NoSelectAllAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleStatementNoSelectAll,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
@@ -0,0 +1,95 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*WhereRequirementAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLWhereRequirement, &WhereRequirementAdvisor{})
+}
+
+// WhereRequirementAdvisor is the advisor checking for WHERE clause requirement.
+type WhereRequirementAdvisor struct {
+}
+
+// Check checks for the WHERE clause requirement.
+func (adv *WhereRequirementAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &whereRequirementChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		checker.text = stmt.Text()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type whereRequirementChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	text           string
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *whereRequirementChecker) Visit(node ast.Node) ast.Visitor {
+	code := advisor.Ok
+	switch n := node.(type) {
+	// DELETE
+	case *ast.DeleteStmt:
+		if n.WhereClause == nil {
+			code = advisor.StatementNoWhere
+		}
+	// UPDATE
+	case *ast.UpdateStmt:
+		if n.WhereClause == nil {
+			code = advisor.StatementNoWhere
+		}
+	// SELECT
+	case *ast.SelectStmt:
+		// The WHERE clauses of the UNION/INTERSECT/EXCEPT are in the sub-queries.
+		if n.SetOperation == ast.SetOperationTypeNone && n.WhereClause == nil {
+			code = advisor.StatementNoWhere
+		}
+	}
+
+	if code != advisor.Ok {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:         checker.level,
+			Code:           code,
+			Title:          checker.title,
+			Content:        fmt.Sprintf("\"%s\" requires WHERE clause", checker.text),
+			StatementIndex: checker.statementIndex,
+		})
+	}
+	return checker
+}
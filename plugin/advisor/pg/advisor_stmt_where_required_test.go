@@ -0,0 +1,111 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestWhereRequirement(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "DELETE FROM t1",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"DELETE FROM t1\" requires WHERE clause",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "UPDATE t1 SET a = 1",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"UPDATE t1 SET a = 1\" requires WHERE clause",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "DELETE FROM t1 WHERE a > 0",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "UPDATE t1 SET a = 1 WHERE a > 10",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "SELECT a FROM t",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"SELECT a FROM t\" requires WHERE clause",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "SELECT a FROM t WHERE a > 0",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "SELECT a FROM t WHERE a > (SELECT max(id) FROM user)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"SELECT a FROM t WHERE a > (SELECT max(id) FROM user)\" requires WHERE clause",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "SELECT a FROM t WHERE a > 0 UNION SELECT b FROM t2",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.StatementNoWhere,
+					Title:          "statement.where.require",
+					Content:        "\"SELECT a FROM t WHERE a > 0 UNION SELECT b FROM t2\" requires WHERE clause",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &WhereRequirementAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleStatementRequireWhere,
+		Level:   advisor.SchemaRuleLevelError,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
@@ -0,0 +1,141 @@
+package pg
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*TableCommentConventionAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLTableCommentConvention, &TableCommentConventionAdvisor{})
+}
+
+// TableCommentConventionAdvisor is the advisor checking for table comment convention.
+type TableCommentConventionAdvisor struct {
+}
+
+// Check checks for table comment convention.
+// PostgreSQL comments the table by the COMMENT ON TABLE statement, so we check the tables created in the statement at the end.
+func (adv *TableCommentConventionAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalCommentConventionRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &tableCommentConventionChecker{
+		level:               level,
+		title:               string(ctx.Rule.Type),
+		required:            payload.Required,
+		maxLength:           payload.MaxLength,
+		tables:              make(map[string]bool),
+		tableStatementIndex: make(map[string]int),
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	return checker.generateAdviceList(), nil
+}
+
+type tableCommentConventionChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	required       bool
+	maxLength      int
+	// tables is the map from the table created in the statement to whether it has the comment.
+	tables map[string]bool
+	// tableStatementIndex is the index of the statement creating the table, and the advice is for it.
+	tableStatementIndex map[string]int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *tableCommentConventionChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		checker.tables[n.Name.Name] = false
+		checker.tableStatementIndex[n.Name.Name] = checker.statementIndex
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			delete(checker.tables, table.Name)
+		}
+	// ALTER TABLE RENAME
+	case *ast.RenameTableStmt:
+		if commented, ok := checker.tables[n.Table.Name]; ok {
+			delete(checker.tables, n.Table.Name)
+			checker.tables[n.NewName] = commented
+			checker.tableStatementIndex[n.NewName] = checker.tableStatementIndex[n.Table.Name]
+		}
+	// COMMENT ON TABLE
+	case *ast.CommentStmt:
+		if n.Type != ast.CommentObjectTypeTable {
+			break
+		}
+		table := n.Table.Name
+		if _, ok := checker.tables[table]; ok {
+			checker.tables[table] = n.Comment != ""
+		}
+		if checker.maxLength > 0 && len(n.Comment) > checker.maxLength {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.TableCommentTooLong,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("The length of table %q comment should be within %d characters", table, checker.maxLength),
+				StatementIndex: checker.statementIndex,
+			})
+		}
+	}
+
+	return checker
+}
+
+func (checker *tableCommentConventionChecker) generateAdviceList() []advisor.Advice {
+	if checker.required {
+		var tableList []string
+		for table, commented := range checker.tables {
+			if !commented {
+				tableList = append(tableList, table)
+			}
+		}
+		// Order it cause the random iteration order in Go, see https://go.dev/blog/maps
+		sort.Strings(tableList)
+		for _, table := range tableList {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.NoTableComment,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Table %q requires comments", table),
+				StatementIndex: checker.tableStatementIndex[table],
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList
+}
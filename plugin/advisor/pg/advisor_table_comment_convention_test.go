@@ -0,0 +1,76 @@
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableCommentConvention(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: `CREATE TABLE t(a int);
+				COMMENT ON TABLE t IS 'some comments'`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(a int)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoTableComment,
+					Title:          "table.comment",
+					Content:        "Table \"t\" requires comments",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(a int);
+				COMMENT ON TABLE t IS 'this is a very long comment'`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.TableCommentTooLong,
+					Title:          "table.comment",
+					Content:        "The length of table \"t\" comment should be within 20 characters",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(a int);
+				ALTER TABLE t RENAME TO t2;
+				COMMENT ON TABLE t2 IS NULL`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.NoTableComment,
+					Title:          "table.comment",
+					Content:        "Table \"t2\" requires comments",
+					StatementIndex: 1,
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.CommentConventionRulePayload{
+		Required:  true,
+		MaxLength: 20,
+	})
+	require.NoError(t, err)
+	advisor.RunSchemaReviewRuleTests(t, tests, &TableCommentConventionAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleTableCommentConvention,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, &advisor.MockCatalogService{})
+}
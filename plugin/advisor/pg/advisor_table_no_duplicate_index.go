@@ -0,0 +1,250 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*NoDuplicateIndexAdvisor)(nil)
+)
+
+func init() {
+	advisor.Register(advisor.Postgres, advisor.PostgreSQLNoDuplicateIndex, &NoDuplicateIndexAdvisor{})
+}
+
+// NoDuplicateIndexAdvisor is the advisor checking for no duplicate index.
+type NoDuplicateIndexAdvisor struct {
+}
+
+// Check checks for no duplicate index.
+func (adv *NoDuplicateIndexAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &noDuplicateIndexChecker{
+		level:   level,
+		title:   string(ctx.Rule.Type),
+		tables:  make(map[string][]*indexColumns),
+		catalog: ctx.Catalog,
+	}
+
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+// indexColumns is the index with its ordered column list.
+type indexColumns struct {
+	name       string
+	unique     bool
+	columnList []string
+}
+
+type noDuplicateIndexChecker struct {
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	// tables is the map from table name to its indexes, including the existing ones in the catalog.
+	tables  map[string][]*indexColumns
+	catalog catalog.Catalog
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *noDuplicateIndexChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		table := n.Name.Name
+		checker.tables[table] = nil
+		for _, column := range n.ColumnList {
+			for _, constraint := range column.ConstraintList {
+				if index := convertConstraintToIndex(table, constraint); index != nil {
+					checker.addIndex(table, index)
+				}
+			}
+		}
+		for _, constraint := range n.ConstraintList {
+			if index := convertConstraintToIndex(table, constraint); index != nil {
+				checker.addIndex(table, index)
+			}
+		}
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			checker.tables[table.Name] = nil
+		}
+	// ALTER TABLE ADD CONSTRAINT
+	case *ast.AddConstraintStmt:
+		table := n.Table.Name
+		if index := convertConstraintToIndex(table, n.Constraint); index != nil {
+			checker.loadIndexList(table)
+			checker.addIndex(table, index)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		table := n.Table.Name
+		for _, column := range n.ColumnList {
+			for _, constraint := range column.ConstraintList {
+				if index := convertConstraintToIndex(table, constraint); index != nil {
+					checker.loadIndexList(table)
+					checker.addIndex(table, index)
+				}
+			}
+		}
+	// ALTER TABLE DROP CONSTRAINT
+	case *ast.DropConstraintStmt:
+		checker.loadIndexList(n.Table.Name)
+		checker.dropIndex(n.Table.Name, n.ConstraintName)
+	// CREATE INDEX
+	case *ast.CreateIndexStmt:
+		table := n.Index.Table.Name
+		var columnList []string
+		for _, key := range n.Index.KeyList {
+			if key.Type != ast.IndexKeyTypeColumn {
+				return nil
+			}
+			columnList = append(columnList, key.Key)
+		}
+		name := n.Index.Name
+		if name == "" {
+			name = fmt.Sprintf("%s_%s_idx", table, strings.Join(columnList, "_"))
+		}
+		checker.loadIndexList(table)
+		checker.addIndex(table, &indexColumns{
+			name:       name,
+			unique:     n.Index.Unique,
+			columnList: columnList,
+		})
+	// DROP INDEX
+	case *ast.DropIndexStmt:
+		for _, index := range n.IndexList {
+			// The index name is unique in the schema, so we find its table in the catalog.
+			if checker.catalog != nil {
+				if catalogIndex := findIndex(checker.catalog, index.Name); catalogIndex != nil {
+					checker.loadIndexList(catalogIndex.TableName)
+				}
+			}
+			for table := range checker.tables {
+				checker.dropIndex(table, index.Name)
+			}
+		}
+	}
+
+	return checker
+}
+
+// loadIndexList loads the existing indexes of the table from the catalog if the table is not seen before.
+func (checker *noDuplicateIndexChecker) loadIndexList(table string) {
+	if _, ok := checker.tables[table]; ok {
+		return
+	}
+	checker.tables[table] = nil
+	if checker.catalog == nil {
+		return
+	}
+	tableInfo, err := checker.catalog.FindTable(context.Background(), &catalog.TableFind{
+		TableName: table,
+	})
+	if err != nil {
+		log.Printf("Cannot find table %s with error %v\n", table, err)
+		return
+	}
+	if tableInfo == nil {
+		return
+	}
+	for _, index := range tableInfo.IndexList {
+		checker.tables[table] = append(checker.tables[table], &indexColumns{
+			name:       index.Name,
+			unique:     index.Unique,
+			columnList: index.ColumnExpressions,
+		})
+	}
+}
+
+// addIndex adds the index to the table, and reports it if there is an index covering it already.
+// A unique index with the same columns as a non-unique index is not duplicate, because it adds the constraint.
+func (checker *noDuplicateIndexChecker) addIndex(table string, index *indexColumns) {
+	for _, existing := range checker.tables[table] {
+		if (existing.unique || !index.unique) && isSameColumnList(existing.columnList, index.columnList) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.DuplicateIndex,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Index %q in table %q duplicates the index %q on (%s)", index.name, table, existing.name, strings.Join(index.columnList, ", ")),
+				StatementIndex: checker.statementIndex,
+			})
+			break
+		}
+	}
+	checker.tables[table] = append(checker.tables[table], index)
+}
+
+func (checker *noDuplicateIndexChecker) dropIndex(table string, name string) {
+	var indexList []*indexColumns
+	for _, index := range checker.tables[table] {
+		if index.name != name {
+			indexList = append(indexList, index)
+		}
+	}
+	checker.tables[table] = indexList
+}
+
+// convertConstraintToIndex returns the index created by the constraint, or nil if the constraint doesn't create an index.
+// The unnamed constraint uses the name generated by PostgreSQL.
+func convertConstraintToIndex(table string, constraint *ast.ConstraintDef) *indexColumns {
+	name := constraint.Name
+	switch constraint.Type {
+	case ast.ConstraintTypePrimary:
+		if name == "" {
+			name = defaultPKName(table)
+		}
+	case ast.ConstraintTypeUnique:
+		if name == "" {
+			name = fmt.Sprintf("%s_%s_key", table, strings.Join(constraint.KeyList, "_"))
+		}
+	default:
+		return nil
+	}
+	return &indexColumns{
+		name:       name,
+		unique:     true,
+		columnList: constraint.KeyList,
+	}
+}
+
+func isSameColumnList(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+)
+
+func TestNoDuplicateIndex(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "CREATE TABLE t(id INT PRIMARY KEY, name TEXT UNIQUE); CREATE INDEX idx_t_id_name ON t(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id INT PRIMARY KEY, name TEXT); CREATE INDEX idx_t_id ON t(id)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DuplicateIndex,
+					Title:          "table.no-duplicate-index",
+					Content:        "Index \"idx_t_id\" in table \"t\" duplicates the index \"t_pkey\" on (id)",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id INT, name TEXT, CONSTRAINT uk_t_name UNIQUE (name)); ALTER TABLE t ADD CONSTRAINT uk_t_name_2 UNIQUE (name)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DuplicateIndex,
+					Title:          "table.no-duplicate-index",
+					Content:        "Index \"uk_t_name_2\" in table \"t\" duplicates the index \"uk_t_name\" on (name)",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: "CREATE INDEX idx_tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DuplicateIndex,
+					Title:          "table.no-duplicate-index",
+					Content:        "Index \"idx_tech_book_id_name\" in table \"tech_book\" duplicates the index \"old_index\" on (id, name)",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: "DROP INDEX old_index; ALTER TABLE tech_book DROP CONSTRAINT old_uk, DROP CONSTRAINT \"PRIMARY\"; CREATE INDEX idx_tech_book_id_name ON tech_book(id, name)",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE INDEX ON t(a); CREATE INDEX ON t(a)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Warn,
+					Code:           advisor.DuplicateIndex,
+					Title:          "table.no-duplicate-index",
+					Content:        "Index \"t_a_idx\" in table \"t\" duplicates the index \"t_a_idx\" on (a)",
+					StatementIndex: 2,
+				},
+			},
+		},
+	}
+
+	advisor.RunSchemaReviewRuleTests(t, tests, &NoDuplicateIndexAdvisor{}, &advisor.SchemaReviewRule{
+		Type:    advisor.SchemaRuleTableNoDuplicateIndex,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, &advisor.MockCatalogService{})
+}
@@ -1,7 +1,12 @@
 package pg
 
 import (
+	"context"
+	"fmt"
+	"log"
+
 	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
 	"github.com/youzi-1122/bytebase/plugin/parser/ast"
 )
 
@@ -24,30 +29,206 @@ func (adv *TableRequirePKAdvisor) Check(ctx advisor.Context, statement string) (
 		return errAdvice, nil
 	}
 
-	_, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
+	level, err := advisor.NewStatusBySchemaReviewRuleLevel(ctx.Rule.Level)
 	if err != nil {
 		return nil, err
 	}
+	checker := &tableRequirePKChecker{
+		level:               level,
+		title:               string(ctx.Rule.Type),
+		tables:              make(tableState),
+		pkNames:             make(map[string]string),
+		tableStatementIndex: make(map[string]int),
+		catalog:             ctx.Catalog,
+	}
 
-	checker := &tableRequirePKChecker{}
-
-	for _, stmt := range stmts {
+	for i, stmt := range stmts {
+		checker.statementIndex = i + 1
 		ast.Walk(checker, stmt)
 	}
 
-	return []advisor.Advice{}, nil
+	return checker.generateAdviceList(), nil
 }
 
-// TODO(rebelice): fill the implementation to check table PK.
 type tableRequirePKChecker struct {
-	// adviceList []advisor.Advice
-	// level      advisor.Status
-	// title      string
-	// tables:  make(tablePK),
-	// catalog catalog.Catalog
+	adviceList     []advisor.Advice
+	level          advisor.Status
+	title          string
+	statementIndex int
+	// tables is the PK columns of the tables.
+	tables tableState
+	// pkNames is the PK constraint names of the tables in tables.
+	pkNames map[string]string
+	// tableStatementIndex is the index of the last statement changing the PK of the table, and the advice is for it.
+	tableStatementIndex map[string]int
+	catalog             catalog.Catalog
 }
 
 // Visit implements the ast.Visitor interface.
 func (checker *tableRequirePKChecker) Visit(node ast.Node) ast.Visitor {
+	switch n := node.(type) {
+	// CREATE TABLE
+	case *ast.CreateTableStmt:
+		checker.createTable(n)
+	// DROP TABLE
+	case *ast.DropTableStmt:
+		for _, table := range n.TableList {
+			delete(checker.tables, table.Name)
+			delete(checker.pkNames, table.Name)
+		}
+	// ALTER TABLE RENAME TO
+	case *ast.RenameTableStmt:
+		if pk, ok := checker.tables[n.Table.Name]; ok {
+			checker.tables[n.NewName] = pk
+			checker.pkNames[n.NewName] = checker.pkNames[n.Table.Name]
+			checker.tableStatementIndex[n.NewName] = checker.statementIndex
+			delete(checker.tables, n.Table.Name)
+			delete(checker.pkNames, n.Table.Name)
+		}
+	// ALTER TABLE ADD COLUMN
+	case *ast.AddColumnListStmt:
+		for _, column := range n.ColumnList {
+			for _, constraint := range column.ConstraintList {
+				if constraint.Type == ast.ConstraintTypePrimary {
+					checker.setPK(n.Table.Name, constraint.Name, constraint.KeyList)
+				}
+			}
+		}
+	// ALTER TABLE ADD CONSTRAINT
+	case *ast.AddConstraintStmt:
+		switch n.Constraint.Type {
+		case ast.ConstraintTypePrimary:
+			checker.setPK(n.Table.Name, n.Constraint.Name, n.Constraint.KeyList)
+		case ast.ConstraintTypePrimaryUsingIndex:
+			index := findIndex(checker.catalog, n.Constraint.IndexName)
+			if index == nil {
+				// The index may be created in the same statement, and we cannot tell its columns.
+				delete(checker.tables, n.Table.Name)
+				delete(checker.pkNames, n.Table.Name)
+				break
+			}
+			// The index will be renamed to the constraint name if the constraint name is specified.
+			name := n.Constraint.Name
+			if name == "" {
+				name = n.Constraint.IndexName
+			}
+			checker.setPK(n.Table.Name, name, index.ColumnExpressions)
+		}
+	// ALTER TABLE DROP CONSTRAINT
+	case *ast.DropConstraintStmt:
+		if n.ConstraintName == checker.getPKName(n.Table.Name) {
+			checker.setPK(n.Table.Name, "", nil)
+		}
+	// ALTER TABLE RENAME CONSTRAINT
+	case *ast.RenameConstraintStmt:
+		if n.ConstraintName == checker.getPKName(n.Table.Name) {
+			checker.loadPK(n.Table.Name)
+			checker.pkNames[n.Table.Name] = n.NewName
+		}
+	// ALTER TABLE DROP COLUMN
+	case *ast.DropColumnStmt:
+		checker.loadPK(n.Table.Name)
+		if pk, ok := checker.tables[n.Table.Name]; ok && pk[n.ColumnName] {
+			// PostgreSQL drops the PK with its column.
+			checker.setPK(n.Table.Name, "", nil)
+		}
+	// ALTER TABLE RENAME COLUMN
+	case *ast.RenameColumnStmt:
+		if pk, ok := checker.tables[n.Table.Name]; ok && pk[n.ColumnName] {
+			delete(pk, n.ColumnName)
+			pk[n.NewName] = true
+		}
+	}
+
 	return checker
 }
+
+func (checker *tableRequirePKChecker) generateAdviceList() []advisor.Advice {
+	tableList := checker.tables.tableList()
+	for _, tableName := range tableList {
+		if len(checker.tables[tableName]) == 0 {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:         checker.level,
+				Code:           advisor.TableNoPK,
+				Title:          checker.title,
+				Content:        fmt.Sprintf("Table %q requires PRIMARY KEY", tableName),
+				StatementIndex: checker.tableStatementIndex[tableName],
+			})
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList
+}
+
+func (checker *tableRequirePKChecker) createTable(node *ast.CreateTableStmt) {
+	table := node.Name.Name
+	checker.setPK(table, "", nil)
+
+	for _, column := range node.ColumnList {
+		for _, constraint := range column.ConstraintList {
+			if constraint.Type == ast.ConstraintTypePrimary {
+				checker.setPK(table, constraint.Name, constraint.KeyList)
+			}
+		}
+	}
+	for _, constraint := range node.ConstraintList {
+		if constraint.Type == ast.ConstraintTypePrimary {
+			checker.setPK(table, constraint.Name, constraint.KeyList)
+		}
+	}
+}
+
+// setPK sets the PK of the table. The empty column list means the table has no PK.
+func (checker *tableRequirePKChecker) setPK(table string, name string, columns []string) {
+	if name == "" {
+		name = defaultPKName(table)
+	}
+	checker.tables[table] = newColumnSet(columns)
+	checker.pkNames[table] = name
+	checker.tableStatementIndex[table] = checker.statementIndex
+}
+
+// getPKName returns the PK constraint name of the table.
+func (checker *tableRequirePKChecker) getPKName(table string) string {
+	if name, ok := checker.pkNames[table]; ok {
+		return name
+	}
+	return defaultPKName(table)
+}
+
+// loadPK loads the PK of the table from the catalog if the table isn't in the statements.
+// We assume the PK uses the default name, because the catalog doesn't know the constraint type.
+func (checker *tableRequirePKChecker) loadPK(table string) {
+	if _, ok := checker.tables[table]; ok {
+		return
+	}
+	pk, err := checker.catalog.FindIndex(context.Background(), &catalog.IndexFind{
+		TableName: table,
+		IndexName: defaultPKName(table),
+	})
+	if err != nil {
+		log.Printf(
+			"Cannot find primary key in table %s with error %v\n",
+			table,
+			err,
+		)
+		return
+	}
+	if pk == nil {
+		return
+	}
+	checker.setPK(table, pk.Name, pk.ColumnExpressions)
+}
+
+// defaultPKName returns the PK constraint name generated by PostgreSQL if not specified.
+func defaultPKName(table string) string {
+	return fmt.Sprintf("%s_pkey", table)
+}
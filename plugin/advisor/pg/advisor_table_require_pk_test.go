@@ -10,7 +10,123 @@ func TestRequirePK(t *testing.T) {
 	tests := []advisor.TestCase{
 		{
 			Statement: "CREATE TABLE t(id INT PRIMARY KEY)",
-			Want:      []advisor.Advice{},
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id INT, name TEXT, PRIMARY KEY (id, name))",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "CREATE TABLE t(id INT)",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table \"t\" requires PRIMARY KEY",
+					StatementIndex: 1,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(id INT);
+						ALTER TABLE t ADD CONSTRAINT t_pk PRIMARY KEY (id)`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(id INT);
+						DROP TABLE t`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(id INT PRIMARY KEY, name TEXT);
+						ALTER TABLE t DROP CONSTRAINT t_pkey`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table \"t\" requires PRIMARY KEY",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(id INT, CONSTRAINT t_pk PRIMARY KEY (id));
+						ALTER TABLE t DROP CONSTRAINT t_pkey`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(id INT PRIMARY KEY, name TEXT);
+						ALTER TABLE t DROP COLUMN id`,
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table \"t\" requires PRIMARY KEY",
+					StatementIndex: 2,
+				},
+			},
+		},
+		{
+			Statement: `CREATE TABLE t(id INT PRIMARY KEY, name TEXT);
+						ALTER TABLE t DROP COLUMN name`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "ALTER TABLE t DROP CONSTRAINT t_pkey",
+			Want: []advisor.Advice{
+				{
+					Status:         advisor.Error,
+					Code:           advisor.TableNoPK,
+					Title:          "table.require-pk",
+					Content:        "Table \"t\" requires PRIMARY KEY",
+					StatementIndex: 1,
+				},
+			},
 		},
 	}
 
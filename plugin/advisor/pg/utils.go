@@ -0,0 +1,57 @@
+package pg
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type columnSet map[string]bool
+
+func newColumnSet(columns []string) columnSet {
+	res := make(columnSet)
+	for _, col := range columns {
+		res[col] = true
+	}
+	return res
+}
+
+type tableState map[string]columnSet
+
+// tableList returns table list in lexicographical order.
+func (t tableState) tableList() []string {
+	var tableList []string
+	for tableName := range t {
+		tableList = append(tableList, tableName)
+	}
+	sort.Strings(tableList)
+	return tableList
+}
+
+// indexMetaData is the index name with its table and the tokens for the naming template.
+type indexMetaData struct {
+	indexName string
+	tableName string
+	metaData  map[string]string
+}
+
+// getTemplateRegexp formats the template as regex.
+func getTemplateRegexp(template string, templateList []string, tokens map[string]string) (*regexp.Regexp, error) {
+	for _, key := range templateList {
+		if token, ok := tokens[key]; ok {
+			template = strings.ReplaceAll(template, key, token)
+		}
+	}
+
+	return regexp.Compile(template)
+}
+
+// containsFold returns whether the list contains the value case-insensitively.
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strings"
 
 	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
 )
@@ -50,14 +51,41 @@ const (
 	SchemaRuleStatementRequireWhere SchemaReviewRuleType = "statement.where.require"
 	// SchemaRuleStatementNoLeadingWildcardLike disallow leading '%' in LIKE, e.g. LIKE foo = '%x' is not allowed.
 	SchemaRuleStatementNoLeadingWildcardLike SchemaReviewRuleType = "statement.where.no-leading-wildcard-like"
+	// SchemaRuleStatementAffectedRowLimit limit the estimated affected rows of the UPDATE and DELETE statements.
+	// It needs the database connection to EXPLAIN the statements, so it's checked by the affected rows task check instead of the advisors.
+	SchemaRuleStatementAffectedRowLimit SchemaReviewRuleType = "statement.affected-row-limit"
 
 	// SchemaRuleTableRequirePK require the table to have a primary key.
 	SchemaRuleTableRequirePK SchemaReviewRuleType = "table.require-pk"
+	// SchemaRuleTableNoDuplicateIndex disallow the index with the same columns as an existing index.
+	SchemaRuleTableNoDuplicateIndex SchemaReviewRuleType = "table.no-duplicate-index"
+	// SchemaRuleTableCommentConvention enforce the table comment convention.
+	SchemaRuleTableCommentConvention SchemaReviewRuleType = "table.comment"
+	// SchemaRuleIndexTotalNumberLimit limit the index count in each table.
+	SchemaRuleIndexTotalNumberLimit SchemaReviewRuleType = "index.total-number-limit"
 
 	// SchemaRuleRequiredColumn enforce the required columns in each table.
 	SchemaRuleRequiredColumn SchemaReviewRuleType = "column.required"
 	// SchemaRuleColumnNotNull enforce the columns cannot have NULL value.
 	SchemaRuleColumnNotNull SchemaReviewRuleType = "column.no-null"
+	// SchemaRuleColumnCommentConvention enforce the column comment convention.
+	SchemaRuleColumnCommentConvention SchemaReviewRuleType = "column.comment"
+	// SchemaRuleColumnTypeDisallowList enforce the column types not in the disallow list, e.g. ENUM and FLOAT.
+	SchemaRuleColumnTypeDisallowList SchemaReviewRuleType = "column.type-disallow-list"
+	// SchemaRuleColumnMaximumVarcharLength limit the length of the VARCHAR columns.
+	SchemaRuleColumnMaximumVarcharLength SchemaReviewRuleType = "column.maximum-varchar-length"
+	// SchemaRuleColumnAutoIncrementMustUnsignedBigint require the auto-increment column to be unsigned BIGINT.
+	// PostgreSQL doesn't have unsigned integers, so it only requires BIGINT for the serial and identity columns.
+	SchemaRuleColumnAutoIncrementMustUnsignedBigint SchemaReviewRuleType = "column.auto-increment-must-unsigned-bigint"
+
+	// SchemaRuleCharsetAllowlist enforce the charset in the allow list.
+	SchemaRuleCharsetAllowlist SchemaReviewRuleType = "system.charset.allowlist"
+	// SchemaRuleCollationAllowlist enforce the collation in the allow list.
+	SchemaRuleCollationAllowlist SchemaReviewRuleType = "system.collation.allowlist"
+
+	// SchemaRuleCustomExpression enforce the user-defined expression, and the advice uses the title in the payload.
+	// A policy can have many rules of this type.
+	SchemaRuleCustomExpression SchemaReviewRuleType = "custom.expression"
 
 	// SchemaRuleSchemaBackwardCompatibility enforce the MySQL and TiDB support check whether the schema change is backward compatible.
 	SchemaRuleSchemaBackwardCompatibility SchemaReviewRuleType = "schema.backward-compatibility"
@@ -140,6 +168,22 @@ func (rule *SchemaReviewRule) Validate() error {
 		if _, err := UnmarshalRequiredColumnRulePayload(rule.Payload); err != nil {
 			return err
 		}
+	case SchemaRuleStatementAffectedRowLimit, SchemaRuleColumnMaximumVarcharLength, SchemaRuleIndexTotalNumberLimit:
+		if _, err := UnmarshalNumberTypeRulePayload(rule.Payload); err != nil {
+			return err
+		}
+	case SchemaRuleTableCommentConvention, SchemaRuleColumnCommentConvention:
+		if _, err := UnmarshalCommentConventionRulePayload(rule.Payload); err != nil {
+			return err
+		}
+	case SchemaRuleColumnTypeDisallowList, SchemaRuleCharsetAllowlist, SchemaRuleCollationAllowlist:
+		if _, err := UnmarshalStringArrayTypeRulePayload(rule.Payload); err != nil {
+			return err
+		}
+	case SchemaRuleCustomExpression:
+		if _, _, err := UnmarshalCustomExpressionRulePayload(rule.Payload); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -155,6 +199,23 @@ type RequiredColumnRulePayload struct {
 	ColumnList []string `json:"columnList"`
 }
 
+// NumberTypeRulePayload is the payload for the rule with a number limit.
+type NumberTypeRulePayload struct {
+	Number int `json:"number"`
+}
+
+// CommentConventionRulePayload is the payload for the comment convention rule.
+type CommentConventionRulePayload struct {
+	Required bool `json:"required"`
+	// MaxLength is the maximum length of the comment, 0 means no limit.
+	MaxLength int `json:"maxLength"`
+}
+
+// StringArrayTypeRulePayload is the payload for the rule with a string list, e.g. the allow list and the disallow list.
+type StringArrayTypeRulePayload struct {
+	List []string `json:"list"`
+}
+
 // UnamrshalNamingRulePayloadAsRegexp will unmarshal payload to NamingRulePayload and compile it as regular expression.
 func UnamrshalNamingRulePayloadAsRegexp(payload string) (*regexp.Regexp, error) {
 	var nr NamingRulePayload
@@ -222,6 +283,50 @@ func UnmarshalRequiredColumnRulePayload(payload string) (*RequiredColumnRulePayl
 	return &rcr, nil
 }
 
+// UnmarshalNumberTypeRulePayload will unmarshal payload to NumberTypeRulePayload.
+func UnmarshalNumberTypeRulePayload(payload string) (*NumberTypeRulePayload, error) {
+	var nlr NumberTypeRulePayload
+	if err := json.Unmarshal([]byte(payload), &nlr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal number type rule payload %q: %q", payload, err)
+	}
+	if nlr.Number <= 0 {
+		return nil, fmt.Errorf("invalid number type rule payload, number must be positive but found %d", nlr.Number)
+	}
+	return &nlr, nil
+}
+
+// UnmarshalCommentConventionRulePayload will unmarshal payload to CommentConventionRulePayload.
+func UnmarshalCommentConventionRulePayload(payload string) (*CommentConventionRulePayload, error) {
+	var ccr CommentConventionRulePayload
+	if err := json.Unmarshal([]byte(payload), &ccr); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal comment convention rule payload %q: %q", payload, err)
+	}
+	if ccr.MaxLength < 0 {
+		return nil, fmt.Errorf("invalid comment convention rule payload, max length cannot be negative but found %d", ccr.MaxLength)
+	}
+	if !ccr.Required && ccr.MaxLength == 0 {
+		return nil, fmt.Errorf("invalid comment convention rule payload, it should require the comment or limit the comment length")
+	}
+	return &ccr, nil
+}
+
+// UnmarshalStringArrayTypeRulePayload will unmarshal payload to StringArrayTypeRulePayload.
+func UnmarshalStringArrayTypeRulePayload(payload string) (*StringArrayTypeRulePayload, error) {
+	var sar StringArrayTypeRulePayload
+	if err := json.Unmarshal([]byte(payload), &sar); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal string array type rule payload %q: %q", payload, err)
+	}
+	if len(sar.List) == 0 {
+		return nil, fmt.Errorf("invalid string array type rule payload, list cannot be empty")
+	}
+	for _, item := range sar.List {
+		if strings.TrimSpace(item) == "" {
+			return nil, fmt.Errorf("invalid string array type rule payload, list item cannot be empty")
+		}
+	}
+	return &sar, nil
+}
+
 // SchemaReviewCheckContext is the context for schema review check.
 type SchemaReviewCheckContext struct {
 	Charset   string
@@ -237,6 +342,10 @@ func SchemaReviewCheck(ctx context.Context, statements string, policy *SchemaRev
 		if rule.Level == SchemaRuleLevelDisabled {
 			continue
 		}
+		// The affected row limit is checked by the affected rows task check.
+		if rule.Type == SchemaRuleStatementAffectedRowLimit {
+			continue
+		}
 
 		advisorType, err := getAdvisorTypeByRule(rule.Type, context.DbType)
 		if err != nil {
@@ -281,70 +390,162 @@ func getAdvisorTypeByRule(ruleType SchemaReviewRuleType, engine DBType) (Type, e
 	switch ruleType {
 	case SchemaRuleStatementRequireWhere:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLWhereRequirement, nil
+		case Postgres:
+			return PostgreSQLWhereRequirement, nil
 		}
 	case SchemaRuleStatementNoLeadingWildcardLike:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLNoLeadingWildcardLike, nil
+		case Postgres:
+			return PostgreSQLNoLeadingWildcardLike, nil
 		}
 	case SchemaRuleStatementNoSelectAll:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLNoSelectAll, nil
+		case Postgres:
+			return PostgreSQLNoSelectAll, nil
 		}
 	case SchemaRuleSchemaBackwardCompatibility:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLMigrationCompatibility, nil
+		case Postgres:
+			return PostgreSQLMigrationCompatibility, nil
 		}
 	case SchemaRuleTableNaming:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLNamingTableConvention, nil
 		case Postgres:
 			return PostgreSQLNamingTableConvention, nil
 		}
 	case SchemaRuleIDXNaming:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLNamingIndexConvention, nil
+		case Postgres:
+			return PostgreSQLNamingIndexConvention, nil
 		}
 	case SchemaRuleUKNaming:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLNamingUKConvention, nil
+		case Postgres:
+			return PostgreSQLNamingUKConvention, nil
 		}
 	case SchemaRuleFKNaming:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLNamingFKConvention, nil
+		case Postgres:
+			return PostgreSQLNamingFKConvention, nil
 		}
 	case SchemaRuleColumnNaming:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLNamingColumnConvention, nil
 		case Postgres:
 			return PostgreSQLNamingColumnConvention, nil
 		}
 	case SchemaRuleRequiredColumn:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLColumnRequirement, nil
+		case Postgres:
+			return PostgreSQLColumnRequirement, nil
 		}
 	case SchemaRuleColumnNotNull:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLColumnNoNull, nil
+		case Postgres:
+			return PostgreSQLColumnNoNull, nil
 		}
 	case SchemaRuleTableRequirePK:
 		switch engine {
-		case MySQL, TiDB:
+		case MySQL, TiDB, MariaDB, OceanBase:
 			return MySQLTableRequirePK, nil
+		case Postgres:
+			return PostgreSQLTableRequirePK, nil
+		}
+	case SchemaRuleTableNoDuplicateIndex:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLNoDuplicateIndex, nil
+		case Postgres:
+			return PostgreSQLNoDuplicateIndex, nil
+		}
+	case SchemaRuleTableCommentConvention:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLTableCommentConvention, nil
+		case Postgres:
+			return PostgreSQLTableCommentConvention, nil
+		}
+	case SchemaRuleColumnCommentConvention:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLColumnCommentConvention, nil
+		case Postgres:
+			return PostgreSQLColumnCommentConvention, nil
+		}
+	case SchemaRuleColumnTypeDisallowList:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLColumnDisallowType, nil
+		case Postgres:
+			return PostgreSQLColumnDisallowType, nil
+		}
+	case SchemaRuleColumnMaximumVarcharLength:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLColumnMaximumVarcharLength, nil
+		case Postgres:
+			return PostgreSQLColumnMaximumVarcharLength, nil
+		}
+	case SchemaRuleColumnAutoIncrementMustUnsignedBigint:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLAutoIncrementColumnMustUnsignedBigint, nil
+		case Postgres:
+			return PostgreSQLAutoIncrementColumnMustUnsignedBigint, nil
+		}
+	case SchemaRuleCharsetAllowlist:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLCharsetAllowlist, nil
+		case Postgres:
+			return PostgreSQLCharsetAllowlist, nil
+		}
+	case SchemaRuleCollationAllowlist:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLCollationAllowlist, nil
+		case Postgres:
+			return PostgreSQLCollationAllowlist, nil
+		}
+	case SchemaRuleIndexTotalNumberLimit:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLIndexTotalNumberLimit, nil
+		case Postgres:
+			return PostgreSQLIndexTotalNumberLimit, nil
+		}
+	case SchemaRuleCustomExpression:
+		switch engine {
+		case MySQL, TiDB, MariaDB, OceanBase:
+			return MySQLCustomExpression, nil
+		case Postgres:
+			return PostgreSQLCustomExpression, nil
 		}
 	case SchemaRuleMySQLEngine:
-		if engine == MySQL {
+		// OceanBase and TiDB have their own storage engines.
+		switch engine {
+		case MySQL, MariaDB:
 			return MySQLUseInnoDB, nil
 		}
 	}
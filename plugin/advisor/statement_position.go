@@ -0,0 +1,168 @@
+package advisor
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	tidbparser "github.com/pingcap/tidb/parser"
+	"github.com/youzi-1122/bytebase/plugin/parser"
+)
+
+// disableRuleDirective is the comment directive to disable schema review rules for the next statement.
+// For example, "-- bytebase:disable naming.table, column.no-null".
+const disableRuleDirective = "bytebase:disable"
+
+// statementPosition is the position of a statement in the SQL script.
+type statementPosition struct {
+	// line and column are 1-based, pointing to the start of the statement after the leading comments.
+	line   int
+	column int
+	// disabledRuleSet is the rule types disabled by the leading comments of the statement.
+	disabledRuleSet map[SchemaReviewRuleType]bool
+}
+
+// setAdvicePosition fills the line and column of the advices by their statement index,
+// and suppresses the advices whose rule is disabled by the comments before the statement.
+func setAdvicePosition(dbType DBType, ctx Context, statement string, adviceList []Advice) []Advice {
+	hasStatementIndex := false
+	for _, advice := range adviceList {
+		if advice.StatementIndex > 0 {
+			hasStatementIndex = true
+			break
+		}
+	}
+	if !hasStatementIndex {
+		return adviceList
+	}
+
+	positionList, err := getStatementPositionList(dbType, statement, ctx.Charset, ctx.Collation)
+	if err != nil {
+		log.Printf("failed to get the statement positions, error: %v\n", err)
+		return adviceList
+	}
+	for i := range adviceList {
+		advice := &adviceList[i]
+		if advice.StatementIndex <= 0 || advice.StatementIndex > len(positionList) {
+			continue
+		}
+		position := positionList[advice.StatementIndex-1]
+		advice.Line = position.line
+		advice.Column = position.column
+		if ctx.Rule != nil && advice.Status != Success && position.disabledRuleSet[ctx.Rule.Type] {
+			advice.Status = Success
+			advice.Suppressed = true
+		}
+	}
+	return adviceList
+}
+
+// getStatementPositionList returns the position of each statement in the SQL script,
+// in the same order as the statements parsed by the advisors.
+func getStatementPositionList(dbType DBType, statement string, charset string, collation string) ([]statementPosition, error) {
+	textList, err := splitStatement(dbType, statement, charset, collation)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []statementPosition
+	offset := 0
+	for _, text := range textList {
+		start := strings.Index(statement[offset:], text)
+		if start < 0 {
+			return nil, fmt.Errorf("failed to locate statement %q", text)
+		}
+		start += offset
+		offset = start + len(text)
+
+		commentLength, disabledRuleSet := scanLeadingComments(text)
+		line, column := getLineAndColumn(statement, start+commentLength)
+		res = append(res, statementPosition{
+			line:            line,
+			column:          column,
+			disabledRuleSet: disabledRuleSet,
+		})
+	}
+	return res, nil
+}
+
+// splitStatement splits the SQL script into statement texts by the parser of the advisors.
+// The text may start with the comments between the previous statement and it.
+func splitStatement(dbType DBType, statement string, charset string, collation string) ([]string, error) {
+	switch dbType {
+	case MySQL, TiDB, MariaDB, OceanBase:
+		p := tidbparser.New()
+		// To support MySQL8 window function syntax.
+		p.EnableWindowFunc(true)
+		nodeList, _, err := p.Parse(statement, charset, collation)
+		if err != nil {
+			return nil, err
+		}
+		var res []string
+		for _, node := range nodeList {
+			res = append(res, node.Text())
+		}
+		return res, nil
+	case Postgres:
+		return parser.SplitMultiSQL(parser.Postgres, statement)
+	}
+	return nil, fmt.Errorf("statement position doesn't support database type %s", dbType)
+}
+
+// scanLeadingComments returns the length of the leading whitespaces and comments of the statement text,
+// and the rule types disabled by the leading "-- bytebase:disable <rule>" comments.
+func scanLeadingComments(text string) (int, map[SchemaReviewRuleType]bool) {
+	disabledRuleSet := make(map[SchemaReviewRuleType]bool)
+	pos := 0
+	for pos < len(text) {
+		r, size := utf8.DecodeRuneInString(text[pos:])
+		switch {
+		case unicode.IsSpace(r):
+			pos += size
+		case strings.HasPrefix(text[pos:], "--"):
+			end := strings.IndexByte(text[pos:], '\n')
+			if end < 0 {
+				end = len(text) - pos
+			}
+			for _, ruleType := range parseDisableRuleDirective(text[pos+2 : pos+end]) {
+				disabledRuleSet[ruleType] = true
+			}
+			pos += end
+		case strings.HasPrefix(text[pos:], "/*"):
+			end := strings.Index(text[pos+2:], "*/")
+			if end < 0 {
+				return pos, disabledRuleSet
+			}
+			pos += end + 4
+		default:
+			return pos, disabledRuleSet
+		}
+	}
+	return pos, disabledRuleSet
+}
+
+// parseDisableRuleDirective returns the rule types in the comment like "bytebase:disable naming.table, column.no-null".
+func parseDisableRuleDirective(comment string) []SchemaReviewRuleType {
+	comment = strings.TrimSpace(comment)
+	if !strings.HasPrefix(comment, disableRuleDirective) {
+		return nil
+	}
+	var res []SchemaReviewRuleType
+	fields := strings.FieldsFunc(comment[len(disableRuleDirective):], func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	for _, field := range fields {
+		res = append(res, SchemaReviewRuleType(field))
+	}
+	return res
+}
+
+// getLineAndColumn returns the 1-based line and column of the byte offset in the text.
+func getLineAndColumn(text string, offset int) (int, int) {
+	line := strings.Count(text[:offset], "\n") + 1
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	column := utf8.RuneCountInString(text[lineStart:offset]) + 1
+	return line, column
+}
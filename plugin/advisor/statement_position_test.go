@@ -0,0 +1,93 @@
+package advisor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/pingcap/tidb/types/parser_driver"
+)
+
+func TestSetAdvicePosition(t *testing.T) {
+	type test struct {
+		dbType    DBType
+		statement string
+		ruleType  SchemaReviewRuleType
+		input     []Advice
+		want      []Advice
+	}
+
+	tests := []test{
+		{
+			dbType:    MySQL,
+			statement: "CREATE TABLE t(a int);\n  -- comment\n  CREATE TABLE `t;2`(a int);",
+			ruleType:  SchemaRuleTableNaming,
+			input: []Advice{
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t", StatementIndex: 1},
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t;2", StatementIndex: 2},
+			},
+			want: []Advice{
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t", StatementIndex: 1, Line: 1, Column: 1},
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t;2", StatementIndex: 2, Line: 3, Column: 3},
+			},
+		},
+		{
+			dbType:    MySQL,
+			statement: "CREATE TABLE t(a int); -- bytebase:disable naming.table, column.no-null\nCREATE TABLE t2(a int);\nCREATE TABLE t3(a int);",
+			ruleType:  SchemaRuleTableNaming,
+			input: []Advice{
+				{Status: Error, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t2", StatementIndex: 2},
+				{Status: Error, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t3", StatementIndex: 3},
+			},
+			want: []Advice{
+				{Status: Success, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t2", StatementIndex: 2, Line: 2, Column: 1, Suppressed: true},
+				{Status: Error, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t3", StatementIndex: 3, Line: 3, Column: 1},
+			},
+		},
+		{
+			// The directive only disables the specified rules.
+			dbType:    MySQL,
+			statement: "-- bytebase:disable naming.column\nCREATE TABLE t(a int);",
+			ruleType:  SchemaRuleTableNaming,
+			input: []Advice{
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t", StatementIndex: 1},
+			},
+			want: []Advice{
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t", StatementIndex: 1, Line: 2, Column: 1},
+			},
+		},
+		{
+			dbType:    Postgres,
+			statement: "CREATE TABLE t(a int);\n/* multi-line\n comment */\n-- bytebase:disable naming.table\n\tCREATE TABLE \"t;2\"(a int);",
+			ruleType:  SchemaRuleTableNaming,
+			input: []Advice{
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t", StatementIndex: 1},
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t;2", StatementIndex: 2},
+			},
+			want: []Advice{
+				{Status: Warn, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t", StatementIndex: 1, Line: 1, Column: 1},
+				{Status: Success, Code: NamingTableConventionMismatch, Title: "naming.table", Content: "t;2", StatementIndex: 2, Line: 5, Column: 2, Suppressed: true},
+			},
+		},
+		{
+			// The advice without statement index is not changed.
+			dbType:    Postgres,
+			statement: "-- bytebase:disable naming.table\nCREATE TABLE t(a int);",
+			ruleType:  SchemaRuleTableNaming,
+			input: []Advice{
+				{Status: Success, Code: Ok, Title: "OK"},
+			},
+			want: []Advice{
+				{Status: Success, Code: Ok, Title: "OK"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		ctx := Context{
+			Rule: &SchemaReviewRule{Type: test.ruleType, Level: SchemaRuleLevelWarning},
+		}
+		got := setAdvicePosition(test.dbType, ctx, test.statement, test.input)
+		require.Equal(t, test.want, got, test.statement)
+	}
+}
@@ -2,7 +2,6 @@ package advisor
 
 import (
 	"context"
-	"fmt"
 	"testing"
 
 	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
@@ -20,35 +19,130 @@ const (
 	MockOldUKName = "old_uk"
 	// MockOldPKName is the mock old foreign key for test.
 	MockOldPKName = "PRIMARY"
+	// MockTableName is the mock table of the indexes for test.
+	MockTableName = "tech_book"
 )
 
 var (
 	// MockIndexColumnList is the mock index column list for test.
 	MockIndexColumnList = []string{"id", "name"}
+	// MockTableRowCount is the mock row count of the mock table for test.
+	MockTableRowCount int64 = 100
+	// MockEmptyTableName is the mock table without any row for test.
+	MockEmptyTableName = "empty_book"
 )
 
+// FindTable implements the catalog interface.
+func (c *MockCatalogService) FindTable(ctx context.Context, find *catalog.TableFind) (*catalog.Table, error) {
+	switch find.TableName {
+	case MockTableName:
+		var indexList []*catalog.Index
+		for _, name := range []string{MockOldIndexName, MockOldUKName, MockOldPKName} {
+			index, err := c.FindIndex(ctx, &catalog.IndexFind{TableName: MockTableName, IndexName: name})
+			if err != nil {
+				return nil, err
+			}
+			indexList = append(indexList, index)
+		}
+		return &catalog.Table{
+			Name:     MockTableName,
+			RowCount: MockTableRowCount,
+			ColumnList: []*catalog.Column{
+				mockColumn(MockTableName, "id", 1),
+				mockColumn(MockTableName, "name", 2),
+			},
+			ConstraintList: []*catalog.Constraint{
+				{
+					Name:       MockOldUKName,
+					TableName:  MockTableName,
+					Type:       catalog.ConstraintTypeUnique,
+					ColumnList: MockIndexColumnList,
+				},
+				{
+					Name:       MockOldPKName,
+					TableName:  MockTableName,
+					Type:       catalog.ConstraintTypePrimary,
+					ColumnList: MockIndexColumnList,
+				},
+			},
+			IndexList: indexList,
+		}, nil
+	case MockEmptyTableName:
+		return &catalog.Table{
+			Name:     MockEmptyTableName,
+			RowCount: 0,
+			ColumnList: []*catalog.Column{
+				mockColumn(MockEmptyTableName, "id", 1),
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+// FindColumn implements the catalog interface.
+func (c *MockCatalogService) FindColumn(ctx context.Context, find *catalog.ColumnFind) (*catalog.Column, error) {
+	table, err := c.FindTable(ctx, &catalog.TableFind{TableName: find.TableName})
+	if err != nil || table == nil {
+		return nil, err
+	}
+	for _, column := range table.ColumnList {
+		if column.Name == find.ColumnName {
+			return column, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindConstraint implements the catalog interface.
+func (c *MockCatalogService) FindConstraint(ctx context.Context, find *catalog.ConstraintFind) (*catalog.Constraint, error) {
+	table, err := c.FindTable(ctx, &catalog.TableFind{TableName: find.TableName})
+	if err != nil || table == nil {
+		return nil, err
+	}
+	for _, constraint := range table.ConstraintList {
+		if constraint.Name == find.ConstraintName {
+			return constraint, nil
+		}
+	}
+	return nil, nil
+}
+
 // FindIndex implements the catalog interface.
 func (c *MockCatalogService) FindIndex(ctx context.Context, find *catalog.IndexFind) (*catalog.Index, error) {
 	switch find.IndexName {
 	case MockOldIndexName:
 		return &catalog.Index{
 			Name:              MockOldIndexName,
+			TableName:         MockTableName,
 			ColumnExpressions: MockIndexColumnList,
 		}, nil
 	case MockOldUKName:
 		return &catalog.Index{
 			Unique:            true,
-			Name:              MockOldIndexName,
+			Name:              MockOldUKName,
+			TableName:         MockTableName,
 			ColumnExpressions: MockIndexColumnList,
 		}, nil
 	case MockOldPKName:
 		return &catalog.Index{
 			Unique:            true,
+			Primary:           true,
 			Name:              MockOldPKName,
+			TableName:         MockTableName,
 			ColumnExpressions: MockIndexColumnList,
 		}, nil
 	}
-	return nil, fmt.Errorf("cannot find index for %v", find)
+	return nil, nil
+}
+
+func mockColumn(tableName string, columnName string, position int) *catalog.Column {
+	return &catalog.Column{
+		Name:      columnName,
+		TableName: tableName,
+		Position:  position,
+		Type:      "int",
+		Nullable:  false,
+	}
 }
 
 // TestCase is the data struct for test.
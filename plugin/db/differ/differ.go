@@ -0,0 +1,284 @@
+// Package differ computes the DDL statements to migrate a database schema to another.
+package differ
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+)
+
+// dialect generates the DDL statements for a database type.
+type dialect interface {
+	// isPrimaryKey returns whether the index is the primary key of the table.
+	isPrimaryKey(name string) bool
+	// hasDefault returns whether the column has the default value.
+	hasDefault(column *db.Column) bool
+	// normalizeView returns the view definition used for comparison and creation.
+	normalizeView(schema *db.Schema, view *db.View) string
+
+	createTable(table *db.Table, indexList []*index) []string
+	dropTable(table *db.Table) string
+	alterTableOption(oldTable, newTable *db.Table) []string
+	// addColumn adds the column after the previous column. The previous column is nil if the column is the first one.
+	addColumn(table *db.Table, column, previous *db.Column) []string
+	modifyColumn(table *db.Table, oldColumn, newColumn *db.Column) []string
+	dropColumn(table *db.Table, column *db.Column) string
+	createIndex(table *db.Table, index *index) []string
+	dropIndex(table *db.Table, index *index) string
+	createView(name, definition, comment string) []string
+	dropView(name string) string
+}
+
+// index is the index composed from the db.Index list, which has an entry for each index column.
+type index struct {
+	name           string
+	expressionList []string
+	indexType      string
+	unique         bool
+	primary        bool
+	comment        string
+}
+
+func (i *index) equal(other *index) bool {
+	if i.indexType != other.indexType || i.unique != other.unique || i.primary != other.primary || i.comment != other.comment {
+		return false
+	}
+	if len(i.expressionList) != len(other.expressionList) {
+		return false
+	}
+	for j := range i.expressionList {
+		if i.expressionList[j] != other.expressionList[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// SchemaDiff returns the ordered DDL statements to migrate the old schema to the new schema.
+// Only MySQL, TiDB and Postgres are supported.
+//
+// The statements are ordered as:
+//  1. drop the removed or changed views, as they may depend on the tables.
+//  2. drop the removed or changed indexes.
+//  3. drop the removed tables.
+//  4. create the new tables.
+//  5. add, modify and drop the columns, and alter the table options.
+//  6. create the new or changed indexes.
+//  7. create the new or changed views.
+func SchemaDiff(dbType db.Type, oldSchema, newSchema *db.Schema) ([]string, error) {
+	var d dialect
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		d = &mysqlDialect{}
+	case db.Postgres:
+		d = &pgDialect{}
+	default:
+		return nil, fmt.Errorf("schema diff for database type %q not supported; supported types: %s, %s, %s, %s, %s", dbType, db.MySQL, db.TiDB, db.MariaDB, db.OceanBase, db.Postgres)
+	}
+
+	var (
+		dropViewList    []string
+		dropIndexList   []string
+		dropTableList   []string
+		createTableList []string
+		alterTableList  []string
+		createIndexList []string
+		createViewList  []string
+	)
+
+	oldTableMap := make(map[string]*db.Table)
+	for i := range oldSchema.TableList {
+		oldTableMap[oldSchema.TableList[i].Name] = &oldSchema.TableList[i]
+	}
+	newTableMap := make(map[string]*db.Table)
+	for i := range newSchema.TableList {
+		newTableMap[newSchema.TableList[i].Name] = &newSchema.TableList[i]
+	}
+
+	for i := range oldSchema.TableList {
+		oldTable := &oldSchema.TableList[i]
+		if _, ok := newTableMap[oldTable.Name]; !ok {
+			dropTableList = append(dropTableList, d.dropTable(oldTable))
+		}
+	}
+	for i := range newSchema.TableList {
+		newTable := &newSchema.TableList[i]
+		oldTable, ok := oldTableMap[newTable.Name]
+		if !ok {
+			createTableList = append(createTableList, d.createTable(newTable, buildIndexList(d, newTable))...)
+			continue
+		}
+
+		// Columns.
+		oldColumnList, newColumnList := sortColumnList(oldTable.ColumnList), sortColumnList(newTable.ColumnList)
+		oldColumnMap := make(map[string]*db.Column)
+		for _, column := range oldColumnList {
+			oldColumnMap[column.Name] = column
+		}
+		newColumnMap := make(map[string]*db.Column)
+		for _, column := range newColumnList {
+			newColumnMap[column.Name] = column
+		}
+		var addColumnList, modifyColumnList, dropColumnList []string
+		for j, newColumn := range newColumnList {
+			oldColumn, ok := oldColumnMap[newColumn.Name]
+			if !ok {
+				var previous *db.Column
+				if j > 0 {
+					previous = newColumnList[j-1]
+				}
+				addColumnList = append(addColumnList, d.addColumn(newTable, newColumn, previous)...)
+				continue
+			}
+			if !equalColumn(d, oldColumn, newColumn) {
+				modifyColumnList = append(modifyColumnList, d.modifyColumn(newTable, oldColumn, newColumn)...)
+			}
+		}
+		for _, oldColumn := range oldColumnList {
+			if _, ok := newColumnMap[oldColumn.Name]; !ok {
+				dropColumnList = append(dropColumnList, d.dropColumn(oldTable, oldColumn))
+			}
+		}
+		alterTableList = append(alterTableList, addColumnList...)
+		alterTableList = append(alterTableList, modifyColumnList...)
+		alterTableList = append(alterTableList, dropColumnList...)
+		alterTableList = append(alterTableList, d.alterTableOption(oldTable, newTable)...)
+
+		// Indexes.
+		oldIndexList, newIndexList := buildIndexList(d, oldTable), buildIndexList(d, newTable)
+		oldIndexMap := make(map[string]*index)
+		for _, index := range oldIndexList {
+			oldIndexMap[index.name] = index
+		}
+		newIndexMap := make(map[string]*index)
+		for _, index := range newIndexList {
+			newIndexMap[index.name] = index
+		}
+		for _, oldIndex := range oldIndexList {
+			if newIndex, ok := newIndexMap[oldIndex.name]; !ok || !oldIndex.equal(newIndex) {
+				dropIndexList = append(dropIndexList, d.dropIndex(oldTable, oldIndex))
+			}
+		}
+		for _, newIndex := range newIndexList {
+			if oldIndex, ok := oldIndexMap[newIndex.name]; !ok || !oldIndex.equal(newIndex) {
+				createIndexList = append(createIndexList, d.createIndex(newTable, newIndex)...)
+			}
+		}
+	}
+
+	// Views.
+	oldViewMap := make(map[string]*db.View)
+	for i := range oldSchema.ViewList {
+		oldViewMap[oldSchema.ViewList[i].Name] = &oldSchema.ViewList[i]
+	}
+	newViewMap := make(map[string]*db.View)
+	for i := range newSchema.ViewList {
+		newViewMap[newSchema.ViewList[i].Name] = &newSchema.ViewList[i]
+	}
+	for i := range oldSchema.ViewList {
+		oldView := &oldSchema.ViewList[i]
+		newView, ok := newViewMap[oldView.Name]
+		if !ok || d.normalizeView(oldSchema, oldView) != d.normalizeView(newSchema, newView) || oldView.Comment != newView.Comment {
+			dropViewList = append(dropViewList, d.dropView(oldView.Name))
+		}
+	}
+	for i := range newSchema.ViewList {
+		newView := &newSchema.ViewList[i]
+		definition := d.normalizeView(newSchema, newView)
+		oldView, ok := oldViewMap[newView.Name]
+		if !ok || d.normalizeView(oldSchema, oldView) != definition || oldView.Comment != newView.Comment {
+			createViewList = append(createViewList, d.createView(newView.Name, definition, newView.Comment)...)
+		}
+	}
+
+	var statementList []string
+	for _, list := range [][]string{dropViewList, dropIndexList, dropTableList, createTableList, alterTableList, createIndexList, createViewList} {
+		statementList = append(statementList, list...)
+	}
+	return statementList, nil
+}
+
+// ParseSchema parses the schema dump, such as the MigrationHistory.Schema, into the schema which can be diffed by SchemaDiff.
+// Only MySQL, TiDB and Postgres are supported.
+//
+// The parsed schema is not comparable to the synced schema, so the dumps to diff should be both parsed by ParseSchema.
+func ParseSchema(dbType db.Type, databaseName, dump string) (*db.Schema, error) {
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		return parseMySQLSchema(databaseName, dump)
+	case db.Postgres:
+		return parsePostgresSchema(databaseName, dump)
+	default:
+		return nil, fmt.Errorf("schema parsing for database type %q not supported; supported types: %s, %s, %s, %s, %s", dbType, db.MySQL, db.TiDB, db.MariaDB, db.OceanBase, db.Postgres)
+	}
+}
+
+// equalColumn returns whether the columns have the same definition regardless of the position.
+func equalColumn(d dialect, oldColumn, newColumn *db.Column) bool {
+	if oldColumn.Type != newColumn.Type ||
+		oldColumn.Nullable != newColumn.Nullable ||
+		oldColumn.CharacterSet != newColumn.CharacterSet ||
+		oldColumn.Collation != newColumn.Collation ||
+		oldColumn.Comment != newColumn.Comment ||
+		oldColumn.Extra != newColumn.Extra {
+		return false
+	}
+	oldHasDefault, newHasDefault := d.hasDefault(oldColumn), d.hasDefault(newColumn)
+	if oldHasDefault != newHasDefault {
+		return false
+	}
+	return !oldHasDefault || *oldColumn.Default == *newColumn.Default
+}
+
+// sortColumnList returns the columns ordered by the position.
+func sortColumnList(columnList []db.Column) []*db.Column {
+	var list []*db.Column
+	for i := range columnList {
+		list = append(list, &columnList[i])
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Position < list[j].Position
+	})
+	return list
+}
+
+// buildIndexList composes the indexes of the table in the order of their first appearance.
+func buildIndexList(d dialect, table *db.Table) []*index {
+	indexList := make([]db.Index, len(table.IndexList))
+	copy(indexList, table.IndexList)
+	sort.SliceStable(indexList, func(i, j int) bool {
+		return indexList[i].Position < indexList[j].Position
+	})
+
+	var list []*index
+	indexMap := make(map[string]*index)
+	for _, idx := range indexList {
+		if i, ok := indexMap[idx.Name]; ok {
+			i.expressionList = append(i.expressionList, idx.Expression)
+			continue
+		}
+		i := &index{
+			name:           idx.Name,
+			expressionList: []string{idx.Expression},
+			indexType:      idx.Type,
+			unique:         idx.Unique,
+			primary:        d.isPrimaryKey(idx.Name),
+			comment:        idx.Comment,
+		}
+		indexMap[idx.Name] = i
+		list = append(list, i)
+	}
+
+	// Keep the index order of the table instead of the order sorted by the position.
+	order := make(map[string]int)
+	for i, idx := range table.IndexList {
+		if _, ok := order[idx.Name]; !ok {
+			order[idx.Name] = i
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return order[list[i].name] < order[list[j].name]
+	})
+	return list
+}
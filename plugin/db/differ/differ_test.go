@@ -0,0 +1,309 @@
+package differ
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/stretchr/testify/require"
+)
+
+func newString(s string) *string {
+	return &s
+}
+
+func TestMySQLSchemaDiff(t *testing.T) {
+	a := require.New(t)
+	idColumn := db.Column{Name: "id", Position: 1, Type: "int", Extra: "auto_increment"}
+	nameColumn := db.Column{Name: "name", Position: 2, Type: "varchar(255)", Default: newString(""), CharacterSet: "utf8mb4", Collation: "utf8mb4_general_ci"}
+	primaryKey := db.Index{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true}
+	tests := []struct {
+		name      string
+		oldSchema *db.Schema
+		newSchema *db.Schema
+		want      []string
+	}{
+		{
+			name:      "same",
+			oldSchema: &db.Schema{Name: "db1", TableList: []db.Table{{Name: "t", ColumnList: []db.Column{idColumn}}}},
+			newSchema: &db.Schema{Name: "db2", TableList: []db.Table{{Name: "t", ColumnList: []db.Column{idColumn}}}},
+			want:      nil,
+		},
+		{
+			name:      "create table",
+			oldSchema: &db.Schema{Name: "db"},
+			newSchema: &db.Schema{
+				Name: "db",
+				TableList: []db.Table{
+					{
+						Name:       "user",
+						Engine:     "InnoDB",
+						Collation:  "utf8mb4_general_ci",
+						Comment:    "user's info",
+						ColumnList: []db.Column{nameColumn, idColumn},
+						IndexList: []db.Index{
+							primaryKey,
+							{Name: "idx_name", Expression: "lower(`name`)", Position: 1, Type: "BTREE"},
+						},
+					},
+				},
+			},
+			want: []string{
+				"CREATE TABLE `user` (\n" +
+					"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+					"  `name` varchar(255) NOT NULL DEFAULT '',\n" +
+					"  PRIMARY KEY (`id`),\n" +
+					"  KEY `idx_name` ((lower(`name`)))\n" +
+					") ENGINE=InnoDB COLLATE=utf8mb4_general_ci COMMENT='user''s info';",
+			},
+		},
+		{
+			name: "alter table",
+			oldSchema: &db.Schema{
+				Name: "db",
+				TableList: []db.Table{
+					{
+						Name:       "t",
+						ColumnList: []db.Column{idColumn, {Name: "age", Position: 2, Type: "int", Nullable: true}},
+						IndexList:  []db.Index{{Name: "idx_age", Expression: "age", Position: 1, Type: "BTREE"}},
+					},
+					{Name: "removed", ColumnList: []db.Column{idColumn}},
+				},
+			},
+			newSchema: &db.Schema{
+				Name: "db",
+				TableList: []db.Table{
+					{
+						Name: "t",
+						ColumnList: []db.Column{
+							idColumn,
+							{Name: "created_ts", Position: 2, Type: "timestamp", Default: newString("CURRENT_TIMESTAMP"), Extra: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP"},
+							{Name: "age", Position: 3, Type: "bigint", Nullable: true, Comment: "age"},
+						},
+						IndexList: []db.Index{
+							primaryKey,
+							{Name: "idx_age", Expression: "age", Position: 1, Type: "BTREE", Unique: true},
+						},
+					},
+				},
+			},
+			want: []string{
+				"ALTER TABLE `t` DROP INDEX `idx_age`;",
+				"DROP TABLE `removed`;",
+				"ALTER TABLE `t` ADD COLUMN `created_ts` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP AFTER `id`;",
+				"ALTER TABLE `t` MODIFY COLUMN `age` bigint NULL COMMENT 'age';",
+				"ALTER TABLE `t` ADD PRIMARY KEY (`id`);",
+				"ALTER TABLE `t` ADD UNIQUE KEY `idx_age` (`age`);",
+			},
+		},
+		{
+			name: "view",
+			oldSchema: &db.Schema{
+				Name: "db_dev",
+				ViewList: []db.View{
+					{Name: "v1", Definition: "select `db_dev`.`t`.`id` AS `id` from `db_dev`.`t`"},
+					{Name: "v2", Definition: "select 1 AS `1`"},
+				},
+			},
+			newSchema: &db.Schema{
+				Name: "db_prod",
+				ViewList: []db.View{
+					{Name: "v1", Definition: "select `db_prod`.`t`.`id` AS `id` from `db_prod`.`t`"},
+					{Name: "v2", Definition: "select 2 AS `2`"},
+				},
+			},
+			want: []string{
+				"DROP VIEW `v2`;",
+				"CREATE VIEW `v2` AS select 2 AS `2`;",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		statementList, err := SchemaDiff(db.MySQL, test.oldSchema, test.newSchema)
+		a.NoError(err, test.name)
+		a.Equal(test.want, statementList, test.name)
+	}
+}
+
+func TestPostgresSchemaDiff(t *testing.T) {
+	a := require.New(t)
+	idColumn := db.Column{Name: "id", Position: 1, Type: "integer", Default: newString("nextval('t_id_seq'::regclass)")}
+	tests := []struct {
+		name      string
+		oldSchema *db.Schema
+		newSchema *db.Schema
+		want      []string
+	}{
+		{
+			name:      "create table",
+			oldSchema: &db.Schema{Name: "db"},
+			newSchema: &db.Schema{
+				Name: "db",
+				TableList: []db.Table{
+					{
+						Name:    `public."T"`,
+						Comment: "t",
+						ColumnList: []db.Column{
+							idColumn,
+							{Name: "name", Position: 2, Type: "character varying(255)", Nullable: true, Default: newString(""), Comment: "name"},
+						},
+						IndexList: []db.Index{
+							{Name: `"T_pkey"`, Expression: "id", Position: 1, Type: "btree", Unique: true},
+							{Name: "idx_name", Expression: "lower((name)::text)", Position: 1, Type: "btree"},
+						},
+					},
+				},
+			},
+			want: []string{
+				"CREATE TABLE public.\"T\" (\n" +
+					"  \"id\" integer NOT NULL DEFAULT nextval('t_id_seq'::regclass),\n" +
+					"  \"name\" character varying(255),\n" +
+					"  CONSTRAINT \"T_pkey\" PRIMARY KEY (id)\n" +
+					");",
+				`COMMENT ON TABLE public."T" IS 't';`,
+				`COMMENT ON COLUMN public."T"."name" IS 'name';`,
+				`CREATE INDEX idx_name ON public."T" USING btree (lower((name)::text));`,
+			},
+		},
+		{
+			name: "alter table",
+			oldSchema: &db.Schema{
+				Name: "db",
+				TableList: []db.Table{
+					{
+						Name: "public.t",
+						ColumnList: []db.Column{
+							idColumn,
+							{Name: "name", Position: 2, Type: "text", Nullable: true, Default: newString("")},
+							{Name: "age", Position: 3, Type: "integer", Nullable: true, Default: newString("")},
+						},
+						IndexList: []db.Index{
+							{Name: "t_pkey", Expression: "id", Position: 1, Type: "btree", Unique: true},
+							{Name: "idx_name", Expression: "name", Position: 1, Type: "btree"},
+						},
+					},
+				},
+				ViewList: []db.View{{Name: "public.v", Definition: " SELECT t.id\n   FROM t;"}},
+			},
+			newSchema: &db.Schema{
+				Name: "db",
+				TableList: []db.Table{
+					{
+						Name:    "public.t",
+						Comment: "t",
+						ColumnList: []db.Column{
+							idColumn,
+							{Name: "name", Position: 2, Type: "character varying(64)", Default: newString("'unknown'::character varying")},
+							{Name: "email", Position: 3, Type: "text", Nullable: true, Default: newString("")},
+						},
+						IndexList: []db.Index{
+							{Name: "idx_name", Expression: "name", Position: 1, Type: "btree", Unique: true},
+						},
+					},
+				},
+				ViewList: []db.View{{Name: "public.v", Definition: " SELECT t.id\n   FROM t;"}},
+			},
+			want: []string{
+				"ALTER TABLE public.t DROP CONSTRAINT t_pkey;",
+				"DROP INDEX public.idx_name;",
+				`ALTER TABLE public.t ADD COLUMN "email" text;`,
+				`ALTER TABLE public.t ALTER COLUMN "name" TYPE character varying(64);`,
+				`ALTER TABLE public.t ALTER COLUMN "name" SET NOT NULL;`,
+				`ALTER TABLE public.t ALTER COLUMN "name" SET DEFAULT 'unknown'::character varying;`,
+				`ALTER TABLE public.t DROP COLUMN "age";`,
+				"COMMENT ON TABLE public.t IS 't';",
+				"CREATE UNIQUE INDEX idx_name ON public.t USING btree (name);",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		statementList, err := SchemaDiff(db.Postgres, test.oldSchema, test.newSchema)
+		a.NoError(err, test.name)
+		a.Equal(test.want, statementList, test.name)
+	}
+}
+
+func TestSchemaDiffNotSupported(t *testing.T) {
+	_, err := SchemaDiff(db.ClickHouse, &db.Schema{}, &db.Schema{})
+	require.Error(t, err)
+}
+
+func TestParseMySQLSchemaDiff(t *testing.T) {
+	a := require.New(t)
+	oldDump := "SET character_set_client = utf8mb4;\n" +
+		"CREATE TABLE `user` (\n" +
+		"  `id` int NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(255) NOT NULL DEFAULT '',\n" +
+		"  `age` int DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  KEY `idx_name` (`name`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;\n" +
+		"DELIMITER ;;\n" +
+		"CREATE TRIGGER `t1` BEFORE INSERT ON `user` FOR EACH ROW SET NEW.age = 1 ;;\n" +
+		"DELIMITER ;\n" +
+		"CREATE VIEW `v` AS select `db`.`user`.`id` AS `id` from `db`.`user`;\n"
+	newDump := "CREATE TABLE `user` (\n" +
+		"  `id` INT NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(255) NOT NULL DEFAULT '',\n" +
+		"  `created_ts` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'created',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY (`name`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;\n" +
+		"CREATE VIEW `v` AS select `db`.`user`.`id` AS `id` from `db`.`user`;\n"
+
+	// MariaDB and OceanBase use the MySQL dialect.
+	for _, dbType := range []db.Type{db.MySQL, db.TiDB, db.MariaDB, db.OceanBase} {
+		oldSchema, err := ParseSchema(dbType, "db", oldDump)
+		a.NoError(err)
+		newSchema, err := ParseSchema(dbType, "db", newDump)
+		a.NoError(err)
+		statementList, err := SchemaDiff(dbType, oldSchema, newSchema)
+		a.NoError(err)
+		a.Equal([]string{
+			"ALTER TABLE `user` DROP INDEX `idx_name`;",
+			"ALTER TABLE `user` ADD COLUMN `created_ts` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'created' AFTER `name`;",
+			"ALTER TABLE `user` DROP COLUMN `age`;",
+			"ALTER TABLE `user` ADD UNIQUE KEY `name` (`name`);",
+		}, statementList, dbType)
+	}
+}
+
+func TestParsePostgresSchemaDiff(t *testing.T) {
+	a := require.New(t)
+	oldDump := "SET statement_timeout = 0;\n" +
+		"CREATE TABLE public.\"User\" (\n" +
+		"    id integer NOT NULL,\n" +
+		"    name character varying(255)\n" +
+		");\n" +
+		"CREATE SEQUENCE public.user_id_seq AS integer START WITH 1 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;\n" +
+		"ALTER TABLE ONLY public.\"User\" ALTER COLUMN id SET DEFAULT nextval('public.user_id_seq'::regclass);\n" +
+		"ALTER TABLE ONLY public.\"User\" ADD CONSTRAINT \"User_pkey\" PRIMARY KEY (id);\n" +
+		"CREATE INDEX idx_name ON public.\"User\" USING btree (name);\n"
+	newDump := "CREATE TABLE \"User\" (\n" +
+		"    id integer NOT NULL DEFAULT nextval('public.user_id_seq'::regclass) PRIMARY KEY,\n" +
+		"    name varchar(255) NOT NULL,\n" +
+		"    email text\n" +
+		");\n" +
+		"COMMENT ON COLUMN public.\"User\".email IS 'email';\n" +
+		"CREATE UNIQUE INDEX idx_name ON public.\"User\" USING btree (lower((name)::text));\n"
+
+	oldSchema, err := ParseSchema(db.Postgres, "db", oldDump)
+	a.NoError(err)
+	newSchema, err := ParseSchema(db.Postgres, "db", newDump)
+	a.NoError(err)
+	statementList, err := SchemaDiff(db.Postgres, oldSchema, newSchema)
+	a.NoError(err)
+	a.Equal([]string{
+		"DROP INDEX public.idx_name;",
+		`ALTER TABLE public."User" ADD COLUMN "email" text;`,
+		`COMMENT ON COLUMN public."User"."email" IS 'email';`,
+		`ALTER TABLE public."User" ALTER COLUMN "name" SET NOT NULL;`,
+		`CREATE UNIQUE INDEX idx_name ON public."User" USING btree (lower(name::text));`,
+	}, statementList)
+}
+
+func TestParseSchemaNotSupported(t *testing.T) {
+	_, err := ParseSchema(db.ClickHouse, "db", "")
+	require.Error(t, err)
+}
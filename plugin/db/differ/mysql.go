@@ -0,0 +1,209 @@
+package differ
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+)
+
+var (
+	_ dialect = (*mysqlDialect)(nil)
+
+	mysqlBitValueRegexp  = regexp.MustCompile("^b'[01]*'$")
+	mysqlCurrentTsRegexp = regexp.MustCompile(`(?i)^(current_timestamp|now|localtime|localtimestamp)(\(\d*\))?$`)
+)
+
+const mysqlPrimaryKeyName = "PRIMARY"
+
+type mysqlDialect struct{}
+
+func (*mysqlDialect) isPrimaryKey(name string) bool {
+	return name == mysqlPrimaryKeyName
+}
+
+func (*mysqlDialect) hasDefault(column *db.Column) bool {
+	return column.Default != nil
+}
+
+// normalizeView removes the database name from the view definition, such as "select `db`.`t`.`id` AS `id` from `db`.`t`",
+// so that the views in different databases can be compared.
+func (*mysqlDialect) normalizeView(schema *db.Schema, view *db.View) string {
+	return strings.ReplaceAll(view.Definition, fmt.Sprintf("%s.", mysqlQuote(schema.Name)), "")
+}
+
+func (d *mysqlDialect) createTable(table *db.Table, indexList []*index) []string {
+	var lineList []string
+	for _, column := range sortColumnList(table.ColumnList) {
+		lineList = append(lineList, fmt.Sprintf("  %s", d.columnDefinition(table, column)))
+	}
+	for _, index := range indexList {
+		lineList = append(lineList, fmt.Sprintf("  %s", d.indexDefinition(table, index)))
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "CREATE TABLE %s (\n%s\n)", mysqlQuote(table.Name), strings.Join(lineList, ",\n"))
+	if table.Engine != "" {
+		fmt.Fprintf(&buf, " ENGINE=%s", table.Engine)
+	}
+	if table.Collation != "" {
+		fmt.Fprintf(&buf, " COLLATE=%s", table.Collation)
+	}
+	if table.Comment != "" {
+		fmt.Fprintf(&buf, " COMMENT=%s", mysqlString(table.Comment))
+	}
+	buf.WriteString(";")
+	return []string{buf.String()}
+}
+
+func (*mysqlDialect) dropTable(table *db.Table) string {
+	return fmt.Sprintf("DROP TABLE %s;", mysqlQuote(table.Name))
+}
+
+func (*mysqlDialect) alterTableOption(oldTable, newTable *db.Table) []string {
+	var optionList []string
+	if oldTable.Engine != newTable.Engine && newTable.Engine != "" {
+		optionList = append(optionList, fmt.Sprintf("ENGINE=%s", newTable.Engine))
+	}
+	if oldTable.Collation != newTable.Collation && newTable.Collation != "" {
+		optionList = append(optionList, fmt.Sprintf("COLLATE=%s", newTable.Collation))
+	}
+	if oldTable.Comment != newTable.Comment {
+		optionList = append(optionList, fmt.Sprintf("COMMENT=%s", mysqlString(newTable.Comment)))
+	}
+	if len(optionList) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s %s;", mysqlQuote(newTable.Name), strings.Join(optionList, " "))}
+}
+
+func (d *mysqlDialect) addColumn(table *db.Table, column, previous *db.Column) []string {
+	position := "FIRST"
+	if previous != nil {
+		position = fmt.Sprintf("AFTER %s", mysqlQuote(previous.Name))
+	}
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", mysqlQuote(table.Name), d.columnDefinition(table, column), position)}
+}
+
+func (d *mysqlDialect) modifyColumn(table *db.Table, _, newColumn *db.Column) []string {
+	return []string{fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;", mysqlQuote(table.Name), d.columnDefinition(table, newColumn))}
+}
+
+func (*mysqlDialect) dropColumn(table *db.Table, column *db.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", mysqlQuote(table.Name), mysqlQuote(column.Name))
+}
+
+func (d *mysqlDialect) createIndex(table *db.Table, index *index) []string {
+	return []string{fmt.Sprintf("ALTER TABLE %s ADD %s;", mysqlQuote(table.Name), d.indexDefinition(table, index))}
+}
+
+func (*mysqlDialect) dropIndex(table *db.Table, index *index) string {
+	if index.primary {
+		return fmt.Sprintf("ALTER TABLE %s DROP PRIMARY KEY;", mysqlQuote(table.Name))
+	}
+	return fmt.Sprintf("ALTER TABLE %s DROP INDEX %s;", mysqlQuote(table.Name), mysqlQuote(index.name))
+}
+
+// createView creates the view, and the comment is ignored as MySQL doesn't support the view comment.
+func (*mysqlDialect) createView(name, definition, _ string) []string {
+	return []string{fmt.Sprintf("CREATE VIEW %s AS %s;", mysqlQuote(name), definition)}
+}
+
+func (*mysqlDialect) dropView(name string) string {
+	return fmt.Sprintf("DROP VIEW %s;", mysqlQuote(name))
+}
+
+// columnDefinition returns the column definition, such as "`name` varchar(255) NOT NULL DEFAULT 'unknown' COMMENT 'the name'".
+func (*mysqlDialect) columnDefinition(table *db.Table, column *db.Column) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s %s", mysqlQuote(column.Name), column.Type)
+	// The column collation is omitted if it's the same as the table collation.
+	if column.Collation != "" && column.Collation != table.Collation {
+		if column.CharacterSet != "" {
+			fmt.Fprintf(&buf, " CHARACTER SET %s", column.CharacterSet)
+		}
+		fmt.Fprintf(&buf, " COLLATE %s", column.Collation)
+	}
+	if column.Nullable {
+		buf.WriteString(" NULL")
+	} else {
+		buf.WriteString(" NOT NULL")
+	}
+	if column.Default != nil {
+		fmt.Fprintf(&buf, " DEFAULT %s", mysqlDefault(column))
+	}
+	extra := strings.ToLower(column.Extra)
+	if strings.Contains(extra, "auto_increment") {
+		buf.WriteString(" AUTO_INCREMENT")
+	}
+	if i := strings.Index(extra, "on update "); i >= 0 {
+		fmt.Fprintf(&buf, " %s", strings.ToUpper(column.Extra[i:]))
+	}
+	if column.Comment != "" {
+		fmt.Fprintf(&buf, " COMMENT %s", mysqlString(column.Comment))
+	}
+	return buf.String()
+}
+
+// indexDefinition returns the index definition, such as "UNIQUE KEY `uk_name` (`name`)".
+func (*mysqlDialect) indexDefinition(table *db.Table, index *index) string {
+	columnSet := make(map[string]bool)
+	for _, column := range table.ColumnList {
+		columnSet[column.Name] = true
+	}
+	var keyList []string
+	for _, expression := range index.expressionList {
+		if columnSet[expression] {
+			keyList = append(keyList, mysqlQuote(expression))
+		} else {
+			// The functional key part must be enclosed within parentheses.
+			keyList = append(keyList, fmt.Sprintf("(%s)", expression))
+		}
+	}
+	keys := strings.Join(keyList, ", ")
+
+	var buf strings.Builder
+	switch {
+	case index.primary:
+		fmt.Fprintf(&buf, "PRIMARY KEY (%s)", keys)
+	case strings.EqualFold(index.indexType, "FULLTEXT"):
+		fmt.Fprintf(&buf, "FULLTEXT KEY %s (%s)", mysqlQuote(index.name), keys)
+	case strings.EqualFold(index.indexType, "SPATIAL"):
+		fmt.Fprintf(&buf, "SPATIAL KEY %s (%s)", mysqlQuote(index.name), keys)
+	case index.unique:
+		fmt.Fprintf(&buf, "UNIQUE KEY %s (%s)", mysqlQuote(index.name), keys)
+	default:
+		fmt.Fprintf(&buf, "KEY %s (%s)", mysqlQuote(index.name), keys)
+	}
+	if strings.EqualFold(index.indexType, "HASH") {
+		buf.WriteString(" USING HASH")
+	}
+	if index.comment != "" {
+		fmt.Fprintf(&buf, " COMMENT %s", mysqlString(index.comment))
+	}
+	return buf.String()
+}
+
+// mysqlDefault returns the default value in the column definition.
+// The information_schema.COLUMNS reports the literal default value without quotes,
+// and the expression default value with the DEFAULT_GENERATED extra since MySQL 8.0.13.
+func mysqlDefault(column *db.Column) string {
+	value := *column.Default
+	switch {
+	case mysqlCurrentTsRegexp.MatchString(value), mysqlBitValueRegexp.MatchString(value):
+		return value
+	case strings.Contains(strings.ToUpper(column.Extra), "DEFAULT_GENERATED"):
+		return fmt.Sprintf("(%s)", value)
+	}
+	return mysqlString(value)
+}
+
+func mysqlQuote(identifier string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(identifier, "`", "``"))
+}
+
+func mysqlString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
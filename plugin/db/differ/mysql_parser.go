@@ -0,0 +1,327 @@
+package differ
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+	tidbparser "github.com/pingcap/tidb/parser"
+	tidbast "github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	tidbmysql "github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+
+	// Register pingcap parser driver.
+	_ "github.com/pingcap/tidb/types/parser_driver"
+)
+
+// parseMySQLSchema parses the tables, indexes and views from the MySQL schema dump.
+// The routines, events and triggers enclosed by the DELIMITER are ignored, and so are the foreign keys.
+func parseMySQLSchema(databaseName, dump string) (*db.Schema, error) {
+	p := tidbparser.New()
+	// To support MySQL8 window function syntax.
+	p.EnableWindowFunc(true)
+	nodeList, _, err := p.Parse(removeMySQLDelimiterBlock(dump), "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	schema := &db.Schema{Name: databaseName}
+	tableMap := make(map[string]*db.Table)
+	var tableNameList []string
+	for _, node := range nodeList {
+		switch node := node.(type) {
+		case *tidbast.CreateTableStmt:
+			table, err := convertMySQLCreateTable(node)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := tableMap[table.Name]; !ok {
+				tableNameList = append(tableNameList, table.Name)
+			}
+			tableMap[table.Name] = table
+		case *tidbast.CreateIndexStmt:
+			table, ok := tableMap[node.Table.Name.O]
+			if !ok {
+				return nil, fmt.Errorf("table %q not found for index %q", node.Table.Name.O, node.IndexName)
+			}
+			var indexType string
+			switch node.KeyType {
+			case tidbast.IndexKeyTypeFullText:
+				indexType = "FULLTEXT"
+			case tidbast.IndexKeyTypeSpatial:
+				indexType = "SPATIAL"
+			}
+			indexList, err := convertMySQLIndex(node.IndexName, node.IndexPartSpecifications, node.IndexOption, indexType, node.KeyType == tidbast.IndexKeyTypeUnique, false /* primary */)
+			if err != nil {
+				return nil, err
+			}
+			table.IndexList = append(table.IndexList, indexList...)
+		case *tidbast.CreateViewStmt:
+			definition, err := restoreMySQLNode(node.Select)
+			if err != nil {
+				return nil, err
+			}
+			schema.ViewList = append(schema.ViewList, db.View{
+				Name:       node.ViewName.Name.O,
+				Definition: definition,
+			})
+		}
+	}
+	for _, name := range tableNameList {
+		schema.TableList = append(schema.TableList, *tableMap[name])
+	}
+	return schema, nil
+}
+
+func convertMySQLCreateTable(node *tidbast.CreateTableStmt) (*db.Table, error) {
+	table := &db.Table{
+		Name: node.Table.Name.O,
+		Type: "BASE TABLE",
+	}
+	for _, option := range node.Options {
+		switch option.Tp {
+		case tidbast.TableOptionEngine:
+			table.Engine = option.StrValue
+		case tidbast.TableOptionCollate:
+			table.Collation = option.StrValue
+		case tidbast.TableOptionComment:
+			table.Comment = option.StrValue
+		}
+	}
+
+	for i, columnDef := range node.Cols {
+		column := db.Column{
+			Name:         columnDef.Name.Name.O,
+			Position:     i + 1,
+			Type:         mysqlColumnType(columnDef),
+			Nullable:     true,
+			CharacterSet: columnDef.Tp.Charset,
+			Collation:    columnDef.Tp.Collate,
+		}
+		var extraList []string
+		for _, option := range columnDef.Options {
+			switch option.Tp {
+			case tidbast.ColumnOptionPrimaryKey:
+				column.Nullable = false
+				table.IndexList = append(table.IndexList, db.Index{Name: mysqlPrimaryKeyName, Expression: column.Name, Position: 1, Type: "BTREE", Unique: true, Visible: true})
+			case tidbast.ColumnOptionUniqKey:
+				table.IndexList = append(table.IndexList, db.Index{Name: column.Name, Expression: column.Name, Position: 1, Type: "BTREE", Unique: true, Visible: true})
+			case tidbast.ColumnOptionNotNull:
+				column.Nullable = false
+			case tidbast.ColumnOptionNull:
+				column.Nullable = true
+			case tidbast.ColumnOptionAutoIncrement:
+				extraList = append(extraList, "auto_increment")
+			case tidbast.ColumnOptionDefaultValue:
+				value, generated, err := mysqlDefaultValue(option.Expr)
+				if err != nil {
+					return nil, err
+				}
+				column.Default = value
+				if generated {
+					extraList = append(extraList, "DEFAULT_GENERATED")
+				}
+			case tidbast.ColumnOptionOnUpdate:
+				value, err := restoreMySQLNode(option.Expr)
+				if err != nil {
+					return nil, err
+				}
+				extraList = append(extraList, fmt.Sprintf("on update %s", strings.TrimSuffix(value, "()")))
+			case tidbast.ColumnOptionComment:
+				comment, err := mysqlStringValue(option.Expr)
+				if err != nil {
+					return nil, err
+				}
+				column.Comment = comment
+			case tidbast.ColumnOptionCollate:
+				column.Collation = option.StrValue
+			}
+		}
+		column.Extra = strings.Join(extraList, " ")
+		table.ColumnList = append(table.ColumnList, column)
+	}
+
+	// MySQL names the unnamed index after its first column, and appends a suffix for the duplicate name.
+	nameSet := make(map[string]bool)
+	for _, index := range table.IndexList {
+		nameSet[index.Name] = true
+	}
+	for _, constraint := range node.Constraints {
+		var indexType string
+		var unique, primary bool
+		switch constraint.Tp {
+		case tidbast.ConstraintPrimaryKey:
+			primary, unique = true, true
+		case tidbast.ConstraintUniq, tidbast.ConstraintUniqKey, tidbast.ConstraintUniqIndex:
+			unique = true
+		case tidbast.ConstraintKey, tidbast.ConstraintIndex:
+		case tidbast.ConstraintFulltext:
+			indexType = "FULLTEXT"
+		default:
+			continue
+		}
+		name := constraint.Name
+		if primary {
+			name = mysqlPrimaryKeyName
+			for _, key := range constraint.Keys {
+				if key.Column == nil {
+					continue
+				}
+				for i := range table.ColumnList {
+					if table.ColumnList[i].Name == key.Column.Name.O {
+						table.ColumnList[i].Nullable = false
+					}
+				}
+			}
+		} else if name == "" && len(constraint.Keys) > 0 && constraint.Keys[0].Column != nil {
+			name = constraint.Keys[0].Column.Name.O
+			for i := 2; nameSet[name]; i++ {
+				name = fmt.Sprintf("%s_%d", constraint.Keys[0].Column.Name.O, i)
+			}
+		}
+		nameSet[name] = true
+		indexList, err := convertMySQLIndex(name, constraint.Keys, constraint.Option, indexType, unique, primary)
+		if err != nil {
+			return nil, err
+		}
+		table.IndexList = append(table.IndexList, indexList...)
+	}
+	return table, nil
+}
+
+func convertMySQLIndex(name string, keyList []*tidbast.IndexPartSpecification, option *tidbast.IndexOption, indexType string, unique, primary bool) ([]db.Index, error) {
+	if primary {
+		name = mysqlPrimaryKeyName
+	}
+	var comment string
+	if indexType == "" {
+		indexType = "BTREE"
+	}
+	if option != nil {
+		comment = option.Comment
+		if option.Tp == model.IndexTypeHash {
+			indexType = "HASH"
+		}
+	}
+
+	var indexList []db.Index
+	for i, key := range keyList {
+		index := db.Index{
+			Name:     name,
+			Position: i + 1,
+			Type:     indexType,
+			Unique:   unique,
+			Visible:  true,
+			Comment:  comment,
+		}
+		if key.Column != nil {
+			index.Expression = key.Column.Name.O
+		} else {
+			expression, err := restoreMySQLNode(key.Expr)
+			if err != nil {
+				return nil, err
+			}
+			index.Expression = expression
+		}
+		indexList = append(indexList, index)
+	}
+	return indexList, nil
+}
+
+// mysqlColumnType returns the column type in the format of the information_schema.COLUMNS.COLUMN_TYPE, such as "int(11) unsigned".
+func mysqlColumnType(columnDef *tidbast.ColumnDef) string {
+	columnType := strings.ToLower(columnDef.Tp.CompactStr())
+	if tidbmysql.HasUnsignedFlag(columnDef.Tp.Flag) {
+		columnType += " unsigned"
+	}
+	if tidbmysql.HasZerofillFlag(columnDef.Tp.Flag) {
+		columnType += " zerofill"
+	}
+	return columnType
+}
+
+// mysqlDefaultValue returns the default value in the format of the information_schema.COLUMNS.COLUMN_DEFAULT,
+// and whether the default value is an expression.
+func mysqlDefaultValue(expr tidbast.ExprNode) (*string, bool, error) {
+	switch expr := expr.(type) {
+	case tidbast.ValueExpr:
+		if expr.GetValue() == nil {
+			return nil, false, nil
+		}
+		restored, err := restoreMySQLNode(expr)
+		if err != nil {
+			return nil, false, err
+		}
+		// Keep the bit value literal such as b'1'.
+		if mysqlBitValueRegexp.MatchString(restored) {
+			return &restored, false, nil
+		}
+		value := expr.GetString()
+		if value == "" {
+			value = expr.GetDatumString()
+		}
+		return &value, false, nil
+	case *tidbast.UnaryOperationExpr:
+		// The negative number such as -1.
+		if value, ok := expr.V.(tidbast.ValueExpr); ok && expr.Op == opcode.Minus {
+			s := fmt.Sprintf("-%s", value.GetDatumString())
+			return &s, false, nil
+		}
+	case *tidbast.FuncCallExpr:
+		value, err := restoreMySQLNode(expr)
+		if err != nil {
+			return nil, false, err
+		}
+		value = strings.TrimSuffix(value, "()")
+		if mysqlCurrentTsRegexp.MatchString(value) {
+			return &value, false, nil
+		}
+		return &value, true, nil
+	}
+	value, err := restoreMySQLNode(expr)
+	if err != nil {
+		return nil, false, err
+	}
+	return &value, true, nil
+}
+
+func mysqlStringValue(expr tidbast.ExprNode) (string, error) {
+	if value, ok := expr.(tidbast.ValueExpr); ok {
+		return value.GetString(), nil
+	}
+	return restoreMySQLNode(expr)
+}
+
+func restoreMySQLNode(node tidbast.Node) (string, error) {
+	var buf strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &buf)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// removeMySQLDelimiterBlock removes the statements enclosed by "DELIMITER ;;" and "DELIMITER ;" in the dump,
+// which are the routines, events and triggers.
+func removeMySQLDelimiterBlock(dump string) string {
+	var buf strings.Builder
+	inBlock := false
+	scanner := bufio.NewScanner(strings.NewReader(dump))
+	scanner.Buffer(make([]byte, bufio.MaxScanTokenSize), len(dump)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(strings.ToUpper(trimmed), "DELIMITER ") {
+			inBlock = strings.TrimSpace(trimmed[len("DELIMITER "):]) != ";"
+			continue
+		}
+		if inBlock {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
@@ -0,0 +1,207 @@
+package differ
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+)
+
+var (
+	_ dialect = (*pgDialect)(nil)
+)
+
+// pgDialect generates the Postgres DDL statements.
+// The table, index and view names synced from Postgres are qualified with the schema and quoted if needed, such as `public."Order"`,
+// while the column names are not quoted.
+type pgDialect struct{}
+
+// isPrimaryKey returns whether the index is the primary key by the default constraint name "<table>_pkey",
+// as the synced index doesn't tell the primary key.
+func (*pgDialect) isPrimaryKey(name string) bool {
+	return strings.HasSuffix(strings.Trim(name, `"`), "_pkey")
+}
+
+// hasDefault returns whether the column has the default value, and the empty default means no default value.
+func (*pgDialect) hasDefault(column *db.Column) bool {
+	return column.Default != nil && *column.Default != ""
+}
+
+// normalizeView trims the definition from pg_get_viewdef, such as " SELECT t.id\n   FROM t;".
+func (*pgDialect) normalizeView(_ *db.Schema, view *db.View) string {
+	return strings.TrimSuffix(strings.TrimSpace(view.Definition), ";")
+}
+
+func (d *pgDialect) createTable(table *db.Table, indexList []*index) []string {
+	var lineList []string
+	columnList := sortColumnList(table.ColumnList)
+	for _, column := range columnList {
+		lineList = append(lineList, fmt.Sprintf("  %s", d.columnDefinition(column)))
+	}
+	var createIndexList []string
+	for _, index := range indexList {
+		if index.primary {
+			lineList = append(lineList, fmt.Sprintf("  CONSTRAINT %s PRIMARY KEY (%s)", index.name, strings.Join(index.expressionList, ", ")))
+			continue
+		}
+		createIndexList = append(createIndexList, d.createIndex(table, index)...)
+	}
+
+	statementList := []string{fmt.Sprintf("CREATE TABLE %s (\n%s\n);", table.Name, strings.Join(lineList, ",\n"))}
+	if table.Comment != "" {
+		statementList = append(statementList, fmt.Sprintf("COMMENT ON TABLE %s IS %s;", table.Name, pgString(table.Comment)))
+	}
+	for _, column := range columnList {
+		if column.Comment != "" {
+			statementList = append(statementList, d.columnComment(table, column))
+		}
+	}
+	return append(statementList, createIndexList...)
+}
+
+func (*pgDialect) dropTable(table *db.Table) string {
+	return fmt.Sprintf("DROP TABLE %s;", table.Name)
+}
+
+func (*pgDialect) alterTableOption(oldTable, newTable *db.Table) []string {
+	if oldTable.Comment == newTable.Comment {
+		return nil
+	}
+	return []string{fmt.Sprintf("COMMENT ON TABLE %s IS %s;", newTable.Name, pgCommentString(newTable.Comment))}
+}
+
+// addColumn adds the column to the end of the table, as Postgres doesn't support the column position.
+func (d *pgDialect) addColumn(table *db.Table, column, _ *db.Column) []string {
+	statementList := []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", table.Name, d.columnDefinition(column))}
+	if column.Comment != "" {
+		statementList = append(statementList, d.columnComment(table, column))
+	}
+	return statementList
+}
+
+func (d *pgDialect) modifyColumn(table *db.Table, oldColumn, newColumn *db.Column) []string {
+	prefix := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", table.Name, pgQuote(newColumn.Name))
+	var statementList []string
+	if oldColumn.Type != newColumn.Type || oldColumn.Collation != newColumn.Collation {
+		if newColumn.Collation != "" {
+			statementList = append(statementList, fmt.Sprintf("%s TYPE %s COLLATE %s;", prefix, newColumn.Type, pgQuote(newColumn.Collation)))
+		} else {
+			statementList = append(statementList, fmt.Sprintf("%s TYPE %s;", prefix, newColumn.Type))
+		}
+	}
+	if oldColumn.Nullable != newColumn.Nullable {
+		if newColumn.Nullable {
+			statementList = append(statementList, fmt.Sprintf("%s DROP NOT NULL;", prefix))
+		} else {
+			statementList = append(statementList, fmt.Sprintf("%s SET NOT NULL;", prefix))
+		}
+	}
+	oldHasDefault, newHasDefault := d.hasDefault(oldColumn), d.hasDefault(newColumn)
+	if newHasDefault && (!oldHasDefault || *oldColumn.Default != *newColumn.Default) {
+		statementList = append(statementList, fmt.Sprintf("%s SET DEFAULT %s;", prefix, *newColumn.Default))
+	} else if oldHasDefault && !newHasDefault {
+		statementList = append(statementList, fmt.Sprintf("%s DROP DEFAULT;", prefix))
+	}
+	if oldColumn.Comment != newColumn.Comment {
+		statementList = append(statementList, d.columnComment(table, newColumn))
+	}
+	return statementList
+}
+
+func (*pgDialect) dropColumn(table *db.Table, column *db.Column) string {
+	return fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table.Name, pgQuote(column.Name))
+}
+
+func (*pgDialect) createIndex(table *db.Table, index *index) []string {
+	expressions := strings.Join(index.expressionList, ", ")
+	if index.primary {
+		return []string{fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s PRIMARY KEY (%s);", table.Name, index.name, expressions)}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("CREATE ")
+	if index.unique {
+		buf.WriteString("UNIQUE ")
+	}
+	fmt.Fprintf(&buf, "INDEX %s ON %s", index.name, table.Name)
+	if index.indexType != "" {
+		fmt.Fprintf(&buf, " USING %s", index.indexType)
+	}
+	fmt.Fprintf(&buf, " (%s);", expressions)
+	statementList := []string{buf.String()}
+	if index.comment != "" {
+		statementList = append(statementList, fmt.Sprintf("COMMENT ON INDEX %s IS %s;", pgQualify(table.Name, index.name), pgString(index.comment)))
+	}
+	return statementList
+}
+
+func (*pgDialect) dropIndex(table *db.Table, index *index) string {
+	if index.primary {
+		return fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", table.Name, index.name)
+	}
+	return fmt.Sprintf("DROP INDEX %s;", pgQualify(table.Name, index.name))
+}
+
+func (*pgDialect) createView(name, definition, comment string) []string {
+	statementList := []string{fmt.Sprintf("CREATE VIEW %s AS %s;", name, definition)}
+	if comment != "" {
+		statementList = append(statementList, fmt.Sprintf("COMMENT ON VIEW %s IS %s;", name, pgString(comment)))
+	}
+	return statementList
+}
+
+func (*pgDialect) dropView(name string) string {
+	return fmt.Sprintf("DROP VIEW %s;", name)
+}
+
+// columnDefinition returns the column definition, such as `"name" character varying(255) NOT NULL DEFAULT 'unknown'::character varying`.
+func (d *pgDialect) columnDefinition(column *db.Column) string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s %s", pgQuote(column.Name), column.Type)
+	if column.Collation != "" {
+		fmt.Fprintf(&buf, " COLLATE %s", pgQuote(column.Collation))
+	}
+	if !column.Nullable {
+		buf.WriteString(" NOT NULL")
+	}
+	if d.hasDefault(column) {
+		fmt.Fprintf(&buf, " DEFAULT %s", *column.Default)
+	}
+	return buf.String()
+}
+
+func (*pgDialect) columnComment(table *db.Table, column *db.Column) string {
+	return fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", table.Name, pgQuote(column.Name), pgCommentString(column.Comment))
+}
+
+// pgQualify qualifies the index name with the schema of the table, as the index is in the same schema as the table.
+func pgQualify(tableName, indexName string) string {
+	inQuote := false
+	for i, c := range tableName {
+		switch c {
+		case '"':
+			inQuote = !inQuote
+		case '.':
+			if !inQuote {
+				return fmt.Sprintf("%s.%s", tableName[:i], indexName)
+			}
+		}
+	}
+	return indexName
+}
+
+func pgQuote(identifier string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(identifier, `"`, `""`))
+}
+
+func pgString(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+// pgCommentString returns NULL for the empty comment to remove the comment.
+func pgCommentString(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return pgString(s)
+}
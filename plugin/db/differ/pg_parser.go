@@ -0,0 +1,387 @@
+package differ
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+)
+
+const pgDefaultSchema = "public"
+
+var (
+	pgIdentifierRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+)
+
+// parsePostgresSchema parses the tables, indexes, views and comments from the Postgres schema dump such as the pg_dump output.
+// The names are qualified and quoted in the same way as the synced schema, and the other objects such as sequences, functions and foreign keys are ignored.
+func parsePostgresSchema(databaseName, dump string) (*db.Schema, error) {
+	res, err := pgquery.Parse(dump)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pgSchemaParser{
+		tableMap:        make(map[string]*db.Table),
+		relationNameMap: make(map[string]string),
+	}
+	schema := &db.Schema{Name: databaseName}
+	viewMap := make(map[string]*db.View)
+	var viewNameList []string
+	for _, stmt := range res.Stmts {
+		switch node := stmt.Stmt.Node.(type) {
+		case *pgquery.Node_CreateStmt:
+			if err := p.createTable(node.CreateStmt); err != nil {
+				return nil, err
+			}
+		case *pgquery.Node_AlterTableStmt:
+			if err := p.alterTable(node.AlterTableStmt); err != nil {
+				return nil, err
+			}
+		case *pgquery.Node_IndexStmt:
+			if err := p.createIndex(node.IndexStmt); err != nil {
+				return nil, err
+			}
+		case *pgquery.Node_ViewStmt:
+			definition, err := pgquery.Deparse(&pgquery.ParseResult{Stmts: []*pgquery.RawStmt{{Stmt: node.ViewStmt.Query}}})
+			if err != nil {
+				return nil, err
+			}
+			name := pgRangeVarName(node.ViewStmt.View)
+			if _, ok := viewMap[name]; !ok {
+				viewNameList = append(viewNameList, name)
+			}
+			viewMap[name] = &db.View{Name: name, Definition: definition}
+		case *pgquery.Node_CommentStmt:
+			if err := p.comment(node.CommentStmt, viewMap); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, name := range p.tableNameList {
+		schema.TableList = append(schema.TableList, *p.tableMap[name])
+	}
+	for _, name := range viewNameList {
+		schema.ViewList = append(schema.ViewList, *viewMap[name])
+	}
+	return schema, nil
+}
+
+type pgSchemaParser struct {
+	tableMap map[string]*db.Table
+	// relationNameMap maps the qualified table name to the unquoted relation name to build the default constraint name.
+	relationNameMap map[string]string
+	tableNameList   []string
+}
+
+func (p *pgSchemaParser) createTable(stmt *pgquery.CreateStmt) error {
+	table := &db.Table{
+		Name: pgRangeVarName(stmt.Relation),
+		Type: "BASE TABLE",
+	}
+	if _, ok := p.tableMap[table.Name]; !ok {
+		p.tableNameList = append(p.tableNameList, table.Name)
+	}
+	p.tableMap[table.Name] = table
+	p.relationNameMap[table.Name] = stmt.Relation.Relname
+
+	for _, elt := range stmt.TableElts {
+		switch elt := elt.Node.(type) {
+		case *pgquery.Node_ColumnDef:
+			if err := p.addColumn(table, elt.ColumnDef); err != nil {
+				return err
+			}
+		case *pgquery.Node_Constraint:
+			if err := p.addConstraint(table, elt.Constraint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *pgSchemaParser) addColumn(table *db.Table, columnDef *pgquery.ColumnDef) error {
+	columnType, err := pgDeparseType(columnDef.TypeName)
+	if err != nil {
+		return err
+	}
+	column := db.Column{
+		Name:     columnDef.Colname,
+		Position: len(table.ColumnList) + 1,
+		Type:     columnType,
+		Nullable: true,
+		Default:  new(string),
+	}
+	if columnDef.CollClause != nil {
+		if nameList := pgStringList(columnDef.CollClause.Collname); len(nameList) > 0 {
+			column.Collation = nameList[len(nameList)-1]
+		}
+	}
+	table.ColumnList = append(table.ColumnList, column)
+
+	for _, node := range columnDef.Constraints {
+		constraint := node.GetConstraint()
+		if constraint == nil {
+			continue
+		}
+		switch constraint.Contype {
+		case pgquery.ConstrType_CONSTR_NOTNULL:
+			table.ColumnList[len(table.ColumnList)-1].Nullable = false
+		case pgquery.ConstrType_CONSTR_NULL:
+			table.ColumnList[len(table.ColumnList)-1].Nullable = true
+		case pgquery.ConstrType_CONSTR_DEFAULT:
+			value, err := pgDeparseExpression(constraint.RawExpr)
+			if err != nil {
+				return err
+			}
+			table.ColumnList[len(table.ColumnList)-1].Default = &value
+		case pgquery.ConstrType_CONSTR_PRIMARY, pgquery.ConstrType_CONSTR_UNIQUE:
+			constraint.Keys = []*pgquery.Node{pgquery.MakeStrNode(column.Name)}
+			if err := p.addConstraint(table, constraint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addConstraint adds the primary key and unique constraints as the indexes with the Postgres default names.
+func (p *pgSchemaParser) addConstraint(table *db.Table, constraint *pgquery.Constraint) error {
+	if constraint == nil {
+		return nil
+	}
+	keyList := pgStringList(constraint.Keys)
+	name := constraint.Conname
+	switch constraint.Contype {
+	case pgquery.ConstrType_CONSTR_PRIMARY:
+		if name == "" {
+			name = fmt.Sprintf("%s_pkey", p.relationNameMap[table.Name])
+		}
+		for i := range table.ColumnList {
+			for _, key := range keyList {
+				if table.ColumnList[i].Name == key {
+					table.ColumnList[i].Nullable = false
+				}
+			}
+		}
+	case pgquery.ConstrType_CONSTR_UNIQUE:
+		if name == "" {
+			name = fmt.Sprintf("%s_%s_key", p.relationNameMap[table.Name], strings.Join(keyList, "_"))
+		}
+	default:
+		return nil
+	}
+
+	for i, key := range keyList {
+		table.IndexList = append(table.IndexList, db.Index{
+			Name:       pgQuoteIfNeeded(name),
+			Expression: pgQuoteIfNeeded(key),
+			Position:   i + 1,
+			Type:       "btree",
+			Unique:     true,
+			Visible:    true,
+		})
+	}
+	return nil
+}
+
+func (p *pgSchemaParser) alterTable(stmt *pgquery.AlterTableStmt) error {
+	if stmt.Relkind != pgquery.ObjectType_OBJECT_TABLE {
+		return nil
+	}
+	// Ignore the relations other than the tables, such as the sequences which are also altered by ALTER TABLE.
+	table, ok := p.tableMap[pgRangeVarName(stmt.Relation)]
+	if !ok {
+		return nil
+	}
+	for _, node := range stmt.Cmds {
+		cmd := node.GetAlterTableCmd()
+		if cmd == nil {
+			continue
+		}
+		switch cmd.Subtype {
+		case pgquery.AlterTableType_AT_AddConstraint:
+			if err := p.addConstraint(table, cmd.Def.GetConstraint()); err != nil {
+				return err
+			}
+		case pgquery.AlterTableType_AT_ColumnDefault, pgquery.AlterTableType_AT_SetNotNull, pgquery.AlterTableType_AT_DropNotNull:
+			column, err := pgGetColumn(table, cmd.Name)
+			if err != nil {
+				return err
+			}
+			switch cmd.Subtype {
+			case pgquery.AlterTableType_AT_ColumnDefault:
+				value := ""
+				if cmd.Def != nil {
+					if value, err = pgDeparseExpression(cmd.Def); err != nil {
+						return err
+					}
+				}
+				column.Default = &value
+			case pgquery.AlterTableType_AT_SetNotNull:
+				column.Nullable = false
+			case pgquery.AlterTableType_AT_DropNotNull:
+				column.Nullable = true
+			}
+		}
+	}
+	return nil
+}
+
+func (p *pgSchemaParser) createIndex(stmt *pgquery.IndexStmt) error {
+	// Ignore the indexes on the materialized views.
+	table, ok := p.tableMap[pgRangeVarName(stmt.Relation)]
+	if !ok {
+		return nil
+	}
+	indexType := stmt.AccessMethod
+	if indexType == "" {
+		indexType = "btree"
+	}
+	for i, node := range stmt.IndexParams {
+		elem := node.GetIndexElem()
+		if elem == nil {
+			continue
+		}
+		expression := pgQuoteIfNeeded(elem.Name)
+		if elem.Expr != nil {
+			var err error
+			if expression, err = pgDeparseExpression(elem.Expr); err != nil {
+				return err
+			}
+		}
+		table.IndexList = append(table.IndexList, db.Index{
+			Name:       pgQuoteIfNeeded(stmt.Idxname),
+			Expression: expression,
+			Position:   i + 1,
+			Type:       indexType,
+			Unique:     stmt.Unique || stmt.Primary,
+			Visible:    true,
+		})
+	}
+	return nil
+}
+
+// comment sets the comments on the table, column, index and view.
+func (p *pgSchemaParser) comment(stmt *pgquery.CommentStmt, viewMap map[string]*db.View) error {
+	var nameList []string
+	switch object := stmt.Object.Node.(type) {
+	case *pgquery.Node_List:
+		nameList = pgStringList(object.List.Items)
+	case *pgquery.Node_String_:
+		nameList = []string{object.String_.Str}
+	}
+	if len(nameList) == 0 {
+		return nil
+	}
+
+	switch stmt.Objtype {
+	case pgquery.ObjectType_OBJECT_TABLE:
+		if table, ok := p.tableMap[pgQualifiedName(nameList)]; ok {
+			table.Comment = stmt.Comment
+		}
+	case pgquery.ObjectType_OBJECT_COLUMN:
+		table, ok := p.tableMap[pgQualifiedName(nameList[:len(nameList)-1])]
+		if !ok {
+			return nil
+		}
+		column, err := pgGetColumn(table, nameList[len(nameList)-1])
+		if err != nil {
+			return err
+		}
+		column.Comment = stmt.Comment
+	case pgquery.ObjectType_OBJECT_VIEW:
+		if view, ok := viewMap[pgQualifiedName(nameList)]; ok {
+			view.Comment = stmt.Comment
+		}
+	case pgquery.ObjectType_OBJECT_INDEX:
+		// The index is in the same schema as its table.
+		schemaName := pgDefaultSchema
+		if len(nameList) > 1 {
+			schemaName = nameList[len(nameList)-2]
+		}
+		indexName := pgQuoteIfNeeded(nameList[len(nameList)-1])
+		for _, table := range p.tableMap {
+			if !strings.HasPrefix(table.Name, pgQuoteIfNeeded(schemaName)+".") {
+				continue
+			}
+			for i := range table.IndexList {
+				if table.IndexList[i].Name == indexName {
+					table.IndexList[i].Comment = stmt.Comment
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func pgGetColumn(table *db.Table, name string) (*db.Column, error) {
+	for i := range table.ColumnList {
+		if table.ColumnList[i].Name == name {
+			return &table.ColumnList[i], nil
+		}
+	}
+	return nil, fmt.Errorf("column %q not found in table %q", name, table.Name)
+}
+
+// pgDeparseType deparses the type name by the type cast, such as "character varying(255)" to "varchar(255)".
+func pgDeparseType(typeName *pgquery.TypeName) (string, error) {
+	node := &pgquery.Node{Node: &pgquery.Node_TypeCast{TypeCast: &pgquery.TypeCast{
+		Arg:      pgquery.MakeAConstIntNode(0, 0),
+		TypeName: typeName,
+	}}}
+	s, err := pgDeparseExpression(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(s, "0::"), nil
+}
+
+// pgDeparseExpression deparses the expression by the select statement.
+func pgDeparseExpression(expr *pgquery.Node) (string, error) {
+	selectStmt := &pgquery.Node{Node: &pgquery.Node_SelectStmt{SelectStmt: &pgquery.SelectStmt{
+		TargetList: []*pgquery.Node{pgquery.MakeResTargetNodeWithVal(expr, 0)},
+	}}}
+	s, err := pgquery.Deparse(&pgquery.ParseResult{Stmts: []*pgquery.RawStmt{{Stmt: selectStmt}}})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(s, "SELECT "), nil
+}
+
+func pgStringList(nodeList []*pgquery.Node) []string {
+	var list []string
+	for _, node := range nodeList {
+		if s := node.GetString_(); s != nil {
+			list = append(list, s.Str)
+		}
+	}
+	return list
+}
+
+// pgRangeVarName returns the qualified name such as `public."Order"`.
+func pgRangeVarName(rangeVar *pgquery.RangeVar) string {
+	if rangeVar.Schemaname == "" {
+		return pgQualifiedName([]string{rangeVar.Relname})
+	}
+	return pgQualifiedName([]string{rangeVar.Schemaname, rangeVar.Relname})
+}
+
+func pgQualifiedName(nameList []string) string {
+	schemaName, name := pgDefaultSchema, nameList[len(nameList)-1]
+	if len(nameList) > 1 {
+		schemaName = nameList[len(nameList)-2]
+	}
+	return fmt.Sprintf("%s.%s", pgQuoteIfNeeded(schemaName), pgQuoteIfNeeded(name))
+}
+
+// pgQuoteIfNeeded quotes the identifier with the capital or special characters.
+func pgQuoteIfNeeded(identifier string) string {
+	if pgIdentifierRegexp.MatchString(identifier) {
+		return identifier
+	}
+	return pgQuote(identifier)
+}
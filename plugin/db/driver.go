@@ -19,10 +19,22 @@ type Type string
 const (
 	// ClickHouse is the database type for CLICKHOUSE.
 	ClickHouse Type = "CLICKHOUSE"
+	// MariaDB is the database type for MARIADB.
+	MariaDB Type = "MARIADB"
+	// MSSQL is the database type for SQL Server.
+	MSSQL Type = "MSSQL"
+	// MongoDB is the database type for MONGODB.
+	MongoDB Type = "MONGODB"
 	// MySQL is the database type for MYSQL.
 	MySQL Type = "MYSQL"
+	// OceanBase is the database type for OceanBase in MySQL mode.
+	OceanBase Type = "OCEANBASE"
+	// Oracle is the database type for ORACLE.
+	Oracle Type = "ORACLE"
 	// Postgres is the database type for POSTGRES.
 	Postgres Type = "POSTGRES"
+	// Redis is the database type for REDIS.
+	Redis Type = "REDIS"
 	// Snowflake is the database type for SNOWFLAKE.
 	Snowflake Type = "SNOWFLAKE"
 	// SQLite is the database type for SQLite.
@@ -43,7 +55,7 @@ type User struct {
 // View is the database view.
 type View struct {
 	Name string
-	// CreatedTs isn't supported for ClickHouse.
+	// CreatedTs isn't supported for ClickHouse, MongoDB.
 	CreatedTs  int64
 	UpdatedTs  int64
 	Definition string
@@ -69,7 +81,7 @@ type Index struct {
 	Unique bool
 	// Visible isn't supported for Postgres, SQLite.
 	Visible bool
-	// Comment isn't supported for SQLite.
+	// Comment isn't supported for SQLite, MongoDB.
 	Comment string
 }
 
@@ -81,39 +93,42 @@ type Column struct {
 	// Nullable isn't supported for ClickHouse.
 	Nullable bool
 	Type     string
-	// CharacterSet isn't supported for Postgres, ClickHouse, SQLite.
+	// CharacterSet isn't supported for Postgres, ClickHouse, SQLite, MSSQL, Oracle.
 	CharacterSet string
 	// Collation isn't supported for ClickHouse, SQLite.
 	Collation string
 	// Comment isn't supported for SQLite.
 	Comment string
+	// Extra is the extra information such as auto_increment, only supported for MySQL.
+	Extra string
 }
 
 // Table is the database table.
 type Table struct {
 	Name string
-	// CreatedTs isn't supported for ClickHouse, SQLite.
+	// CreatedTs isn't supported for ClickHouse, SQLite, MongoDB, Redis.
 	CreatedTs int64
-	// UpdatedTs isn't supported for SQLite.
+	// UpdatedTs isn't supported for SQLite, MongoDB, Redis.
 	UpdatedTs int64
 	Type      string
-	// Engine isn't supported for Postgres, Snowflake, SQLite.
+	// Engine isn't supported for Postgres, Snowflake, SQLite, MSSQL, Oracle, MongoDB, Redis.
 	Engine string
-	// Collation isn't supported for Postgres, ClickHouse, Snowflake, SQLite.
+	// Collation isn't supported for Postgres, ClickHouse, Snowflake, SQLite, MSSQL, Oracle, Redis.
 	Collation string
 	RowCount  int64
-	// DataSize isn't supported for SQLite.
+	// DataSize isn't supported for SQLite, Oracle.
 	DataSize int64
-	// IndexSize isn't supported for ClickHouse, Snowflake, SQLite.
+	// IndexSize isn't supported for ClickHouse, Snowflake, SQLite, Oracle, Redis.
 	IndexSize int64
-	// DataFree isn't supported for Postgres, ClickHouse, Snowflake, SQLite.
+	// DataFree isn't supported for Postgres, ClickHouse, Snowflake, SQLite, MSSQL, Oracle, MongoDB, Redis.
 	DataFree int64
-	// CreateOptions isn't supported for Postgres, ClickHouse, Snowflake, SQLite.
+	// CreateOptions isn't supported for Postgres, ClickHouse, Snowflake, SQLite, MSSQL, Oracle, Redis.
 	CreateOptions string
-	// Comment isn't supported for SQLite.
-	Comment    string
+	// Comment isn't supported for SQLite, MongoDB, Redis.
+	Comment string
+	// ColumnList isn't supported for MongoDB, Redis.
 	ColumnList []Column
-	// IndexList isn't supported for ClickHouse, Snowflake.
+	// IndexList isn't supported for ClickHouse, Snowflake, Redis.
 	IndexList []Index
 }
 
@@ -126,18 +141,18 @@ type InstanceMeta struct {
 // DatabaseMeta is the metadata for a database.
 type DatabaseMeta struct {
 	Name string
-	// CharacterSet isn't supported for ClickHouse, Snowflake.
+	// CharacterSet isn't supported for ClickHouse, Snowflake, MSSQL, MongoDB, Redis.
 	CharacterSet string
-	// Collation isn't supported for ClickHouse, Snowflake.
+	// Collation isn't supported for ClickHouse, Snowflake, Oracle, MongoDB, Redis.
 	Collation string
 }
 
 // Schema is the database schema.
 type Schema struct {
 	Name string
-	// CharacterSet isn't supported for ClickHouse, Snowflake.
+	// CharacterSet isn't supported for ClickHouse, Snowflake, MSSQL, MongoDB, Redis.
 	CharacterSet string
-	// Collation isn't supported for ClickHouse, Snowflake.
+	// Collation isn't supported for ClickHouse, Snowflake, Oracle, MongoDB, Redis.
 	Collation     string
 	TableList     []Table
 	ViewList      []View
@@ -317,6 +332,46 @@ func ParseMigrationInfo(filePath string, filePathTemplate string) (*MigrationInf
 	return mi, nil
 }
 
+// ParseSchemaFileInfo matches filePath against schemaPathTemplate
+// If filePath matches, then it will derive MigrationInfo from the filePath, and the version is left empty for the caller to assign.
+// Both filePath and schemaPathTemplate are the full file path (including the base directory) of the repository.
+func ParseSchemaFileInfo(filePath string, schemaPathTemplate string) (*MigrationInfo, error) {
+	placeholderList := []string{
+		"ENV_NAME",
+		"DB_NAME",
+	}
+	filePathRegex := schemaPathTemplate
+	for _, placeholder := range placeholderList {
+		filePathRegex = strings.ReplaceAll(filePathRegex, fmt.Sprintf("{{%s}}", placeholder), fmt.Sprintf("(?P<%s>[a-zA-Z0-9+-=/_#?!$. ]+)", placeholder))
+	}
+	myRegex, err := regexp.Compile(filePathRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema path template: %q", schemaPathTemplate)
+	}
+	if !myRegex.MatchString(filePath) {
+		return nil, fmt.Errorf("file path %q does not match schema path template %q", filePath, schemaPathTemplate)
+	}
+
+	mi := &MigrationInfo{
+		Source: VCS,
+		Type:   Migrate,
+	}
+	matchList := myRegex.FindStringSubmatch(filePath)
+	if index := myRegex.SubexpIndex("ENV_NAME"); index >= 0 {
+		mi.Environment = matchList[index]
+	}
+	if index := myRegex.SubexpIndex("DB_NAME"); index >= 0 {
+		mi.Namespace = matchList[index]
+		mi.Database = matchList[index]
+	}
+	if mi.Namespace == "" {
+		return nil, fmt.Errorf("file path %q does not contain {{DB_NAME}}, configured schema path template %q", filePath, schemaPathTemplate)
+	}
+	mi.Description = fmt.Sprintf("Apply %s schema change", mi.Database)
+
+	return mi, nil
+}
+
 // MigrationHistory is the API message for migration history.
 type MigrationHistory struct {
 	ID int
@@ -206,3 +206,56 @@ func TestParseMigrationInfo(t *testing.T) {
 		require.Equal(t, tc.want, *mi)
 	}
 }
+
+func TestParseSchemaFileInfo(t *testing.T) {
+	type test struct {
+		filePath           string
+		schemaPathTemplate string
+		want               MigrationInfo
+		wantErr            string
+	}
+
+	tests := []test{
+		{
+			filePath:           "bytebase/.db1__LATEST.sql",
+			schemaPathTemplate: "bytebase/.{{DB_NAME}}__LATEST.sql",
+			want: MigrationInfo{
+				Namespace:   "db1",
+				Database:    "db1",
+				Environment: "",
+				Source:      VCS,
+				Type:        Migrate,
+				Description: "Apply db1 schema change",
+			},
+			wantErr: "",
+		},
+		{
+			filePath:           "bytebase/dev/.db1__LATEST.sql",
+			schemaPathTemplate: "bytebase/{{ENV_NAME}}/.{{DB_NAME}}__LATEST.sql",
+			want: MigrationInfo{
+				Namespace:   "db1",
+				Database:    "db1",
+				Environment: "dev",
+				Source:      VCS,
+				Type:        Migrate,
+				Description: "Apply db1 schema change",
+			},
+			wantErr: "",
+		},
+		{
+			filePath:           "bytebase/db1__001__migrate.sql",
+			schemaPathTemplate: "bytebase/.{{DB_NAME}}__LATEST.sql",
+			wantErr:            "does not match schema path template",
+		},
+	}
+
+	for _, tc := range tests {
+		mi, err := ParseSchemaFileInfo(tc.filePath, tc.schemaPathTemplate)
+		if tc.wantErr != "" {
+			require.Contains(t, err.Error(), tc.wantErr)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.want, *mi)
+	}
+}
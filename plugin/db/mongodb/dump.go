@@ -0,0 +1,265 @@
+package mongodb
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Dump and restore
+const (
+	databaseHeaderFmt = "" +
+		"//\n" +
+		"// MongoDB schema structure for %s\n" +
+		"//\n"
+	dataHeaderFmt = "" +
+		"//\n" +
+		"// Data for collection %s\n" +
+		"//\n"
+
+	// insertBatchSize is the number of documents in an insertMany statement.
+	insertBatchSize = 1000
+)
+
+// Dump dumps the database as a mongosh script.
+func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
+	var dumpableDbNames []string
+	if database != "" {
+		dumpableDbNames = []string{database}
+	} else {
+		var err error
+		dumpableDbNames, err = driver.getDatabases(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get databases: %s", err)
+		}
+	}
+
+	for _, dbName := range dumpableDbNames {
+		// The database switching statement is only included when dumping all databases.
+		includeUseDatabaseStmt := database == ""
+		if err := dumpOneDatabase(ctx, driver.client.Database(dbName), out, schemaOnly, includeUseDatabaseStmt); err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+func dumpOneDatabase(ctx context.Context, database *mongo.Database, out io.Writer, schemaOnly bool, includeUseDatabaseStmt bool) error {
+	if includeUseDatabaseStmt {
+		header := fmt.Sprintf(databaseHeaderFmt, database.Name())
+		if _, err := io.WriteString(out, fmt.Sprintf("%sdb = db.getSiblingDB(%s);\n\n", header, quoteString(database.Name()))); err != nil {
+			return err
+		}
+	}
+
+	collections, err := listCollections(ctx, database)
+	if err != nil {
+		return fmt.Errorf("failed to list collections of database %q, error: %w", database.Name(), err)
+	}
+
+	// Collections and their indexes go first, because views could be defined on them.
+	for _, collection := range collections {
+		if collection.Type == "view" {
+			continue
+		}
+		stmt, err := getCreateCollectionStmt(&collection)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+		if err := dumpIndexes(ctx, database.Collection(collection.Name), out); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, "\n"); err != nil {
+			return err
+		}
+	}
+	for _, collection := range collections {
+		if collection.Type != "view" {
+			continue
+		}
+		stmt, err := getCreateViewStmt(&collection)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, fmt.Sprintf("%s\n", stmt)); err != nil {
+			return err
+		}
+	}
+
+	if !schemaOnly {
+		for _, collection := range collections {
+			if collection.Type == "view" {
+				continue
+			}
+			if err := dumpDocuments(ctx, database.Collection(collection.Name), out); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getCreateCollectionStmt returns the createCollection statement with the options such as the validator and collation.
+func getCreateCollectionStmt(collection *collectionInfo) (string, error) {
+	if len(collection.Options) == 0 {
+		return fmt.Sprintf("db.createCollection(%s);\n", quoteString(collection.Name)), nil
+	}
+	options, err := marshalRelaxed(collection.Options)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("db.createCollection(%s, EJSON.deserialize(%s));\n", quoteString(collection.Name), options), nil
+}
+
+// getCreateViewStmt returns the createView statement.
+func getCreateViewStmt(collection *collectionInfo) (string, error) {
+	pipeline, err := marshalRelaxed(bson.D{{Key: "pipeline", Value: collection.option("pipeline")}})
+	if err != nil {
+		return "", err
+	}
+	stmt := fmt.Sprintf("db.createView(%s, %s, EJSON.deserialize(%s).pipeline", quoteString(collection.Name), quoteString(fmt.Sprintf("%v", collection.option("viewOn"))), pipeline)
+	if collation := collection.option("collation"); collation != nil {
+		options, err := marshalRelaxed(bson.D{{Key: "collation", Value: collation}})
+		if err != nil {
+			return "", err
+		}
+		stmt = fmt.Sprintf("%s, EJSON.deserialize(%s)", stmt, options)
+	}
+	return stmt + ");", nil
+}
+
+// dumpIndexes dumps the indexes except the default _id index.
+func dumpIndexes(ctx context.Context, collection *mongo.Collection, out io.Writer) error {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get indexes of collection %q, error: %w", collection.Name(), err)
+	}
+	var indexes []bson.D
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		stmt, err := getCreateIndexStmt(collection.Name(), index)
+		if err != nil {
+			return err
+		}
+		if stmt == "" {
+			continue
+		}
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getCreateIndexStmt returns the createIndex statement for the index returned by listIndexes.
+// It returns empty string for the default _id index.
+func getCreateIndexStmt(collectionName string, index bson.D) (string, error) {
+	var key interface{}
+	var options bson.D
+	for _, e := range index {
+		switch e.Key {
+		case "key":
+			key = e.Value
+		case "v", "ns":
+			// The index version and namespace are generated by the server.
+		default:
+			options = append(options, e)
+			if e.Key == "name" && e.Value == "_id_" {
+				return "", nil
+			}
+		}
+	}
+	keyJSON, err := marshalRelaxed(bson.D{{Key: "key", Value: key}})
+	if err != nil {
+		return "", err
+	}
+	optionsJSON, err := marshalRelaxed(options)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("db.getCollection(%s).createIndex(EJSON.deserialize(%s).key, EJSON.deserialize(%s));\n", quoteString(collectionName), keyJSON, optionsJSON), nil
+}
+
+// dumpDocuments dumps the documents in canonical extended JSON, so the BSON types are kept.
+func dumpDocuments(ctx context.Context, collection *mongo.Collection, out io.Writer) error {
+	cursor, err := collection.Find(ctx, bson.D{})
+	if err != nil {
+		return fmt.Errorf("failed to find documents of collection %q, error: %w", collection.Name(), err)
+	}
+	defer cursor.Close(ctx)
+
+	if _, err := io.WriteString(out, fmt.Sprintf(dataHeaderFmt, collection.Name())); err != nil {
+		return err
+	}
+	var documents []string
+	flush := func() error {
+		if len(documents) == 0 {
+			return nil
+		}
+		stmt := fmt.Sprintf("db.getCollection(%s).insertMany(EJSON.deserialize([\n%s\n]));\n", quoteString(collection.Name()), strings.Join(documents, ",\n"))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+		documents = nil
+		return nil
+	}
+	for cursor.Next(ctx) {
+		b, err := bson.MarshalExtJSON(cursor.Current, true /* canonical */, false /* escapeHTML */)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, string(b))
+		if len(documents) == insertBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, "\n")
+	return err
+}
+
+// marshalRelaxed marshals the document in relaxed extended JSON, which is also a JavaScript object literal.
+func marshalRelaxed(document interface{}) (string, error) {
+	b, err := bson.MarshalExtJSON(document, false /* canonical */, false /* escapeHTML */)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Restore restores a database.
+func (driver *Driver) Restore(ctx context.Context, sc *bufio.Scanner) (err error) {
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return driver.Execute(ctx, strings.Join(lines, "\n"))
+}
+
+// RestoreTx restores the database in the given transaction.
+func (*Driver) RestoreTx(ctx context.Context, tx *sql.Tx, sc *bufio.Scanner) error {
+	return fmt.Errorf("MongoDB doesn't support restoring in a database/sql transaction")
+}
@@ -0,0 +1,353 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// migrationHistoryCollection is the collection in the bytebase database to record the migration history.
+const migrationHistoryCollection = "migration_history"
+
+// migrationHistory is the migration history document.
+// The fields are the same as the migration_history table of the SQL databases.
+type migrationHistory struct {
+	ID                  int    `bson:"id"`
+	CreatedBy           string `bson:"created_by"`
+	CreatedTs           int64  `bson:"created_ts"`
+	UpdatedBy           string `bson:"updated_by"`
+	UpdatedTs           int64  `bson:"updated_ts"`
+	ReleaseVersion      string `bson:"release_version"`
+	Namespace           string `bson:"namespace"`
+	Sequence            int    `bson:"sequence"`
+	Source              string `bson:"source"`
+	Type                string `bson:"type"`
+	Status              string `bson:"status"`
+	Version             string `bson:"version"`
+	Description         string `bson:"description"`
+	Statement           string `bson:"statement"`
+	Schema              string `bson:"schema"`
+	SchemaPrev          string `bson:"schema_prev"`
+	ExecutionDurationNs int64  `bson:"execution_duration_ns"`
+	IssueID             string `bson:"issue_id"`
+	Payload             string `bson:"payload"`
+}
+
+// migrationHistoryIndexes are the indexes of the migration history collection, which follow the migration_history table of the SQL databases.
+var migrationHistoryIndexes = []mongo.IndexModel{
+	{
+		Keys:    bson.D{{Key: "id", Value: 1}},
+		Options: options.Index().SetName("bytebase_idx_unique_migration_history_id").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "namespace", Value: 1}, {Key: "sequence", Value: 1}},
+		Options: options.Index().SetName("bytebase_idx_unique_migration_history_namespace_sequence").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "namespace", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetName("bytebase_idx_unique_migration_history_namespace_version").SetUnique(true),
+	},
+	{
+		Keys:    bson.D{{Key: "namespace", Value: 1}, {Key: "source", Value: 1}, {Key: "type", Value: 1}},
+		Options: options.Index().SetName("bytebase_idx_migration_history_namespace_source_type"),
+	},
+	{
+		Keys:    bson.D{{Key: "namespace", Value: 1}, {Key: "created_ts", Value: 1}},
+		Options: options.Index().SetName("bytebase_idx_migration_history_namespace_created"),
+	},
+}
+
+func (driver *Driver) migrationHistory() *mongo.Collection {
+	return driver.client.Database(bytebaseDatabase).Collection(migrationHistoryCollection)
+}
+
+// NeedsSetupMigration returns whether it needs to setup migration.
+func (driver *Driver) NeedsSetupMigration(ctx context.Context) (bool, error) {
+	names, err := driver.client.Database(bytebaseDatabase).ListCollectionNames(ctx, bson.D{{Key: "name", Value: migrationHistoryCollection}})
+	if err != nil {
+		return false, err
+	}
+	return len(names) == 0, nil
+}
+
+// SetupMigrationIfNeeded sets up migration if needed.
+func (driver *Driver) SetupMigrationIfNeeded(ctx context.Context) error {
+	setup, err := driver.NeedsSetupMigration(ctx)
+	if err != nil {
+		return nil
+	}
+
+	if setup {
+		log.Info("Bytebase migration schema not found, creating schema...",
+			zap.String("environment", driver.connectionCtx.EnvironmentName),
+			zap.String("database", driver.connectionCtx.InstanceName),
+		)
+		// Creating the indexes creates the database and collection implicitly.
+		if _, err := driver.migrationHistory().Indexes().CreateMany(ctx, migrationHistoryIndexes); err != nil {
+			log.Error("Failed to initialize migration schema.",
+				zap.Error(err),
+				zap.String("environment", driver.connectionCtx.EnvironmentName),
+				zap.String("database", driver.connectionCtx.InstanceName),
+			)
+			return err
+		}
+		log.Info("Successfully created migration schema.",
+			zap.String("environment", driver.connectionCtx.EnvironmentName),
+			zap.String("database", driver.connectionCtx.InstanceName),
+		)
+	}
+
+	return nil
+}
+
+// ExecuteMigration will execute the migration.
+// MongoDB can't use util.ExecuteMigration which records the migration history in a database/sql transaction,
+// so it follows the same phases with the migration history collection.
+func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (migrationHistoryID int64, updatedSchema string, resErr error) {
+	var prevSchemaBuf bytes.Buffer
+	// Don't record schema if the database hasn't exist yet.
+	if !m.CreateDatabase {
+		if _, err := driver.Dump(ctx, m.Database, &prevSchemaBuf, true /*schemaOnly*/); err != nil {
+			return -1, "", util.FormatError(err)
+		}
+	}
+
+	// Phase 1 - Pre-check before executing migration
+	// Phase 2 - Record migration history as PENDING
+	insertedID, err := driver.beginMigration(ctx, m, prevSchemaBuf.String(), statement)
+	if err != nil {
+		return -1, "", err
+	}
+
+	startedNs := time.Now().UnixNano()
+
+	defer func() {
+		if err := driver.endMigration(ctx, startedNs, insertedID, updatedSchema, resErr == nil /*isDone*/); err != nil {
+			log.Error("Failed to update migration history record",
+				zap.Error(err),
+				zap.Int64("migration_id", migrationHistoryID),
+			)
+		}
+	}()
+
+	// Phase 3 - Executing migration
+	// Branch migration type always has empty script.
+	// Baseline migration type could has non-empty script but will not execute, except for CreateDatabase.
+	doMigrate := true
+	if statement == "" {
+		doMigrate = false
+	}
+	if m.Type == db.Baseline && !m.CreateDatabase {
+		doMigrate = false
+	}
+	if doMigrate {
+		// Switch to the target database only if we're NOT creating this target database.
+		// The create database script switches to the target database itself.
+		if !m.CreateDatabase {
+			driver.databaseName = m.Database
+		}
+		if err := driver.Execute(ctx, statement); err != nil {
+			return -1, "", util.FormatError(err)
+		}
+	}
+
+	// Phase 4 - Dump the schema after migration
+	var afterSchemaBuf bytes.Buffer
+	if _, err := driver.Dump(ctx, m.Database, &afterSchemaBuf, true /*schemaOnly*/); err != nil {
+		return -1, "", util.FormatError(err)
+	}
+
+	return insertedID, afterSchemaBuf.String(), nil
+}
+
+// beginMigration checks before executing migration and inserts a migration history document with pending status.
+func (driver *Driver) beginMigration(ctx context.Context, m *db.MigrationInfo, prevSchema string, statement string) (int64, error) {
+	storedVersion, err := util.ToStoredVersion(m.UseSemanticVersion, m.Version, m.SemanticVersionSuffix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert to stored version, error %w", err)
+	}
+	// Phase 1 - Pre-check before executing migration
+	if existingID, err := util.PreCheckMigration(ctx, driver, m); err != nil {
+		return -1, err
+	} else if existingID >= 0 {
+		return existingID, nil
+	}
+
+	largestSequence, err := driver.findLargestSequence(ctx, m.Namespace, false /* baseline */)
+	if err != nil {
+		return -1, err
+	}
+
+	// Check if there is any higher version already been applied since the last baseline or branch.
+	if version, err := driver.findLargestVersionSinceBaseline(ctx, m.Namespace); err != nil {
+		return -1, err
+	} else if version != nil && *version >= m.Version {
+		return -1, common.Errorf(common.MigrationOutOfOrder, fmt.Errorf("database %q has already applied version %s which >= %s", m.Database, *version, m.Version))
+	}
+
+	// Phase 2 - Record migration history as PENDING.
+	var last migrationHistory
+	insertedID := 1
+	if err := driver.migrationHistory().FindOne(ctx, bson.D{}, options.FindOne().SetSort(bson.D{{Key: "id", Value: -1}})).Decode(&last); err == nil {
+		insertedID = last.ID + 1
+	} else if err != mongo.ErrNoDocuments {
+		return -1, err
+	}
+	now := time.Now().Unix()
+	history := migrationHistory{
+		ID:             insertedID,
+		CreatedBy:      m.Creator,
+		CreatedTs:      now,
+		UpdatedBy:      m.Creator,
+		UpdatedTs:      now,
+		ReleaseVersion: m.ReleaseVersion,
+		Namespace:      m.Namespace,
+		Sequence:       largestSequence + 1,
+		Source:         string(m.Source),
+		Type:           string(m.Type),
+		Status:         string(db.Pending),
+		Version:        storedVersion,
+		Description:    m.Description,
+		Statement:      statement,
+		Schema:         prevSchema,
+		SchemaPrev:     prevSchema,
+		IssueID:        m.IssueID,
+		Payload:        m.Payload,
+	}
+	if _, err := driver.migrationHistory().InsertOne(ctx, history); err != nil {
+		return -1, util.FormatError(err)
+	}
+	return int64(insertedID), nil
+}
+
+// endMigration updates the migration history document to DONE or FAILED depending on migration is done or not.
+func (driver *Driver) endMigration(ctx context.Context, startedNs int64, migrationHistoryID int64, updatedSchema string, isDone bool) error {
+	migrationDurationNs := time.Now().UnixNano() - startedNs
+
+	update := bson.D{
+		{Key: "status", Value: string(db.Failed)},
+		{Key: "execution_duration_ns", Value: migrationDurationNs},
+	}
+	if isDone {
+		update = bson.D{
+			{Key: "status", Value: string(db.Done)},
+			{Key: "execution_duration_ns", Value: migrationDurationNs},
+			{Key: "schema", Value: updatedSchema},
+		}
+	}
+	_, err := driver.migrationHistory().UpdateOne(ctx, bson.D{{Key: "id", Value: migrationHistoryID}}, bson.D{{Key: "$set", Value: update}})
+	return err
+}
+
+// findLargestSequence will return the largest sequence number, or 0 if we haven't applied any migration for this namespace.
+func (driver *Driver) findLargestSequence(ctx context.Context, namespace string, baseline bool) (int, error) {
+	filter := bson.D{{Key: "namespace", Value: namespace}}
+	if baseline {
+		filter = append(filter, bson.E{Key: "type", Value: bson.D{{Key: "$in", Value: bson.A{string(db.Baseline), string(db.Branch)}}}})
+	}
+	var history migrationHistory
+	if err := driver.migrationHistory().FindOne(ctx, filter, options.FindOne().SetSort(bson.D{{Key: "sequence", Value: -1}})).Decode(&history); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		return -1, err
+	}
+	return history.Sequence, nil
+}
+
+// findLargestVersionSinceBaseline will find the largest version since last baseline or branch.
+func (driver *Driver) findLargestVersionSinceBaseline(ctx context.Context, namespace string) (*string, error) {
+	largestBaselineSequence, err := driver.findLargestSequence(ctx, namespace, true /* baseline */)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{
+		{Key: "namespace", Value: namespace},
+		{Key: "sequence", Value: bson.D{{Key: "$gte", Value: largestBaselineSequence}}},
+	}
+	var history migrationHistory
+	if err := driver.migrationHistory().FindOne(ctx, filter, options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}})).Decode(&history); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &history.Version, nil
+}
+
+// FindMigrationHistoryList finds the migration history.
+func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
+	filter := bson.D{}
+	if v := find.ID; v != nil {
+		filter = append(filter, bson.E{Key: "id", Value: *v})
+	}
+	if v := find.Database; v != nil {
+		filter = append(filter, bson.E{Key: "namespace", Value: *v})
+	}
+	if v := find.Version; v != nil {
+		// TODO(d): support semantic versioning.
+		storedVersion, err := util.ToStoredVersion(false, *v, "")
+		if err != nil {
+			return nil, err
+		}
+		filter = append(filter, bson.E{Key: "version", Value: storedVersion})
+	}
+	if v := find.Source; v != nil {
+		filter = append(filter, bson.E{Key: "source", Value: string(*v)})
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_ts", Value: -1}, {Key: "id", Value: -1}})
+	if v := find.Limit; v != nil {
+		opts.SetLimit(int64(*v))
+	}
+
+	cursor, err := driver.migrationHistory().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var historyList []migrationHistory
+	if err := cursor.All(ctx, &historyList); err != nil {
+		return nil, err
+	}
+
+	var migrationHistoryList []*db.MigrationHistory
+	for _, history := range historyList {
+		useSemanticVersion, version, semanticVersionSuffix, err := util.FromStoredVersion(history.Version)
+		if err != nil {
+			return nil, err
+		}
+		migrationHistoryList = append(migrationHistoryList, &db.MigrationHistory{
+			ID:                    history.ID,
+			Creator:               history.CreatedBy,
+			CreatedTs:             history.CreatedTs,
+			Updater:               history.UpdatedBy,
+			UpdatedTs:             history.UpdatedTs,
+			ReleaseVersion:        history.ReleaseVersion,
+			Namespace:             history.Namespace,
+			Sequence:              history.Sequence,
+			Source:                db.MigrationSource(history.Source),
+			Type:                  db.MigrationType(history.Type),
+			Status:                db.MigrationStatus(history.Status),
+			Version:               version,
+			Description:           history.Description,
+			Statement:             history.Statement,
+			Schema:                history.Schema,
+			SchemaPrev:            history.SchemaPrev,
+			ExecutionDurationNs:   history.ExecutionDurationNs,
+			IssueID:               history.IssueID,
+			Payload:               history.Payload,
+			UseSemanticVersion:    useSemanticVersion,
+			SemanticVersionSuffix: semanticVersionSuffix,
+		})
+	}
+	return migrationHistoryList, nil
+}
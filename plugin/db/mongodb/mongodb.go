@@ -0,0 +1,301 @@
+package mongodb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+var (
+	systemDatabases = map[string]bool{
+		"admin":  true,
+		"config": true,
+		"local":  true,
+	}
+	bytebaseDatabase = "bytebase"
+
+	_ db.Driver = (*Driver)(nil)
+)
+
+func init() {
+	db.Register(db.MongoDB, newDriver)
+}
+
+// Driver is the MongoDB driver.
+type Driver struct {
+	connectionCtx db.ConnectionContext
+	config        db.ConnectionConfig
+
+	client       *mongo.Client
+	databaseName string
+}
+
+func newDriver(config db.DriverConfig) db.Driver {
+	return &Driver{}
+}
+
+// Open opens a MongoDB driver.
+func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+	driver.connectionCtx = connCtx
+	driver.config = config
+	driver.databaseName = config.Database
+
+	uri, loggedURI := buildURI(config, "")
+	log.Debug("Opening MongoDB driver",
+		zap.String("uri", loggedURI),
+		zap.String("environment", connCtx.EnvironmentName),
+		zap.String("database", connCtx.InstanceName),
+	)
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	driver.client = client
+	return driver, nil
+}
+
+// Close closes the driver.
+func (driver *Driver) Close(ctx context.Context) error {
+	return driver.client.Disconnect(ctx)
+}
+
+// Ping pings the database.
+func (driver *Driver) Ping(ctx context.Context) error {
+	return driver.client.Ping(ctx, nil)
+}
+
+// GetDbConnection gets a database connection.
+// MongoDB isn't a database/sql database, so it returns error.
+func (driver *Driver) GetDbConnection(ctx context.Context, database string) (*sql.DB, error) {
+	return nil, fmt.Errorf("MongoDB doesn't support database/sql connection")
+}
+
+// GetVersion gets the version.
+func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
+	var buildInfo struct {
+		Version string `bson:"version"`
+	}
+	if err := driver.client.Database("admin").RunCommand(ctx, bson.D{{Key: "buildInfo", Value: 1}}).Decode(&buildInfo); err != nil {
+		return "", err
+	}
+	return buildInfo.Version, nil
+}
+
+// Execute executes the mongosh script against the current database.
+func (driver *Driver) Execute(ctx context.Context, statement string) error {
+	_, err := driver.runScript(ctx, driver.databaseName, statement)
+	return err
+}
+
+// Query queries a statement.
+// The statement is never evaluated as JavaScript. Only a single read-only collection method call in the form of
+// db.<collection>.<method>(<arguments in extended JSON>) is allowed, which is executed with the Go driver.
+func (driver *Driver) Query(ctx context.Context, statement string, limit int) ([]interface{}, error) {
+	query, err := parseQuery(statement)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := query.run(ctx, driver.client.Database(driver.databaseName), limit)
+	if err != nil {
+		return nil, err
+	}
+	return convertRows(rows)
+}
+
+// runScript runs the JavaScript with mongosh against the database and returns the standard output.
+func (driver *Driver) runScript(ctx context.Context, database string, script string) (string, error) {
+	mongosh, err := exec.LookPath("mongosh")
+	if err != nil {
+		return "", fmt.Errorf("mongosh is required to execute MongoDB scripts, error: %w", err)
+	}
+
+	// The credentials are passed by the script rather than the URI in the command line, which is visible to every
+	// local user.
+	config := driver.config
+	if config.Username != "" {
+		script = fmt.Sprintf("db.getSiblingDB(\"admin\").auth(%s, %s);\n%s", quoteString(config.Username), quoteString(config.Password), script)
+		config.Username, config.Password = "", ""
+	}
+
+	// The script could be too large to pass by --eval, so we write it into a temporary file, which is only
+	// accessible to the current user.
+	f, err := os.CreateTemp("", "bytebase-mongodb-*.js")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(script); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	uri, _ := buildURI(config, database)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, mongosh, "--quiet", uri, f.Name())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		output := strings.TrimSpace(stderr.String())
+		if output == "" {
+			output = strings.TrimSpace(stdout.String())
+		}
+		return "", fmt.Errorf("failed to execute script with mongosh, error: %w, output: %s", err, output)
+	}
+	return stdout.String(), nil
+}
+
+// buildURI returns the connection URI and its redacted form for logging.
+func buildURI(config db.ConnectionConfig, database string) (string, string) {
+	host := config.Host
+	if config.Port != "" && !strings.Contains(host, ",") {
+		host = fmt.Sprintf("%s:%s", config.Host, config.Port)
+	}
+
+	query := url.Values{}
+	query.Add("appName", "bytebase")
+	if config.Username != "" {
+		// The users are usually created in the admin database.
+		query.Add("authSource", "admin")
+	}
+	u := &url.URL{
+		Scheme:   "mongodb",
+		Host:     host,
+		Path:     "/" + database,
+		RawQuery: query.Encode(),
+	}
+	logged := *u
+	if config.Username != "" {
+		u.User = url.UserPassword(config.Username, config.Password)
+		logged.User = url.UserPassword(config.Username, "<<redacted password>>")
+	}
+	return u.String(), logged.String()
+}
+
+// convertRows converts the query result rows into the column names, column types and rows.
+func convertRows(rows []bson.RawValue) ([]interface{}, error) {
+	// Documents are flattened by their top-level fields, and other values are put in the "result" column.
+	var columnNames []string
+	columnIndex := make(map[string]int)
+	var documents []map[string]bson.RawValue
+	for _, row := range rows {
+		document := make(map[string]bson.RawValue)
+		var keys []string
+		if doc, ok := row.DocumentOK(); ok {
+			elements, err := doc.Elements()
+			if err != nil {
+				return nil, err
+			}
+			for _, element := range elements {
+				keys = append(keys, element.Key())
+				document[element.Key()] = element.Value()
+			}
+		} else {
+			keys = append(keys, "result")
+			document["result"] = row
+		}
+		for _, key := range keys {
+			if _, ok := columnIndex[key]; !ok {
+				columnIndex[key] = len(columnNames)
+				columnNames = append(columnNames, key)
+			}
+		}
+		documents = append(documents, document)
+	}
+
+	columnTypeNames := make([]string, len(columnNames))
+	data := []interface{}{}
+	for _, document := range documents {
+		rowData := make([]interface{}, len(columnNames))
+		for key, value := range document {
+			i := columnIndex[key]
+			rowData[i] = convertValue(value)
+			if columnTypeNames[i] == "" && rowData[i] != nil {
+				columnTypeNames[i] = getTypeName(value.Type)
+			}
+		}
+		data = append(data, rowData)
+	}
+	for i := range columnTypeNames {
+		if columnTypeNames[i] == "" {
+			columnTypeNames[i] = "NULL"
+		}
+	}
+
+	return []interface{}{columnNames, columnTypeNames, data}, nil
+}
+
+// convertValue converts the BSON value to the JSON-friendly value.
+func convertValue(value bson.RawValue) interface{} {
+	switch value.Type {
+	case bsontype.Null, bsontype.Undefined:
+		return nil
+	case bsontype.String:
+		return value.StringValue()
+	case bsontype.Boolean:
+		return value.Boolean()
+	case bsontype.Int32:
+		return int64(value.Int32())
+	case bsontype.Int64:
+		return value.Int64()
+	case bsontype.Double:
+		return value.Double()
+	case bsontype.ObjectID:
+		return value.ObjectID().Hex()
+	case bsontype.DateTime:
+		return value.Time().UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+	// Other values are presented in extended JSON.
+	return value.String()
+}
+
+func getTypeName(t bsontype.Type) string {
+	switch t {
+	case bsontype.String:
+		return "STRING"
+	case bsontype.Boolean:
+		return "BOOL"
+	case bsontype.Int32:
+		return "INT"
+	case bsontype.Int64:
+		return "LONG"
+	case bsontype.Double:
+		return "DOUBLE"
+	case bsontype.Decimal128:
+		return "DECIMAL"
+	case bsontype.ObjectID:
+		return "OBJECTID"
+	case bsontype.DateTime:
+		return "DATE"
+	case bsontype.EmbeddedDocument:
+		return "OBJECT"
+	case bsontype.Array:
+		return "ARRAY"
+	case bsontype.Binary:
+		return "BINARY"
+	}
+	return strings.ToUpper(t.String())
+}
+
+// quoteString quotes the string as a JavaScript string literal.
+func quoteString(s string) string {
+	// JSON string is a valid JavaScript string literal, and json.Marshal never fails on a string.
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,268 @@
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	// queryStmt matches a single collection method call, the collection is either referred by name or by
+	// db.getCollection() with a double-quoted string.
+	queryStmt = regexp.MustCompile(`^db\.(?:getCollection\(\s*("(?:[^"\\]|\\.)*")\s*\)|([A-Za-z_$][\w$]*))\.([A-Za-z]+)\(([\s\S]*)\)$`)
+	// forbiddenAggregateStages are the aggregation stages writing to a collection.
+	forbiddenAggregateStages = map[string]bool{
+		"$out":   true,
+		"$merge": true,
+	}
+	// emptyDocument is the BSON encoding of an empty document.
+	emptyDocument = bson.Raw{0x05, 0x00, 0x00, 0x00, 0x00}
+)
+
+// query is a read-only collection method call parsed from the query statement.
+type query struct {
+	collection string
+	method     string
+	args       []bson.RawValue
+}
+
+// parseQuery parses the statement in the form of db.<collection>.<method>(<arguments>), where the arguments are in
+// extended JSON, e.g. db.users.find({"age": {"$gt": 18}}, {"name": 1}).
+func parseQuery(statement string) (*query, error) {
+	statement = strings.TrimRight(strings.TrimSpace(statement), ";")
+	matches := queryStmt.FindStringSubmatch(statement)
+	if matches == nil {
+		return nil, fmt.Errorf("MongoDB only supports read-only statements in the form of db.<collection>.<method>(<arguments in extended JSON>) in query, got %q", statement)
+	}
+
+	q := &query{
+		collection: matches[2],
+		method:     matches[3],
+	}
+	if matches[1] != "" {
+		if err := json.Unmarshal([]byte(matches[1]), &q.collection); err != nil {
+			return nil, fmt.Errorf("invalid collection name %s, error: %w", matches[1], err)
+		}
+	}
+	switch q.method {
+	case "find", "findOne", "aggregate", "countDocuments", "estimatedDocumentCount", "distinct":
+	default:
+		return nil, fmt.Errorf("MongoDB only supports find, findOne, aggregate, countDocuments, estimatedDocumentCount and distinct in query, got %q", q.method)
+	}
+
+	// The arguments are parsed as the elements of an array in extended JSON, so they can only be data.
+	if args := strings.TrimSpace(matches[4]); args != "" {
+		if !json.Valid([]byte(fmt.Sprintf("[%s]", args))) {
+			return nil, fmt.Errorf("the arguments of %s must be in extended JSON, got %q", q.method, args)
+		}
+		var doc struct {
+			Args []bson.RawValue `bson:"args"`
+		}
+		if err := bson.UnmarshalExtJSON([]byte(fmt.Sprintf(`{"args": [%s]}`, args)), false /* canonical */, &doc); err != nil {
+			return nil, fmt.Errorf("the arguments of %s must be in extended JSON, error: %w", q.method, err)
+		}
+		q.args = doc.Args
+	}
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// validate validates the number and the types of the arguments of the method.
+func (q *query) validate() error {
+	switch q.method {
+	case "find", "findOne":
+		if len(q.args) > 2 {
+			return fmt.Errorf("%s accepts the filter and the projection only", q.method)
+		}
+		for i := range q.args {
+			if _, err := q.document(i); err != nil {
+				return err
+			}
+		}
+	case "aggregate":
+		if len(q.args) != 1 || q.args[0].Type != bsontype.Array {
+			return fmt.Errorf("aggregate accepts the pipeline array only")
+		}
+		stages, err := q.args[0].Array().Values()
+		if err != nil {
+			return err
+		}
+		for _, stage := range stages {
+			doc, ok := stage.DocumentOK()
+			if !ok {
+				return fmt.Errorf("the aggregation stage must be a document, got %s", stage.Type)
+			}
+			if err := checkForbiddenStages(doc); err != nil {
+				return err
+			}
+		}
+	case "countDocuments":
+		if len(q.args) > 1 {
+			return fmt.Errorf("countDocuments accepts the filter only")
+		}
+		if _, err := q.document(0); err != nil {
+			return err
+		}
+	case "estimatedDocumentCount":
+		if len(q.args) > 0 {
+			return fmt.Errorf("estimatedDocumentCount accepts no argument")
+		}
+	case "distinct":
+		if len(q.args) < 1 || len(q.args) > 2 || q.args[0].Type != bsontype.String {
+			return fmt.Errorf("distinct accepts the field name and the filter only")
+		}
+		if _, err := q.document(1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// document returns the i-th argument as a document, or an empty document if the argument is absent.
+func (q *query) document(i int) (bson.Raw, error) {
+	if i >= len(q.args) {
+		return emptyDocument, nil
+	}
+	doc, ok := q.args[i].DocumentOK()
+	if !ok {
+		return nil, fmt.Errorf("the argument %d of %s must be a document, got %s", i+1, q.method, q.args[i].Type)
+	}
+	return doc, nil
+}
+
+// checkForbiddenStages checks the stage, including the sub-pipelines nested in it, contains no stage writing to a
+// collection.
+func checkForbiddenStages(doc bson.Raw) error {
+	elements, err := doc.Elements()
+	if err != nil {
+		return err
+	}
+	for _, element := range elements {
+		if forbiddenAggregateStages[element.Key()] {
+			return fmt.Errorf("MongoDB doesn't support the %s stage in query", element.Key())
+		}
+		value := element.Value()
+		switch value.Type {
+		case bsontype.EmbeddedDocument:
+			if err := checkForbiddenStages(value.Document()); err != nil {
+				return err
+			}
+		case bsontype.Array:
+			if err := checkForbiddenStages(value.Array()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// run runs the query against the database and returns at most limit rows, or all rows if limit is not positive.
+func (q *query) run(ctx context.Context, database *mongo.Database, limit int) ([]bson.RawValue, error) {
+	collection := database.Collection(q.collection)
+	switch q.method {
+	case "find", "findOne":
+		filter, err := q.document(0)
+		if err != nil {
+			return nil, err
+		}
+		opts := options.Find()
+		if len(q.args) > 1 {
+			opts.SetProjection(q.args[1].Document())
+		}
+		if q.method == "findOne" {
+			limit = 1
+		}
+		if limit > 0 {
+			opts.SetLimit(int64(limit))
+		}
+		cursor, err := collection.Find(ctx, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+		return readCursor(ctx, cursor, limit)
+	case "aggregate":
+		pipeline, err := q.args[0].Array().Values()
+		if err != nil {
+			return nil, err
+		}
+		var stages []interface{}
+		for _, stage := range pipeline {
+			stages = append(stages, stage.Document())
+		}
+		if limit > 0 {
+			stages = append(stages, bson.D{{Key: "$limit", Value: limit}})
+		}
+		cursor, err := collection.Aggregate(ctx, stages)
+		if err != nil {
+			return nil, err
+		}
+		return readCursor(ctx, cursor, limit)
+	case "countDocuments":
+		filter, err := q.document(0)
+		if err != nil {
+			return nil, err
+		}
+		count, err := collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return marshalValues([]interface{}{count})
+	case "estimatedDocumentCount":
+		count, err := collection.EstimatedDocumentCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return marshalValues([]interface{}{count})
+	case "distinct":
+		filter, err := q.document(1)
+		if err != nil {
+			return nil, err
+		}
+		values, err := collection.Distinct(ctx, q.args[0].StringValue(), filter)
+		if err != nil {
+			return nil, err
+		}
+		if limit > 0 && len(values) > limit {
+			values = values[:limit]
+		}
+		return marshalValues(values)
+	}
+	return nil, fmt.Errorf("unsupported method %q", q.method)
+}
+
+// readCursor reads at most limit documents from the cursor, or all documents if limit is not positive.
+func readCursor(ctx context.Context, cursor *mongo.Cursor, limit int) ([]bson.RawValue, error) {
+	defer cursor.Close(ctx)
+	var rows []bson.RawValue
+	for (limit <= 0 || len(rows) < limit) && cursor.Next(ctx) {
+		// The current document is only valid until the next call, so we copy it.
+		doc := make([]byte, len(cursor.Current))
+		copy(doc, cursor.Current)
+		rows = append(rows, bson.RawValue{Type: bsontype.EmbeddedDocument, Value: doc})
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func marshalValues(values []interface{}) ([]bson.RawValue, error) {
+	var rows []bson.RawValue
+	for _, value := range values {
+		t, data, err := bson.MarshalValue(value)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, bson.RawValue{Type: t, Value: data})
+	}
+	return rows, nil
+}
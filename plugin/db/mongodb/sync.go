@@ -0,0 +1,277 @@
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// collectionInfo is the collection returned by the listCollections command.
+type collectionInfo struct {
+	Name    string `bson:"name"`
+	Type    string `bson:"type"`
+	Options bson.D `bson:"options"`
+}
+
+// option returns the value of the collection option, or nil if the option isn't set.
+func (c *collectionInfo) option(key string) interface{} {
+	for _, e := range c.Options {
+		if e.Key == key {
+			return e.Value
+		}
+	}
+	return nil
+}
+
+// indexInfo is the index returned by the listIndexes command.
+type indexInfo struct {
+	Name   string `bson:"name"`
+	Key    bson.D `bson:"key"`
+	Unique bool   `bson:"unique"`
+	Hidden bool   `bson:"hidden"`
+}
+
+// SyncInstance syncs the instance.
+func (driver *Driver) SyncInstance(ctx context.Context) (*db.InstanceMeta, error) {
+	userList, err := driver.getUserList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var databaseList []db.DatabaseMeta
+	for _, database := range databases {
+		databaseList = append(
+			databaseList,
+			db.DatabaseMeta{
+				Name: database,
+			},
+		)
+	}
+
+	return &db.InstanceMeta{
+		UserList:     userList,
+		DatabaseList: databaseList,
+	}, nil
+}
+
+// SyncSchema synces the schema.
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.Schema, error) {
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaList []*db.Schema
+	for _, database := range databases {
+		if len(databaseList) != 0 {
+			exists := false
+			for _, k := range databaseList {
+				if database == k {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				continue
+			}
+		}
+
+		schema := db.Schema{
+			Name: database,
+		}
+		tableList, viewList, err := driver.syncCollectionSchema(ctx, database)
+		if err != nil {
+			return nil, err
+		}
+		schema.TableList, schema.ViewList = tableList, viewList
+
+		schemaList = append(schemaList, &schema)
+	}
+
+	return schemaList, nil
+}
+
+func (driver *Driver) getDatabases(ctx context.Context) ([]string, error) {
+	names, err := driver.client.ListDatabaseNames(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	var databases []string
+	for _, name := range names {
+		if systemDatabases[name] || name == bytebaseDatabase {
+			continue
+		}
+		databases = append(databases, name)
+	}
+	sort.Strings(databases)
+	return databases, nil
+}
+
+// getUserList returns the users of all databases, and the user name is in the "user@database" form.
+func (driver *Driver) getUserList(ctx context.Context) ([]db.User, error) {
+	var result struct {
+		Users []struct {
+			User  string `bson:"user"`
+			DB    string `bson:"db"`
+			Roles []struct {
+				Role string `bson:"role"`
+				DB   string `bson:"db"`
+			} `bson:"roles"`
+		} `bson:"users"`
+	}
+	command := bson.D{{Key: "usersInfo", Value: bson.D{{Key: "forAllDBs", Value: true}}}}
+	if err := driver.client.Database("admin").RunCommand(ctx, command).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to get users, error: %w", err)
+	}
+
+	var userList []db.User
+	for _, user := range result.Users {
+		var roles []string
+		for _, role := range user.Roles {
+			roles = append(roles, fmt.Sprintf("%s@%s", role.Role, role.DB))
+		}
+		userList = append(userList, db.User{
+			Name:  fmt.Sprintf("%s@%s", user.User, user.DB),
+			Grant: strings.Join(roles, ", "),
+		})
+	}
+	return userList, nil
+}
+
+// listCollections returns the collections and views of the database sorted by name, excluding the system collections.
+func listCollections(ctx context.Context, database *mongo.Database) ([]collectionInfo, error) {
+	cursor, err := database.ListCollections(ctx, bson.D{})
+	if err != nil {
+		return nil, err
+	}
+	var collections []collectionInfo
+	if err := cursor.All(ctx, &collections); err != nil {
+		return nil, err
+	}
+	var result []collectionInfo
+	for _, collection := range collections {
+		if strings.HasPrefix(collection.Name, "system.") {
+			continue
+		}
+		result = append(result, collection)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (driver *Driver) syncCollectionSchema(ctx context.Context, databaseName string) ([]db.Table, []db.View, error) {
+	database := driver.client.Database(databaseName)
+	collections, err := listCollections(ctx, database)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list collections of database %q, error: %w", databaseName, err)
+	}
+
+	var tableList []db.Table
+	var viewList []db.View
+	for _, collection := range collections {
+		if collection.Type == "view" {
+			definition, err := formatViewDefinition(&collection)
+			if err != nil {
+				return nil, nil, err
+			}
+			viewList = append(viewList, db.View{
+				Name:       collection.Name,
+				Definition: definition,
+			})
+			continue
+		}
+
+		table := db.Table{
+			Name: collection.Name,
+			Type: strings.ToUpper(collection.Type),
+		}
+		if collation, ok := collection.option("collation").(bson.D); ok {
+			for _, e := range collation {
+				if e.Key == "locale" {
+					table.Collation = fmt.Sprintf("%v", e.Value)
+				}
+			}
+		}
+		// The validator is the closest thing to the table definition, so we put it in the create options.
+		if validator := collection.option("validator"); validator != nil {
+			b, err := bson.MarshalExtJSON(bson.D{{Key: "validator", Value: validator}}, false /* canonical */, false /* escapeHTML */)
+			if err != nil {
+				return nil, nil, err
+			}
+			table.CreateOptions = string(b)
+		}
+
+		var stats struct {
+			Count          int64 `bson:"count"`
+			Size           int64 `bson:"size"`
+			TotalIndexSize int64 `bson:"totalIndexSize"`
+		}
+		if err := database.RunCommand(ctx, bson.D{{Key: "collStats", Value: collection.Name}}).Decode(&stats); err != nil {
+			return nil, nil, fmt.Errorf("failed to get stats of collection %q, error: %w", collection.Name, err)
+		}
+		table.RowCount, table.DataSize, table.IndexSize = stats.Count, stats.Size, stats.TotalIndexSize
+
+		indexList, err := getIndexes(ctx, database.Collection(collection.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get indexes of collection %q, error: %w", collection.Name, err)
+		}
+		table.IndexList = indexList
+
+		tableList = append(tableList, table)
+	}
+
+	return tableList, viewList, nil
+}
+
+// getIndexes returns the indexes of the collection, and each key field of an index is an entry.
+func getIndexes(ctx context.Context, collection *mongo.Collection) ([]db.Index, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var indexes []indexInfo
+	if err := cursor.All(ctx, &indexes); err != nil {
+		return nil, err
+	}
+
+	var indexList []db.Index
+	for _, index := range indexes {
+		for i, key := range index.Key {
+			indexList = append(indexList, db.Index{
+				Name:       index.Name,
+				Expression: key.Key,
+				Position:   i + 1,
+				// The type is the direction (1 or -1) or the special index type such as "text", "2dsphere" and "hashed".
+				Type:    fmt.Sprintf("%v", key.Value),
+				Unique:  index.Unique,
+				Visible: !index.Hidden,
+			})
+		}
+	}
+	return indexList, nil
+}
+
+// formatViewDefinition formats the view definition in the {viewOn, pipeline} form.
+func formatViewDefinition(collection *collectionInfo) (string, error) {
+	definition := bson.D{
+		{Key: "viewOn", Value: collection.option("viewOn")},
+		{Key: "pipeline", Value: collection.option("pipeline")},
+	}
+	b, err := bson.MarshalExtJSON(definition, false /* canonical */, false /* escapeHTML */)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,595 @@
+package mssql
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+)
+
+// Dump and restore
+const (
+	databaseHeaderFmt = "" +
+		"--\n" +
+		"-- SQL Server database structure for %s\n" +
+		"--\n"
+	tableDataHeaderFmt = "" +
+		"--\n" +
+		"-- Data for table %s\n" +
+		"--\n"
+	batchTerminator = "GO\n"
+)
+
+// Dump dumps the database.
+func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
+	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return "", err
+	}
+	defer txn.Rollback()
+
+	var dumpableDbNames []string
+	if database != "" {
+		dumpableDbNames = []string{database}
+	} else {
+		databases, err := driver.getDatabases(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get databases: %s", err)
+		}
+		for _, database := range databases {
+			dumpableDbNames = append(dumpableDbNames, database.Name)
+		}
+	}
+
+	for _, dbName := range dumpableDbNames {
+		// The CREATE DATABASE and USE statements are only included when dumping all databases.
+		includeCreateDatabaseStmt := database == ""
+		if err := dumpOneDatabase(ctx, txn, dbName, out, schemaOnly, includeCreateDatabaseStmt); err != nil {
+			return "", err
+		}
+	}
+
+	if err := txn.Commit(); err != nil {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// dumpOneDatabase dumps the schemas, tables, data, indexes, foreign keys, views and other modules of a database.
+func dumpOneDatabase(ctx context.Context, txn *sql.Tx, database string, out io.Writer, schemaOnly bool, includeCreateDatabaseStmt bool) error {
+	if includeCreateDatabaseStmt {
+		header := fmt.Sprintf(databaseHeaderFmt, database)
+		stmt := fmt.Sprintf("%sCREATE DATABASE %s;\n%sUSE %s;\n%s\n", header, quoteIdentifier(database), batchTerminator, quoteIdentifier(database), batchTerminator)
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+
+	schemaList, err := getSchemas(ctx, txn, database)
+	if err != nil {
+		return fmt.Errorf("failed to get schemas from database %q: %s", database, err)
+	}
+	for _, schema := range schemaList {
+		if _, err := io.WriteString(out, fmt.Sprintf("CREATE SCHEMA %s;\n%s\n", quoteIdentifier(schema), batchTerminator)); err != nil {
+			return err
+		}
+	}
+
+	columns, err := getColumns(ctx, txn, database)
+	if err != nil {
+		return fmt.Errorf("failed to get columns from database %q: %s", database, err)
+	}
+	indexes, err := getIndexes(ctx, txn, database)
+	if err != nil {
+		return fmt.Errorf("failed to get indexes from database %q: %s", database, err)
+	}
+	checks, err := getCheckConstraints(ctx, txn, database)
+	if err != nil {
+		return fmt.Errorf("failed to get check constraints from database %q: %s", database, err)
+	}
+	tables := groupTables(columns, indexes, checks)
+
+	for _, tbl := range tables {
+		if _, err := io.WriteString(out, tbl.createStatement()); err != nil {
+			return err
+		}
+	}
+
+	if !schemaOnly {
+		for _, tbl := range tables {
+			if err := exportTableData(ctx, txn, database, tbl, out); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, tbl := range tables {
+		for _, index := range tbl.indexes {
+			// Primary key and unique constraints are created together with the table.
+			if index.primaryKey || index.uniqueConstraint {
+				continue
+			}
+			if _, err := io.WriteString(out, index.createStatement()); err != nil {
+				return err
+			}
+		}
+	}
+
+	foreignKeys, err := getForeignKeys(ctx, txn, database)
+	if err != nil {
+		return fmt.Errorf("failed to get foreign keys from database %q: %s", database, err)
+	}
+	for _, fk := range foreignKeys {
+		if _, err := io.WriteString(out, fk.createStatement()); err != nil {
+			return err
+		}
+	}
+
+	moduleList, err := getModules(ctx, txn, database)
+	if err != nil {
+		return fmt.Errorf("failed to get views and routines from database %q: %s", database, err)
+	}
+	for _, module := range moduleList {
+		if _, err := io.WriteString(out, fmt.Sprintf("%s\n%s\n", strings.TrimSpace(module), batchTerminator)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSchemas gets the user schemas excluding the built-in schemas and the fixed database role schemas.
+func getSchemas(ctx context.Context, txn *sql.Tx, database string) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT name
+		FROM %s.sys.schemas
+		WHERE schema_id > 4 AND schema_id < 16384
+		ORDER BY schema_id`, quoteIdentifier(database))
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var schemaList []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		schemaList = append(schemaList, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return schemaList, nil
+}
+
+// tableSchema is the table definition assembled from the columns, indexes and check constraints.
+type tableSchema struct {
+	schemaName  string
+	name        string
+	columns     []*tableColumn
+	indexes     []*tableIndex
+	constraints []string
+}
+
+func groupTables(columns []*tableColumn, indexes []*tableIndex, checks []*checkConstraint) []*tableSchema {
+	var tables []*tableSchema
+	tableMap := make(map[string]*tableSchema)
+	for _, column := range columns {
+		key := fmt.Sprintf("%s.%s", column.schemaName, column.tableName)
+		tbl, ok := tableMap[key]
+		if !ok {
+			tbl = &tableSchema{schemaName: column.schemaName, name: column.tableName}
+			tableMap[key] = tbl
+			tables = append(tables, tbl)
+		}
+		tbl.columns = append(tbl.columns, column)
+	}
+	for _, index := range indexes {
+		tbl, ok := tableMap[fmt.Sprintf("%s.%s", index.schemaName, index.tableName)]
+		if !ok {
+			continue
+		}
+		tbl.indexes = append(tbl.indexes, index)
+		switch {
+		case index.primaryKey:
+			tbl.constraints = append(tbl.constraints, fmt.Sprintf("CONSTRAINT %s PRIMARY KEY %s (%s)", quoteIdentifier(index.name), index.typeDesc, strings.Join(index.columnList, ", ")))
+		case index.uniqueConstraint:
+			tbl.constraints = append(tbl.constraints, fmt.Sprintf("CONSTRAINT %s UNIQUE %s (%s)", quoteIdentifier(index.name), index.typeDesc, strings.Join(index.columnList, ", ")))
+		}
+	}
+	for _, check := range checks {
+		tbl, ok := tableMap[fmt.Sprintf("%s.%s", check.schemaName, check.tableName)]
+		if !ok {
+			continue
+		}
+		tbl.constraints = append(tbl.constraints, fmt.Sprintf("CONSTRAINT %s CHECK %s", quoteIdentifier(check.name), check.definition))
+	}
+	return tables
+}
+
+func (t *tableSchema) qualifiedName() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(t.schemaName), quoteIdentifier(t.name))
+}
+
+func (t *tableSchema) createStatement() string {
+	var lines []string
+	for _, column := range t.columns {
+		lines = append(lines, "    "+column.definition())
+	}
+	for _, constraint := range t.constraints {
+		lines = append(lines, "    "+constraint)
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n);\n%s\n", t.qualifiedName(), strings.Join(lines, ",\n"), batchTerminator)
+}
+
+// hasIdentity returns whether the table has an identity column.
+func (t *tableSchema) hasIdentity() bool {
+	for _, column := range t.columns {
+		if column.identity {
+			return true
+		}
+	}
+	return false
+}
+
+// insertableColumns returns the columns whose values can be inserted explicitly.
+func (t *tableSchema) insertableColumns() []*tableColumn {
+	var columns []*tableColumn
+	for _, column := range t.columns {
+		if column.computedDefinition.Valid {
+			continue
+		}
+		if typeName := strings.ToLower(column.typeName); typeName == "timestamp" || typeName == "rowversion" {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func (c *tableColumn) definition() string {
+	if c.computedDefinition.Valid {
+		return fmt.Sprintf("%s AS %s", quoteIdentifier(c.name), c.computedDefinition.String)
+	}
+	parts := []string{quoteIdentifier(c.name), formatColumnType(c.typeName, c.maxLength, c.precision, c.scale)}
+	if c.identity {
+		parts = append(parts, fmt.Sprintf("IDENTITY(%d, %d)", c.identitySeed.Int64, c.identityIncrement.Int64))
+	}
+	if c.collation != "" {
+		parts = append(parts, fmt.Sprintf("COLLATE %s", c.collation))
+	}
+	if c.nullable {
+		parts = append(parts, "NULL")
+	} else {
+		parts = append(parts, "NOT NULL")
+	}
+	if c.defaultValue.Valid {
+		parts = append(parts, fmt.Sprintf("DEFAULT %s", c.defaultValue.String))
+	}
+	return strings.Join(parts, " ")
+}
+
+func (index *tableIndex) createStatement() string {
+	unique := ""
+	if index.unique {
+		unique = "UNIQUE "
+	}
+	stmt := fmt.Sprintf("CREATE %s%s INDEX %s ON %s.%s (%s);\n",
+		unique, index.typeDesc, quoteIdentifier(index.name), quoteIdentifier(index.schemaName), quoteIdentifier(index.tableName), strings.Join(index.columnList, ", "))
+	if index.disabled {
+		stmt += fmt.Sprintf("ALTER INDEX %s ON %s.%s DISABLE;\n", quoteIdentifier(index.name), quoteIdentifier(index.schemaName), quoteIdentifier(index.tableName))
+	}
+	return stmt + batchTerminator + "\n"
+}
+
+// checkConstraint is the check constraint of a table.
+type checkConstraint struct {
+	schemaName string
+	tableName  string
+	name       string
+	definition string
+}
+
+func getCheckConstraints(ctx context.Context, txn *sql.Tx, database string) ([]*checkConstraint, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			s.name,
+			t.name,
+			cc.name,
+			cc.definition
+		FROM %[1]s.sys.check_constraints cc
+		JOIN %[1]s.sys.tables t ON cc.parent_object_id = t.object_id
+		JOIN %[1]s.sys.schemas s ON t.schema_id = s.schema_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY s.name, t.name, cc.name`, quoteIdentifier(database))
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var checks []*checkConstraint
+	for rows.Next() {
+		var check checkConstraint
+		if err := rows.Scan(
+			&check.schemaName,
+			&check.tableName,
+			&check.name,
+			&check.definition,
+		); err != nil {
+			return nil, err
+		}
+		checks = append(checks, &check)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return checks, nil
+}
+
+// foreignKey is the foreign key of a table.
+type foreignKey struct {
+	schemaName           string
+	tableName            string
+	name                 string
+	columnList           []string
+	referencedSchemaName string
+	referencedTableName  string
+	referencedColumnList []string
+	deleteAction         string
+	updateAction         string
+}
+
+func (fk *foreignKey) createStatement() string {
+	stmt := fmt.Sprintf("ALTER TABLE %s.%s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s.%s (%s)",
+		quoteIdentifier(fk.schemaName), quoteIdentifier(fk.tableName), quoteIdentifier(fk.name), strings.Join(fk.columnList, ", "),
+		quoteIdentifier(fk.referencedSchemaName), quoteIdentifier(fk.referencedTableName), strings.Join(fk.referencedColumnList, ", "))
+	if fk.deleteAction != "NO_ACTION" {
+		stmt += fmt.Sprintf(" ON DELETE %s", strings.ReplaceAll(fk.deleteAction, "_", " "))
+	}
+	if fk.updateAction != "NO_ACTION" {
+		stmt += fmt.Sprintf(" ON UPDATE %s", strings.ReplaceAll(fk.updateAction, "_", " "))
+	}
+	return fmt.Sprintf("%s;\n%s\n", stmt, batchTerminator)
+}
+
+func getForeignKeys(ctx context.Context, txn *sql.Tx, database string) ([]*foreignKey, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			s.name,
+			t.name,
+			fk.name,
+			c.name,
+			rs.name,
+			rt.name,
+			rc.name,
+			fk.delete_referential_action_desc,
+			fk.update_referential_action_desc
+		FROM %[1]s.sys.foreign_keys fk
+		JOIN %[1]s.sys.foreign_key_columns fkc ON fk.object_id = fkc.constraint_object_id
+		JOIN %[1]s.sys.tables t ON fk.parent_object_id = t.object_id
+		JOIN %[1]s.sys.schemas s ON t.schema_id = s.schema_id
+		JOIN %[1]s.sys.columns c ON fkc.parent_object_id = c.object_id AND fkc.parent_column_id = c.column_id
+		JOIN %[1]s.sys.tables rt ON fk.referenced_object_id = rt.object_id
+		JOIN %[1]s.sys.schemas rs ON rt.schema_id = rs.schema_id
+		JOIN %[1]s.sys.columns rc ON fkc.referenced_object_id = rc.object_id AND fkc.referenced_column_id = rc.column_id
+		WHERE t.is_ms_shipped = 0
+		ORDER BY s.name, t.name, fk.name, fkc.constraint_column_id`, quoteIdentifier(database))
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var foreignKeys []*foreignKey
+	fkMap := make(map[string]*foreignKey)
+	for rows.Next() {
+		var fk foreignKey
+		var columnName, referencedColumnName string
+		if err := rows.Scan(
+			&fk.schemaName,
+			&fk.tableName,
+			&fk.name,
+			&columnName,
+			&fk.referencedSchemaName,
+			&fk.referencedTableName,
+			&referencedColumnName,
+			&fk.deleteAction,
+			&fk.updateAction,
+		); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s.%s.%s", fk.schemaName, fk.tableName, fk.name)
+		existing, ok := fkMap[key]
+		if !ok {
+			existing = &fk
+			fkMap[key] = existing
+			foreignKeys = append(foreignKeys, existing)
+		}
+		existing.columnList = append(existing.columnList, quoteIdentifier(columnName))
+		existing.referencedColumnList = append(existing.referencedColumnList, quoteIdentifier(referencedColumnName))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return foreignKeys, nil
+}
+
+// getModules gets the definitions of views, procedures, functions and triggers in creation order.
+func getModules(ctx context.Context, txn *sql.Tx, database string) ([]string, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			m.definition
+		FROM %[1]s.sys.sql_modules m
+		JOIN %[1]s.sys.objects o ON m.object_id = o.object_id
+		WHERE o.is_ms_shipped = 0 AND o.type IN ('V', 'P', 'FN', 'IF', 'TF', 'TR') AND m.definition IS NOT NULL
+		ORDER BY o.create_date, o.object_id`, quoteIdentifier(database))
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var moduleList []string
+	for rows.Next() {
+		var definition string
+		if err := rows.Scan(&definition); err != nil {
+			return nil, err
+		}
+		moduleList = append(moduleList, definition)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return moduleList, nil
+}
+
+// exportTableData writes the table rows as INSERT statements.
+func exportTableData(ctx context.Context, txn *sql.Tx, database string, tbl *tableSchema, out io.Writer) error {
+	columns := tbl.insertableColumns()
+	if len(columns) == 0 {
+		return nil
+	}
+	var columnNames []string
+	for _, column := range columns {
+		columnNames = append(columnNames, quoteIdentifier(column.name))
+	}
+	columnPart := strings.Join(columnNames, ", ")
+	query := fmt.Sprintf("SELECT %s FROM %s.%s", columnPart, quoteIdentifier(database), tbl.qualifiedName())
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columnTypes))
+	refs := make([]interface{}, len(columnTypes))
+	for i := range values {
+		refs[i] = &values[i]
+	}
+
+	hasRow := false
+	for rows.Next() {
+		if !hasRow {
+			hasRow = true
+			header := fmt.Sprintf(tableDataHeaderFmt, tbl.qualifiedName())
+			if tbl.hasIdentity() {
+				header += fmt.Sprintf("SET IDENTITY_INSERT %s ON;\n", tbl.qualifiedName())
+			}
+			if _, err := io.WriteString(out, header); err != nil {
+				return err
+			}
+		}
+		if err := rows.Scan(refs...); err != nil {
+			return err
+		}
+		var tokens []string
+		for i, v := range values {
+			tokens = append(tokens, formatValue(v, columnTypes[i].DatabaseTypeName()))
+		}
+		stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);\n", tbl.qualifiedName(), columnPart, strings.Join(tokens, ", "))
+		if _, err := io.WriteString(out, stmt); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	if hasRow {
+		footer := ""
+		if tbl.hasIdentity() {
+			footer = fmt.Sprintf("SET IDENTITY_INSERT %s OFF;\n", tbl.qualifiedName())
+		}
+		if _, err := io.WriteString(out, footer+batchTerminator+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatValue formats the scanned value as a T-SQL literal according to the database type name of the column.
+func formatValue(v interface{}, databaseTypeName string) string {
+	switch value := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if value {
+			return "1"
+		}
+		return "0"
+	case int64:
+		return fmt.Sprintf("%d", value)
+	case float64:
+		return fmt.Sprintf("%v", value)
+	case string:
+		return "N'" + strings.ReplaceAll(value, "'", "''") + "'"
+	case time.Time:
+		var layout string
+		switch databaseTypeName {
+		case "DATE":
+			layout = "2006-01-02"
+		case "TIME":
+			layout = "15:04:05.9999999"
+		case "DATETIME":
+			layout = "2006-01-02T15:04:05.000"
+		case "SMALLDATETIME":
+			layout = "2006-01-02T15:04:05"
+		case "DATETIMEOFFSET":
+			layout = "2006-01-02T15:04:05.9999999-07:00"
+		default:
+			layout = "2006-01-02T15:04:05.9999999"
+		}
+		return "'" + value.Format(layout) + "'"
+	case []byte:
+		switch databaseTypeName {
+		case "DECIMAL", "NUMERIC", "MONEY", "SMALLMONEY":
+			// The driver returns the decimal types as their string representation.
+			return string(value)
+		}
+		return "0x" + hex.EncodeToString(value)
+	}
+	return "N'" + strings.ReplaceAll(fmt.Sprintf("%v", v), "'", "''") + "'"
+}
+
+// Restore restores a database.
+func (driver *Driver) Restore(ctx context.Context, sc *bufio.Scanner) (err error) {
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return driver.Execute(ctx, strings.Join(lines, "\n"))
+}
+
+// RestoreTx restores the database in the given transaction.
+func (driver *Driver) RestoreTx(ctx context.Context, tx *sql.Tx, sc *bufio.Scanner) error {
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	for _, batch := range splitBatches(strings.Join(lines, "\n")) {
+		if _, err := tx.ExecContext(ctx, batch); err != nil {
+			return util.FormatErrorWithQuery(err, batch)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+
+	// Register the SQL Server database/sql driver.
+	_ "github.com/denisenkom/go-mssqldb"
+	"go.uber.org/zap"
+)
+
+var (
+	systemDatabases = map[string]bool{
+		"master": true,
+		"model":  true,
+		"msdb":   true,
+		"tempdb": true,
+	}
+	bytebaseDatabase = "bytebase"
+
+	// batchSeparator matches the "GO" line used by sqlcmd and SSMS to separate batches.
+	batchSeparator = regexp.MustCompile(`(?i)^\s*GO\s*;?\s*$`)
+	// useDatabaseStmt matches the `USE [db]` statement.
+	useDatabaseStmt = regexp.MustCompile(`(?i)^USE\s+(\[[^\]]+\]|"[^"]+"|\S+?)\s*;?$`)
+
+	// driverName is the driver name that our driver dependence register.
+	driverName = "sqlserver"
+
+	_ db.Driver = (*Driver)(nil)
+)
+
+func init() {
+	db.Register(db.MSSQL, newDriver)
+}
+
+// Driver is the SQL Server driver.
+type Driver struct {
+	connectionCtx db.ConnectionContext
+	config        db.ConnectionConfig
+
+	db           *sql.DB
+	databaseName string
+}
+
+func newDriver(config db.DriverConfig) db.Driver {
+	return &Driver{}
+}
+
+// Open opens a SQL Server driver.
+func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+	driver.connectionCtx = connCtx
+	driver.config = config
+
+	databaseName := config.Database
+	if databaseName == "" {
+		databaseName = "master"
+	}
+	if err := driver.switchDatabase(databaseName); err != nil {
+		return nil, err
+	}
+	return driver, nil
+}
+
+// Close closes the driver.
+func (driver *Driver) Close(ctx context.Context) error {
+	return driver.db.Close()
+}
+
+// Ping pings the database.
+func (driver *Driver) Ping(ctx context.Context) error {
+	return driver.db.PingContext(ctx)
+}
+
+// GetDbConnection gets a database connection.
+func (driver *Driver) GetDbConnection(ctx context.Context, database string) (*sql.DB, error) {
+	if database != "" && database != driver.databaseName {
+		if err := driver.switchDatabase(database); err != nil {
+			return nil, err
+		}
+	}
+	return driver.db, nil
+}
+
+// GetVersion gets the version.
+func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
+	query := "SELECT CAST(SERVERPROPERTY('ProductVersion') AS NVARCHAR(128))"
+	var version string
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return "", common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+	return version, nil
+}
+
+// Execute executes a SQL statement.
+// The statement is split into batches by the "GO" separator. CREATE DATABASE can't run inside a user transaction in SQL Server,
+// and USE switches the connection to another database, so these are applied outside the transaction for the remaining batches.
+func (driver *Driver) Execute(ctx context.Context, statement string) error {
+	var remainingBatches []string
+	flush := func() error {
+		if len(remainingBatches) == 0 {
+			return nil
+		}
+		if err := driver.executeBatches(ctx, remainingBatches); err != nil {
+			return err
+		}
+		remainingBatches = nil
+		return nil
+	}
+
+	for _, batch := range splitBatches(statement) {
+		if database, ok := getDatabaseInUseStatement(batch); ok {
+			if err := flush(); err != nil {
+				return err
+			}
+			if _, err := driver.GetDbConnection(ctx, database); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.ToUpper(batch), "CREATE DATABASE ") {
+			if err := flush(); err != nil {
+				return err
+			}
+			if _, err := driver.db.ExecContext(ctx, batch); err != nil {
+				return err
+			}
+			continue
+		}
+		remainingBatches = append(remainingBatches, batch)
+	}
+	return flush()
+}
+
+func (driver *Driver) executeBatches(ctx context.Context, batches []string) error {
+	tx, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, batch := range batches {
+		if _, err := tx.ExecContext(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Query queries a SQL statement.
+func (driver *Driver) Query(ctx context.Context, statement string, limit int) ([]interface{}, error) {
+	// SQL Server driver doesn't support ReadOnly transaction, so we use tx rollback semantics to enforce readonly.
+	tx, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	return util.QueryTx(ctx, tx, statement, limit)
+}
+
+func (driver *Driver) switchDatabase(dbName string) error {
+	if driver.db != nil {
+		if err := driver.db.Close(); err != nil {
+			return err
+		}
+	}
+
+	dsn, loggedDSN := buildDSN(driver.config, dbName)
+	log.Debug("Opening SQL Server driver",
+		zap.String("dsn", loggedDSN),
+		zap.String("environment", driver.connectionCtx.EnvironmentName),
+		zap.String("database", driver.connectionCtx.InstanceName),
+	)
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return err
+	}
+	driver.db = db
+	driver.databaseName = dbName
+	return nil
+}
+
+// buildDSN returns the connection URL and its redacted form for logging.
+func buildDSN(config db.ConnectionConfig, database string) (string, string) {
+	query := url.Values{}
+	query.Add("database", database)
+	query.Add("app name", "bytebase")
+
+	host := config.Host
+	if config.Port != "" {
+		host = fmt.Sprintf("%s:%s", config.Host, config.Port)
+	}
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		Host:     host,
+		RawQuery: query.Encode(),
+	}
+	logged := *u
+	if config.Password != "" {
+		u.User = url.UserPassword(config.Username, config.Password)
+		logged.User = url.UserPassword(config.Username, "<<redacted password>>")
+	} else {
+		u.User = url.User(config.Username)
+		logged.User = url.User(config.Username)
+	}
+	return u.String(), logged.String()
+}
+
+// splitBatches splits the statement into batches by the "GO" separator lines.
+func splitBatches(statement string) []string {
+	var batches []string
+	var lines []string
+	appendBatch := func() {
+		batch := strings.Trim(strings.Join(lines, "\n"), "\n\t ")
+		if batch != "" {
+			batches = append(batches, batch)
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(statement, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if batchSeparator.MatchString(line) {
+			appendBatch()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	appendBatch()
+	return batches
+}
+
+// getDatabaseInUseStatement returns the database name if the batch is a single `USE [db]` statement.
+func getDatabaseInUseStatement(batch string) (string, bool) {
+	matches := useDatabaseStmt.FindStringSubmatch(strings.TrimSpace(batch))
+	if len(matches) != 2 {
+		return "", false
+	}
+	return unquoteIdentifier(matches[1]), true
+}
+
+// quoteIdentifier quotes the identifier with square brackets.
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf("[%s]", strings.ReplaceAll(name, "]", "]]"))
+}
+
+func unquoteIdentifier(name string) string {
+	switch {
+	case strings.HasPrefix(name, "[") && strings.HasSuffix(name, "]"):
+		return strings.ReplaceAll(name[1:len(name)-1], "]]", "]")
+	case strings.HasPrefix(name, `"`) && strings.HasSuffix(name, `"`):
+		return name[1 : len(name)-1]
+	}
+	return name
+}
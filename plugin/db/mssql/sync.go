@@ -0,0 +1,534 @@
+package mssql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+)
+
+// SyncInstance syncs the instance.
+func (driver *Driver) SyncInstance(ctx context.Context) (*db.InstanceMeta, error) {
+	userList, err := driver.getUserList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var databaseList []db.DatabaseMeta
+	for _, database := range databases {
+		databaseList = append(databaseList, *database)
+	}
+
+	return &db.InstanceMeta{
+		UserList:     userList,
+		DatabaseList: databaseList,
+	}, nil
+}
+
+// SyncSchema synces the schema.
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.Schema, error) {
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaList []*db.Schema
+	for _, database := range databases {
+		if len(databaseList) != 0 {
+			exists := false
+			for _, k := range databaseList {
+				if database.Name == k {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				continue
+			}
+		}
+
+		schema := db.Schema{
+			Name:      database.Name,
+			Collation: database.Collation,
+		}
+		tableList, viewList, err := driver.syncTableSchema(ctx, database.Name)
+		if err != nil {
+			return nil, err
+		}
+		schema.TableList, schema.ViewList = tableList, viewList
+
+		schemaList = append(schemaList, &schema)
+	}
+
+	return schemaList, nil
+}
+
+// getDatabases gets all user databases of an instance, excluding system databases and the bytebase database.
+func (driver *Driver) getDatabases(ctx context.Context) ([]*db.DatabaseMeta, error) {
+	query := `
+		SELECT
+			name,
+			ISNULL(collation_name, '')
+		FROM sys.databases
+		ORDER BY name`
+	rows, err := driver.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var databases []*db.DatabaseMeta
+	for rows.Next() {
+		var database db.DatabaseMeta
+		if err := rows.Scan(
+			&database.Name,
+			&database.Collation,
+		); err != nil {
+			return nil, err
+		}
+		if systemDatabases[database.Name] || database.Name == bytebaseDatabase {
+			continue
+		}
+		databases = append(databases, &database)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return databases, nil
+}
+
+func (driver *Driver) getUserList(ctx context.Context) ([]db.User, error) {
+	// Query server role memberships.
+	grantQuery := `
+		SELECT
+			m.name,
+			r.name
+		FROM sys.server_role_members rm
+		JOIN sys.server_principals r ON rm.role_principal_id = r.principal_id
+		JOIN sys.server_principals m ON rm.member_principal_id = m.principal_id`
+	grants := make(map[string][]string)
+
+	grantRows, err := driver.db.QueryContext(ctx, grantQuery)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, grantQuery)
+	}
+	defer grantRows.Close()
+
+	for grantRows.Next() {
+		var name, role string
+		if err := grantRows.Scan(
+			&name,
+			&role,
+		); err != nil {
+			return nil, err
+		}
+		grants[name] = append(grants[name], role)
+	}
+	if err := grantRows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, grantQuery)
+	}
+
+	// Query login info.
+	// S: SQL login, U: Windows login, G: Windows group, E: external login, X: external group.
+	userQuery := `
+		SELECT
+			name,
+			type_desc,
+			is_disabled
+		FROM sys.server_principals
+		WHERE type IN ('S', 'U', 'G', 'E', 'X') AND name NOT LIKE '##%'
+		ORDER BY name`
+	userRows, err := driver.db.QueryContext(ctx, userQuery)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, userQuery)
+	}
+	defer userRows.Close()
+
+	var userList []db.User
+	for userRows.Next() {
+		var name, typeDesc string
+		var disabled bool
+		if err := userRows.Scan(
+			&name,
+			&typeDesc,
+			&disabled,
+		); err != nil {
+			return nil, err
+		}
+
+		roles := grants[name]
+		sort.Strings(roles)
+		grant := fmt.Sprintf("%s: %s", typeDesc, strings.Join(roles, ", "))
+		if len(roles) == 0 {
+			grant = typeDesc
+		}
+		if disabled {
+			grant = fmt.Sprintf("%s (DISABLED)", grant)
+		}
+		userList = append(userList, db.User{
+			Name:  name,
+			Grant: grant,
+		})
+	}
+	if err := userRows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, userQuery)
+	}
+	return userList, nil
+}
+
+func (driver *Driver) syncTableSchema(ctx context.Context, database string) ([]db.Table, []db.View, error) {
+	columns, err := getColumns(ctx, driver.db, database)
+	if err != nil {
+		return nil, nil, err
+	}
+	// schemaName.tableName -> columnList map
+	columnMap := make(map[string][]db.Column)
+	for _, column := range columns {
+		key := fmt.Sprintf("%s.%s", column.schemaName, column.tableName)
+		columnMap[key] = append(columnMap[key], column.toColumn())
+	}
+
+	indexes, err := getIndexes(ctx, driver.db, database)
+	if err != nil {
+		return nil, nil, err
+	}
+	// schemaName.tableName -> indexList map
+	indexMap := make(map[string][]db.Index)
+	for _, index := range indexes {
+		key := fmt.Sprintf("%s.%s", index.schemaName, index.tableName)
+		for i, expression := range index.columnList {
+			indexMap[key] = append(indexMap[key], db.Index{
+				Name:       index.name,
+				Expression: expression,
+				Position:   i + 1,
+				Type:       index.typeDesc,
+				Unique:     index.unique,
+				Visible:    !index.disabled,
+			})
+		}
+	}
+
+	tableQuery := fmt.Sprintf(`
+		SELECT
+			s.name,
+			t.name,
+			DATEDIFF(SECOND, '1970-01-01', t.create_date),
+			DATEDIFF(SECOND, '1970-01-01', t.modify_date),
+			ISNULL(ps.row_count, 0),
+			ISNULL(ps.data_page_count, 0) * 8192,
+			ISNULL(ps.used_page_count, 0) * 8192,
+			ISNULL(CAST(ep.value AS NVARCHAR(MAX)), '')
+		FROM %[1]s.sys.tables t
+		JOIN %[1]s.sys.schemas s ON t.schema_id = s.schema_id
+		LEFT JOIN (
+			SELECT
+				object_id,
+				SUM(CASE WHEN index_id < 2 THEN row_count ELSE 0 END) AS row_count,
+				SUM(CASE WHEN index_id < 2 THEN in_row_data_page_count + lob_used_page_count + row_overflow_used_page_count ELSE 0 END) AS data_page_count,
+				SUM(used_page_count) AS used_page_count
+			FROM %[1]s.sys.dm_db_partition_stats
+			GROUP BY object_id
+		) ps ON t.object_id = ps.object_id
+		LEFT JOIN %[1]s.sys.extended_properties ep ON ep.class = 1 AND ep.major_id = t.object_id AND ep.minor_id = 0 AND ep.name = 'MS_Description'
+		WHERE t.is_ms_shipped = 0
+		ORDER BY s.name, t.name`, quoteIdentifier(database))
+	tableRows, err := driver.db.QueryContext(ctx, tableQuery)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, tableQuery)
+	}
+	defer tableRows.Close()
+
+	var tables []db.Table
+	for tableRows.Next() {
+		var schemaName, tableName string
+		var totalSize int64
+		var table db.Table
+		if err := tableRows.Scan(
+			&schemaName,
+			&tableName,
+			&table.CreatedTs,
+			&table.UpdatedTs,
+			&table.RowCount,
+			&table.DataSize,
+			&totalSize,
+			&table.Comment,
+		); err != nil {
+			return nil, nil, err
+		}
+
+		table.Name = fmt.Sprintf("%s.%s", schemaName, tableName)
+		table.Type = "BASE TABLE"
+		table.IndexSize = totalSize - table.DataSize
+		table.ColumnList = columnMap[table.Name]
+		table.IndexList = indexMap[table.Name]
+		tables = append(tables, table)
+	}
+	if err := tableRows.Err(); err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, tableQuery)
+	}
+
+	viewQuery := fmt.Sprintf(`
+		SELECT
+			s.name,
+			v.name,
+			DATEDIFF(SECOND, '1970-01-01', v.create_date),
+			DATEDIFF(SECOND, '1970-01-01', v.modify_date),
+			ISNULL(m.definition, ''),
+			ISNULL(CAST(ep.value AS NVARCHAR(MAX)), '')
+		FROM %[1]s.sys.views v
+		JOIN %[1]s.sys.schemas s ON v.schema_id = s.schema_id
+		LEFT JOIN %[1]s.sys.sql_modules m ON v.object_id = m.object_id
+		LEFT JOIN %[1]s.sys.extended_properties ep ON ep.class = 1 AND ep.major_id = v.object_id AND ep.minor_id = 0 AND ep.name = 'MS_Description'
+		WHERE v.is_ms_shipped = 0
+		ORDER BY s.name, v.name`, quoteIdentifier(database))
+	viewRows, err := driver.db.QueryContext(ctx, viewQuery)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, viewQuery)
+	}
+	defer viewRows.Close()
+
+	var views []db.View
+	for viewRows.Next() {
+		var schemaName, viewName string
+		var view db.View
+		if err := viewRows.Scan(
+			&schemaName,
+			&viewName,
+			&view.CreatedTs,
+			&view.UpdatedTs,
+			&view.Definition,
+			&view.Comment,
+		); err != nil {
+			return nil, nil, err
+		}
+		view.Name = fmt.Sprintf("%s.%s", schemaName, viewName)
+		views = append(views, view)
+	}
+	if err := viewRows.Err(); err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, viewQuery)
+	}
+
+	return tables, views, nil
+}
+
+// tableColumn is the column of a table in SQL Server.
+type tableColumn struct {
+	schemaName string
+	tableName  string
+	name       string
+	position   int
+	typeName   string
+	maxLength  int
+	precision  int
+	scale      int
+	nullable   bool
+	identity   bool
+	// identitySeed and identityIncrement are only valid for the identity column.
+	identitySeed      sql.NullInt64
+	identityIncrement sql.NullInt64
+	// computedDefinition is only valid for the computed column, e.g. ([price]*[quantity]).
+	computedDefinition sql.NullString
+	// defaultValue is the definition of the default constraint, e.g. ((0)).
+	defaultValue sql.NullString
+	collation    string
+	comment      string
+}
+
+func (c *tableColumn) toColumn() db.Column {
+	column := db.Column{
+		Name:      c.name,
+		Position:  c.position,
+		Nullable:  c.nullable,
+		Type:      formatColumnType(c.typeName, c.maxLength, c.precision, c.scale),
+		Collation: c.collation,
+		Comment:   c.comment,
+	}
+	if c.defaultValue.Valid {
+		column.Default = &c.defaultValue.String
+	}
+	return column
+}
+
+func getColumns(ctx context.Context, queryer queryer, database string) ([]*tableColumn, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			s.name,
+			t.name,
+			c.name,
+			c.column_id,
+			ty.name,
+			c.max_length,
+			c.precision,
+			c.scale,
+			c.is_nullable,
+			c.is_identity,
+			CAST(idc.seed_value AS BIGINT),
+			CAST(idc.increment_value AS BIGINT),
+			cc.definition,
+			dc.definition,
+			ISNULL(c.collation_name, ''),
+			ISNULL(CAST(ep.value AS NVARCHAR(MAX)), '')
+		FROM %[1]s.sys.columns c
+		JOIN %[1]s.sys.tables t ON c.object_id = t.object_id
+		JOIN %[1]s.sys.schemas s ON t.schema_id = s.schema_id
+		JOIN %[1]s.sys.types ty ON c.user_type_id = ty.user_type_id
+		LEFT JOIN %[1]s.sys.identity_columns idc ON c.object_id = idc.object_id AND c.column_id = idc.column_id
+		LEFT JOIN %[1]s.sys.computed_columns cc ON c.object_id = cc.object_id AND c.column_id = cc.column_id
+		LEFT JOIN %[1]s.sys.default_constraints dc ON c.default_object_id = dc.object_id
+		LEFT JOIN %[1]s.sys.extended_properties ep ON ep.class = 1 AND ep.major_id = c.object_id AND ep.minor_id = c.column_id AND ep.name = 'MS_Description'
+		WHERE t.is_ms_shipped = 0
+		ORDER BY s.name, t.name, c.column_id`, quoteIdentifier(database))
+	rows, err := queryer.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var columns []*tableColumn
+	for rows.Next() {
+		var column tableColumn
+		if err := rows.Scan(
+			&column.schemaName,
+			&column.tableName,
+			&column.name,
+			&column.position,
+			&column.typeName,
+			&column.maxLength,
+			&column.precision,
+			&column.scale,
+			&column.nullable,
+			&column.identity,
+			&column.identitySeed,
+			&column.identityIncrement,
+			&column.computedDefinition,
+			&column.defaultValue,
+			&column.collation,
+			&column.comment,
+		); err != nil {
+			return nil, err
+		}
+		columns = append(columns, &column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return columns, nil
+}
+
+// tableIndex is the index of a table in SQL Server.
+type tableIndex struct {
+	schemaName string
+	tableName  string
+	name       string
+	// typeDesc is CLUSTERED, NONCLUSTERED, etc.
+	typeDesc   string
+	unique     bool
+	primaryKey bool
+	// uniqueConstraint is whether the index is created by a UNIQUE constraint.
+	uniqueConstraint bool
+	disabled         bool
+	// columnList is the key columns ordered by the key ordinal, e.g. [id], [name] DESC.
+	columnList []string
+}
+
+func getIndexes(ctx context.Context, queryer queryer, database string) ([]*tableIndex, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			s.name,
+			t.name,
+			i.name,
+			i.type_desc,
+			i.is_unique,
+			i.is_primary_key,
+			i.is_unique_constraint,
+			i.is_disabled,
+			c.name,
+			ic.is_descending_key
+		FROM %[1]s.sys.indexes i
+		JOIN %[1]s.sys.tables t ON i.object_id = t.object_id
+		JOIN %[1]s.sys.schemas s ON t.schema_id = s.schema_id
+		JOIN %[1]s.sys.index_columns ic ON i.object_id = ic.object_id AND i.index_id = ic.index_id
+		JOIN %[1]s.sys.columns c ON ic.object_id = c.object_id AND ic.column_id = c.column_id
+		WHERE i.type > 0 AND ic.is_included_column = 0 AND t.is_ms_shipped = 0
+		ORDER BY s.name, t.name, i.name, ic.key_ordinal`, quoteIdentifier(database))
+	rows, err := queryer.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var indexes []*tableIndex
+	indexMap := make(map[string]*tableIndex)
+	for rows.Next() {
+		var index tableIndex
+		var columnName string
+		var descending bool
+		if err := rows.Scan(
+			&index.schemaName,
+			&index.tableName,
+			&index.name,
+			&index.typeDesc,
+			&index.unique,
+			&index.primaryKey,
+			&index.uniqueConstraint,
+			&index.disabled,
+			&columnName,
+			&descending,
+		); err != nil {
+			return nil, err
+		}
+		expression := quoteIdentifier(columnName)
+		if descending {
+			expression = fmt.Sprintf("%s DESC", expression)
+		}
+
+		key := fmt.Sprintf("%s.%s.%s", index.schemaName, index.tableName, index.name)
+		if existing, ok := indexMap[key]; ok {
+			existing.columnList = append(existing.columnList, expression)
+			continue
+		}
+		index.columnList = []string{expression}
+		indexMap[key] = &index
+		indexes = append(indexes, &index)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return indexes, nil
+}
+
+// formatColumnType formats the column type with its length, precision and scale, e.g. NVARCHAR(50), DECIMAL(10, 2).
+func formatColumnType(typeName string, maxLength, precision, scale int) string {
+	switch strings.ToLower(typeName) {
+	case "char", "varchar", "binary", "varbinary":
+		if maxLength == -1 {
+			return fmt.Sprintf("%s(MAX)", typeName)
+		}
+		return fmt.Sprintf("%s(%d)", typeName, maxLength)
+	case "nchar", "nvarchar":
+		// The max_length is in bytes and each character takes two bytes.
+		if maxLength == -1 {
+			return fmt.Sprintf("%s(MAX)", typeName)
+		}
+		return fmt.Sprintf("%s(%d)", typeName, maxLength/2)
+	case "decimal", "numeric":
+		return fmt.Sprintf("%s(%d, %d)", typeName, precision, scale)
+	case "datetime2", "datetimeoffset", "time":
+		return fmt.Sprintf("%s(%d)", typeName, scale)
+	}
+	return typeName
+}
+
+// queryer is implemented by both *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
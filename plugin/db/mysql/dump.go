@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/youzi-1122/bytebase/api"
 	"github.com/youzi-1122/bytebase/common"
 	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
 	"github.com/youzi-1122/bytebase/plugin/db/util"
 	"go.uber.org/zap"
 )
@@ -39,6 +41,11 @@ const (
 		"-- View structure for `%s`\n" +
 		"--\n" +
 		"%s;\n"
+	sequenceStmtFmt = "DROP SEQUENCE IF EXISTS `%s`;\n" +
+		"--\n" +
+		"-- Sequence structure for `%s`\n" +
+		"--\n" +
+		"%s;\n"
 	routineStmtFmt = "" +
 		"--\n" +
 		"-- %s structure for `%s`\n" +
@@ -86,8 +93,8 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 	// Please refer to https://github.com/youzi-1122/bytebase/blob/main/docs/design/pitr-mysql.md#full-backup for details.
 
 	options := sql.TxOptions{}
-	// TiDB does not support readonly, so we only set for MySQL.
-	if driver.dbType == "MYSQL" {
+	// TiDB and OceanBase do not support readonly, so we only set for MySQL and MariaDB.
+	if driver.dbType == db.MySQL || driver.dbType == db.MariaDB {
 		options.ReadOnly = true
 	}
 	// If `schemaOnly` is false, now we are still holding the tables' exclusive locks.
@@ -100,7 +107,7 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 	defer txn.Rollback()
 
 	log.Debug("begin to dump database", zap.String("database", database))
-	if err := dumpTxn(ctx, txn, database, out, schemaOnly); err != nil {
+	if err := dumpTxn(ctx, txn, driver.dbType, database, out, schemaOnly); err != nil {
 		return "", err
 	}
 
@@ -131,7 +138,7 @@ func FlushTablesWithReadLock(ctx context.Context, conn *sql.Conn, database strin
 
 	var tableNames []string
 	for _, table := range tables {
-		if table.TableType != baseTableType {
+		if !isBaseTable(table.TableType) {
 			continue
 		}
 		tableNames = append(tableNames, fmt.Sprintf("`%s`", table.Name))
@@ -149,7 +156,7 @@ func FlushTablesWithReadLock(ctx context.Context, conn *sql.Conn, database strin
 	return nil
 }
 
-func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, schemaOnly bool) error {
+func dumpTxn(ctx context.Context, txn *sql.Tx, dbType db.Type, database string, out io.Writer, schemaOnly bool) error {
 	log.Debug("begin to dump database", zap.String("database", database))
 	// Find all dumpable databases
 	dbNames, err := getDatabases(ctx, txn)
@@ -206,14 +213,18 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, s
 		if err != nil {
 			return fmt.Errorf("failed to get tables of database %q, error: %w", dbName, err)
 		}
+		// MariaDB sequences come first, because the tables could use them in the column defaults.
+		sort.SliceStable(tables, func(i, j int) bool {
+			return tables[i].TableType == sequenceTableType && tables[j].TableType != sequenceTableType
+		})
 		for _, tbl := range tables {
-			if schemaOnly && tbl.TableType == baseTableType {
+			if schemaOnly && isBaseTable(tbl.TableType) {
 				tbl.Statement = excludeSchemaAutoIncrementValue(tbl.Statement)
 			}
 			if _, err := io.WriteString(out, fmt.Sprintf("%s\n", tbl.Statement)); err != nil {
 				return err
 			}
-			if !schemaOnly && tbl.TableType == baseTableType {
+			if !schemaOnly && isBaseTable(tbl.TableType) {
 				// Include db prefix if dumping multiple databases.
 				includeDbPrefix := len(dumpableDbNames) > 1
 				if err := exportTableData(txn, dbName, tbl.Name, includeDbPrefix, out); err != nil {
@@ -234,13 +245,16 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, s
 		}
 
 		// Event statements.
-		events, err := getEvents(txn, dbName)
-		if err != nil {
-			return fmt.Errorf("failed to get events of database %q: %s", dbName, err)
-		}
-		for _, et := range events {
-			if _, err := io.WriteString(out, fmt.Sprintf("%s\n", et.statement)); err != nil {
-				return err
+		// OceanBase doesn't support events.
+		if dbType != db.OceanBase {
+			events, err := getEvents(txn, dbName)
+			if err != nil {
+				return fmt.Errorf("failed to get events of database %q: %s", dbName, err)
+			}
+			for _, et := range events {
+				if _, err := io.WriteString(out, fmt.Sprintf("%s\n", et.statement)); err != nil {
+					return err
+				}
 			}
 		}
 
@@ -371,7 +385,8 @@ func getTablesImpl(txn *sql.Tx, dbName string) ([]*TableSchema, error) {
 // getTableStmt gets the create statement of a table.
 func getTableStmt(txn *sql.Tx, dbName, tblName, tblType string) (string, error) {
 	switch tblType {
-	case baseTableType:
+	case baseTableType, systemVersionedTableType:
+		// The create statement of the system-versioned table includes WITH SYSTEM VERSIONING.
 		query := fmt.Sprintf("SHOW CREATE TABLE `%s`.`%s`;", dbName, tblName)
 		var stmt, unused string
 		if err := txn.QueryRow(query).Scan(&unused, &stmt); err != nil {
@@ -380,22 +395,36 @@ func getTableStmt(txn *sql.Tx, dbName, tblName, tblType string) (string, error)
 			}
 			return "", err
 		}
-		return fmt.Sprintf(tableStmtFmt,tblName, tblName, stmt), nil
+		return fmt.Sprintf(tableStmtFmt, tblName, tblName, stmt), nil
 	case viewTableType:
 		// This differs from mysqldump as it includes.
-		//query := fmt.Sprintf("SHOW CREATE VIEW `%s`.`%s`;", dbName, tblName)
-		//var createStmt, unused string
-		//if err := txn.QueryRow(query).Scan(&unused, &createStmt, &unused, &unused); err != nil {
-		//	if err == sql.ErrNoRows {
-		//		return "", common.FormatDBErrorEmptyRowWithQuery(query)
-		//	}
-		//	return "", err
-		//}
-		//return fmt.Sprintf(viewStmtFmt, tblName,tblName, createStmt), nil
+		query := fmt.Sprintf("SHOW CREATE VIEW `%s`.`%s`;", dbName, tblName)
+		var createStmt, unused string
+		if err := txn.QueryRow(query).Scan(&unused, &createStmt, &unused, &unused); err != nil {
+			if err == sql.ErrNoRows {
+				return "", common.FormatDBErrorEmptyRowWithQuery(query)
+			}
+			return "", err
+		}
+		return fmt.Sprintf(viewStmtFmt, tblName, tblName, createStmt), nil
+	case sequenceTableType:
+		query := fmt.Sprintf("SHOW CREATE SEQUENCE `%s`.`%s`;", dbName, tblName)
+		var stmt, unused string
+		if err := txn.QueryRow(query).Scan(&unused, &stmt); err != nil {
+			if err == sql.ErrNoRows {
+				return "", common.FormatDBErrorEmptyRowWithQuery(query)
+			}
+			return "", err
+		}
+		return fmt.Sprintf(sequenceStmtFmt, tblName, tblName, stmt), nil
 	default:
 		return "", fmt.Errorf("unrecognized table type %q for database %q table %q", tblType, dbName, tblName)
 	}
+}
 
+// isBaseTable returns whether the table type stores the data, which includes the MariaDB system-versioned table.
+func isBaseTable(tblType string) bool {
+	return tblType == baseTableType || tblType == systemVersionedTableType
 }
 
 // exportTableData gets the data of a table.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/youzi-1122/bytebase/common"
@@ -18,6 +19,12 @@ import (
 var (
 	baseTableType = "BASE TABLE"
 	viewTableType = "VIEW"
+	// MariaDB only.
+	sequenceTableType        = "SEQUENCE"
+	systemVersionedTableType = "SYSTEM VERSIONED"
+
+	// The version comment is like "OceanBase 3.2.3.0 (r20220418...) (Built ...)" or "OceanBase_CE 4.0.0.0 (r...) (Built ...)".
+	oceanBaseVersionRegex = regexp.MustCompile(`^OceanBase(?:_CE)?\s+v?(\d+(?:\.\d+)*)`)
 
 	_ db.Driver = (*Driver)(nil)
 )
@@ -25,6 +32,8 @@ var (
 func init() {
 	db.Register(db.MySQL, newDriver)
 	db.Register(db.TiDB, newDriver)
+	db.Register(db.MariaDB, newDriver)
+	db.Register(db.OceanBase, newDriver)
 }
 
 // Driver is the MySQL driver.
@@ -53,8 +62,11 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, connCfg db.Conne
 	port := connCfg.Port
 	if port == "" {
 		port = "3306"
-		if dbType == db.TiDB {
+		switch dbType {
+		case db.TiDB:
 			port = "4000"
+		case db.OceanBase:
+			port = "2881"
 		}
 	}
 
@@ -136,6 +148,10 @@ func getDatabases(ctx context.Context, txn *sql.Tx) ([]string, error) {
 // GetVersion gets the version.
 func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
 	query := "SELECT VERSION()"
+	// OceanBase reports the compatible MySQL version in VERSION(), and the OceanBase version is in the version comment.
+	if driver.dbType == db.OceanBase {
+		query = "SELECT @@version_comment"
+	}
 	var version string
 	if err := driver.db.QueryRowContext(ctx, query).Scan(&version); err != nil {
 		if err == sql.ErrNoRows {
@@ -143,7 +159,26 @@ func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
 		}
 		return "", util.FormatErrorWithQuery(err, query)
 	}
-	return version, nil
+	return parseVersion(driver.dbType, version), nil
+}
+
+// parseVersion parses the engine version from the version string of the MySQL compatible engines.
+// The version string is returned as is if it's not recognized.
+func parseVersion(dbType db.Type, version string) string {
+	switch dbType {
+	case db.MariaDB:
+		// MariaDB version is like "10.6.8-MariaDB-1:10.6.8+maria~focal", and the replication
+		// version hack prefixes "5.5.5-" for the MariaDB versions before 11.0.
+		version = strings.TrimPrefix(version, "5.5.5-")
+		if i := strings.Index(version, "-MariaDB"); i >= 0 {
+			return version[:i]
+		}
+	case db.OceanBase:
+		if matches := oceanBaseVersionRegex.FindStringSubmatch(version); len(matches) == 2 {
+			return matches[1]
+		}
+	}
+	return version
 }
 
 // Execute executes a SQL statement.
@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/youzi-1122/bytebase/plugin/db"
+)
+
+func TestParseVersion(t *testing.T) {
+	a := require.New(t)
+	tests := []struct {
+		dbType   db.Type
+		version  string
+		expected string
+	}{
+		{
+			dbType:   db.MySQL,
+			version:  "8.0.28",
+			expected: "8.0.28",
+		},
+		{
+			dbType:   db.TiDB,
+			version:  "5.7.25-TiDB-v5.4.0",
+			expected: "5.7.25-TiDB-v5.4.0",
+		},
+		{
+			dbType:   db.MariaDB,
+			version:  "10.6.8-MariaDB-1:10.6.8+maria~focal",
+			expected: "10.6.8",
+		},
+		{
+			dbType:   db.MariaDB,
+			version:  "5.5.5-10.3.34-MariaDB",
+			expected: "10.3.34",
+		},
+		{
+			dbType:   db.MariaDB,
+			version:  "10.5.16",
+			expected: "10.5.16",
+		},
+		{
+			dbType:   db.OceanBase,
+			version:  "OceanBase 3.2.3.0 (r20220418120000-0123456789abcdef) (Built Apr 18 2022 12:00:00)",
+			expected: "3.2.3.0",
+		},
+		{
+			dbType:   db.OceanBase,
+			version:  "OceanBase_CE 4.0.0.0 (r100000272022110114-6af7f9ae79cd0ecbafd4b1b88e2886ccdba0c3be) (Built Nov  1 2022 14:57:18)",
+			expected: "4.0.0.0",
+		},
+		{
+			dbType:   db.OceanBase,
+			version:  "unknown",
+			expected: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		a.Equal(test.expected, parseVersion(test.dbType, test.version))
+	}
+}
@@ -14,8 +14,10 @@ var (
 	systemDatabases = map[string]bool{
 		"information_schema": true,
 		// TiDB only
-		"metrics_schema":     true,
-		"mysql":              true,
+		"metrics_schema": true,
+		"mysql":          true,
+		// OceanBase only
+		"oceanbase":          true,
 		"performance_schema": true,
 		"sys":                true,
 	}
@@ -82,7 +84,8 @@ func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([
 	if err != nil {
 		return nil, err
 	}
-	isMySQL8 := strings.HasPrefix(version, "8.0")
+	// MariaDB and OceanBase don't have the EXPRESSION and IS_VISIBLE columns in information_schema.STATISTICS.
+	isMySQL8 := (driver.dbType == db.MySQL || driver.dbType == db.TiDB) && strings.HasPrefix(version, "8.0")
 
 	excludedDatabaseList := []string{
 		// Skip our internal "bytebase" database
@@ -194,7 +197,8 @@ func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([
 				COLUMN_TYPE,
 				IFNULL(CHARACTER_SET_NAME, ''),
 				IFNULL(COLLATION_NAME, ''),
-				COLUMN_COMMENT
+				COLUMN_COMMENT,
+				EXTRA
 			FROM information_schema.COLUMNS
 			WHERE ` + columnWhere
 	columnRows, err := driver.db.QueryContext(ctx, columnQuery)
@@ -222,6 +226,7 @@ func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([
 			&column.CharacterSet,
 			&column.Collation,
 			&column.Comment,
+			&column.Extra,
 		); err != nil {
 			return nil, err
 		}
@@ -302,7 +307,8 @@ func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([
 		}
 
 		switch table.Type {
-		case baseTableType:
+		// MariaDB reports the sequences and the system-versioned tables with their own table types.
+		case baseTableType, systemVersionedTableType, sequenceTableType:
 			if tableCollation.Valid {
 				table.Collation = tableCollation.String
 			}
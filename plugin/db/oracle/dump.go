@@ -0,0 +1,196 @@
+package oracle
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+)
+
+// Dump and restore
+const (
+	databaseHeaderFmt = "" +
+		"--\n" +
+		"-- Oracle schema structure for %s\n" +
+		"--\n"
+
+	// setTransformParamStmt makes DBMS_METADATA generate stable DDLs without the storage clauses and schema prefix,
+	// and terminates SQL statements with a semicolon and PL/SQL blocks with a slash.
+	setTransformParamStmt = `
+BEGIN
+	DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'PRETTY', TRUE);
+	DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'SQLTERMINATOR', TRUE);
+	DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'SEGMENT_ATTRIBUTES', FALSE);
+	DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'STORAGE', FALSE);
+	DBMS_METADATA.SET_TRANSFORM_PARAM(DBMS_METADATA.SESSION_TRANSFORM, 'EMIT_SCHEMA', FALSE);
+END;`
+)
+
+// dumpObjectQuery lists the dumpable objects of a schema in dependency order.
+// Indexes backing constraints are created together with their tables, so they are excluded.
+const dumpObjectQuery = `
+	SELECT
+		o.OBJECT_TYPE,
+		o.OBJECT_NAME
+	FROM ALL_OBJECTS o
+	WHERE o.OWNER = :1
+		AND o.GENERATED = 'N'
+		AND o.OBJECT_NAME NOT LIKE 'BIN$%'
+		AND o.OBJECT_TYPE IN ('SEQUENCE', 'TABLE', 'INDEX', 'VIEW', 'MATERIALIZED VIEW', 'TYPE', 'TYPE BODY', 'FUNCTION', 'PROCEDURE', 'PACKAGE', 'PACKAGE BODY', 'TRIGGER', 'SYNONYM')
+		AND NOT EXISTS (
+			SELECT 1 FROM ALL_CONSTRAINTS c
+			WHERE c.OWNER = o.OWNER AND c.INDEX_NAME = o.OBJECT_NAME AND o.OBJECT_TYPE = 'INDEX'
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM ALL_INDEXES i
+			WHERE i.OWNER = o.OWNER AND i.INDEX_NAME = o.OBJECT_NAME AND o.OBJECT_TYPE = 'INDEX' AND i.INDEX_TYPE = 'LOB'
+		)
+		AND NOT EXISTS (
+			SELECT 1 FROM ALL_TABLES t
+			WHERE t.OWNER = o.OWNER AND t.TABLE_NAME = o.OBJECT_NAME AND o.OBJECT_TYPE = 'TABLE' AND (t.NESTED = 'YES' OR t.SECONDARY = 'Y' OR t.IOT_TYPE = 'IOT_OVERFLOW')
+		)
+	ORDER BY
+		CASE o.OBJECT_TYPE
+			WHEN 'SEQUENCE' THEN 1
+			WHEN 'TYPE' THEN 2
+			WHEN 'TABLE' THEN 3
+			WHEN 'INDEX' THEN 4
+			WHEN 'FUNCTION' THEN 5
+			WHEN 'PROCEDURE' THEN 6
+			WHEN 'PACKAGE' THEN 7
+			WHEN 'VIEW' THEN 8
+			WHEN 'MATERIALIZED VIEW' THEN 9
+			WHEN 'SYNONYM' THEN 10
+			WHEN 'TYPE BODY' THEN 11
+			WHEN 'PACKAGE BODY' THEN 12
+			WHEN 'TRIGGER' THEN 13
+		END,
+		o.CREATED,
+		o.OBJECT_NAME`
+
+// Dump dumps the schema of the database. Data dump isn't supported for Oracle, and schemaOnly is always true.
+func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
+	// DBMS_METADATA transform parameters are set per session, so we use a dedicated connection.
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, setTransformParamStmt); err != nil {
+		return "", util.FormatErrorWithQuery(err, setTransformParamStmt)
+	}
+
+	var dumpableDbNames []string
+	if database != "" {
+		dumpableDbNames = []string{strings.ToUpper(database)}
+	} else {
+		dumpableDbNames, err = driver.getDatabases(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get databases: %s", err)
+		}
+	}
+
+	for _, dbName := range dumpableDbNames {
+		// The CURRENT_SCHEMA statement is only included when dumping all databases.
+		includeUseSchemaStmt := database == ""
+		if err := dumpOneDatabase(ctx, conn, dbName, out, includeUseSchemaStmt); err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// dumpObject is the dumpable object in a schema.
+type dumpObject struct {
+	objectType string
+	name       string
+}
+
+// dumpOneDatabase dumps the DDLs of the objects in a schema.
+func dumpOneDatabase(ctx context.Context, conn *sql.Conn, database string, out io.Writer, includeUseSchemaStmt bool) error {
+	if includeUseSchemaStmt {
+		header := fmt.Sprintf(databaseHeaderFmt, database)
+		if _, err := io.WriteString(out, fmt.Sprintf("%s%s;\n\n", header, setCurrentSchemaStmt(database))); err != nil {
+			return err
+		}
+	}
+
+	rows, err := conn.QueryContext(ctx, dumpObjectQuery, database)
+	if err != nil {
+		return util.FormatErrorWithQuery(err, dumpObjectQuery)
+	}
+	defer rows.Close()
+
+	var objects []dumpObject
+	for rows.Next() {
+		var object dumpObject
+		if err := rows.Scan(
+			&object.objectType,
+			&object.name,
+		); err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+	if err := rows.Err(); err != nil {
+		return util.FormatErrorWithQuery(err, dumpObjectQuery)
+	}
+
+	for _, object := range objects {
+		ddl, err := getDDL(ctx, conn, database, object)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(out, fmt.Sprintf("%s\n\n", strings.TrimSpace(ddl))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func getDDL(ctx context.Context, conn *sql.Conn, database string, object dumpObject) (string, error) {
+	// DBMS_METADATA uses underscore in the object type, e.g. PACKAGE_BODY.
+	objectType := strings.ReplaceAll(object.objectType, " ", "_")
+	query := "SELECT DBMS_METADATA.GET_DDL(:1, :2, :3) FROM DUAL"
+	var ddl sql.NullString
+	if err := conn.QueryRowContext(ctx, query, objectType, object.name, database).Scan(&ddl); err != nil {
+		return "", fmt.Errorf("failed to get DDL of %s %q, error: %w", object.objectType, object.name, err)
+	}
+	return ddl.String, nil
+}
+
+// Restore restores a database.
+func (driver *Driver) Restore(ctx context.Context, sc *bufio.Scanner) (err error) {
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return driver.Execute(ctx, strings.Join(lines, "\n"))
+}
+
+// RestoreTx restores the database in the given transaction.
+func (driver *Driver) RestoreTx(ctx context.Context, tx *sql.Tx, sc *bufio.Scanner) error {
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	for _, stmt := range splitStatements(strings.Join(lines, "\n")) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return util.FormatErrorWithQuery(err, stmt)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	// embed will embeds the migration schema.
+	_ "embed"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+	"go.uber.org/zap"
+)
+
+var (
+	//go:embed oracle_migration_schema.sql
+	migrationSchema string
+
+	_ util.MigrationExecutor = (*Driver)(nil)
+)
+
+// NeedsSetupMigration returns whether it needs to setup migration.
+func (driver *Driver) NeedsSetupMigration(ctx context.Context) (bool, error) {
+	const query = `
+		SELECT
+		    1
+		FROM ALL_TABLES
+		WHERE OWNER = 'BYTEBASE' AND TABLE_NAME = 'MIGRATION_HISTORY'
+	`
+	return util.NeedsSetupMigrationSchema(ctx, driver.db, query)
+}
+
+// SetupMigrationIfNeeded sets up migration if needed.
+func (driver *Driver) SetupMigrationIfNeeded(ctx context.Context) error {
+	setup, err := driver.NeedsSetupMigration(ctx)
+	if err != nil {
+		return nil
+	}
+
+	if setup {
+		log.Info("Bytebase migration schema not found, creating schema...",
+			zap.String("environment", driver.connectionCtx.EnvironmentName),
+			zap.String("database", driver.connectionCtx.InstanceName),
+		)
+		if err := driver.Execute(ctx, migrationSchema); err != nil {
+			log.Error("Failed to initialize migration schema.",
+				zap.Error(err),
+				zap.String("environment", driver.connectionCtx.EnvironmentName),
+				zap.String("database", driver.connectionCtx.InstanceName),
+			)
+			return util.FormatErrorWithQuery(err, migrationSchema)
+		}
+		log.Info("Successfully created migration schema.",
+			zap.String("environment", driver.connectionCtx.EnvironmentName),
+			zap.String("database", driver.connectionCtx.InstanceName),
+		)
+	}
+
+	return nil
+}
+
+// FindLargestVersionSinceBaseline will find the largest version since last baseline or branch.
+func (driver Driver) FindLargestVersionSinceBaseline(ctx context.Context, tx *sql.Tx, namespace string) (*string, error) {
+	largestBaselineSequence, err := driver.FindLargestSequence(ctx, tx, namespace, true /* baseline */)
+	if err != nil {
+		return nil, err
+	}
+	const getLargestVersionSinceLastBaselineQuery = `
+		SELECT MAX(version) FROM BYTEBASE.MIGRATION_HISTORY
+		WHERE namespace = :1 AND sequence >= :2
+	`
+	var version sql.NullString
+	if err := tx.QueryRowContext(ctx, getLargestVersionSinceLastBaselineQuery,
+		namespace, largestBaselineSequence,
+	).Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(getLargestVersionSinceLastBaselineQuery)
+		}
+		return nil, util.FormatErrorWithQuery(err, getLargestVersionSinceLastBaselineQuery)
+	}
+	if version.Valid {
+		return &version.String, nil
+	}
+	return nil, nil
+}
+
+// FindLargestSequence will return the largest sequence number.
+func (Driver) FindLargestSequence(ctx context.Context, tx *sql.Tx, namespace string, baseline bool) (int, error) {
+	findLargestSequenceQuery := `
+		SELECT MAX(sequence) FROM BYTEBASE.MIGRATION_HISTORY
+		WHERE namespace = :1`
+	if baseline {
+		findLargestSequenceQuery = fmt.Sprintf("%s AND (type = '%s' OR type = '%s')", findLargestSequenceQuery, db.Baseline, db.Branch)
+	}
+	var sequence sql.NullInt32
+	if err := tx.QueryRowContext(ctx, findLargestSequenceQuery,
+		namespace,
+	).Scan(&sequence); err != nil {
+		if err == sql.ErrNoRows {
+			return -1, common.FormatDBErrorEmptyRowWithQuery(findLargestSequenceQuery)
+		}
+		return -1, util.FormatErrorWithQuery(err, findLargestSequenceQuery)
+	}
+	if sequence.Valid {
+		return int(sequence.Int32), nil
+	}
+	// Returns 0 if we haven't applied any migration for this namespace.
+	return 0, nil
+}
+
+// InsertPendingHistory will insert the migration record with pending status and return the inserted ID.
+func (Driver) InsertPendingHistory(ctx context.Context, tx *sql.Tx, sequence int, prevSchema string, m *db.MigrationInfo, storedVersion, statement string) (int64, error) {
+	const insertHistoryQuery = `
+		INSERT INTO BYTEBASE.MIGRATION_HISTORY (
+			id,
+			created_by,
+			created_ts,
+			updated_by,
+			updated_ts,
+			release_version,
+			namespace,
+			sequence,
+			source,
+			type,
+			status,
+			version,
+			description,
+			statement,
+			schema,
+			schema_prev,
+			execution_duration_ns,
+			issue_id,
+			payload
+		)
+		VALUES (:1, :2, ROUND((CAST(SYS_EXTRACT_UTC(SYSTIMESTAMP) AS DATE) - DATE '1970-01-01') * 86400), :3, ROUND((CAST(SYS_EXTRACT_UTC(SYSTIMESTAMP) AS DATE) - DATE '1970-01-01') * 86400), :4, :5, :6, :7, :8, :9, :10, :11, :12, :13, :14, 0, :15, :16)
+	`
+	// We don't use the identity column because getting the generated ID requires the RETURNING INTO output parameter.
+	maxIDQuery := "SELECT NVL(MAX(id), 0) + 1 FROM BYTEBASE.MIGRATION_HISTORY"
+	var insertedID int64
+	if err := tx.QueryRowContext(ctx, maxIDQuery).Scan(&insertedID); err != nil {
+		return int64(0), util.FormatErrorWithQuery(err, maxIDQuery)
+	}
+
+	_, err := tx.ExecContext(ctx, insertHistoryQuery,
+		insertedID,
+		m.Creator,
+		m.Creator,
+		m.ReleaseVersion,
+		m.Namespace,
+		sequence,
+		m.Source,
+		m.Type,
+		db.Pending,
+		storedVersion,
+		m.Description,
+		statement,
+		prevSchema,
+		prevSchema,
+		m.IssueID,
+		m.Payload,
+	)
+	if err != nil {
+		return int64(0), util.FormatErrorWithQuery(err, insertHistoryQuery)
+	}
+	return insertedID, nil
+}
+
+// UpdateHistoryAsDone will update the migration record as done.
+func (Driver) UpdateHistoryAsDone(ctx context.Context, tx *sql.Tx, migrationDurationNs int64, updatedSchema string, insertedID int64) error {
+	const updateHistoryAsDoneQuery = `
+		UPDATE
+			BYTEBASE.MIGRATION_HISTORY
+		SET
+			status = :1,
+			execution_duration_ns = :2,
+			schema = :3
+		WHERE id = :4
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryAsDoneQuery, db.Done, migrationDurationNs, updatedSchema, insertedID)
+	return err
+}
+
+// UpdateHistoryAsFailed will update the migration record as failed.
+func (Driver) UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDurationNs int64, insertedID int64) error {
+	const updateHistoryAsFailedQuery = `
+		UPDATE
+			BYTEBASE.MIGRATION_HISTORY
+		SET
+			status = :1,
+			execution_duration_ns = :2
+		WHERE id = :3
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryAsFailedQuery, db.Failed, migrationDurationNs, insertedID)
+	return err
+}
+
+// ExecuteMigration will execute the migration.
+func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, string, error) {
+	return util.ExecuteMigration(ctx, driver, m, statement, bytebaseDatabase)
+}
+
+// FindMigrationHistoryList finds the migration history.
+func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
+	baseQuery := `
+	SELECT
+		id,
+		created_by,
+		created_ts,
+		updated_by,
+		updated_ts,
+		NVL(release_version, EMPTY_CLOB()),
+		namespace,
+		sequence,
+		source,
+		type,
+		status,
+		version,
+		NVL(description, EMPTY_CLOB()),
+		NVL(statement, EMPTY_CLOB()),
+		NVL(schema, EMPTY_CLOB()),
+		NVL(schema_prev, EMPTY_CLOB()),
+		execution_duration_ns,
+		NVL(issue_id, EMPTY_CLOB()),
+		NVL(payload, EMPTY_CLOB())
+		FROM BYTEBASE.MIGRATION_HISTORY `
+	paramNames, params := []string{}, []interface{}{}
+	if v := find.ID; v != nil {
+		paramNames, params = append(paramNames, "id"), append(params, *v)
+	}
+	if v := find.Database; v != nil {
+		paramNames, params = append(paramNames, "namespace"), append(params, *v)
+	}
+	if v := find.Version; v != nil {
+		// TODO(d): support semantic versioning.
+		storedVersion, err := util.ToStoredVersion(false, *v, "")
+		if err != nil {
+			return nil, err
+		}
+		paramNames, params = append(paramNames, "version"), append(params, storedVersion)
+	}
+	if v := find.Source; v != nil {
+		paramNames, params = append(paramNames, "source"), append(params, *v)
+	}
+	var query = baseQuery +
+		formatParamNameInColonPosition(paramNames) +
+		`ORDER BY created_ts DESC`
+	if v := find.Limit; v != nil {
+		query += fmt.Sprintf(" FETCH FIRST %d ROWS ONLY", *v)
+	}
+	return util.FindMigrationHistoryList(ctx, query, params, driver, bytebaseDatabase, find, baseQuery)
+}
+
+// formatParamNameInColonPosition formats the param names in the :1, :2 form used by Oracle.
+func formatParamNameInColonPosition(paramNames []string) string {
+	if len(paramNames) == 0 {
+		return ""
+	}
+	var parts []string
+	for i, param := range paramNames {
+		parts = append(parts, fmt.Sprintf("%s = :%d", param, i+1))
+	}
+	return fmt.Sprintf("WHERE %s ", strings.Join(parts, " AND "))
+}
@@ -0,0 +1,218 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+
+	go_ora "github.com/sijms/go-ora/v2"
+	"go.uber.org/zap"
+)
+
+var (
+	// bytebaseDatabase is the schema storing the migration history.
+	// Oracle folds unquoted identifiers to upper case.
+	bytebaseDatabase = "BYTEBASE"
+
+	// plsqlBlockStart matches the statements which are PL/SQL blocks terminated by a line with a single slash.
+	plsqlBlockStart = regexp.MustCompile(`(?is)^(DECLARE|BEGIN|CREATE\s+(OR\s+REPLACE\s+)?((NON)?EDITIONABLE\s+)?(PROCEDURE|FUNCTION|PACKAGE|TRIGGER|TYPE|LIBRARY|JAVA))\b`)
+
+	_ db.Driver = (*Driver)(nil)
+)
+
+func init() {
+	db.Register(db.Oracle, newDriver)
+}
+
+// Driver is the Oracle driver.
+type Driver struct {
+	connectionCtx db.ConnectionContext
+
+	db *sql.DB
+	// databaseName is the schema used as the current schema when executing statements.
+	// Bytebase maps an Oracle schema to a database.
+	databaseName string
+}
+
+func newDriver(config db.DriverConfig) db.Driver {
+	return &Driver{}
+}
+
+// Open opens an Oracle driver.
+// The host should be in the EZConnect style "host/service_name" since the service name is required to connect to Oracle.
+func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+	host, serviceName, err := parseHost(config.Host)
+	if err != nil {
+		return nil, err
+	}
+	port := 1521
+	if config.Port != "" {
+		port, err = strconv.Atoi(config.Port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q", config.Port)
+		}
+	}
+	dsn := go_ora.BuildUrl(host, port, serviceName, config.Username, config.Password, nil)
+	loggedDSN := go_ora.BuildUrl(host, port, serviceName, config.Username, "<<redacted password>>", nil)
+	log.Debug("Opening Oracle driver",
+		zap.String("dsn", loggedDSN),
+		zap.String("environment", connCtx.EnvironmentName),
+		zap.String("database", connCtx.InstanceName),
+	)
+	db, err := sql.Open("oracle", dsn)
+	if err != nil {
+		return nil, err
+	}
+	driver.db = db
+	driver.connectionCtx = connCtx
+	driver.databaseName = strings.ToUpper(config.Database)
+
+	return driver, nil
+}
+
+// parseHost parses the host in the "host/service_name" form.
+func parseHost(hostAndService string) (string, string, error) {
+	parts := strings.Split(hostAndService, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("Oracle host %q must be in the form of host/service_name", hostAndService)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Close closes the driver.
+func (driver *Driver) Close(ctx context.Context) error {
+	return driver.db.Close()
+}
+
+// Ping pings the database.
+func (driver *Driver) Ping(ctx context.Context) error {
+	return driver.db.PingContext(ctx)
+}
+
+// GetDbConnection gets a database connection.
+// The schema is switched per session when executing statements, so the connection pool is shared among databases.
+// The migration history is always accessed with the qualified table name, so getting the connection for the bytebase schema doesn't switch the schema.
+func (driver *Driver) GetDbConnection(ctx context.Context, database string) (*sql.DB, error) {
+	if database != "" && strings.ToUpper(database) != bytebaseDatabase {
+		driver.databaseName = strings.ToUpper(database)
+	}
+	return driver.db, nil
+}
+
+// GetVersion gets the version.
+func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
+	query := "SELECT VERSION FROM PRODUCT_COMPONENT_VERSION WHERE PRODUCT LIKE 'Oracle%' AND ROWNUM = 1"
+	var version string
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		if err == sql.ErrNoRows {
+			return "", common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+	return version, nil
+}
+
+// Execute executes a SQL statement.
+// All statements run in a single session so that `ALTER SESSION SET CURRENT_SCHEMA` takes effect for the following statements.
+// Note that Oracle commits DDL implicitly.
+func (driver *Driver) Execute(ctx context.Context, statement string) error {
+	stmts := splitStatements(statement)
+	if len(stmts) == 0 {
+		return nil
+	}
+
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if driver.databaseName != "" {
+		if _, err := conn.ExecContext(ctx, setCurrentSchemaStmt(driver.databaseName)); err != nil {
+			return err
+		}
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return util.FormatErrorWithQuery(err, stmt)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Query queries a SQL statement.
+func (driver *Driver) Query(ctx context.Context, statement string, limit int) ([]interface{}, error) {
+	// Oracle doesn't accept the trailing semicolon of a SQL statement.
+	statement = strings.TrimRight(strings.TrimSpace(statement), ";")
+	// Oracle driver doesn't support ReadOnly transaction, so we use tx rollback semantics to enforce readonly.
+	tx, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	return util.QueryTx(ctx, tx, statement, limit)
+}
+
+func setCurrentSchemaStmt(schema string) string {
+	return fmt.Sprintf(`ALTER SESSION SET CURRENT_SCHEMA = "%s"`, schema)
+}
+
+// splitStatements splits the script into statements.
+// SQL statements end with a semicolon, which is removed because Oracle doesn't accept it.
+// PL/SQL blocks such as anonymous blocks, procedures and packages end with a line with a single slash, and keep their own semicolons.
+func splitStatements(statement string) []string {
+	var stmts []string
+	var lines []string
+	isPLSQL := false
+	appendStmt := func(stmt string) {
+		stmt = strings.TrimSpace(stmt)
+		if stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		lines = nil
+		isPLSQL = false
+	}
+
+	for _, line := range strings.Split(statement, "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if len(lines) == 0 {
+			// Skip the blank lines and comments between statements.
+			if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+				continue
+			}
+			isPLSQL = plsqlBlockStart.MatchString(trimmed)
+		}
+		if trimmed == "/" {
+			appendStmt(strings.Join(lines, "\n"))
+			continue
+		}
+		lines = append(lines, line)
+		if !isPLSQL && !strings.HasPrefix(trimmed, "--") && strings.HasSuffix(trimmed, ";") {
+			appendStmt(strings.TrimSuffix(strings.TrimSpace(strings.Join(lines, "\n")), ";"))
+		}
+	}
+	if isPLSQL {
+		appendStmt(strings.Join(lines, "\n"))
+	} else {
+		appendStmt(strings.TrimSuffix(strings.TrimSpace(strings.Join(lines, "\n")), ";"))
+	}
+	return stmts
+}
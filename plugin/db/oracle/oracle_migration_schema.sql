@@ -0,0 +1,53 @@
+-- This is the bytebase schema to track migration info for Oracle
+-- Create a schema called BYTEBASE, which can't be logged in. NO AUTHENTICATION requires Oracle 18c or later.
+CREATE USER BYTEBASE NO AUTHENTICATION;
+
+GRANT UNLIMITED TABLESPACE TO BYTEBASE;
+
+-- Create migration_history table
+-- Oracle treats the empty string as NULL, so the columns which could be empty are nullable CLOBs and read with NVL(column, EMPTY_CLOB()).
+CREATE TABLE BYTEBASE.MIGRATION_HISTORY (
+    id NUMBER(19) PRIMARY KEY,
+    created_by VARCHAR2(255) NOT NULL,
+    created_ts NUMBER(19) NOT NULL,
+    updated_by VARCHAR2(255) NOT NULL,
+    updated_ts NUMBER(19) NOT NULL,
+    -- Record the client version creating this migration history. For Bytebase, we use its binary release version. Different Bytebase release might
+    -- record different history info and this field helps to handle such situation properly. Moreover, it helps debugging.
+    release_version CLOB,
+    -- Allows granular tracking of migration history (e.g If an application manages schemas for a multi-tenant service and each tenant has its own schema, that application can use namespace to record the tenant name to track the per-tenant schema migration)
+    -- Since bytebase also manages different application databases from an instance, it leverages this field to track each database migration history.
+    namespace VARCHAR2(255) NOT NULL,
+    -- Used to detect out of order migration together with 'namespace' and 'version' column.
+    sequence NUMBER(19) NOT NULL CHECK (sequence >= 0),
+    -- We call it source because maybe we could load history from other migration tool.
+    -- Current allowed values are UI, VCS, LIBRARY.
+    source VARCHAR2(255) NOT NULL,
+    -- Current allowed values are BASELINE, MIGRATE, BRANCH, DATA.
+    type VARCHAR2(255) NOT NULL,
+    -- Current allowed values are PENDING, DONE, FAILED.
+    -- Oracle commits DDL implicitly, so we can't record DDL and migration_history into a single transaction.
+    -- Thus, we create a "PENDING" record before applying the DDL and update that record to "DONE" after applying the DDL.
+    status VARCHAR2(255) NOT NULL,
+    -- Record the migration version.
+    version VARCHAR2(255) NOT NULL,
+    description CLOB,
+    -- Record the migration statement
+    statement CLOB,
+    -- Record the schema after migration
+    schema CLOB,
+    -- Record the schema before migration. Though we could also fetch it from the previous migration history, it would complicate fetching logic.
+    -- Besides, by storing the schema_prev, we can perform consistency check to see if the migration history has any gaps.
+    schema_prev CLOB,
+    execution_duration_ns NUMBER(19) NOT NULL,
+    issue_id CLOB,
+    payload CLOB
+);
+
+CREATE UNIQUE INDEX BYTEBASE.BB_IDX_UNIQ_MH_NS_SEQ ON BYTEBASE.MIGRATION_HISTORY (namespace, sequence);
+
+CREATE UNIQUE INDEX BYTEBASE.BB_IDX_UNIQ_MH_NS_VER ON BYTEBASE.MIGRATION_HISTORY (namespace, version);
+
+CREATE INDEX BYTEBASE.BB_IDX_MH_NS_SOURCE_TYPE ON BYTEBASE.MIGRATION_HISTORY (namespace, source, type);
+
+CREATE INDEX BYTEBASE.BB_IDX_MH_NS_CREATED ON BYTEBASE.MIGRATION_HISTORY (namespace, created_ts);
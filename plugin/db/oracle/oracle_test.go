@@ -0,0 +1,103 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      []string
+	}{
+		{
+			statement: "CREATE TABLE t (id NUMBER);",
+			want:      []string{"CREATE TABLE t (id NUMBER)"},
+		},
+		{
+			statement: "-- comment\nCREATE TABLE t (\n  -- id;\n  id NUMBER\n);\n\nINSERT INTO t VALUES (1);\nINSERT INTO t VALUES (2)",
+			want: []string{
+				"CREATE TABLE t (\n  -- id;\n  id NUMBER\n)",
+				"INSERT INTO t VALUES (1)",
+				"INSERT INTO t VALUES (2)",
+			},
+		},
+		{
+			statement: "CREATE OR REPLACE PROCEDURE p AS\nBEGIN\n  INSERT INTO t VALUES (1);\n  COMMIT;\nEND;\n/\nALTER SESSION SET CURRENT_SCHEMA = \"HR\";\nBEGIN\n  NULL;\nEND;\n/\n",
+			want: []string{
+				"CREATE OR REPLACE PROCEDURE p AS\nBEGIN\n  INSERT INTO t VALUES (1);\n  COMMIT;\nEND;",
+				`ALTER SESSION SET CURRENT_SCHEMA = "HR"`,
+				"BEGIN\n  NULL;\nEND;",
+			},
+		},
+		{
+			statement: "  CREATE OR REPLACE EDITIONABLE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  :new.id := 1;\nEND;\n/\nALTER TRIGGER tr ENABLE;",
+			want: []string{
+				"CREATE OR REPLACE EDITIONABLE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW\nBEGIN\n  :new.id := 1;\nEND;",
+				"ALTER TRIGGER tr ENABLE",
+			},
+		},
+		{
+			// The PL/SQL block without the trailing slash.
+			statement: "DECLARE\n  n NUMBER;\nBEGIN\n  n := 1;\nEND;",
+			want:      []string{"DECLARE\n  n NUMBER;\nBEGIN\n  n := 1;\nEND;"},
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, splitStatements(test.statement))
+	}
+}
+
+func TestParseHost(t *testing.T) {
+	tests := []struct {
+		host        string
+		wantHost    string
+		wantService string
+		wantErr     bool
+	}{
+		{"localhost/ORCLPDB1", "localhost", "ORCLPDB1", false},
+		{"10.0.0.1/xe.example.com", "10.0.0.1", "xe.example.com", false},
+		{"localhost", "", "", true},
+		{"localhost/", "", "", true},
+	}
+
+	for _, test := range tests {
+		host, service, err := parseHost(test.host)
+		if test.wantErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.wantHost, host)
+		require.Equal(t, test.wantService, service)
+	}
+}
+
+func TestFormatColumnType(t *testing.T) {
+	tests := []struct {
+		typeName   string
+		dataLength int
+		precision  int
+		scale      int
+		charLength int
+		charUsed   string
+		want       string
+	}{
+		{"VARCHAR2", 80, -1, -1, 20, "C", "VARCHAR2(20 CHAR)"},
+		{"VARCHAR2", 20, -1, -1, 20, "B", "VARCHAR2(20 BYTE)"},
+		{"NVARCHAR2", 40, -1, -1, 20, "C", "NVARCHAR2(20)"},
+		{"NUMBER", 22, -1, -1, 0, " ", "NUMBER"},
+		{"NUMBER", 22, -1, 0, 0, " ", "NUMBER(*,0)"},
+		{"NUMBER", 22, 10, 0, 0, " ", "NUMBER(10)"},
+		{"NUMBER", 22, 10, 2, 0, " ", "NUMBER(10,2)"},
+		{"RAW", 16, -1, -1, 0, " ", "RAW(16)"},
+		{"DATE", 7, -1, -1, 0, " ", "DATE"},
+		{"TIMESTAMP(6)", 11, -1, 6, 0, " ", "TIMESTAMP(6)"},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, formatColumnType(test.typeName, test.dataLength, test.precision, test.scale, test.charLength, test.charUsed))
+	}
+}
@@ -0,0 +1,434 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+)
+
+// SyncInstance syncs the instance.
+func (driver *Driver) SyncInstance(ctx context.Context) (*db.InstanceMeta, error) {
+	userList, err := driver.getUserList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	characterSet, err := driver.getCharacterSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var databaseList []db.DatabaseMeta
+	for _, database := range databases {
+		databaseList = append(
+			databaseList,
+			db.DatabaseMeta{
+				Name:         database,
+				CharacterSet: characterSet,
+			},
+		)
+	}
+
+	return &db.InstanceMeta{
+		UserList:     userList,
+		DatabaseList: databaseList,
+	}, nil
+}
+
+// SyncSchema synces the schema.
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.Schema, error) {
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+	characterSet, err := driver.getCharacterSet(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaList []*db.Schema
+	for _, database := range databases {
+		if len(databaseList) != 0 {
+			exists := false
+			for _, k := range databaseList {
+				if database == strings.ToUpper(k) {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				continue
+			}
+		}
+
+		schema := db.Schema{
+			Name:         database,
+			CharacterSet: characterSet,
+		}
+		tableList, viewList, err := driver.syncTableSchema(ctx, database)
+		if err != nil {
+			return nil, err
+		}
+		schema.TableList, schema.ViewList = tableList, viewList
+
+		schemaList = append(schemaList, &schema)
+	}
+
+	return schemaList, nil
+}
+
+// getDatabases gets the schemas which are not maintained by Oracle, excluding the bytebase schema.
+func (driver *Driver) getDatabases(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT USERNAME
+		FROM ALL_USERS
+		WHERE ORACLE_MAINTAINED = 'N'
+		ORDER BY USERNAME`
+	rows, err := driver.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var databases []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		if name == bytebaseDatabase {
+			continue
+		}
+		databases = append(databases, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return databases, nil
+}
+
+// getCharacterSet gets the database character set, which is shared by all schemas.
+func (driver *Driver) getCharacterSet(ctx context.Context) (string, error) {
+	query := "SELECT VALUE FROM NLS_DATABASE_PARAMETERS WHERE PARAMETER = 'NLS_CHARACTERSET'"
+	var characterSet string
+	if err := driver.db.QueryRowContext(ctx, query).Scan(&characterSet); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", util.FormatErrorWithQuery(err, query)
+	}
+	return characterSet, nil
+}
+
+// getUserList gets the users and their granted roles. It requires the access to the DBA views.
+func (driver *Driver) getUserList(ctx context.Context) ([]db.User, error) {
+	grantQuery := `
+		SELECT
+			GRANTEE,
+			GRANTED_ROLE
+		FROM DBA_ROLE_PRIVS
+		ORDER BY GRANTEE, GRANTED_ROLE`
+	grants := make(map[string][]string)
+
+	grantRows, err := driver.db.QueryContext(ctx, grantQuery)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, grantQuery)
+	}
+	defer grantRows.Close()
+
+	for grantRows.Next() {
+		var name, role string
+		if err := grantRows.Scan(
+			&name,
+			&role,
+		); err != nil {
+			return nil, err
+		}
+		grants[name] = append(grants[name], role)
+	}
+	if err := grantRows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, grantQuery)
+	}
+
+	userQuery := `
+		SELECT
+			USERNAME,
+			ACCOUNT_STATUS
+		FROM DBA_USERS
+		WHERE ORACLE_MAINTAINED = 'N'
+		ORDER BY USERNAME`
+	userRows, err := driver.db.QueryContext(ctx, userQuery)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, userQuery)
+	}
+	defer userRows.Close()
+
+	var userList []db.User
+	for userRows.Next() {
+		var name, status string
+		if err := userRows.Scan(
+			&name,
+			&status,
+		); err != nil {
+			return nil, err
+		}
+		if name == bytebaseDatabase {
+			continue
+		}
+
+		grant := strings.Join(grants[name], ", ")
+		if status != "OPEN" {
+			grant = fmt.Sprintf("%s (%s)", grant, status)
+		}
+		userList = append(userList, db.User{
+			Name:  name,
+			Grant: strings.TrimSpace(grant),
+		})
+	}
+	if err := userRows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, userQuery)
+	}
+	return userList, nil
+}
+
+func (driver *Driver) syncTableSchema(ctx context.Context, database string) ([]db.Table, []db.View, error) {
+	// Query column info.
+	columnQuery := `
+		SELECT
+			c.TABLE_NAME,
+			c.COLUMN_NAME,
+			c.COLUMN_ID,
+			c.DATA_DEFAULT,
+			c.NULLABLE,
+			c.DATA_TYPE,
+			NVL(c.DATA_LENGTH, 0),
+			NVL(c.DATA_PRECISION, -1),
+			NVL(c.DATA_SCALE, -1),
+			NVL(c.CHAR_LENGTH, 0),
+			NVL(c.CHAR_USED, ' '),
+			c.COLLATION,
+			cc.COMMENTS
+		FROM ALL_TAB_COLUMNS c
+		LEFT JOIN ALL_COL_COMMENTS cc ON c.OWNER = cc.OWNER AND c.TABLE_NAME = cc.TABLE_NAME AND c.COLUMN_NAME = cc.COLUMN_NAME
+		WHERE c.OWNER = :1
+		ORDER BY c.TABLE_NAME, c.COLUMN_ID`
+	columnRows, err := driver.db.QueryContext(ctx, columnQuery, database)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, columnQuery)
+	}
+	defer columnRows.Close()
+
+	// tableName -> columnList map
+	columnMap := make(map[string][]db.Column)
+	for columnRows.Next() {
+		var tableName, nullable, typeName, charUsed string
+		var defaultStr, collation, comment sql.NullString
+		var dataLength, precision, scale, charLength int
+		var column db.Column
+		if err := columnRows.Scan(
+			&tableName,
+			&column.Name,
+			&column.Position,
+			&defaultStr,
+			&nullable,
+			&typeName,
+			&dataLength,
+			&precision,
+			&scale,
+			&charLength,
+			&charUsed,
+			&collation,
+			&comment,
+		); err != nil {
+			return nil, nil, err
+		}
+		column.Nullable = nullable == "Y"
+		column.Type = formatColumnType(typeName, dataLength, precision, scale, charLength, charUsed)
+		column.Collation, column.Comment = collation.String, comment.String
+		if defaultStr.Valid {
+			defaultValue := strings.TrimSpace(defaultStr.String)
+			column.Default = &defaultValue
+		}
+
+		columnMap[tableName] = append(columnMap[tableName], column)
+	}
+	if err := columnRows.Err(); err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, columnQuery)
+	}
+
+	// Query index info.
+	indexQuery := `
+		SELECT
+			i.TABLE_NAME,
+			i.INDEX_NAME,
+			i.INDEX_TYPE,
+			i.UNIQUENESS,
+			i.VISIBILITY,
+			ic.COLUMN_NAME,
+			ic.COLUMN_POSITION,
+			ic.DESCEND
+		FROM ALL_INDEXES i
+		JOIN ALL_IND_COLUMNS ic ON i.OWNER = ic.INDEX_OWNER AND i.INDEX_NAME = ic.INDEX_NAME
+		WHERE i.OWNER = :1 AND i.INDEX_TYPE <> 'LOB'
+		ORDER BY i.TABLE_NAME, i.INDEX_NAME, ic.COLUMN_POSITION`
+	indexRows, err := driver.db.QueryContext(ctx, indexQuery, database)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, indexQuery)
+	}
+	defer indexRows.Close()
+
+	// tableName -> indexList map
+	indexMap := make(map[string][]db.Index)
+	for indexRows.Next() {
+		var tableName, uniqueness, visibility, descend string
+		var index db.Index
+		if err := indexRows.Scan(
+			&tableName,
+			&index.Name,
+			&index.Type,
+			&uniqueness,
+			&visibility,
+			&index.Expression,
+			&index.Position,
+			&descend,
+		); err != nil {
+			return nil, nil, err
+		}
+		index.Unique = uniqueness == "UNIQUE"
+		index.Visible = visibility == "VISIBLE"
+		if descend == "DESC" {
+			index.Expression = fmt.Sprintf("%s DESC", index.Expression)
+		}
+
+		indexMap[tableName] = append(indexMap[tableName], index)
+	}
+	if err := indexRows.Err(); err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, indexQuery)
+	}
+
+	// Query table info.
+	tableQuery := `
+		SELECT
+			t.TABLE_NAME,
+			ROUND((CAST(o.CREATED AS DATE) - DATE '1970-01-01') * 86400),
+			ROUND((CAST(o.LAST_DDL_TIME AS DATE) - DATE '1970-01-01') * 86400),
+			NVL(t.NUM_ROWS, 0),
+			tc.COMMENTS
+		FROM ALL_TABLES t
+		JOIN ALL_OBJECTS o ON t.OWNER = o.OWNER AND t.TABLE_NAME = o.OBJECT_NAME AND o.OBJECT_TYPE = 'TABLE'
+		LEFT JOIN ALL_TAB_COMMENTS tc ON t.OWNER = tc.OWNER AND t.TABLE_NAME = tc.TABLE_NAME
+		WHERE t.OWNER = :1 AND t.NESTED = 'NO' AND t.SECONDARY = 'N' AND t.DROPPED = 'NO' AND t.IOT_TYPE IS NULL
+		ORDER BY t.TABLE_NAME`
+	tableRows, err := driver.db.QueryContext(ctx, tableQuery, database)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, tableQuery)
+	}
+	defer tableRows.Close()
+
+	var tables []db.Table
+	for tableRows.Next() {
+		var comment sql.NullString
+		var table db.Table
+		if err := tableRows.Scan(
+			&table.Name,
+			&table.CreatedTs,
+			&table.UpdatedTs,
+			&table.RowCount,
+			&comment,
+		); err != nil {
+			return nil, nil, err
+		}
+		table.Type = "BASE TABLE"
+		table.Comment = comment.String
+		table.ColumnList = columnMap[table.Name]
+		table.IndexList = indexMap[table.Name]
+		tables = append(tables, table)
+	}
+	if err := tableRows.Err(); err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, tableQuery)
+	}
+
+	// Query view info.
+	viewQuery := `
+		SELECT
+			v.VIEW_NAME,
+			ROUND((CAST(o.CREATED AS DATE) - DATE '1970-01-01') * 86400),
+			ROUND((CAST(o.LAST_DDL_TIME AS DATE) - DATE '1970-01-01') * 86400),
+			v.TEXT,
+			tc.COMMENTS
+		FROM ALL_VIEWS v
+		JOIN ALL_OBJECTS o ON v.OWNER = o.OWNER AND v.VIEW_NAME = o.OBJECT_NAME AND o.OBJECT_TYPE = 'VIEW'
+		LEFT JOIN ALL_TAB_COMMENTS tc ON v.OWNER = tc.OWNER AND v.VIEW_NAME = tc.TABLE_NAME
+		WHERE v.OWNER = :1
+		ORDER BY v.VIEW_NAME`
+	viewRows, err := driver.db.QueryContext(ctx, viewQuery, database)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, viewQuery)
+	}
+	defer viewRows.Close()
+
+	var views []db.View
+	for viewRows.Next() {
+		var view db.View
+		var definition, comment sql.NullString
+		if err := viewRows.Scan(
+			&view.Name,
+			&view.CreatedTs,
+			&view.UpdatedTs,
+			&definition,
+			&comment,
+		); err != nil {
+			return nil, nil, err
+		}
+		view.Definition, view.Comment = definition.String, comment.String
+		views = append(views, view)
+	}
+	if err := viewRows.Err(); err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, viewQuery)
+	}
+
+	return tables, views, nil
+}
+
+// formatColumnType formats the column type with its length, precision and scale, e.g. VARCHAR2(20 BYTE), NUMBER(10,2).
+// The precision and scale are -1 if they are NULL.
+func formatColumnType(typeName string, dataLength, precision, scale, charLength int, charUsed string) string {
+	switch typeName {
+	case "VARCHAR2", "NVARCHAR2", "CHAR", "NCHAR":
+		if strings.HasPrefix(typeName, "N") {
+			return fmt.Sprintf("%s(%d)", typeName, charLength)
+		}
+		unit := "BYTE"
+		if charUsed == "C" {
+			unit = "CHAR"
+		}
+		return fmt.Sprintf("%s(%d %s)", typeName, charLength, unit)
+	case "RAW":
+		return fmt.Sprintf("%s(%d)", typeName, dataLength)
+	case "NUMBER":
+		switch {
+		case precision == -1 && scale == -1:
+			return typeName
+		case precision == -1:
+			// NUMBER(*,0) is reported as a NULL precision with zero scale, e.g. INTEGER.
+			return fmt.Sprintf("%s(*,%d)", typeName, scale)
+		case scale == -1 || scale == 0:
+			return fmt.Sprintf("%s(%d)", typeName, precision)
+		default:
+			return fmt.Sprintf("%s(%d,%d)", typeName, precision, scale)
+		}
+	case "FLOAT":
+		if precision != -1 {
+			return fmt.Sprintf("%s(%d)", typeName, precision)
+		}
+	}
+	return typeName
+}
@@ -47,6 +47,9 @@ type Driver struct {
 
 	// strictDatabase should be used only if the user gives only a database instead of a whole instance to access.
 	strictDatabase string
+
+	// walDir is the directory of the archived WAL for PITR.
+	walDir string
 }
 
 func newDriver(config db.DriverConfig) db.Driver {
@@ -247,6 +250,11 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 					return err
 				}
 			}
+		} else if strings.HasPrefix(stmt, "DROP DATABASE ") {
+			// DROP DATABASE cannot run inside a transaction block.
+			if _, err := driver.db.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
 		} else if strings.HasPrefix(stmt, "ALTER DATABASE") && strings.Contains(stmt, " OWNER TO ") {
 			if _, err := driver.db.ExecContext(ctx, stmt); err != nil {
 				return err
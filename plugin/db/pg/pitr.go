@@ -0,0 +1,594 @@
+package pg
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxDatabaseNameLength is the allowed max database name length in Postgres.
+	MaxDatabaseNameLength = 63
+
+	// WALArchiveSlotName is the physical replication slot retaining the WAL on the instance until it's archived.
+	WALArchiveSlotName = "bytebase_wal_archive"
+	// recoveryWALDirName is the directory in the recovered data directory where the archived WAL is copied to.
+	recoveryWALDirName = "bytebase_recovery_wal"
+)
+
+var (
+	// The WAL segment file name consists of the timeline, the log and the segment IDs in hex.
+	walFileNameRegexp = regexp.MustCompile(`^[0-9A-F]{24}$`)
+	// For example, "START WAL LOCATION: 0/2000028 (file 000000010000000000000002)".
+	backupLabelStartWALRegexp = regexp.MustCompile(`(?m)^START WAL LOCATION: ([0-9A-F]+/[0-9A-F]+) \(file ([0-9A-F]{24})\)$`)
+)
+
+// SetUpForPITR sets up the WAL archive directory of the instance for PITR.
+func (driver *Driver) SetUpForPITR(walDir string) {
+	driver.walDir = walDir
+}
+
+// CreateWALArchiveSlot creates the physical replication slot retaining the WAL for the archiving if it doesn't exist.
+// The slot is created by pg_receivewal through the replication connection the archiving uses, so it's never created
+// on an instance which pg_receivewal can't connect to. The user of the admin data source needs the REPLICATION
+// privilege.
+//
+// The slot retains the WAL on the instance until it's archived, so a broken archiving fills up the disk of the
+// instance. On Postgres 13 and later, max_slot_wal_keep_size is required to bound the retained WAL, and the slot
+// exceeding it is invalidated by Postgres. The earlier versions can't bound it, and the caller should drop the slot if
+// the archiving keeps failing.
+func (driver *Driver) CreateWALArchiveSlot(ctx context.Context, slotName string) error {
+	if err := driver.checkMaxSlotWALKeepSize(ctx); err != nil {
+		return err
+	}
+	args := driver.getConnectionArgs()
+	args = append(args,
+		fmt.Sprintf("--slot=%s", slotName),
+		"--create-slot",
+		"--if-not-exists",
+	)
+	cmd := exec.CommandContext(ctx, filepath.Join(driver.pgInstanceDir, "bin", "pg_receivewal"), args...)
+	cmd.Env = driver.getCommandEnv()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to create replication slot %q with pg_receivewal, error: %w, output: %s", slotName, err, string(output))
+	}
+	log.Info("Created replication slot for WAL archiving", zap.String("slot", slotName))
+	return nil
+}
+
+// checkMaxSlotWALKeepSize checks max_slot_wal_keep_size bounds the WAL retained by the replication slots on Postgres
+// 13 and later, where -1 means unlimited.
+func (driver *Driver) checkMaxSlotWALKeepSize(ctx context.Context) error {
+	var versionNum int
+	if err := driver.db.QueryRowContext(ctx, "SELECT current_setting('server_version_num')::int;").Scan(&versionNum); err != nil {
+		return fmt.Errorf("failed to get server version, error: %w", err)
+	}
+	if versionNum < 130000 {
+		return nil
+	}
+	var maxSlotWALKeepSize string
+	if err := driver.db.QueryRowContext(ctx, "SELECT current_setting('max_slot_wal_keep_size');").Scan(&maxSlotWALKeepSize); err != nil {
+		return fmt.Errorf("failed to get max_slot_wal_keep_size, error: %w", err)
+	}
+	if maxSlotWALKeepSize == "-1" {
+		return fmt.Errorf("max_slot_wal_keep_size is unlimited, please set it to bound the WAL retained for the archiving on the instance")
+	}
+	return nil
+}
+
+// ArchiveWAL streams the WAL of the instance to the WAL archive directory with pg_receivewal through the replication
+// slot, up to the current WAL location. The slot retains the WAL on the instance between the calls, so there is no
+// gap in the archive.
+func (driver *Driver) ArchiveWAL(ctx context.Context, slotName string) error {
+	var endLSN string
+	if err := driver.db.QueryRowContext(ctx, "SELECT pg_current_wal_lsn();").Scan(&endLSN); err != nil {
+		return fmt.Errorf("failed to get current WAL location, error: %w", err)
+	}
+
+	args := driver.getConnectionArgs()
+	args = append(args,
+		fmt.Sprintf("--directory=%s", driver.walDir),
+		fmt.Sprintf("--slot=%s", slotName),
+		fmt.Sprintf("--endpos=%s", endLSN),
+		"--no-loop",
+	)
+	cmd := exec.CommandContext(ctx, filepath.Join(driver.pgInstanceDir, "bin", "pg_receivewal"), args...)
+	cmd.Env = driver.getCommandEnv()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to archive WAL to %q, error: %w, output: %s", driver.walDir, err, string(output))
+	}
+	return nil
+}
+
+// ArchiveWALForPITR archives the WAL of the instance for the recovery to a past point in time. A commit record is
+// written before archiving, since the recovery only stops at the first commit after the target time.
+func (driver *Driver) ArchiveWALForPITR(ctx context.Context, slotName string) error {
+	// txid_current() assigns a transaction ID, so a commit record is written for the query.
+	if _, err := driver.db.ExecContext(ctx, "SELECT txid_current();"); err != nil {
+		return fmt.Errorf("failed to write commit record, error: %w", err)
+	}
+	return driver.ArchiveWAL(ctx, slotName)
+}
+
+// GetWALArchiveSlotLag returns the bytes of the WAL retained by the replication slot on the instance, which is the WAL
+// not archived yet.
+func (driver *Driver) GetWALArchiveSlotLag(ctx context.Context, slotName string) (int64, error) {
+	// The restart LSN is null until the slot created by pg_receivewal is streamed for the first time.
+	query := "SELECT COALESCE(pg_wal_lsn_diff(pg_current_wal_lsn(), restart_lsn), 0)::bigint FROM pg_replication_slots WHERE slot_name = $1;"
+	var lag int64
+	if err := driver.db.QueryRowContext(ctx, query, slotName).Scan(&lag); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, common.Errorf(common.NotFound, fmt.Errorf("replication slot %q not found", slotName))
+		}
+		return 0, util.FormatErrorWithQuery(err, query)
+	}
+	return lag, nil
+}
+
+// DropWALArchiveSlot drops the replication slot of the WAL archiving if it exists, so the WAL is no longer retained
+// on the instance.
+func (driver *Driver) DropWALArchiveSlot(ctx context.Context, slotName string) error {
+	if _, err := driver.db.ExecContext(ctx, "SELECT pg_drop_replication_slot(slot_name) FROM pg_replication_slots WHERE slot_name = $1;", slotName); err != nil {
+		return fmt.Errorf("failed to drop replication slot %q, error: %w", slotName, err)
+	}
+	return nil
+}
+
+// BaseBackup takes a base backup of the instance with pg_basebackup, and writes the tarball of the data directory to
+// out. The WAL needed to make the base backup consistent is included, so the base backup can be restored on its own.
+// The instance with tablespaces outside the data directory is not supported.
+func (driver *Driver) BaseBackup(ctx context.Context, out io.Writer) (*api.PgBaseBackup, error) {
+	var tablespaceCount int
+	if err := driver.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pg_tablespace WHERE spcname NOT IN ('pg_default', 'pg_global');").Scan(&tablespaceCount); err != nil {
+		return nil, fmt.Errorf("failed to count tablespaces, error: %w", err)
+	}
+	if tablespaceCount > 0 {
+		return nil, fmt.Errorf("base backup of the instance with %d user defined tablespaces is not supported", tablespaceCount)
+	}
+
+	args := driver.getConnectionArgs()
+	args = append(args,
+		"--pgdata=-",
+		"--format=tar",
+		"--wal-method=fetch",
+		"--checkpoint=fast",
+		"--label=bytebase",
+	)
+	cmd := exec.CommandContext(ctx, filepath.Join(driver.pgInstanceDir, "bin", "pg_basebackup"), args...)
+	cmd.Env = driver.getCommandEnv()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start pg_basebackup, error: %w", err)
+	}
+
+	// Read the backup label from the tarball while streaming it to the output.
+	r := io.TeeReader(stdout, out)
+	label, err := readBackupLabel(r)
+	if err == nil {
+		// Copy the padding after the end of the tarball.
+		_, err = io.Copy(io.Discard, r)
+	}
+	if err != nil {
+		// Don't leave pg_basebackup blocked on writing the pipe.
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("failed to stream base backup, error: %w", err)
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to take base backup, error: %w, stderr: %s", err, stderr.String())
+	}
+	if label == "" {
+		return nil, fmt.Errorf("backup_label not found in base backup")
+	}
+
+	baseBackup, err := parseBackupLabel(label)
+	if err != nil {
+		return nil, err
+	}
+	baseBackup.EndTs = time.Now().Unix()
+	return baseBackup, nil
+}
+
+// readBackupLabel reads the tarball to the end, and returns the content of the backup_label file.
+func readBackupLabel(r io.Reader) (string, error) {
+	var label string
+	tarReader := tar.NewReader(r)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return label, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if header.Name != "backup_label" {
+			continue
+		}
+		content, err := io.ReadAll(tarReader)
+		if err != nil {
+			return "", err
+		}
+		label = string(content)
+	}
+}
+
+// parseBackupLabel parses the start WAL location of the base backup from the backup_label file.
+func parseBackupLabel(label string) (*api.PgBaseBackup, error) {
+	matches := backupLabelStartWALRegexp.FindStringSubmatch(label)
+	if matches == nil {
+		return nil, fmt.Errorf("start WAL location not found in backup_label %q", label)
+	}
+	// The first 8 hex digits of the WAL file name is the timeline.
+	timeline, err := strconv.ParseInt(matches[2][:8], 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WAL file name %q, error: %w", matches[2], err)
+	}
+	return &api.PgBaseBackup{
+		StartLSN:     matches[1],
+		StartWALFile: matches[2],
+		Timeline:     int(timeline),
+	}, nil
+}
+
+// PrepareRecovery prepares the data directory restored from the base backup to replay the archived WAL up to the
+// target time. The archived WAL segments since the base backup are copied into the data directory for the restore
+// command. The server configurations of the original instance are replaced, since they may refer to the paths and
+// the extensions unavailable locally. The major version of the local Postgres must match the base backup.
+func PrepareRecovery(pgInstanceDir, dataDir, walDir string, baseBackup *api.PgBaseBackup, targetTs int64) error {
+	if err := checkRecoveryVersion(pgInstanceDir, dataDir); err != nil {
+		return err
+	}
+
+	recoveryWALDir := filepath.Join(dataDir, recoveryWALDirName)
+	if err := os.MkdirAll(recoveryWALDir, 0700); err != nil {
+		return fmt.Errorf("failed to create recovery WAL directory %q, error: %w", recoveryWALDir, err)
+	}
+	walFileList, err := getRecoveryWALFileList(walDir, baseBackup.StartWALFile)
+	if err != nil {
+		return err
+	}
+	for _, walFile := range walFileList {
+		// The restore command looks for the complete segment name.
+		if err := copyFile(filepath.Join(walDir, walFile), filepath.Join(recoveryWALDir, strings.TrimSuffix(walFile, ".partial"))); err != nil {
+			return fmt.Errorf("failed to copy WAL file %q, error: %w", walFile, err)
+		}
+	}
+
+	for _, name := range []string{"postgresql.auto.conf", "standby.signal", "recovery.conf"} {
+		if err := os.Remove(filepath.Join(dataDir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %q, error: %w", name, err)
+		}
+	}
+	// The TCP connection is disabled, and the recovered instance is only accessed through the Unix-domain socket.
+	// The hot standby is off, so the instance accepts connections only after the recovery, and doesn't require the
+	// resource settings to be no less than the original instance.
+	config := strings.Join([]string{
+		"# Generated by Bytebase for the point-in-time recovery.",
+		"listen_addresses = ''",
+		"hot_standby = off",
+		"archive_mode = off",
+		fmt.Sprintf("restore_command = 'cp \"%s/%%f\" \"%%p\"'", recoveryWALDir),
+		fmt.Sprintf("recovery_target_time = '%s'", time.Unix(targetTs, 0).UTC().Format("2006-01-02 15:04:05-07")),
+		"recovery_target_action = 'promote'",
+		"",
+	}, "\n")
+	if err := os.WriteFile(filepath.Join(dataDir, "postgresql.conf"), []byte(config), 0600); err != nil {
+		return fmt.Errorf("failed to write postgresql.conf, error: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "pg_hba.conf"), []byte("local all all trust\n"), 0600); err != nil {
+		return fmt.Errorf("failed to write pg_hba.conf, error: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "recovery.signal"), nil, 0600); err != nil {
+		return fmt.Errorf("failed to write recovery.signal, error: %w", err)
+	}
+	return nil
+}
+
+// checkRecoveryVersion checks the major version of the base backup matches the local Postgres.
+func checkRecoveryVersion(pgInstanceDir, dataDir string) error {
+	content, err := os.ReadFile(filepath.Join(dataDir, "PG_VERSION"))
+	if err != nil {
+		return fmt.Errorf("failed to read PG_VERSION of base backup, error: %w", err)
+	}
+	backupVersion := strings.TrimSpace(string(content))
+	// For example, "postgres (PostgreSQL) 14.2".
+	output, err := exec.Command(filepath.Join(pgInstanceDir, "bin", "postgres"), "--version").Output()
+	if err != nil {
+		return fmt.Errorf("failed to get local Postgres version, error: %w", err)
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) == 0 {
+		return fmt.Errorf("invalid local Postgres version %q", string(output))
+	}
+	localVersion := strings.Split(fields[len(fields)-1], ".")[0]
+	if localVersion != backupVersion {
+		return fmt.Errorf("base backup of Postgres %s can't be restored by the local Postgres %s", backupVersion, localVersion)
+	}
+	return nil
+}
+
+// getRecoveryWALFileList returns the archived WAL files needed to replay from the start WAL file, including the
+// timeline history files. The partial segment is included if the complete one is not archived yet.
+func getRecoveryWALFileList(walDir, startWALFile string) ([]string, error) {
+	entries, err := os.ReadDir(walDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WAL archive directory %q, error: %w", walDir, err)
+	}
+	segmentMap := make(map[string]bool)
+	for _, entry := range entries {
+		if walFileNameRegexp.MatchString(entry.Name()) {
+			segmentMap[entry.Name()] = true
+		}
+	}
+	var walFileList []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, ".history") {
+			walFileList = append(walFileList, name)
+			continue
+		}
+		segment := strings.TrimSuffix(name, ".partial")
+		if !walFileNameRegexp.MatchString(segment) || segment < startWALFile {
+			continue
+		}
+		if segment != name && segmentMap[segment] {
+			continue
+		}
+		walFileList = append(walFileList, name)
+	}
+	sort.Strings(walFileList)
+	return walFileList, nil
+}
+
+// CopyWALFiles copies the archived WAL files needed to replay from the start WAL file into the destination directory,
+// except the ones already there. It collects the WAL not uploaded yet, such as the partial segment, for the recovery.
+func CopyWALFiles(walDir, dstDir, startWALFile string) error {
+	walFileList, err := getRecoveryWALFileList(walDir, startWALFile)
+	if err != nil {
+		return err
+	}
+	for _, walFile := range walFileList {
+		dst := filepath.Join(dstDir, walFile)
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+		if err := copyFile(filepath.Join(walDir, walFile), dst); err != nil {
+			return fmt.Errorf("failed to copy WAL file %q, error: %w", walFile, err)
+		}
+	}
+	return nil
+}
+
+// GetCompleteWALFileList returns the complete WAL segments and the timeline history files in the WAL archive
+// directory, which are uploaded to the backup storage backend. The partial segment being streamed is excluded.
+func GetCompleteWALFileList(walDir string) ([]string, error) {
+	entries, err := os.ReadDir(walDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WAL archive directory %q, error: %w", walDir, err)
+	}
+	var walFileList []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if walFileNameRegexp.MatchString(name) || strings.HasSuffix(name, ".history") {
+			walFileList = append(walFileList, name)
+		}
+	}
+	sort.Strings(walFileList)
+	return walFileList, nil
+}
+
+// RemoveUploadedWALSegments removes the uploaded WAL segments from the WAL archive directory except the latest
+// complete one, since pg_receivewal resumes streaming after the latest segment in the directory.
+func RemoveUploadedWALSegments(walDir string, uploaded map[string]bool) error {
+	walFileList, err := GetCompleteWALFileList(walDir)
+	if err != nil {
+		return err
+	}
+	var latestSegment string
+	for _, walFile := range walFileList {
+		if walFileNameRegexp.MatchString(walFile) {
+			latestSegment = walFile
+		}
+	}
+	for _, walFile := range walFileList {
+		if !walFileNameRegexp.MatchString(walFile) || walFile == latestSegment || !uploaded[walFile] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(walDir, walFile)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove WAL file %q, error: %w", walFile, err)
+		}
+	}
+	return nil
+}
+
+// IsWALSegmentBefore returns whether the WAL file is a complete or partial segment before the start WAL file, which
+// is not needed to replay from the start WAL file.
+func IsWALSegmentBefore(walFile, startWALFile string) bool {
+	segment := strings.TrimSuffix(walFile, ".partial")
+	return walFileNameRegexp.MatchString(segment) && segment < startWALFile
+}
+
+// PruneWALArchive removes the archived WAL segments before the start WAL file of the oldest base backup in use.
+func PruneWALArchive(walDir, startWALFile string) error {
+	entries, err := os.ReadDir(walDir)
+	if err != nil {
+		return fmt.Errorf("failed to read WAL archive directory %q, error: %w", walDir, err)
+	}
+	for _, entry := range entries {
+		if !IsWALSegmentBefore(entry.Name(), startWALFile) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(walDir, entry.Name())); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove WAL file %q, error: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// RestorePITR creates the PITR database, and copies the database from the instance recovered to the point in time.
+// The PITR database left over by the previous attempt which failed halfway is dropped first.
+func (driver *Driver) RestorePITR(ctx context.Context, recovered db.Driver, database string, suffixTs int64) error {
+	pitrDatabaseName := getPITRDatabaseName(database, suffixTs)
+	if _, err := driver.db.ExecContext(ctx, fmt.Sprintf("DROP DATABASE IF EXISTS \"%s\";", pitrDatabaseName)); err != nil {
+		return fmt.Errorf("failed to drop database %q, error: %w", pitrDatabaseName, err)
+	}
+	if _, err := driver.db.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE \"%s\";", pitrDatabaseName)); err != nil {
+		return fmt.Errorf("failed to create database %q, error: %w", pitrDatabaseName, err)
+	}
+	if err := driver.switchDatabase(pitrDatabaseName); err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	dumpErr := make(chan error, 1)
+	go func() {
+		_, err := recovered.Dump(ctx, database, pw, false /* schemaOnly */)
+		pw.CloseWithError(err)
+		dumpErr <- err
+	}()
+	restoreErr := driver.Restore(ctx, bufio.NewScanner(pr))
+	// Unblock the dump if the restore stops reading halfway.
+	pr.Close()
+	if err := <-dumpErr; err != nil {
+		return fmt.Errorf("failed to dump database %q from the recovered instance, error: %w", database, err)
+	}
+	if restoreErr != nil {
+		return fmt.Errorf("failed to restore database %q, error: %w", pitrDatabaseName, restoreErr)
+	}
+	return nil
+}
+
+// SwapPITRDatabase renames the original database to the PITR old database, and the PITR database to the original one.
+// It returns the PITR database name and the PITR old database name.
+// The connections to both databases are terminated, since a database with connections can't be renamed.
+func SwapPITRDatabase(ctx context.Context, conn *sql.Conn, database string, suffixTs int64) (string, string, error) {
+	pitrDatabaseName := getPITRDatabaseName(database, suffixTs)
+	pitrOldDatabaseName := getPITROldDatabaseName(database, suffixTs)
+
+	// Handle the case that the original database does not exist, because user could drop a database and want to restore it.
+	dbExists, err := databaseExists(ctx, conn, database)
+	if err != nil {
+		return pitrDatabaseName, pitrOldDatabaseName, fmt.Errorf("failed to check whether database %q exists, error: %w", database, err)
+	}
+	for _, name := range []string{database, pitrDatabaseName} {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_terminate_backend(pid) FROM pg_stat_activity WHERE datname = $1 AND pid <> pg_backend_pid();", name); err != nil {
+			return pitrDatabaseName, pitrOldDatabaseName, fmt.Errorf("failed to terminate connections to database %q, error: %w", name, err)
+		}
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return pitrDatabaseName, pitrOldDatabaseName, err
+	}
+	defer tx.Rollback()
+	if dbExists {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER DATABASE \"%s\" RENAME TO \"%s\";", database, pitrOldDatabaseName)); err != nil {
+			return pitrDatabaseName, pitrOldDatabaseName, err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("ALTER DATABASE \"%s\" RENAME TO \"%s\";", pitrDatabaseName, database)); err != nil {
+		return pitrDatabaseName, pitrOldDatabaseName, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pitrDatabaseName, pitrOldDatabaseName, err
+	}
+	return pitrDatabaseName, pitrOldDatabaseName, nil
+}
+
+func databaseExists(ctx context.Context, conn *sql.Conn, database string) (bool, error) {
+	query := "SELECT 1 FROM pg_database WHERE datname = $1;"
+	var unused int
+	if err := conn.QueryRowContext(ctx, query, database).Scan(&unused); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, util.FormatErrorWithQuery(err, query)
+	}
+	return true, nil
+}
+
+// Composes a pitr database name that we use as the target database to copy the recovered database.
+// For example, getPITRDatabaseName("dbfoo", 1653018005) -> "dbfoo_pitr_1653018005"
+func getPITRDatabaseName(database string, suffixTs int64) string {
+	suffix := fmt.Sprintf("pitr_%d", suffixTs)
+	return getSafeName(database, suffix)
+}
+
+// Composes a database name that we use as the target database for swapping out the original database.
+// For example, getPITROldDatabaseName("dbfoo", 1653018005) -> "dbfoo_pitr_1653018005_old"
+func getPITROldDatabaseName(database string, suffixTs int64) string {
+	suffix := fmt.Sprintf("pitr_%d_old", suffixTs)
+	return getSafeName(database, suffix)
+}
+
+func getSafeName(baseName, suffix string) string {
+	name := fmt.Sprintf("%s_%s", baseName, suffix)
+	if len(name) <= MaxDatabaseNameLength {
+		return name
+	}
+	extraCharacters := len(name) - MaxDatabaseNameLength
+	return fmt.Sprintf("%s_%s", baseName[0:len(baseName)-extraCharacters], suffix)
+}
+
+// getConnectionArgs returns the connection arguments of the Postgres client tools.
+func (driver *Driver) getConnectionArgs() []string {
+	args := []string{
+		fmt.Sprintf("--host=%s", driver.config.Host),
+		fmt.Sprintf("--port=%s", driver.config.Port),
+		fmt.Sprintf("--username=%s", driver.config.Username),
+	}
+	if driver.config.Password == "" {
+		args = append(args, "--no-password")
+	}
+	return args
+}
+
+// getCommandEnv returns the environment variables of the Postgres client tools, which carry the password.
+func (driver *Driver) getCommandEnv() []string {
+	env := os.Environ()
+	if driver.config.Password != "" {
+		env = append(env, fmt.Sprintf("PGPASSWORD=%s", driver.config.Password))
+	}
+	return env
+}
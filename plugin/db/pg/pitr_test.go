@@ -0,0 +1,150 @@
+package pg
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBackupLabel(t *testing.T) {
+	tests := []struct {
+		label   string
+		want    *api.PgBaseBackup
+		wantErr bool
+	}{
+		{
+			label: strings.Join([]string{
+				"START WAL LOCATION: 0/2000028 (file 000000010000000000000002)",
+				"CHECKPOINT LOCATION: 0/2000060",
+				"BACKUP METHOD: streamed",
+				"BACKUP FROM: primary",
+				"START TIME: 2022-07-31 10:00:00 UTC",
+				"LABEL: bytebase",
+				"START TIMELINE: 1",
+				"",
+			}, "\n"),
+			want: &api.PgBaseBackup{
+				StartLSN:     "0/2000028",
+				StartWALFile: "000000010000000000000002",
+				Timeline:     1,
+			},
+		},
+		{
+			label: "START WAL LOCATION: 1A/B3000028 (file 0000000A0000001A000000B3)\n",
+			want: &api.PgBaseBackup{
+				StartLSN:     "1A/B3000028",
+				StartWALFile: "0000000A0000001A000000B3",
+				Timeline:     10,
+			},
+		},
+		{
+			label:   "CHECKPOINT LOCATION: 0/2000060\n",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := parseBackupLabel(test.label)
+		if test.wantErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.want, got)
+	}
+}
+
+func TestWALArchive(t *testing.T) {
+	walDir := t.TempDir()
+	for _, name := range []string{
+		"000000010000000000000001",
+		"000000010000000000000002",
+		"000000010000000000000003",
+		"000000010000000000000004.partial",
+		"00000002.history",
+		"000000020000000000000004.partial",
+		"000000020000000000000004",
+		"unknown",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(walDir, name), nil, 0600))
+	}
+
+	walFileList, err := getRecoveryWALFileList(walDir, "000000010000000000000002")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"000000010000000000000002",
+		"000000010000000000000003",
+		"000000010000000000000004.partial",
+		"00000002.history",
+		"000000020000000000000004",
+	}, walFileList)
+
+	require.NoError(t, PruneWALArchive(walDir, "000000010000000000000003"))
+	entries, err := os.ReadDir(walDir)
+	require.NoError(t, err)
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	sort.Strings(remaining)
+	require.Equal(t, []string{
+		"000000010000000000000003",
+		"000000010000000000000004.partial",
+		"00000002.history",
+		"000000020000000000000004",
+		"000000020000000000000004.partial",
+		"unknown",
+	}, remaining)
+
+	completeList, err := GetCompleteWALFileList(walDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"000000010000000000000003",
+		"00000002.history",
+		"000000020000000000000004",
+	}, completeList)
+
+	uploaded := make(map[string]bool)
+	for _, walFile := range completeList {
+		uploaded[walFile] = true
+	}
+	require.NoError(t, RemoveUploadedWALSegments(walDir, uploaded))
+	completeList, err = GetCompleteWALFileList(walDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"00000002.history",
+		"000000020000000000000004",
+	}, completeList)
+}
+
+func TestGetPITRDatabaseName(t *testing.T) {
+	tests := []struct {
+		database string
+		suffixTs int64
+		want     string
+		wantOld  string
+	}{
+		{
+			database: "dbfoo",
+			suffixTs: 1653018005,
+			want:     "dbfoo_pitr_1653018005",
+			wantOld:  "dbfoo_pitr_1653018005_old",
+		},
+		{
+			database: strings.Repeat("a", 63),
+			suffixTs: 1653018005,
+			want:     strings.Repeat("a", 47) + "_pitr_1653018005",
+			wantOld:  strings.Repeat("a", 43) + "_pitr_1653018005_old",
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, getPITRDatabaseName(test.database, test.suffixTs))
+		require.Equal(t, test.wantOld, getPITROldDatabaseName(test.database, test.suffixTs))
+	}
+}
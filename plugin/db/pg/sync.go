@@ -204,6 +204,10 @@ func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([
 				dbColumn.Position = col.ordinalPosition
 				dbColumn.Default = &col.columnDefault
 				dbColumn.Type = col.dataType
+				if col.characterMaximumLength != "" {
+					// Keep the length so that the column type can be used in the DDL, e.g. character varying(255).
+					dbColumn.Type = fmt.Sprintf("%s(%s)", col.dataType, col.characterMaximumLength)
+				}
 				dbColumn.Nullable = col.isNullable
 				dbColumn.Collation = col.collationName
 				dbColumn.Comment = col.comment
@@ -0,0 +1,198 @@
+package redis
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// Dump and restore
+const (
+	databaseHeaderFmt = "" +
+		"#\n" +
+		"# Redis data for database %d\n" +
+		"#\n"
+)
+
+// Dump dumps the keys of the database as commands.
+// Redis has no schema, so nothing is dumped for the schema-only dump.
+func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
+	if schemaOnly {
+		return "", nil
+	}
+
+	var dumpableDbNames []string
+	if database != "" {
+		dumpableDbNames = []string{database}
+	} else {
+		var err error
+		dumpableDbNames, err = driver.getDatabases(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get databases: %s", err)
+		}
+	}
+
+	for _, dbName := range dumpableDbNames {
+		databaseIndex, err := parseDatabaseIndex(dbName)
+		if err != nil {
+			return "", err
+		}
+		// The SELECT command is only included when dumping all databases.
+		includeSelectStmt := database == ""
+		if err := dumpOneDatabase(ctx, driver.getClient(databaseIndex), databaseIndex, out, includeSelectStmt); err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+func dumpOneDatabase(ctx context.Context, client *goredis.Client, databaseIndex int, out io.Writer, includeSelectStmt bool) error {
+	if includeSelectStmt {
+		header := fmt.Sprintf(databaseHeaderFmt, databaseIndex)
+		if _, err := io.WriteString(out, fmt.Sprintf("%sSELECT %d\n", header, databaseIndex)); err != nil {
+			return err
+		}
+	}
+
+	var cursor uint64
+	for {
+		keys, nextCursor, err := client.Scan(ctx, cursor, "", scanBatchSize).Result()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if strings.HasPrefix(key, migrationHistoryKeyPrefix) {
+				continue
+			}
+			commands, err := getKeyCommands(ctx, client, key)
+			if err != nil {
+				return err
+			}
+			for _, command := range commands {
+				if _, err := io.WriteString(out, formatCommand(command)+"\n"); err != nil {
+					return err
+				}
+			}
+		}
+		if nextCursor == 0 {
+			break
+		}
+		cursor = nextCursor
+	}
+	return nil
+}
+
+// getKeyCommands returns the commands to recreate the key with its expiration.
+// It returns no command if the key is deleted after scanning.
+func getKeyCommands(ctx context.Context, client *goredis.Client, key string) ([][]string, error) {
+	keyType, err := client.Type(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	commands := [][]string{{"DEL", key}}
+	switch keyType {
+	case "none":
+		return nil, nil
+	case "string":
+		value, err := client.Get(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, []string{"SET", key, value})
+	case "list":
+		values, err := client.LRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, append([]string{"RPUSH", key}, values...))
+	case "set":
+		members, err := client.SMembers(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, append([]string{"SADD", key}, members...))
+	case "zset":
+		members, err := client.ZRangeWithScores(ctx, key, 0, -1).Result()
+		if err != nil {
+			return nil, err
+		}
+		command := []string{"ZADD", key}
+		for _, member := range members {
+			command = append(command, strconv.FormatFloat(member.Score, 'g', -1, 64), fmt.Sprintf("%v", member.Member))
+		}
+		commands = append(commands, command)
+	case "hash":
+		fields, err := client.HGetAll(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		command := []string{"HSET", key}
+		for _, field := range sortedKeys(fields) {
+			command = append(command, field, fields[field])
+		}
+		commands = append(commands, command)
+	case "stream":
+		messages, err := client.XRange(ctx, key, "-", "+").Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			values := make(map[string]string)
+			for field, value := range message.Values {
+				values[field] = fmt.Sprintf("%v", value)
+			}
+			command := []string{"XADD", key, message.ID}
+			for _, field := range sortedKeys(values) {
+				command = append(command, field, values[field])
+			}
+			commands = append(commands, command)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %q of key %q", keyType, key)
+	}
+
+	ttl, err := client.PTTL(ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		commands = append(commands, []string{"PEXPIRE", key, strconv.FormatInt(ttl.Milliseconds(), 10)})
+	}
+	return commands, nil
+}
+
+// sortedKeys returns the keys of the map in order, so the dump is stable.
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Restore restores a database.
+func (driver *Driver) Restore(ctx context.Context, sc *bufio.Scanner) (err error) {
+	var lines []string
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	return driver.Execute(ctx, strings.Join(lines, "\n"))
+}
+
+// RestoreTx restores the database in the given transaction.
+func (*Driver) RestoreTx(ctx context.Context, tx *sql.Tx, sc *bufio.Scanner) error {
+	return fmt.Errorf("Redis doesn't support restoring in a database/sql transaction")
+}
@@ -0,0 +1,294 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/util"
+	"go.uber.org/zap"
+)
+
+// The migration history is recorded in the database 0. Each record is a hash, and the IDs are kept in a sorted set.
+const (
+	migrationHistoryKeyPrefix = "bytebase:migration_history:"
+	migrationHistoryIDsKey    = migrationHistoryKeyPrefix + "ids"
+	migrationHistoryNextIDKey = migrationHistoryKeyPrefix + "next_id"
+)
+
+// migrationHistory is the migration history record.
+// The fields are the same as the migration_history table of the SQL databases.
+type migrationHistory struct {
+	ID                  int    `redis:"id"`
+	CreatedBy           string `redis:"created_by"`
+	CreatedTs           int64  `redis:"created_ts"`
+	UpdatedBy           string `redis:"updated_by"`
+	UpdatedTs           int64  `redis:"updated_ts"`
+	ReleaseVersion      string `redis:"release_version"`
+	Namespace           string `redis:"namespace"`
+	Sequence            int    `redis:"sequence"`
+	Source              string `redis:"source"`
+	Type                string `redis:"type"`
+	Status              string `redis:"status"`
+	Version             string `redis:"version"`
+	Description         string `redis:"description"`
+	Statement           string `redis:"statement"`
+	Schema              string `redis:"schema"`
+	SchemaPrev          string `redis:"schema_prev"`
+	ExecutionDurationNs int64  `redis:"execution_duration_ns"`
+	IssueID             string `redis:"issue_id"`
+	Payload             string `redis:"payload"`
+}
+
+func getMigrationHistoryKey(id int64) string {
+	return migrationHistoryKeyPrefix + strconv.FormatInt(id, 10)
+}
+
+// NeedsSetupMigration returns whether it needs to setup migration.
+// The migration history keys are created on the first migration, so there is nothing to set up.
+func (*Driver) NeedsSetupMigration(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+// SetupMigrationIfNeeded sets up migration if needed.
+func (*Driver) SetupMigrationIfNeeded(ctx context.Context) error {
+	return nil
+}
+
+// ExecuteMigration will execute the migration.
+// Redis can't use util.ExecuteMigration which records the migration history in a database/sql transaction,
+// so it follows the same phases with the migration history keys.
+func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (migrationHistoryID int64, updatedSchema string, resErr error) {
+	if m.CreateDatabase {
+		return -1, "", fmt.Errorf("Redis doesn't support creating database")
+	}
+	databaseIndex, err := parseDatabaseIndex(m.Database)
+	if err != nil {
+		return -1, "", err
+	}
+
+	// Phase 1 - Pre-check before executing migration
+	// Phase 2 - Record migration history as PENDING
+	insertedID, err := driver.beginMigration(ctx, m, statement)
+	if err != nil {
+		return -1, "", err
+	}
+
+	startedNs := time.Now().UnixNano()
+
+	defer func() {
+		if err := driver.endMigration(ctx, startedNs, insertedID, resErr == nil /*isDone*/); err != nil {
+			log.Error("Failed to update migration history record",
+				zap.Error(err),
+				zap.Int64("migration_id", migrationHistoryID),
+			)
+		}
+	}()
+
+	// Phase 3 - Executing migration
+	// Baseline and branch migration types don't execute the commands.
+	if statement != "" && m.Type != db.Baseline && m.Type != db.Branch {
+		driver.databaseIndex = databaseIndex
+		if err := driver.Execute(ctx, statement); err != nil {
+			return -1, "", util.FormatError(err)
+		}
+	}
+
+	// Phase 4 - Dump the schema after migration
+	// Redis has no schema, and we still follow the convention of other drivers.
+	var afterSchemaBuf bytes.Buffer
+	if _, err := driver.Dump(ctx, m.Database, &afterSchemaBuf, true /*schemaOnly*/); err != nil {
+		return -1, "", util.FormatError(err)
+	}
+
+	return insertedID, afterSchemaBuf.String(), nil
+}
+
+// beginMigration checks before executing migration and inserts a migration history record with pending status.
+func (driver *Driver) beginMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, error) {
+	storedVersion, err := util.ToStoredVersion(m.UseSemanticVersion, m.Version, m.SemanticVersionSuffix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert to stored version, error %w", err)
+	}
+	// Phase 1 - Pre-check before executing migration
+	if existingID, err := util.PreCheckMigration(ctx, driver, m); err != nil {
+		return -1, err
+	} else if existingID >= 0 {
+		return existingID, nil
+	}
+
+	historyList, err := driver.listMigrationHistory(ctx)
+	if err != nil {
+		return -1, err
+	}
+	largestSequence, largestBaselineSequence := 0, 0
+	for _, history := range historyList {
+		if history.Namespace != m.Namespace {
+			continue
+		}
+		if history.Sequence > largestSequence {
+			largestSequence = history.Sequence
+		}
+		if (history.Type == string(db.Baseline) || history.Type == string(db.Branch)) && history.Sequence > largestBaselineSequence {
+			largestBaselineSequence = history.Sequence
+		}
+	}
+	// Check if there is any higher version already been applied since the last baseline or branch.
+	for _, history := range historyList {
+		if history.Namespace == m.Namespace && history.Sequence >= largestBaselineSequence && history.Version >= m.Version {
+			return -1, common.Errorf(common.MigrationOutOfOrder, fmt.Errorf("database %q has already applied version %s which >= %s", m.Database, history.Version, m.Version))
+		}
+	}
+
+	// Phase 2 - Record migration history as PENDING.
+	client := driver.getClient(0)
+	insertedID, err := client.Incr(ctx, migrationHistoryNextIDKey).Result()
+	if err != nil {
+		return -1, err
+	}
+	now := time.Now().Unix()
+	history := map[string]interface{}{
+		"id":                    insertedID,
+		"created_by":            m.Creator,
+		"created_ts":            now,
+		"updated_by":            m.Creator,
+		"updated_ts":            now,
+		"release_version":       m.ReleaseVersion,
+		"namespace":             m.Namespace,
+		"sequence":              largestSequence + 1,
+		"source":                string(m.Source),
+		"type":                  string(m.Type),
+		"status":                string(db.Pending),
+		"version":               storedVersion,
+		"description":           m.Description,
+		"statement":             statement,
+		"schema":                "",
+		"schema_prev":           "",
+		"execution_duration_ns": 0,
+		"issue_id":              m.IssueID,
+		"payload":               m.Payload,
+	}
+	if _, err := client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.HSet(ctx, getMigrationHistoryKey(insertedID), history)
+		pipe.ZAdd(ctx, migrationHistoryIDsKey, &goredis.Z{Score: float64(insertedID), Member: insertedID})
+		return nil
+	}); err != nil {
+		return -1, err
+	}
+	return insertedID, nil
+}
+
+// endMigration updates the migration history record to DONE or FAILED depending on migration is done or not.
+func (driver *Driver) endMigration(ctx context.Context, startedNs int64, migrationHistoryID int64, isDone bool) error {
+	migrationDurationNs := time.Now().UnixNano() - startedNs
+	status := db.Failed
+	if isDone {
+		status = db.Done
+	}
+	return driver.getClient(0).HSet(ctx, getMigrationHistoryKey(migrationHistoryID),
+		"status", string(status),
+		"execution_duration_ns", migrationDurationNs,
+	).Err()
+}
+
+// listMigrationHistory returns all migration history records in the descending order of ID.
+func (driver *Driver) listMigrationHistory(ctx context.Context) ([]*migrationHistory, error) {
+	client := driver.getClient(0)
+	ids, err := client.ZRevRange(ctx, migrationHistoryIDsKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := client.Pipeline()
+	cmds := make([]*goredis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, migrationHistoryKeyPrefix+id)
+	}
+	if len(ids) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var historyList []*migrationHistory
+	for _, cmd := range cmds {
+		var history migrationHistory
+		if err := cmd.Scan(&history); err != nil {
+			return nil, err
+		}
+		historyList = append(historyList, &history)
+	}
+	return historyList, nil
+}
+
+// FindMigrationHistoryList finds the migration history.
+func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
+	var storedVersion *string
+	if v := find.Version; v != nil {
+		// TODO(d): support semantic versioning.
+		version, err := util.ToStoredVersion(false, *v, "")
+		if err != nil {
+			return nil, err
+		}
+		storedVersion = &version
+	}
+
+	historyList, err := driver.listMigrationHistory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrationHistoryList []*db.MigrationHistory
+	for _, history := range historyList {
+		if v := find.ID; v != nil && history.ID != *v {
+			continue
+		}
+		if v := find.Database; v != nil && history.Namespace != *v {
+			continue
+		}
+		if storedVersion != nil && history.Version != *storedVersion {
+			continue
+		}
+		if v := find.Source; v != nil && history.Source != string(*v) {
+			continue
+		}
+		if v := find.Limit; v != nil && len(migrationHistoryList) >= *v {
+			break
+		}
+
+		useSemanticVersion, version, semanticVersionSuffix, err := util.FromStoredVersion(history.Version)
+		if err != nil {
+			return nil, err
+		}
+		migrationHistoryList = append(migrationHistoryList, &db.MigrationHistory{
+			ID:                    history.ID,
+			Creator:               history.CreatedBy,
+			CreatedTs:             history.CreatedTs,
+			Updater:               history.UpdatedBy,
+			UpdatedTs:             history.UpdatedTs,
+			ReleaseVersion:        history.ReleaseVersion,
+			Namespace:             history.Namespace,
+			Sequence:              history.Sequence,
+			Source:                db.MigrationSource(history.Source),
+			Type:                  db.MigrationType(history.Type),
+			Status:                db.MigrationStatus(history.Status),
+			Version:               version,
+			Description:           history.Description,
+			Statement:             history.Statement,
+			Schema:                history.Schema,
+			SchemaPrev:            history.SchemaPrev,
+			ExecutionDurationNs:   history.ExecutionDurationNs,
+			IssueID:               history.IssueID,
+			Payload:               history.Payload,
+			UseSemanticVersion:    useSemanticVersion,
+			SemanticVersionSuffix: semanticVersionSuffix,
+		})
+	}
+	return migrationHistoryList, nil
+}
@@ -0,0 +1,501 @@
+package redis
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"go.uber.org/zap"
+)
+
+var (
+	// readOnlyCommands are the commands allowed in Query.
+	readOnlyCommands = map[string]bool{
+		"BITCOUNT":         true,
+		"BITPOS":           true,
+		"DBSIZE":           true,
+		"EXISTS":           true,
+		"GET":              true,
+		"GETBIT":           true,
+		"GETRANGE":         true,
+		"HEXISTS":          true,
+		"HGET":             true,
+		"HGETALL":          true,
+		"HKEYS":            true,
+		"HLEN":             true,
+		"HMGET":            true,
+		"HSCAN":            true,
+		"HSTRLEN":          true,
+		"HVALS":            true,
+		"LINDEX":           true,
+		"LLEN":             true,
+		"LRANGE":           true,
+		"MGET":             true,
+		"PTTL":             true,
+		"RANDOMKEY":        true,
+		"SCAN":             true,
+		"SCARD":            true,
+		"SISMEMBER":        true,
+		"SMEMBERS":         true,
+		"SRANDMEMBER":      true,
+		"SSCAN":            true,
+		"STRLEN":           true,
+		"TTL":              true,
+		"TYPE":             true,
+		"XLEN":             true,
+		"XRANGE":           true,
+		"XREVRANGE":        true,
+		"ZCARD":            true,
+		"ZCOUNT":           true,
+		"ZRANGE":           true,
+		"ZRANGEBYLEX":      true,
+		"ZRANGEBYSCORE":    true,
+		"ZRANK":            true,
+		"ZREVRANGE":        true,
+		"ZREVRANGEBYSCORE": true,
+		"ZREVRANK":         true,
+		"ZSCAN":            true,
+		"ZSCORE":           true,
+	}
+
+	_ db.Driver = (*Driver)(nil)
+)
+
+func init() {
+	db.Register(db.Redis, newDriver)
+}
+
+// Driver is the Redis driver.
+// The databases are the logical database indexes of a standalone Redis server, and Redis Cluster isn't supported.
+type Driver struct {
+	connectionCtx db.ConnectionContext
+	config        db.ConnectionConfig
+	options       *goredis.Options
+
+	// clients are the clients of the logical databases, because the SELECT command changes the state of a pooled connection.
+	clients       map[int]*goredis.Client
+	databaseIndex int
+}
+
+func newDriver(config db.DriverConfig) db.Driver {
+	return &Driver{}
+}
+
+// Open opens a Redis driver.
+func (driver *Driver) Open(ctx context.Context, dbType db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+	databaseIndex, err := parseDatabaseIndex(config.Database)
+	if err != nil {
+		return nil, err
+	}
+	port := config.Port
+	if port == "" {
+		port = "6379"
+	}
+	addr := fmt.Sprintf("%s:%s", config.Host, port)
+	// Set SSL configuration.
+	tlsConfig, err := config.TLSConfig.GetSslConfig()
+	if err != nil {
+		return nil, fmt.Errorf("redis: tls config error: %v", err)
+	}
+
+	log.Debug("Opening Redis driver",
+		zap.String("addr", addr),
+		zap.String("environment", connCtx.EnvironmentName),
+		zap.String("database", connCtx.InstanceName),
+	)
+	driver.connectionCtx = connCtx
+	driver.config = config
+	driver.options = &goredis.Options{
+		Addr: addr,
+		// Username is only used for Redis 6.0+ ACL.
+		Username:  config.Username,
+		Password:  config.Password,
+		TLSConfig: tlsConfig,
+	}
+	driver.clients = make(map[int]*goredis.Client)
+	driver.databaseIndex = databaseIndex
+	return driver, nil
+}
+
+// Close closes the driver.
+func (driver *Driver) Close(ctx context.Context) error {
+	var closeErr error
+	for _, client := range driver.clients {
+		if err := client.Close(); err != nil {
+			closeErr = err
+		}
+	}
+	return closeErr
+}
+
+// Ping pings the database.
+func (driver *Driver) Ping(ctx context.Context) error {
+	return driver.getClient(driver.databaseIndex).Ping(ctx).Err()
+}
+
+// GetDbConnection gets a database connection.
+// Redis isn't a database/sql database, so it returns error.
+func (driver *Driver) GetDbConnection(ctx context.Context, database string) (*sql.DB, error) {
+	return nil, fmt.Errorf("Redis doesn't support database/sql connection")
+}
+
+// GetVersion gets the version.
+func (driver *Driver) GetVersion(ctx context.Context) (string, error) {
+	info, err := driver.getClient(0).Info(ctx, "server").Result()
+	if err != nil {
+		return "", err
+	}
+	if version, ok := parseInfo(info)["redis_version"]; ok {
+		return version, nil
+	}
+	return "", fmt.Errorf("failed to find redis_version in the server info")
+}
+
+// getClient returns the client of the logical database.
+func (driver *Driver) getClient(databaseIndex int) *goredis.Client {
+	if client, ok := driver.clients[databaseIndex]; ok {
+		return client
+	}
+	options := *driver.options
+	options.DB = databaseIndex
+	client := goredis.NewClient(&options)
+	driver.clients[databaseIndex] = client
+	return client
+}
+
+// Execute executes the commands, one command per line.
+// The consecutive commands are executed in a MULTI/EXEC transaction against the current database, and the SELECT command
+// switches the database for the following commands. Redis doesn't roll back the transaction on a runtime error of a command.
+func (driver *Driver) Execute(ctx context.Context, statement string) error {
+	commands, err := splitCommands(statement)
+	if err != nil {
+		return err
+	}
+
+	databaseIndex := driver.databaseIndex
+	var remainingCommands [][]string
+	flush := func() error {
+		if len(remainingCommands) == 0 {
+			return nil
+		}
+		if err := driver.executeCommands(ctx, databaseIndex, remainingCommands); err != nil {
+			return err
+		}
+		remainingCommands = nil
+		return nil
+	}
+
+	for _, command := range commands {
+		if strings.ToUpper(command[0]) == "SELECT" {
+			if len(command) != 2 {
+				return fmt.Errorf("invalid SELECT command %q", formatCommand(command))
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+			if databaseIndex, err = parseDatabaseIndex(command[1]); err != nil {
+				return err
+			}
+			continue
+		}
+		remainingCommands = append(remainingCommands, command)
+	}
+	return flush()
+}
+
+func (driver *Driver) executeCommands(ctx context.Context, databaseIndex int, commands [][]string) error {
+	cmds, err := driver.getClient(databaseIndex).TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		for _, command := range commands {
+			pipe.Do(ctx, toArgs(command)...)
+		}
+		return nil
+	})
+	if err != nil {
+		// Find the failed command for the error message.
+		for i, cmd := range cmds {
+			if cmd.Err() != nil && i < len(commands) {
+				return fmt.Errorf("failed to execute command %q, error: %w", formatCommand(commands[i]), cmd.Err())
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// Query queries a read-only command.
+// The elements of an array reply are returned as rows up to the limit, and the other replies are returned as a single row.
+func (driver *Driver) Query(ctx context.Context, statement string, limit int) ([]interface{}, error) {
+	commands, err := splitCommands(statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(commands) != 1 {
+		return nil, fmt.Errorf("Redis only supports querying a single command, got %d commands", len(commands))
+	}
+	command := commands[0]
+	name := strings.ToUpper(command[0])
+	// KEYS is O(N) and blocks the server on large keyspaces, and the limit is only applied after the full reply arrives.
+	if name == "KEYS" {
+		return nil, fmt.Errorf("Redis doesn't support KEYS in query, which blocks the server on large keyspaces, use SCAN 0 MATCH <pattern> COUNT <count> instead")
+	}
+	if !readOnlyCommands[name] {
+		return nil, fmt.Errorf("Redis only supports read-only commands like GET in query, got %q", command[0])
+	}
+	command = withScanCount(command, limit)
+
+	result, err := driver.getClient(driver.databaseIndex).Do(ctx, toArgs(command)...).Result()
+	if err != nil && err != goredis.Nil {
+		return nil, err
+	}
+
+	var values []interface{}
+	if array, ok := result.([]interface{}); ok {
+		values = array
+	} else {
+		values = []interface{}{result}
+	}
+	columnType := "STRING"
+	data := []interface{}{}
+	for _, value := range values {
+		switch v := value.(type) {
+		case int64:
+			columnType = "INT"
+		case []interface{}:
+			// The nested array, e.g. the keys of SCAN, is presented in JSON.
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			value = string(b)
+		}
+		data = append(data, []interface{}{value})
+		if len(data) == limit {
+			break
+		}
+	}
+
+	return []interface{}{[]string{"value"}, []string{columnType}, data}, nil
+}
+
+// withScanCount appends the COUNT hint derived from the limit to the SCAN family commands without one, so each call
+// returns a page of about the limit instead of the default 10 elements.
+func withScanCount(command []string, limit int) []string {
+	if limit <= 0 {
+		return command
+	}
+	// The options follow the cursor of SCAN, and follow the key and the cursor of HSCAN, SSCAN and ZSCAN.
+	optionIndex := 3
+	switch strings.ToUpper(command[0]) {
+	case "SCAN":
+		optionIndex = 2
+	case "HSCAN", "SSCAN", "ZSCAN":
+	default:
+		return command
+	}
+	for i := optionIndex; i < len(command); i++ {
+		if strings.ToUpper(command[i]) == "COUNT" {
+			return command
+		}
+	}
+	return append(command, "COUNT", strconv.Itoa(limit))
+}
+
+// parseDatabaseIndex parses the logical database index, and the empty database is the default database 0.
+func parseDatabaseIndex(database string) (int, error) {
+	if database == "" {
+		return 0, nil
+	}
+	index, err := strconv.Atoi(database)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("Redis database should be the logical database index, got %q", database)
+	}
+	return index, nil
+}
+
+// parseInfo parses the reply of the INFO command into key-value pairs.
+func parseInfo(info string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if idx := strings.Index(line, ":"); idx > 0 {
+			result[line[:idx]] = line[idx+1:]
+		}
+	}
+	return result
+}
+
+func toArgs(command []string) []interface{} {
+	var args []interface{}
+	for _, arg := range command {
+		args = append(args, arg)
+	}
+	return args
+}
+
+// splitCommands splits the statement into commands, one command per line. Empty lines and the comment lines starting with "#" are skipped.
+func splitCommands(statement string) ([][]string, error) {
+	var commands [][]string
+	for i, line := range strings.Split(statement, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args, err := splitArgs(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid command at line %d, error: %w", i+1, err)
+		}
+		if len(args) > 0 {
+			commands = append(commands, args)
+		}
+	}
+	return commands, nil
+}
+
+// splitArgs splits the line into arguments in the same way as redis-cli.
+// The double-quoted argument supports the escape sequences such as \n and \xHH, and the single-quoted argument only supports \'.
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	i, n := 0, len(line)
+	for {
+		for i < n && isSpace(line[i]) {
+			i++
+		}
+		if i >= n {
+			return args, nil
+		}
+
+		var current []byte
+		inDoubleQuotes, inSingleQuotes, done := false, false, false
+		for !done {
+			switch {
+			case inDoubleQuotes:
+				if i >= n {
+					return nil, fmt.Errorf("unbalanced double quotes")
+				}
+				switch {
+				case line[i] == '\\' && i+3 < n && line[i+1] == 'x' && isHexDigit(line[i+2]) && isHexDigit(line[i+3]):
+					b, _ := strconv.ParseUint(line[i+2:i+4], 16, 8)
+					current = append(current, byte(b))
+					i += 3
+				case line[i] == '\\' && i+1 < n:
+					i++
+					switch line[i] {
+					case 'n':
+						current = append(current, '\n')
+					case 'r':
+						current = append(current, '\r')
+					case 't':
+						current = append(current, '\t')
+					case 'b':
+						current = append(current, '\b')
+					case 'a':
+						current = append(current, '\a')
+					default:
+						current = append(current, line[i])
+					}
+				case line[i] == '"':
+					// The closing quote must be followed by a space or nothing at all.
+					if i+1 < n && !isSpace(line[i+1]) {
+						return nil, fmt.Errorf("closing double quote must be followed by a space")
+					}
+					done = true
+				default:
+					current = append(current, line[i])
+				}
+			case inSingleQuotes:
+				if i >= n {
+					return nil, fmt.Errorf("unbalanced single quotes")
+				}
+				switch {
+				case line[i] == '\\' && i+1 < n && line[i+1] == '\'':
+					i++
+					current = append(current, '\'')
+				case line[i] == '\'':
+					if i+1 < n && !isSpace(line[i+1]) {
+						return nil, fmt.Errorf("closing single quote must be followed by a space")
+					}
+					done = true
+				default:
+					current = append(current, line[i])
+				}
+			default:
+				if i >= n {
+					done = true
+					break
+				}
+				switch line[i] {
+				case ' ', '\t', '\r', '\n', '\v', '\f':
+					done = true
+				case '"':
+					inDoubleQuotes = true
+				case '\'':
+					inSingleQuotes = true
+				default:
+					current = append(current, line[i])
+				}
+			}
+			if i < n {
+				i++
+			}
+		}
+		args = append(args, string(current))
+	}
+}
+
+// quoteArg quotes the argument in double quotes, and the non-printable bytes are escaped as \xHH.
+func quoteArg(arg string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(arg); i++ {
+		c := arg[i]
+		switch c {
+		case '\\', '"':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\a':
+			sb.WriteString(`\a`)
+		case '\b':
+			sb.WriteString(`\b`)
+		default:
+			if c >= 0x20 && c < 0x7f {
+				sb.WriteByte(c)
+			} else {
+				sb.WriteString(fmt.Sprintf(`\x%02x`, c))
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// formatCommand formats the command with the quoted arguments.
+func formatCommand(command []string) string {
+	parts := []string{command[0]}
+	for _, arg := range command[1:] {
+		parts = append(parts, quoteArg(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\v' || c == '\f'
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,147 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCommands(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      [][]string
+		wantErr   bool
+	}{
+		{
+			statement: "SET foo bar\n\n# comment\nHSET user:1 name \"Alice Smith\" age 20",
+			want: [][]string{
+				{"SET", "foo", "bar"},
+				{"HSET", "user:1", "name", "Alice Smith", "age", "20"},
+			},
+		},
+		{
+			statement: `SET key "line\nbreak \"quoted\" \x41\xff"`,
+			want: [][]string{
+				{"SET", "key", "line\nbreak \"quoted\" A\xff"},
+			},
+		},
+		{
+			statement: `SET key 'it\'s \n raw' ""`,
+			want: [][]string{
+				{"SET", "key", `it's \n raw`, ""},
+			},
+		},
+		{
+			statement: "  SELECT 1  \r\nDEL a b\r\n",
+			want: [][]string{
+				{"SELECT", "1"},
+				{"DEL", "a", "b"},
+			},
+		},
+		{
+			statement: `SET key "unbalanced`,
+			wantErr:   true,
+		},
+		{
+			statement: `SET key "a"b`,
+			wantErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		commands, err := splitCommands(test.statement)
+		if test.wantErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.want, commands)
+	}
+}
+
+func TestFormatCommand(t *testing.T) {
+	commands := [][]string{
+		{"SET", "foo", "bar"},
+		{"HSET", "user:1", "name", "Alice \"Al\" Smith", "bio", "a\\b\nc\td"},
+		{"SET", "binary", "\x00\x01\xff中"},
+		{"RPUSH", "list", ""},
+	}
+	for _, command := range commands {
+		formatted := formatCommand(command)
+		got, err := splitCommands(formatted)
+		require.NoError(t, err)
+		require.Equal(t, [][]string{command}, got, formatted)
+	}
+	require.Equal(t, `SET "key" "a\"b\n\x00"`, formatCommand([]string{"SET", "key", "a\"b\n\x00"}))
+}
+
+func TestWithScanCount(t *testing.T) {
+	tests := []struct {
+		command []string
+		limit   int
+		want    []string
+	}{
+		{
+			command: []string{"scan", "0", "MATCH", "user:*"},
+			limit:   100,
+			want:    []string{"scan", "0", "MATCH", "user:*", "COUNT", "100"},
+		},
+		{
+			command: []string{"SCAN", "0", "count", "10"},
+			limit:   100,
+			want:    []string{"SCAN", "0", "count", "10"},
+		},
+		{
+			// The key named "count" is not the COUNT option.
+			command: []string{"HSCAN", "count", "0"},
+			limit:   100,
+			want:    []string{"HSCAN", "count", "0", "COUNT", "100"},
+		},
+		{
+			command: []string{"SCAN", "0"},
+			limit:   0,
+			want:    []string{"SCAN", "0"},
+		},
+		{
+			command: []string{"GET", "foo"},
+			limit:   100,
+			want:    []string{"GET", "foo"},
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, withScanCount(test.command, test.limit))
+	}
+}
+
+func TestParseDatabaseIndex(t *testing.T) {
+	tests := []struct {
+		database string
+		want     int
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"15", 15, false},
+		{"-1", 0, true},
+		{"db1", 0, true},
+	}
+
+	for _, test := range tests {
+		index, err := parseDatabaseIndex(test.database)
+		if test.wantErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.want, index)
+	}
+}
+
+func TestParseInfo(t *testing.T) {
+	info := "# Server\r\nredis_version:6.2.6\r\nredis_mode:standalone\r\n\r\n# Keyspace\r\ndb0:keys=1,expires=0,avg_ttl=0\r\n"
+	result := parseInfo(info)
+	require.Equal(t, "6.2.6", result["redis_version"])
+	require.Equal(t, "standalone", result["redis_mode"])
+	require.Equal(t, "keys=1,expires=0,avg_ttl=0", result["db0"])
+}
@@ -0,0 +1,229 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/youzi-1122/bytebase/plugin/db"
+)
+
+const (
+	// defaultDatabaseCount is the default number of logical databases of a Redis server.
+	defaultDatabaseCount = 16
+	// maxScanKeyCount is the maximum number of keys to scan in a database when syncing the schema.
+	// For larger databases, the key counts and memory are estimated from the scanned keys.
+	maxScanKeyCount = 10000
+	// scanBatchSize is the COUNT hint of the SCAN command.
+	scanBatchSize = 1000
+)
+
+// SyncInstance syncs the instance.
+func (driver *Driver) SyncInstance(ctx context.Context) (*db.InstanceMeta, error) {
+	userList, err := driver.getUserList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var databaseList []db.DatabaseMeta
+	for _, database := range databases {
+		databaseList = append(
+			databaseList,
+			db.DatabaseMeta{
+				Name: database,
+			},
+		)
+	}
+
+	return &db.InstanceMeta{
+		UserList:     userList,
+		DatabaseList: databaseList,
+	}, nil
+}
+
+// SyncSchema synces the schema.
+// Redis has no schema, so each key type of a database is reported as a table with the key count and memory.
+func (driver *Driver) SyncSchema(ctx context.Context, databaseList ...string) ([]*db.Schema, error) {
+	databases, err := driver.getDatabases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaList []*db.Schema
+	for _, database := range databases {
+		if len(databaseList) != 0 {
+			exists := false
+			for _, k := range databaseList {
+				if database == k {
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				continue
+			}
+		}
+
+		databaseIndex, err := parseDatabaseIndex(database)
+		if err != nil {
+			return nil, err
+		}
+		tableList, err := driver.syncKeyspace(ctx, databaseIndex)
+		if err != nil {
+			return nil, err
+		}
+		schemaList = append(schemaList, &db.Schema{
+			Name:      database,
+			TableList: tableList,
+		})
+	}
+
+	return schemaList, nil
+}
+
+// getDatabases returns the logical database indexes.
+func (driver *Driver) getDatabases(ctx context.Context) ([]string, error) {
+	count := defaultDatabaseCount
+	// CONFIG could be disabled by the managed Redis services, so we fall back to the default count.
+	if result, err := driver.getClient(0).ConfigGet(ctx, "databases").Result(); err == nil && len(result) == 2 {
+		if v, err := strconv.Atoi(fmt.Sprintf("%v", result[1])); err == nil && v > 0 {
+			count = v
+		}
+	}
+
+	var databases []string
+	for i := 0; i < count; i++ {
+		databases = append(databases, strconv.Itoa(i))
+	}
+	return databases, nil
+}
+
+// getUserList returns the ACL users for Redis 6.0+, or the default user for the older versions.
+func (driver *Driver) getUserList(ctx context.Context) ([]db.User, error) {
+	rules, err := driver.getClient(0).Do(ctx, "ACL", "LIST").StringSlice()
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "unknown command") {
+			return []db.User{{Name: "default"}}, nil
+		}
+		return nil, fmt.Errorf("failed to get users, error: %w", err)
+	}
+
+	var userList []db.User
+	for _, rule := range rules {
+		// The rule is in the form of "user <name> <rules>...".
+		fields := strings.SplitN(rule, " ", 3)
+		if len(fields) < 2 || fields[0] != "user" {
+			continue
+		}
+		user := db.User{Name: fields[1]}
+		if len(fields) == 3 {
+			user.Grant = fields[2]
+		}
+		userList = append(userList, user)
+	}
+	return userList, nil
+}
+
+// keyTypeStat is the statistic of a key type.
+type keyTypeStat struct {
+	count  int64
+	memory int64
+}
+
+// syncKeyspace returns the key count and memory of each key type in the database.
+func (driver *Driver) syncKeyspace(ctx context.Context, databaseIndex int) ([]db.Table, error) {
+	client := driver.getClient(databaseIndex)
+	dbSize, err := client.DBSize(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*keyTypeStat)
+	var scanned int64
+	var cursor uint64
+	for {
+		var keys []string
+		keys, cursor, err = client.Scan(ctx, cursor, "", scanBatchSize).Result()
+		if err != nil {
+			return nil, err
+		}
+		if err := collectKeyTypeStats(ctx, client, keys, stats); err != nil {
+			return nil, err
+		}
+		scanned += int64(len(keys))
+		if cursor == 0 || scanned >= maxScanKeyCount {
+			break
+		}
+	}
+
+	// Estimate the statistics from the scanned keys if the scan is incomplete.
+	ratio := float64(1)
+	if cursor != 0 && scanned > 0 {
+		ratio = float64(dbSize) / float64(scanned)
+	}
+
+	var tableList []db.Table
+	for keyType, stat := range stats {
+		tableList = append(tableList, db.Table{
+			Name:     keyType,
+			Type:     "KEY",
+			RowCount: int64(float64(stat.count) * ratio),
+			DataSize: int64(float64(stat.memory) * ratio),
+		})
+	}
+	sort.Slice(tableList, func(i, j int) bool {
+		return tableList[i].Name < tableList[j].Name
+	})
+	return tableList, nil
+}
+
+// collectKeyTypeStats adds the type and memory of the keys to the statistics. The migration history keys are excluded.
+func collectKeyTypeStats(ctx context.Context, client *goredis.Client, keys []string, stats map[string]*keyTypeStat) error {
+	var userKeys []string
+	for _, key := range keys {
+		if !strings.HasPrefix(key, migrationHistoryKeyPrefix) {
+			userKeys = append(userKeys, key)
+		}
+	}
+	if len(userKeys) == 0 {
+		return nil
+	}
+
+	pipe := client.Pipeline()
+	typeCmds := make([]*goredis.StatusCmd, len(userKeys))
+	memoryCmds := make([]*goredis.IntCmd, len(userKeys))
+	for i, key := range userKeys {
+		typeCmds[i] = pipe.Type(ctx, key)
+		memoryCmds[i] = pipe.MemoryUsage(ctx, key)
+	}
+	// MEMORY USAGE isn't supported before Redis 4.0, and a key could be deleted after scanning, so we check the errors of each command instead.
+	_, _ = pipe.Exec(ctx)
+
+	for i := range userKeys {
+		keyType, err := typeCmds[i].Result()
+		if err != nil {
+			return err
+		}
+		if keyType == "none" {
+			continue
+		}
+		stat, ok := stats[keyType]
+		if !ok {
+			stat = &keyTypeStat{}
+			stats[keyType] = stat
+		}
+		stat.count++
+		if memory, err := memoryCmds[i].Result(); err == nil {
+			stat.memory += memory
+		}
+	}
+	return nil
+}
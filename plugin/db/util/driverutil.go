@@ -198,34 +198,10 @@ func BeginMigration(ctx context.Context, executor MigrationExecutor, m *db.Migra
 		return 0, fmt.Errorf("failed to convert to stored version, error %w", err)
 	}
 	// Phase 1 - Pre-check before executing migration
-	// Check if the same migration version has already been applied.
-	if list, err := executor.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{
-		Database: &m.Namespace,
-		Version:  &m.Version,
-	}); err != nil {
-		return -1, fmt.Errorf("check duplicate version error: %q", err)
-	} else if len(list) > 0 {
-		switch list[0].Status {
-		case db.Done:
-			return -1, common.Errorf(common.MigrationAlreadyApplied,
-				fmt.Errorf("database %q has already applied version %s", m.Database, m.Version))
-		case db.Pending:
-			err := fmt.Errorf("database %q version %s migration is already in progress", m.Database, m.Version)
-			log.Debug(err.Error())
-			// For force migration, we will ignore the existing migration history and continue to migration.
-			if m.Force {
-				return int64(list[0].ID), nil
-			}
-			return -1, common.Errorf(common.MigrationPending, err)
-		case db.Failed:
-			err := fmt.Errorf("database %q version %s migration has failed, please check your database to make sure things are fine and then start a new migration using a new version ", m.Database, m.Version)
-			log.Debug(err.Error())
-			// For force migration, we will ignore the existing migration history and continue to migration.
-			if m.Force {
-				return int64(list[0].ID), nil
-			}
-			return -1, common.Errorf(common.MigrationFailed, err)
-		}
+	if existingID, err := PreCheckMigration(ctx, executor, m); err != nil {
+		return -1, err
+	} else if existingID >= 0 {
+		return existingID, nil
 	}
 
 	sqldb, err := executor.GetDbConnection(ctx, databaseName)
@@ -267,6 +243,41 @@ func BeginMigration(ctx context.Context, executor MigrationExecutor, m *db.Migra
 	return insertedID, nil
 }
 
+// PreCheckMigration checks if the same migration version has already been applied.
+// It returns the ID of the existing migration history if a PENDING or FAILED version is forced to migrate again, otherwise it returns -1.
+func PreCheckMigration(ctx context.Context, driver db.Driver, m *db.MigrationInfo) (int64, error) {
+	// Check if the same migration version has already been applied.
+	if list, err := driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{
+		Database: &m.Namespace,
+		Version:  &m.Version,
+	}); err != nil {
+		return -1, fmt.Errorf("check duplicate version error: %q", err)
+	} else if len(list) > 0 {
+		switch list[0].Status {
+		case db.Done:
+			return -1, common.Errorf(common.MigrationAlreadyApplied,
+				fmt.Errorf("database %q has already applied version %s", m.Database, m.Version))
+		case db.Pending:
+			err := fmt.Errorf("database %q version %s migration is already in progress", m.Database, m.Version)
+			log.Debug(err.Error())
+			// For force migration, we will ignore the existing migration history and continue to migration.
+			if m.Force {
+				return int64(list[0].ID), nil
+			}
+			return -1, common.Errorf(common.MigrationPending, err)
+		case db.Failed:
+			err := fmt.Errorf("database %q version %s migration has failed, please check your database to make sure things are fine and then start a new migration using a new version ", m.Database, m.Version)
+			log.Debug(err.Error())
+			// For force migration, we will ignore the existing migration history and continue to migration.
+			if m.Force {
+				return int64(list[0].ID), nil
+			}
+			return -1, common.Errorf(common.MigrationFailed, err)
+		}
+	}
+	return -1, nil
+}
+
 // EndMigration updates the migration history record to DONE or FAILED depending on migration is done or not.
 func EndMigration(ctx context.Context, executor MigrationExecutor, startedNs int64, migrationHistoryID int64, updatedSchema string, databaseName string, isDone bool) (err error) {
 	migrationDurationNs := time.Now().UnixNano() - startedNs
@@ -309,6 +320,12 @@ func Query(ctx context.Context, sqldb *sql.DB, statement string, limit int) ([]i
 	}
 	defer tx.Rollback()
 
+	return QueryTx(ctx, tx, statement, limit)
+}
+
+// QueryTx will execute a readonly / SELECT query in the given transaction.
+// It's used by the engines whose driver doesn't support ReadOnly transaction, and the caller should roll back the transaction.
+func QueryTx(ctx context.Context, tx *sql.Tx, statement string, limit int) ([]interface{}, error) {
 	rows, err := tx.QueryContext(ctx, statement)
 	if err != nil {
 		return nil, FormatErrorWithQuery(err, statement)
@@ -446,7 +463,7 @@ func FindMigrationHistoryList(ctx context.Context, findMigrationHistoryListQuery
 			return nil, err
 		}
 
-		useSemanticVersion, version, semanticVersionSuffix, err := fromStoredVersion(storedVersion)
+		useSemanticVersion, version, semanticVersionSuffix, err := FromStoredVersion(storedVersion)
 		if err != nil {
 			return nil, err
 		}
@@ -500,8 +517,8 @@ func ToStoredVersion(useSemanticVersion bool, version, semanticVersionSuffix str
 	return fmt.Sprintf("%04s.%04s.%04s-%s", major, minor, patch, semanticVersionSuffix), nil
 }
 
-// fromStoredVersion converts stored version to semantic or non-semantic version.
-func fromStoredVersion(storedVersion string) (bool, string, string, error) {
+// FromStoredVersion converts stored version to semantic or non-semantic version.
+func FromStoredVersion(storedVersion string) (bool, string, string, error) {
 	if strings.HasPrefix(storedVersion, NonSemanticPrefix) {
 		return false, strings.TrimPrefix(storedVersion, NonSemanticPrefix), "", nil
 	}
@@ -0,0 +1,13 @@
+package ast
+
+// AlterColumnTypeStmt is the struct for alter column type statement.
+// For PostgreSQL dialect is ALTER TABLE ALTER COLUMN TYPE.
+type AlterColumnTypeStmt struct {
+	node
+
+	Table      *TableDef
+	ColumnName string
+	Type       *DataTypeDef
+	// Collation is the collation in the COLLATE clause, empty if not specified.
+	Collation string
+}
@@ -4,6 +4,9 @@ package ast
 type ColumnDef struct {
 	node
 
-	ColumnName     string
+	ColumnName string
+	Type       *DataTypeDef
+	// Collation is the collation in the COLLATE clause, empty if not specified.
+	Collation      string
 	ConstraintList []*ConstraintDef
 }
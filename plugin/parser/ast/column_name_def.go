@@ -0,0 +1,11 @@
+package ast
+
+// ColumnNameDef is the struct for the column name in the expression.
+type ColumnNameDef struct {
+	expression
+
+	// Table is nil if the column name doesn't contain the table name.
+	Table *TableDef
+	// ColumnName is "*" for all columns.
+	ColumnName string
+}
@@ -0,0 +1,27 @@
+package ast
+
+// CommentObjectType is the type of the object to comment.
+type CommentObjectType int
+
+const (
+	// CommentObjectTypeUndefined is the object type not supported yet.
+	CommentObjectTypeUndefined CommentObjectType = iota
+	// CommentObjectTypeTable is the table object type.
+	CommentObjectTypeTable
+	// CommentObjectTypeColumn is the column object type.
+	CommentObjectTypeColumn
+)
+
+// CommentStmt is the struct for comment statement.
+// For PostgreSQL dialect is COMMENT ON TABLE/COLUMN ... IS ....
+type CommentStmt struct {
+	node
+
+	Type CommentObjectType
+	// Table is the commented table, or the table of the commented column.
+	Table *TableDef
+	// ColumnName is a CommentObjectTypeColumn type specific field.
+	ColumnName string
+	// Comment is empty for COMMENT ON ... IS NULL, which drops the comment.
+	Comment string
+}
@@ -18,6 +18,14 @@ const (
 	// ConstraintTypeUniqueUsingIndex is the unique constraint only for the PostgreSQL table_constraint_using_index.
 	// See https://www.postgresql.org/docs/current/sql-altertable.html.
 	ConstraintTypeUniqueUsingIndex
+	// ConstraintTypeNotNull is the not null constraint, which is a column constraint.
+	ConstraintTypeNotNull
+	// ConstraintTypeDefault is the default value constraint, which is a column constraint.
+	ConstraintTypeDefault
+	// ConstraintTypeCheck is the check constraint.
+	ConstraintTypeCheck
+	// ConstraintTypeIdentity is the GENERATED ... AS IDENTITY constraint, which is a column constraint.
+	ConstraintTypeIdentity
 )
 
 // ConstraintDef is struct for constraint definition.
@@ -0,0 +1,12 @@
+package ast
+
+// CreateDatabaseStmt is the struct for create database statement.
+type CreateDatabaseStmt struct {
+	node
+
+	DatabaseName string
+	// Encoding is the character set encoding, empty if not specified.
+	Encoding string
+	// Collation is the collation (LC_COLLATE), empty if not specified.
+	Collation string
+}
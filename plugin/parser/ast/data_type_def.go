@@ -0,0 +1,17 @@
+package ast
+
+// DataTypeDef is the struct for the column data type.
+type DataTypeDef struct {
+	node
+
+	// Name is the type name. The built-in type uses the internal name of PostgreSQL,
+	// e.g. "int4" for INTEGER, "varchar" for CHARACTER VARYING and "float8" for DOUBLE PRECISION.
+	// The serial types keep their names, e.g. "serial" and "bigserial".
+	Name string
+	// Schema is the schema of the type, it's "pg_catalog" for the most built-in types.
+	Schema string
+	// ModifierList is the integer type modifiers, e.g. [255] for VARCHAR(255) and [10, 2] for NUMERIC(10, 2).
+	ModifierList []int
+	// IsArray is true if the type is an array type, e.g. INTEGER[].
+	IsArray bool
+}
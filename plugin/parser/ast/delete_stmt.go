@@ -0,0 +1,10 @@
+package ast
+
+// DeleteStmt is the struct for delete statement.
+type DeleteStmt struct {
+	node
+
+	Table *TableDef
+	// WhereClause is nil if there is no WHERE clause.
+	WhereClause ExpressionNode
+}
@@ -0,0 +1,10 @@
+package ast
+
+// DropNotNullStmt is the struct for drop not null statement.
+// For PostgreSQL dialect is the ALTER TABLE ALTER COLUMN DROP NOT NULL.
+type DropNotNullStmt struct {
+	node
+
+	Table      *TableDef
+	ColumnName string
+}
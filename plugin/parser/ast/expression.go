@@ -0,0 +1,14 @@
+package ast
+
+// ExpressionNode is the interface for expression.
+type ExpressionNode interface {
+	Node
+	expressionNode()
+}
+
+// expression is the base struct for all ExpressionNode.
+type expression struct {
+	node
+}
+
+func (*expression) expressionNode() {}
@@ -0,0 +1,10 @@
+package ast
+
+// InsertStmt is the struct for insert statement.
+type InsertStmt struct {
+	node
+
+	Table *TableDef
+	// Select is the select statement for INSERT ... SELECT, and it's nil for INSERT ... VALUES.
+	Select *SelectStmt
+}
@@ -0,0 +1,13 @@
+package ast
+
+// PatternLikeDef is the struct for the LIKE expression.
+type PatternLikeDef struct {
+	expression
+
+	// Not is true for NOT LIKE.
+	Not bool
+	// CaseInsensitive is true for the PostgreSQL ILIKE.
+	CaseInsensitive bool
+	Expression      ExpressionNode
+	Pattern         ExpressionNode
+}
@@ -0,0 +1,29 @@
+package ast
+
+// SetOperationType is the type for set operations.
+type SetOperationType int
+
+const (
+	// SetOperationTypeNone is the type for the select statement without set operations.
+	SetOperationTypeNone SetOperationType = iota
+	// SetOperationTypeUnion is the type for UNION.
+	SetOperationTypeUnion
+	// SetOperationTypeIntersect is the type for INTERSECT.
+	SetOperationTypeIntersect
+	// SetOperationTypeExcept is the type for EXCEPT.
+	SetOperationTypeExcept
+)
+
+// SelectStmt is the struct for select statement.
+// For the set operations, only the LQuery and RQuery are set.
+type SelectStmt struct {
+	node
+
+	SetOperation SetOperationType
+	LQuery       *SelectStmt
+	RQuery       *SelectStmt
+
+	FieldList []ExpressionNode
+	// WhereClause is nil if there is no WHERE clause.
+	WhereClause ExpressionNode
+}
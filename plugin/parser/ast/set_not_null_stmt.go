@@ -0,0 +1,10 @@
+package ast
+
+// SetNotNullStmt is the struct for set not null statement.
+// For PostgreSQL dialect is the ALTER TABLE ALTER COLUMN SET NOT NULL.
+type SetNotNullStmt struct {
+	node
+
+	Table      *TableDef
+	ColumnName string
+}
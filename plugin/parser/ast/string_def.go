@@ -0,0 +1,8 @@
+package ast
+
+// StringDef is the struct for the string constant.
+type StringDef struct {
+	expression
+
+	Value string
+}
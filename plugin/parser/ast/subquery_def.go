@@ -0,0 +1,8 @@
+package ast
+
+// SubqueryDef is the struct for the subquery in the expression.
+type SubqueryDef struct {
+	expression
+
+	Select *SelectStmt
+}
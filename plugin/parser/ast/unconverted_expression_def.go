@@ -0,0 +1,9 @@
+package ast
+
+// UnconvertedExpressionDef is the struct for the expressions we don't convert yet, such as the AND/OR expressions and the function calls.
+// It keeps the converted operands and arguments in ExpressionList, so we can still walk the expressions in it.
+type UnconvertedExpressionDef struct {
+	expression
+
+	ExpressionList []ExpressionNode
+}
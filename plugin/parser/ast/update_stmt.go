@@ -0,0 +1,10 @@
+package ast
+
+// UpdateStmt is the struct for update statement.
+type UpdateStmt struct {
+	node
+
+	Table *TableDef
+	// WhereClause is nil if there is no WHERE clause.
+	WhereClause ExpressionNode
+}
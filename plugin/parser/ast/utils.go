@@ -26,6 +26,13 @@ func Walk(v Visitor, node Node) {
 		if n.Constraint != nil {
 			Walk(v, n.Constraint)
 		}
+	case *AlterColumnTypeStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+		if n.Type != nil {
+			Walk(v, n.Type)
+		}
 	case *AlterTableStmt:
 		if n.Table != nil {
 			Walk(v, n.Table)
@@ -41,6 +48,17 @@ func Walk(v Visitor, node Node) {
 			Walk(v, n.Column)
 		}
 	case *ColumnDef:
+		if n.Type != nil {
+			Walk(v, n.Type)
+		}
+	case *ColumnNameDef:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+	case *CommentStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
 	case *ConstraintDef:
 		if n.Foreign != nil {
 			Walk(v, n.Foreign)
@@ -55,6 +73,15 @@ func Walk(v Visitor, node Node) {
 		for _, cons := range n.ConstraintList {
 			Walk(v, cons)
 		}
+	case *CreateDatabaseStmt:
+	case *DataTypeDef:
+	case *DeleteStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+		if n.WhereClause != nil {
+			Walk(v, n.WhereClause)
+		}
 	case *DropColumnStmt:
 		if n.Table != nil {
 			Walk(v, n.Table)
@@ -63,6 +90,11 @@ func Walk(v Visitor, node Node) {
 		if n.Table != nil {
 			Walk(v, n.Table)
 		}
+	case *DropNotNullStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+	case *DropDatabaseStmt:
 	case *DropIndexStmt:
 		for _, indexDef := range n.IndexList {
 			Walk(v, indexDef)
@@ -83,6 +115,20 @@ func Walk(v Visitor, node Node) {
 			Walk(v, keyDef)
 		}
 	case *IndexKeyDef:
+	case *InsertStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+		if n.Select != nil {
+			Walk(v, n.Select)
+		}
+	case *PatternLikeDef:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+		if n.Pattern != nil {
+			Walk(v, n.Pattern)
+		}
 	case *RenameColumnStmt:
 		if n.Table != nil {
 			Walk(v, n.Table)
@@ -99,6 +145,39 @@ func Walk(v Visitor, node Node) {
 		if n.Table != nil {
 			Walk(v, n.Table)
 		}
+	case *SelectStmt:
+		if n.LQuery != nil {
+			Walk(v, n.LQuery)
+		}
+		if n.RQuery != nil {
+			Walk(v, n.RQuery)
+		}
+		for _, field := range n.FieldList {
+			Walk(v, field)
+		}
+		if n.WhereClause != nil {
+			Walk(v, n.WhereClause)
+		}
+	case *SetNotNullStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+	case *StringDef:
+	case *SubqueryDef:
+		if n.Select != nil {
+			Walk(v, n.Select)
+		}
 	case *TableDef:
+	case *UnconvertedExpressionDef:
+		for _, expr := range n.ExpressionList {
+			Walk(v, expr)
+		}
+	case *UpdateStmt:
+		if n.Table != nil {
+			Walk(v, n.Table)
+		}
+		if n.WhereClause != nil {
+			Walk(v, n.WhereClause)
+		}
 	}
 }
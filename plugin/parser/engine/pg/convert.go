@@ -1,6 +1,8 @@
 package pg
 
 import (
+	"strings"
+
 	"github.com/youzi-1122/bytebase/plugin/parser"
 	"github.com/youzi-1122/bytebase/plugin/parser/ast"
 	pgquery "github.com/pganalyze/pg_query_go/v2"
@@ -71,6 +73,37 @@ func convert(node *pgquery.Node, text string) (res ast.Node, err error) {
 					}
 
 					alterTable.AlterItemList = append(alterTable.AlterItemList, dropConstraint)
+				case pgquery.AlterTableType_AT_SetNotNull:
+					setNotNull := &ast.SetNotNullStmt{
+						Table:      alterTable.Table,
+						ColumnName: alterCmd.Name,
+					}
+
+					alterTable.AlterItemList = append(alterTable.AlterItemList, setNotNull)
+				case pgquery.AlterTableType_AT_DropNotNull:
+					dropNotNull := &ast.DropNotNullStmt{
+						Table:      alterTable.Table,
+						ColumnName: alterCmd.Name,
+					}
+
+					alterTable.AlterItemList = append(alterTable.AlterItemList, dropNotNull)
+				case pgquery.AlterTableType_AT_AlterColumnType:
+					def, ok := alterCmd.Def.Node.(*pgquery.Node_ColumnDef)
+					if !ok {
+						return nil, parser.NewConvertErrorf("expected ColumnDef but found %t", alterCmd.Def.Node)
+					}
+					column, err := convertColumnDef(def)
+					if err != nil {
+						return nil, err
+					}
+					alterColumnType := &ast.AlterColumnTypeStmt{
+						Table:      alterTable.Table,
+						ColumnName: alterCmd.Name,
+						Type:       column.Type,
+						Collation:  column.Collation,
+					}
+
+					alterTable.AlterItemList = append(alterTable.AlterItemList, alterColumnType)
 				}
 			}
 		}
@@ -182,11 +215,288 @@ func convert(node *pgquery.Node, text string) (res ast.Node, err error) {
 			}
 			return dropTable, nil
 		}
+	case *pgquery.Node_CreatedbStmt:
+		createDatabase := &ast.CreateDatabaseStmt{
+			DatabaseName: in.CreatedbStmt.Dbname,
+		}
+		for _, option := range in.CreatedbStmt.Options {
+			defElem, ok := option.Node.(*pgquery.Node_DefElem)
+			if !ok {
+				return nil, parser.NewConvertErrorf("expected DefElem but found %t", option.Node)
+			}
+			value, ok := defElem.DefElem.Arg.GetNode().(*pgquery.Node_String_)
+			if !ok {
+				continue
+			}
+			switch defElem.DefElem.Defname {
+			case "encoding":
+				createDatabase.Encoding = value.String_.Str
+			case "lc_collate":
+				createDatabase.Collation = value.String_.Str
+			}
+		}
+		return createDatabase, nil
+	case *pgquery.Node_CommentStmt:
+		list, ok := in.CommentStmt.Object.GetNode().(*pgquery.Node_List)
+		if !ok {
+			// Only the table and column comments are supported.
+			return nil, nil
+		}
+		comment := &ast.CommentStmt{
+			Comment: in.CommentStmt.Comment,
+		}
+		switch in.CommentStmt.Objtype {
+		case pgquery.ObjectType_OBJECT_TABLE:
+			table, err := convertListToTableDef(list)
+			if err != nil {
+				return nil, err
+			}
+			comment.Type = ast.CommentObjectTypeTable
+			comment.Table = table
+		case pgquery.ObjectType_OBJECT_COLUMN:
+			stringList, err := convertListToStringList(list)
+			if err != nil {
+				return nil, err
+			}
+			// The object is [schema.]table.column.
+			switch len(stringList) {
+			case 3:
+				comment.Table = &ast.TableDef{Schema: stringList[0], Name: stringList[1]}
+			case 2:
+				comment.Table = &ast.TableDef{Name: stringList[0]}
+			default:
+				return nil, parser.NewConvertErrorf("expected length is 2 or 3, but found %d", len(stringList))
+			}
+			comment.Type = ast.CommentObjectTypeColumn
+			comment.ColumnName = stringList[len(stringList)-1]
+		default:
+			return nil, nil
+		}
+		return comment, nil
+	case *pgquery.Node_DropdbStmt:
+		return &ast.DropDatabaseStmt{
+			DatabaseName: in.DropdbStmt.Dbname,
+			IfExists:     in.DropdbStmt.MissingOk,
+		}, nil
+	case *pgquery.Node_SelectStmt:
+		return convertSelectStmt(in.SelectStmt)
+	case *pgquery.Node_InsertStmt:
+		insert := &ast.InsertStmt{
+			Table: convertRangeVarToTableName(in.InsertStmt.Relation),
+		}
+		// The INSERT ... VALUES statement is also a SelectStmt with the values lists in pg_query.
+		if node, ok := in.InsertStmt.SelectStmt.GetNode().(*pgquery.Node_SelectStmt); ok && len(node.SelectStmt.ValuesLists) == 0 {
+			selectStmt, err := convertSelectStmt(node.SelectStmt)
+			if err != nil {
+				return nil, err
+			}
+			insert.Select = selectStmt
+		}
+		return insert, nil
+	case *pgquery.Node_UpdateStmt:
+		update := &ast.UpdateStmt{
+			Table: convertRangeVarToTableName(in.UpdateStmt.Relation),
+		}
+		if in.UpdateStmt.WhereClause != nil {
+			where, err := convertExpressionNode(in.UpdateStmt.WhereClause)
+			if err != nil {
+				return nil, err
+			}
+			update.WhereClause = where
+		}
+		return update, nil
+	case *pgquery.Node_DeleteStmt:
+		deleteStmt := &ast.DeleteStmt{
+			Table: convertRangeVarToTableName(in.DeleteStmt.Relation),
+		}
+		if in.DeleteStmt.WhereClause != nil {
+			where, err := convertExpressionNode(in.DeleteStmt.WhereClause)
+			if err != nil {
+				return nil, err
+			}
+			deleteStmt.WhereClause = where
+		}
+		return deleteStmt, nil
 	}
 
 	return nil, nil
 }
 
+func convertSelectStmt(in *pgquery.SelectStmt) (*ast.SelectStmt, error) {
+	switch in.Op {
+	case pgquery.SetOperation_SETOP_UNION, pgquery.SetOperation_SETOP_INTERSECT, pgquery.SetOperation_SETOP_EXCEPT:
+		lQuery, err := convertSelectStmt(in.Larg)
+		if err != nil {
+			return nil, err
+		}
+		rQuery, err := convertSelectStmt(in.Rarg)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.SelectStmt{
+			SetOperation: convertSetOperation(in.Op),
+			LQuery:       lQuery,
+			RQuery:       rQuery,
+		}, nil
+	}
+
+	selectStmt := &ast.SelectStmt{
+		SetOperation: ast.SetOperationTypeNone,
+	}
+	for _, target := range in.TargetList {
+		resTarget, ok := target.Node.(*pgquery.Node_ResTarget)
+		if !ok {
+			return nil, parser.NewConvertErrorf("expected ResTarget but found %T", target.Node)
+		}
+		field, err := convertExpressionNode(resTarget.ResTarget.Val)
+		if err != nil {
+			return nil, err
+		}
+		selectStmt.FieldList = append(selectStmt.FieldList, field)
+	}
+	if in.WhereClause != nil {
+		where, err := convertExpressionNode(in.WhereClause)
+		if err != nil {
+			return nil, err
+		}
+		selectStmt.WhereClause = where
+	}
+	return selectStmt, nil
+}
+
+func convertSetOperation(in pgquery.SetOperation) ast.SetOperationType {
+	switch in {
+	case pgquery.SetOperation_SETOP_UNION:
+		return ast.SetOperationTypeUnion
+	case pgquery.SetOperation_SETOP_INTERSECT:
+		return ast.SetOperationTypeIntersect
+	case pgquery.SetOperation_SETOP_EXCEPT:
+		return ast.SetOperationTypeExcept
+	}
+	return ast.SetOperationTypeNone
+}
+
+// convertExpressionNode converts the pg_query expression to ast.ExpressionNode.
+// The expressions we don't care about yet are converted to ast.UnconvertedExpressionDef with their sub-expressions.
+func convertExpressionNode(node *pgquery.Node) (ast.ExpressionNode, error) {
+	if node == nil {
+		return &ast.UnconvertedExpressionDef{}, nil
+	}
+	switch in := node.Node.(type) {
+	case *pgquery.Node_ColumnRef:
+		return convertColumnRef(in.ColumnRef)
+	case *pgquery.Node_AConst:
+		if str, ok := in.AConst.Val.GetNode().(*pgquery.Node_String_); ok {
+			return &ast.StringDef{Value: str.String_.Str}, nil
+		}
+		return &ast.UnconvertedExpressionDef{}, nil
+	case *pgquery.Node_AExpr:
+		switch in.AExpr.Kind {
+		case pgquery.A_Expr_Kind_AEXPR_LIKE, pgquery.A_Expr_Kind_AEXPR_ILIKE:
+			expr, err := convertExpressionNode(in.AExpr.Lexpr)
+			if err != nil {
+				return nil, err
+			}
+			pattern, err := convertExpressionNode(in.AExpr.Rexpr)
+			if err != nil {
+				return nil, err
+			}
+			operator, err := convertOperatorName(in.AExpr.Name)
+			if err != nil {
+				return nil, err
+			}
+			return &ast.PatternLikeDef{
+				// The operators are "~~" for LIKE, "!~~" for NOT LIKE, "~~*" for ILIKE and "!~~*" for NOT ILIKE.
+				Not:             strings.HasPrefix(operator, "!"),
+				CaseInsensitive: in.AExpr.Kind == pgquery.A_Expr_Kind_AEXPR_ILIKE,
+				Expression:      expr,
+				Pattern:         pattern,
+			}, nil
+		}
+		return convertUnconvertedExpression(in.AExpr.Lexpr, in.AExpr.Rexpr)
+	case *pgquery.Node_BoolExpr:
+		return convertUnconvertedExpression(in.BoolExpr.Args...)
+	case *pgquery.Node_FuncCall:
+		return convertUnconvertedExpression(in.FuncCall.Args...)
+	case *pgquery.Node_NullTest:
+		return convertUnconvertedExpression(in.NullTest.Arg)
+	case *pgquery.Node_TypeCast:
+		return convertUnconvertedExpression(in.TypeCast.Arg)
+	case *pgquery.Node_List:
+		return convertUnconvertedExpression(in.List.Items...)
+	case *pgquery.Node_SubLink:
+		selectNode, ok := in.SubLink.Subselect.GetNode().(*pgquery.Node_SelectStmt)
+		if !ok {
+			return nil, parser.NewConvertErrorf("expected SelectStmt but found %T", in.SubLink.Subselect.GetNode())
+		}
+		selectStmt, err := convertSelectStmt(selectNode.SelectStmt)
+		if err != nil {
+			return nil, err
+		}
+		return &ast.SubqueryDef{Select: selectStmt}, nil
+	}
+	return &ast.UnconvertedExpressionDef{}, nil
+}
+
+func convertUnconvertedExpression(nodeList ...*pgquery.Node) (*ast.UnconvertedExpressionDef, error) {
+	res := &ast.UnconvertedExpressionDef{}
+	for _, node := range nodeList {
+		if node == nil {
+			continue
+		}
+		expr, err := convertExpressionNode(node)
+		if err != nil {
+			return nil, err
+		}
+		res.ExpressionList = append(res.ExpressionList, expr)
+	}
+	return res, nil
+}
+
+func convertColumnRef(in *pgquery.ColumnRef) (*ast.ColumnNameDef, error) {
+	var nameList []string
+	for _, field := range in.Fields {
+		switch item := field.Node.(type) {
+		case *pgquery.Node_String_:
+			nameList = append(nameList, item.String_.Str)
+		case *pgquery.Node_AStar:
+			nameList = append(nameList, "*")
+		default:
+			return nil, parser.NewConvertErrorf("expected String or A_Star but found %T", field.Node)
+		}
+	}
+
+	column := &ast.ColumnNameDef{}
+	switch len(nameList) {
+	case 1:
+		column.ColumnName = nameList[0]
+	case 2:
+		column.Table = &ast.TableDef{Name: nameList[0]}
+		column.ColumnName = nameList[1]
+	case 3:
+		column.Table = &ast.TableDef{Schema: nameList[0], Name: nameList[1]}
+		column.ColumnName = nameList[2]
+	case 4:
+		column.Table = &ast.TableDef{Database: nameList[0], Schema: nameList[1], Name: nameList[2]}
+		column.ColumnName = nameList[3]
+	default:
+		return nil, parser.NewConvertErrorf("expected length is 1 to 4, but found %d", len(nameList))
+	}
+	return column, nil
+}
+
+func convertOperatorName(in []*pgquery.Node) (string, error) {
+	// The operator name could be schema-qualified, and the last one is the operator.
+	if len(in) == 0 {
+		return "", parser.NewConvertErrorf("expected operator name but found empty")
+	}
+	name, ok := in[len(in)-1].Node.(*pgquery.Node_String_)
+	if !ok {
+		return "", parser.NewConvertErrorf("expected String but found %T", in[len(in)-1].Node)
+	}
+	return name.String_.Str, nil
+}
+
 func convertListToTableDef(in *pgquery.Node_List) (*ast.TableDef, error) {
 	stringList, err := convertListToStringList(in)
 	if err != nil {
@@ -311,6 +621,14 @@ func convertConstraintType(in pgquery.ConstrType, usingIndex bool) ast.Constrain
 			return ast.ConstraintTypeUndefined
 		}
 		return ast.ConstraintTypeForeign
+	case pgquery.ConstrType_CONSTR_NOTNULL:
+		return ast.ConstraintTypeNotNull
+	case pgquery.ConstrType_CONSTR_DEFAULT:
+		return ast.ConstraintTypeDefault
+	case pgquery.ConstrType_CONSTR_CHECK:
+		return ast.ConstraintTypeCheck
+	case pgquery.ConstrType_CONSTR_IDENTITY:
+		return ast.ConstraintTypeIdentity
 	}
 	return ast.ConstraintTypeUndefined
 }
@@ -319,6 +637,23 @@ func convertColumnDef(in *pgquery.Node_ColumnDef) (*ast.ColumnDef, error) {
 	column := &ast.ColumnDef{
 		ColumnName: in.ColumnDef.Colname,
 	}
+	if in.ColumnDef.TypeName != nil {
+		dataType, err := convertTypeName(in.ColumnDef.TypeName)
+		if err != nil {
+			return nil, err
+		}
+		column.Type = dataType
+	}
+	if in.ColumnDef.CollClause != nil {
+		// The collation name may be qualified by the schema, e.g. pg_catalog."C".
+		nameList, err := convertListToStringList(&pgquery.Node_List{List: &pgquery.List{Items: in.ColumnDef.CollClause.Collname}})
+		if err != nil {
+			return nil, err
+		}
+		if len(nameList) > 0 {
+			column.Collation = nameList[len(nameList)-1]
+		}
+	}
 
 	for _, cons := range in.ColumnDef.Constraints {
 		constraint, ok := cons.Node.(*pgquery.Node_Constraint)
@@ -335,3 +670,30 @@ func convertColumnDef(in *pgquery.Node_ColumnDef) (*ast.ColumnDef, error) {
 
 	return column, nil
 }
+
+func convertTypeName(in *pgquery.TypeName) (*ast.DataTypeDef, error) {
+	nameList, err := convertListToStringList(&pgquery.Node_List{List: &pgquery.List{Items: in.Names}})
+	if err != nil {
+		return nil, err
+	}
+	if len(nameList) == 0 {
+		return nil, parser.NewConvertErrorf("expected type name but found empty")
+	}
+	dataType := &ast.DataTypeDef{
+		Name:    nameList[len(nameList)-1],
+		IsArray: len(in.ArrayBounds) > 0,
+	}
+	if len(nameList) > 1 {
+		dataType.Schema = nameList[len(nameList)-2]
+	}
+	for _, typmod := range in.Typmods {
+		aConst, ok := typmod.Node.(*pgquery.Node_AConst)
+		if !ok {
+			continue
+		}
+		if integer, ok := aConst.AConst.Val.GetNode().(*pgquery.Node_Integer); ok {
+			dataType.ModifierList = append(dataType.ModifierList, int(integer.Integer.Ival))
+		}
+	}
+	return dataType, nil
+}
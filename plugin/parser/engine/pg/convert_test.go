@@ -38,8 +38,8 @@ func TestPGConvertCreateTableStmt(t *testing.T) {
 						Name: "techBook",
 					},
 					ColumnList: []*ast.ColumnDef{
-						{ColumnName: "a"},
-						{ColumnName: "b"},
+						{ColumnName: "a", Type: &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"}},
+						{ColumnName: "b", Type: &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"}},
 					},
 				},
 			},
@@ -56,8 +56,8 @@ func TestPGConvertCreateTableStmt(t *testing.T) {
 						Name: "techbook",
 					},
 					ColumnList: []*ast.ColumnDef{
-						{ColumnName: "A"},
-						{ColumnName: "b"},
+						{ColumnName: "A", Type: &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"}},
+						{ColumnName: "b", Type: &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"}},
 					},
 				},
 			},
@@ -75,6 +75,7 @@ func TestPGConvertCreateTableStmt(t *testing.T) {
 					ColumnList: []*ast.ColumnDef{
 						{
 							ColumnName: "a",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"},
 							ConstraintList: []*ast.ConstraintDef{
 								{
 									Name:    "t_pk_a",
@@ -100,9 +101,11 @@ func TestPGConvertCreateTableStmt(t *testing.T) {
 					ColumnList: []*ast.ColumnDef{
 						{
 							ColumnName: "a",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"},
 						},
 						{
 							ColumnName: "b",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"},
 							ConstraintList: []*ast.ConstraintDef{
 								{
 									Name:    "uk_b",
@@ -135,6 +138,7 @@ func TestPGConvertCreateTableStmt(t *testing.T) {
 					ColumnList: []*ast.ColumnDef{
 						{
 							ColumnName: "a",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"},
 							ConstraintList: []*ast.ConstraintDef{
 								{
 									Name:    "fk_a",
@@ -185,7 +189,7 @@ func TestPGAddColumnStmt(t *testing.T) {
 								Name: "techbook",
 							},
 							ColumnList: []*ast.ColumnDef{
-								{ColumnName: "a"},
+								{ColumnName: "a", Type: &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"}},
 							},
 						},
 					},
@@ -210,6 +214,7 @@ func TestPGAddColumnStmt(t *testing.T) {
 							ColumnList: []*ast.ColumnDef{
 								{
 									ColumnName: "a",
+									Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"},
 									ConstraintList: []*ast.ConstraintDef{
 										{
 											Type:    ast.ConstraintTypeUnique,
@@ -589,3 +594,363 @@ func TestPGDropTableStmt(t *testing.T) {
 
 	runTests(t, tests)
 }
+
+func TestPGNotNullStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "CREATE TABLE tech_book(a INT NOT NULL)",
+			want: []ast.Node{
+				&ast.CreateTableStmt{
+					Name: &ast.TableDef{Name: "tech_book"},
+					ColumnList: []*ast.ColumnDef{
+						{
+							ColumnName: "a",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"},
+							ConstraintList: []*ast.ConstraintDef{
+								{
+									Type:    ast.ConstraintTypeNotNull,
+									KeyList: []string{"a"},
+								},
+							},
+						},
+					},
+				},
+			},
+			textList: []string{
+				"CREATE TABLE tech_book(a INT NOT NULL)",
+			},
+		},
+		{
+			stmt: "ALTER TABLE tech_book ALTER COLUMN a SET NOT NULL, ALTER COLUMN b DROP NOT NULL",
+			want: []ast.Node{
+				&ast.AlterTableStmt{
+					Table: &ast.TableDef{Name: "tech_book"},
+					AlterItemList: []ast.Node{
+						&ast.SetNotNullStmt{
+							Table:      &ast.TableDef{Name: "tech_book"},
+							ColumnName: "a",
+						},
+						&ast.DropNotNullStmt{
+							Table:      &ast.TableDef{Name: "tech_book"},
+							ColumnName: "b",
+						},
+					},
+				},
+			},
+			textList: []string{
+				"ALTER TABLE tech_book ALTER COLUMN a SET NOT NULL, ALTER COLUMN b DROP NOT NULL",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestPGSelectStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "SELECT * FROM tech_book",
+			want: []ast.Node{
+				&ast.SelectStmt{
+					FieldList: []ast.ExpressionNode{
+						&ast.ColumnNameDef{ColumnName: "*"},
+					},
+				},
+			},
+			textList: []string{
+				"SELECT * FROM tech_book",
+			},
+		},
+		{
+			stmt: "SELECT t.a, s.t.b FROM s.t WHERE a NOT LIKE '%abc' AND b IN (SELECT b FROM s.t WHERE c ILIKE 'abc%')",
+			want: []ast.Node{
+				&ast.SelectStmt{
+					FieldList: []ast.ExpressionNode{
+						&ast.ColumnNameDef{
+							Table:      &ast.TableDef{Name: "t"},
+							ColumnName: "a",
+						},
+						&ast.ColumnNameDef{
+							Table:      &ast.TableDef{Schema: "s", Name: "t"},
+							ColumnName: "b",
+						},
+					},
+					WhereClause: &ast.UnconvertedExpressionDef{
+						ExpressionList: []ast.ExpressionNode{
+							&ast.PatternLikeDef{
+								Not:        true,
+								Expression: &ast.ColumnNameDef{ColumnName: "a"},
+								Pattern:    &ast.StringDef{Value: "%abc"},
+							},
+							&ast.SubqueryDef{
+								Select: &ast.SelectStmt{
+									FieldList: []ast.ExpressionNode{
+										&ast.ColumnNameDef{ColumnName: "b"},
+									},
+									WhereClause: &ast.PatternLikeDef{
+										CaseInsensitive: true,
+										Expression:      &ast.ColumnNameDef{ColumnName: "c"},
+										Pattern:         &ast.StringDef{Value: "abc%"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			textList: []string{
+				"SELECT t.a, s.t.b FROM s.t WHERE a NOT LIKE '%abc' AND b IN (SELECT b FROM s.t WHERE c ILIKE 'abc%')",
+			},
+		},
+		{
+			stmt: "SELECT a FROM t UNION SELECT a FROM s",
+			want: []ast.Node{
+				&ast.SelectStmt{
+					SetOperation: ast.SetOperationTypeUnion,
+					LQuery: &ast.SelectStmt{
+						FieldList: []ast.ExpressionNode{
+							&ast.ColumnNameDef{ColumnName: "a"},
+						},
+					},
+					RQuery: &ast.SelectStmt{
+						FieldList: []ast.ExpressionNode{
+							&ast.ColumnNameDef{ColumnName: "a"},
+						},
+					},
+				},
+			},
+			textList: []string{
+				"SELECT a FROM t UNION SELECT a FROM s",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestPGDMLStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "INSERT INTO t VALUES (1, 2)",
+			want: []ast.Node{
+				&ast.InsertStmt{
+					Table: &ast.TableDef{Name: "t"},
+				},
+			},
+			textList: []string{
+				"INSERT INTO t VALUES (1, 2)",
+			},
+		},
+		{
+			stmt: "INSERT INTO t SELECT a FROM s",
+			want: []ast.Node{
+				&ast.InsertStmt{
+					Table: &ast.TableDef{Name: "t"},
+					Select: &ast.SelectStmt{
+						FieldList: []ast.ExpressionNode{
+							&ast.ColumnNameDef{ColumnName: "a"},
+						},
+					},
+				},
+			},
+			textList: []string{
+				"INSERT INTO t SELECT a FROM s",
+			},
+		},
+		{
+			stmt: "UPDATE t SET a = 1 WHERE b LIKE 'x'; DELETE FROM t",
+			want: []ast.Node{
+				&ast.UpdateStmt{
+					Table: &ast.TableDef{Name: "t"},
+					WhereClause: &ast.PatternLikeDef{
+						Expression: &ast.ColumnNameDef{ColumnName: "b"},
+						Pattern:    &ast.StringDef{Value: "x"},
+					},
+				},
+				&ast.DeleteStmt{
+					Table: &ast.TableDef{Name: "t"},
+				},
+			},
+			textList: []string{
+				"UPDATE t SET a = 1 WHERE b LIKE 'x';",
+				"DELETE FROM t",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestPGAlterColumnTypeStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "ALTER TABLE tech_book ALTER COLUMN a TYPE BIGINT, ALTER COLUMN b SET DATA TYPE TEXT",
+			want: []ast.Node{
+				&ast.AlterTableStmt{
+					Table: &ast.TableDef{Name: "tech_book"},
+					AlterItemList: []ast.Node{
+						&ast.AlterColumnTypeStmt{
+							Table:      &ast.TableDef{Name: "tech_book"},
+							ColumnName: "a",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int8"},
+						},
+						&ast.AlterColumnTypeStmt{
+							Table:      &ast.TableDef{Name: "tech_book"},
+							ColumnName: "b",
+							Type:       &ast.DataTypeDef{Name: "text"},
+						},
+					},
+				},
+			},
+			textList: []string{
+				"ALTER TABLE tech_book ALTER COLUMN a TYPE BIGINT, ALTER COLUMN b SET DATA TYPE TEXT",
+			},
+		},
+		{
+			stmt: "ALTER TABLE tech_book ADD COLUMN a INT NOT NULL DEFAULT 0 CHECK (a >= 0)",
+			want: []ast.Node{
+				&ast.AlterTableStmt{
+					Table: &ast.TableDef{Name: "tech_book"},
+					AlterItemList: []ast.Node{
+						&ast.AddColumnListStmt{
+							Table: &ast.TableDef{Name: "tech_book"},
+							ColumnList: []*ast.ColumnDef{
+								{
+									ColumnName: "a",
+									Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int4"},
+									ConstraintList: []*ast.ConstraintDef{
+										{
+											Type:    ast.ConstraintTypeNotNull,
+											KeyList: []string{"a"},
+										},
+										{
+											Type:    ast.ConstraintTypeDefault,
+											KeyList: []string{"a"},
+										},
+										{
+											Type:    ast.ConstraintTypeCheck,
+											KeyList: []string{"a"},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			textList: []string{
+				"ALTER TABLE tech_book ADD COLUMN a INT NOT NULL DEFAULT 0 CHECK (a >= 0)",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestPGDropDatabaseStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "DROP DATABASE IF EXISTS test",
+			want: []ast.Node{
+				&ast.DropDatabaseStmt{
+					DatabaseName: "test",
+					IfExists:     true,
+				},
+			},
+			textList: []string{
+				"DROP DATABASE IF EXISTS test",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestPGColumnDataType(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "CREATE TABLE t(a VARCHAR(20) COLLATE \"C\", b NUMERIC(10, 2)[], c BIGSERIAL, d BIGINT GENERATED ALWAYS AS IDENTITY)",
+			want: []ast.Node{
+				&ast.CreateTableStmt{
+					Name: &ast.TableDef{Name: "t"},
+					ColumnList: []*ast.ColumnDef{
+						{
+							ColumnName: "a",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "varchar", ModifierList: []int{20}},
+							Collation:  "C",
+						},
+						{
+							ColumnName: "b",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "numeric", ModifierList: []int{10, 2}, IsArray: true},
+						},
+						{
+							ColumnName: "c",
+							Type:       &ast.DataTypeDef{Name: "bigserial"},
+						},
+						{
+							ColumnName: "d",
+							Type:       &ast.DataTypeDef{Schema: "pg_catalog", Name: "int8"},
+							ConstraintList: []*ast.ConstraintDef{
+								{
+									Type:    ast.ConstraintTypeIdentity,
+									KeyList: []string{"d"},
+								},
+							},
+						},
+					},
+				},
+			},
+			textList: []string{
+				"CREATE TABLE t(a VARCHAR(20) COLLATE \"C\", b NUMERIC(10, 2)[], c BIGSERIAL, d BIGINT GENERATED ALWAYS AS IDENTITY)",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestPGCommentStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "COMMENT ON TABLE s.t IS 'table comment'; COMMENT ON COLUMN t.a IS NULL",
+			want: []ast.Node{
+				&ast.CommentStmt{
+					Type:    ast.CommentObjectTypeTable,
+					Table:   &ast.TableDef{Schema: "s", Name: "t"},
+					Comment: "table comment",
+				},
+				&ast.CommentStmt{
+					Type:       ast.CommentObjectTypeColumn,
+					Table:      &ast.TableDef{Name: "t"},
+					ColumnName: "a",
+				},
+			},
+			textList: []string{
+				"COMMENT ON TABLE s.t IS 'table comment';",
+				"COMMENT ON COLUMN t.a IS NULL",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
+
+func TestPGCreateDatabaseStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "CREATE DATABASE test ENCODING 'UTF8' LC_COLLATE 'en_US.UTF-8'",
+			want: []ast.Node{
+				&ast.CreateDatabaseStmt{
+					DatabaseName: "test",
+					Encoding:     "UTF8",
+					Collation:    "en_US.UTF-8",
+				},
+			},
+			textList: []string{
+				"CREATE DATABASE test ENCODING 'UTF8' LC_COLLATE 'en_US.UTF-8'",
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
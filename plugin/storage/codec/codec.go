@@ -0,0 +1,249 @@
+// Package codec encodes the backup stream with the compression and the encryption.
+package codec
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the compression algorithm.
+type Compression string
+
+const (
+	// CompressionNone is no compression.
+	CompressionNone Compression = ""
+	// CompressionGzip is the gzip compression.
+	CompressionGzip Compression = "GZIP"
+	// CompressionZstd is the zstd compression.
+	CompressionZstd Compression = "ZSTD"
+)
+
+const (
+	// chunkSize is the plaintext size of an encrypted chunk.
+	chunkSize = 64 * 1024
+	// noncePrefixSize is the size of the random nonce prefix written at the beginning of the encrypted stream.
+	// The nonce of a chunk is the prefix, the 4-byte chunk counter and the 1-byte last chunk flag.
+	noncePrefixSize = 7
+)
+
+// NewEncoder returns a writer which compresses and then encrypts the stream written to w.
+// The stream is not encrypted if key is empty. The caller must close the writer to flush the stream.
+func NewEncoder(w io.Writer, compression Compression, key []byte) (io.WriteCloser, error) {
+	var closerList []io.Closer
+	out := w
+	if len(key) > 0 {
+		ew, err := NewEncryptWriter(out, key)
+		if err != nil {
+			return nil, err
+		}
+		closerList = append(closerList, ew)
+		out = ew
+	}
+	switch compression {
+	case CompressionNone:
+	case CompressionGzip:
+		gw := gzip.NewWriter(out)
+		closerList = append(closerList, gw)
+		out = gw
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(out)
+		if err != nil {
+			return nil, err
+		}
+		closerList = append(closerList, zw)
+		out = zw
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+	return &encoder{Writer: out, closerList: closerList}, nil
+}
+
+type encoder struct {
+	io.Writer
+	// closerList is closed in the reverse order, so the outer writer is flushed before the inner one.
+	closerList []io.Closer
+}
+
+func (e *encoder) Close() error {
+	for i := len(e.closerList) - 1; i >= 0; i-- {
+		if err := e.closerList[i].Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewDecoder returns a reader which decrypts and then decompresses the stream encoded by NewEncoder.
+// The caller should close the reader to release the resources.
+func NewDecoder(r io.Reader, compression Compression, key []byte) (io.ReadCloser, error) {
+	in := r
+	if len(key) > 0 {
+		dr, err := NewDecryptReader(in, key)
+		if err != nil {
+			return nil, err
+		}
+		in = dr
+	}
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(in), nil
+	case CompressionGzip:
+		return gzip.NewReader(in)
+	case CompressionZstd:
+		zr, err := zstd.NewReader(in)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	}
+	return nil, fmt.Errorf("unsupported compression %q", compression)
+}
+
+// NewEncryptWriter returns a writer which encrypts the stream with the AES-GCM in chunks.
+// The key must be 16, 24 or 32 bytes to select AES-128, AES-192 or AES-256.
+// The last chunk is sealed with a flag in the nonce, so the truncated stream fails to decrypt.
+func NewEncryptWriter(w io.Writer, key []byte) (io.WriteCloser, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, noncePrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce, error: %w", err)
+	}
+	if _, err := w.Write(prefix); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, prefix: prefix}, nil
+}
+
+type encryptWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+}
+
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	e.buf = append(e.buf, p...)
+	// Keep the last chunk in the buffer, which is sealed on close.
+	for len(e.buf) > chunkSize {
+		if err := e.seal(e.buf[:chunkSize], false); err != nil {
+			return 0, err
+		}
+		e.buf = e.buf[chunkSize:]
+	}
+	return len(p), nil
+}
+
+func (e *encryptWriter) Close() error {
+	return e.seal(e.buf, true)
+}
+
+func (e *encryptWriter) seal(plaintext []byte, last bool) error {
+	nonce, err := chunkNonce(e.prefix, e.counter, last)
+	if err != nil {
+		return err
+	}
+	e.counter++
+	_, err = e.w.Write(e.aead.Seal(nil, nonce, plaintext, nil))
+	return err
+}
+
+// NewDecryptReader returns a reader which decrypts the stream encrypted by NewEncryptWriter.
+func NewDecryptReader(r io.Reader, key []byte) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, noncePrefixSize)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, fmt.Errorf("failed to read nonce, error: %w", err)
+	}
+	return &decryptReader{r: bufio.NewReader(r), aead: aead, prefix: prefix}, nil
+}
+
+type decryptReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	done    bool
+}
+
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptReader) open() error {
+	ciphertext := make([]byte, chunkSize+d.aead.Overhead())
+	n, err := io.ReadFull(d.r, ciphertext)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return fmt.Errorf("encrypted stream is truncated")
+		}
+		return err
+	}
+	// The chunk is the last one if nothing follows.
+	last := err == io.ErrUnexpectedEOF
+	if !last {
+		if _, err := d.r.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	nonce, err := chunkNonce(d.prefix, d.counter, last)
+	if err != nil {
+		return err
+	}
+	d.counter++
+	plaintext, err := d.aead.Open(nil, nonce, ciphertext[:n], nil)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt chunk %d, the key is wrong or the stream is corrupted", d.counter-1)
+	}
+	d.buf = plaintext
+	d.done = last
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key, error: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(prefix []byte, counter uint32, last bool) ([]byte, error) {
+	if counter == ^uint32(0) {
+		return nil, fmt.Errorf("too many chunks in the encrypted stream")
+	}
+	nonce := make([]byte, noncePrefixSize+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], counter)
+	if last {
+		nonce[len(nonce)-1] = 1
+	}
+	return nonce, nil
+}
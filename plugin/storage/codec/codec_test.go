@@ -0,0 +1,86 @@
+package codec
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	contentList := []string{
+		"",
+		"CREATE TABLE t (id INT);\n",
+		strings.Repeat("a", chunkSize),
+		strings.Repeat("INSERT INTO t VALUES (1);\n", 10000),
+	}
+	for _, compression := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		for _, key := range [][]byte{nil, key} {
+			for _, content := range contentList {
+				a := require.New(t)
+				var buf bytes.Buffer
+				w, err := NewEncoder(&buf, compression, key)
+				a.NoError(err)
+				_, err = io.Copy(w, strings.NewReader(content))
+				a.NoError(err)
+				a.NoError(w.Close())
+
+				r, err := NewDecoder(bytes.NewReader(buf.Bytes()), compression, key)
+				a.NoError(err)
+				got, err := io.ReadAll(r)
+				a.NoError(err)
+				a.NoError(r.Close())
+				a.Equal(content, string(got), "compression %q, encrypted %t, length %d", compression, key != nil, len(content))
+			}
+		}
+	}
+}
+
+func TestDecryptTamperedStream(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	content := strings.Repeat("x", 3*chunkSize)
+	var buf bytes.Buffer
+	w, err := NewEncryptWriter(&buf, key)
+	require.NoError(t, err)
+	_, err = w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	encrypted := buf.Bytes()
+	chunkEnd := noncePrefixSize + chunkSize + 16
+
+	tests := []struct {
+		name string
+		data []byte
+		key  []byte
+	}{
+		{
+			name: "wrong key",
+			data: encrypted,
+			key:  []byte("fedcba9876543210fedcba9876543210"),
+		},
+		{
+			name: "truncated at chunk boundary",
+			data: encrypted[:chunkEnd],
+			key:  key,
+		},
+		{
+			name: "truncated in chunk",
+			data: encrypted[:len(encrypted)-1],
+			key:  key,
+		},
+		{
+			name: "modified",
+			data: append(append(append([]byte{}, encrypted[:chunkEnd]...), encrypted[chunkEnd]^1), encrypted[chunkEnd+1:]...),
+			key:  key,
+		},
+	}
+	for _, test := range tests {
+		r, err := NewDecryptReader(bytes.NewReader(test.data), test.key)
+		require.NoError(t, err, test.name)
+		_, err = io.ReadAll(r)
+		require.Error(t, err, test.name)
+	}
+}
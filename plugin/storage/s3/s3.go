@@ -0,0 +1,100 @@
+// Package s3 is the plugin for the S3-compatible object storage, such as AWS S3 and MinIO.
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Config is the configuration to connect to the S3-compatible object storage.
+type Config struct {
+	// Region is the region of the bucket, such as "us-east-1".
+	Region string
+	// Endpoint is the custom endpoint URL for the S3-compatible services such as MinIO.
+	// The default AWS S3 endpoint of the region is used if empty.
+	Endpoint string
+	Bucket   string
+	// AccessKeyID and SecretAccessKey are the static credentials.
+	// The default AWS credential chain, such as the environment variables and the instance role, is used if empty.
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// Client is the client to read and write the objects in a bucket.
+type Client struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewClient creates a new client for the bucket in the config.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("bucket must be specified")
+	}
+	optionList := []func(*config.LoadOptions) error{
+		config.WithRegion(cfg.Region),
+	}
+	if cfg.AccessKeyID != "" || cfg.SecretAccessKey != "" {
+		optionList = append(optionList, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, "")))
+	}
+	awsConfig, err := config.LoadDefaultConfig(ctx, optionList...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config, error: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.EndpointResolver = s3.EndpointResolverFromURL(cfg.Endpoint)
+			// The S3-compatible services such as MinIO usually don't support the virtual-hosted-style URL.
+			o.UsePathStyle = true
+		}
+	})
+	return &Client{
+		client: client,
+		bucket: cfg.Bucket,
+	}, nil
+}
+
+// UploadObject streams the body to the object with the key.
+// The body is uploaded in parts, so its size doesn't need to be known in advance.
+func (c *Client) UploadObject(ctx context.Context, key string, body io.Reader) error {
+	uploader := manager.NewUploader(c.client)
+	if _, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	}); err != nil {
+		return fmt.Errorf("failed to upload object %q to bucket %q, error: %w", key, c.bucket, err)
+	}
+	return nil
+}
+
+// ReadObject returns the reader of the object with the key. The caller should close the reader.
+func (c *Client) ReadObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object %q from bucket %q, error: %w", key, c.bucket, err)
+	}
+	return output.Body, nil
+}
+
+// DeleteObject deletes the object with the key.
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	if _, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		return fmt.Errorf("failed to delete object %q from bucket %q, error: %w", key, c.bucket, err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestObjectRoundTrip runs against a local MinIO, for example:
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//	BYTEBASE_TEST_S3_ENDPOINT=http://localhost:9000 BYTEBASE_TEST_S3_BUCKET=backup go test ./plugin/storage/s3/
+//
+// The bucket should exist, and the MinIO default credentials are used if the access key is not set.
+func TestObjectRoundTrip(t *testing.T) {
+	endpoint := os.Getenv("BYTEBASE_TEST_S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("BYTEBASE_TEST_S3_ENDPOINT is not set")
+	}
+	cfg := Config{
+		Region:          "us-east-1",
+		Endpoint:        endpoint,
+		Bucket:          os.Getenv("BYTEBASE_TEST_S3_BUCKET"),
+		AccessKeyID:     os.Getenv("BYTEBASE_TEST_S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("BYTEBASE_TEST_S3_SECRET_ACCESS_KEY"),
+	}
+	if cfg.AccessKeyID == "" {
+		cfg.AccessKeyID, cfg.SecretAccessKey = "minioadmin", "minioadmin"
+	}
+	a := require.New(t)
+	ctx := context.Background()
+
+	client, err := NewClient(ctx, cfg)
+	a.NoError(err)
+
+	key := "bytebase-test/backup/db/1/round-trip.sql"
+	// Larger than the minimal part size to upload in multiple parts.
+	content := strings.Repeat("INSERT INTO t VALUES (1);\n", 300000)
+	err = client.UploadObject(ctx, key, strings.NewReader(content))
+	a.NoError(err)
+
+	reader, err := client.ReadObject(ctx, key)
+	a.NoError(err)
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, reader)
+	a.NoError(err)
+	a.NoError(reader.Close())
+	a.Equal(content, buf.String())
+
+	err = client.DeleteObject(ctx, key)
+	a.NoError(err)
+	_, err = client.ReadObject(ctx, key)
+	a.Error(err)
+}
+
+func TestNewClientWithoutBucket(t *testing.T) {
+	_, err := NewClient(context.Background(), Config{Region: "us-east-1"})
+	require.Error(t, err)
+}
@@ -0,0 +1,1035 @@
+package bitbucket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/plugin/vcs"
+	"github.com/youzi-1122/bytebase/plugin/vcs/internal/oauth"
+)
+
+const (
+	// apiURL is the API URL.
+	apiURL = "https://api.bitbucket.org/2.0"
+	// oauthURL is the URL for exchanging and refreshing the OAuth token.
+	oauthURL = "https://bitbucket.org/site/oauth2/access_token"
+	// apiPageSize is the default page size when making API requests.
+	apiPageSize = 100
+)
+
+func init() {
+	vcs.Register(vcs.BitbucketOrg, newProvider)
+}
+
+var _ vcs.Provider = (*Provider)(nil)
+
+// Provider is a Bitbucket Cloud VCS provider.
+type Provider struct {
+	client *http.Client
+}
+
+func newProvider(config vcs.ProviderConfig) vcs.Provider {
+	if config.Client == nil {
+		config.Client = &http.Client{}
+	}
+	return &Provider{
+		client: config.Client,
+	}
+}
+
+// APIURL returns the API URL path of Bitbucket Cloud.
+func (p *Provider) APIURL(string) string {
+	return apiURL
+}
+
+// WebhookType is the Bitbucket webhook event type, which is delivered in the
+// "X-Event-Key" header.
+type WebhookType string
+
+const (
+	// WebhookPush is the webhook type for push.
+	WebhookPush WebhookType = "repo:push"
+	// WebhookPullRequestCreated is the webhook type for pull request creation.
+	WebhookPullRequestCreated WebhookType = "pullrequest:created"
+	// WebhookPullRequestUpdated is the webhook type for pull request update,
+	// which is also triggered when new commits are pushed to the source branch.
+	WebhookPullRequestUpdated WebhookType = "pullrequest:updated"
+)
+
+// WebhookInfo is the API message for webhook info.
+type WebhookInfo struct {
+	UUID string `json:"uuid"`
+}
+
+// WebhookCreateOrUpdate is the API message for creating or updating a webhook.
+type WebhookCreateOrUpdate struct {
+	Description string `json:"description"`
+	// URL is the URL to which the payloads will be delivered.
+	URL    string `json:"url"`
+	Active bool   `json:"active"`
+	// Secret is the secret used to compute the HMAC hex digest of the payload,
+	// which is delivered in the "X-Hub-Signature" header.
+	Secret string `json:"secret,omitempty"`
+	// Events is the list of events the webhook is triggered for, we only need
+	// the "repo:push", "pullrequest:created" and "pullrequest:updated" events.
+	Events []string `json:"events"`
+}
+
+// Link is the API message for a hypermedia link.
+type Link struct {
+	Href string `json:"href"`
+}
+
+// User represents a Bitbucket API response for a user.
+type User struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// Repository represents a Bitbucket API response for a repository.
+type Repository struct {
+	UUID     string `json:"uuid"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Links    struct {
+		HTML Link `json:"html"`
+	} `json:"links"`
+}
+
+// Commit represents a Bitbucket API response for a commit.
+type Commit struct {
+	Hash string `json:"hash"`
+	// Date expects corresponding JSON value is a string in RFC 3339 format,
+	// see https://pkg.go.dev/time#Time.MarshalJSON.
+	Date    time.Time `json:"date"`
+	Message string    `json:"message"`
+	Author  struct {
+		// Raw is the raw author string in the form of "Name <email>".
+		Raw  string `json:"raw"`
+		User *User  `json:"user"`
+	} `json:"author"`
+	Links struct {
+		HTML Link `json:"html"`
+	} `json:"links"`
+}
+
+// AuthorName returns the display name of the linked Bitbucket user, or the
+// name part of the raw author string if the author is not a Bitbucket user.
+func (c Commit) AuthorName() string {
+	if c.Author.User != nil && c.Author.User.DisplayName != "" {
+		return c.Author.User.DisplayName
+	}
+	if i := strings.Index(c.Author.Raw, " <"); i != -1 {
+		return c.Author.Raw[:i]
+	}
+	return c.Author.Raw
+}
+
+// WebhookPushReference is the API message for the state of a reference before
+// or after the webhook push event.
+type WebhookPushReference struct {
+	// Type is the type of the reference, can be one of "branch", "tag",
+	// "named_branch" and "bookmark".
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+// WebhookPushChange is the API message for a reference change of the webhook
+// push event.
+type WebhookPushChange struct {
+	// Old is nil if the reference is created by the push.
+	Old *WebhookPushReference `json:"old"`
+	// New is nil if the reference is deleted by the push.
+	New *WebhookPushReference `json:"new"`
+	// Commits lists the pushed commits from the newest, and is truncated on
+	// large pushes, in which case Truncated is true.
+	Commits   []Commit `json:"commits"`
+	Truncated bool     `json:"truncated"`
+}
+
+// WebhookPushEvent is the API message for webhook push event.
+//
+// Docs: https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Push
+type WebhookPushEvent struct {
+	Actor      User       `json:"actor"`
+	Repository Repository `json:"repository"`
+	Push       struct {
+		Changes []WebhookPushChange `json:"changes"`
+	} `json:"push"`
+}
+
+// WebhookPullRequestEndpoint is the API message for the source or destination
+// of the webhook pull request.
+type WebhookPullRequestEndpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+}
+
+// WebhookPullRequestEvent is the API message for webhook pull request event.
+//
+// Docs: https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/#Pull-request-events
+type WebhookPullRequestEvent struct {
+	Repository  Repository `json:"repository"`
+	PullRequest struct {
+		ID          int                        `json:"id"`
+		Source      WebhookPullRequestEndpoint `json:"source"`
+		Destination WebhookPullRequestEndpoint `json:"destination"`
+		Links       struct {
+			HTML Link `json:"html"`
+		} `json:"links"`
+	} `json:"pullrequest"`
+}
+
+// PullRequestComment is the API message for a pull request comment.
+type PullRequestComment struct {
+	ID      int `json:"id,omitempty"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+}
+
+// DiffStat represents a Bitbucket API response for the file change of a diff.
+type DiffStat struct {
+	// Status can be one of "added", "removed", "modified", "renamed",
+	// "merge conflict" and "local deleted".
+	Status string `json:"status"`
+	New    *struct {
+		Path string `json:"path"`
+	} `json:"new"`
+}
+
+// TreeEntry represents a Bitbucket API response for a file or directory in the
+// repository.
+type TreeEntry struct {
+	Path string `json:"path"`
+	// Type can be one of "commit_file" and "commit_directory".
+	Type   string `json:"type"`
+	Size   int64  `json:"size"`
+	Commit struct {
+		Hash string `json:"hash"`
+	} `json:"commit"`
+}
+
+// RepositoryPermission represents a Bitbucket API response for the
+// permission of a user in a repository.
+type RepositoryPermission struct {
+	// Permission can be one of "admin", "write" and "read".
+	Permission string `json:"permission"`
+	User       User   `json:"user"`
+}
+
+// Email represents a Bitbucket API response for an email of the authenticated
+// user.
+type Email struct {
+	Email       string `json:"email"`
+	IsPrimary   bool   `json:"is_primary"`
+	IsConfirmed bool   `json:"is_confirmed"`
+}
+
+// paginatedResponse is the generic paginated Bitbucket API response, "next" is
+// the URL of the next page and is empty for the last page.
+type paginatedResponse struct {
+	Values json.RawMessage `json:"values"`
+	Next   string          `json:"next"`
+}
+
+// oauthResponse is a Bitbucket OAuth response.
+type oauthResponse struct {
+	AccessToken      string `json:"access_token" `
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// toVCSOAuthToken converts the response to *vcs.OAuthToken.
+func (o oauthResponse) toVCSOAuthToken(createdAt int64) *vcs.OAuthToken {
+	oauthToken := &vcs.OAuthToken{
+		AccessToken:  o.AccessToken,
+		RefreshToken: o.RefreshToken,
+		ExpiresIn:    o.ExpiresIn,
+		CreatedAt:    createdAt,
+	}
+	// Bitbucket access tokens expire in two hours.
+	if oauthToken.ExpiresIn != 0 {
+		oauthToken.ExpiresTs = oauthToken.CreatedAt + oauthToken.ExpiresIn
+	}
+	return oauthToken
+}
+
+// requestOAuthToken requests an OAuth token with the form using the client
+// credentials in the HTTP basic authentication.
+func requestOAuthToken(ctx context.Context, client *http.Client, clientID, clientSecret string, form url.Values) (*oauthResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oauthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "construct POST %s", oauthURL)
+	}
+	req.SetBasicAuth(clientID, clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "POST %s", oauthURL)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth response body, code %v, error: %v", resp.StatusCode, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	oauthResp := new(oauthResponse)
+	if err := json.Unmarshal(body, oauthResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OAuth response body, code %v, error: %v", resp.StatusCode, err)
+	}
+	if oauthResp.Error != "" {
+		return nil, fmt.Errorf("failed to request OAuth token, error: %v, error_description: %v", oauthResp.Error, oauthResp.ErrorDescription)
+	}
+	return oauthResp, nil
+}
+
+// ExchangeOAuthToken exchanges OAuth content with the provided authorization code.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/oauth-2/
+func (p *Provider) ExchangeOAuthToken(ctx context.Context, _ string, oauthExchange *common.OAuthExchange) (*vcs.OAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", oauthExchange.Code)
+	oauthResp, err := requestOAuthToken(ctx, p.client, oauthExchange.ClientID, oauthExchange.ClientSecret, form)
+	if err != nil {
+		return nil, errors.Wrap(err, "exchange OAuth token")
+	}
+	return oauthResp.toVCSOAuthToken(time.Now().Unix()), nil
+}
+
+// get makes a HTTP GET request to the URL of the Bitbucket API and returns
+// the response body, the returned error is a common.NotFound error if the
+// resource is not found.
+func (p *Provider) get(ctx context.Context, oauthCtx common.OauthContext, url string) (string, error) {
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, fmt.Errorf("failed to GET %s, not found", url))
+	} else if code >= 300 {
+		return "", fmt.Errorf("failed to GET %s, status code: %d, body: %s", url, code, body)
+	}
+	return body, nil
+}
+
+// getPaginated makes HTTP GET requests to the URL of the Bitbucket API and
+// follows the "next" link until the last page. It calls the callback with the
+// "values" of each page.
+func (p *Provider) getPaginated(ctx context.Context, oauthCtx common.OauthContext, url string, callback func(values json.RawMessage) error) error {
+	for url != "" {
+		body, err := p.get(ctx, oauthCtx, url)
+		if err != nil {
+			return err
+		}
+
+		var resp paginatedResponse
+		if err := json.Unmarshal([]byte(body), &resp); err != nil {
+			return errors.Wrap(err, "unmarshal body")
+		}
+		if err := callback(resp.Values); err != nil {
+			return err
+		}
+		url = resp.Next
+	}
+	return nil
+}
+
+// TryLogin tries to fetch the user info from the current OAuth context.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-get
+func (p *Provider) TryLogin(ctx context.Context, oauthCtx common.OauthContext, _ string) (*vcs.UserInfo, error) {
+	body, err := p.get(ctx, oauthCtx, fmt.Sprintf("%s/user", apiURL))
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch user")
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+
+	// The email is not part of the user response, it is only accessible by the
+	// user themself through the emails API.
+	var primaryEmail string
+	if err := p.getPaginated(ctx, oauthCtx, fmt.Sprintf("%s/user/emails", apiURL), func(values json.RawMessage) error {
+		var emails []Email
+		if err := json.Unmarshal(values, &emails); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		for _, email := range emails {
+			if email.IsPrimary && email.IsConfirmed {
+				primaryEmail = email.Email
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "fetch user emails")
+	}
+
+	return &vcs.UserInfo{
+		PublicEmail: primaryEmail,
+		Name:        user.DisplayName,
+		State:       vcs.StateActive,
+	}, nil
+}
+
+// FetchUserInfo fetches user info of given user UUID or account ID. The email
+// is always empty because Bitbucket does not expose emails of other users.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-users-selected-user-get
+func (p *Provider) FetchUserInfo(ctx context.Context, oauthCtx common.OauthContext, _, user string) (*vcs.UserInfo, error) {
+	body, err := p.get(ctx, oauthCtx, fmt.Sprintf("%s/users/%s", apiURL, url.PathEscape(user)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch user %s", user)
+	}
+
+	var u User
+	if err := json.Unmarshal([]byte(body), &u); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+	return &vcs.UserInfo{
+		Name:  u.DisplayName,
+		State: vcs.StateActive,
+	}, nil
+}
+
+// FetchCommitByID fetches the commit data by its ID from the repository.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-commit-commit-get
+func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, commitID string) (*vcs.Commit, error) {
+	body, err := p.get(ctx, oauthCtx, fmt.Sprintf("%s/repositories/%s/commit/%s", apiURL, repositoryID, commitID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetch commit %s", commitID)
+	}
+
+	commit := &Commit{}
+	if err := json.Unmarshal([]byte(body), commit); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+	return &vcs.Commit{
+		ID:         commit.Hash,
+		AuthorName: commit.AuthorName(),
+		CreatedTs:  commit.Date.Unix(),
+	}, nil
+}
+
+// FetchCommitAddedFileList fetches the list of file paths added by the commit.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diffstat-spec-get
+func (p *Provider) FetchCommitAddedFileList(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, commitID string) ([]string, error) {
+	addedList, _, err := p.FetchCommitFileList(ctx, oauthCtx, repositoryID, commitID)
+	return addedList, err
+}
+
+// FetchCommitFileList fetches the lists of file paths added and modified by
+// the commit. Unlike other providers, the Bitbucket push event does not list
+// the changed files, so the modified files have to be fetched as well for the
+// SDL schema change type.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-diffstat-spec-get
+func (p *Provider) FetchCommitFileList(ctx context.Context, oauthCtx common.OauthContext, repositoryID, commitID string) (addedList []string, modifiedList []string, err error) {
+	url := fmt.Sprintf("%s/repositories/%s/diffstat/%s?pagelen=%d", apiURL, repositoryID, commitID, apiPageSize)
+	if err := p.getPaginated(ctx, oauthCtx, url, func(values json.RawMessage) error {
+		var diffStats []DiffStat
+		if err := json.Unmarshal(values, &diffStats); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		for _, diffStat := range diffStats {
+			if diffStat.New == nil {
+				continue
+			}
+			switch diffStat.Status {
+			case "added":
+				addedList = append(addedList, diffStat.New.Path)
+			case "modified":
+				modifiedList = append(modifiedList, diffStat.New.Path)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, nil, errors.Wrapf(err, "fetch diffstat of commit %s", commitID)
+	}
+	return addedList, modifiedList, nil
+}
+
+// FetchCommitRange fetches the commits reachable from the include commit but
+// not from the exclude commit, from the newest. It is used to recover the full
+// commit list of a truncated push event.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-commits-get
+func (p *Provider) FetchCommitRange(ctx context.Context, oauthCtx common.OauthContext, repositoryID, include, exclude string) ([]Commit, error) {
+	var commitList []Commit
+	url := fmt.Sprintf("%s/repositories/%s/commits?include=%s&exclude=%s&pagelen=%d", apiURL, repositoryID, include, exclude, apiPageSize)
+	if err := p.getPaginated(ctx, oauthCtx, url, func(values json.RawMessage) error {
+		var page []Commit
+		if err := json.Unmarshal(values, &page); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		commitList = append(commitList, page...)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "fetch commits from %s to %s", exclude, include)
+	}
+	return commitList, nil
+}
+
+// RepositoryRole is the role of the repository member.
+type RepositoryRole string
+
+// The list of Bitbucket roles.
+const (
+	RepositoryRoleAdmin RepositoryRole = "admin"
+	RepositoryRoleWrite RepositoryRole = "write"
+	RepositoryRoleRead  RepositoryRole = "read"
+)
+
+func getRoleAndMappedRole(permission string) (bitbucketRole RepositoryRole, bytebaseRole common.ProjectRole) {
+	// Please refer to https://support.atlassian.com/bitbucket-cloud/docs/repository-privacy-permissions-and-more/
+	// for the detailed permission descriptions of Bitbucket.
+	switch permission {
+	case "admin":
+		return RepositoryRoleAdmin, common.ProjectOwner
+	case "write":
+		return RepositoryRoleWrite, common.ProjectDeveloper
+	case "read":
+		return RepositoryRoleRead, common.ProjectDeveloper
+	}
+	return "", ""
+}
+
+// FetchRepositoryActiveMemberList fetches all members of a repository.
+//
+// Bitbucket does not expose emails of other users, so the members can only be
+// synced when each of them is also the authenticated user, which effectively
+// means the repository has a single member. For other members, an error
+// listing their names is returned like other providers do for members without
+// public emails.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-workspaces/#api-workspaces-workspace-permissions-repositories-repo-slug-get
+func (p *Provider) FetchRepositoryActiveMemberList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string) ([]*vcs.RepositoryMember, error) {
+	workspace, repoSlug, err := splitRepositoryID(repositoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []RepositoryPermission
+	url := fmt.Sprintf("%s/workspaces/%s/permissions/repositories/%s?pagelen=%d", apiURL, workspace, repoSlug, apiPageSize)
+	if err := p.getPaginated(ctx, oauthCtx, url, func(values json.RawMessage) error {
+		var page []RepositoryPermission
+		if err := json.Unmarshal(values, &page); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		permissions = append(permissions, page...)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "fetch repository permissions")
+	}
+
+	currentUser, err := p.TryLogin(ctx, oauthCtx, instanceURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "fetch current user")
+	}
+
+	var emptyEmailUserList []string
+	var allMembers []*vcs.RepositoryMember
+	for _, permission := range permissions {
+		if permission.User.DisplayName != currentUser.Name || currentUser.PublicEmail == "" {
+			emptyEmailUserList = append(emptyEmailUserList, permission.User.DisplayName)
+			continue
+		}
+
+		bitbucketRole, bytebaseRole := getRoleAndMappedRole(permission.Permission)
+		allMembers = append(allMembers,
+			&vcs.RepositoryMember{
+				Name:         currentUser.Name,
+				Email:        currentUser.PublicEmail,
+				Role:         bytebaseRole,
+				VCSRole:      string(bitbucketRole),
+				State:        vcs.StateActive,
+				RoleProvider: vcs.BitbucketOrg,
+			},
+		)
+	}
+
+	if len(emptyEmailUserList) != 0 {
+		return nil, fmt.Errorf("[ %v ] did not expose their email through Bitbucket, Bitbucket does not allow reading emails of other users, please add them to the project manually", strings.Join(emptyEmailUserList, ", "))
+	}
+
+	return allMembers, nil
+}
+
+// FetchAllRepositoryList fetches all repositories where the authenticated user
+// has the admin permission, which is required to create webhook in the
+// repository.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-repositories-get
+func (p *Provider) FetchAllRepositoryList(ctx context.Context, oauthCtx common.OauthContext, _ string) ([]*vcs.Repository, error) {
+	var allRepos []*vcs.Repository
+	url := fmt.Sprintf("%s/repositories?role=admin&pagelen=%d", apiURL, apiPageSize)
+	if err := p.getPaginated(ctx, oauthCtx, url, func(values json.RawMessage) error {
+		var repos []Repository
+		if err := json.Unmarshal(values, &repos); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		for _, r := range repos {
+			allRepos = append(allRepos,
+				&vcs.Repository{
+					// Bitbucket identifies a repository by its UUID or full name
+					// rather than a numeric ID, the full name is used as the
+					// external ID.
+					Name:     r.Name,
+					FullPath: r.FullName,
+					WebURL:   r.Links.HTML.Href,
+				},
+			)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "fetch repository list")
+	}
+	return allRepos, nil
+}
+
+// FetchRepositoryFileList fetches the files from repository tree.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-source/#api-repositories-workspace-repo-slug-src-commit-path-get
+func (p *Provider) FetchRepositoryFileList(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, ref, filePath string) ([]*vcs.RepositoryTreeNode, error) {
+	var fileList []*vcs.RepositoryTreeNode
+	// Bitbucket lists the directory recursively up to the given depth.
+	url := fmt.Sprintf("%s/repositories/%s/src/%s/%s?max_depth=%d&pagelen=%d", apiURL, repositoryID, url.PathEscape(ref), escapeFilePath(filePath), 64, apiPageSize)
+	if err := p.getPaginated(ctx, oauthCtx, url, func(values json.RawMessage) error {
+		var entries []TreeEntry
+		if err := json.Unmarshal(values, &entries); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		// Filter out folder nodes, we only need the file nodes.
+		for _, entry := range entries {
+			if entry.Type == "commit_file" {
+				fileList = append(fileList, &vcs.RepositoryTreeNode{
+					Path: entry.Path,
+					Type: "blob",
+				})
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "fetch repository tree")
+	}
+	return fileList, nil
+}
+
+// commitFile commits the file through the form-based source API, which
+// creates the file or overwrites the existing one.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-source/#api-repositories-workspace-repo-slug-src-post
+func (p *Provider) commitFile(ctx context.Context, oauthCtx common.OauthContext, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	form := url.Values{}
+	form.Set(filePath, fileCommitCreate.Content)
+	form.Set("message", fileCommitCreate.CommitMessage)
+	form.Set("branch", fileCommitCreate.Branch)
+	// The commit is rejected if the branch head is no longer the parent,
+	// which is used to detect conflicting writes.
+	if fileCommitCreate.LastCommitID != "" {
+		form.Set("parents", fileCommitCreate.LastCommitID)
+	}
+
+	url := fmt.Sprintf("%s/repositories/%s/src", apiURL, repositoryID)
+	code, body, err := oauth.PostForm(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		strings.NewReader(form.Encode()),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code >= 300 {
+		return fmt.Errorf("failed to commit file through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// CreateFile creates a file.
+func (p *Provider) CreateFile(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	return p.commitFile(ctx, oauthCtx, repositoryID, filePath, fileCommitCreate)
+}
+
+// OverwriteFile overwrites an existing file.
+func (p *Provider) OverwriteFile(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	return p.commitFile(ctx, oauthCtx, repositoryID, filePath, fileCommitCreate)
+}
+
+// ReadFileMeta reads the file metadata.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-source/#api-repositories-workspace-repo-slug-filehistory-commit-path-get
+func (p *Provider) ReadFileMeta(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, filePath, ref string) (*vcs.FileMeta, error) {
+	// The file history lists the file versions from the latest, so the first
+	// entry carries the last commit that touched the file.
+	url := fmt.Sprintf("%s/repositories/%s/filehistory/%s/%s?pagelen=1", apiURL, repositoryID, url.PathEscape(ref), escapeFilePath(filePath))
+	body, err := p.get(ctx, oauthCtx, url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read file metadata %s", filePath)
+	}
+
+	var resp paginatedResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	var entries []TreeEntry
+	if err := json.Unmarshal(resp.Values, &entries); err != nil {
+		return nil, errors.Wrap(err, "unmarshal values")
+	}
+	if len(entries) == 0 {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("file %q not found", filePath))
+	}
+
+	return &vcs.FileMeta{
+		Name:         path.Base(entries[0].Path),
+		Path:         entries[0].Path,
+		Size:         entries[0].Size,
+		LastCommitID: entries[0].Commit.Hash,
+	}, nil
+}
+
+// ReadFileContent reads the file content.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-source/#api-repositories-workspace-repo-slug-src-commit-path-get
+func (p *Provider) ReadFileContent(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, filePath, ref string) (string, error) {
+	// The source API responds the raw file content for a file path.
+	content, err := p.get(ctx, oauthCtx, fmt.Sprintf("%s/repositories/%s/src/%s/%s", apiURL, repositoryID, url.PathEscape(ref), escapeFilePath(filePath)))
+	if err != nil {
+		return "", errors.Wrapf(err, "read file content %s", filePath)
+	}
+	return content, nil
+}
+
+// CreateWebhook creates a webhook in the repository. It returns the created
+// webhook UUID on success.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-repositories-workspace-repo-slug-hooks-post
+func (p *Provider) CreateWebhook(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID string, payload []byte) (string, error) {
+	url := fmt.Sprintf("%s/repositories/%s/hooks", apiURL, repositoryID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, fmt.Errorf("failed to create webhook through URL %s", url))
+	} else if code >= 300 {
+		return "", fmt.Errorf("failed to create webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	webhookInfo := &WebhookInfo{}
+	if err := json.Unmarshal([]byte(body), webhookInfo); err != nil {
+		return "", errors.Wrap(err, "unmarshal body")
+	}
+	return webhookInfo.UUID, nil
+}
+
+// PatchWebhook patches a webhook in the repository, Bitbucket replaces the
+// whole webhook with the payload.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-repositories-workspace-repo-slug-hooks-uid-put
+func (p *Provider) PatchWebhook(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, webhookID string, payload []byte) error {
+	url := fmt.Sprintf("%s/repositories/%s/hooks/%s", apiURL, repositoryID, url.PathEscape(webhookID))
+	code, body, err := oauth.Put(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to patch webhook through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to patch webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// DeleteWebhook deletes a webhook in the repository.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-repositories-workspace-repo-slug-hooks-uid-delete
+func (p *Provider) DeleteWebhook(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, webhookID string) error {
+	url := fmt.Sprintf("%s/repositories/%s/hooks/%s", apiURL, repositoryID, url.PathEscape(webhookID))
+	code, body, err := oauth.Delete(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "DELETE %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to delete webhook through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to delete webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// ListPullRequestFile lists the files changed by the pull request.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-diffstat-get
+func (p *Provider) ListPullRequestFile(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, pullRequestID string) ([]*vcs.PullRequestFile, error) {
+	var fileList []*vcs.PullRequestFile
+	url := fmt.Sprintf("%s/repositories/%s/pullrequests/%s/diffstat?pagelen=%d", apiURL, repositoryID, pullRequestID, apiPageSize)
+	if err := p.getPaginated(ctx, oauthCtx, url, func(values json.RawMessage) error {
+		var diffStats []DiffStat
+		if err := json.Unmarshal(values, &diffStats); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		for _, diffStat := range diffStats {
+			// The new file is absent if the file is removed.
+			if diffStat.New == nil {
+				continue
+			}
+			fileList = append(fileList, &vcs.PullRequestFile{
+				Path:      diffStat.New.Path,
+				IsDeleted: diffStat.Status == "removed",
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "fetch diffstat of pull request %s", pullRequestID)
+	}
+	return fileList, nil
+}
+
+// CreatePullRequestComment creates a comment on the pull request.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-post
+func (p *Provider) CreatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, pullRequestID, comment string) error {
+	pullRequestComment := PullRequestComment{}
+	pullRequestComment.Content.Raw = comment
+	payload, err := json.Marshal(pullRequestComment)
+	if err != nil {
+		return errors.Wrap(err, "marshal pull request comment")
+	}
+
+	url := fmt.Sprintf("%s/repositories/%s/pullrequests/%s/comments", apiURL, repositoryID, pullRequestID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to create pull request comment through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to create pull request comment through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// ListPullRequestComment lists the comments on the pull request.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-get
+func (p *Provider) ListPullRequestComment(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	var commentList []*vcs.PullRequestComment
+	url := fmt.Sprintf("%s/repositories/%s/pullrequests/%s/comments?pagelen=%d", apiURL, repositoryID, pullRequestID, apiPageSize)
+	if err := p.getPaginated(ctx, oauthCtx, url, func(values json.RawMessage) error {
+		var comments []PullRequestComment
+		if err := json.Unmarshal(values, &comments); err != nil {
+			return errors.Wrap(err, "unmarshal")
+		}
+		for _, comment := range comments {
+			commentList = append(commentList, &vcs.PullRequestComment{
+				ID:   strconv.Itoa(comment.ID),
+				Body: comment.Content.Raw,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "fetch comments of pull request %s", pullRequestID)
+	}
+	return commentList, nil
+}
+
+// UpdatePullRequestComment updates a comment on the pull request.
+//
+// Docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-pullrequests/#api-repositories-workspace-repo-slug-pullrequests-pull-request-id-comments-comment-id-put
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, pullRequestID, commentID, comment string) error {
+	pullRequestComment := PullRequestComment{}
+	pullRequestComment.Content.Raw = comment
+	payload, err := json.Marshal(pullRequestComment)
+	if err != nil {
+		return errors.Wrap(err, "marshal pull request comment")
+	}
+
+	url := fmt.Sprintf("%s/repositories/%s/pullrequests/%s/comments/%s", apiURL, repositoryID, pullRequestID, commentID)
+	code, body, err := oauth.Put(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to update pull request comment through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to update pull request comment through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// splitRepositoryID splits the repository full name into the workspace and
+// the repository slug.
+func splitRepositoryID(repositoryID string) (workspace string, repoSlug string, err error) {
+	parts := strings.Split(repositoryID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("invalid repository ID %q, want the form of {workspace}/{repo_slug}", repositoryID)
+	}
+	return parts[0], parts[1], nil
+}
+
+// escapeFilePath escapes each segment of the file path while keeping the path
+// separators.
+func escapeFilePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// oauthContext is the request context for refreshing oauth token.
+type oauthContext struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+}
+
+func tokenRefresher(oauthCtx oauthContext, refresher common.TokenRefresher) oauth.TokenRefresher {
+	return func(ctx context.Context, client *http.Client, oldToken *string) error {
+		form := url.Values{}
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", oauthCtx.RefreshToken)
+		r, err := requestOAuthToken(ctx, client, oauthCtx.ClientID, oauthCtx.ClientSecret, form)
+		if err != nil {
+			return errors.Wrap(err, "refresh OAuth token")
+		}
+
+		// Update the old token to new value for retries.
+		*oldToken = r.AccessToken
+
+		var expireAt int64
+		if r.ExpiresIn != 0 {
+			expireAt = time.Now().Unix() + r.ExpiresIn
+		}
+		return refresher(r.AccessToken, r.RefreshToken, expireAt)
+	}
+}
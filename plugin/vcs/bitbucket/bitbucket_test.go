@@ -0,0 +1,237 @@
+package bitbucket
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/plugin/vcs"
+)
+
+func TestProvider_ExchangeOAuthToken(t *testing.T) {
+	p := newProvider(
+		vcs.ProviderConfig{
+			Client: &http.Client{
+				Transport: &common.MockRoundTripper{
+					MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/site/oauth2/access_token", r.URL.Path)
+						clientID, clientSecret, ok := r.BasicAuth()
+						assert.True(t, ok)
+						assert.Equal(t, "test_client_id", clientID)
+						assert.Equal(t, "test_client_secret", clientSecret)
+
+						body, err := io.ReadAll(r.Body)
+						require.NoError(t, err)
+						assert.Equal(t, "code=test_code&grant_type=authorization_code", string(body))
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body: io.NopCloser(strings.NewReader(`
+{
+  "access_token": "test_access_token",
+  "scopes": "repository:admin webhook account",
+  "expires_in": 7200,
+  "refresh_token": "test_refresh_token",
+  "token_type": "bearer"
+}
+`)),
+						}, nil
+					},
+				},
+			},
+		},
+	)
+
+	ctx := context.Background()
+	got, err := p.ExchangeOAuthToken(ctx, "",
+		&common.OAuthExchange{
+			ClientID:     "test_client_id",
+			ClientSecret: "test_client_secret",
+			Code:         "test_code",
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "test_access_token", got.AccessToken)
+	assert.Equal(t, "test_refresh_token", got.RefreshToken)
+	assert.Equal(t, got.CreatedAt+7200, got.ExpiresTs)
+}
+
+func TestProvider_FetchCommitByID(t *testing.T) {
+	p := newProvider(
+		vcs.ProviderConfig{
+			Client: &http.Client{
+				Transport: &common.MockRoundTripper{
+					MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/2.0/repositories/octocat/hello-world/commit/7638417", r.URL.Path)
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body: io.NopCloser(strings.NewReader(`
+{
+  "type": "commit",
+  "hash": "7638417",
+  "date": "2014-11-07T22:01:45+00:00",
+  "message": "Add migrations\n",
+  "author": {
+    "type": "author",
+    "raw": "Monalisa Octocat <octocat@example.com>"
+  }
+}
+`)),
+						}, nil
+					},
+				},
+			},
+		},
+	)
+
+	ctx := context.Background()
+	got, err := p.FetchCommitByID(ctx, common.OauthContext{}, "", "octocat/hello-world", "7638417")
+	require.NoError(t, err)
+
+	want := &vcs.Commit{
+		ID:         "7638417",
+		AuthorName: "Monalisa Octocat",
+		CreatedTs:  1415397705,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_FetchCommitAddedFileList(t *testing.T) {
+	p := newProvider(
+		vcs.ProviderConfig{
+			Client: &http.Client{
+				Transport: &common.MockRoundTripper{
+					MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/2.0/repositories/octocat/hello-world/diffstat/7638417", r.URL.Path)
+						// The first page links to the second page.
+						if r.URL.Query().Get("page") == "" {
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Body: io.NopCloser(strings.NewReader(`
+{
+  "pagelen": 1,
+  "values": [
+    {"type": "diffstat", "status": "added", "old": null, "new": {"path": "bytebase/prod/db1__1__migrate__init.sql"}},
+    {"type": "diffstat", "status": "modified", "old": {"path": "README.md"}, "new": {"path": "README.md"}}
+  ],
+  "next": "https://api.bitbucket.org/2.0/repositories/octocat/hello-world/diffstat/7638417?page=2"
+}
+`)),
+							}, nil
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body: io.NopCloser(strings.NewReader(`
+{
+  "pagelen": 1,
+  "values": [
+    {"type": "diffstat", "status": "removed", "old": {"path": "old.sql"}, "new": null},
+    {"type": "diffstat", "status": "added", "old": null, "new": {"path": "bytebase/prod/db1__2__data__seed.sql"}}
+  ]
+}
+`)),
+						}, nil
+					},
+				},
+			},
+		},
+	)
+
+	ctx := context.Background()
+	got, err := p.FetchCommitAddedFileList(ctx, common.OauthContext{}, "", "octocat/hello-world", "7638417")
+	require.NoError(t, err)
+
+	want := []string{
+		"bytebase/prod/db1__1__migrate__init.sql",
+		"bytebase/prod/db1__2__data__seed.sql",
+	}
+	assert.Equal(t, want, got)
+
+	_, gotModified, err := p.(*Provider).FetchCommitFileList(ctx, common.OauthContext{}, "octocat/hello-world", "7638417")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"README.md"}, gotModified)
+}
+
+func TestProvider_FetchCommitRange(t *testing.T) {
+	p := newProvider(
+		vcs.ProviderConfig{
+			Client: &http.Client{
+				Transport: &common.MockRoundTripper{
+					MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/2.0/repositories/octocat/hello-world/commits", r.URL.Path)
+						assert.Equal(t, "c3", r.URL.Query().Get("include"))
+						assert.Equal(t, "c0", r.URL.Query().Get("exclude"))
+						if r.URL.Query().Get("page") == "" {
+							return &http.Response{
+								StatusCode: http.StatusOK,
+								Body: io.NopCloser(strings.NewReader(`
+{
+  "values": [{"hash": "c3"}, {"hash": "c2"}],
+  "next": "https://api.bitbucket.org/2.0/repositories/octocat/hello-world/commits?include=c3&exclude=c0&page=2"
+}
+`)),
+							}, nil
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(strings.NewReader(`{"values": [{"hash": "c1"}]}`)),
+						}, nil
+					},
+				},
+			},
+		},
+	)
+
+	ctx := context.Background()
+	got, err := p.(*Provider).FetchCommitRange(ctx, common.OauthContext{}, "octocat/hello-world", "c3", "c0")
+	require.NoError(t, err)
+
+	var gotHashList []string
+	for _, commit := range got {
+		gotHashList = append(gotHashList, commit.Hash)
+	}
+	assert.Equal(t, []string{"c3", "c2", "c1"}, gotHashList)
+}
+
+func TestCommit_AuthorName(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit Commit
+		want   string
+	}{
+		{
+			name: "raw author",
+			commit: Commit{Author: struct {
+				Raw  string `json:"raw"`
+				User *User  `json:"user"`
+			}{Raw: "Monalisa Octocat <octocat@example.com>"}},
+			want: "Monalisa Octocat",
+		},
+		{
+			name: "linked user",
+			commit: Commit{Author: struct {
+				Raw  string `json:"raw"`
+				User *User  `json:"user"`
+			}{Raw: "octocat <octocat@example.com>", User: &User{DisplayName: "Monalisa Octocat"}}},
+			want: "Monalisa Octocat",
+		},
+		{
+			name: "raw author without email",
+			commit: Commit{Author: struct {
+				Raw  string `json:"raw"`
+				User *User  `json:"user"`
+			}{Raw: "octocat"}},
+			want: "octocat",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.commit.AuthorName())
+		})
+	}
+}
@@ -0,0 +1,1309 @@
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/plugin/vcs"
+	"github.com/youzi-1122/bytebase/plugin/vcs/internal/oauth"
+)
+
+const (
+	// apiPath is the API path.
+	apiPath = "api/v1"
+	// apiPageSize is the default page size when making API requests.
+	apiPageSize = 50
+)
+
+func init() {
+	vcs.Register(vcs.GiteaSelfHost, newProvider)
+}
+
+var _ vcs.Provider = (*Provider)(nil)
+
+// Provider is a Gitea self host VCS provider.
+type Provider struct {
+	client *http.Client
+}
+
+func newProvider(config vcs.ProviderConfig) vcs.Provider {
+	if config.Client == nil {
+		config.Client = &http.Client{}
+	}
+	return &Provider{
+		client: config.Client,
+	}
+}
+
+// APIURL returns the API URL path of a Gitea instance.
+func (p *Provider) APIURL(instanceURL string) string {
+	return fmt.Sprintf("%s/%s", instanceURL, apiPath)
+}
+
+// WebhookType is the Gitea webhook event type, which is delivered in the
+// "X-Gitea-Event" header.
+type WebhookType string
+
+const (
+	// WebhookPush is the webhook type for push.
+	WebhookPush WebhookType = "push"
+	// WebhookPullRequest is the webhook type for pull request.
+	WebhookPullRequest WebhookType = "pull_request"
+)
+
+// WebhookInfo is the API message for webhook info.
+type WebhookInfo struct {
+	ID int `json:"id"`
+}
+
+// WebhookConfig is the API message for webhook configuration.
+type WebhookConfig struct {
+	// URL is the URL to which the payloads will be delivered.
+	URL string `json:"url"`
+	// ContentType is the media type used to serialize the payloads. Supported
+	// values include "json" and "form".
+	ContentType string `json:"content_type"`
+	// Secret is the secret used to compute the HMAC hex digest of the payload,
+	// which is delivered in the "X-Gitea-Signature" header.
+	Secret string `json:"secret,omitempty"`
+}
+
+// WebhookCreate is the API message for creating a webhook.
+type WebhookCreate struct {
+	// Type is the type of the webhook, we always use the native "gitea" type.
+	Type   string        `json:"type"`
+	Config WebhookConfig `json:"config"`
+	// Events is the list of events the webhook is triggered for, we only need
+	// the "push" and "pull_request" events.
+	Events       []string `json:"events"`
+	BranchFilter string   `json:"branch_filter"`
+	Active       bool     `json:"active"`
+}
+
+// WebhookPatch is the API message for patching a webhook.
+type WebhookPatch struct {
+	Config WebhookConfig `json:"config"`
+	// Events is the list of events the webhook is triggered for, the events
+	// are left unchanged if it is empty.
+	Events       []string `json:"events,omitempty"`
+	BranchFilter string   `json:"branch_filter"`
+}
+
+// WebhookRepository is the API message for webhook repository.
+type WebhookRepository struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// WebhookUser is the API message for webhook user.
+type WebhookUser struct {
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+}
+
+// WebhookCommitAuthor is the API message for webhook commit author.
+type WebhookCommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookCommit is the API message for webhook commit.
+type WebhookCommit struct {
+	ID        string              `json:"id"`
+	Message   string              `json:"message"`
+	Timestamp string              `json:"timestamp"`
+	URL       string              `json:"url"`
+	Author    WebhookCommitAuthor `json:"author"`
+	Added     []string            `json:"added"`
+	Modified  []string            `json:"modified"`
+}
+
+// WebhookPushEvent is the API message for webhook push event.
+type WebhookPushEvent struct {
+	Ref        string            `json:"ref"`
+	Repository WebhookRepository `json:"repository"`
+	Pusher     WebhookUser       `json:"pusher"`
+	Commits    []WebhookCommit   `json:"commits"`
+}
+
+// PullRequestBranch is the API message for the branch of a pull request.
+type PullRequestBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// PullRequest is the API message for a pull request.
+type PullRequest struct {
+	HTMLURL string            `json:"html_url"`
+	Head    PullRequestBranch `json:"head"`
+	Base    PullRequestBranch `json:"base"`
+}
+
+// WebhookPullRequestEvent is the API message for webhook pull request event.
+type WebhookPullRequestEvent struct {
+	// Action can be one of "opened", "synchronized", "reopened", "closed",
+	// "edited" and other actions we are not interested in.
+	Action      string            `json:"action"`
+	Number      int               `json:"number"`
+	PullRequest PullRequest       `json:"pull_request"`
+	Repository  WebhookRepository `json:"repository"`
+}
+
+// IssueComment is the API message for an issue comment, a pull request is an
+// issue as well in Gitea.
+type IssueComment struct {
+	ID   int64  `json:"id,omitempty"`
+	Body string `json:"body"`
+}
+
+// User represents a Gitea API response for a user.
+type User struct {
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+}
+
+// Repository represents a Gitea API response for a repository.
+type Repository struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	FullName    string `json:"full_name"`
+	HTMLURL     string `json:"html_url"`
+	Permissions struct {
+		Admin bool `json:"admin"`
+	} `json:"permissions"`
+}
+
+// Commit represents a Gitea API response for a commit.
+type Commit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			// Date expects corresponding JSON value is a string in RFC 3339 format,
+			// see https://pkg.go.dev/time#Time.MarshalJSON.
+			Date time.Time `json:"date"`
+			Name string    `json:"name"`
+		} `json:"author"`
+	} `json:"commit"`
+	Files []CommitFile `json:"files"`
+}
+
+// CommitFile represents a Gitea API response for a file changed by a commit.
+type CommitFile struct {
+	Filename string `json:"filename"`
+	// Status is the status of the file, can be one of "added", "removed" and
+	// "modified".
+	Status string `json:"status"`
+}
+
+// RepositoryTree represents a Gitea API response for a repository tree.
+type RepositoryTree struct {
+	Tree []struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+	} `json:"tree"`
+	Truncated bool `json:"truncated"`
+}
+
+// File represents a Gitea API response for a repository file.
+type File struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Size     int64  `json:"size"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	SHA      string `json:"sha"`
+}
+
+// FileCommit is the API message for file commit.
+type FileCommit struct {
+	Branch  string `json:"branch"`
+	Content string `json:"content"`
+	Message string `json:"message"`
+	// SHA is the blob SHA of the file being replaced, it is only required when
+	// updating an existing file.
+	SHA string `json:"sha,omitempty"`
+}
+
+// oauthResponse is a Gitea OAuth response.
+type oauthResponse struct {
+	AccessToken      string `json:"access_token" `
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int64  `json:"expires_in"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// toVCSOAuthToken converts the response to *vcs.OAuthToken.
+func (o oauthResponse) toVCSOAuthToken(createdAt int64) *vcs.OAuthToken {
+	oauthToken := &vcs.OAuthToken{
+		AccessToken:  o.AccessToken,
+		RefreshToken: o.RefreshToken,
+		ExpiresIn:    o.ExpiresIn,
+		CreatedAt:    createdAt,
+	}
+	if oauthToken.ExpiresIn != 0 {
+		oauthToken.ExpiresTs = oauthToken.CreatedAt + oauthToken.ExpiresIn
+	}
+	return oauthToken
+}
+
+// ExchangeOAuthToken exchanges OAuth content with the provided authorization code.
+//
+// Docs: https://docs.gitea.io/en-us/oauth2-provider/
+func (p *Provider) ExchangeOAuthToken(ctx context.Context, instanceURL string, oauthExchange *common.OAuthExchange) (*vcs.OAuthToken, error) {
+	body, err := json.Marshal(
+		map[string]string{
+			"client_id":     oauthExchange.ClientID,
+			"client_secret": oauthExchange.ClientSecret,
+			"code":          oauthExchange.Code,
+			"redirect_uri":  oauthExchange.RedirectURL,
+			"grant_type":    "authorization_code",
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal OAuth exchange")
+	}
+
+	url := fmt.Sprintf("%s/login/oauth/access_token", instanceURL)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrapf(err, "construct POST %s", url)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange OAuth token, error: %v", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OAuth response body, code %v, error: %v", resp.StatusCode, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	oauthResp := new(oauthResponse)
+	if err := json.Unmarshal(respBody, oauthResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OAuth response body, code %v, error: %v", resp.StatusCode, err)
+	}
+	if oauthResp.Error != "" {
+		return nil, fmt.Errorf("failed to exchange OAuth token, error: %v, error_description: %v", oauthResp.Error, oauthResp.ErrorDescription)
+	}
+	return oauthResp.toVCSOAuthToken(time.Now().Unix()), nil
+}
+
+// fetchUserInfo fetches user information from the given resourceURI, which
+// should be either "user" or "users/{username}".
+func (p *Provider) fetchUserInfo(ctx context.Context, oauthCtx common.OauthContext, instanceURL, resourceURI string) (*vcs.UserInfo, error) {
+	url := fmt.Sprintf("%s/%s", p.APIURL(instanceURL), resourceURI)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "GET")
+	}
+
+	if code == http.StatusNotFound {
+		errInfo := []string{fmt.Sprintf("failed to fetch user info from Gitea instance %s", instanceURL)}
+		resourceURISplit := strings.Split(resourceURI, "/")
+		if len(resourceURISplit) > 1 {
+			errInfo = append(errInfo, fmt.Sprintf("Username: %s", resourceURISplit[1]))
+		}
+		return nil, common.Errorf(common.NotFound, fmt.Errorf(strings.Join(errInfo, ", ")))
+	} else if code >= 300 {
+		return nil, fmt.Errorf("failed to read user info from Gitea instance %s, status code: %d", instanceURL, code)
+	}
+
+	var user User
+	if err := json.Unmarshal([]byte(body), &user); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+	name := user.FullName
+	if name == "" {
+		name = user.Login
+	}
+	return &vcs.UserInfo{
+		PublicEmail: user.Email,
+		Name:        name,
+		State:       vcs.StateActive,
+	}, nil
+}
+
+// TryLogin tries to fetch the user info from the current OAuth context.
+func (p *Provider) TryLogin(ctx context.Context, oauthCtx common.OauthContext, instanceURL string) (*vcs.UserInfo, error) {
+	return p.fetchUserInfo(ctx, oauthCtx, instanceURL, "user")
+}
+
+// FetchUserInfo fetches user info of given username.
+func (p *Provider) FetchUserInfo(ctx context.Context, oauthCtx common.OauthContext, instanceURL, username string) (*vcs.UserInfo, error) {
+	return p.fetchUserInfo(ctx, oauthCtx, instanceURL, fmt.Sprintf("users/%s", username))
+}
+
+// fetchCommit fetches the commit data by its ID from the repository.
+func (p *Provider) fetchCommit(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) (*Commit, error) {
+	url := fmt.Sprintf("%s/repos/%s/git/commits/%s", p.APIURL(instanceURL), repositoryID, commitID)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "GET")
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch commit data from Gitea instance %s, not found", instanceURL))
+	} else if code >= 300 {
+		return nil, fmt.Errorf("failed to fetch commit data from Gitea instance %s, status code: %d, body: %s", instanceURL, code, body)
+	}
+
+	commit := &Commit{}
+	if err := json.Unmarshal([]byte(body), commit); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal commit data from Gitea instance %s, err: %w", instanceURL, err)
+	}
+	return commit, nil
+}
+
+// FetchCommitByID fetches the commit data by its ID from the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetSingleCommit
+func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) (*vcs.Commit, error) {
+	commit, err := p.fetchCommit(ctx, oauthCtx, instanceURL, repositoryID, commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vcs.Commit{
+		ID:         commit.SHA,
+		AuthorName: commit.Commit.Author.Name,
+		CreatedTs:  commit.Commit.Author.Date.Unix(),
+	}, nil
+}
+
+// FetchCommitAddedFileList fetches the list of file paths added by the commit.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetSingleCommit
+func (p *Provider) FetchCommitAddedFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) ([]string, error) {
+	commit, err := p.fetchCommit(ctx, oauthCtx, instanceURL, repositoryID, commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	var addedList []string
+	for _, file := range commit.Files {
+		if file.Status == "added" {
+			addedList = append(addedList, file.Filename)
+		}
+	}
+	return addedList, nil
+}
+
+// RepositoryCollaboratorPermission represents a Gitea API response for the
+// permission of a repository collaborator.
+type RepositoryCollaboratorPermission struct {
+	// Permission can be one of "owner", "admin", "write" and "read".
+	Permission string `json:"permission"`
+}
+
+// RepositoryRole is the role of the repository collaborator.
+type RepositoryRole string
+
+// The list of Gitea roles.
+const (
+	RepositoryRoleOwner RepositoryRole = "owner"
+	RepositoryRoleAdmin RepositoryRole = "admin"
+	RepositoryRoleWrite RepositoryRole = "write"
+	RepositoryRoleRead  RepositoryRole = "read"
+)
+
+func getRoleAndMappedRole(permission string) (giteaRole RepositoryRole, bytebaseRole common.ProjectRole) {
+	// Please refer to https://docs.gitea.io/en-us/permissions/ for the detailed
+	// permission descriptions of Gitea.
+	switch permission {
+	case "owner":
+		return RepositoryRoleOwner, common.ProjectOwner
+	case "admin":
+		return RepositoryRoleAdmin, common.ProjectOwner
+	case "write":
+		return RepositoryRoleWrite, common.ProjectDeveloper
+	case "read":
+		return RepositoryRoleRead, common.ProjectDeveloper
+	}
+	return "", ""
+}
+
+// FetchRepositoryActiveMemberList fetches all active members of a repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoListCollaborators
+func (p *Provider) FetchRepositoryActiveMemberList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string) ([]*vcs.RepositoryMember, error) {
+	var allCollaborators []User
+	page := 1
+	for {
+		collaborators, hasNextPage, err := p.fetchPaginatedRepositoryCollaborators(ctx, oauthCtx, instanceURL, repositoryID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		allCollaborators = append(allCollaborators, collaborators...)
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+
+	var emptyEmailUserList []string
+	var allMembers []*vcs.RepositoryMember
+	for _, c := range allCollaborators {
+		name := c.FullName
+		if name == "" {
+			name = c.Login
+		}
+		if c.Email == "" {
+			emptyEmailUserList = append(emptyEmailUserList, name)
+			continue
+		}
+
+		permission, err := p.fetchRepositoryCollaboratorPermission(ctx, oauthCtx, instanceURL, repositoryID, c.Login)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch permission, login: %s", c.Login)
+		}
+		giteaRole, bytebaseRole := getRoleAndMappedRole(permission)
+		allMembers = append(allMembers,
+			&vcs.RepositoryMember{
+				Name:         name,
+				Email:        c.Email,
+				Role:         bytebaseRole,
+				VCSRole:      string(giteaRole),
+				State:        vcs.StateActive,
+				RoleProvider: vcs.GiteaSelfHost,
+			},
+		)
+	}
+
+	if len(emptyEmailUserList) != 0 {
+		return nil, fmt.Errorf("[ %v ] did not configure their public email in Gitea, please make sure every members' public email is configured before syncing", strings.Join(emptyEmailUserList, ", "))
+	}
+
+	return allMembers, nil
+}
+
+// fetchPaginatedRepositoryCollaborators fetches collaborators of a repository
+// in given page. It return the paginated results along with a boolean
+// indicating whether the next page exists.
+func (p *Provider) fetchPaginatedRepositoryCollaborators(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, page int) (collaborators []User, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/collaborators?page=%d&limit=%d", p.APIURL(instanceURL), repositoryID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch repository collaborators from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to read repository collaborators from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &collaborators); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return collaborators, len(collaborators) >= apiPageSize, nil
+}
+
+// fetchRepositoryCollaboratorPermission fetches the permission of the
+// collaborator in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetRepoPermissions
+func (p *Provider) fetchRepositoryCollaboratorPermission(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, login string) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/collaborators/%s/permission", p.APIURL(instanceURL), repositoryID, login)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, fmt.Errorf("failed to fetch collaborator permission from URL %s", url))
+	} else if code >= 300 {
+		return "", fmt.Errorf("failed to fetch collaborator permission from URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	var permission RepositoryCollaboratorPermission
+	if err := json.Unmarshal([]byte(body), &permission); err != nil {
+		return "", errors.Wrap(err, "unmarshal body")
+	}
+	return permission.Permission, nil
+}
+
+// FetchAllRepositoryList fetches all repositories where the authenticated user
+// has the admin permission, which is required to create webhook in the
+// repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/user/userCurrentListRepos
+func (p *Provider) FetchAllRepositoryList(ctx context.Context, oauthCtx common.OauthContext, instanceURL string) ([]*vcs.Repository, error) {
+	var giteaRepos []Repository
+	page := 1
+	for {
+		repos, hasNextPage, err := p.fetchPaginatedRepositoryList(ctx, oauthCtx, instanceURL, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		giteaRepos = append(giteaRepos, repos...)
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+
+	var allRepos []*vcs.Repository
+	for _, r := range giteaRepos {
+		if !r.Permissions.Admin {
+			continue
+		}
+		allRepos = append(allRepos,
+			&vcs.Repository{
+				ID:       r.ID,
+				Name:     r.Name,
+				FullPath: r.FullName,
+				WebURL:   r.HTMLURL,
+			},
+		)
+	}
+	return allRepos, nil
+}
+
+// fetchPaginatedRepositoryList fetches repositories of the authenticated user
+// in given page. It return the paginated results along with a boolean
+// indicating whether the next page exists.
+func (p *Provider) fetchPaginatedRepositoryList(ctx context.Context, oauthCtx common.OauthContext, instanceURL string, page int) (repos []Repository, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/user/repos?page=%d&limit=%d", p.APIURL(instanceURL), page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch repository list from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch repository list from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &repos); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal")
+	}
+	return repos, len(repos) >= apiPageSize, nil
+}
+
+// FetchRepositoryFileList fetches the files from repository tree.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/GetTree
+func (p *Provider) FetchRepositoryFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, ref, filePath string) ([]*vcs.RepositoryTreeNode, error) {
+	var fileList []*vcs.RepositoryTreeNode
+	page := 1
+	for {
+		tree, err := p.fetchPaginatedRepositoryTree(ctx, oauthCtx, instanceURL, repositoryID, ref, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated tree")
+		}
+		// Filter out folder nodes and files not under the given path, we only
+		// need the file nodes.
+		for _, node := range tree.Tree {
+			if node.Type == "blob" && strings.HasPrefix(node.Path, filePath) {
+				fileList = append(fileList, &vcs.RepositoryTreeNode{
+					Path: node.Path,
+					Type: node.Type,
+				})
+			}
+		}
+
+		if !tree.Truncated {
+			break
+		}
+		page++
+	}
+	return fileList, nil
+}
+
+// fetchPaginatedRepositoryTree fetches the recursive repository tree in given
+// page. The tree is truncated if the next page exists.
+func (p *Provider) fetchPaginatedRepositoryTree(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, ref string, page int) (*RepositoryTree, error) {
+	url := fmt.Sprintf("%s/repos/%s/git/trees/%s?recursive=true&page=%d&per_page=%d", p.APIURL(instanceURL), repositoryID, url.PathEscape(ref), page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch repository tree from URL %s", url))
+	} else if code >= 300 {
+		return nil, fmt.Errorf("failed to fetch repository tree from URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	tree := &RepositoryTree{}
+	if err := json.Unmarshal([]byte(body), tree); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	return tree, nil
+}
+
+// CreateFile creates a file.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoCreateFile
+func (p *Provider) CreateFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	body, err := json.Marshal(
+		FileCommit{
+			Branch:  fileCommitCreate.Branch,
+			Content: base64.StdEncoding.EncodeToString([]byte(fileCommitCreate.Content)),
+			Message: fileCommitCreate.CommitMessage,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "marshal file commit")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/contents/%s", p.APIURL(instanceURL), repositoryID, escapeFilePath(filePath))
+	code, respBody, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code >= 300 {
+		return fmt.Errorf("failed to create file through URL %s, status code: %d, body: %s", url, code, respBody)
+	}
+	return nil
+}
+
+// OverwriteFile overwrites an existing file. The "LastCommitID" of the
+// fileCommit should be the blob SHA of the file, which is returned as the
+// "LastCommitID" by ReadFileMeta.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoUpdateFile
+func (p *Provider) OverwriteFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath string, fileCommitCreate vcs.FileCommitCreate) error {
+	body, err := json.Marshal(
+		FileCommit{
+			Branch:  fileCommitCreate.Branch,
+			Content: base64.StdEncoding.EncodeToString([]byte(fileCommitCreate.Content)),
+			Message: fileCommitCreate.CommitMessage,
+			SHA:     fileCommitCreate.LastCommitID,
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "marshal file commit")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/contents/%s", p.APIURL(instanceURL), repositoryID, escapeFilePath(filePath))
+	code, respBody, err := oauth.Put(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(body),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code >= 300 {
+		return fmt.Errorf("failed to overwrite file through URL %s, status code: %d, body: %s", url, code, respBody)
+	}
+	return nil
+}
+
+// ReadFileMeta reads the file metadata.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetContents
+func (p *Provider) ReadFileMeta(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (*vcs.FileMeta, error) {
+	file, err := p.readFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read file metadata %s", filePath)
+	}
+
+	return &vcs.FileMeta{
+		Name: file.Name,
+		Path: file.Path,
+		Size: file.Size,
+		// Gitea identifies a file version by its blob SHA, which is also what it
+		// requires to overwrite the file.
+		LastCommitID: file.SHA,
+	}, nil
+}
+
+// ReadFileContent reads the file content.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetContents
+func (p *Provider) ReadFileContent(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (string, error) {
+	file, err := p.readFile(ctx, oauthCtx, instanceURL, repositoryID, filePath, ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "read file content %s", filePath)
+	}
+	return file.Content, nil
+}
+
+// readFile reads the file data including metadata and content.
+func (p *Provider) readFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (*File, error) {
+	url := fmt.Sprintf("%s/repos/%s/contents/%s?ref=%s", p.APIURL(instanceURL), repositoryID, escapeFilePath(filePath), url.QueryEscape(ref))
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("failed to read file data from URL %s", url))
+	} else if code >= 300 {
+		return nil, fmt.Errorf("failed to read file data from URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	file := &File{}
+	if err := json.Unmarshal([]byte(body), file); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	if file.Type != "file" {
+		return nil, fmt.Errorf("%q is not a file, got type %q", filePath, file.Type)
+	}
+
+	if file.Encoding == "base64" {
+		decodedContent, err := base64.StdEncoding.DecodeString(file.Content)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode file content")
+		}
+		file.Content = string(decodedContent)
+	}
+	return file, nil
+}
+
+// escapeFilePath escapes each segment of the file path while keeping the path
+// separators.
+func escapeFilePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// CreateWebhook creates a webhook in the repository. It returns the created
+// webhook ID on success.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoCreateHook
+func (p *Provider) CreateWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, payload []byte) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/hooks", p.APIURL(instanceURL), repositoryID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, fmt.Errorf("failed to create webhook through URL %s", url))
+	} else if code >= 300 {
+		reason := fmt.Sprintf("failed to create webhook through URL %s, status code: %d, body: %s", url, code, body)
+		// Gitea only delivers webhooks to the hosts listed in the "ALLOWED_HOST_LIST" setting.
+		if code == http.StatusUnprocessableEntity {
+			reason += ".\n\nIf Gitea and Bytebase are in the same private network, " +
+				"please add the Bytebase host to the [webhook] ALLOWED_HOST_LIST setting of Gitea, see https://docs.gitea.io/en-us/config-cheat-sheet/#webhook-webhook"
+		}
+		return "", fmt.Errorf(reason)
+	}
+
+	webhookInfo := &WebhookInfo{}
+	if err := json.Unmarshal([]byte(body), webhookInfo); err != nil {
+		return "", errors.Wrap(err, "unmarshal body")
+	}
+	return strconv.Itoa(webhookInfo.ID), nil
+}
+
+// PatchWebhook patches a webhook in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoEditHook
+func (p *Provider) PatchWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string, payload []byte) error {
+	url := fmt.Sprintf("%s/repos/%s/hooks/%s", p.APIURL(instanceURL), repositoryID, webhookID)
+	code, body, err := oauth.Patch(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to patch webhook through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to patch webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// DeleteWebhook deletes a webhook in the repository.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoDeleteHook
+func (p *Provider) DeleteWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string) error {
+	url := fmt.Sprintf("%s/repos/%s/hooks/%s", p.APIURL(instanceURL), repositoryID, webhookID)
+	code, body, err := oauth.Delete(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "DELETE %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to delete webhook through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to delete webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// ListPullRequestFile lists the files changed by the pull request.
+//
+// Docs: https://try.gitea.io/api/swagger#/repository/repoGetPullRequestFiles
+func (p *Provider) ListPullRequestFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*vcs.PullRequestFile, error) {
+	var fileList []*vcs.PullRequestFile
+	page := 1
+	for {
+		files, hasNextPage, err := p.fetchPaginatedPullRequestFileList(ctx, oauthCtx, instanceURL, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		for _, file := range files {
+			fileList = append(fileList, &vcs.PullRequestFile{
+				Path:      file.Filename,
+				IsDeleted: file.Status == "removed",
+			})
+		}
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+	return fileList, nil
+}
+
+// fetchPaginatedPullRequestFileList fetches files changed by the pull request
+// in given page. It return the paginated results along with a boolean
+// indicating whether the next page exists.
+func (p *Provider) fetchPaginatedPullRequestFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string, page int) (files []CommitFile, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls/%s/files?page=%d&limit=%d", p.APIURL(instanceURL), repositoryID, pullRequestID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch pull request files from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch pull request files from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &files); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return files, len(files) >= apiPageSize, nil
+}
+
+// CreatePullRequestComment creates a comment on the pull request.
+//
+// Docs: https://try.gitea.io/api/swagger#/issue/issueCreateComment
+func (p *Provider) CreatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID, comment string) error {
+	payload, err := json.Marshal(IssueComment{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "marshal issue comment")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments", p.APIURL(instanceURL), repositoryID, pullRequestID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to create pull request comment through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to create pull request comment through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// ListPullRequestComment lists the comments on the pull request.
+//
+// Docs: https://try.gitea.io/api/swagger#/issue/issueGetComments
+func (p *Provider) ListPullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	var commentList []*vcs.PullRequestComment
+	page := 1
+	for {
+		comments, hasNextPage, err := p.fetchPaginatedPullRequestCommentList(ctx, oauthCtx, instanceURL, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		for _, comment := range comments {
+			commentList = append(commentList, &vcs.PullRequestComment{
+				ID:   strconv.FormatInt(comment.ID, 10),
+				Body: comment.Body,
+			})
+		}
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+	return commentList, nil
+}
+
+// fetchPaginatedPullRequestCommentList fetches comments on the pull request in
+// given page. It return the paginated results along with a boolean indicating
+// whether the next page exists.
+func (p *Provider) fetchPaginatedPullRequestCommentList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string, page int) (comments []IssueComment, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments?page=%d&limit=%d", p.APIURL(instanceURL), repositoryID, pullRequestID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch pull request comments from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch pull request comments from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &comments); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return comments, len(comments) >= apiPageSize, nil
+}
+
+// UpdatePullRequestComment updates a comment on the pull request.
+//
+// Docs: https://try.gitea.io/api/swagger#/issue/issueEditComment
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, _, commentID, comment string) error {
+	payload, err := json.Marshal(IssueComment{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "marshal issue comment")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/issues/comments/%s", p.APIURL(instanceURL), repositoryID, commentID)
+	code, body, err := oauth.Patch(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to update pull request comment through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to update pull request comment through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// oauthContext is the request context for refreshing oauth token.
+type oauthContext struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	RefreshToken string `json:"refresh_token"`
+	GrantType    string `json:"grant_type"`
+}
+
+type refreshOAuthResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	// token_type is not used.
+}
+
+func tokenRefresher(instanceURL string, oauthCtx oauthContext, refresher common.TokenRefresher) oauth.TokenRefresher {
+	return func(ctx context.Context, client *http.Client, oldToken *string) error {
+		url := fmt.Sprintf("%s/login/oauth/access_token", instanceURL)
+		oauthCtx.GrantType = "refresh_token"
+		body, err := json.Marshal(oauthCtx)
+		if err != nil {
+			return err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return errors.Wrapf(err, "construct POST %s", url)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "POST %s", url)
+		}
+
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrapf(err, "read body of POST %s", url)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("non-200 status code %d with body %q", resp.StatusCode, body)
+		}
+
+		var r refreshOAuthResponse
+		if err = json.Unmarshal(body, &r); err != nil {
+			return errors.Wrapf(err, "unmarshal body from POST %s", url)
+		}
+
+		// Update the old token to new value for retries.
+		*oldToken = r.AccessToken
+
+		var expireAt int64
+		if r.ExpiresIn != 0 {
+			expireAt = time.Now().Unix() + r.ExpiresIn
+		}
+		if err = refresher(r.AccessToken, r.RefreshToken, expireAt); err != nil {
+			return err
+		}
+		return nil
+	}
+}
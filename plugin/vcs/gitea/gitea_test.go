@@ -0,0 +1,173 @@
+package gitea
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/plugin/vcs"
+	"github.com/youzi-1122/bytebase/plugin/vcs/internal/oauth"
+)
+
+func TestProvider_FetchUserInfo(t *testing.T) {
+	p := newProvider(
+		vcs.ProviderConfig{
+			Client: &http.Client{
+				Transport: &common.MockRoundTripper{
+					MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/api/v1/users/gitea", r.URL.Path)
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body: io.NopCloser(strings.NewReader(`
+{
+  "id": 1,
+  "login": "gitea",
+  "full_name": "Gitea Tea",
+  "email": "gitea@example.com",
+  "avatar_url": "https://gitea.example.com/avatars/1",
+  "language": "en-US",
+  "is_admin": false
+}
+`)),
+						}, nil
+					},
+				},
+			},
+		},
+	)
+
+	ctx := context.Background()
+	got, err := p.FetchUserInfo(ctx, common.OauthContext{}, "https://gitea.example.com", "gitea")
+	require.NoError(t, err)
+
+	want := &vcs.UserInfo{
+		PublicEmail: "gitea@example.com",
+		Name:        "Gitea Tea",
+		State:       vcs.StateActive,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestProvider_FetchCommitAddedFileList(t *testing.T) {
+	p := newProvider(
+		vcs.ProviderConfig{
+			Client: &http.Client{
+				Transport: &common.MockRoundTripper{
+					MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+						assert.Equal(t, "/api/v1/repos/gitea/hello/git/commits/5f3c1e2", r.URL.Path)
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body: io.NopCloser(strings.NewReader(`
+{
+  "sha": "5f3c1e2",
+  "commit": {
+    "message": "Add migrations",
+    "author": {
+      "name": "Gitea Tea",
+      "email": "gitea@example.com",
+      "date": "2022-07-20T10:00:00Z"
+    }
+  },
+  "files": [
+    {"filename": "bytebase/prod/db1__1__migrate__init.sql", "status": "added"},
+    {"filename": "README.md", "status": "modified"},
+    {"filename": "bytebase/prod/db1__2__data__seed.sql", "status": "added"},
+    {"filename": "old.sql", "status": "removed"}
+  ]
+}
+`)),
+						}, nil
+					},
+				},
+			},
+		},
+	)
+
+	ctx := context.Background()
+	got, err := p.FetchCommitAddedFileList(ctx, common.OauthContext{}, "https://gitea.example.com", "gitea/hello", "5f3c1e2")
+	require.NoError(t, err)
+
+	want := []string{
+		"bytebase/prod/db1__1__migrate__init.sql",
+		"bytebase/prod/db1__2__data__seed.sql",
+	}
+	assert.Equal(t, want, got)
+
+	commit, err := p.FetchCommitByID(ctx, common.OauthContext{}, "https://gitea.example.com", "gitea/hello", "5f3c1e2")
+	require.NoError(t, err)
+	assert.Equal(t,
+		&vcs.Commit{
+			ID:         "5f3c1e2",
+			AuthorName: "Gitea Tea",
+			CreatedTs:  1658311200,
+		},
+		commit,
+	)
+}
+
+func TestOAuth_RefreshToken(t *testing.T) {
+	ctx := context.Background()
+	client := &http.Client{
+		Transport: &common.MockRoundTripper{
+			MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+				if r.URL.Path == "/login/oauth/access_token" {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body: io.NopCloser(strings.NewReader(`
+{
+  "access_token": "new_access_token",
+  "token_type": "bearer",
+  "expires_in": 3600,
+  "refresh_token": "new_refresh_token"
+}
+`)),
+					}, nil
+				}
+
+				token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+				if token == "expired" {
+					return &http.Response{
+						StatusCode: http.StatusBadRequest,
+						Body: io.NopCloser(strings.NewReader(`
+					{"error":"invalid_token","error_description":"Token is expired. You can either do re-authorization or token refresh."}
+					`)),
+					}, nil
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{}`)),
+				}, nil
+			},
+		},
+	}
+	token := "expired"
+
+	calledRefresher := false
+	refresher := func(accessToken, refreshToken string, _ int64) error {
+		calledRefresher = true
+		assert.Equal(t, "new_access_token", accessToken)
+		assert.Equal(t, "new_refresh_token", refreshToken)
+		return nil
+	}
+
+	_, _, err := oauth.Get(
+		ctx,
+		client,
+		"https://gitea.example.com/api/v1/user",
+		&token,
+		tokenRefresher(
+			"https://gitea.example.com",
+			oauthContext{},
+			refresher,
+		),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "new_access_token", token)
+	assert.True(t, calledRefresher)
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,6 +25,9 @@ const (
 	apiURL = "https://api.github.com"
 	// apiPageSize is the default page size when making API requests.
 	apiPageSize = 100
+	// WebhookPushEventCommitLimit is the maximum number of commits listed in
+	// the webhook push event, the rest of the pushed commits are left out.
+	WebhookPushEventCommitLimit = 20
 )
 
 func init() {
@@ -84,6 +88,142 @@ type Repository struct {
 	HTMLURL  string `json:"html_url"`
 }
 
+// WebhookType is the GitHub webhook event type, which is delivered in the
+// "X-GitHub-Event" header.
+type WebhookType string
+
+const (
+	// WebhookPing is the webhook type for ping, GitHub sends it right after the
+	// webhook is created.
+	WebhookPing WebhookType = "ping"
+	// WebhookPush is the webhook type for push.
+	WebhookPush WebhookType = "push"
+	// WebhookPullRequest is the webhook type for pull request.
+	WebhookPullRequest WebhookType = "pull_request"
+)
+
+// WebhookInfo is the API message for webhook info.
+type WebhookInfo struct {
+	ID int `json:"id"`
+}
+
+// WebhookConfig is the API message for webhook configuration.
+type WebhookConfig struct {
+	// URL is the URL to which the payloads will be delivered.
+	URL string `json:"url"`
+	// ContentType is the media type used to serialize the payloads. Supported
+	// values include "json" and "form".
+	ContentType string `json:"content_type"`
+	// Secret is the secret used to compute the HMAC hex digest of the payload,
+	// which is delivered in the "X-Hub-Signature-256" header.
+	Secret string `json:"secret,omitempty"`
+	// InsecureSSL determines whether the SSL certificate of the host for URL
+	// will be verified when delivering payloads. Supported values include "0"
+	// (verification is performed) and "1" (verification is not performed).
+	InsecureSSL string `json:"insecure_ssl"`
+}
+
+// WebhookCreateOrUpdate is the API message for creating or updating a webhook.
+type WebhookCreateOrUpdate struct {
+	Config WebhookConfig `json:"config"`
+	// Events is the list of events the webhook is triggered for, we only need
+	// the "push" and "pull_request" events.
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+// WebhookRepository is the API message for webhook repository.
+type WebhookRepository struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// WebhookPusher is the API message for webhook pusher.
+type WebhookPusher struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookCommitAuthor is the API message for webhook commit author.
+type WebhookCommitAuthor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// WebhookCommit is the API message for webhook commit.
+type WebhookCommit struct {
+	ID        string              `json:"id"`
+	Distinct  bool                `json:"distinct"`
+	Message   string              `json:"message"`
+	Timestamp string              `json:"timestamp"`
+	URL       string              `json:"url"`
+	Author    WebhookCommitAuthor `json:"author"`
+	Added     []string            `json:"added"`
+	Modified  []string            `json:"modified"`
+}
+
+// WebhookPushEvent is the API message for webhook push event.
+//
+// Docs: https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#push
+type WebhookPushEvent struct {
+	Ref string `json:"ref"`
+	// Before and After are the SHAs of the most recent commit on the ref
+	// before and after the push.
+	Before string `json:"before"`
+	After  string `json:"after"`
+	// Created is true if the ref is created by the push.
+	Created    bool              `json:"created"`
+	Repository WebhookRepository `json:"repository"`
+	Pusher     WebhookPusher     `json:"pusher"`
+	// Commits lists the pushed commits from the oldest, and is capped at
+	// WebhookPushEventCommitLimit entries on large pushes.
+	Commits []WebhookCommit `json:"commits"`
+}
+
+// PullRequestBranch is the API message for the branch of a pull request.
+type PullRequestBranch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// PullRequest is the API message for a pull request.
+type PullRequest struct {
+	HTMLURL string            `json:"html_url"`
+	Head    PullRequestBranch `json:"head"`
+	Base    PullRequestBranch `json:"base"`
+}
+
+// WebhookPullRequestEvent is the API message for webhook pull request event.
+//
+// Docs: https://docs.github.com/en/developers/webhooks-and-events/webhooks/webhook-events-and-payloads#pull_request
+type WebhookPullRequestEvent struct {
+	// Action can be one of "opened", "synchronize", "reopened", "closed",
+	// "edited" and other actions we are not interested in.
+	Action      string            `json:"action"`
+	Number      int               `json:"number"`
+	PullRequest PullRequest       `json:"pull_request"`
+	Repository  WebhookRepository `json:"repository"`
+}
+
+// IssueComment is the API message for an issue comment, a pull request is an
+// issue as well in GitHub.
+type IssueComment struct {
+	ID   int64  `json:"id,omitempty"`
+	Body string `json:"body"`
+}
+
+// File represents a GitHub API response for a repository file.
+type File struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Size     int64  `json:"size"`
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	SHA      string `json:"sha"`
+}
+
 // fetchUserInfo fetches user information from the given resourceURI, which
 // should be either "user" or "users/{username}".
 func (p *Provider) fetchUserInfo(ctx context.Context, oauthCtx common.OauthContext, resourceURI string) (*vcs.UserInfo, error) {
@@ -182,6 +322,205 @@ func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthCon
 	}, nil
 }
 
+// CommitFile represents a GitHub API response for a file changed by a commit.
+type CommitFile struct {
+	Filename string `json:"filename"`
+	// Status is the status of the file, can be one of "added", "removed",
+	// "modified", "renamed", "copied", "changed" and "unchanged".
+	Status string `json:"status"`
+}
+
+// FetchCommitAddedFileList fetches the list of file paths added by the commit.
+//
+// Docs: https://docs.github.com/en/rest/commits/commits#get-a-commit
+func (p *Provider) FetchCommitAddedFileList(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, commitID string) ([]string, error) {
+	files, err := p.fetchCommitFileList(ctx, oauthCtx, repositoryID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	var addedList []string
+	for _, file := range files {
+		if file.Status == "added" {
+			addedList = append(addedList, file.Filename)
+		}
+	}
+	return addedList, nil
+}
+
+// fetchCommitFileList fetches all files changed by the commit.
+func (p *Provider) fetchCommitFileList(ctx context.Context, oauthCtx common.OauthContext, repositoryID, commitID string) ([]CommitFile, error) {
+	var allFiles []CommitFile
+	page := 1
+	for {
+		files, hasNextPage, err := p.fetchPaginatedCommitFileList(ctx, oauthCtx, repositoryID, commitID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		allFiles = append(allFiles, files...)
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+	return allFiles, nil
+}
+
+// CompareCommit represents a GitHub API response for a commit in the
+// comparison of two commits.
+type CompareCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+			// Date is in RFC 3339 format.
+			Date string `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+}
+
+// FetchCommitRange fetches the commits reachable from the head commit but not
+// from the base commit, from the oldest, along with the files added and
+// modified by each commit. It is used to recover the full commit list of a
+// push event with more than WebhookPushEventCommitLimit commits.
+//
+// Docs: https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (p *Provider) FetchCommitRange(ctx context.Context, oauthCtx common.OauthContext, repositoryID, base, head string) ([]WebhookCommit, error) {
+	var compareCommitList []CompareCommit
+	page := 1
+	for {
+		commits, hasNextPage, err := p.fetchPaginatedCompareCommitList(ctx, oauthCtx, repositoryID, base, head, page)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch commits from %s to %s", base, head)
+		}
+		compareCommitList = append(compareCommitList, commits...)
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+
+	var commitList []WebhookCommit
+	for _, commit := range compareCommitList {
+		files, err := p.fetchCommitFileList(ctx, oauthCtx, repositoryID, commit.SHA)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetch files of commit %s", commit.SHA)
+		}
+		webhookCommit := WebhookCommit{
+			ID:        commit.SHA,
+			Distinct:  true,
+			Message:   commit.Commit.Message,
+			Timestamp: commit.Commit.Author.Date,
+			URL:       commit.HTMLURL,
+			Author: WebhookCommitAuthor{
+				Name:  commit.Commit.Author.Name,
+				Email: commit.Commit.Author.Email,
+			},
+		}
+		for _, file := range files {
+			switch file.Status {
+			case "added":
+				webhookCommit.Added = append(webhookCommit.Added, file.Filename)
+			case "modified":
+				webhookCommit.Modified = append(webhookCommit.Modified, file.Filename)
+			}
+		}
+		commitList = append(commitList, webhookCommit)
+	}
+	return commitList, nil
+}
+
+// fetchPaginatedCompareCommitList fetches the commits between the base and
+// head commits in given page. It return the paginated results along with a
+// boolean indicating whether the next page exists.
+func (p *Provider) fetchPaginatedCompareCommitList(ctx context.Context, oauthCtx common.OauthContext, repositoryID, base, head string, page int) (commits []CompareCommit, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/compare/%s...%s?page=%d&per_page=%d", apiURL, repositoryID, base, head, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch commit comparison from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch commit comparison from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	var comparison struct {
+		TotalCommits int             `json:"total_commits"`
+		Commits      []CompareCommit `json:"commits"`
+	}
+	if err := json.Unmarshal([]byte(body), &comparison); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return comparison.Commits, len(comparison.Commits) > 0 && page*apiPageSize < comparison.TotalCommits, nil
+}
+
+// fetchPaginatedCommitFileList fetches files changed by the commit in given
+// page. It return the paginated results along with a boolean indicating
+// whether the next page exists.
+func (p *Provider) fetchPaginatedCommitFileList(ctx context.Context, oauthCtx common.OauthContext, repositoryID, commitID string, page int) (files []CommitFile, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/commits/%s?page=%d&per_page=%d", apiURL, repositoryID, commitID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch commit files from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch commit files from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	var commit struct {
+		Files []CommitFile `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(body), &commit); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return commit.Files, len(commit.Files) >= apiPageSize, nil
+}
+
 // FetchUserInfo fetches user info of given user ID.
 func (p *Provider) FetchUserInfo(ctx context.Context, oauthCtx common.OauthContext, _, username string) (*vcs.UserInfo, error) {
 	return p.fetchUserInfo(ctx, oauthCtx, fmt.Sprintf("users/%s", username))
@@ -456,28 +795,396 @@ func (p *Provider) OverwriteFile(ctx context.Context, oauthCtx common.OauthConte
 }
 
 // ReadFileMeta reads the file metadata.
-func (p *Provider) ReadFileMeta(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (*vcs.FileMeta, error) {
-	return nil, errors.New("not implemented yet") // TODO: https://github.com/youzi-1122/bytebase/issues/928
+//
+// Docs: https://docs.github.com/en/rest/repos/contents#get-repository-content
+func (p *Provider) ReadFileMeta(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, filePath, ref string) (*vcs.FileMeta, error) {
+	file, err := p.readFile(ctx, oauthCtx, repositoryID, filePath, ref)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read file metadata %s", filePath)
+	}
+
+	return &vcs.FileMeta{
+		Name: file.Name,
+		Path: file.Path,
+		Size: file.Size,
+		// GitHub identifies a file version by its blob SHA, which is also what
+		// it requires to overwrite the file.
+		LastCommitID: file.SHA,
+	}, nil
 }
 
 // ReadFileContent reads the file content.
-func (p *Provider) ReadFileContent(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, filePath, ref string) (string, error) {
-	return "", errors.New("not implemented yet") // TODO: https://github.com/youzi-1122/bytebase/issues/928
+//
+// Docs: https://docs.github.com/en/rest/repos/contents#get-repository-content
+func (p *Provider) ReadFileContent(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, filePath, ref string) (string, error) {
+	file, err := p.readFile(ctx, oauthCtx, repositoryID, filePath, ref)
+	if err != nil {
+		return "", errors.Wrapf(err, "read file content %s", filePath)
+	}
+	return file.Content, nil
 }
 
-// CreateWebhook creates a webhook in a GitLab project.
-func (p *Provider) CreateWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID string, payload []byte) (string, error) {
-	return "", errors.New("not implemented yet") // TODO: https://github.com/youzi-1122/bytebase/issues/928
+// readFile reads the file data including metadata and content.
+func (p *Provider) readFile(ctx context.Context, oauthCtx common.OauthContext, repositoryID, filePath, ref string) (*File, error) {
+	url := fmt.Sprintf("%s/repos/%s/contents/%s?ref=%s", apiURL, repositoryID, escapeFilePath(filePath), url.QueryEscape(ref))
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("failed to read file data from URL %s", url))
+	} else if code >= 300 {
+		return nil, fmt.Errorf("failed to read file data from URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	file := &File{}
+	if err := json.Unmarshal([]byte(body), file); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+	if file.Type != "file" {
+		return nil, fmt.Errorf("%q is not a file, got type %q", filePath, file.Type)
+	}
+
+	if file.Encoding == "base64" {
+		// GitHub wraps the base64-encoded content at 60 characters per line.
+		decodedContent, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+		if err != nil {
+			return nil, errors.Wrap(err, "decode file content")
+		}
+		file.Content = string(decodedContent)
+	}
+	return file, nil
 }
 
-// PatchWebhook patches a webhook in a GitLab project.
-func (p *Provider) PatchWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string, payload []byte) error {
-	return errors.New("not implemented yet") // TODO: https://github.com/youzi-1122/bytebase/issues/928
+// escapeFilePath escapes each segment of the file path while keeping the path
+// separators.
+func escapeFilePath(filePath string) string {
+	segments := strings.Split(filePath, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
 }
 
-// DeleteWebhook deletes a webhook in a GitLab project.
-func (p *Provider) DeleteWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string) error {
-	return errors.New("not implemented yet") // TODO: https://github.com/youzi-1122/bytebase/issues/928
+// CreateWebhook creates a webhook in the repository. It returns the created
+// webhook ID on success.
+//
+// Docs: https://docs.github.com/en/rest/webhooks/repos#create-a-repository-webhook
+func (p *Provider) CreateWebhook(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID string, payload []byte) (string, error) {
+	url := fmt.Sprintf("%s/repos/%s/hooks", apiURL, repositoryID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return "", errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return "", common.Errorf(common.NotFound, fmt.Errorf("failed to create webhook through URL %s", url))
+	} else if code >= 300 {
+		return "", fmt.Errorf("failed to create webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	webhookInfo := &WebhookInfo{}
+	if err := json.Unmarshal([]byte(body), webhookInfo); err != nil {
+		return "", errors.Wrap(err, "unmarshal body")
+	}
+	return strconv.Itoa(webhookInfo.ID), nil
+}
+
+// PatchWebhook patches a webhook in the repository.
+//
+// Docs: https://docs.github.com/en/rest/webhooks/repos#update-a-repository-webhook
+func (p *Provider) PatchWebhook(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, webhookID string, payload []byte) error {
+	url := fmt.Sprintf("%s/repos/%s/hooks/%s", apiURL, repositoryID, webhookID)
+	code, body, err := oauth.Patch(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to patch webhook through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to patch webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// DeleteWebhook deletes a webhook in the repository.
+//
+// Docs: https://docs.github.com/en/rest/webhooks/repos#delete-a-repository-webhook
+func (p *Provider) DeleteWebhook(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, webhookID string) error {
+	url := fmt.Sprintf("%s/repos/%s/hooks/%s", apiURL, repositoryID, webhookID)
+	code, body, err := oauth.Delete(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "DELETE %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to delete webhook through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to delete webhook through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// ListPullRequestFile lists the files changed by the pull request.
+//
+// Docs: https://docs.github.com/en/rest/pulls/pulls#list-pull-requests-files
+func (p *Provider) ListPullRequestFile(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, pullRequestID string) ([]*vcs.PullRequestFile, error) {
+	var fileList []*vcs.PullRequestFile
+	page := 1
+	for {
+		files, hasNextPage, err := p.fetchPaginatedPullRequestFileList(ctx, oauthCtx, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		for _, file := range files {
+			fileList = append(fileList, &vcs.PullRequestFile{
+				Path:      file.Filename,
+				IsDeleted: file.Status == "removed",
+			})
+		}
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+	return fileList, nil
+}
+
+// fetchPaginatedPullRequestFileList fetches files changed by the pull request
+// in given page. It return the paginated results along with a boolean
+// indicating whether the next page exists.
+func (p *Provider) fetchPaginatedPullRequestFileList(ctx context.Context, oauthCtx common.OauthContext, repositoryID, pullRequestID string, page int) (files []CommitFile, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/pulls/%s/files?page=%d&per_page=%d", apiURL, repositoryID, pullRequestID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch pull request files from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch pull request files from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &files); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return files, len(files) >= apiPageSize, nil
+}
+
+// CreatePullRequestComment creates a comment on the pull request.
+//
+// Docs: https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+func (p *Provider) CreatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, pullRequestID, comment string) error {
+	payload, err := json.Marshal(IssueComment{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "marshal issue comment")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments", apiURL, repositoryID, pullRequestID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to create pull request comment through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to create pull request comment through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// ListPullRequestComment lists the comments on the pull request.
+//
+// Docs: https://docs.github.com/en/rest/issues/comments#list-issue-comments
+func (p *Provider) ListPullRequestComment(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	var commentList []*vcs.PullRequestComment
+	page := 1
+	for {
+		comments, hasNextPage, err := p.fetchPaginatedPullRequestCommentList(ctx, oauthCtx, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		for _, comment := range comments {
+			commentList = append(commentList, &vcs.PullRequestComment{
+				ID:   strconv.FormatInt(comment.ID, 10),
+				Body: comment.Body,
+			})
+		}
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+	return commentList, nil
+}
+
+// fetchPaginatedPullRequestCommentList fetches comments on the pull request in
+// given page. It return the paginated results along with a boolean indicating
+// whether the next page exists.
+func (p *Provider) fetchPaginatedPullRequestCommentList(ctx context.Context, oauthCtx common.OauthContext, repositoryID, pullRequestID string, page int) (comments []IssueComment, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments?page=%d&per_page=%d", apiURL, repositoryID, pullRequestID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch pull request comments from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch pull request comments from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &comments); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return comments, len(comments) >= apiPageSize, nil
+}
+
+// UpdatePullRequestComment updates a comment on the pull request.
+//
+// Docs: https://docs.github.com/en/rest/issues/comments#update-an-issue-comment
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, _, repositoryID, _, commentID, comment string) error {
+	payload, err := json.Marshal(IssueComment{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "marshal issue comment")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/issues/comments/%s", apiURL, repositoryID, commentID)
+	code, body, err := oauth.Patch(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PATCH %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to update pull request comment through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to update pull request comment through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
 }
 
 // oauthContext is the request context for refreshing oauth token.
@@ -38,6 +38,8 @@ type WebhookType string
 const (
 	// WebhookPush is the webhook type for push.
 	WebhookPush WebhookType = "push"
+	// WebhookMergeRequest is the webhook type for merge request.
+	WebhookMergeRequest WebhookType = "merge_request"
 )
 
 // WebhookInfo is the API message for webhook info.
@@ -54,9 +56,9 @@ type WebhookPost struct {
 	// For now, there is no native dry run DDL support in mysql/postgres. One may wonder if we could wrap the DDL
 	// in a transaction and just not commit at the end, unfortunately there are side effects which are hard to control.
 	// See https://www.postgresql.org/message-id/CAMsr%2BYGiYQ7PYvYR2Voio37YdCpp79j5S%2BcmgVJMOLM2LnRQcA%40mail.gmail.com
-	// So we can't possibly display useful info when reviewing a MR, thus we don't enable this event.
-	// Saying that, delivering a souding dry run solution would be great and hopefully we can achieve that one day.
-	// MergeRequestsEvents  bool   `json:"merge_requests_events"`
+	// So we only review the migration files statically against the schema review policy when a MR is opened or updated,
+	// and post the advice back as a MR comment.
+	MergeRequestsEvents    bool   `json:"merge_requests_events"`
 	PushEventsBranchFilter string `json:"push_events_branch_filter"`
 	// TODO(tianzhou): This is set to false, be lax to not enable_ssl_verification
 	EnableSSLVerification bool `json:"enable_ssl_verification"`
@@ -65,6 +67,7 @@ type WebhookPost struct {
 // WebhookPut is the API message for webhook PUT.
 type WebhookPut struct {
 	URL                    string `json:"url"`
+	MergeRequestsEvents    bool   `json:"merge_requests_events"`
 	PushEventsBranchFilter string `json:"push_events_branch_filter"`
 }
 
@@ -82,13 +85,14 @@ type WebhookCommitAuthor struct {
 
 // WebhookCommit is the API message for webhook commit.
 type WebhookCommit struct {
-	ID        string              `json:"id"`
-	Title     string              `json:"title"`
-	Message   string              `json:"message"`
-	Timestamp string              `json:"timestamp"`
-	URL       string              `json:"url"`
-	Author    WebhookCommitAuthor `json:"author"`
-	AddedList []string            `json:"added"`
+	ID           string              `json:"id"`
+	Title        string              `json:"title"`
+	Message      string              `json:"message"`
+	Timestamp    string              `json:"timestamp"`
+	URL          string              `json:"url"`
+	Author       WebhookCommitAuthor `json:"author"`
+	AddedList    []string            `json:"added"`
+	ModifiedList []string            `json:"modified"`
 }
 
 // WebhookPushEvent is the API message for webhook push event.
@@ -100,6 +104,39 @@ type WebhookPushEvent struct {
 	CommitList []WebhookCommit `json:"commits"`
 }
 
+// WebhookMergeRequestAttributes is the API message for the attributes of the
+// webhook merge request.
+type WebhookMergeRequestAttributes struct {
+	// IID is the ID of the merge request in the project.
+	IID          int    `json:"iid"`
+	URL          string `json:"url"`
+	TargetBranch string `json:"target_branch"`
+	// Action can be one of "open", "close", "reopen", "update", "approved",
+	// "unapproved", "approval", "unapproval" and "merge".
+	Action     string `json:"action"`
+	LastCommit struct {
+		ID string `json:"id"`
+	} `json:"last_commit"`
+}
+
+// WebhookMergeRequestEvent is the API message for webhook merge request event.
+type WebhookMergeRequestEvent struct {
+	ObjectKind       WebhookType                   `json:"object_kind"`
+	Project          WebhookProject                `json:"project"`
+	ObjectAttributes WebhookMergeRequestAttributes `json:"object_attributes"`
+}
+
+// MergeRequestChanges is the API message for the changes of a merge request.
+type MergeRequestChanges struct {
+	Changes []CommitDiff `json:"changes"`
+}
+
+// MergeRequestNote is the API message for a merge request note.
+type MergeRequestNote struct {
+	ID   int64  `json:"id,omitempty"`
+	Body string `json:"body"`
+}
+
 // Commit is the API message for commit.
 type Commit struct {
 	ID         string `json:"id"`
@@ -109,6 +146,14 @@ type Commit struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// CommitDiff is the API message for a file diff of a commit.
+type CommitDiff struct {
+	NewPath     string `json:"new_path"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
 // FileCommit is the API message for file commit.
 type FileCommit struct {
 	Branch        string `json:"branch"`
@@ -427,6 +472,72 @@ func (p *Provider) FetchCommitByID(ctx context.Context, oauthCtx common.OauthCon
 	}, nil
 }
 
+// FetchCommitAddedFileList fetches the list of file paths added by the commit.
+//
+// Docs: https://docs.gitlab.com/ee/api/commits.html#get-the-diff-of-a-commit
+func (p *Provider) FetchCommitAddedFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) ([]string, error) {
+	var addedList []string
+	page := 1
+	for {
+		diffList, hasNextPage, err := p.fetchPaginatedCommitDiffList(ctx, oauthCtx, instanceURL, repositoryID, commitID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		for _, diff := range diffList {
+			if diff.NewFile {
+				addedList = append(addedList, diff.NewPath)
+			}
+		}
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+	return addedList, nil
+}
+
+// fetchPaginatedCommitDiffList fetches the file diffs of a commit in given
+// page. It return the paginated results along with a boolean indicating
+// whether the next page exists.
+func (p *Provider) fetchPaginatedCommitDiffList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string, page int) (diffList []CommitDiff, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/projects/%s/repository/commits/%s/diff?page=%d&per_page=%d", p.APIURL(instanceURL), repositoryID, commitID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch commit diff from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch commit diff from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &diffList); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return diffList, len(diffList) >= apiPageSize, nil
+}
+
 // FetchUserInfo fetches user info of given user ID.
 func (p *Provider) FetchUserInfo(ctx context.Context, oauthCtx common.OauthContext, instanceURL, userID string) (*vcs.UserInfo, error) {
 	return p.fetchUserInfo(ctx, oauthCtx, instanceURL, fmt.Sprintf("users/%s", userID))
@@ -827,6 +938,194 @@ func (p *Provider) DeleteWebhook(ctx context.Context, oauthCtx common.OauthConte
 	return nil
 }
 
+// ListPullRequestFile lists the files changed by the merge request.
+//
+// Docs: https://docs.gitlab.com/ee/api/merge_requests.html#get-single-merge-request-changes
+func (p *Provider) ListPullRequestFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*vcs.PullRequestFile, error) {
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%s/changes", p.APIURL(instanceURL), repositoryID, pullRequestID)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("failed to list merge request changes from URL %s", url))
+	} else if code >= 300 {
+		return nil, fmt.Errorf("failed to list merge request changes from URL %s, status code: %d, body: %s", url, code, body)
+	}
+
+	changes := &MergeRequestChanges{}
+	if err := json.Unmarshal([]byte(body), changes); err != nil {
+		return nil, errors.Wrap(err, "unmarshal body")
+	}
+
+	var fileList []*vcs.PullRequestFile
+	for _, change := range changes.Changes {
+		fileList = append(fileList, &vcs.PullRequestFile{
+			Path:      change.NewPath,
+			IsDeleted: change.DeletedFile,
+		})
+	}
+	return fileList, nil
+}
+
+// CreatePullRequestComment creates a note on the merge request.
+//
+// Docs: https://docs.gitlab.com/ee/api/notes.html#create-new-merge-request-note
+func (p *Provider) CreatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID, comment string) error {
+	payload, err := json.Marshal(MergeRequestNote{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "marshal merge request note")
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes", p.APIURL(instanceURL), repositoryID, pullRequestID)
+	code, body, err := oauth.Post(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "POST %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to create merge request note through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to create merge request note through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
+// ListPullRequestComment lists the notes on the merge request.
+//
+// Docs: https://docs.gitlab.com/ee/api/notes.html#list-all-merge-request-notes
+func (p *Provider) ListPullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*vcs.PullRequestComment, error) {
+	var commentList []*vcs.PullRequestComment
+	page := 1
+	for {
+		notes, hasNextPage, err := p.fetchPaginatedPullRequestCommentList(ctx, oauthCtx, instanceURL, repositoryID, pullRequestID, page)
+		if err != nil {
+			return nil, errors.Wrap(err, "fetch paginated list")
+		}
+		for _, note := range notes {
+			commentList = append(commentList, &vcs.PullRequestComment{
+				ID:   strconv.FormatInt(note.ID, 10),
+				Body: note.Body,
+			})
+		}
+
+		if !hasNextPage {
+			break
+		}
+		page++
+	}
+	return commentList, nil
+}
+
+// fetchPaginatedPullRequestCommentList fetches notes on the merge request in
+// given page. It return the paginated results along with a boolean indicating
+// whether the next page exists.
+func (p *Provider) fetchPaginatedPullRequestCommentList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string, page int) (notes []MergeRequestNote, hasNextPage bool, err error) {
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes?page=%d&per_page=%d", p.APIURL(instanceURL), repositoryID, pullRequestID, page, apiPageSize)
+	code, body, err := oauth.Get(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "GET %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return nil, false, common.Errorf(common.NotFound, fmt.Errorf("failed to fetch merge request notes from URL %s", url))
+	} else if code >= 300 {
+		return nil, false,
+			fmt.Errorf("failed to fetch merge request notes from URL %s, status code: %d, body: %s",
+				url,
+				code,
+				body,
+			)
+	}
+
+	if err := json.Unmarshal([]byte(body), &notes); err != nil {
+		return nil, false, errors.Wrap(err, "unmarshal body")
+	}
+	return notes, len(notes) >= apiPageSize, nil
+}
+
+// UpdatePullRequestComment updates a note on the merge request.
+//
+// Docs: https://docs.gitlab.com/ee/api/notes.html#modify-existing-merge-request-note
+func (p *Provider) UpdatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID, commentID, comment string) error {
+	payload, err := json.Marshal(MergeRequestNote{Body: comment})
+	if err != nil {
+		return errors.Wrap(err, "marshal merge request note")
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes/%s", p.APIURL(instanceURL), repositoryID, pullRequestID, commentID)
+	code, body, err := oauth.Put(
+		ctx,
+		p.client,
+		url,
+		&oauthCtx.AccessToken,
+		bytes.NewReader(payload),
+		tokenRefresher(
+			instanceURL,
+			oauthContext{
+				ClientID:     oauthCtx.ClientID,
+				ClientSecret: oauthCtx.ClientSecret,
+				RefreshToken: oauthCtx.RefreshToken,
+			},
+			oauthCtx.Refresher,
+		),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "PUT %s", url)
+	}
+
+	if code == http.StatusNotFound {
+		return common.Errorf(common.NotFound, fmt.Errorf("failed to update merge request note through URL %s", url))
+	} else if code >= 300 {
+		return fmt.Errorf("failed to update merge request note through URL %s, status code: %d, body: %s", url, code, body)
+	}
+	return nil
+}
+
 // readFile reads the file data including metadata and content.
 func (p *Provider) readFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL string, repositoryID string, filePath string, ref string) (*File, error) {
 	url := fmt.Sprintf("%s/projects/%s/repository/files/%s?ref=%s", p.APIURL(instanceURL), repositoryID, url.QueryEscape(filePath), url.QueryEscape(ref))
@@ -16,13 +16,17 @@ import (
 type TokenRefresher func(ctx context.Context, client *http.Client, oldToken *string) error
 
 func requester(ctx context.Context, client *http.Client, method, url string, token *string, body io.Reader) func() (*http.Response, error) {
+	return requesterWithContentType(ctx, client, method, url, token, body, "application/json")
+}
+
+func requesterWithContentType(ctx context.Context, client *http.Client, method, url string, token *string, body io.Reader, contentType string) func() (*http.Response, error) {
 	return func() (*http.Response, error) {
 		req, err := http.NewRequestWithContext(ctx, method, url, body)
 		if err != nil {
 			return nil, errors.Wrapf(err, "construct %s %s", method, url)
 		}
 
-		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Type", contentType)
 		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", *token))
 		resp, err := client.Do(req)
 		if err != nil {
@@ -38,6 +42,13 @@ func Post(ctx context.Context, client *http.Client, url string, token *string, b
 	return retry(ctx, client, token, tokenRefresher, requester(ctx, client, http.MethodPost, url, token, body))
 }
 
+// PostForm makes a HTTP POST request with the URL-encoded form body to the
+// given URL using the token. It refreshes token and retries the request in the
+// case of the token has expired.
+func PostForm(ctx context.Context, client *http.Client, url string, token *string, body io.Reader, tokenRefresher TokenRefresher) (code int, respBody string, err error) {
+	return retry(ctx, client, token, tokenRefresher, requesterWithContentType(ctx, client, http.MethodPost, url, token, body, "application/x-www-form-urlencoded"))
+}
+
 // Get makes a HTTP GET request to the given URL using the token. It refreshes
 // token and retries the request in the case of the token has expired.
 func Get(ctx context.Context, client *http.Client, url string, token *string, tokenRefresher TokenRefresher) (code int, respBody string, err error) {
@@ -50,6 +61,12 @@ func Put(ctx context.Context, client *http.Client, url string, token *string, bo
 	return retry(ctx, client, token, tokenRefresher, requester(ctx, client, http.MethodPut, url, token, body))
 }
 
+// Patch makes a HTTP PATCH request to the given URL using the token. It refreshes
+// token and retries the request in the case of the token has expired.
+func Patch(ctx context.Context, client *http.Client, url string, token *string, body io.Reader, tokenRefresher TokenRefresher) (code int, respBody string, err error) {
+	return retry(ctx, client, token, tokenRefresher, requester(ctx, client, http.MethodPatch, url, token, body))
+}
+
 // Delete makes a HTTP DELETE request to the given URL using the token. It refreshes
 // token and retries the request in the case of the token has expired.
 func Delete(ctx context.Context, client *http.Client, url string, token *string, tokenRefresher TokenRefresher) (code int, respBody string, err error) {
@@ -35,6 +35,27 @@ func TestPost(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPostForm(t *testing.T) {
+	ctx := context.Background()
+	client := &http.Client{
+		Transport: &common.MockRoundTripper{
+			MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "key=value", string(body))
+				return &http.Response{}, nil
+			},
+		},
+	}
+	token := "token"
+	_, _, err := PostForm(ctx, client, "", &token, strings.NewReader("key=value"), nil)
+	require.NoError(t, err)
+}
+
 func TestGet(t *testing.T) {
 	ctx := context.Background()
 	client := &http.Client{
@@ -73,6 +94,27 @@ func TestPut(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPatch(t *testing.T) {
+	ctx := context.Background()
+	client := &http.Client{
+		Transport: &common.MockRoundTripper{
+			MockRoundTrip: func(r *http.Request) (*http.Response, error) {
+				assert.Equal(t, http.MethodPatch, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "PATCH body", string(body))
+				return &http.Response{}, nil
+			},
+		},
+	}
+	token := "token"
+	_, _, err := Patch(ctx, client, "", &token, strings.NewReader("PATCH body"), nil)
+	require.NoError(t, err)
+}
+
 func TestDelete(t *testing.T) {
 	ctx := context.Background()
 	client := &http.Client{
@@ -16,6 +16,10 @@ const (
 	GitLabSelfHost Type = "GITLAB_SELF_HOST"
 	// GitHubCom is the VCS type for GitHub.com.
 	GitHubCom Type = "GITHUB_COM"
+	// GiteaSelfHost is the VCS type for Gitea self host.
+	GiteaSelfHost Type = "GITEA_SELF_HOST"
+	// BitbucketOrg is the VCS type for Bitbucket Cloud (bitbucket.org).
+	BitbucketOrg Type = "BITBUCKET_ORG"
 )
 
 // OAuthToken is the API message for OAuthToken.
@@ -36,8 +40,14 @@ type OAuthToken struct {
 // Commit records the commit data.
 type Commit struct {
 	ID         string
-	AuthorName string
+	Title      string
+	Message    string
 	CreatedTs  int64
+	URL        string
+	AuthorName string
+	AddedList  []string
+	// ModifiedList is the list of modified files, which is only used by the SDL schema change type to pick up the changed schema files.
+	ModifiedList []string
 }
 
 // FileCommit is the API message for a VCS file commit.
@@ -85,6 +95,33 @@ type PushEvent struct {
 	FileCommit         FileCommit `json:"fileCommit"`
 }
 
+// PullRequestEvent is the API message for a VCS pull request event, which is
+// called merge request in GitLab.
+type PullRequestEvent struct {
+	VCSType Type `json:"vcsType"`
+	// ID is the number of the pull request in the repository, which is the
+	// "iid" of a GitLab merge request.
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	RepositoryID string `json:"repositoryId"`
+	// HeadCommitID is the latest commit ID of the source branch.
+	HeadCommitID string `json:"headCommitId"`
+	// TargetBranch is the branch the pull request merges into.
+	TargetBranch string `json:"targetBranch"`
+}
+
+// PullRequestFile is the API message for a file changed by a pull request.
+type PullRequestFile struct {
+	Path      string
+	IsDeleted bool
+}
+
+// PullRequestComment is the API message for a comment on a pull request.
+type PullRequestComment struct {
+	ID   string
+	Body string
+}
+
 // State is the state of a VCS user account.
 type State string
 
@@ -144,6 +181,13 @@ type Provider interface {
 	// repositoryID: the repository ID from the external VCS system (note this is NOT the ID of Bytebase's own repository resource)
 	// commitID: the commit ID
 	FetchCommitByID(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) (*Commit, error)
+	// Fetch the list of file paths added by the commit
+	//
+	// oauthCtx: OAuth context to fetch the commit
+	// instanceURL: VCS instance URL
+	// repositoryID: the repository ID from the external VCS system (note this is NOT the ID of Bytebase's own repository resource)
+	// commitID: the commit ID
+	FetchCommitAddedFileList(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, commitID string) ([]string, error)
 	// Fetch the user info of the given userID
 	//
 	// oauthCtx: OAuth context to write the file content
@@ -213,6 +257,38 @@ type Provider interface {
 	PatchWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string, payload []byte) error
 	// Deletes a webhook.
 	DeleteWebhook(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, webhookID string) error
+
+	// Lists the files changed by the pull request
+	//
+	// oauthCtx: OAuth context to list the pull request files
+	// instanceURL: VCS instance URL
+	// repositoryID: the repository ID from the external VCS system (note this is NOT the ID of Bytebase's own repository resource)
+	// pullRequestID: the pull request number in the repository
+	ListPullRequestFile(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*PullRequestFile, error)
+	// Creates a comment on the pull request
+	//
+	// oauthCtx: OAuth context to create the comment
+	// instanceURL: VCS instance URL
+	// repositoryID: the repository ID from the external VCS system (note this is NOT the ID of Bytebase's own repository resource)
+	// pullRequestID: the pull request number in the repository
+	// comment: the comment body in markdown
+	CreatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID, comment string) error
+	// Lists the comments on the pull request
+	//
+	// oauthCtx: OAuth context to list the comments
+	// instanceURL: VCS instance URL
+	// repositoryID: the repository ID from the external VCS system (note this is NOT the ID of Bytebase's own repository resource)
+	// pullRequestID: the pull request number in the repository
+	ListPullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID string) ([]*PullRequestComment, error)
+	// Updates a comment on the pull request
+	//
+	// oauthCtx: OAuth context to update the comment
+	// instanceURL: VCS instance URL
+	// repositoryID: the repository ID from the external VCS system (note this is NOT the ID of Bytebase's own repository resource)
+	// pullRequestID: the pull request number in the repository
+	// commentID: the ID of the comment to update
+	// comment: the comment body in markdown
+	UpdatePullRequestComment(ctx context.Context, oauthCtx common.OauthContext, instanceURL, repositoryID, pullRequestID, commentID, comment string) error
 }
 
 var (
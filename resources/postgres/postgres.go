@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/youzi-1122/bytebase/common"
 	"github.com/youzi-1122/bytebase/resources/utils"
@@ -31,6 +32,14 @@ type Instance struct {
 // Port returns the port number of the postgres instance.
 func (i Instance) Port() int { return i.port }
 
+// NewInstance returns the postgres instance of the data directory using the postgres binary installed in baseDir.
+func NewInstance(baseDir, dataDir string) *Instance {
+	return &Instance{
+		BaseDir: baseDir,
+		dataDir: dataDir,
+	}
+}
+
 // Start starts a postgres instance on given port, outputs to stdout and stderr.
 //
 // If port is 0, then it will choose a random unused port.
@@ -38,15 +47,30 @@ func (i Instance) Port() int { return i.port }
 // If waitSec > 0, watis at most `waitSec` seconds for the postgres instance to start.
 // Otherwise, returns immediately.
 func (i *Instance) Start(port int, stdout, stderr io.Writer) (err error) {
+	return i.start(port, stdout, stderr, 0)
+}
+
+// StartWithTimeout starts a postgres instance on given port like Start, but waits at most timeout for the postgres
+// instance to accept connections, e.g. after a long archive recovery.
+func (i *Instance) StartWithTimeout(port int, stdout, stderr io.Writer, timeout time.Duration) error {
+	return i.start(port, stdout, stderr, timeout)
+}
+
+func (i *Instance) start(port int, stdout, stderr io.Writer, timeout time.Duration) error {
 	pgbin := filepath.Join(i.BaseDir, "bin", "pg_ctl")
 
 	i.port = port
 
 	// See -p -k -h option definitions in the link below.
 	// https://www.postgresql.org/docs/current/app-postgres.html
-	p := exec.Command(pgbin, "start", "-w",
+	args := []string{"start", "-w"}
+	if timeout > 0 {
+		args = append(args, "-t", strconv.Itoa(int(timeout.Seconds())))
+	}
+	args = append(args,
 		"-D", i.dataDir,
 		"-o", fmt.Sprintf(`-p %d -k %s -h ""`, i.port, common.GetPostgresSocketDir()))
+	p := exec.Command(pgbin, args...)
 
 	p.Stdout = stdout
 	p.Stderr = stderr
@@ -162,6 +186,30 @@ func Install(resourceDir, pgDataDir, pgUser string) (*Instance, error) {
 	}, nil
 }
 
+// PrepareDataDir sets the permissions of a postgres data directory which is not created by initdb, e.g. restored
+// from a base backup, so that the postgres instance can start on it.
+func PrepareDataDir(pgDataDir string) error {
+	if err := os.Chmod(pgDataDir, 0700); err != nil {
+		return fmt.Errorf("failed to chmod postgres data directory %q to 0700, error: %w", pgDataDir, err)
+	}
+	uid, gid, sameUser, err := shouldSwitchUser()
+	if err != nil {
+		return err
+	}
+	if sameUser {
+		return nil
+	}
+	return filepath.Walk(pgDataDir, func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := os.Lchown(path, uid, gid); err != nil {
+			return fmt.Errorf("failed to change owner of %q to bytebase, error: %w", path, err)
+		}
+		return nil
+	})
+}
+
 // initDB inits a postgres database if not yet.
 func initDB(pgBinDir, pgDataDir, pgUser string) error {
 	versionPath := filepath.Join(pgDataDir, "PG_VERSION")
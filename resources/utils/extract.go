@@ -32,6 +32,11 @@ func ExtractTarXz(tarXzF io.Reader, targetDir string) error {
 	return extractTar(xzR, targetDir)
 }
 
+// ExtractTar extracts the given file as .tar format to the given directory.
+func ExtractTar(tarF io.Reader, targetDir string) error {
+	return extractTar(tarF, targetDir)
+}
+
 func extractTar(r io.Reader, targetDir string) error {
 	tarReader := tar.NewReader(r)
 	for {
@@ -60,23 +65,11 @@ func extractTar(r io.Reader, targetDir string) error {
 
 		switch header.Typeflag {
 		case tar.TypeReg:
-			outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
-			if err != nil {
+			// Close the file right after writing, since a tarball such as a Postgres base backup may contain more files
+			// than the open file limit.
+			if err := extractFile(tarReader, targetPath, header); err != nil {
 				return err
 			}
-			defer outFile.Close()
-
-			var totalWritten int64
-			for totalWritten < header.Size {
-				written, err := io.CopyN(outFile, tarReader, 1024)
-				if err != nil {
-					if err == io.EOF {
-						break
-					}
-					return err
-				}
-				totalWritten += written
-			}
 		case tar.TypeDir:
 			if err := os.MkdirAll(targetPath, os.FileMode(header.Mode)); err != nil {
 				return err
@@ -92,3 +85,24 @@ func extractTar(r io.Reader, targetDir string) error {
 
 	return nil
 }
+
+func extractFile(r io.Reader, targetPath string, header *tar.Header) error {
+	outFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_RDWR, os.FileMode(header.Mode))
+	if err != nil {
+		return err
+	}
+	defer outFile.Close()
+
+	var totalWritten int64
+	for totalWritten < header.Size {
+		written, err := io.CopyN(outFile, r, 1024)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		totalWritten += written
+	}
+	return outFile.Close()
+}
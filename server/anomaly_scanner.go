@@ -12,12 +12,19 @@ import (
 	"github.com/youzi-1122/bytebase/common"
 	"github.com/youzi-1122/bytebase/common/log"
 	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/pg"
 	"go.uber.org/zap"
 )
 
 const (
 	// The chosen interval is a balance between anomaly staleness tolerance and background load.
 	anomalyScanInterval = time.Duration(10) * time.Minute
+	// backupMissingGracePeriod is the time allowed for the backup runner to pick up and finish a scheduled backup.
+	backupMissingGracePeriod = time.Duration(2) * time.Hour
+	// walArchiveLagThreshold is the bytes of the Postgres WAL retained for the archiving beyond which the archiving is
+	// considered falling behind. The WAL archiver drains the replication slot every minute, so the lag rarely exceeds it
+	// unless the archiving keeps failing.
+	walArchiveLagThreshold = int64(1) << 30
 )
 
 // NewAnomalyScanner creates a anomaly scanner
@@ -223,6 +230,78 @@ func (s *AnomalyScanner) checkInstanceAnomaly(ctx context.Context, instance *api
 			}
 		}
 	}
+
+	// Check WAL archive lag
+	if instance.Engine == db.Postgres {
+		s.checkWALArchiveLagAnomaly(ctx, instance, driver)
+	}
+}
+
+// checkWALArchiveLagAnomaly checks the WAL retained by the replication slot of the Postgres WAL archiving, which fills
+// up the disk of the instance if the archiving falls behind.
+func (s *AnomalyScanner) checkWALArchiveLagAnomaly(ctx context.Context, instance *api.Instance, driver db.Driver) {
+	archive, err := s.server.store.GetPgWALArchiveByInstanceID(ctx, instance.ID)
+	if err != nil {
+		log.Error("Failed to get WAL archive",
+			zap.String("instance", instance.Name),
+			zap.String("type", string(api.AnomalyInstanceWALArchiveLag)),
+			zap.Error(err))
+		return
+	}
+	var lag int64
+	if archive != nil {
+		pgDriver, ok := driver.(*pg.Driver)
+		if !ok {
+			log.Error("Failed to cast driver to pg.Driver", zap.String("instance", instance.Name))
+			return
+		}
+		// The slot may be missing if the archiving failed before creating it, and the archive failure is reported then.
+		if lag, err = pgDriver.GetWALArchiveSlotLag(ctx, archive.SlotName); err != nil && common.ErrorCode(err) != common.NotFound {
+			log.Error("Failed to get WAL archive slot lag",
+				zap.String("instance", instance.Name),
+				zap.String("type", string(api.AnomalyInstanceWALArchiveLag)),
+				zap.Error(err))
+			return
+		}
+	}
+
+	if archive != nil && (lag >= walArchiveLagThreshold || archive.LastError != "") {
+		anomalyPayload := api.AnomalyInstanceWALArchiveLagPayload{
+			SlotName: archive.SlotName,
+			LagBytes: lag,
+			Detail:   archive.LastError,
+		}
+		payload, err := json.Marshal(anomalyPayload)
+		if err != nil {
+			log.Error("Failed to marshal anomaly payload",
+				zap.String("instance", instance.Name),
+				zap.String("type", string(api.AnomalyInstanceWALArchiveLag)),
+				zap.Error(err))
+			return
+		}
+		if _, err = s.server.store.UpsertActiveAnomaly(ctx, &api.AnomalyUpsert{
+			CreatorID:  api.SystemBotID,
+			InstanceID: instance.ID,
+			Type:       api.AnomalyInstanceWALArchiveLag,
+			Payload:    string(payload),
+		}); err != nil {
+			log.Error("Failed to create anomaly",
+				zap.String("instance", instance.Name),
+				zap.String("type", string(api.AnomalyInstanceWALArchiveLag)),
+				zap.Error(err))
+		}
+		return
+	}
+	err = s.server.store.ArchiveAnomaly(ctx, &api.AnomalyArchive{
+		InstanceID: &instance.ID,
+		Type:       api.AnomalyInstanceWALArchiveLag,
+	})
+	if err != nil && common.ErrorCode(err) != common.NotFound {
+		log.Error("Failed to close anomaly",
+			zap.String("instance", instance.Name),
+			zap.String("type", string(api.AnomalyInstanceWALArchiveLag)),
+			zap.Error(err))
+	}
 }
 
 func (s *AnomalyScanner) checkDatabaseAnomaly(ctx context.Context, instance *api.Instance, database *api.Database) {
@@ -373,31 +452,36 @@ func (s *AnomalyScanner) checkBackupAnomaly(ctx context.Context, instance *api.I
 		return
 	}
 
-	if backupSetting != nil && backupSetting.Enabled && backupSetting.Hour != -1 {
-		if backupSetting.DayOfWeek == -1 {
-			schedule = api.BackupPlanPolicyScheduleDaily
-		} else {
-			schedule = api.BackupPlanPolicyScheduleWeekly
-		}
+	cronExpression := ""
+	if backupSetting != nil && backupSetting.Enabled {
+		schedule = backupSetting.GetBackupPlanPolicySchedule()
+		cronExpression = backupSetting.GetCronExpression()
 	}
 
 	// Check backup policy violation
 	{
 		var backupPolicyAnomalyPayload *api.AnomalyDatabaseBackupPolicyViolationPayload
-		if policyMap[instance.EnvironmentID].Schedule != api.BackupPlanPolicyScheduleUnset {
-			if policyMap[instance.EnvironmentID].Schedule == api.BackupPlanPolicyScheduleDaily &&
-				schedule != api.BackupPlanPolicyScheduleDaily {
-				backupPolicyAnomalyPayload = &api.AnomalyDatabaseBackupPolicyViolationPayload{
-					EnvironmentID:          instance.EnvironmentID,
-					ExpectedBackupSchedule: policyMap[instance.EnvironmentID].Schedule,
-					ActualBackupSchedule:   schedule,
+		// The backups should be taken at least as frequently as the backup plan policy schedule.
+		if policy := policyMap[instance.EnvironmentID]; policy.Schedule != api.BackupPlanPolicyScheduleUnset {
+			satisfied := false
+			if schedule != api.BackupPlanPolicyScheduleUnset {
+				satisfied, err = api.IsBackupScheduleSatisfied(cronExpression, policy.GetCronExpression(), time.Now())
+				if err != nil {
+					log.Warn("Failed to compare backup schedule with backup plan policy",
+						zap.String("instance", instance.Name),
+						zap.String("database", database.Name),
+						zap.String("schedule", cronExpression),
+						zap.String("policy", policy.GetCronExpression()),
+						zap.Error(err))
 				}
-			} else if policyMap[instance.EnvironmentID].Schedule == api.BackupPlanPolicyScheduleWeekly &&
-				schedule == api.BackupPlanPolicyScheduleUnset {
+			}
+			if !satisfied {
 				backupPolicyAnomalyPayload = &api.AnomalyDatabaseBackupPolicyViolationPayload{
 					EnvironmentID:          instance.EnvironmentID,
-					ExpectedBackupSchedule: policyMap[instance.EnvironmentID].Schedule,
+					ExpectedBackupSchedule: policy.Schedule,
 					ActualBackupSchedule:   schedule,
+					ExpectedCronExpression: policy.GetCronExpression(),
+					ActualCronExpression:   cronExpression,
 				}
 			}
 		}
@@ -443,17 +527,16 @@ func (s *AnomalyScanner) checkBackupAnomaly(ctx context.Context, instance *api.I
 	// Check backup missing
 	{
 		var backupMissingAnomalyPayload *api.AnomalyDatabaseBackupMissingPayload
-		// The anomaly fires if backup is enabled, however no successful backup has been taken during the period.
+		// The anomaly fires if backup is enabled, however no successful backup has been taken since the scheduled time.
 		if backupSetting != nil && backupSetting.Enabled {
-			expectedSchedule := api.BackupPlanPolicyScheduleWeekly
-			backupMaxAge := time.Duration(7*24) * time.Hour
-			if backupSetting.DayOfWeek == -1 {
-				expectedSchedule = api.BackupPlanPolicyScheduleDaily
-				backupMaxAge = time.Duration(24) * time.Hour
-			}
-
-			// Ignore if backup setting has been changed after the max age.
-			if backupSetting.UpdatedTs < time.Now().Add(-backupMaxAge).Unix() {
+			backupSchedule, err := api.ParseBackupSchedule(cronExpression)
+			if err != nil {
+				log.Error("Failed to parse backup schedule",
+					zap.String("instance", instance.Name),
+					zap.String("database", database.Name),
+					zap.String("schedule", cronExpression),
+					zap.Error(err))
+			} else {
 				status := api.BackupStatusDone
 				backupFind := &api.BackupFind{
 					DatabaseID: &database.ID,
@@ -467,19 +550,23 @@ func (s *AnomalyScanner) checkBackupAnomaly(ctx context.Context, instance *api.I
 						zap.Error(err))
 				}
 
-				hasValidBackup := false
-				if len(backupList) > 0 {
-					if backupList[0].UpdatedTs >= time.Now().Add(-backupMaxAge).Unix() {
-						hasValidBackup = true
-					}
+				// A backup is expected at the first scheduled time after the last successful backup, or after the backup
+				// setting is changed, whichever is later.
+				// The backup list is ordered by the updated time, which is bumped by the verification.
+				var lastBackupTs int64
+				if latestBackup := getLatestBackup(backupList); latestBackup != nil {
+					lastBackupTs = latestBackup.CreatedTs
 				}
-
-				if !hasValidBackup {
+				lastTs := backupSetting.UpdatedTs
+				if lastBackupTs > lastTs {
+					lastTs = lastBackupTs
+				}
+				expectedTime := backupSchedule.Next(time.Unix(lastTs, 0).UTC())
+				if !expectedTime.IsZero() && expectedTime.Add(backupMissingGracePeriod).Before(time.Now()) {
 					backupMissingAnomalyPayload = &api.AnomalyDatabaseBackupMissingPayload{
-						ExpectedBackupSchedule: expectedSchedule,
-					}
-					if len(backupList) > 0 {
-						backupMissingAnomalyPayload.LastBackupTs = backupList[0].UpdatedTs
+						ExpectedBackupSchedule: schedule,
+						ExpectedCronExpression: cronExpression,
+						LastBackupTs:           lastBackupTs,
 					}
 				}
 			}
@@ -522,4 +609,83 @@ func (s *AnomalyScanner) checkBackupAnomaly(ctx context.Context, instance *api.I
 			}
 		}
 	}
+
+	// Check backup verification failure
+	{
+		var backupVerificationAnomalyPayload *api.AnomalyDatabaseBackupVerificationFailurePayload
+		// The anomaly fires if the latest verified backup fails the verification, and lasts until a later backup passes.
+		if backupSetting != nil {
+			verificationPolicy, err := api.UnmarshalBackupVerificationPolicy(backupSetting.VerificationPolicy)
+			if err != nil {
+				log.Error("Failed to unmarshal backup verification policy",
+					zap.String("instance", instance.Name),
+					zap.String("database", database.Name),
+					zap.Error(err))
+			} else if verificationPolicy.Enabled {
+				status := api.BackupStatusDone
+				backupList, err := s.server.store.FindBackup(ctx, &api.BackupFind{
+					DatabaseID: &database.ID,
+					Status:     &status,
+				})
+				if err != nil {
+					log.Error("Failed to retrieve backup list",
+						zap.String("instance", instance.Name),
+						zap.String("database", database.Name),
+						zap.Error(err))
+				}
+
+				var verifiedBackupList []*api.Backup
+				for _, backup := range backupList {
+					if backup.Payload.Verification != nil {
+						verifiedBackupList = append(verifiedBackupList, backup)
+					}
+				}
+				if backup := getLatestBackup(verifiedBackupList); backup != nil && backup.Payload.Verification.Status == api.BackupVerificationStatusFailed {
+					backupVerificationAnomalyPayload = &api.AnomalyDatabaseBackupVerificationFailurePayload{
+						BackupID:   backup.ID,
+						BackupName: backup.Name,
+						VerifiedTs: backup.Payload.Verification.VerifiedTs,
+						Detail:     backup.Payload.Verification.Detail,
+					}
+				}
+			}
+		}
+
+		if backupVerificationAnomalyPayload != nil {
+			payload, err := json.Marshal(*backupVerificationAnomalyPayload)
+			if err != nil {
+				log.Error("Failed to marshal anomaly payload",
+					zap.String("instance", instance.Name),
+					zap.String("database", database.Name),
+					zap.String("type", string(api.AnomalyDatabaseBackupVerificationFailure)),
+					zap.Error(err))
+			} else {
+				if _, err = s.server.store.UpsertActiveAnomaly(ctx, &api.AnomalyUpsert{
+					CreatorID:  api.SystemBotID,
+					InstanceID: instance.ID,
+					DatabaseID: &database.ID,
+					Type:       api.AnomalyDatabaseBackupVerificationFailure,
+					Payload:    string(payload),
+				}); err != nil {
+					log.Error("Failed to create anomaly",
+						zap.String("instance", instance.Name),
+						zap.String("database", database.Name),
+						zap.String("type", string(api.AnomalyDatabaseBackupVerificationFailure)),
+						zap.Error(err))
+				}
+			}
+		} else {
+			err := s.server.store.ArchiveAnomaly(ctx, &api.AnomalyArchive{
+				DatabaseID: &database.ID,
+				Type:       api.AnomalyDatabaseBackupVerificationFailure,
+			})
+			if err != nil && common.ErrorCode(err) != common.NotFound {
+				log.Error("Failed to close anomaly",
+					zap.String("instance", instance.Name),
+					zap.String("database", database.Name),
+					zap.String("type", string(api.AnomalyDatabaseBackupVerificationFailure)),
+					zap.Error(err))
+			}
+		}
+	}
 }
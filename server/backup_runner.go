@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/youzi-1122/bytebase/api"
 	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/cron"
 	"github.com/youzi-1122/bytebase/common/log"
 	"go.uber.org/zap"
 )
@@ -51,6 +54,7 @@ func (s *BackupRunner) Run(ctx context.Context, wg *sync.WaitGroup) {
 					}
 				}()
 				s.startAutoBackups(ctx, runningTasks, &mu)
+				s.pruneBackups(ctx)
 			}()
 		case <-ctx.Done(): // if cancel() execute
 			return
@@ -59,11 +63,13 @@ func (s *BackupRunner) Run(ctx context.Context, wg *sync.WaitGroup) {
 }
 
 func (s *BackupRunner) startAutoBackups(ctx context.Context, runningTasks map[int]bool, mu *sync.RWMutex) {
-	// Find all databases that need a backup in this hour.
-	t := time.Now().UTC().Truncate(time.Hour)
+	// Find all databases that need a backup in the past hour. The backup name is derived from the scheduled time, so the
+	// backup of the same schedule won't be created twice across the rounds.
+	now := time.Now().UTC().Truncate(time.Second)
+	start := now.Add(-time.Hour)
 	match := &api.BackupSettingsMatch{
-		Hour:      t.Hour(),
-		DayOfWeek: int(t.Weekday()),
+		StartTs: start.Unix(),
+		EndTs:   now.Unix(),
 	}
 	backupSettingList, err := s.server.store.FindBackupSettingsMatch(ctx, match)
 	if err != nil {
@@ -72,20 +78,50 @@ func (s *BackupRunner) startAutoBackups(ctx context.Context, runningTasks map[in
 	}
 
 	for _, backupSetting := range backupSettingList {
-		mu.Lock()
-		if _, ok := runningTasks[backupSetting.ID]; ok {
-			mu.Unlock()
+		schedule, err := api.ParseBackupSchedule(backupSetting.GetCronExpression())
+		if err != nil {
+			log.Error("Failed to parse backup schedule",
+				zap.Int("databaseID", backupSetting.DatabaseID),
+				zap.String("schedule", backupSetting.GetCronExpression()),
+				zap.Error(err))
 			continue
 		}
-		runningTasks[backupSetting.ID] = true
-		mu.Unlock()
+		t := cron.Last(schedule, start, now)
 
 		db := backupSetting.Database
 		if db.Name == api.AllDatabaseName {
 			// Skip backup job for wildcard database `*`.
 			continue
 		}
-		backupName := fmt.Sprintf("%s-%s-%s-autobackup", api.ProjectShortSlug(db.Project), api.EnvSlug(db.Instance.Environment), t.Format("20060102T030405"))
+
+		mu.RLock()
+		_, running := runningTasks[backupSetting.ID]
+		mu.RUnlock()
+		if running {
+			continue
+		}
+
+		// Check the existing backup before scheduling, so we don't connect to the instance on every round of the hour
+		// after the backup of the schedule is created.
+		backupName := fmt.Sprintf("%s-%s-%s-autobackup", api.ProjectShortSlug(db.Project), api.EnvSlug(db.Instance.Environment), t.Format("20060102T150405"))
+		backupList, err := s.server.store.FindBackup(ctx, &api.BackupFind{
+			DatabaseID: &db.ID,
+			Name:       &backupName,
+		})
+		if err != nil {
+			log.Error("Failed to find backup",
+				zap.Int("databaseID", db.ID),
+				zap.String("backup", backupName),
+				zap.Error(err))
+			continue
+		}
+		if len(backupList) > 0 {
+			continue
+		}
+
+		mu.Lock()
+		runningTasks[backupSetting.ID] = true
+		mu.Unlock()
 		go func(database *api.Database, backupSettingID int, backupName string, hookURL string) {
 			log.Debug("Schedule auto backup",
 				zap.String("database", database.Name),
@@ -96,13 +132,17 @@ func (s *BackupRunner) startAutoBackups(ctx context.Context, runningTasks map[in
 				delete(runningTasks, backupSettingID)
 				mu.Unlock()
 			}()
-			_, err := s.server.scheduleBackupTask(ctx, database, backupName, api.BackupTypeAutomatic, api.BackupStorageBackendLocal, api.SystemBotID)
+			backup, err := s.server.scheduleBackupTask(ctx, database, backupName, api.BackupTypeAutomatic, api.SystemBotID)
 			if err != nil {
 				log.Error("Failed to create automatic backup for database",
 					zap.Int("databaseID", database.ID),
 					zap.Error(err))
 				return
 			}
+			if backup == nil {
+				// The backup of the schedule already exists.
+				return
+			}
 			// Backup succeeded. POST hook URL.
 			if hookURL == "" {
 				return
@@ -118,7 +158,138 @@ func (s *BackupRunner) startAutoBackups(ctx context.Context, runningTasks map[in
 	}
 }
 
-func (s *Server) scheduleBackupTask(ctx context.Context, database *api.Database, backupName string, backupType api.BackupType, storageBackend api.BackupStorageBackend, creatorID int) (*api.Backup, error) {
+// pruneBackups deletes the backups beyond the retention policies of the backup settings.
+func (s *BackupRunner) pruneBackups(ctx context.Context) {
+	backupSettingList, err := s.server.store.FindBackupSetting(ctx, &api.BackupSettingFind{})
+	if err != nil {
+		log.Error("Failed to retrieve backup settings", zap.Error(err))
+		return
+	}
+
+	now := time.Now().Unix()
+	for _, backupSetting := range backupSettingList {
+		database := backupSetting.Database
+		if database == nil || database.Name == api.AllDatabaseName {
+			continue
+		}
+		retentionPolicy, err := api.UnmarshalBackupRetentionPolicy(backupSetting.RetentionPolicy)
+		if err != nil {
+			log.Error("Failed to unmarshal backup retention policy",
+				zap.Int("databaseID", database.ID),
+				zap.Error(err))
+			continue
+		}
+		if *retentionPolicy == (api.BackupRetentionPolicy{}) {
+			continue
+		}
+		backupList, err := s.server.store.FindBackup(ctx, &api.BackupFind{DatabaseID: &database.ID})
+		if err != nil {
+			log.Error("Failed to retrieve backups for database",
+				zap.Int("databaseID", database.ID),
+				zap.Error(err))
+			continue
+		}
+		for _, backup := range getPrunableBackupList(backupList, retentionPolicy, now) {
+			if err := s.server.pruneBackup(ctx, database, backup); err != nil {
+				log.Error("Failed to prune backup",
+					zap.Int("databaseID", database.ID),
+					zap.String("backup", backup.Name),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// getPrunableBackupList returns the backups beyond the retention policy.
+// The successful backups of each type are kept by both the keep count and the retention period, while the failed ones
+// are only kept by the retention period. The pending backups are always kept.
+// The latest successful backup is never pruned, and neither is the latest one with the binlog info or the Postgres base
+// backup, since the PITR restores from it.
+func getPrunableBackupList(backupList []*api.Backup, retentionPolicy *api.BackupRetentionPolicy, now int64) []*api.Backup {
+	sortedList := make([]*api.Backup, len(backupList))
+	copy(sortedList, backupList)
+	// Sort in order that the latest backup comes first.
+	sort.SliceStable(sortedList, func(i, j int) bool {
+		return sortedList[i].CreatedTs > sortedList[j].CreatedTs
+	})
+
+	protected := make(map[int]bool)
+	var latestFound, latestPITRFound bool
+	for _, backup := range sortedList {
+		if backup.Status != api.BackupStatusDone {
+			continue
+		}
+		if !latestFound {
+			protected[backup.ID] = true
+			latestFound = true
+		}
+		if !latestPITRFound && (!backup.Payload.BinlogInfo.IsEmpty() || backup.Payload.PgBaseBackup != nil) {
+			protected[backup.ID] = true
+			latestPITRFound = true
+		}
+	}
+
+	var prunableList []*api.Backup
+	doneCount := make(map[api.BackupType]int)
+	for _, backup := range sortedList {
+		rule := retentionPolicy.GetRule(backup.Type)
+		expired := rule.RetentionPeriodTs > 0 && now-backup.CreatedTs > rule.RetentionPeriodTs
+		prunable := false
+		switch backup.Status {
+		case api.BackupStatusDone:
+			doneCount[backup.Type]++
+			prunable = expired || (rule.KeepCount > 0 && doneCount[backup.Type] > rule.KeepCount)
+		case api.BackupStatusFailed:
+			prunable = expired
+		}
+		if prunable && !protected[backup.ID] {
+			prunableList = append(prunableList, backup)
+		}
+	}
+	return prunableList
+}
+
+// pruneBackup deletes the backup file and the backup record, and creates an activity for the pruned backup.
+func (s *Server) pruneBackup(ctx context.Context, database *api.Database, backup *api.Backup) error {
+	// Delete the backup file first, so that a failure leaves the record to retry in the next round.
+	if err := s.removeBackupFile(ctx, backup, database.Instance.EnvironmentID); err != nil {
+		return err
+	}
+	if err := s.store.DeleteBackup(ctx, &api.BackupDelete{ID: backup.ID, DeleterID: api.SystemBotID}); err != nil {
+		return fmt.Errorf("failed to delete backup %q, error: %w", backup.Name, err)
+	}
+	log.Info("Pruned backup by the retention policy",
+		zap.String("database", database.Name),
+		zap.String("backup", backup.Name),
+	)
+
+	payload, err := json.Marshal(api.ActivityDatabaseBackupPrunePayload{
+		DatabaseID:   database.ID,
+		BackupID:     backup.ID,
+		BackupType:   backup.Type,
+		DatabaseName: database.Name,
+		BackupName:   backup.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity payload for pruned backup %q, error: %w", backup.Name, err)
+	}
+	activityCreate := &api.ActivityCreate{
+		CreatorID:   api.SystemBotID,
+		ContainerID: database.ProjectID,
+		Type:        api.ActivityDatabaseBackupPrune,
+		Level:       api.ActivityInfo,
+		Payload:     string(payload),
+		Comment:     fmt.Sprintf("Pruned %s backup %q of database %q by the retention policy.", strings.ToLower(string(backup.Type)), backup.Name, database.Name),
+	}
+	if _, err := s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{}); err != nil {
+		return fmt.Errorf("failed to create activity for pruned backup %q, error: %w", backup.Name, err)
+	}
+	return nil
+}
+
+// scheduleBackupTask schedules the backup task of the database, and the backup is stored in the storage backend
+// configured by the backup storage policy of the database environment.
+func (s *Server) scheduleBackupTask(ctx context.Context, database *api.Database, backupName string, backupType api.BackupType, creatorID int) (*api.Backup, error) {
 	// Store the migration history version if exists.
 	driver, err := getAdminDatabaseDriver(ctx, database.Instance, database.Name, s.pgInstanceDir)
 	if err != nil {
@@ -130,15 +301,27 @@ func (s *Server) scheduleBackupTask(ctx context.Context, database *api.Database,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get migration history for database %q, error: %w", database.Name, err)
 	}
-	path := getBackupRelativeFilePath(database.ID, backupName)
-	if err := createBackupDirectory(s.profile.DataDir, database.ID); err != nil {
-		return nil, fmt.Errorf("failed to create backup directory, error: %w", err)
+	storagePolicy, err := s.store.GetBackupStoragePolicyByEnvID(ctx, database.Instance.EnvironmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup storage policy for environment %q, error: %w", database.Instance.Environment.Name, err)
+	}
+	var path string
+	switch storagePolicy.StorageBackend {
+	case api.BackupStorageBackendLocal:
+		path = getBackupRelativeFilePath(database.ID, backupName)
+		if err := createBackupDirectory(s.profile.DataDir, database.ID); err != nil {
+			return nil, fmt.Errorf("failed to create backup directory, error: %w", err)
+		}
+	case api.BackupStorageBackendS3:
+		path = getBackupObjectKey(storagePolicy.S3.Prefix, database.ID, backupName)
+	default:
+		return nil, fmt.Errorf("backup storage backend %q not supported", storagePolicy.StorageBackend)
 	}
 	backupCreate := &api.BackupCreate{
 		CreatorID:               creatorID,
 		DatabaseID:              database.ID,
 		Name:                    backupName,
-		StorageBackend:          storageBackend,
+		StorageBackend:          storagePolicy.StorageBackend,
 		Type:                    backupType,
 		Path:                    path,
 		MigrationHistoryVersion: migrationHistoryVersion,
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPrunableBackupList(t *testing.T) {
+	const day = 24 * 60 * 60
+	const now = 100 * day
+	binlogInfo := api.BinlogInfo{FileName: "binlog.000001", Position: 1}
+	newBackup := func(id int, backupType api.BackupType, status api.BackupStatus, createdTs int64, binlog api.BinlogInfo) *api.Backup {
+		return &api.Backup{
+			ID:        id,
+			Type:      backupType,
+			Status:    status,
+			CreatedTs: createdTs,
+			Payload:   api.BackupPayload{BinlogInfo: binlog},
+		}
+	}
+	withPgBaseBackup := func(backup *api.Backup) *api.Backup {
+		backup.Payload.PgBaseBackup = &api.PgBaseBackup{StartWALFile: "000000010000000000000002"}
+		return backup
+	}
+
+	tests := []struct {
+		name            string
+		backupList      []*api.Backup
+		retentionPolicy api.BackupRetentionPolicy
+		want            []int
+	}{
+		{
+			name: "empty policy keeps all",
+			backupList: []*api.Backup{
+				newBackup(1, api.BackupTypeAutomatic, api.BackupStatusDone, now-3*day, api.BinlogInfo{}),
+				newBackup(2, api.BackupTypeAutomatic, api.BackupStatusDone, now-2*day, api.BinlogInfo{}),
+			},
+			retentionPolicy: api.BackupRetentionPolicy{},
+			want:            nil,
+		},
+		{
+			name: "keep count",
+			backupList: []*api.Backup{
+				newBackup(1, api.BackupTypeAutomatic, api.BackupStatusDone, now-4*day, api.BinlogInfo{}),
+				newBackup(2, api.BackupTypeAutomatic, api.BackupStatusDone, now-3*day, api.BinlogInfo{}),
+				newBackup(3, api.BackupTypeAutomatic, api.BackupStatusDone, now-2*day, api.BinlogInfo{}),
+				newBackup(4, api.BackupTypeAutomatic, api.BackupStatusFailed, now-1*day, api.BinlogInfo{}),
+				newBackup(5, api.BackupTypeManual, api.BackupStatusDone, now-5*day, api.BinlogInfo{}),
+			},
+			retentionPolicy: api.BackupRetentionPolicy{
+				Automatic: api.BackupRetentionRule{KeepCount: 2},
+			},
+			want: []int{1},
+		},
+		{
+			name: "retention period by type",
+			backupList: []*api.Backup{
+				newBackup(1, api.BackupTypeManual, api.BackupStatusDone, now-10*day, api.BinlogInfo{}),
+				newBackup(2, api.BackupTypeManual, api.BackupStatusFailed, now-8*day, api.BinlogInfo{}),
+				newBackup(3, api.BackupTypePITR, api.BackupStatusDone, now-9*day, api.BinlogInfo{}),
+				newBackup(4, api.BackupTypeAutomatic, api.BackupStatusDone, now-1*day, api.BinlogInfo{}),
+				newBackup(5, api.BackupTypeManual, api.BackupStatusPendingCreate, now-8*day, api.BinlogInfo{}),
+			},
+			retentionPolicy: api.BackupRetentionPolicy{
+				Manual: api.BackupRetentionRule{RetentionPeriodTs: 7 * day},
+				PITR:   api.BackupRetentionRule{RetentionPeriodTs: 30 * day},
+			},
+			want: []int{2, 1},
+		},
+		{
+			name: "latest backup is protected",
+			backupList: []*api.Backup{
+				newBackup(1, api.BackupTypeAutomatic, api.BackupStatusDone, now-10*day, api.BinlogInfo{}),
+				newBackup(2, api.BackupTypeAutomatic, api.BackupStatusDone, now-9*day, api.BinlogInfo{}),
+			},
+			retentionPolicy: api.BackupRetentionPolicy{
+				Automatic: api.BackupRetentionRule{RetentionPeriodTs: day},
+			},
+			want: []int{1},
+		},
+		{
+			name: "latest backup with binlog info is protected for PITR",
+			backupList: []*api.Backup{
+				newBackup(1, api.BackupTypeAutomatic, api.BackupStatusDone, now-10*day, binlogInfo),
+				newBackup(2, api.BackupTypeAutomatic, api.BackupStatusDone, now-9*day, binlogInfo),
+				newBackup(3, api.BackupTypeManual, api.BackupStatusDone, now-1*day, api.BinlogInfo{}),
+			},
+			retentionPolicy: api.BackupRetentionPolicy{
+				Automatic: api.BackupRetentionRule{KeepCount: 1, RetentionPeriodTs: day},
+			},
+			want: []int{1},
+		},
+		{
+			name: "latest backup with Postgres base backup is protected for PITR",
+			backupList: []*api.Backup{
+				withPgBaseBackup(newBackup(1, api.BackupTypeAutomatic, api.BackupStatusDone, now-10*day, api.BinlogInfo{})),
+				withPgBaseBackup(newBackup(2, api.BackupTypeAutomatic, api.BackupStatusDone, now-9*day, api.BinlogInfo{})),
+				newBackup(3, api.BackupTypeManual, api.BackupStatusDone, now-1*day, api.BinlogInfo{}),
+			},
+			retentionPolicy: api.BackupRetentionPolicy{
+				Automatic: api.BackupRetentionRule{KeepCount: 1, RetentionPeriodTs: day},
+			},
+			want: []int{1},
+		},
+	}
+
+	for _, test := range tests {
+		var got []int
+		for _, backup := range getPrunableBackupList(test.backupList, &test.retentionPolicy, now) {
+			got = append(got, backup.ID)
+		}
+		assert.Equal(t, test.want, got, test.name)
+	}
+}
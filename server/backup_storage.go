@@ -0,0 +1,465 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/plugin/storage/codec"
+	"github.com/youzi-1122/bytebase/plugin/storage/s3"
+)
+
+// getBackupS3Client returns the client of the S3 storage backend configured by the backup storage policy of the environment.
+func (s *Server) getBackupS3Client(ctx context.Context, environmentID int) (*s3.Client, *api.BackupStorageS3Config, error) {
+	policy, err := s.store.GetBackupStoragePolicyByEnvID(ctx, environmentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get backup storage policy for environment %d, error: %w", environmentID, err)
+	}
+	if policy.StorageBackend != api.BackupStorageBackendS3 || policy.S3 == nil {
+		return nil, nil, fmt.Errorf("backup storage backend of environment %d is %q instead of %q", environmentID, policy.StorageBackend, api.BackupStorageBackendS3)
+	}
+	var secretAccessKey string
+	if policy.S3.AccessKeyID != "" {
+		if secretAccessKey, err = s.getBackupS3SecretAccessKey(ctx, environmentID); err != nil {
+			return nil, nil, err
+		}
+	}
+	client, err := s3.NewClient(ctx, s3.Config{
+		Region:          policy.S3.Region,
+		Endpoint:        policy.S3.Endpoint,
+		Bucket:          policy.S3.Bucket,
+		AccessKeyID:     policy.S3.AccessKeyID,
+		SecretAccessKey: secretAccessKey,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, policy.S3, nil
+}
+
+// getBackupS3SecretAccessKey returns the secret access key of the S3 storage backend of the environment, or empty if
+// it's not set. It's stored in its own setting instead of the backup storage policy, so it's never returned by the
+// policy API.
+func (s *Server) getBackupS3SecretAccessKey(ctx context.Context, environmentID int) (string, error) {
+	name := api.BackupS3SecretAccessKeySettingName(environmentID)
+	settingList, err := s.store.FindSetting(ctx, &api.SettingFind{Name: &name})
+	if err != nil {
+		return "", fmt.Errorf("failed to find setting %s, error: %w", name, err)
+	}
+	if len(settingList) == 0 {
+		return "", nil
+	}
+	return settingList[0].Value, nil
+}
+
+// setBackupS3SecretAccessKey stores the secret access key of the S3 storage backend of the environment.
+func (s *Server) setBackupS3SecretAccessKey(ctx context.Context, environmentID int, updaterID int, secretAccessKey string) error {
+	name := api.BackupS3SecretAccessKeySettingName(environmentID)
+	if _, err := s.store.CreateSettingIfNotExist(ctx, &api.SettingCreate{
+		CreatorID:   updaterID,
+		Name:        name,
+		Value:       "",
+		Description: fmt.Sprintf("The secret access key of the S3 backup storage backend of environment %d.", environmentID),
+	}); err != nil {
+		return err
+	}
+	_, err := s.store.PatchSetting(ctx, &api.SettingPatch{
+		UpdaterID: updaterID,
+		Name:      name,
+		Value:     secretAccessKey,
+	})
+	return err
+}
+
+// getBackupObjectKey returns the object key of the backup in the S3 storage backend.
+func getBackupObjectKey(prefix string, databaseID int, name string) string {
+	return path.Join(prefix, "backup", "db", strconv.Itoa(databaseID), fmt.Sprintf("%s.sql", name))
+}
+
+// getPgBaseBackupObjectKey returns the object key of the Postgres base backup of the instance in the S3 storage backend.
+func getPgBaseBackupObjectKey(prefix string, instanceID int, name string) string {
+	return path.Join(prefix, "backup", "instance", strconv.Itoa(instanceID), "basebackup", fmt.Sprintf("%s.tar", name))
+}
+
+// getWALFileObjectKey returns the object key of the archived Postgres WAL file of the instance in the S3 storage backend.
+func getWALFileObjectKey(prefix string, instanceID int, name string) string {
+	return path.Join(prefix, "backup", "instance", strconv.Itoa(instanceID), "wal-archive", name)
+}
+
+// backupFile is a file of the backup in the storage backend. Besides the dump file, a Postgres backup taken with the
+// PITR policy enabled references the base backup file of the instance as well.
+type backupFile struct {
+	// name is the name of the backup, for the error messages.
+	name           string
+	storageBackend api.BackupStorageBackend
+	path           string
+	info           api.BackupFileInfo
+}
+
+// getBackupDumpFile returns the dump file of the backup.
+func getBackupDumpFile(backup *api.Backup) *backupFile {
+	return &backupFile{
+		name:           backup.Name,
+		storageBackend: backup.StorageBackend,
+		path:           backup.Path,
+		info:           backup.Payload.BackupFileInfo,
+	}
+}
+
+// getPgBaseBackupFile returns the Postgres base backup file of the backup, or nil if the backup has none.
+func getPgBaseBackupFile(backup *api.Backup) *backupFile {
+	baseBackup := backup.Payload.PgBaseBackup
+	if baseBackup == nil {
+		return nil
+	}
+	file := getPgInstanceBaseBackupFile(baseBackup)
+	file.name = backup.Name
+	// The base backup taken along with the backup is stored in the storage backend of the backup.
+	if file.storageBackend == "" {
+		file.storageBackend = backup.StorageBackend
+	}
+	return file
+}
+
+// getPgInstanceBaseBackupFile returns the file of the Postgres base backup in the storage backend.
+func getPgInstanceBaseBackupFile(baseBackup *api.PgBaseBackup) *backupFile {
+	return &backupFile{
+		name:           baseBackup.Path,
+		storageBackend: baseBackup.StorageBackend,
+		path:           baseBackup.Path,
+		info:           baseBackup.BackupFileInfo,
+	}
+}
+
+// getPgWALFile returns the archived Postgres WAL file in the storage backend.
+func getPgWALFile(file *api.PgWALFile) *backupFile {
+	return &backupFile{
+		name:           file.Name,
+		storageBackend: file.StorageBackend,
+		path:           file.Path,
+		info:           file.Payload,
+	}
+}
+
+// writeBackupFile streams the backup written by the dump function to the storage backend of the backup.
+// The backup of the S3 storage backend is stored in the bucket of the environment, and the backup path is the object key.
+// The backup is compressed and encrypted by the backup storage policy of the environment, and the encoding, the checksum
+// and the size of the backup file are recorded in the payload.
+func (s *Server) writeBackupFile(ctx context.Context, backup *api.Backup, environmentID int, payload *api.BackupPayload, dump func(w io.Writer) error) error {
+	return s.writeStorageFile(ctx, getBackupDumpFile(backup), environmentID, &payload.BackupFileInfo, dump)
+}
+
+// writePgBaseBackupFile streams the Postgres base backup of the instance written by the dump function to the storage
+// backend of the environment, and returns the base backup with its file recorded.
+func (s *Server) writePgBaseBackupFile(ctx context.Context, instance *api.Instance, name string, dump func(w io.Writer) (*api.PgBaseBackup, error)) (*api.PgBaseBackup, error) {
+	storagePolicy, err := s.store.GetBackupStoragePolicyByEnvID(ctx, instance.EnvironmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup storage policy for environment %d, error: %w", instance.EnvironmentID, err)
+	}
+	file := &backupFile{
+		name:           name,
+		storageBackend: storagePolicy.StorageBackend,
+	}
+	switch storagePolicy.StorageBackend {
+	case api.BackupStorageBackendLocal:
+		dir := getPgBaseBackupRelativeDir(instance.ID)
+		if err := os.MkdirAll(filepath.Join(s.profile.DataDir, dir), os.ModePerm); err != nil {
+			return nil, fmt.Errorf("failed to create base backup directory %q, error: %w", dir, err)
+		}
+		file.path = filepath.Join(dir, fmt.Sprintf("%s.tar", name))
+	case api.BackupStorageBackendS3:
+		file.path = getPgBaseBackupObjectKey(storagePolicy.S3.Prefix, instance.ID, name)
+	default:
+		return nil, fmt.Errorf("backup storage backend %q not supported", storagePolicy.StorageBackend)
+	}
+
+	var baseBackup *api.PgBaseBackup
+	var info api.BackupFileInfo
+	if err := s.writeStorageFile(ctx, file, instance.EnvironmentID, &info, func(w io.Writer) error {
+		var err error
+		baseBackup, err = dump(w)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	baseBackup.StorageBackend = file.storageBackend
+	baseBackup.Path = file.path
+	baseBackup.BackupFileInfo = info
+	return baseBackup, nil
+}
+
+func (s *Server) writeStorageFile(ctx context.Context, file *backupFile, environmentID int, info *api.BackupFileInfo, dump func(w io.Writer) error) error {
+	policy, err := s.store.GetBackupStoragePolicyByEnvID(ctx, environmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get backup storage policy for environment %d, error: %w", environmentID, err)
+	}
+	var key []byte
+	if policy.Encrypted {
+		if key, err = s.getBackupEncryptionKey(ctx); err != nil {
+			return err
+		}
+	}
+
+	return s.uploadBackupFile(ctx, file, environmentID, func(w io.Writer) error {
+		hash := sha256.New()
+		cw := &countingWriter{w: io.MultiWriter(w, hash)}
+		encoder, err := codec.NewEncoder(cw, codec.Compression(policy.Compression), key)
+		if err != nil {
+			return err
+		}
+		if err := dump(encoder); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return fmt.Errorf("failed to encode backup, error: %w", err)
+		}
+		info.Compression = policy.Compression
+		info.Encrypted = policy.Encrypted
+		info.Checksum = hex.EncodeToString(hash.Sum(nil))
+		info.Size = cw.n
+		return nil
+	})
+}
+
+// uploadBackupFile streams the content written by the write function to the backup file in the storage backend.
+func (s *Server) uploadBackupFile(ctx context.Context, file *backupFile, environmentID int, write func(w io.Writer) error) error {
+	switch file.storageBackend {
+	case api.BackupStorageBackendLocal:
+		f, err := os.Create(filepath.Join(s.profile.DataDir, file.path))
+		if err != nil {
+			return fmt.Errorf("failed to open backup path: %s", file.path)
+		}
+		defer f.Close()
+		if err := write(f); err != nil {
+			return err
+		}
+		return f.Sync()
+	case api.BackupStorageBackendS3:
+		client, _, err := s.getBackupS3Client(ctx, environmentID)
+		if err != nil {
+			return err
+		}
+		pr, pw := io.Pipe()
+		uploadErr := make(chan error, 1)
+		go func() {
+			err := client.UploadObject(ctx, file.path, pr)
+			// Unblock the write if the upload fails halfway.
+			pr.CloseWithError(err)
+			uploadErr <- err
+		}()
+		writeErr := write(pw)
+		// Closing with nil error finishes the upload with io.EOF.
+		pw.CloseWithError(writeErr)
+		if err := <-uploadErr; writeErr == nil {
+			return err
+		}
+		return writeErr
+	}
+	return fmt.Errorf("backup storage backend %q not supported", file.storageBackend)
+}
+
+// openBackupFile opens the backup from its storage backend, and returns the reader of the decoded backup.
+// The checksum and the size of the backup file are verified before returning, so that a tampered or truncated backup
+// is never restored. The caller should close the reader.
+func (s *Server) openBackupFile(ctx context.Context, backup *api.Backup, environmentID int) (io.ReadCloser, error) {
+	return s.openStorageFile(ctx, getBackupDumpFile(backup), environmentID)
+}
+
+// openPgBaseBackupFile opens the Postgres base backup of the backup like openBackupFile, and returns the reader of
+// the decoded tarball. The caller should close the reader.
+func (s *Server) openPgBaseBackupFile(ctx context.Context, backup *api.Backup, environmentID int) (io.ReadCloser, error) {
+	file := getPgBaseBackupFile(backup)
+	if file == nil {
+		return nil, fmt.Errorf("backup %q has no base backup", backup.Name)
+	}
+	return s.openStorageFile(ctx, file, environmentID)
+}
+
+func (s *Server) openStorageFile(ctx context.Context, file *backupFile, environmentID int) (io.ReadCloser, error) {
+	f, err := s.downloadBackupFile(ctx, file, environmentID)
+	if err != nil {
+		return nil, err
+	}
+	// The backups taken before the checksum is recorded are neither compressed nor encrypted.
+	if file.info.Checksum == "" {
+		return f, nil
+	}
+
+	reader := &backupFileReader{closerList: []io.Closer{f}}
+	if err := verifyBackupFile(f, file.info.Checksum, file.info.Size); err != nil {
+		reader.Close()
+		return nil, fmt.Errorf("failed to verify backup %q, error: %w", file.name, err)
+	}
+	var key []byte
+	if file.info.Encrypted {
+		if key, err = s.getBackupEncryptionKey(ctx); err != nil {
+			reader.Close()
+			return nil, err
+		}
+	}
+	decoder, err := codec.NewDecoder(f, codec.Compression(file.info.Compression), key)
+	if err != nil {
+		reader.Close()
+		return nil, fmt.Errorf("failed to decode backup %q, error: %w", file.name, err)
+	}
+	reader.Reader = decoder
+	reader.closerList = append(reader.closerList, decoder)
+	return reader, nil
+}
+
+// downloadBackupFile opens the backup file in the storage backend. The S3 object is downloaded to a temporary file,
+// which is removed on close, so that the file can be read twice to verify the checksum first.
+func (s *Server) downloadBackupFile(ctx context.Context, file *backupFile, environmentID int) (io.ReadSeekCloser, error) {
+	switch file.storageBackend {
+	case api.BackupStorageBackendLocal:
+		backupPath := file.path
+		if !filepath.IsAbs(backupPath) {
+			backupPath = filepath.Join(s.profile.DataDir, backupPath)
+		}
+		f, err := os.OpenFile(backupPath, os.O_RDONLY, os.ModePerm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open backup file at %s: %w", backupPath, err)
+		}
+		return f, nil
+	case api.BackupStorageBackendS3:
+		client, _, err := s.getBackupS3Client(ctx, environmentID)
+		if err != nil {
+			return nil, err
+		}
+		object, err := client.ReadObject(ctx, file.path)
+		if err != nil {
+			return nil, err
+		}
+		defer object.Close()
+		f, err := os.CreateTemp("", "bytebase-backup-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary backup file, error: %w", err)
+		}
+		tmp := &tempFile{File: f}
+		if _, err := io.Copy(f, object); err != nil {
+			tmp.Close()
+			return nil, fmt.Errorf("failed to download backup %q, error: %w", file.path, err)
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			tmp.Close()
+			return nil, err
+		}
+		return tmp, nil
+	}
+	return nil, fmt.Errorf("backup storage backend %q not supported", file.storageBackend)
+}
+
+// verifyBackupFile verifies the SHA-256 checksum and the size of the backup file, and rewinds the file.
+func verifyBackupFile(file io.ReadSeeker, checksum string, size int64) error {
+	hash := sha256.New()
+	n, err := io.Copy(hash, file)
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("backup file size %d mismatches the recorded size %d", n, size)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != checksum {
+		return fmt.Errorf("backup file checksum %s mismatches the recorded checksum %s", got, checksum)
+	}
+	_, err = file.Seek(0, io.SeekStart)
+	return err
+}
+
+// getBackupEncryptionKey returns the workspace key to encrypt the backups.
+func (s *Server) getBackupEncryptionKey(ctx context.Context) ([]byte, error) {
+	name := api.SettingBackupEncryptionKey
+	settingList, err := s.store.FindSetting(ctx, &api.SettingFind{Name: &name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find setting %s, error: %w", name, err)
+	}
+	if len(settingList) == 0 {
+		return nil, fmt.Errorf("setting %s not found", name)
+	}
+	key, err := hex.DecodeString(settingList[0].Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup encryption key, error: %w", err)
+	}
+	return key, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// backupFileReader reads the decoded backup, and closes the decoder and the backup file in order.
+type backupFileReader struct {
+	io.Reader
+	closerList []io.Closer
+}
+
+func (r *backupFileReader) Close() error {
+	var closeErr error
+	for i := len(r.closerList) - 1; i >= 0; i-- {
+		if err := r.closerList[i].Close(); err != nil && closeErr == nil {
+			closeErr = err
+		}
+	}
+	return closeErr
+}
+
+// tempFile is the temporary file removed on close.
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	if removeErr := os.Remove(f.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// removeBackupFile removes the backup from its storage backend, including the Postgres base backup taken along with
+// the backup if any. The base backup of the instance referenced by the backup is removed by the WAL archiver after no
+// backup references it. It's not an error if the backup file doesn't exist.
+func (s *Server) removeBackupFile(ctx context.Context, backup *api.Backup, environmentID int) error {
+	if file := getPgBaseBackupFile(backup); file != nil && backup.Payload.PgBaseBackupID == 0 {
+		if err := s.removeStorageFile(ctx, file, environmentID); err != nil {
+			return err
+		}
+	}
+	return s.removeStorageFile(ctx, getBackupDumpFile(backup), environmentID)
+}
+
+func (s *Server) removeStorageFile(ctx context.Context, file *backupFile, environmentID int) error {
+	switch file.storageBackend {
+	case api.BackupStorageBackendLocal:
+		backupPath := file.path
+		if !filepath.IsAbs(backupPath) {
+			backupPath = filepath.Join(s.profile.DataDir, backupPath)
+		}
+		if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove backup file at %s: %w", backupPath, err)
+		}
+		return nil
+	case api.BackupStorageBackendS3:
+		client, _, err := s.getBackupS3Client(ctx, environmentID)
+		if err != nil {
+			return err
+		}
+		// Deleting a nonexistent object succeeds in S3.
+		return client.DeleteObject(ctx, file.path)
+	}
+	return fmt.Errorf("backup storage backend %q not supported", file.storageBackend)
+}
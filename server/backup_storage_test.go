@@ -0,0 +1,61 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyBackupFile(t *testing.T) {
+	content := []byte("CREATE TABLE t (id INT);\n")
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name     string
+		content  []byte
+		checksum string
+		size     int64
+		wantErr  bool
+	}{
+		{
+			name:     "valid",
+			content:  content,
+			checksum: checksum,
+			size:     int64(len(content)),
+			wantErr:  false,
+		},
+		{
+			name:     "truncated",
+			content:  content[:len(content)-1],
+			checksum: checksum,
+			size:     int64(len(content)),
+			wantErr:  true,
+		},
+		{
+			name:     "modified",
+			content:  append([]byte("DROP"), content[4:]...),
+			checksum: checksum,
+			size:     int64(len(content)),
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		file := bytes.NewReader(test.content)
+		err := verifyBackupFile(file, test.checksum, test.size)
+		if test.wantErr {
+			require.Error(t, err, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		// The file is rewound to be restored.
+		got, err := io.ReadAll(file)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.content, got, test.name)
+	}
+}
@@ -0,0 +1,323 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"go.uber.org/zap"
+)
+
+const (
+	// The restore verification is expensive, and only the latest successful backup of a database is verified once.
+	backupVerifierInterval = time.Duration(30) * time.Minute
+
+	// The row count from the schema sync is an estimation taken after the backup, so the row count of a table in the
+	// restored database is expected to be within the ratio of it, or differ by no more than the slack for small tables.
+	backupVerificationRowCountRatio = 2
+	backupVerificationRowCountSlack = 1000
+)
+
+// NewBackupVerifier creates a new backup verifier.
+func NewBackupVerifier(server *Server) *BackupVerifier {
+	return &BackupVerifier{
+		server: server,
+	}
+}
+
+// BackupVerifier is the runner verifying the latest successful backups by restoring them into scratch databases.
+type BackupVerifier struct {
+	server *Server
+}
+
+// Run is the runner for backup verifier.
+func (s *BackupVerifier) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(backupVerifierInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug(fmt.Sprintf("Backup verifier started and will run every %v", backupVerifierInterval))
+	for {
+		select {
+		case <-ticker.C:
+			log.Debug("New backup verification round started...")
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = fmt.Errorf("%v", r)
+						}
+						log.Error("Backup verifier PANIC RECOVER", zap.Error(err))
+					}
+				}()
+				s.verifyBackups(ctx)
+			}()
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+// verifyBackups verifies the latest successful backup of the databases enabling the backup verification.
+// The backups are verified one by one to limit the load on the instances.
+func (s *BackupVerifier) verifyBackups(ctx context.Context) {
+	backupSettingList, err := s.server.store.FindBackupSetting(ctx, &api.BackupSettingFind{})
+	if err != nil {
+		log.Error("Failed to retrieve backup settings", zap.Error(err))
+		return
+	}
+
+	for _, backupSetting := range backupSettingList {
+		database := backupSetting.Database
+		if database == nil || database.Name == api.AllDatabaseName {
+			continue
+		}
+		verificationPolicy, err := api.UnmarshalBackupVerificationPolicy(backupSetting.VerificationPolicy)
+		if err != nil {
+			log.Error("Failed to unmarshal backup verification policy",
+				zap.Int("databaseID", database.ID),
+				zap.Error(err))
+			continue
+		}
+		if !verificationPolicy.Enabled {
+			continue
+		}
+
+		status := api.BackupStatusDone
+		backupList, err := s.server.store.FindBackup(ctx, &api.BackupFind{DatabaseID: &database.ID, Status: &status})
+		if err != nil {
+			log.Error("Failed to retrieve backups for database",
+				zap.Int("databaseID", database.ID),
+				zap.Error(err))
+			continue
+		}
+		backup := getLatestBackup(backupList)
+		if backup == nil || backup.Payload.Verification != nil {
+			continue
+		}
+
+		log.Debug("Verify backup",
+			zap.String("database", database.Name),
+			zap.String("backup", backup.Name),
+		)
+		verification := s.server.verifyBackup(ctx, database, backup, verificationPolicy)
+		if verification.Status == api.BackupVerificationStatusFailed {
+			log.Warn("Backup verification failed",
+				zap.String("database", database.Name),
+				zap.String("backup", backup.Name),
+				zap.String("detail", verification.Detail))
+		}
+		backupPayload := backup.Payload
+		backupPayload.Verification = verification
+		payload, err := json.Marshal(backupPayload)
+		if err != nil {
+			log.Error("Failed to marshal backup payload",
+				zap.Int("backupID", backup.ID),
+				zap.Error(err))
+			continue
+		}
+		if _, err := s.server.store.PatchBackup(ctx, &api.BackupPatch{
+			ID:        backup.ID,
+			UpdaterID: api.SystemBotID,
+			Status:    string(backup.Status),
+			Comment:   backup.Comment,
+			Payload:   string(payload),
+		}); err != nil {
+			log.Error("Failed to record backup verification",
+				zap.Int("backupID", backup.ID),
+				zap.Error(err))
+		}
+	}
+}
+
+// getLatestBackup returns the latest created backup in the list.
+func getLatestBackup(backupList []*api.Backup) *api.Backup {
+	var latest *api.Backup
+	for _, backup := range backupList {
+		if latest == nil || backup.CreatedTs > latest.CreatedTs {
+			latest = backup
+		}
+	}
+	return latest
+}
+
+// verifyBackup restores the backup into a scratch database on the sandbox instance, or the instance of the database
+// if the sandbox instance is unset, and compares the table row counts with the ones from the schema sync.
+// The scratch database is dropped afterwards.
+func (s *Server) verifyBackup(ctx context.Context, database *api.Database, backup *api.Backup, verificationPolicy *api.BackupVerificationPolicy) *api.BackupVerification {
+	verification := &api.BackupVerification{
+		VerifiedTs: time.Now().Unix(),
+		InstanceID: database.InstanceID,
+	}
+	if verificationPolicy.SandboxInstanceID != 0 {
+		verification.InstanceID = verificationPolicy.SandboxInstanceID
+	}
+
+	actualRowCountMap, err := s.restoreScratchDatabase(ctx, database, backup, verification.InstanceID)
+	if err != nil {
+		verification.Status = api.BackupVerificationStatusFailed
+		verification.Detail = err.Error()
+		return verification
+	}
+	expectedTableList, err := s.store.FindTable(ctx, &api.TableFind{DatabaseID: &database.ID})
+	if err != nil {
+		verification.Status = api.BackupVerificationStatusFailed
+		verification.Detail = fmt.Sprintf("failed to find tables of database %q, error: %v", database.Name, err)
+		return verification
+	}
+
+	tableList, problemList := compareRestoredTableList(expectedTableList, actualRowCountMap, backup.CreatedTs)
+	verification.TableList = tableList
+	verification.Status = api.BackupVerificationStatusPassed
+	if len(problemList) > 0 {
+		verification.Status = api.BackupVerificationStatusFailed
+		verification.Detail = strings.Join(problemList, "; ")
+	}
+	return verification
+}
+
+// restoreScratchDatabase restores the backup into a scratch database on the instance, and returns the row count of
+// each table in the restored database.
+func (s *Server) restoreScratchDatabase(ctx context.Context, database *api.Database, backup *api.Backup, instanceID int) (map[string]int64, error) {
+	instance, err := s.store.GetInstanceByID(ctx, instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find instance %d, error: %w", instanceID, err)
+	}
+	if instance == nil {
+		return nil, fmt.Errorf("instance %d not found", instanceID)
+	}
+	if instance.Engine != database.Instance.Engine {
+		return nil, fmt.Errorf("instance %q engine %s mismatches the database instance engine %s", instance.Name, instance.Engine, database.Instance.Engine)
+	}
+	var createStmt, dropStmt string
+	scratchDatabaseName := fmt.Sprintf("bytebase_verify_backup_%d", backup.ID)
+	switch instance.Engine {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		createStmt = fmt.Sprintf("CREATE DATABASE `%s`;", scratchDatabaseName)
+		dropStmt = fmt.Sprintf("DROP DATABASE IF EXISTS `%s`;", scratchDatabaseName)
+	case db.Postgres:
+		createStmt = fmt.Sprintf("CREATE DATABASE \"%s\";", scratchDatabaseName)
+		dropStmt = fmt.Sprintf("DROP DATABASE IF EXISTS \"%s\";", scratchDatabaseName)
+	default:
+		return nil, fmt.Errorf("backup verification is not supported for engine %s", instance.Engine)
+	}
+
+	driver, err := getAdminDatabaseDriver(ctx, instance, "", s.pgInstanceDir)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+	// Drop the scratch database left over by the previous verification which failed halfway.
+	if err := driver.Execute(ctx, dropStmt); err != nil {
+		return nil, fmt.Errorf("failed to drop scratch database %q, error: %w", scratchDatabaseName, err)
+	}
+	if err := driver.Execute(ctx, createStmt); err != nil {
+		return nil, fmt.Errorf("failed to create scratch database %q, error: %w", scratchDatabaseName, err)
+	}
+	defer func() {
+		if err := driver.Execute(ctx, dropStmt); err != nil {
+			log.Error("Failed to drop scratch database",
+				zap.String("instance", instance.Name),
+				zap.String("database", scratchDatabaseName),
+				zap.Error(err))
+		}
+	}()
+
+	return s.countScratchDatabaseRows(ctx, instance, scratchDatabaseName, database, backup)
+}
+
+// countScratchDatabaseRows restores the backup into the scratch database, and counts the rows of each table.
+// The connection to the scratch database is closed on return, so that the scratch database can be dropped.
+func (s *Server) countScratchDatabaseRows(ctx context.Context, instance *api.Instance, scratchDatabaseName string, database *api.Database, backup *api.Backup) (map[string]int64, error) {
+	driver, err := getAdminDatabaseDriver(ctx, instance, scratchDatabaseName, s.pgInstanceDir)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	f, err := s.openBackupFile(ctx, backup, database.Instance.EnvironmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := driver.Restore(ctx, bufio.NewScanner(f)); err != nil {
+		return nil, fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	schemaList, err := driver.SyncSchema(ctx, scratchDatabaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync schema of scratch database %q, error: %w", scratchDatabaseName, err)
+	}
+	sqlDB, err := driver.GetDbConnection(ctx, scratchDatabaseName)
+	if err != nil {
+		return nil, err
+	}
+	rowCountMap := make(map[string]int64)
+	for _, schema := range schemaList {
+		for _, table := range schema.TableList {
+			// The table names from the Postgres schema sync are quoted if needed.
+			tableName := table.Name
+			switch instance.Engine {
+			case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+				tableName = fmt.Sprintf("`%s`", strings.ReplaceAll(table.Name, "`", "``"))
+			}
+			var rowCount int64
+			if err := sqlDB.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)).Scan(&rowCount); err != nil {
+				return nil, fmt.Errorf("failed to count rows of table %q, error: %w", table.Name, err)
+			}
+			rowCountMap[table.Name] = rowCount
+		}
+	}
+	return rowCountMap, nil
+}
+
+// compareRestoredTableList compares the row counts of the tables in the restored database with the expected ones from
+// the schema sync, and returns the comparison and the problems found. The tables created after the backup are skipped,
+// and the extra tables in the restored database are ignored since they may be dropped after the backup.
+func compareRestoredTableList(expectedTableList []*api.Table, actualRowCountMap map[string]int64, backupCreatedTs int64) ([]*api.BackupVerificationTable, []string) {
+	var tableList []*api.BackupVerificationTable
+	var problemList []string
+	for _, expected := range expectedTableList {
+		if expected.CreatedTs > backupCreatedTs {
+			continue
+		}
+		table := &api.BackupVerificationTable{
+			Name:             expected.Name,
+			ExpectedRowCount: expected.RowCount,
+			ActualRowCount:   -1,
+		}
+		tableList = append(tableList, table)
+		actual, ok := actualRowCountMap[expected.Name]
+		if !ok {
+			problemList = append(problemList, fmt.Sprintf("table %q is missing", expected.Name))
+			continue
+		}
+		table.ActualRowCount = actual
+		if !isRowCountMatched(expected.RowCount, actual) {
+			problemList = append(problemList, fmt.Sprintf("table %q has %d rows, while about %d rows are expected", expected.Name, actual, expected.RowCount))
+		}
+	}
+	sort.Slice(tableList, func(i, j int) bool {
+		return tableList[i].Name < tableList[j].Name
+	})
+	return tableList, problemList
+}
+
+func isRowCountMatched(expected, actual int64) bool {
+	diff := expected - actual
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff <= backupVerificationRowCountSlack {
+		return true
+	}
+	return actual*backupVerificationRowCountRatio >= expected && actual <= expected*backupVerificationRowCountRatio
+}
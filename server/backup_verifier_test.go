@@ -0,0 +1,60 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareRestoredTableList(t *testing.T) {
+	const backupCreatedTs = 1000
+	expectedTableList := []*api.Table{
+		{Name: "user", RowCount: 50000, CreatedTs: 100},
+		{Name: "order", RowCount: 120000, CreatedTs: 100},
+		{Name: "tag", RowCount: 10, CreatedTs: 100},
+		{Name: "audit", RowCount: 300, CreatedTs: 100},
+		// Created after the backup.
+		{Name: "coupon", RowCount: 5000, CreatedTs: 2000},
+	}
+	actualRowCountMap := map[string]int64{
+		"user":  48000,
+		"order": 10,
+		"tag":   0,
+		// Dropped after the backup.
+		"legacy": 1,
+	}
+
+	tableList, problemList := compareRestoredTableList(expectedTableList, actualRowCountMap, backupCreatedTs)
+	assert.Equal(t, []*api.BackupVerificationTable{
+		{Name: "audit", ExpectedRowCount: 300, ActualRowCount: -1},
+		{Name: "order", ExpectedRowCount: 120000, ActualRowCount: 10},
+		{Name: "tag", ExpectedRowCount: 10, ActualRowCount: 0},
+		{Name: "user", ExpectedRowCount: 50000, ActualRowCount: 48000},
+	}, tableList)
+	assert.Equal(t, []string{
+		`table "order" has 10 rows, while about 120000 rows are expected`,
+		`table "audit" is missing`,
+	}, problemList)
+}
+
+func TestIsRowCountMatched(t *testing.T) {
+	tests := []struct {
+		expected int64
+		actual   int64
+		want     bool
+	}{
+		{expected: 0, actual: 0, want: true},
+		{expected: 0, actual: 1000, want: true},
+		{expected: 0, actual: 1001, want: false},
+		{expected: 10000, actual: 5000, want: true},
+		{expected: 10000, actual: 4999, want: false},
+		{expected: 10000, actual: 20000, want: true},
+		{expected: 10000, actual: 20001, want: false},
+	}
+
+	for _, test := range tests {
+		got := isRowCountMatched(test.expected, test.actual)
+		assert.Equal(t, test.want, got, "expected %d, actual %d", test.expected, test.actual)
+	}
+}
@@ -9,6 +9,8 @@ import (
 const (
 	// secretLength is the length for the secret used to sign the JWT auto token
 	secretLength = 32
+	// backupEncryptionKeyLength is the byte length of the AES-256 key to encrypt the backups.
+	backupEncryptionKeyLength = 32
 )
 
 // retrieved via the SettingService upon startup
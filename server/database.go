@@ -16,6 +16,7 @@ import (
 	"github.com/youzi-1122/bytebase/common"
 	"github.com/youzi-1122/bytebase/common/log"
 	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/differ"
 )
 
 func (s *Server) registerDatabaseRoutes(g *echo.Group) {
@@ -479,6 +480,57 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		return nil
 	})
 
+	// The schema diff returns the DDL statements to migrate the database to the schema of the source database,
+	// which can be used to fix the schema drift or synchronize the schema across environments.
+	g.GET("/database/:id/schema-diff", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("id"))).SetInternal(err)
+		}
+		sourceID, err := strconv.Atoi(c.QueryParam("sourceDatabaseId"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Source database ID is not a number: %s", c.QueryParam("sourceDatabaseId"))).SetInternal(err)
+		}
+
+		targetDatabase, err := s.store.GetDatabase(ctx, &api.DatabaseFind{ID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", id)).SetInternal(err)
+		}
+		if targetDatabase == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
+		}
+		sourceDatabase, err := s.store.GetDatabase(ctx, &api.DatabaseFind{ID: &sourceID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", sourceID)).SetInternal(err)
+		}
+		if sourceDatabase == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", sourceID))
+		}
+		if sourceDatabase.Instance.Engine != targetDatabase.Instance.Engine {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Cannot diff the %s database against the %s database", sourceDatabase.Instance.Engine, targetDatabase.Instance.Engine))
+		}
+
+		sourceSchema, err := s.syncDatabaseSchemaForDiff(ctx, sourceDatabase)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to sync schema of database %q", sourceDatabase.Name)).SetInternal(err)
+		}
+		targetSchema, err := s.syncDatabaseSchemaForDiff(ctx, targetDatabase)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to sync schema of database %q", targetDatabase.Name)).SetInternal(err)
+		}
+		statementList, err := differ.SchemaDiff(targetDatabase.Instance.Engine, targetSchema, sourceSchema)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to diff the database schema").SetInternal(err)
+		}
+
+		return c.JSON(http.StatusOK, &api.DatabaseSchemaDiff{
+			SourceDatabaseID: sourceDatabase.ID,
+			TargetDatabaseID: targetDatabase.ID,
+			StatementList:    statementList,
+		})
+	})
+
 	g.POST("/database/:id/backup", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		id, err := strconv.Atoi(c.Param("id"))
@@ -500,7 +552,7 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
 		}
 
-		backup, err := s.scheduleBackupTask(ctx, database, backupCreate.Name, backupCreate.Type, backupCreate.StorageBackend, c.Get(getPrincipalIDContextKey()).(int))
+		backup, err := s.scheduleBackupTask(ctx, database, backupCreate.Name, backupCreate.Type, c.Get(getPrincipalIDContextKey()).(int))
 		if err != nil {
 			if common.ErrorCode(err) == common.DbConnectionFailure {
 				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to connect to instance %q", database.Instance.Name)).SetInternal(err)
@@ -567,8 +619,40 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		}
 		backupSettingUpsert.EnvironmentID = db.Instance.Environment.ID
 
+		if backupSettingUpsert.VerificationPolicy != nil {
+			verificationPolicy, err := api.UnmarshalBackupVerificationPolicy(*backupSettingUpsert.VerificationPolicy)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformed backup verification policy").SetInternal(err)
+			}
+			if verificationPolicy.SandboxInstanceID != 0 {
+				sandboxInstance, err := s.store.GetInstanceByID(ctx, verificationPolicy.SandboxInstanceID)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch sandbox instance ID: %v", verificationPolicy.SandboxInstanceID)).SetInternal(err)
+				}
+				if sandboxInstance == nil {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Sandbox instance not found with ID %d", verificationPolicy.SandboxInstanceID))
+				}
+				if sandboxInstance.Engine != db.Instance.Engine {
+					return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Sandbox instance engine %s mismatches the database instance engine %s", sandboxInstance.Engine, db.Instance.Engine))
+				}
+			}
+		}
+
+		if backupSettingUpsert.PITRPolicy != nil {
+			pitrPolicy, err := api.UnmarshalBackupPITRPolicy(*backupSettingUpsert.PITRPolicy)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformed backup PITR policy").SetInternal(err)
+			}
+			if pitrPolicy.Enabled && !isWALArchivingSupported(db.Instance.Engine) {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Backup PITR policy is not supported for engine %s", db.Instance.Engine))
+			}
+		}
+
 		backupSetting, err := s.store.UpsertBackupSetting(ctx, backupSettingUpsert)
 		if err != nil {
+			if common.ErrorCode(err) == common.Invalid {
+				return echo.NewHTTPError(http.StatusBadRequest, common.ErrorMessage(err)).SetInternal(err)
+			}
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to set backup setting").SetInternal(err)
 		}
 
@@ -813,6 +897,30 @@ func (s *Server) setDatabaseLabels(ctx context.Context, labelsJSON string, datab
 	return nil
 }
 
+// syncDatabaseSchemaForDiff syncs the latest schema of the database from the instance.
+func (s *Server) syncDatabaseSchemaForDiff(ctx context.Context, database *api.Database) (*db.Schema, error) {
+	driver, err := getAdminDatabaseDriver(ctx, database.Instance, database.Name, s.pgInstanceDir)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+
+	schemaList, err := driver.SyncSchema(ctx, database.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(schemaList) != 1 {
+		return nil, fmt.Errorf("found %v databases with name %s, expecting one", len(schemaList), database.Name)
+	}
+	return schemaList[0], nil
+}
+
+// isWALArchivingSupported returns whether the PITR policy archiving the WAL is supported for the engine.
+// The MySQL PITR replays the binlog without the policy.
+func isWALArchivingSupported(engine db.Type) bool {
+	return engine == db.Postgres
+}
+
 // Try to get database driver using the instance's admin data source.
 // Upon successful return, caller MUST call driver.Close, otherwise, it will leak the database connection.
 func getAdminDatabaseDriver(ctx context.Context, instance *api.Instance, databaseName, pgInstanceDir string) (db.Driver, error) {
@@ -469,6 +469,49 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 			}
 			// Snowflake needs to use upper case of DatabaseName.
 			c.DatabaseName = strings.ToUpper(c.DatabaseName)
+		case db.Oracle:
+			// Oracle maps a schema to a database, and the character set is defined at the database level.
+			if c.CharacterSet != "" {
+				return nil, echo.NewHTTPError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Failed to create issue, Oracle does not support character set, got %s\n", c.CharacterSet),
+				)
+			}
+			if c.Collation != "" {
+				return nil, echo.NewHTTPError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Failed to create issue, Oracle does not support collation, got %s\n", c.Collation),
+				)
+			}
+			// Oracle needs to use upper case of DatabaseName.
+			c.DatabaseName = strings.ToUpper(c.DatabaseName)
+		case db.MongoDB:
+			if c.CharacterSet != "" {
+				return nil, echo.NewHTTPError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Failed to create issue, MongoDB does not support character set, got %s\n", c.CharacterSet),
+				)
+			}
+			if c.Collation != "" {
+				return nil, echo.NewHTTPError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Failed to create issue, MongoDB does not support collation, got %s\n", c.Collation),
+				)
+			}
+		case db.Redis:
+			// Redis has a fixed number of logical databases, which can't be created.
+			return nil, echo.NewHTTPError(
+				http.StatusBadRequest,
+				"Failed to create issue, Redis does not support creating database",
+			)
+		case db.MSSQL:
+			// SQL Server derives the character set from the collation.
+			if c.CharacterSet != "" {
+				return nil, echo.NewHTTPError(
+					http.StatusBadRequest,
+					fmt.Sprintf("Failed to create issue, SQL Server does not support character set, got %s\n", c.CharacterSet),
+				)
+			}
 		case db.Postgres:
 			if c.Owner == "" {
 				return nil, echo.NewHTTPError(
@@ -840,6 +883,9 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 			if database == nil {
 				return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("database ID not found: %d", detail.DatabaseID))
 			}
+			if !api.IsGhostSupported(database.Instance.Engine) {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("failed to create issue, gh-ost doesn't support %s", database.Instance.Engine))
+			}
 
 			taskStatus, err := s.getPipelineApprovalPolicyForEnv(ctx, database.Instance.EnvironmentID)
 			if err != nil {
@@ -1003,14 +1049,14 @@ func createGhostTaskList(database *api.Database, vcsPushEvent *vcs.PushEvent, de
 
 func getDatabaseNameAndStatement(dbType db.Type, createDatabaseContext api.CreateDatabaseContext, schema string) (string, string) {
 	databaseName := createDatabaseContext.DatabaseName
-	// Snowflake needs to use upper case of DatabaseName.
-	if dbType == db.Snowflake {
+	// Snowflake and Oracle need to use upper case of DatabaseName.
+	if dbType == db.Snowflake || dbType == db.Oracle {
 		databaseName = strings.ToUpper(databaseName)
 	}
 
 	var stmt string
 	switch dbType {
-	case db.MySQL, db.TiDB:
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
 		stmt = fmt.Sprintf("CREATE DATABASE `%s` CHARACTER SET %s COLLATE %s;", databaseName, createDatabaseContext.CharacterSet, createDatabaseContext.Collation)
 		if schema != "" {
 			stmt = fmt.Sprintf("%s\nUSE `%s`;\n%s", stmt, databaseName, schema)
@@ -1048,6 +1094,27 @@ func getDatabaseNameAndStatement(dbType db.Type, createDatabaseContext api.Creat
 		if schema != "" {
 			stmt = fmt.Sprintf("%s\nUSE DATABASE %s;\n%s", stmt, databaseName, schema)
 		}
+	case db.Oracle:
+		// Oracle maps a schema to a database. The schema-only account can't be logged in.
+		stmt = fmt.Sprintf("CREATE USER \"%s\" NO AUTHENTICATION;\nGRANT UNLIMITED TABLESPACE TO \"%s\";", databaseName, databaseName)
+		if schema != "" {
+			stmt = fmt.Sprintf("%s\nALTER SESSION SET CURRENT_SCHEMA = \"%s\";\n%s", stmt, databaseName, schema)
+		}
+	case db.MSSQL:
+		stmt = fmt.Sprintf("CREATE DATABASE [%s]", databaseName)
+		if createDatabaseContext.Collation != "" {
+			stmt = fmt.Sprintf("%s COLLATE %s", stmt, createDatabaseContext.Collation)
+		}
+		stmt += ";"
+		if schema != "" {
+			stmt = fmt.Sprintf("%s\nGO\nUSE [%s];\nGO\n%s", stmt, databaseName, schema)
+		}
+	case db.MongoDB:
+		// MongoDB creates the database implicitly when the first collection is created in it.
+		stmt = fmt.Sprintf("db = db.getSiblingDB(%q);", databaseName)
+		if schema != "" {
+			stmt = fmt.Sprintf("%s\n%s", stmt, schema)
+		}
 	case db.SQLite:
 		// This is a fake CREATE DATABASE and USE statement since a single SQLite file represents a database. Engine driver will recognize it and establish a connection to create the sqlite file representing the database.
 		stmt = fmt.Sprintf("CREATE DATABASE '%s';", databaseName)
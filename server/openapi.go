@@ -19,11 +19,26 @@ var (
 	_ catalog.Catalog = (*catalogService)(nil)
 )
 
-// catalogService is the catalog service for sql check api.
+// catalogService is the empty catalog service for sql check api.
+// It's used if the database is not specified, and the catalog-based checks will be skipped.
 type catalogService struct{}
 
+// FindTable is the API message for find table in catalog.
+func (c *catalogService) FindTable(ctx context.Context, find *catalog.TableFind) (*catalog.Table, error) {
+	return nil, nil
+}
+
+// FindColumn is the API message for find column in catalog.
+func (c *catalogService) FindColumn(ctx context.Context, find *catalog.ColumnFind) (*catalog.Column, error) {
+	return nil, nil
+}
+
+// FindConstraint is the API message for find constraint in catalog.
+func (c *catalogService) FindConstraint(ctx context.Context, find *catalog.ConstraintFind) (*catalog.Constraint, error) {
+	return nil, nil
+}
+
 // FindIndex is the API message for find index in catalog.
-// We will not connect to the user's database in the early version of sql check api
 func (c *catalogService) FindIndex(ctx context.Context, find *catalog.IndexFind) (*catalog.Index, error) {
 	return nil, nil
 }
@@ -40,7 +55,7 @@ func (s *Server) registerOpenAPIRoutes(g *echo.Group) {
 // @Produce  json
 // @Param  environment   query  string  true   "The environment name. Case sensitive."
 // @Param  statement     query  string  true   "The SQL statement."
-// @Param  databaseType  query  string  false  "The database type. Required if the port, host and database name is not specified."  Enums(MySQL, PostgreSQL, TiDB)
+// @Param  databaseType  query  string  false  "The database type. Required if the port, host and database name is not specified."  Enums(MySQL, PostgreSQL, TiDB, MariaDB, OceanBase)
 // @Param  host          query  string  false  "The instance host."
 // @Param  port          query  string  false  "The instance port."
 // @Param  databaseName  query  string  false  "The database name in the instance."
@@ -62,13 +77,15 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 	ctx := c.Request().Context()
 	var dbType db.Type
 	var catalog catalog.Catalog = &catalogService{}
+	var database *api.Database
 
 	databaseName := c.QueryParams().Get("databaseName")
 	host := c.QueryParams().Get("host")
 	port := c.QueryParams().Get("port")
 
 	if databaseName != "" && host != "" && port != "" {
-		database, err := s.findDatabase(ctx, host, port, databaseName)
+		var err error
+		database, err = s.findDatabase(ctx, host, port, databaseName)
 		if err != nil {
 			return err
 		}
@@ -97,12 +114,23 @@ func (s *Server) sqlCheckController(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid environment %s", envName))
 	}
 
+	// The project and database policies override the environment policy if the database is specified.
+	var policyID int
+	if database != nil {
+		policyID, err = s.store.GetSchemaReviewPolicyIDByDatabase(ctx, database)
+	} else {
+		policyID, err = s.store.GetSchemaReviewPolicyIDByEnvID(ctx, envList[0].ID)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get schema review policy").SetInternal(err)
+	}
+
 	_, adviceList, err := s.sqlCheck(
 		ctx,
 		advisorDBType,
 		"utf8mb4",
 		"utf8mb4_general_ci",
-		envList[0].ID,
+		policyID,
 		statement,
 		catalog,
 	)
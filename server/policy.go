@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -33,7 +34,7 @@ func (s *Server) hasAccessToUpsertPolicy(policyUpsert *api.PolicyUpsert) error {
 		if !s.feature(api.FeatureApprovalPolicy) {
 			return fmt.Errorf(api.FeatureApprovalPolicy.AccessErrorMessage())
 		}
-	case api.PolicyTypeBackupPlan:
+	case api.PolicyTypeBackupPlan, api.PolicyTypeBackupStorage:
 		if !s.feature(api.FeatureBackupPolicy) {
 			return fmt.Errorf(api.FeatureBackupPolicy.AccessErrorMessage())
 		}
@@ -45,12 +46,65 @@ func (s *Server) hasAccessToUpsertPolicy(policyUpsert *api.PolicyUpsert) error {
 	return nil
 }
 
+// policyResource is the resource type a policy can be attached to, with the path and the ID param of its routes.
+type policyResource struct {
+	resourceType api.PolicyResourceType
+	path         string
+	idParam      string
+}
+
+var policyResourceList = []policyResource{
+	{resourceType: api.PolicyResourceTypeEnvironment, path: "environment", idParam: "environmentID"},
+	{resourceType: api.PolicyResourceTypeProject, path: "project", idParam: "projectID"},
+	{resourceType: api.PolicyResourceTypeDatabase, path: "database", idParam: "databaseID"},
+}
+
 func (s *Server) registerPolicyRoutes(g *echo.Group) {
-	g.PATCH("/policy/environment/:environmentID", func(c echo.Context) error {
+	for _, resource := range policyResourceList {
+		s.registerPolicyResourceRoutes(g, resource)
+	}
+
+	g.GET("/policy", func(c echo.Context) error {
+		pType := api.PolicyType(c.QueryParam("type"))
+		if err := api.ValidatePolicy(pType, ""); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid policy type: %q", pType)).SetInternal(err)
+		}
+		// Only list the environment policies by default, the project and database policies are the overrides.
+		resourceType := api.PolicyResourceTypeEnvironment
+		if v := c.QueryParam("resourceType"); v != "" {
+			resourceType = api.PolicyResourceType(v)
+			if err := api.ValidatePolicyResource(resourceType, pType); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid policy resource type: %q", resourceType)).SetInternal(err)
+			}
+		}
+
+		policyFind := &api.PolicyFind{
+			ResourceType: &resourceType,
+			Type:         &pType,
+		}
+
+		ctx := c.Request().Context()
+		policyList, err := s.store.ListPolicy(ctx, policyFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list policy for type %q", pType)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, policyList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal list policy response: %v", pType)).SetInternal(err)
+		}
+		return nil
+	})
+}
+
+func (s *Server) registerPolicyResourceRoutes(g *echo.Group, resource policyResource) {
+	path := fmt.Sprintf("/policy/%s/:%s", resource.path, resource.idParam)
+
+	g.PATCH(path, func(c echo.Context) error {
 		ctx := c.Request().Context()
-		environmentID, err := strconv.Atoi(c.Param("environmentID"))
+		resourceID, err := strconv.Atoi(c.Param(resource.idParam))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("environmentID is not a number: %s", c.Param("id"))).SetInternal(err)
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s is not a number: %s", resource.idParam, c.Param(resource.idParam))).SetInternal(err)
 		}
 
 		policyUpsert := &api.PolicyUpsert{}
@@ -61,14 +115,30 @@ func (s *Server) registerPolicyRoutes(g *echo.Group) {
 		if err := api.ValidatePolicy(pType, ""); err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid policy type: %q", pType)).SetInternal(err)
 		}
+		if err := api.ValidatePolicyResource(resource.resourceType, pType); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
+		if err := s.checkPolicyResourceExists(ctx, resource.resourceType, resourceID); err != nil {
+			return err
+		}
 
-		policyUpsert.EnvironmentID = environmentID
+		policyUpsert.ResourceType = resource.resourceType
+		setPolicyResourceID(resource.resourceType, resourceID, &policyUpsert.EnvironmentID, &policyUpsert.ProjectID, &policyUpsert.DatabaseID)
 		policyUpsert.Type = pType
 		policyUpsert.UpdaterID = c.Get(getPrincipalIDContextKey()).(int)
 
 		if err := s.hasAccessToUpsertPolicy(policyUpsert); err != nil {
 			return echo.NewHTTPError(http.StatusForbidden, err.Error()).SetInternal(err)
 		}
+		// secretAccessKey is the S3 secret access key to store after the backup storage policy is set, and nil to keep the stored one.
+		var secretAccessKey *string
+		if pType == api.PolicyTypeBackupStorage && policyUpsert.Payload != nil && *policyUpsert.Payload != "" {
+			payload, secret, err := s.stripBackupStorageSecret(ctx, resourceID, *policyUpsert.Payload)
+			if err != nil {
+				return err
+			}
+			policyUpsert.Payload, secretAccessKey = &payload, secret
+		}
 
 		policy, err := s.store.UpsertPolicy(ctx, policyUpsert)
 		if err != nil {
@@ -77,6 +147,11 @@ func (s *Server) registerPolicyRoutes(g *echo.Group) {
 			}
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to set policy for type %q", pType)).SetInternal(err)
 		}
+		if secretAccessKey != nil {
+			if err := s.setBackupS3SecretAccessKey(ctx, resourceID, policyUpsert.UpdaterID, *secretAccessKey); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to save the secret access key").SetInternal(err)
+			}
+		}
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
 		if err := jsonapi.MarshalPayload(c.Response().Writer, policy); err != nil {
@@ -85,24 +160,25 @@ func (s *Server) registerPolicyRoutes(g *echo.Group) {
 		return nil
 	})
 
-	g.DELETE("/policy/environment/:environmentID", func(c echo.Context) error {
-		environmentID, err := strconv.Atoi(c.Param("environmentID"))
+	g.DELETE(path, func(c echo.Context) error {
+		resourceID, err := strconv.Atoi(c.Param(resource.idParam))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("environmentID"))).SetInternal(err)
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param(resource.idParam))).SetInternal(err)
 		}
 
 		policyDelete := &api.PolicyDelete{
-			EnvironmentID: environmentID,
-			DeleterID:     c.Get(getPrincipalIDContextKey()).(int),
-			Type:          api.PolicyType(c.QueryParam("type")),
+			ResourceType: resource.resourceType,
+			DeleterID:    c.Get(getPrincipalIDContextKey()).(int),
+			Type:         api.PolicyType(c.QueryParam("type")),
 		}
+		setPolicyResourceID(resource.resourceType, resourceID, &policyDelete.EnvironmentID, &policyDelete.ProjectID, &policyDelete.DatabaseID)
 
 		ctx := c.Request().Context()
 		if err := s.store.DeletePolicy(ctx, policyDelete); err != nil {
 			if common.ErrorCode(err) == common.Invalid {
 				return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 			}
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete policy by environment ID %d", environmentID)).SetInternal(err)
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to delete policy by %s %d", resource.idParam, resourceID)).SetInternal(err)
 		}
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
@@ -110,19 +186,29 @@ func (s *Server) registerPolicyRoutes(g *echo.Group) {
 		return nil
 	})
 
-	g.GET("/policy/environment/:environmentID", func(c echo.Context) error {
+	g.GET(path, func(c echo.Context) error {
 		ctx := c.Request().Context()
-		environmentID, err := strconv.Atoi(c.Param("environmentID"))
+		resourceID, err := strconv.Atoi(c.Param(resource.idParam))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("environmentID is not a number: %s", c.Param("id"))).SetInternal(err)
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s is not a number: %s", resource.idParam, c.Param(resource.idParam))).SetInternal(err)
 		}
 		policyFind := &api.PolicyFind{}
 		pType := api.PolicyType(c.QueryParam("type"))
 		if err := api.ValidatePolicy(pType, ""); err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid policy type: %q", pType)).SetInternal(err)
 		}
+		if err := api.ValidatePolicyResource(resource.resourceType, pType); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+		}
 		policyFind.Type = &pType
-		policyFind.EnvironmentID = &environmentID
+		switch resource.resourceType {
+		case api.PolicyResourceTypeProject:
+			policyFind.ProjectID = &resourceID
+		case api.PolicyResourceTypeDatabase:
+			policyFind.DatabaseID = &resourceID
+		default:
+			policyFind.EnvironmentID = &resourceID
+		}
 
 		policy, err := s.store.GetPolicy(ctx, policyFind)
 		if err != nil {
@@ -135,27 +221,78 @@ func (s *Server) registerPolicyRoutes(g *echo.Group) {
 		}
 		return nil
 	})
+}
 
-	g.GET("/policy", func(c echo.Context) error {
-		pType := api.PolicyType(c.QueryParam("type"))
-		if err := api.ValidatePolicy(pType, ""); err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid policy type: %q", pType)).SetInternal(err)
-		}
+// stripBackupStorageSecret removes the S3 secret access key from the backup storage policy payload of the environment,
+// since it's stored in its own setting. It returns the payload without the secret access key, and the secret access
+// key to store, which is nil if it's left empty to keep the stored one, and empty if the access key ID is cleared.
+func (s *Server) stripBackupStorageSecret(ctx context.Context, environmentID int, payload string) (string, *string, error) {
+	policy, err := api.UnmarshalBackupStoragePolicy(payload)
+	if err != nil {
+		return "", nil, echo.NewHTTPError(http.StatusBadRequest, "Malformed backup storage policy").SetInternal(err)
+	}
+	if policy.S3 == nil {
+		return payload, nil, nil
+	}
 
-		policyFind := &api.PolicyFind{
-			Type: &pType,
+	secretAccessKey := policy.S3.SecretAccessKey
+	keep := false
+	switch {
+	case policy.S3.AccessKeyID == "" && secretAccessKey != "":
+		return "", nil, echo.NewHTTPError(http.StatusBadRequest, "Access key ID is required with the secret access key")
+	case policy.S3.AccessKeyID != "" && secretAccessKey == "":
+		stored, err := s.getBackupS3SecretAccessKey(ctx, environmentID)
+		if err != nil {
+			return "", nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to get the stored secret access key").SetInternal(err)
 		}
+		if stored == "" {
+			return "", nil, echo.NewHTTPError(http.StatusBadRequest, "Secret access key is required with the access key ID")
+		}
+		keep = true
+	}
 
-		ctx := c.Request().Context()
-		policyList, err := s.store.ListPolicy(ctx, policyFind)
+	policy.S3.SecretAccessKey = ""
+	stripped, err := policy.String()
+	if err != nil {
+		return "", nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal backup storage policy").SetInternal(err)
+	}
+	if keep {
+		return stripped, nil, nil
+	}
+	return stripped, &secretAccessKey, nil
+}
+
+// checkPolicyResourceExists returns the HTTP error if the project or the database of the policy doesn't exist.
+func (s *Server) checkPolicyResourceExists(ctx context.Context, resourceType api.PolicyResourceType, resourceID int) error {
+	switch resourceType {
+	case api.PolicyResourceTypeProject:
+		project, err := s.store.GetProjectByID(ctx, resourceID)
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list policy for type %q", pType)).SetInternal(err)
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find project ID: %d", resourceID)).SetInternal(err)
 		}
-
-		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
-		if err := jsonapi.MarshalPayload(c.Response().Writer, policyList); err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal list policy response: %v", pType)).SetInternal(err)
+		if project == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project ID not found: %d", resourceID))
 		}
-		return nil
-	})
+	case api.PolicyResourceTypeDatabase:
+		database, err := s.store.GetDatabase(ctx, &api.DatabaseFind{ID: &resourceID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find database ID: %d", resourceID)).SetInternal(err)
+		}
+		if database == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database ID not found: %d", resourceID))
+		}
+	}
+	return nil
+}
+
+// setPolicyResourceID sets the resource ID by the resource type.
+func setPolicyResourceID(resourceType api.PolicyResourceType, resourceID int, environmentID, projectID, databaseID *int) {
+	switch resourceType {
+	case api.PolicyResourceTypeProject:
+		*projectID = resourceID
+	case api.PolicyResourceTypeDatabase:
+		*databaseID = resourceID
+	default:
+		*environmentID = resourceID
+	}
 }
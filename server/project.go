@@ -18,6 +18,9 @@ import (
 	"github.com/labstack/echo/v4"
 
 	vcsPlugin "github.com/youzi-1122/bytebase/plugin/vcs"
+	"github.com/youzi-1122/bytebase/plugin/vcs/bitbucket"
+	"github.com/youzi-1122/bytebase/plugin/vcs/gitea"
+	"github.com/youzi-1122/bytebase/plugin/vcs/github"
 	"github.com/youzi-1122/bytebase/plugin/vcs/gitlab"
 )
 
@@ -168,6 +171,12 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 			}
 		}
 
+		if v := projectPatch.SchemaChangeType; v != nil {
+			if err := s.validateProjectSchemaChangeType(ctx, id, *v); err != nil {
+				return err
+			}
+		}
+
 		project, err := s.store.PatchProject(ctx, projectPatch)
 		if err != nil {
 			if common.ErrorCode(err) == common.NotFound {
@@ -213,6 +222,9 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 		if err := api.ValidateRepositorySchemaPathTemplate(repositoryCreate.SchemaPathTemplate, project.TenantMode); err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed create linked repository request: %s", err.Error()))
 		}
+		if repositoryCreate.SchemaPathTemplate == "" && project.SchemaChangeType == api.ProjectSchemaChangeTypeSDL {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed create linked repository request: the schema path template is required for the SDL schema change type")
+		}
 
 		vcs, err := s.store.GetVCSByID(ctx, repositoryCreate.VCSID)
 		if err != nil {
@@ -228,11 +240,13 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 
 		// Create webhook and retrieve the created webhook id
 		var webhookCreatePayload []byte
-		if vcs.Type == "GITLAB_SELF_HOST" {
+		switch vcs.Type {
+		case vcsPlugin.GitLabSelfHost:
 			webhookPost := gitlab.WebhookPost{
 				URL:                    fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, gitLabWebhookPath, repositoryCreate.WebhookEndpointID),
 				SecretToken:            repositoryCreate.WebhookSecretToken,
 				PushEvents:             true,
+				MergeRequestsEvents:    true,
 				PushEventsBranchFilter: repositoryCreate.BranchFilter,
 				EnableSSLVerification:  false,
 			}
@@ -240,6 +254,51 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal post request for creating webhook for project ID: %v", repositoryCreate.ProjectID)).SetInternal(err)
 			}
+		case vcsPlugin.GitHubCom:
+			// GitHub does not support filtering the push event by branch, the branch filter is applied when we receive the event.
+			webhookPost := github.WebhookCreateOrUpdate{
+				Config: github.WebhookConfig{
+					URL:         fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, gitHubWebhookPath, repositoryCreate.WebhookEndpointID),
+					ContentType: "json",
+					Secret:      repositoryCreate.WebhookSecretToken,
+					InsecureSSL: "0",
+				},
+				Events: []string{string(github.WebhookPush), string(github.WebhookPullRequest)},
+				Active: true,
+			}
+			webhookCreatePayload, err = json.Marshal(webhookPost)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal post request for creating webhook for project ID: %v", repositoryCreate.ProjectID)).SetInternal(err)
+			}
+		case vcsPlugin.GiteaSelfHost:
+			webhookPost := gitea.WebhookCreate{
+				Type: "gitea",
+				Config: gitea.WebhookConfig{
+					URL:         fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, giteaWebhookPath, repositoryCreate.WebhookEndpointID),
+					ContentType: "json",
+					Secret:      repositoryCreate.WebhookSecretToken,
+				},
+				Events:       []string{string(gitea.WebhookPush), string(gitea.WebhookPullRequest)},
+				BranchFilter: repositoryCreate.BranchFilter,
+				Active:       true,
+			}
+			webhookCreatePayload, err = json.Marshal(webhookPost)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal post request for creating webhook for project ID: %v", repositoryCreate.ProjectID)).SetInternal(err)
+			}
+		case vcsPlugin.BitbucketOrg:
+			// Bitbucket does not support filtering the push event by branch, the branch filter is applied when we receive the event.
+			webhookPost := bitbucket.WebhookCreateOrUpdate{
+				Description: "Bytebase GitOps",
+				URL:         fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, bitbucketWebhookPath, repositoryCreate.WebhookEndpointID),
+				Active:      true,
+				Secret:      repositoryCreate.WebhookSecretToken,
+				Events:      []string{string(bitbucket.WebhookPush), string(bitbucket.WebhookPullRequestCreated), string(bitbucket.WebhookPullRequestUpdated)},
+			}
+			webhookCreatePayload, err = json.Marshal(webhookPost)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal post request for creating webhook for project ID: %v", repositoryCreate.ProjectID)).SetInternal(err)
+			}
 		}
 
 		webhookID, err := vcsPlugin.Get(vcs.Type, vcsPlugin.ProviderConfig{}).CreateWebhook(
@@ -337,6 +396,9 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 			if err := api.ValidateRepositorySchemaPathTemplate(*repoPatch.SchemaPathTemplate, project.TenantMode); err != nil {
 				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Malformed create linked repository request: %s", err.Error()))
 			}
+			if *repoPatch.SchemaPathTemplate == "" && project.SchemaChangeType == api.ProjectSchemaChangeTypeSDL {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformed patch linked repository request: the schema path template is required for the SDL schema change type")
+			}
 		}
 
 		// Remove enclosing /
@@ -367,7 +429,9 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update repository for project ID: %d", projectID)).SetInternal(err)
 		}
 
-		if repoPatch.BranchFilter != nil {
+		// GitHub and Bitbucket webhooks have no branch filter, the updated branch filter is applied when we receive the event,
+		// so there is nothing to patch.
+		if repoPatch.BranchFilter != nil && repo.VCS.Type != vcsPlugin.GitHubCom && repo.VCS.Type != vcsPlugin.BitbucketOrg {
 			vcs, err := s.store.GetVCSByID(ctx, repo.VCSID)
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update repository for project ID: %d", projectID)).SetInternal(err)
@@ -382,12 +446,26 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 			if vcs.Type == "GITLAB_SELF_HOST" {
 				webhookPut := gitlab.WebhookPut{
 					URL:                    fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, gitLabWebhookPath, updatedRepo.WebhookEndpointID),
+					MergeRequestsEvents:    true,
 					PushEventsBranchFilter: *repoPatch.BranchFilter,
 				}
 				webhookPatchPayload, err = json.Marshal(webhookPut)
 				if err != nil {
 					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal put request for updating webhook %s for project ID: %v", repo.ExternalWebhookID, projectID)).SetInternal(err)
 				}
+			} else if vcs.Type == vcsPlugin.GiteaSelfHost {
+				webhookPatch := gitea.WebhookPatch{
+					Config: gitea.WebhookConfig{
+						URL:         fmt.Sprintf("%s:%d/%s/%s", s.profile.BackendHost, s.profile.BackendPort, giteaWebhookPath, updatedRepo.WebhookEndpointID),
+						ContentType: "json",
+						Secret:      updatedRepo.WebhookSecretToken,
+					},
+					BranchFilter: *repoPatch.BranchFilter,
+				}
+				webhookPatchPayload, err = json.Marshal(webhookPatch)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal patch request for updating webhook %s for project ID: %v", repo.ExternalWebhookID, projectID)).SetInternal(err)
+				}
 			}
 
 			err = vcsPlugin.Get(vcs.Type, vcsPlugin.ProviderConfig{}).PatchWebhook(
@@ -553,6 +631,41 @@ func (s *Server) registerProjectRoutes(g *echo.Group) {
 	})
 }
 
+// validateProjectSchemaChangeType validates the schema change type to patch.
+// The SDL schema change type requires the schema path template of the linked repository to locate the schema files,
+// and it's not supported for projects in tenant mode.
+func (s *Server) validateProjectSchemaChangeType(ctx context.Context, projectID int, schemaChangeType api.ProjectSchemaChangeType) error {
+	switch schemaChangeType {
+	case api.ProjectSchemaChangeTypeDDL:
+		return nil
+	case api.ProjectSchemaChangeTypeSDL:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid schema change type %q", schemaChangeType))
+	}
+
+	project, err := s.store.GetProjectByID(ctx, projectID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch project ID: %v", projectID)).SetInternal(err)
+	}
+	if project == nil {
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Project not found with ID %d", projectID))
+	}
+	if project.TenantMode == api.TenantModeTenant {
+		return echo.NewHTTPError(http.StatusBadRequest, "The SDL schema change type is not supported for projects in tenant mode")
+	}
+	if project.WorkflowType != api.VCSWorkflow {
+		return nil
+	}
+	repo, err := s.store.GetRepository(ctx, &api.RepositoryFind{ProjectID: &projectID})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch repository for project ID: %d", projectID)).SetInternal(err)
+	}
+	if repo != nil && repo.SchemaPathTemplate == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "The SDL schema change type requires the schema path template of the linked repository")
+	}
+	return nil
+}
+
 // refreshToken is a token refresher that stores the latest access token configuration to repository.
 func (s *Server) refreshToken(ctx context.Context, repositoryID int) common.TokenRefresher {
 	return func(token, refreshToken string, expiresTs int64) error {
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -47,6 +49,8 @@ type Server struct {
 	MetricReporter     *MetricReporter
 	SchemaSyncer       *SchemaSyncer
 	BackupRunner       *BackupRunner
+	BackupVerifier     *BackupVerifier
+	WALArchiver        *WALArchiver
 	AnomalyScanner     *AnomalyScanner
 	runnerWG           sync.WaitGroup
 
@@ -242,10 +246,14 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		statementSimpleExecutor := NewTaskCheckStatementAdvisorSimpleExecutor()
 		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementFakeAdvise, statementSimpleExecutor)
 		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementSyntax, statementSimpleExecutor)
+		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementCompatibility, statementSimpleExecutor)
 
 		statementCompositeExecutor := NewTaskCheckStatementAdvisorCompositeExecutor()
 		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementAdvise, statementCompositeExecutor)
 
+		statementAffectedRowsExecutor := NewTaskCheckStatementAffectedRowsExecutor()
+		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementAffectedRows, statementAffectedRowsExecutor)
+
 		databaseConnectExecutor := NewTaskCheckDatabaseConnectExecutor()
 		taskCheckScheduler.Register(api.TaskCheckDatabaseConnect, databaseConnectExecutor)
 
@@ -266,6 +274,12 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		// Backup runner
 		s.BackupRunner = NewBackupRunner(s, prof.BackupRunnerInterval)
 
+		// Backup verifier
+		s.BackupVerifier = NewBackupVerifier(s)
+
+		// WAL archiver
+		s.WALArchiver = NewWALArchiver(s)
+
 		// Anomaly scanner
 		s.AnomalyScanner = NewAnomalyScanner(s)
 
@@ -439,6 +453,20 @@ func (s *Server) initSetting(ctx context.Context, store *store.Store) (*config,
 		return nil, err
 	}
 
+	// initial backup encryption key
+	backupEncryptionKey := make([]byte, backupEncryptionKeyLength)
+	if _, err := rand.Read(backupEncryptionKey); err != nil {
+		return nil, err
+	}
+	if _, err = store.CreateSettingIfNotExist(ctx, &api.SettingCreate{
+		CreatorID:   api.SystemBotID,
+		Name:        api.SettingBackupEncryptionKey,
+		Value:       hex.EncodeToString(backupEncryptionKey),
+		Description: "The hex encoded AES-256 key to encrypt the backups.",
+	}); err != nil {
+		return nil, err
+	}
+
 	return conf, nil
 }
 
@@ -456,8 +484,14 @@ func (s *Server) Run(ctx context.Context) error {
 		s.runnerWG.Add(1)
 		go s.BackupRunner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
+		go s.BackupVerifier.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
+		go s.WALArchiver.Run(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
 		go s.AnomalyScanner.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
+		go s.subscribeWebhookPullRequestEvents(ctx, &s.runnerWG)
+		s.runnerWG.Add(1)
 
 		if s.MetricReporter != nil {
 			go s.MetricReporter.Run(ctx, &s.runnerWG)
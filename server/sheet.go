@@ -223,6 +223,10 @@ func (s *Server) registerSheetRoutes(g *echo.Group) {
 				sheetSource = api.SheetFromGitLabSelfHost
 			case vcsPlugin.GitHubCom:
 				sheetSource = api.SheetFromGitHubCom
+			case vcsPlugin.GiteaSelfHost:
+				sheetSource = api.SheetFromGiteaSelfHost
+			case vcsPlugin.BitbucketOrg:
+				sheetSource = api.SheetFromBitbucketOrg
 			}
 			vscSheetType := api.SheetForSQL
 			sheetFind := &api.SheetFind{
@@ -181,12 +181,17 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			}
 			db := dbList[0]
 
+			policyID, err := s.store.GetSchemaReviewPolicyIDByDatabase(ctx, db)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get schema review policy for database `%s`", exec.DatabaseName)).SetInternal(err)
+			}
+
 			adviceLevel, adviceList, err = s.sqlCheck(
 				ctx,
 				dbType,
 				db.CharacterSet,
 				db.Collation,
-				instance.EnvironmentID,
+				policyID,
 				exec.Statement,
 				store.NewCatalog(&db.ID, s.store),
 			)
@@ -795,6 +800,22 @@ func getLatestSchemaVersion(ctx context.Context, driver db.Driver, databaseName
 	return schemaVersion, nil
 }
 
+func getLatestSchema(ctx context.Context, driver db.Driver, databaseName string) (string, error) {
+	limit := 1
+	history, err := driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{
+		Database: &databaseName,
+		Limit:    &limit,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get migration history for database %q, error %v", databaseName, err)
+	}
+	var schema string
+	if len(history) == 1 {
+		schema = history[0].Schema
+	}
+	return schema, nil
+}
+
 func validateSQLSelectStatement(sqlStatement string) bool {
 	// Check if the query has only one statement.
 	count := 0
@@ -858,12 +879,12 @@ func (s *Server) sqlCheck(
 	dbType advisor.DBType,
 	dbCharacterSet string,
 	dbCollation string,
-	environmentID int,
+	policyID int,
 	statement string,
 	catalog catalog.Catalog,
 ) (advisor.Status, []advisor.Advice, error) {
 	var adviceList []advisor.Advice
-	policy, err := s.store.GetNormalSchemaReviewPolicy(ctx, &api.PolicyFind{EnvironmentID: &environmentID})
+	policy, err := s.store.GetNormalSchemaReviewPolicy(ctx, &api.PolicyFind{ID: &policyID})
 	if err != nil {
 		if e, ok := err.(*common.Error); ok && e.Code == common.NotFound {
 			adviceList = []advisor.Advice{
@@ -238,6 +238,33 @@ func (s *Server) registerTaskRoutes(g *echo.Group) {
 					}
 				}
 
+				if taskPatched.Type != api.TaskDatabaseDataUpdate && api.IsCompatibilityCheckSupported(task.Database.Instance.Engine, s.profile.Mode) {
+					payload, err := json.Marshal(api.TaskCheckDatabaseStatementAdvisePayload{
+						Statement: *taskPatch.Statement,
+						DbType:    task.Database.Instance.Engine,
+						Charset:   taskPatched.Database.CharacterSet,
+						Collation: taskPatched.Database.Collation,
+					})
+					if err != nil {
+						return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("failed to marshal statement advise payload: %v, err: %w", task.Name, err))
+					}
+					_, err = s.store.CreateTaskCheckRunIfNeeded(ctx, &api.TaskCheckRunCreate{
+						CreatorID:               api.SystemBotID,
+						TaskID:                  task.ID,
+						Type:                    api.TaskCheckDatabaseStatementCompatibility,
+						Payload:                 string(payload),
+						SkipIfAlreadyTerminated: false,
+					})
+					if err != nil {
+						// It's OK if we failed to trigger a check, just emit an error log
+						log.Error("Failed to trigger compatibility check after changing the task statement",
+							zap.Int("task_id", task.ID),
+							zap.String("task_name", task.Name),
+							zap.Error(err),
+						)
+					}
+				}
+
 				if s.feature(api.FeatureSchemaReviewPolicy) && api.IsSchemaReviewSupported(task.Database.Instance.Engine, s.profile.Mode) {
 					if err := s.triggerDatabaseStatementAdviseTask(ctx, *taskPatch.Statement, taskPatched); err != nil {
 						return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("failed to trigger database statement advise task, err: %w", err)).SetInternal(err)
@@ -544,14 +571,14 @@ func (s *Server) changeTaskStatusWithPatch(ctx context.Context, task *api.Task,
 }
 
 func (s *Server) triggerDatabaseStatementAdviseTask(ctx context.Context, statement string, task *api.Task) error {
-	policyID, err := s.store.GetSchemaReviewPolicyIDByEnvID(ctx, task.Instance.EnvironmentID)
+	policyID, err := s.store.GetSchemaReviewPolicyIDByDatabase(ctx, task.Database)
 
 	if err != nil {
 		// It's OK if we failed to find the schema review policy, just emit an error log
 		log.Error("Failed to found schema review policy id for task",
 			zap.Int("task_id", task.ID),
 			zap.String("task_name", task.Name),
-			zap.Int("environment_id", task.Instance.EnvironmentID),
+			zap.Int("database_id", task.Database.ID),
 			zap.Error(err),
 		)
 		return nil
@@ -583,5 +610,22 @@ func (s *Server) triggerDatabaseStatementAdviseTask(ctx context.Context, stateme
 		)
 	}
 
+	if task.Type == api.TaskDatabaseDataUpdate && api.IsAffectedRowsCheckSupported(task.Database.Instance.Engine) {
+		if _, err := s.store.CreateTaskCheckRunIfNeeded(ctx, &api.TaskCheckRunCreate{
+			CreatorID:               api.SystemBotID,
+			TaskID:                  task.ID,
+			Type:                    api.TaskCheckDatabaseStatementAffectedRows,
+			Payload:                 string(payload),
+			SkipIfAlreadyTerminated: false,
+		}); err != nil {
+			// It's OK if we failed to trigger a check, just emit an error log
+			log.Error("Failed to trigger affected rows check after changing task statement",
+				zap.Int("task_id", task.ID),
+				zap.String("task_name", task.Name),
+				zap.Error(err),
+			)
+		}
+	}
+
 	return nil
 }
@@ -83,7 +83,10 @@ func (exec *TaskCheckStatementAdvisorCompositeExecutor) Run(ctx context.Context,
 		status := api.TaskCheckStatusSuccess
 		switch advice.Status {
 		case advisor.Success:
-			continue
+			// Keep the suppressed advices for audit.
+			if !advice.Suppressed {
+				continue
+			}
 		case advisor.Warn:
 			status = api.TaskCheckStatusWarn
 		case advisor.Error:
@@ -91,11 +94,15 @@ func (exec *TaskCheckStatementAdvisorCompositeExecutor) Run(ctx context.Context,
 		}
 
 		result = append(result, api.TaskCheckResult{
-			Status:    status,
-			Namespace: api.AdvisorNamespace,
-			Code:      advice.Code.Int(),
-			Title:     advice.Title,
-			Content:   advice.Content,
+			Status:         status,
+			Namespace:      api.AdvisorNamespace,
+			Code:           advice.Code.Int(),
+			Title:          advice.Title,
+			Content:        advice.Content,
+			StatementIndex: advice.StatementIndex,
+			Line:           advice.Line,
+			Column:         advice.Column,
+			Suppressed:     advice.Suppressed,
 		})
 	}
 
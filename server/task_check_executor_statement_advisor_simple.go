@@ -9,6 +9,7 @@ import (
 	"github.com/youzi-1122/bytebase/common"
 	"github.com/youzi-1122/bytebase/plugin/advisor"
 	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/store"
 )
 
 // NewTaskCheckStatementAdvisorSimpleExecutor creates a task check statement simple advisor executor.
@@ -27,19 +28,43 @@ func (exec *TaskCheckStatementAdvisorSimpleExecutor) Run(ctx context.Context, se
 		return nil, common.Errorf(common.Invalid, fmt.Errorf("invalid check statement advise payload: %w", err))
 	}
 
+	advisorCtx := advisor.Context{
+		Charset:   payload.Charset,
+		Collation: payload.Collation,
+	}
 	var advisorType advisor.Type
 	switch taskCheckRun.Type {
 	case api.TaskCheckDatabaseStatementFakeAdvise:
 		advisorType = advisor.Fake
 	case api.TaskCheckDatabaseStatementSyntax:
 		switch payload.DbType {
-		case db.MySQL, db.TiDB:
+		case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
 			advisorType = advisor.MySQLSyntax
 		case db.Postgres:
 			advisorType = advisor.PostgreSQLSyntax
 		default:
 			return nil, common.Errorf(common.Invalid, fmt.Errorf("invalid database type: %s for syntax statement advisor", payload.DbType))
 		}
+	case api.TaskCheckDatabaseStatementCompatibility:
+		switch payload.DbType {
+		case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+			advisorType = advisor.MySQLMigrationCompatibility
+		case db.Postgres:
+			advisorType = advisor.PostgreSQLMigrationCompatibility
+		default:
+			return nil, common.Errorf(common.Invalid, fmt.Errorf("invalid database type: %s for compatibility statement advisor", payload.DbType))
+		}
+		// The incompatible changes are reported as warnings, so users can still run the task manually.
+		advisorCtx.Rule = &advisor.SchemaReviewRule{
+			Type:  advisor.SchemaRuleSchemaBackwardCompatibility,
+			Level: advisor.SchemaRuleLevelWarning,
+		}
+		// The catalog tells whether the changed tables have data.
+		task, err := server.store.GetTaskByID(ctx, taskCheckRun.TaskID)
+		if err != nil {
+			return nil, common.Errorf(common.Internal, fmt.Errorf("failed to get task by id: %w", err))
+		}
+		advisorCtx.Catalog = store.NewCatalog(task.DatabaseID, server.store)
 	}
 
 	dbType, err := api.ConvertToAdvisorDBType(payload.DbType)
@@ -50,10 +75,7 @@ func (exec *TaskCheckStatementAdvisorSimpleExecutor) Run(ctx context.Context, se
 	adviceList, err := advisor.Check(
 		dbType,
 		advisorType,
-		advisor.Context{
-			Charset:   payload.Charset,
-			Collation: payload.Collation,
-		},
+		advisorCtx,
 		payload.Statement,
 	)
 	if err != nil {
@@ -73,11 +95,15 @@ func (exec *TaskCheckStatementAdvisorSimpleExecutor) Run(ctx context.Context, se
 		}
 
 		result = append(result, api.TaskCheckResult{
-			Status:    status,
-			Namespace: api.AdvisorNamespace,
-			Code:      advice.Code.Int(),
-			Title:     advice.Title,
-			Content:   advice.Content,
+			Status:         status,
+			Namespace:      api.AdvisorNamespace,
+			Code:           advice.Code.Int(),
+			Title:          advice.Title,
+			Content:        advice.Content,
+			StatementIndex: advice.StatementIndex,
+			Line:           advice.Line,
+			Column:         advice.Column,
+			Suppressed:     advice.Suppressed,
 		})
 	}
 
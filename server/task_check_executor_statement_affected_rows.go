@@ -0,0 +1,374 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tidbparser "github.com/pingcap/tidb/parser"
+	tidbast "github.com/pingcap/tidb/parser/ast"
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/parser"
+	"github.com/youzi-1122/bytebase/plugin/parser/ast"
+)
+
+// NewTaskCheckStatementAffectedRowsExecutor creates a task check statement affected rows executor.
+func NewTaskCheckStatementAffectedRowsExecutor() TaskCheckExecutor {
+	return &TaskCheckStatementAffectedRowsExecutor{}
+}
+
+// TaskCheckStatementAffectedRowsExecutor is the task check statement affected rows executor.
+// It estimates the affected rows of each UPDATE and DELETE statement by EXPLAIN through the read-only data source,
+// and checks them against the affected row limit rule in the schema review policy.
+type TaskCheckStatementAffectedRowsExecutor struct {
+}
+
+// Run will run the task check statement affected rows executor once.
+func (exec *TaskCheckStatementAffectedRowsExecutor) Run(ctx context.Context, server *Server, taskCheckRun *api.TaskCheckRun) (result []api.TaskCheckResult, err error) {
+	payload := &api.TaskCheckDatabaseStatementAdvisePayload{}
+	if err := json.Unmarshal([]byte(taskCheckRun.Payload), payload); err != nil {
+		return nil, common.Errorf(common.Invalid, fmt.Errorf("invalid check statement affected rows payload: %w", err))
+	}
+
+	policy, err := server.store.GetNormalSchemaReviewPolicy(ctx, &api.PolicyFind{ID: &payload.PolicyID})
+	if err != nil {
+		if e, ok := err.(*common.Error); ok && e.Code == common.NotFound {
+			return skipAffectedRowsCheckResult("Empty schema review policy or disabled"), nil
+		}
+		return nil, common.Errorf(common.Internal, fmt.Errorf("failed to get schema review policy: %w", err))
+	}
+
+	var rule *advisor.SchemaReviewRule
+	for _, r := range policy.RuleList {
+		if r.Type == advisor.SchemaRuleStatementAffectedRowLimit && r.Level != advisor.SchemaRuleLevelDisabled {
+			rule = r
+			break
+		}
+	}
+	if rule == nil {
+		return skipAffectedRowsCheckResult("The affected row limit rule is not enabled"), nil
+	}
+	rulePayload, err := advisor.UnmarshalNumberTypeRulePayload(rule.Payload)
+	if err != nil {
+		return nil, common.Errorf(common.Invalid, err)
+	}
+	status := api.TaskCheckStatusWarn
+	if rule.Level == advisor.SchemaRuleLevelError {
+		status = api.TaskCheckStatusError
+	}
+
+	statementList, err := getDataChangeStatementList(payload.DbType, payload.Statement, payload.Charset, payload.Collation)
+	if err != nil {
+		// The syntax error is reported by the syntax check.
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusWarn,
+				Namespace: api.AdvisorNamespace,
+				Code:      advisor.StatementSyntaxError.Int(),
+				Title:     "Failed to parse the statement for the affected rows",
+				Content:   err.Error(),
+			},
+		}, nil
+	}
+	if len(statementList) == 0 {
+		return skipAffectedRowsCheckResult("There is no UPDATE or DELETE statement"), nil
+	}
+
+	task, err := server.store.GetTaskByID(ctx, taskCheckRun.TaskID)
+	if err != nil {
+		return nil, common.Errorf(common.Internal, fmt.Errorf("failed to get task by id: %w", err))
+	}
+	if task == nil {
+		return nil, common.Errorf(common.NotFound, fmt.Errorf("task not found %v", taskCheckRun.TaskID))
+	}
+	database, err := server.store.GetDatabase(ctx, &api.DatabaseFind{ID: task.DatabaseID})
+	if err != nil {
+		return nil, common.Errorf(common.Internal, err)
+	}
+	if database == nil {
+		return nil, common.Errorf(common.Internal, fmt.Errorf("database ID not found %v", task.DatabaseID))
+	}
+
+	driver, err := tryGetReadOnlyDatabaseDriver(ctx, database.Instance, database.Name)
+	if err != nil {
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusError,
+				Namespace: api.BBNamespace,
+				Code:      common.DbConnectionFailure.Int(),
+				Title:     fmt.Sprintf("Failed to connect %q", database.Name),
+				Content:   err.Error(),
+			},
+		}, nil
+	}
+	defer driver.Close(ctx)
+
+	for _, statement := range statementList {
+		rowCount, err := estimateAffectedRows(ctx, driver, payload.DbType, statement)
+		if err != nil {
+			// The table may be created by the previous statements in the same task, so we only warn here.
+			result = append(result, api.TaskCheckResult{
+				Status:    api.TaskCheckStatusWarn,
+				Namespace: api.BBNamespace,
+				Code:      common.DbExecutionError.Int(),
+				Title:     "Failed to estimate the affected rows",
+				Content:   fmt.Sprintf("%q: %s", statement, err.Error()),
+			})
+			continue
+		}
+		if rowCount > int64(rulePayload.Number) {
+			result = append(result, api.TaskCheckResult{
+				Status:    status,
+				Namespace: api.AdvisorNamespace,
+				Code:      advisor.StatementAffectedRowExceeded.Int(),
+				Title:     string(rule.Type),
+				Content:   fmt.Sprintf("%q is estimated to affect %d rows, which exceeds the limit %d", statement, rowCount, rulePayload.Number),
+			})
+		}
+	}
+
+	if len(result) == 0 {
+		result = append(result, api.TaskCheckResult{
+			Status:    api.TaskCheckStatusSuccess,
+			Namespace: api.AdvisorNamespace,
+			Code:      advisor.Ok.Int(),
+			Title:     "OK",
+			Content:   fmt.Sprintf("The estimated affected rows of each statement are within the limit %d", rulePayload.Number),
+		})
+	}
+	return result, nil
+}
+
+func skipAffectedRowsCheckResult(reason string) []api.TaskCheckResult {
+	return []api.TaskCheckResult{
+		{
+			Status:    api.TaskCheckStatusSuccess,
+			Namespace: api.AdvisorNamespace,
+			Code:      advisor.Ok.Int(),
+			Title:     "OK",
+			Content:   reason,
+		},
+	}
+}
+
+// getDataChangeStatementList returns the UPDATE and DELETE statements in the statement.
+func getDataChangeStatementList(dbType db.Type, statement string, charset string, collation string) ([]string, error) {
+	var res []string
+	switch dbType {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		p := tidbparser.New()
+		// To support MySQL8 window function syntax.
+		p.EnableWindowFunc(true)
+		nodeList, _, err := p.Parse(statement, charset, collation)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodeList {
+			switch node.(type) {
+			case *tidbast.UpdateStmt, *tidbast.DeleteStmt:
+				res = append(res, strings.TrimSpace(node.Text()))
+			}
+		}
+	case db.Postgres:
+		nodeList, err := parser.Parse(parser.Postgres, parser.Context{}, statement)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodeList {
+			switch node.(type) {
+			case *ast.UpdateStmt, *ast.DeleteStmt:
+				res = append(res, strings.TrimSpace(node.Text()))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("affected rows check doesn't support database type %s", dbType)
+	}
+	return res, nil
+}
+
+// estimateAffectedRows returns the estimated affected rows of the statement by EXPLAIN.
+// EXPLAIN doesn't execute the statement, and the driver runs it in a read-only transaction.
+func estimateAffectedRows(ctx context.Context, driver db.Driver, dbType db.Type, statement string) (int64, error) {
+	statement = strings.TrimSuffix(strings.TrimSpace(statement), ";")
+	switch dbType {
+	case db.MySQL, db.MariaDB:
+		res, err := driver.Query(ctx, fmt.Sprintf("EXPLAIN %s", statement), 0)
+		if err != nil {
+			return 0, err
+		}
+		return getMaxExplainRows(res, "rows")
+	case db.TiDB:
+		res, err := driver.Query(ctx, fmt.Sprintf("EXPLAIN %s", statement), 0)
+		if err != nil {
+			return 0, err
+		}
+		return getMaxExplainRows(res, "estRows")
+	case db.OceanBase:
+		res, err := driver.Query(ctx, fmt.Sprintf("EXPLAIN %s", statement), 0)
+		if err != nil {
+			return 0, err
+		}
+		return getOceanBaseExplainRows(res)
+	case db.Postgres:
+		res, err := driver.Query(ctx, fmt.Sprintf("EXPLAIN (FORMAT JSON) %s", statement), 0)
+		if err != nil {
+			return 0, err
+		}
+		return getPostgresExplainRows(res)
+	}
+	return 0, fmt.Errorf("affected rows check doesn't support database type %s", dbType)
+}
+
+// getMaxExplainRows returns the largest estimated rows in the column of the tabular EXPLAIN result.
+// For MySQL, each row is a table accessed by the statement. For TiDB, each row is an operator, and the root UPDATE/DELETE operator has no estimation.
+// The largest one is an upper bound of the affected rows for the single table statement.
+func getMaxExplainRows(res []interface{}, column string) (int64, error) {
+	columnNames, rows, err := getQueryResult(res)
+	if err != nil {
+		return 0, err
+	}
+	index := -1
+	for i, name := range columnNames {
+		if strings.EqualFold(name, column) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return 0, fmt.Errorf("column %q not found in the EXPLAIN result", column)
+	}
+
+	var maxRows int64
+	for _, row := range rows {
+		if index >= len(row) || row[index] == nil {
+			continue
+		}
+		value, err := strconv.ParseFloat(fmt.Sprintf("%v", row[index]), 64)
+		if err != nil {
+			// TiDB uses "N/A" for the operator without estimation.
+			continue
+		}
+		if int64(value) > maxRows {
+			maxRows = int64(value)
+		}
+	}
+	return maxRows, nil
+}
+
+// getOceanBaseExplainRows returns the largest estimated rows in the OceanBase EXPLAIN result.
+// Unlike MySQL, OceanBase returns the plan as a text table in the "Query Plan" column, either in a single row or one row
+// per line depending on the version, e.g.
+//
+//	|ID|OPERATOR   |NAME|EST. ROWS|COST|
+//	|0 |DELETE     |    |1000     |4201|
+//	|1 | TABLE SCAN|t   |1000     |3911|
+func getOceanBaseExplainRows(res []interface{}) (int64, error) {
+	_, rows, err := getQueryResult(res)
+	if err != nil {
+		return 0, err
+	}
+	var lines []string
+	for _, row := range rows {
+		for _, value := range row {
+			if value != nil {
+				lines = append(lines, strings.Split(fmt.Sprintf("%v", value), "\n")...)
+			}
+		}
+	}
+
+	index := -1
+	var maxRows int64
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "|") {
+			continue
+		}
+		cells := strings.Split(strings.Trim(line, "|"), "|")
+		// The header is "EST. ROWS" in OceanBase 3.x and "EST.ROWS" in OceanBase 4.x.
+		if index < 0 {
+			for i, cell := range cells {
+				if strings.ReplaceAll(strings.ToUpper(cell), " ", "") == "EST.ROWS" {
+					index = i
+					break
+				}
+			}
+			continue
+		}
+		if index >= len(cells) {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(cells[index]), 64)
+		if err != nil {
+			continue
+		}
+		if int64(value) > maxRows {
+			maxRows = int64(value)
+		}
+	}
+	if index < 0 {
+		return 0, fmt.Errorf("column %q not found in the EXPLAIN result", "EST. ROWS")
+	}
+	return maxRows, nil
+}
+
+// postgresExplainPlan is the plan node of the PostgreSQL EXPLAIN JSON output.
+type postgresExplainPlan struct {
+	NodeType string                `json:"Node Type"`
+	PlanRows float64               `json:"Plan Rows"`
+	Plans    []postgresExplainPlan `json:"Plans"`
+}
+
+// getPostgresExplainRows returns the estimated rows of the PostgreSQL EXPLAIN JSON output.
+// The ModifyTable node of the UPDATE/DELETE statement always estimates 0 rows without RETURNING, so we use its scan node.
+func getPostgresExplainRows(res []interface{}) (int64, error) {
+	_, rows, err := getQueryResult(res)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return 0, fmt.Errorf("empty EXPLAIN result")
+	}
+	var explain []struct {
+		Plan postgresExplainPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(fmt.Sprintf("%v", rows[0][0])), &explain); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal the EXPLAIN result: %w", err)
+	}
+	if len(explain) == 0 {
+		return 0, fmt.Errorf("empty EXPLAIN result")
+	}
+	plan := explain[0].Plan
+	if plan.NodeType == "ModifyTable" && len(plan.Plans) > 0 {
+		plan = plan.Plans[0]
+	}
+	return int64(plan.PlanRows), nil
+}
+
+// getQueryResult returns the column names and rows of the result of db.Driver.Query.
+func getQueryResult(res []interface{}) ([]string, [][]interface{}, error) {
+	if len(res) != 3 {
+		return nil, nil, fmt.Errorf("invalid query result length %d", len(res))
+	}
+	columnNames, ok := res[0].([]string)
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid column names %v in query result", res[0])
+	}
+	data, ok := res[2].([]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid data %v in query result", res[2])
+	}
+	var rows [][]interface{}
+	for _, d := range data {
+		row, ok := d.([]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid row %v in query result", d)
+		}
+		rows = append(rows, row)
+	}
+	return columnNames, rows, nil
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/youzi-1122/bytebase/plugin/db"
+
+	// Register the parsers for the statements.
+	_ "github.com/pingcap/tidb/types/parser_driver"
+	_ "github.com/youzi-1122/bytebase/plugin/parser/engine/pg"
+)
+
+func TestGetDataChangeStatementList(t *testing.T) {
+	tests := []struct {
+		dbType    db.Type
+		statement string
+		want      []string
+	}{
+		{
+			dbType:    db.MySQL,
+			statement: "INSERT INTO t VALUES (1); UPDATE t SET a = 1 WHERE id > 10;\nDELETE FROM t;",
+			want:      []string{"UPDATE t SET a = 1 WHERE id > 10;", "DELETE FROM t;"},
+		},
+		{
+			dbType:    db.TiDB,
+			statement: "CREATE TABLE t(a INT); SELECT * FROM t",
+			want:      nil,
+		},
+		{
+			dbType:    db.Postgres,
+			statement: "INSERT INTO t VALUES (1);\nUPDATE t SET a = 1 WHERE id > 10;\nDELETE FROM t;",
+			want:      []string{"UPDATE t SET a = 1 WHERE id > 10;", "DELETE FROM t;"},
+		},
+	}
+
+	for _, test := range tests {
+		statementList, err := getDataChangeStatementList(test.dbType, test.statement, "", "")
+		require.NoError(t, err)
+		require.Equal(t, test.want, statementList)
+	}
+
+	_, err := getDataChangeStatementList(db.MySQL, "UPDATE t SET", "", "")
+	require.Error(t, err)
+	_, err = getDataChangeStatementList(db.ClickHouse, "DELETE FROM t", "", "")
+	require.Error(t, err)
+}
+
+func TestGetMaxExplainRows(t *testing.T) {
+	// MySQL EXPLAIN result of a multi-table DELETE statement.
+	mysqlResult := []interface{}{
+		[]string{"id", "select_type", "table", "type", "rows", "filtered"},
+		[]string{"INT", "VARCHAR", "VARCHAR", "VARCHAR", "BIGINT", "DOUBLE"},
+		[]interface{}{
+			[]interface{}{"1", "DELETE", "t1", "ALL", "50000000", "100.00"},
+			[]interface{}{"1", "SIMPLE", "t2", "ref", "3", "100.00"},
+		},
+	}
+	rows, err := getMaxExplainRows(mysqlResult, "rows")
+	require.NoError(t, err)
+	require.Equal(t, int64(50000000), rows)
+
+	// TiDB EXPLAIN result of an UPDATE statement.
+	tidbResult := []interface{}{
+		[]string{"id", "estRows", "task", "access object", "operator info"},
+		[]string{"VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR", "VARCHAR"},
+		[]interface{}{
+			[]interface{}{"Update_4", "N/A", "root", "", "N/A"},
+			[]interface{}{"└─TableReader_8", "3323.33", "root", "", "data:Selection_7"},
+			[]interface{}{"  └─TableFullScan_6", "10000.00", "cop[tikv]", "table:t", "keep order:false"},
+		},
+	}
+	rows, err = getMaxExplainRows(tidbResult, "estRows")
+	require.NoError(t, err)
+	require.Equal(t, int64(10000), rows)
+
+	_, err = getMaxExplainRows(tidbResult, "rows")
+	require.Error(t, err)
+}
+
+func TestGetOceanBaseExplainRows(t *testing.T) {
+	// OceanBase 3.x returns the plan in a single row.
+	plan := strings.Join([]string{
+		"=========================================",
+		"|ID|OPERATOR        |NAME|EST. ROWS|COST|",
+		"-----------------------------------------",
+		"|0 |DELETE          |    |1000     |4201|",
+		"|1 | TABLE SCAN     |t   |100000   |3911|",
+		"=========================================",
+		"",
+		"Outputs & filters: ",
+		"-------------------------------------",
+		"  0 - output(nil), filter(nil), table_columns([{t: ({t: (t.id, t.a)})}])",
+	}, "\n")
+	result := []interface{}{
+		[]string{"Query Plan"},
+		[]string{"VARCHAR"},
+		[]interface{}{
+			[]interface{}{plan},
+		},
+	}
+	rows, err := getOceanBaseExplainRows(result)
+	require.NoError(t, err)
+	require.Equal(t, int64(100000), rows)
+
+	// OceanBase 4.x returns the plan in one row per line.
+	result = []interface{}{
+		[]string{"Query Plan"},
+		[]string{"VARCHAR"},
+		[]interface{}{
+			[]interface{}{"==================================================="},
+			[]interface{}{"|ID|OPERATOR          |NAME|EST.ROWS|EST.TIME(us)|"},
+			[]interface{}{"---------------------------------------------------"},
+			[]interface{}{"|0 |UPDATE            |    |3       |59          |"},
+			[]interface{}{"|1 |└─TABLE FULL SCAN|t   |3       |3           |"},
+			[]interface{}{"==================================================="},
+		},
+	}
+	rows, err = getOceanBaseExplainRows(result)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), rows)
+
+	_, err = getOceanBaseExplainRows([]interface{}{[]string{"Query Plan"}, []string{"VARCHAR"}, []interface{}{}})
+	require.Error(t, err)
+}
+
+func TestGetPostgresExplainRows(t *testing.T) {
+	result := []interface{}{
+		[]string{"QUERY PLAN"},
+		[]string{"JSON"},
+		[]interface{}{
+			[]interface{}{`[{"Plan": {"Node Type": "ModifyTable", "Operation": "Delete", "Plan Rows": 0, "Plans": [{"Node Type": "Seq Scan", "Plan Rows": 2550}]}}]`},
+		},
+	}
+	rows, err := getPostgresExplainRows(result)
+	require.NoError(t, err)
+	require.Equal(t, int64(2550), rows)
+
+	result = []interface{}{
+		[]string{"QUERY PLAN"},
+		[]string{"JSON"},
+		[]interface{}{
+			[]interface{}{`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 42}}]`},
+		},
+	}
+	rows, err = getPostgresExplainRows(result)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), rows)
+}
@@ -333,10 +333,33 @@ func (s *TaskCheckScheduler) ScheduleCheckIfNeeded(ctx context.Context, task *ap
 			}
 		}
 
+		// The data update doesn't change the schema, so we only check the compatibility for the schema update.
+		if task.Type != api.TaskDatabaseDataUpdate && api.IsCompatibilityCheckSupported(database.Instance.Engine, s.server.profile.Mode) {
+			payload, err := json.Marshal(api.TaskCheckDatabaseStatementAdvisePayload{
+				Statement: statement,
+				DbType:    database.Instance.Engine,
+				Charset:   database.CharacterSet,
+				Collation: database.Collation,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal statement advise payload: %v, err: %w", task.Name, err)
+			}
+			_, err = s.server.store.CreateTaskCheckRunIfNeeded(ctx, &api.TaskCheckRunCreate{
+				CreatorID:               creatorID,
+				TaskID:                  task.ID,
+				Type:                    api.TaskCheckDatabaseStatementCompatibility,
+				Payload:                 string(payload),
+				SkipIfAlreadyTerminated: skipIfAlreadyTerminated,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		if s.server.feature(api.FeatureSchemaReviewPolicy) && api.IsSchemaReviewSupported(database.Instance.Engine, s.server.profile.Mode) {
-			policyID, err := s.server.store.GetSchemaReviewPolicyIDByEnvID(ctx, task.Instance.EnvironmentID)
+			policyID, err := s.server.store.GetSchemaReviewPolicyIDByDatabase(ctx, database)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get schema review policy ID for task: %v, in environment: %v, err: %w", task.Name, task.Instance.EnvironmentID, err)
+				return nil, fmt.Errorf("failed to get schema review policy ID for task: %v, in database: %v, err: %w", task.Name, database.ID, err)
 			}
 			payload, err := json.Marshal(api.TaskCheckDatabaseStatementAdvisePayload{
 				Statement: statement,
@@ -357,6 +380,18 @@ func (s *TaskCheckScheduler) ScheduleCheckIfNeeded(ctx context.Context, task *ap
 			}); err != nil {
 				return nil, err
 			}
+			// Only the data update estimates the affected rows, and the schema update may change the tables to EXPLAIN.
+			if task.Type == api.TaskDatabaseDataUpdate && api.IsAffectedRowsCheckSupported(database.Instance.Engine) {
+				if _, err := s.server.store.CreateTaskCheckRunIfNeeded(ctx, &api.TaskCheckRunCreate{
+					CreatorID:               creatorID,
+					TaskID:                  task.ID,
+					Type:                    api.TaskCheckDatabaseStatementAffectedRows,
+					Payload:                 string(payload),
+					SkipIfAlreadyTerminated: skipIfAlreadyTerminated,
+				}); err != nil {
+					return nil, err
+				}
+			}
 		}
 
 		taskCheckRunFind := &api.TaskCheckRunFind{
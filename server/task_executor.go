@@ -81,10 +81,21 @@ func preMigration(ctx context.Context, server *Server, task *api.Task, migration
 		if err != nil {
 			return nil, err
 		}
-		mi, err = db.ParseMigrationInfo(
-			vcsPushEvent.FileCommit.Added,
-			filepath.Join(vcsPushEvent.BaseDirectory, repo.FilePathTemplate),
-		)
+		if repo.Project.SchemaChangeType == api.ProjectSchemaChangeTypeSDL {
+			// The committed file is the desired schema file, which doesn't contain the version.
+			mi, err = db.ParseSchemaFileInfo(
+				vcsPushEvent.FileCommit.Added,
+				filepath.Join(vcsPushEvent.BaseDirectory, repo.SchemaPathTemplate),
+			)
+			if err == nil {
+				mi.Version = schemaVersion
+			}
+		} else {
+			mi, err = db.ParseMigrationInfo(
+				vcsPushEvent.FileCommit.Added,
+				filepath.Join(vcsPushEvent.BaseDirectory, repo.FilePathTemplate),
+			)
+		}
 		// This should not happen normally as we already check this when creating the issue. Just in case.
 		if err != nil {
 			return nil, fmt.Errorf("failed to prepare for database migration, error: %w", err)
@@ -179,6 +190,10 @@ func postMigration(ctx context.Context, server *Server, task *api.Task, vcsPushE
 	if err != nil {
 		return true, nil, err
 	}
+	// For the SDL schema change type, the schema file is maintained by the user as the desired schema, so we don't write it back.
+	if project.SchemaChangeType == api.ProjectSchemaChangeTypeSDL {
+		writeBack = false
+	}
 	if writeBack && issue != nil {
 		if project.TenantMode == api.TenantModeTenant {
 			var lastTask *api.Task
@@ -4,11 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common/cron"
 	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/pg"
 	"go.uber.org/zap"
 )
 
@@ -19,6 +25,9 @@ func NewDatabaseBackupTaskExecutor() TaskExecutor {
 
 // DatabaseBackupTaskExecutor is the task executor for database backup.
 type DatabaseBackupTaskExecutor struct {
+	// pgBaseBackupLockMap is the map from the instance ID to the lock, which serializes the backups of the databases on
+	// the Postgres instance sharing the base backup.
+	pgBaseBackupLockMap sync.Map
 }
 
 // RunOnce will run database backup once.
@@ -41,7 +50,7 @@ func (exec *DatabaseBackupTaskExecutor) RunOnce(ctx context.Context, server *Ser
 		zap.String("backup", backup.Name),
 	)
 
-	backupPayload, backupErr := exec.backupDatabase(ctx, task.Instance, task.Database.Name, backup, server.profile.DataDir, server.pgInstanceDir)
+	backupPayload, backupErr := exec.backupDatabase(ctx, server, task.Instance, task.Database.Name, backup)
 	backupPatch := api.BackupPatch{
 		ID:        backup.ID,
 		Status:    string(api.BackupStatusDone),
@@ -66,26 +75,97 @@ func (exec *DatabaseBackupTaskExecutor) RunOnce(ctx context.Context, server *Ser
 	}, nil
 }
 
-// backupDatabase will take a backup of a database.
-func (exec *DatabaseBackupTaskExecutor) backupDatabase(ctx context.Context, instance *api.Instance, databaseName string, backup *api.Backup, dataDir, pgInstanceDir string) (string, error) {
-	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, pgInstanceDir)
+// backupDatabase will take a backup of a database, and stream the dump to the storage backend of the backup.
+func (exec *DatabaseBackupTaskExecutor) backupDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup) (string, error) {
+	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, server.pgInstanceDir)
 	if err != nil {
 		return "", err
 	}
 	defer driver.Close(ctx)
 
-	f, err := os.Create(filepath.Join(dataDir, backup.Path))
+	var dumpPayload string
+	backupPayload := api.BackupPayload{}
+	if err := server.writeBackupFile(ctx, backup, instance.EnvironmentID, &backupPayload, func(w io.Writer) error {
+		dumpPayload, err = driver.Dump(ctx, databaseName, w, false /* schemaOnly */)
+		return err
+	}); err != nil {
+		return "", err
+	}
+	// The dump payload records the binlog info of the backup, which is merged with the encoding and the checksum of the backup file.
+	if dumpPayload != "" {
+		if err := json.Unmarshal([]byte(dumpPayload), &backupPayload); err != nil {
+			return "", fmt.Errorf("failed to unmarshal dump payload, error: %w", err)
+		}
+	}
+
+	// The base backup is the starting point to replay the archived WAL for the Postgres PITR.
+	if instance.Engine == db.Postgres {
+		backupSetting, err := server.store.GetBackupSettingByDatabaseID(ctx, backup.DatabaseID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get backup setting for database %d, error: %w", backup.DatabaseID, err)
+		}
+		pitrEnabled, err := isPITREnabled(backupSetting)
+		if err != nil {
+			return "", err
+		}
+		if pitrEnabled {
+			pgDriver, ok := driver.(*pg.Driver)
+			if !ok {
+				return "", fmt.Errorf("[internal] cast driver to pg.Driver failed")
+			}
+			baseBackup, err := exec.getPgBaseBackup(ctx, server, instance, pgDriver, backupSetting)
+			if err != nil {
+				return "", fmt.Errorf("failed to take base backup, error: %w", err)
+			}
+			backupPayload.PgBaseBackupID = baseBackup.ID
+			backupPayload.PgBaseBackup = &baseBackup.Payload
+		}
+	}
+
+	payload, err := json.Marshal(backupPayload)
 	if err != nil {
-		return "", fmt.Errorf("failed to open backup path: %s", backup.Path)
+		return "", fmt.Errorf("failed to marshal backup payload, error: %w", err)
 	}
-	defer f.Close()
 
-	payload, err := driver.Dump(ctx, databaseName, f, false /* schemaOnly */)
+	return string(payload), nil
+}
+
+// getPgBaseBackup returns the base backup of the Postgres instance for the backup. The base backup covers all the
+// databases on the instance, so the latest one is reused if the backup schedule of the database hasn't activated since
+// it's taken, and the backups of the same schedule share one base backup. Otherwise, a new base backup is taken.
+func (exec *DatabaseBackupTaskExecutor) getPgBaseBackup(ctx context.Context, server *Server, instance *api.Instance, pgDriver *pg.Driver, backupSetting *api.BackupSetting) (*api.PgInstanceBaseBackup, error) {
+	lock, _ := exec.pgBaseBackupLockMap.LoadOrStore(instance.ID, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	baseBackupList, err := server.store.FindPgInstanceBaseBackup(ctx, &api.PgInstanceBaseBackupFind{InstanceID: &instance.ID})
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to find base backups of instance %q, error: %w", instance.Name, err)
+	}
+	if len(baseBackupList) > 0 && backupSetting.Enabled {
+		latest := baseBackupList[0]
+		schedule, err := api.ParseBackupSchedule(backupSetting.GetCronExpression())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse backup schedule %q, error: %w", backupSetting.GetCronExpression(), err)
+		}
+		if cron.Last(schedule, time.Unix(latest.CreatedTs, 0).UTC(), time.Now().UTC()).IsZero() {
+			log.Debug("Reuse the base backup of the instance", zap.String("instance", instance.Name), zap.Int("baseBackup", latest.ID))
+			return latest, nil
+		}
 	}
 
-	return payload, nil
+	name := fmt.Sprintf("%s-basebackup", time.Now().UTC().Format("20060102T150405"))
+	baseBackup, err := server.writePgBaseBackupFile(ctx, instance, name, func(w io.Writer) (*api.PgBaseBackup, error) {
+		return pgDriver.BaseBackup(ctx, w)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return server.store.CreatePgInstanceBaseBackup(ctx, &api.PgInstanceBaseBackupCreate{
+		CreatorID:  api.SystemBotID,
+		InstanceID: instance.ID,
+		Payload:    *baseBackup,
+	})
 }
 
 // Get backup dir relative to the data dir.
@@ -98,11 +178,6 @@ func getBackupRelativeFilePath(databaseID int, name string) string {
 	return filepath.Join(dir, fmt.Sprintf("%s.sql", name))
 }
 
-func getBackupAbsFilePath(dataDir string, databaseID int, name string) string {
-	path := getBackupRelativeFilePath(databaseID, name)
-	return filepath.Join(dataDir, path)
-}
-
 // Create backup directory for database.
 func createBackupDirectory(dataDir string, databaseID int) error {
 	dir := getBackupRelativeDir(databaseID)
@@ -119,8 +194,37 @@ func getBinlogAbsDir(dataDir string, instanceID int) string {
 	return filepath.Join(dataDir, dir)
 }
 
+// getWALArchiveAbsDir returns the directory of the archived Postgres WAL of the instance.
+func getWALArchiveAbsDir(dataDir string, instanceID int) string {
+	return filepath.Join(getBinlogAbsDir(dataDir, instanceID), "wal")
+}
+
+// getWALFileRelativeDir returns the directory of the archived Postgres WAL files of the instance uploaded to the
+// LOCAL storage backend, relative to the data dir.
+func getWALFileRelativeDir(instanceID int) string {
+	return filepath.Join(getBinlogRelativeDir(instanceID), "wal-archive")
+}
+
+// getPgBaseBackupRelativeDir returns the directory of the Postgres base backups of the instance stored in the LOCAL
+// storage backend, relative to the data dir.
+func getPgBaseBackupRelativeDir(instanceID int) string {
+	return filepath.Join(getBinlogRelativeDir(instanceID), "basebackup")
+}
+
 func createBinlogDir(dataDir string, instanceID int) error {
 	dir := getBinlogRelativeDir(instanceID)
 	absDir := filepath.Join(dataDir, dir)
 	return os.MkdirAll(absDir, os.ModePerm)
 }
+
+// isPITREnabled returns whether the PITR policy of the backup setting is enabled.
+func isPITREnabled(backupSetting *api.BackupSetting) (bool, error) {
+	if backupSetting == nil {
+		return false, nil
+	}
+	policy, err := api.UnmarshalBackupPITRPolicy(backupSetting.PITRPolicy)
+	if err != nil {
+		return false, err
+	}
+	return policy.Enabled, nil
+}
@@ -5,8 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strconv"
 
 	"github.com/youzi-1122/bytebase/api"
@@ -70,7 +68,7 @@ func (exec *DatabaseRestoreTaskExecutor) RunOnce(ctx context.Context, server *Se
 	)
 
 	// Restore the database to the target database.
-	if err := exec.restoreDatabase(ctx, targetDatabase.Instance, targetDatabase.Name, backup, server.profile.DataDir, server.pgInstanceDir); err != nil {
+	if err := exec.restoreDatabase(ctx, server, targetDatabase.Instance, targetDatabase.Name, backup, sourceDatabase.Instance.EnvironmentID); err != nil {
 		return true, nil, err
 	}
 
@@ -109,22 +107,18 @@ func (exec *DatabaseRestoreTaskExecutor) RunOnce(ctx context.Context, server *Se
 	}, nil
 }
 
-// restoreDatabase will restore the database from a backup
-func (exec *DatabaseRestoreTaskExecutor) restoreDatabase(ctx context.Context, instance *api.Instance, databaseName string, backup *api.Backup, dataDir, pgInstanceDir string) error {
-	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, pgInstanceDir)
+// restoreDatabase will restore the database from a backup.
+// The backupEnvironmentID is the environment of the backup database, whose backup storage policy locates the backup.
+func (exec *DatabaseRestoreTaskExecutor) restoreDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup, backupEnvironmentID int) error {
+	driver, err := getAdminDatabaseDriver(ctx, instance, databaseName, server.pgInstanceDir)
 	if err != nil {
 		return err
 	}
 	defer driver.Close(ctx)
 
-	backupPath := backup.Path
-	if !filepath.IsAbs(backupPath) {
-		backupPath = filepath.Join(dataDir, backupPath)
-	}
-
-	f, err := os.OpenFile(backupPath, os.O_RDONLY, os.ModePerm)
+	f, err := server.openBackupFile(ctx, backup, backupEnvironmentID)
 	if err != nil {
-		return fmt.Errorf("failed to open backup file at %s: %w", backupPath, err)
+		return err
 	}
 	defer f.Close()
 	sc := bufio.NewScanner(f)
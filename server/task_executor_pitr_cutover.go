@@ -11,6 +11,7 @@ import (
 	"github.com/youzi-1122/bytebase/common/log"
 	"github.com/youzi-1122/bytebase/plugin/db"
 	"github.com/youzi-1122/bytebase/plugin/db/mysql"
+	"github.com/youzi-1122/bytebase/plugin/db/pg"
 	"github.com/youzi-1122/bytebase/resources/mysqlutil"
 	"go.uber.org/zap"
 )
@@ -78,13 +79,20 @@ func (exec *PITRCutoverTaskExecutor) RunOnce(ctx context.Context, server *Server
 // 2. Create a backup with type PITR. The backup is scheduled asynchronously.
 // We must check the possible failed/ongoing PITR type backup in the recovery process.
 func (exec *PITRCutoverTaskExecutor) pitrCutover(ctx context.Context, task *api.Task, server *Server, issue *api.Issue) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	driver, err := getAdminDatabaseDriver(ctx, task.Instance, "", "" /* pgInstanceDir */)
+	driver, err := getAdminDatabaseDriver(ctx, task.Instance, "", server.pgInstanceDir)
 	if err != nil {
 		return true, nil, err
 	}
 	defer driver.Close(ctx)
 
-	driverDB, err := driver.GetDbConnection(ctx, "")
+	// Postgres can't rename the database being connected to, so connect to the maintenance database instead.
+	connDatabase := ""
+	swapPITRDatabase := mysql.SwapPITRDatabase
+	if task.Instance.Engine == db.Postgres {
+		connDatabase = "postgres"
+		swapPITRDatabase = pg.SwapPITRDatabase
+	}
+	driverDB, err := driver.GetDbConnection(ctx, connDatabase)
 	if err != nil {
 		return true, nil, err
 	}
@@ -95,7 +103,7 @@ func (exec *PITRCutoverTaskExecutor) pitrCutover(ctx context.Context, task *api.
 	defer conn.Close()
 
 	log.Debug("Swapping the original and PITR database", zap.String("originalDatabase", task.Database.Name))
-	pitrDatabaseName, pitrOldDatabaseName, err := mysql.SwapPITRDatabase(ctx, conn, task.Database.Name, issue.CreatedTs)
+	pitrDatabaseName, pitrOldDatabaseName, err := swapPITRDatabase(ctx, conn, task.Database.Name, issue.CreatedTs)
 	if err != nil {
 		log.Error("Failed to swap the original and PITR database", zap.String("originalDatabase", task.Database.Name), zap.String("pitrDatabase", pitrDatabaseName), zap.Error(err))
 		return true, nil, fmt.Errorf("failed to swap the original and PITR database, error: %w", err)
@@ -104,7 +112,7 @@ func (exec *PITRCutoverTaskExecutor) pitrCutover(ctx context.Context, task *api.
 
 	if server.profile.Mode == common.ReleaseModeDev {
 		backupName := fmt.Sprintf("%s-%s-pitr-%d", api.ProjectShortSlug(task.Database.Project), api.EnvSlug(task.Database.Instance.Environment), issue.CreatedTs)
-		if _, err := server.scheduleBackupTask(ctx, task.Database, backupName, api.BackupTypePITR, api.BackupStorageBackendLocal, api.SystemBotID); err != nil {
+		if _, err := server.scheduleBackupTask(ctx, task.Database, backupName, api.BackupTypePITR, api.SystemBotID); err != nil {
 			return true, nil, fmt.Errorf("failed to schedule backup task for database %q after PITR, error: %w", task.Database.Name, err)
 		}
 	}
@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	"github.com/youzi-1122/bytebase/api"
@@ -13,11 +16,19 @@ import (
 	"github.com/youzi-1122/bytebase/common/log"
 	"github.com/youzi-1122/bytebase/plugin/db"
 	"github.com/youzi-1122/bytebase/plugin/db/mysql"
+	"github.com/youzi-1122/bytebase/plugin/db/pg"
 	"github.com/youzi-1122/bytebase/resources/mysqlutil"
+	"github.com/youzi-1122/bytebase/resources/postgres"
+	"github.com/youzi-1122/bytebase/resources/utils"
 	"github.com/youzi-1122/bytebase/store"
 	"go.uber.org/zap"
 )
 
+const (
+	// pgRecoveryTimeout is the max time to replay the archived WAL when recovering a Postgres cluster for PITR.
+	pgRecoveryTimeout = time.Duration(1) * time.Hour
+)
+
 // NewPITRRestoreTaskExecutor creates a PITR restore task executor.
 func NewPITRRestoreTaskExecutor(instance mysqlutil.Instance) TaskExecutor {
 	return &PITRRestoreTaskExecutor{
@@ -39,13 +50,17 @@ func (exec *PITRRestoreTaskExecutor) RunOnce(ctx context.Context, server *Server
 		return true, nil, fmt.Errorf("invalid PITR restore payload: %s, error: %w", task.Payload, err)
 	}
 
-	driver, err := getAdminDatabaseDriver(ctx, task.Instance, "", "" /* pgInstanceDir */)
+	driver, err := getAdminDatabaseDriver(ctx, task.Instance, "", server.pgInstanceDir)
 	if err != nil {
 		return true, nil, err
 	}
 	defer driver.Close(ctx)
 
-	if err := exec.doPITRRestore(ctx, task, server.store, driver, server.profile.DataDir, payload.PointInTimeTs, server.profile.Mode); err != nil {
+	doPITRRestore := exec.doPITRRestore
+	if task.Instance.Engine == db.Postgres {
+		doPITRRestore = exec.doPgPITRRestore
+	}
+	if err := doPITRRestore(ctx, task, server, driver, payload.PointInTimeTs); err != nil {
 		log.Error("Failed to do PITR restore", zap.Error(err))
 		return true, nil, err
 	}
@@ -57,9 +72,12 @@ func (exec *PITRRestoreTaskExecutor) RunOnce(ctx context.Context, server *Server
 	}, nil
 }
 
-func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, task *api.Task, store *store.Store, driver db.Driver, dataDir string, targetTs int64, mode common.ReleaseMode) error {
+func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, task *api.Task, server *Server, driver db.Driver, targetTs int64) error {
 	instance := task.Instance
 	database := task.Database
+	store := server.store
+	dataDir := server.profile.DataDir
+	mode := server.profile.Mode
 
 	issue, err := getIssueByPipelineID(ctx, store, task.PipelineID)
 	if err != nil {
@@ -102,13 +120,12 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, task *ap
 		return fmt.Errorf("failed to get latest backup before or equal to %s, error: %w", dateTime, err)
 	}
 	log.Debug("Got latest backup before or equal to targetTs", zap.String("backup", backup.Name))
-	backupFileName := getBackupAbsFilePath(dataDir, backup.DatabaseID, backup.Name)
-	backupFile, err := os.OpenFile(backupFileName, os.O_RDONLY, os.ModePerm)
+	backupFile, err := server.openBackupFile(ctx, backup, instance.EnvironmentID)
 	if err != nil {
-		return fmt.Errorf("failed to open backup file: %s, error: %w", backupFileName, err)
+		return fmt.Errorf("failed to open backup file: %s, error: %w", backup.Path, err)
 	}
 	defer backupFile.Close()
-	log.Debug("Successfully opened backup file", zap.String("filename", backupFileName))
+	log.Debug("Successfully opened backup file", zap.String("filename", backup.Path))
 
 	log.Debug("Start creating and restoring PITR database",
 		zap.String("instance", instance.Name),
@@ -129,6 +146,155 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, task *ap
 	return nil
 }
 
+// doPgPITRRestore recovers a temporary Postgres cluster from the latest base backup before the target time by
+// replaying the archived WAL, and copies the database from the recovered cluster to the PITR database.
+func (exec *PITRRestoreTaskExecutor) doPgPITRRestore(ctx context.Context, task *api.Task, server *Server, driver db.Driver, targetTs int64) error {
+	instance := task.Instance
+	database := task.Database
+	dataDir := server.profile.DataDir
+
+	issue, err := getIssueByPipelineID(ctx, server.store, task.PipelineID)
+	if err != nil {
+		return err
+	}
+
+	backupStatus := api.BackupStatusDone
+	backupList, err := server.store.FindBackup(ctx, &api.BackupFind{DatabaseID: task.DatabaseID, Status: &backupStatus})
+	if err != nil {
+		return err
+	}
+	backup, err := getLatestPgBaseBackupBeforeOrEqualTs(backupList, targetTs)
+	if err != nil {
+		return err
+	}
+	log.Debug("Got latest base backup before or equal to targetTs", zap.String("backup", backup.Name))
+
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		log.Error("Failed to cast driver to pg.Driver")
+		return fmt.Errorf("[internal] cast driver to pg.Driver failed")
+	}
+
+	// The temporary cluster is recovered in the data directory, and removed after copying the database.
+	recoveryDir := filepath.Join(dataDir, "pitr", fmt.Sprintf("%d_%d", database.ID, issue.CreatedTs))
+	if err := os.RemoveAll(recoveryDir); err != nil {
+		return fmt.Errorf("failed to clean up recovery directory %q, error: %w", recoveryDir, err)
+	}
+	defer os.RemoveAll(recoveryDir)
+	walDir := filepath.Join(recoveryDir, "wal")
+	if err := server.WALArchiver.prepareRecoveryWAL(ctx, instance, pgDriver, backup.Payload.PgBaseBackup.StartWALFile, walDir); err != nil {
+		return err
+	}
+	pgDataDir := filepath.Join(recoveryDir, "pgdata")
+	if err := exec.extractPgBaseBackup(ctx, server, backup, instance.EnvironmentID, pgDataDir); err != nil {
+		return err
+	}
+	if err := pg.PrepareRecovery(server.pgInstanceDir, pgDataDir, walDir, backup.Payload.PgBaseBackup, targetTs); err != nil {
+		return err
+	}
+	if err := postgres.PrepareDataDir(pgDataDir); err != nil {
+		return err
+	}
+
+	port, err := getFreePort()
+	if err != nil {
+		return err
+	}
+	log.Debug("Starting the recovered Postgres cluster", zap.String("dataDir", pgDataDir), zap.Int("port", port))
+	pgInstance := postgres.NewInstance(server.pgInstanceDir, pgDataDir)
+	if err := pgInstance.StartWithTimeout(port, os.Stderr, os.Stderr, pgRecoveryTimeout); err != nil {
+		return fmt.Errorf("failed to recover Postgres cluster from backup %q, error: %w", backup.Name, err)
+	}
+	defer func() {
+		if err := pgInstance.Stop(os.Stderr, os.Stderr); err != nil {
+			log.Error("Failed to stop the recovered Postgres cluster", zap.String("dataDir", pgDataDir), zap.Error(err))
+		}
+	}()
+
+	adminDataSource := api.DataSourceFromInstanceWithType(instance, api.Admin)
+	if adminDataSource == nil {
+		return fmt.Errorf("admin data source not found for instance %d", instance.ID)
+	}
+	// The recovered cluster only accepts local connections through the Unix-domain socket with the trust authentication.
+	recoveredDriver, err := db.Open(
+		ctx,
+		db.Postgres,
+		db.DriverConfig{PgInstanceDir: server.pgInstanceDir},
+		db.ConnectionConfig{
+			Host:     common.GetPostgresSocketDir(),
+			Port:     strconv.Itoa(port),
+			Username: adminDataSource.Username,
+			Database: database.Name,
+		},
+		db.ConnectionContext{
+			EnvironmentName: instance.Environment.Name,
+			InstanceName:    instance.Name,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to connect the recovered Postgres cluster, error: %w", err)
+	}
+	defer recoveredDriver.Close(ctx)
+
+	log.Debug("Start creating and restoring PITR database",
+		zap.String("instance", instance.Name),
+		zap.String("database", database.Name),
+	)
+	if err := pgDriver.RestorePITR(ctx, recoveredDriver, database.Name, issue.CreatedTs); err != nil {
+		log.Error("failed to perform a PITR restore in the PITR database",
+			zap.Int("issueID", issue.ID),
+			zap.String("database", database.Name),
+			zap.Error(err))
+		return fmt.Errorf("failed to perform a PITR restore in the PITR database, error: %w", err)
+	}
+	return nil
+}
+
+// extractPgBaseBackup extracts the base backup of the backup into the data directory.
+func (*PITRRestoreTaskExecutor) extractPgBaseBackup(ctx context.Context, server *Server, backup *api.Backup, environmentID int, pgDataDir string) error {
+	f, err := server.openPgBaseBackupFile(ctx, backup, environmentID)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := os.MkdirAll(pgDataDir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory %q, error: %w", pgDataDir, err)
+	}
+	if err := utils.ExtractTar(f, pgDataDir); err != nil {
+		return fmt.Errorf("failed to extract base backup %q, error: %w", backup.Name, err)
+	}
+	return nil
+}
+
+// getLatestPgBaseBackupBeforeOrEqualTs returns the backup with the latest base backup finished before or at the
+// target time, which is the starting point to replay the archived WAL.
+func getLatestPgBaseBackupBeforeOrEqualTs(backupList []*api.Backup, targetTs int64) (*api.Backup, error) {
+	var latest *api.Backup
+	for _, backup := range backupList {
+		baseBackup := backup.Payload.PgBaseBackup
+		if baseBackup == nil || baseBackup.EndTs > targetTs {
+			continue
+		}
+		if latest == nil || baseBackup.EndTs > latest.Payload.PgBaseBackup.EndTs {
+			latest = backup
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no base backup taken before or equal to %s, please enable the PITR policy and take a backup first", time.Unix(targetTs, 0).Format(time.RFC822))
+	}
+	return latest, nil
+}
+
+// getFreePort returns a local TCP port unused at the moment.
+func getFreePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port, error: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
 func getIssueByPipelineID(ctx context.Context, store *store.Store, pid int) (*api.Issue, error) {
 	issue, err := store.GetIssueByPipelineID(ctx, pid)
 	if err != nil {
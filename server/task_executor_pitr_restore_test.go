@@ -0,0 +1,61 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLatestPgBaseBackupBeforeOrEqualTs(t *testing.T) {
+	newBackup := func(id int, baseBackup *api.PgBaseBackup) *api.Backup {
+		return &api.Backup{
+			ID:      id,
+			Payload: api.BackupPayload{PgBaseBackup: baseBackup},
+		}
+	}
+	backupList := []*api.Backup{
+		newBackup(1, &api.PgBaseBackup{EndTs: 100}),
+		newBackup(2, nil),
+		newBackup(3, &api.PgBaseBackup{EndTs: 300}),
+		newBackup(4, &api.PgBaseBackup{EndTs: 200}),
+	}
+
+	tests := []struct {
+		name     string
+		targetTs int64
+		want     int
+		wantErr  bool
+	}{
+		{
+			name:     "before the first base backup",
+			targetTs: 99,
+			wantErr:  true,
+		},
+		{
+			name:     "equal to the end of a base backup",
+			targetTs: 200,
+			want:     4,
+		},
+		{
+			name:     "between base backups",
+			targetTs: 250,
+			want:     4,
+		},
+		{
+			name:     "after the latest base backup",
+			targetTs: 1000,
+			want:     3,
+		},
+	}
+
+	for _, test := range tests {
+		backup, err := getLatestPgBaseBackupBeforeOrEqualTs(backupList, test.targetTs)
+		if test.wantErr {
+			assert.Error(t, err, test.name)
+			continue
+		}
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.want, backup.ID, test.name)
+	}
+}
@@ -295,6 +295,16 @@ func (s *TaskScheduler) ScheduleIfNeeded(ctx context.Context, task *api.Task) (*
 			if !pass {
 				return task, nil
 			}
+
+			if task.Type == api.TaskDatabaseDataUpdate && api.IsAffectedRowsCheckSupported(instance.Engine) {
+				pass, err = s.server.passCheck(ctx, s.server, task, api.TaskCheckDatabaseStatementAffectedRows)
+				if err != nil {
+					return nil, err
+				}
+				if !pass {
+					return task, nil
+				}
+			}
 		}
 	}
 	updatedTask, err := s.server.changeTaskStatus(ctx, task, api.TaskRunning, api.SystemBotID)
@@ -0,0 +1,528 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/pg"
+	"go.uber.org/zap"
+)
+
+const (
+	// The replication slot retains the WAL on the instance between the rounds, so the interval bounds the WAL
+	// accumulated on the instance rather than the recovery point, which is archived right before each PITR restore.
+	walArchiverInterval = time.Duration(1) * time.Minute
+	// maxWALArchiveFailureCount is the number of the consecutive failed archiving rounds after which the replication
+	// slot is dropped, so a broken archiving doesn't retain the WAL on the instance until the disk is full.
+	maxWALArchiveFailureCount = 60
+)
+
+// NewWALArchiver creates a new WAL archiver.
+func NewWALArchiver(server *Server) *WALArchiver {
+	return &WALArchiver{
+		server: server,
+	}
+}
+
+// WALArchiver is the runner archiving the WAL of the Postgres instances having databases with the PITR policy enabled.
+// The WAL is streamed to the local WAL archive directory of the instance first, and the complete WAL files are uploaded
+// to the backup storage backend of the environment.
+type WALArchiver struct {
+	server *Server
+	// mu serializes the archiving rounds and the archiving for the PITR restores, which share the WAL archive directory.
+	mu sync.Mutex
+}
+
+// Run is the runner for WAL archiver.
+func (s *WALArchiver) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(walArchiverInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug(fmt.Sprintf("WAL archiver started and will run every %v", walArchiverInterval))
+	for {
+		select {
+		case <-ticker.C:
+			log.Debug("New WAL archiving round started...")
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = fmt.Errorf("%v", r)
+						}
+						log.Error("WAL archiver PANIC RECOVER", zap.Error(err))
+					}
+				}()
+				s.archiveWAL(ctx)
+			}()
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+// archiveWAL archives the WAL of the instances with the PITR enabled databases, and stops archiving for the instances
+// no longer having any.
+func (s *WALArchiver) archiveWAL(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	backupSettingList, err := s.server.store.FindBackupSetting(ctx, &api.BackupSettingFind{})
+	if err != nil {
+		log.Error("Failed to retrieve backup settings", zap.Error(err))
+		return
+	}
+
+	instanceMap := make(map[int]*api.Instance)
+	databaseListMap := make(map[int][]*api.Database)
+	for _, backupSetting := range backupSettingList {
+		database := backupSetting.Database
+		if database == nil || database.Name == api.AllDatabaseName || database.Instance.Engine != db.Postgres {
+			continue
+		}
+		policy, err := api.UnmarshalBackupPITRPolicy(backupSetting.PITRPolicy)
+		if err != nil {
+			log.Error("Failed to unmarshal backup PITR policy", zap.String("database", database.Name), zap.Error(err))
+			continue
+		}
+		if !policy.Enabled {
+			continue
+		}
+		instanceMap[database.InstanceID] = database.Instance
+		databaseListMap[database.InstanceID] = append(databaseListMap[database.InstanceID], database)
+	}
+
+	for instanceID, instance := range instanceMap {
+		if err := s.archiveInstanceWAL(ctx, instance, databaseListMap[instanceID]); err != nil {
+			log.Error("Failed to archive WAL",
+				zap.String("instance", instance.Name),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if err := s.stopArchiving(ctx, instanceMap); err != nil {
+		log.Error("Failed to stop WAL archiving", zap.Error(err))
+	}
+}
+
+// archiveInstanceWAL archives the WAL of the instance. The replication slot is recorded before it's created, so it's
+// dropped by the record after the archiving stops or keeps failing. The failures are recorded to raise the anomaly.
+func (s *WALArchiver) archiveInstanceWAL(ctx context.Context, instance *api.Instance, databaseList []*api.Database) error {
+	archive, err := s.server.store.GetPgWALArchiveByInstanceID(ctx, instance.ID)
+	if err != nil {
+		return err
+	}
+	if archive == nil {
+		if archive, err = s.server.store.UpsertPgWALArchive(ctx, &api.PgWALArchiveUpsert{
+			UpdaterID:  api.SystemBotID,
+			InstanceID: instance.ID,
+			SlotName:   pg.WALArchiveSlotName,
+		}); err != nil {
+			return fmt.Errorf("failed to record WAL archive, error: %w", err)
+		}
+	}
+
+	archiveErr := s.archiveInstanceWALWithSlot(ctx, instance, archive, databaseList)
+	if archiveErr == nil {
+		if archive.FailureCount == 0 {
+			return nil
+		}
+		_, err := s.server.store.UpsertPgWALArchive(ctx, &api.PgWALArchiveUpsert{
+			UpdaterID:  api.SystemBotID,
+			InstanceID: instance.ID,
+			SlotName:   archive.SlotName,
+		})
+		return err
+	}
+
+	failureCount := archive.FailureCount + 1
+	if failureCount < maxWALArchiveFailureCount {
+		if _, err := s.server.store.UpsertPgWALArchive(ctx, &api.PgWALArchiveUpsert{
+			UpdaterID:    api.SystemBotID,
+			InstanceID:   instance.ID,
+			SlotName:     archive.SlotName,
+			FailureCount: failureCount,
+			LastError:    archiveErr.Error(),
+		}); err != nil {
+			log.Error("Failed to record WAL archive failure", zap.String("instance", instance.Name), zap.Error(err))
+		}
+		return archiveErr
+	}
+	// The archive has a gap once the slot is dropped, so the archiving starts over with a new slot in the next round.
+	log.Warn("Stop WAL archiving after too many failures",
+		zap.String("instance", instance.Name),
+		zap.Int("failureCount", failureCount),
+		zap.Error(archiveErr),
+	)
+	if err := s.stopInstanceArchiving(ctx, instance, archive); err != nil {
+		return fmt.Errorf("failed to stop WAL archiving after %d failures, error: %w", failureCount, err)
+	}
+	return archiveErr
+}
+
+// archiveInstanceWALWithSlot archives the WAL of the instance through the replication slot of the WAL archive, and
+// prunes the archived WAL older than the base backups of the PITR enabled databases on the instance.
+func (s *WALArchiver) archiveInstanceWALWithSlot(ctx context.Context, instance *api.Instance, archive *api.PgWALArchive, databaseList []*api.Database) error {
+	walDir := getWALArchiveAbsDir(s.server.profile.DataDir, instance.ID)
+	if err := os.MkdirAll(walDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create WAL archive directory %q, error: %w", walDir, err)
+	}
+	driver, err := getAdminDatabaseDriver(ctx, instance, "", s.server.pgInstanceDir)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		return fmt.Errorf("[internal] cast driver to pg.Driver failed")
+	}
+	// The slot is only created for an empty archive, since the WAL between the archive and a new slot is lost.
+	entries, err := os.ReadDir(walDir)
+	if err != nil {
+		return fmt.Errorf("failed to read WAL archive directory %q, error: %w", walDir, err)
+	}
+	if len(entries) == 0 {
+		if err := pgDriver.CreateWALArchiveSlot(ctx, archive.SlotName); err != nil {
+			return err
+		}
+	}
+	pgDriver.SetUpForPITR(walDir)
+	if err := pgDriver.ArchiveWAL(ctx, archive.SlotName); err != nil {
+		return err
+	}
+	if err := s.uploadWALFiles(ctx, instance, walDir); err != nil {
+		return err
+	}
+
+	var backupList []*api.Backup
+	backupStatus := api.BackupStatusDone
+	for _, database := range databaseList {
+		list, err := s.server.store.FindBackup(ctx, &api.BackupFind{DatabaseID: &database.ID, Status: &backupStatus})
+		if err != nil {
+			return fmt.Errorf("failed to find backups for database %q, error: %w", database.Name, err)
+		}
+		backupList = append(backupList, list...)
+	}
+	startWALFile := getOldestPgBaseBackupStartWALFile(backupList)
+	// Keep the WAL until the first base backup is taken.
+	if startWALFile == "" {
+		return nil
+	}
+	if err := pg.PruneWALArchive(walDir, startWALFile); err != nil {
+		return err
+	}
+	if err := s.pruneWALFiles(ctx, instance, startWALFile); err != nil {
+		return err
+	}
+	return s.prunePgBaseBackups(ctx, instance, backupList)
+}
+
+// prunePgBaseBackups removes the base backups of the instance no longer referenced by the backups.
+func (s *WALArchiver) prunePgBaseBackups(ctx context.Context, instance *api.Instance, backupList []*api.Backup) error {
+	baseBackupList, err := s.server.store.FindPgInstanceBaseBackup(ctx, &api.PgInstanceBaseBackupFind{InstanceID: &instance.ID})
+	if err != nil {
+		return fmt.Errorf("failed to find base backups of instance %q, error: %w", instance.Name, err)
+	}
+	for _, baseBackup := range getPrunablePgBaseBackupList(baseBackupList, backupList) {
+		if err := s.removePgBaseBackup(ctx, instance.EnvironmentID, baseBackup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removePgBaseBackup removes the base backup of the instance from its storage backend and deletes its record.
+func (s *WALArchiver) removePgBaseBackup(ctx context.Context, environmentID int, baseBackup *api.PgInstanceBaseBackup) error {
+	if err := s.server.removeStorageFile(ctx, getPgInstanceBaseBackupFile(&baseBackup.Payload), environmentID); err != nil {
+		return fmt.Errorf("failed to remove base backup %d, error: %w", baseBackup.ID, err)
+	}
+	return s.server.store.DeletePgInstanceBaseBackup(ctx, &api.PgInstanceBaseBackupDelete{ID: baseBackup.ID})
+}
+
+// uploadWALFiles uploads the complete WAL files in the WAL archive directory to the backup storage backend of the
+// environment, encoded by its backup storage policy like the backups, and removes the uploaded WAL segments from the
+// directory.
+func (s *WALArchiver) uploadWALFiles(ctx context.Context, instance *api.Instance, walDir string) error {
+	fileList, err := s.server.store.FindPgWALFile(ctx, &api.PgWALFileFind{InstanceID: &instance.ID})
+	if err != nil {
+		return fmt.Errorf("failed to find archived WAL files, error: %w", err)
+	}
+	uploaded := make(map[string]bool)
+	for _, file := range fileList {
+		uploaded[file.Name] = true
+	}
+	walFileList, err := pg.GetCompleteWALFileList(walDir)
+	if err != nil {
+		return err
+	}
+
+	storagePolicy, err := s.server.store.GetBackupStoragePolicyByEnvID(ctx, instance.EnvironmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get backup storage policy for environment %d, error: %w", instance.EnvironmentID, err)
+	}
+	for _, walFile := range walFileList {
+		if uploaded[walFile] {
+			continue
+		}
+		if err := s.uploadWALFile(ctx, instance, storagePolicy, walDir, walFile); err != nil {
+			return err
+		}
+		uploaded[walFile] = true
+	}
+	return pg.RemoveUploadedWALSegments(walDir, uploaded)
+}
+
+func (s *WALArchiver) uploadWALFile(ctx context.Context, instance *api.Instance, storagePolicy *api.BackupStoragePolicy, walDir, walFile string) error {
+	file := &backupFile{
+		name:           walFile,
+		storageBackend: storagePolicy.StorageBackend,
+	}
+	switch storagePolicy.StorageBackend {
+	case api.BackupStorageBackendLocal:
+		dir := getWALFileRelativeDir(instance.ID)
+		if err := os.MkdirAll(filepath.Join(s.server.profile.DataDir, dir), os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create WAL file directory %q, error: %w", dir, err)
+		}
+		file.path = filepath.Join(dir, walFile)
+	case api.BackupStorageBackendS3:
+		file.path = getWALFileObjectKey(storagePolicy.S3.Prefix, instance.ID, walFile)
+	default:
+		return fmt.Errorf("backup storage backend %q not supported", storagePolicy.StorageBackend)
+	}
+
+	var info api.BackupFileInfo
+	if err := s.server.writeStorageFile(ctx, file, instance.EnvironmentID, &info, func(w io.Writer) error {
+		f, err := os.Open(filepath.Join(walDir, walFile))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to upload WAL file %q, error: %w", walFile, err)
+	}
+	if _, err := s.server.store.UpsertPgWALFile(ctx, &api.PgWALFileUpsert{
+		UpdaterID:      api.SystemBotID,
+		InstanceID:     instance.ID,
+		Name:           walFile,
+		StorageBackend: file.storageBackend,
+		Path:           file.path,
+		Payload:        info,
+	}); err != nil {
+		return fmt.Errorf("failed to record WAL file %q, error: %w", walFile, err)
+	}
+	return nil
+}
+
+// pruneWALFiles removes the uploaded WAL segments before the start WAL file of the oldest base backup in use.
+func (s *WALArchiver) pruneWALFiles(ctx context.Context, instance *api.Instance, startWALFile string) error {
+	fileList, err := s.server.store.FindPgWALFile(ctx, &api.PgWALFileFind{InstanceID: &instance.ID})
+	if err != nil {
+		return fmt.Errorf("failed to find archived WAL files, error: %w", err)
+	}
+	for _, file := range fileList {
+		if !pg.IsWALSegmentBefore(file.Name, startWALFile) {
+			continue
+		}
+		if err := s.removeWALFile(ctx, instance.EnvironmentID, file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeWALFile removes the uploaded WAL file from its storage backend and deletes its record.
+func (s *WALArchiver) removeWALFile(ctx context.Context, environmentID int, file *api.PgWALFile) error {
+	if err := s.server.removeStorageFile(ctx, getPgWALFile(file), environmentID); err != nil {
+		return fmt.Errorf("failed to remove WAL file %q, error: %w", file.Name, err)
+	}
+	return s.server.store.DeletePgWALFile(ctx, &api.PgWALFileDelete{ID: file.ID})
+}
+
+// prepareRecoveryWAL archives the WAL of the instance up to now for the PITR restore, and collects the archived WAL
+// needed to replay from the start WAL file into the directory. The uploaded WAL files are downloaded and decoded, and
+// the WAL not uploaded yet is copied from the WAL archive directory.
+func (s *WALArchiver) prepareRecoveryWAL(ctx context.Context, instance *api.Instance, pgDriver *pg.Driver, startWALFile, dir string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	archive, err := s.server.store.GetPgWALArchiveByInstanceID(ctx, instance.ID)
+	if err != nil {
+		return err
+	}
+	if archive == nil {
+		return fmt.Errorf("the WAL of instance %q is not archived, please enable the PITR policy and take a backup first", instance.Name)
+	}
+	walDir := getWALArchiveAbsDir(s.server.profile.DataDir, instance.ID)
+	pgDriver.SetUpForPITR(walDir)
+	log.Debug("Archiving WAL up to now")
+	if err := pgDriver.ArchiveWALForPITR(ctx, archive.SlotName); err != nil {
+		return err
+	}
+	if err := s.uploadWALFiles(ctx, instance, walDir); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create directory %q, error: %w", dir, err)
+	}
+	fileList, err := s.server.store.FindPgWALFile(ctx, &api.PgWALFileFind{InstanceID: &instance.ID})
+	if err != nil {
+		return fmt.Errorf("failed to find archived WAL files, error: %w", err)
+	}
+	for _, file := range fileList {
+		if pg.IsWALSegmentBefore(file.Name, startWALFile) {
+			continue
+		}
+		if err := s.downloadWALFile(ctx, instance.EnvironmentID, file, dir); err != nil {
+			return err
+		}
+	}
+	return pg.CopyWALFiles(walDir, dir, startWALFile)
+}
+
+func (s *WALArchiver) downloadWALFile(ctx context.Context, environmentID int, file *api.PgWALFile, dir string) error {
+	reader, err := s.server.openStorageFile(ctx, getPgWALFile(file), environmentID)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	f, err := os.OpenFile(filepath.Join(dir, file.Name), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, reader); err != nil {
+		return fmt.Errorf("failed to download WAL file %q, error: %w", file.Name, err)
+	}
+	return f.Close()
+}
+
+// stopArchiving stops the WAL archiving of the recorded instances which are not in the instance map of the PITR
+// enabled databases any more, so the WAL is no longer retained.
+func (s *WALArchiver) stopArchiving(ctx context.Context, instanceMap map[int]*api.Instance) error {
+	archiveList, err := s.server.store.FindPgWALArchive(ctx, &api.PgWALArchiveFind{})
+	if err != nil {
+		return fmt.Errorf("failed to find WAL archives, error: %w", err)
+	}
+	for _, archive := range archiveList {
+		if instanceMap[archive.InstanceID] != nil {
+			continue
+		}
+		instance, err := s.server.store.GetInstanceByID(ctx, archive.InstanceID)
+		if err != nil {
+			return fmt.Errorf("failed to get instance %d, error: %w", archive.InstanceID, err)
+		}
+		if err := s.stopInstanceArchiving(ctx, instance, archive); err != nil {
+			log.Error("Failed to stop WAL archiving", zap.Int("instance", archive.InstanceID), zap.Error(err))
+			continue
+		}
+		log.Info("Stopped WAL archiving", zap.Int("instance", archive.InstanceID))
+	}
+	return nil
+}
+
+// stopInstanceArchiving drops the replication slot, removes the archived WAL including the uploaded WAL files and the
+// base backups of the instance, and deletes the record of the WAL archive. The record is kept if the slot fails to drop, so it's retried in the next
+// round. The slot of a deleted instance is dropped on a best-effort basis.
+func (s *WALArchiver) stopInstanceArchiving(ctx context.Context, instance *api.Instance, archive *api.PgWALArchive) error {
+	// The record references the instance, so the instance is never removed before the record.
+	if instance == nil {
+		return fmt.Errorf("instance %d not found", archive.InstanceID)
+	}
+	if err := dropWALArchiveSlot(ctx, s.server, instance, archive.SlotName); err != nil {
+		if instance.RowStatus == api.Normal {
+			return fmt.Errorf("failed to drop replication slot %q, error: %w", archive.SlotName, err)
+		}
+		log.Warn("Failed to drop replication slot of deleted instance",
+			zap.String("instance", instance.Name),
+			zap.String("slot", archive.SlotName),
+			zap.Error(err),
+		)
+	}
+	walDir := getWALArchiveAbsDir(s.server.profile.DataDir, archive.InstanceID)
+	if err := os.RemoveAll(walDir); err != nil {
+		return fmt.Errorf("failed to remove WAL archive directory %q, error: %w", walDir, err)
+	}
+	fileList, err := s.server.store.FindPgWALFile(ctx, &api.PgWALFileFind{InstanceID: &archive.InstanceID})
+	if err != nil {
+		return fmt.Errorf("failed to find archived WAL files, error: %w", err)
+	}
+	for _, file := range fileList {
+		if err := s.removeWALFile(ctx, instance.EnvironmentID, file); err != nil {
+			return err
+		}
+	}
+	// The base backups are useless without the archived WAL.
+	baseBackupList, err := s.server.store.FindPgInstanceBaseBackup(ctx, &api.PgInstanceBaseBackupFind{InstanceID: &archive.InstanceID})
+	if err != nil {
+		return fmt.Errorf("failed to find base backups of instance %q, error: %w", instance.Name, err)
+	}
+	for _, baseBackup := range baseBackupList {
+		if err := s.removePgBaseBackup(ctx, instance.EnvironmentID, baseBackup); err != nil {
+			return err
+		}
+	}
+	return s.server.store.DeletePgWALArchive(ctx, &api.PgWALArchiveDelete{InstanceID: archive.InstanceID})
+}
+
+func dropWALArchiveSlot(ctx context.Context, server *Server, instance *api.Instance, slotName string) error {
+	driver, err := getAdminDatabaseDriver(ctx, instance, "", server.pgInstanceDir)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	pgDriver, ok := driver.(*pg.Driver)
+	if !ok {
+		return fmt.Errorf("[internal] cast driver to pg.Driver failed")
+	}
+	return pgDriver.DropWALArchiveSlot(ctx, slotName)
+}
+
+// getOldestPgBaseBackupStartWALFile returns the start WAL file of the oldest base backup in the backup list,
+// or empty if there is none.
+func getOldestPgBaseBackupStartWALFile(backupList []*api.Backup) string {
+	var startWALFile string
+	for _, backup := range backupList {
+		baseBackup := backup.Payload.PgBaseBackup
+		if baseBackup == nil {
+			continue
+		}
+		if startWALFile == "" || baseBackup.StartWALFile < startWALFile {
+			startWALFile = baseBackup.StartWALFile
+		}
+	}
+	return startWALFile
+}
+
+// getPrunablePgBaseBackupList returns the base backups in the list older than the oldest one referenced by the
+// backups, where the latest base backup comes first. The newer ones are kept even if unreferenced, since the running
+// backups may reference them. None is prunable if no backup references any base backup.
+func getPrunablePgBaseBackupList(baseBackupList []*api.PgInstanceBaseBackup, backupList []*api.Backup) []*api.PgInstanceBaseBackup {
+	referenced := make(map[int]bool)
+	for _, backup := range backupList {
+		if id := backup.Payload.PgBaseBackupID; id != 0 {
+			referenced[id] = true
+		}
+	}
+	oldest := -1
+	for i, baseBackup := range baseBackupList {
+		if referenced[baseBackup.ID] {
+			oldest = i
+		}
+	}
+	if oldest == -1 {
+		return nil
+	}
+	return baseBackupList[oldest+1:]
+}
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/youzi-1122/bytebase/api"
+)
+
+func TestGetPrunablePgBaseBackupList(t *testing.T) {
+	// The latest base backup comes first.
+	baseBackupList := []*api.PgInstanceBaseBackup{{ID: 4}, {ID: 3}, {ID: 2}, {ID: 1}}
+	newBackup := func(baseBackupID int) *api.Backup {
+		return &api.Backup{Payload: api.BackupPayload{PgBaseBackupID: baseBackupID}}
+	}
+
+	tests := []struct {
+		name       string
+		backupList []*api.Backup
+		want       []int
+	}{
+		{
+			name:       "no reference",
+			backupList: []*api.Backup{newBackup(0)},
+			want:       nil,
+		},
+		{
+			name:       "older than the oldest reference",
+			backupList: []*api.Backup{newBackup(3), newBackup(3), newBackup(0)},
+			want:       []int{2, 1},
+		},
+		{
+			name:       "unreferenced between references",
+			backupList: []*api.Backup{newBackup(4), newBackup(2)},
+			want:       []int{1},
+		},
+		{
+			name:       "oldest referenced",
+			backupList: []*api.Backup{newBackup(1)},
+			want:       nil,
+		},
+	}
+
+	for _, test := range tests {
+		var got []int
+		for _, baseBackup := range getPrunablePgBaseBackupList(baseBackupList, test.backupList) {
+			got = append(got, baseBackup.ID)
+		}
+		assert.Equal(t, test.want, got, test.name)
+	}
+}
@@ -2,10 +2,14 @@ package server
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -18,13 +22,22 @@ import (
 	"github.com/youzi-1122/bytebase/api"
 	"github.com/youzi-1122/bytebase/common"
 	"github.com/youzi-1122/bytebase/common/log"
+	"github.com/youzi-1122/bytebase/plugin/advisor"
 	"github.com/youzi-1122/bytebase/plugin/db"
+	"github.com/youzi-1122/bytebase/plugin/db/differ"
 	"github.com/youzi-1122/bytebase/plugin/vcs"
+	"github.com/youzi-1122/bytebase/plugin/vcs/bitbucket"
+	"github.com/youzi-1122/bytebase/plugin/vcs/gitea"
+	"github.com/youzi-1122/bytebase/plugin/vcs/github"
 	"github.com/youzi-1122/bytebase/plugin/vcs/gitlab"
+	"github.com/youzi-1122/bytebase/store"
 )
 
 var (
-	gitLabWebhookPath = "hook/gitlab"
+	gitLabWebhookPath    = "hook/gitlab"
+	gitHubWebhookPath    = "hook/github"
+	giteaWebhookPath     = "hook/gitea"
+	bitbucketWebhookPath = "hook/bitbucket"
 )
 
 func (s *Server) registerWebhookRoutes(g *echo.Group) {
@@ -41,23 +54,14 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusBadRequest, "Malformed push event").SetInternal(err)
 		}
 
-		// This shouldn't happen as we only setup webhook to receive push event, just in case.
-		if pushEvent.ObjectKind != gitlab.WebhookPush {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %s, want push", pushEvent.ObjectKind))
+		// This shouldn't happen as we only setup webhook to receive push and merge request events, just in case.
+		if pushEvent.ObjectKind != gitlab.WebhookPush && pushEvent.ObjectKind != gitlab.WebhookMergeRequest {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %s, want push or merge_request", pushEvent.ObjectKind))
 		}
 
-		webhookEndpointID := c.Param("id")
-		repo, err := s.store.GetRepository(ctx, &api.RepositoryFind{WebhookEndpointID: &webhookEndpointID})
+		repo, err := s.findWebhookRepository(ctx, c.Param("id"))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to respond webhook event for endpoint: %v", webhookEndpointID)).SetInternal(err)
-		}
-		if repo == nil {
-			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Endpoint not found: %v", webhookEndpointID))
-		}
-
-		if repo.VCS == nil {
-			err := fmt.Errorf("VCS not found for ID: %v", repo.VCSID)
-			return echo.NewHTTPError(http.StatusInternalServerError, err).SetInternal(err)
+			return err
 		}
 
 		if c.Request().Header.Get("X-Gitlab-Token") != repo.WebhookSecretToken {
@@ -68,171 +72,952 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Project mismatch, got %d, want %s", pushEvent.Project.ID, repo.ExternalID))
 		}
 
+		if pushEvent.ObjectKind == gitlab.WebhookMergeRequest {
+			mergeRequestEvent := &gitlab.WebhookMergeRequestEvent{}
+			if err := json.Unmarshal(b, mergeRequestEvent); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformed merge request event").SetInternal(err)
+			}
+			// Only review the merge request when it is opened or its source branch is updated.
+			switch mergeRequestEvent.ObjectAttributes.Action {
+			case "open", "reopen", "update":
+			default:
+				return c.String(http.StatusOK, "")
+			}
+
+			createdMessageList, err := s.reviewPullRequest(ctx, repo, vcs.PullRequestEvent{
+				VCSType:      repo.VCS.Type,
+				ID:           strconv.Itoa(mergeRequestEvent.ObjectAttributes.IID),
+				URL:          mergeRequestEvent.ObjectAttributes.URL,
+				RepositoryID: strconv.Itoa(mergeRequestEvent.Project.ID),
+				HeadCommitID: mergeRequestEvent.ObjectAttributes.LastCommit.ID,
+				TargetBranch: mergeRequestEvent.ObjectAttributes.TargetBranch,
+			})
+			if err != nil {
+				return err
+			}
+			return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+		}
+
 		log.Debug("Processing gitlab webhook push event...",
 			zap.String("project", repo.Project.Name),
 		)
 
-		distinctFileList := dedupMigrationFilesFromCommitList(pushEvent.CommitList)
-		createdMessageList := []string{}
-		for _, item := range distinctFileList {
-			commit := item.commit
-			added := item.fileName
-			addedEscaped := common.EscapeForLogging(added)
-			log.Debug("Processing added file...",
-				zap.String("file", addedEscaped),
-				zap.String("commit", common.EscapeForLogging(commit.ID)),
-			)
+		var commitList []vcs.Commit
+		for _, commit := range pushEvent.CommitList {
+			commitList = append(commitList, vcs.Commit{
+				ID:           commit.ID,
+				Title:        commit.Title,
+				Message:      commit.Message,
+				CreatedTs:    parseWebhookCommitTimestamp(commit.ID, commit.Timestamp),
+				URL:          commit.URL,
+				AuthorName:   commit.Author.Name,
+				AddedList:    commit.AddedList,
+				ModifiedList: commit.ModifiedList,
+			})
+		}
+		vcsPushEvent := vcs.PushEvent{
+			VCSType:            repo.VCS.Type,
+			BaseDirectory:      repo.BaseDirectory,
+			Ref:                pushEvent.Ref,
+			RepositoryID:       strconv.Itoa(pushEvent.Project.ID),
+			RepositoryURL:      pushEvent.Project.WebURL,
+			RepositoryFullPath: pushEvent.Project.FullPath,
+			AuthorName:         pushEvent.AuthorName,
+		}
 
-			if !strings.HasPrefix(addedEscaped, repo.BaseDirectory) {
-				log.Debug("Ignored committed file, not under base directory.", zap.String("file", addedEscaped), zap.String("base_directory", repo.BaseDirectory))
-				continue
+		createdMessageList, err := s.createIssueFromPushEvent(ctx, repo, vcsPushEvent, commitList)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+	})
+
+	g.POST("/github/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var b []byte
+		b, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to read webhook request").SetInternal(err)
+		}
+
+		repo, err := s.findWebhookRepository(ctx, c.Param("id"))
+		if err != nil {
+			return err
+		}
+
+		// Verify the signature before looking at the payload, see
+		// https://docs.github.com/en/developers/webhooks-and-events/webhooks/securing-your-webhooks
+		if !validateGitHubWebhookSignature256(c.Request().Header.Get("X-Hub-Signature-256"), repo.WebhookSecretToken, b) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Signature mismatch")
+		}
+
+		// GitHub sends a ping event right after the webhook is created.
+		eventType := github.WebhookType(c.Request().Header.Get("X-GitHub-Event"))
+		if eventType == github.WebhookPing {
+			return c.String(http.StatusOK, "OK")
+		}
+		if eventType == github.WebhookPullRequest {
+			pullRequestEvent := &github.WebhookPullRequestEvent{}
+			if err := json.Unmarshal(b, pullRequestEvent); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformed pull request event").SetInternal(err)
+			}
+			if pullRequestEvent.Repository.FullName != repo.ExternalID {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Repository mismatch, got %s, want %s", pullRequestEvent.Repository.FullName, repo.ExternalID))
+			}
+			// Only review the pull request when it is opened or its head branch is updated.
+			switch pullRequestEvent.Action {
+			case "opened", "reopened", "synchronize":
+			default:
+				return c.String(http.StatusOK, "")
 			}
 
-			// Ignore the schema file we auto generated to the repository.
-			if isSkipGeneratedSchemaFile(repo, addedEscaped) {
-				log.Debug("Ignored generated latest schema file.", zap.String("file", addedEscaped))
-				continue
+			createdMessageList, err := s.reviewPullRequest(ctx, repo, vcs.PullRequestEvent{
+				VCSType:      repo.VCS.Type,
+				ID:           strconv.Itoa(pullRequestEvent.Number),
+				URL:          pullRequestEvent.PullRequest.HTMLURL,
+				RepositoryID: pullRequestEvent.Repository.FullName,
+				HeadCommitID: pullRequestEvent.PullRequest.Head.SHA,
+				TargetBranch: pullRequestEvent.PullRequest.Base.Ref,
+			})
+			if err != nil {
+				return err
 			}
+			return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+		}
+		// This shouldn't happen as we only setup webhook to receive push and pull request events, just in case.
+		if eventType != github.WebhookPush {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %s, want push or pull_request", eventType))
+		}
 
-			vcsPushEvent := vcs.PushEvent{
-				VCSType:            repo.VCS.Type,
-				BaseDirectory:      repo.BaseDirectory,
-				Ref:                pushEvent.Ref,
-				RepositoryID:       strconv.Itoa(pushEvent.Project.ID),
-				RepositoryURL:      pushEvent.Project.WebURL,
-				RepositoryFullPath: pushEvent.Project.FullPath,
-				AuthorName:         pushEvent.AuthorName,
-				FileCommit: vcs.FileCommit{
-					ID:         commit.ID,
-					Title:      commit.Title,
-					Message:    commit.Message,
-					CreatedTs:  item.createdTime.Unix(),
-					URL:        commit.URL,
-					AuthorName: commit.Author.Name,
-					Added:      addedEscaped,
-				},
-			}
-
-			// Create a WARNING project activity if committed file is ignored
-			var createIgnoredFileActivity = func(err error) {
-				log.Warn("Ignored committed file", zap.String("file", addedEscaped), zap.Error(err))
-				bytes, marshalErr := json.Marshal(api.ActivityProjectRepositoryPushPayload{
-					VCSPushEvent: vcsPushEvent,
-				})
-				if marshalErr != nil {
-					log.Warn("Failed to construct project activity payload to record ignored repository committed file", zap.Error(marshalErr))
-					return
-				}
+		pushEvent := &github.WebhookPushEvent{}
+		if err := json.Unmarshal(b, pushEvent); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed push event").SetInternal(err)
+		}
+
+		if pushEvent.Repository.FullName != repo.ExternalID {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Repository mismatch, got %s, want %s", pushEvent.Repository.FullName, repo.ExternalID))
+		}
 
-				activityCreate := &api.ActivityCreate{
-					CreatorID:   api.SystemBotID,
-					ContainerID: repo.ProjectID,
-					Type:        api.ActivityProjectRepositoryPush,
-					Level:       api.ActivityWarn,
-					Comment:     fmt.Sprintf("Ignored committed file %q, %s.", addedEscaped, err.Error()),
-					Payload:     string(bytes),
+		// GitHub does not support filtering the webhook by branch, so we need to filter ourselves.
+		if !isBranchFilterMatched(repo.BranchFilter, pushEvent.Ref) {
+			log.Debug("Ignored github webhook push event, branch filter mismatch.",
+				zap.String("project", repo.Project.Name),
+				zap.String("ref", common.EscapeForLogging(pushEvent.Ref)),
+				zap.String("branch_filter", repo.BranchFilter),
+			)
+			return c.String(http.StatusOK, "")
+		}
+
+		log.Debug("Processing github webhook push event...",
+			zap.String("project", repo.Project.Name),
+		)
+
+		pushedCommitList := pushEvent.Commits
+		// GitHub caps the commit list of the push event, so we fetch the full list through the API
+		// in case the push is truncated, otherwise the migration files in the left out commits are missed.
+		if len(pushEvent.Commits) >= github.WebhookPushEventCommitLimit {
+			if pushEvent.Created {
+				// Without the old head, the pushed range of a new branch can't be told apart from the
+				// existing history, so only the commits in the payload are processed.
+				log.Warn("GitHub push event of a new branch may be truncated, only the commits in the payload are processed.",
+					zap.String("project", repo.Project.Name),
+					zap.String("ref", common.EscapeForLogging(pushEvent.Ref)),
+				)
+			} else {
+				provider, ok := vcs.Get(vcs.GitHubCom, vcs.ProviderConfig{}).(*github.Provider)
+				if !ok {
+					return echo.NewHTTPError(http.StatusInternalServerError, "[internal] cast provider to github.Provider failed")
 				}
-				_, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{})
+				commitList, err := provider.FetchCommitRange(
+					ctx,
+					common.OauthContext{
+						ClientID:     repo.VCS.ApplicationID,
+						ClientSecret: repo.VCS.Secret,
+						AccessToken:  repo.AccessToken,
+						RefreshToken: repo.RefreshToken,
+						Refresher:    s.refreshToken(ctx, repo.ID),
+					},
+					repo.ExternalID,
+					pushEvent.Before,
+					pushEvent.After,
+				)
 				if err != nil {
-					log.Warn("Failed to create project activity to record ignored repository committed file", zap.Error(err))
+					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch commits of truncated push to %s", pushEvent.Ref)).SetInternal(err)
 				}
+				pushedCommitList = commitList
 			}
+		}
 
-			mi, err := db.ParseMigrationInfo(addedEscaped, filepath.Join(repo.BaseDirectory, repo.FilePathTemplate))
-			if err != nil {
-				createIgnoredFileActivity(err)
-				continue
+		var commitList []vcs.Commit
+		for _, commit := range pushedCommitList {
+			commitList = append(commitList, vcs.Commit{
+				ID:           commit.ID,
+				Title:        commitTitle(commit.Message),
+				Message:      commit.Message,
+				CreatedTs:    parseWebhookCommitTimestamp(commit.ID, commit.Timestamp),
+				URL:          commit.URL,
+				AuthorName:   commit.Author.Name,
+				AddedList:    commit.Added,
+				ModifiedList: commit.Modified,
+			})
+		}
+		vcsPushEvent := vcs.PushEvent{
+			VCSType:            repo.VCS.Type,
+			BaseDirectory:      repo.BaseDirectory,
+			Ref:                pushEvent.Ref,
+			RepositoryID:       pushEvent.Repository.FullName,
+			RepositoryURL:      pushEvent.Repository.HTMLURL,
+			RepositoryFullPath: pushEvent.Repository.FullName,
+			AuthorName:         pushEvent.Pusher.Name,
+		}
+
+		createdMessageList, err := s.createIssueFromPushEvent(ctx, repo, vcsPushEvent, commitList)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+	})
+
+	g.POST("/gitea/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var b []byte
+		b, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to read webhook request").SetInternal(err)
+		}
+
+		repo, err := s.findWebhookRepository(ctx, c.Param("id"))
+		if err != nil {
+			return err
+		}
+
+		// Gitea signs the payload with the secret and delivers the hex digest without prefix, see
+		// https://docs.gitea.io/en-us/webhooks/
+		if !validateWebhookHMACSHA256(c.Request().Header.Get("X-Gitea-Signature"), repo.WebhookSecretToken, b) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Signature mismatch")
+		}
+
+		eventType := gitea.WebhookType(c.Request().Header.Get("X-Gitea-Event"))
+		if eventType == gitea.WebhookPullRequest {
+			pullRequestEvent := &gitea.WebhookPullRequestEvent{}
+			if err := json.Unmarshal(b, pullRequestEvent); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformed pull request event").SetInternal(err)
+			}
+			if pullRequestEvent.Repository.FullName != repo.ExternalID {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Repository mismatch, got %s, want %s", pullRequestEvent.Repository.FullName, repo.ExternalID))
+			}
+			// Only review the pull request when it is opened or its head branch is updated.
+			switch pullRequestEvent.Action {
+			case "opened", "reopened", "synchronized":
+			default:
+				return c.String(http.StatusOK, "")
 			}
 
-			// Retrieve sql by reading the file content
-			content, err := vcs.Get(vcs.GitLabSelfHost, vcs.ProviderConfig{}).ReadFileContent(
-				ctx,
-				common.OauthContext{
-					ClientID:     repo.VCS.ApplicationID,
-					ClientSecret: repo.VCS.Secret,
-					AccessToken:  repo.AccessToken,
-					RefreshToken: repo.RefreshToken,
-					Refresher:    s.refreshToken(ctx, repo.ID),
-				},
-				repo.VCS.InstanceURL,
-				repo.ExternalID,
-				addedEscaped,
-				commit.ID,
-			)
+			createdMessageList, err := s.reviewPullRequest(ctx, repo, vcs.PullRequestEvent{
+				VCSType:      repo.VCS.Type,
+				ID:           strconv.Itoa(pullRequestEvent.Number),
+				URL:          pullRequestEvent.PullRequest.HTMLURL,
+				RepositoryID: pullRequestEvent.Repository.FullName,
+				HeadCommitID: pullRequestEvent.PullRequest.Head.SHA,
+				TargetBranch: pullRequestEvent.PullRequest.Base.Ref,
+			})
 			if err != nil {
-				createIgnoredFileActivity(err)
-				continue
+				return err
 			}
+			return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+		}
+		// This shouldn't happen as we only setup webhook to receive push and pull request events, just in case.
+		if eventType != gitea.WebhookPush {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %s, want push or pull_request", eventType))
+		}
 
-			// Create schema update issue.
-			var createContext string
-			if repo.Project.TenantMode == api.TenantModeTenant {
-				if !s.feature(api.FeatureMultiTenancy) {
-					return echo.NewHTTPError(http.StatusForbidden, api.FeatureMultiTenancy.AccessErrorMessage())
-				}
-				createContext, err = s.createTenantSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, commit, addedEscaped, content)
-			} else {
-				createContext, err = s.createSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, commit, addedEscaped, content)
+		pushEvent := &gitea.WebhookPushEvent{}
+		if err := json.Unmarshal(b, pushEvent); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed push event").SetInternal(err)
+		}
+
+		if pushEvent.Repository.FullName != repo.ExternalID {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Repository mismatch, got %s, want %s", pushEvent.Repository.FullName, repo.ExternalID))
+		}
+
+		log.Debug("Processing gitea webhook push event...",
+			zap.String("project", repo.Project.Name),
+		)
+
+		var commitList []vcs.Commit
+		for _, commit := range pushEvent.Commits {
+			commitList = append(commitList, vcs.Commit{
+				ID:           commit.ID,
+				Title:        commitTitle(commit.Message),
+				Message:      commit.Message,
+				CreatedTs:    parseWebhookCommitTimestamp(commit.ID, commit.Timestamp),
+				URL:          commit.URL,
+				AuthorName:   commit.Author.Name,
+				AddedList:    commit.Added,
+				ModifiedList: commit.Modified,
+			})
+		}
+		vcsPushEvent := vcs.PushEvent{
+			VCSType:            repo.VCS.Type,
+			BaseDirectory:      repo.BaseDirectory,
+			Ref:                pushEvent.Ref,
+			RepositoryID:       pushEvent.Repository.FullName,
+			RepositoryURL:      pushEvent.Repository.HTMLURL,
+			RepositoryFullPath: pushEvent.Repository.FullName,
+			AuthorName:         pushEvent.Pusher.Login,
+		}
+
+		createdMessageList, err := s.createIssueFromPushEvent(ctx, repo, vcsPushEvent, commitList)
+		if err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+	})
+
+	g.POST("/bitbucket/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		var b []byte
+		b, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to read webhook request").SetInternal(err)
+		}
+
+		repo, err := s.findWebhookRepository(ctx, c.Param("id"))
+		if err != nil {
+			return err
+		}
+
+		// Bitbucket signs the payload in the same format as GitHub, see
+		// https://support.atlassian.com/bitbucket-cloud/docs/manage-webhooks/
+		if !validateGitHubWebhookSignature256(c.Request().Header.Get("X-Hub-Signature"), repo.WebhookSecretToken, b) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Signature mismatch")
+		}
+
+		eventType := bitbucket.WebhookType(c.Request().Header.Get("X-Event-Key"))
+		if eventType == bitbucket.WebhookPullRequestCreated || eventType == bitbucket.WebhookPullRequestUpdated {
+			pullRequestEvent := &bitbucket.WebhookPullRequestEvent{}
+			if err := json.Unmarshal(b, pullRequestEvent); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, "Malformed pull request event").SetInternal(err)
 			}
+			if pullRequestEvent.Repository.FullName != repo.ExternalID {
+				return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Repository mismatch, got %s, want %s", pullRequestEvent.Repository.FullName, repo.ExternalID))
+			}
+
+			createdMessageList, err := s.reviewPullRequest(ctx, repo, vcs.PullRequestEvent{
+				VCSType:      repo.VCS.Type,
+				ID:           strconv.Itoa(pullRequestEvent.PullRequest.ID),
+				URL:          pullRequestEvent.PullRequest.Links.HTML.Href,
+				RepositoryID: pullRequestEvent.Repository.FullName,
+				HeadCommitID: pullRequestEvent.PullRequest.Source.Commit.Hash,
+				TargetBranch: pullRequestEvent.PullRequest.Destination.Branch.Name,
+			})
 			if err != nil {
-				createIgnoredFileActivity(err)
+				return err
+			}
+			return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+		}
+		// This shouldn't happen as we only setup webhook to receive push and pull request events, just in case.
+		if eventType != bitbucket.WebhookPush {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid webhook event type, got %s, want %s", eventType, bitbucket.WebhookPush))
+		}
+
+		pushEvent := &bitbucket.WebhookPushEvent{}
+		if err := json.Unmarshal(b, pushEvent); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed push event").SetInternal(err)
+		}
+
+		if pushEvent.Repository.FullName != repo.ExternalID {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Repository mismatch, got %s, want %s", pushEvent.Repository.FullName, repo.ExternalID))
+		}
+
+		log.Debug("Processing bitbucket webhook push event...",
+			zap.String("project", repo.Project.Name),
+		)
+
+		oauthCtx := common.OauthContext{
+			ClientID:     repo.VCS.ApplicationID,
+			ClientSecret: repo.VCS.Secret,
+			AccessToken:  repo.AccessToken,
+			RefreshToken: repo.RefreshToken,
+			Refresher:    s.refreshToken(ctx, repo.ID),
+		}
+		provider, ok := vcs.Get(vcs.BitbucketOrg, vcs.ProviderConfig{}).(*bitbucket.Provider)
+		if !ok {
+			return echo.NewHTTPError(http.StatusInternalServerError, "[internal] cast provider to bitbucket.Provider failed")
+		}
+
+		// A Bitbucket push event may contain changes of several references, and
+		// the payload does not list the files changed by each commit, so we have
+		// to fetch the changed files of each commit through the API.
+		var createdMessageList []string
+		for _, change := range pushEvent.Push.Changes {
+			if change.New == nil || change.New.Type != "branch" {
+				continue
+			}
+			ref := "refs/heads/" + change.New.Name
+			// Bitbucket does not support filtering the webhook by branch, so we need to filter ourselves.
+			if !isBranchFilterMatched(repo.BranchFilter, ref) {
+				log.Debug("Ignored bitbucket webhook push event, branch filter mismatch.",
+					zap.String("project", repo.Project.Name),
+					zap.String("ref", common.EscapeForLogging(ref)),
+					zap.String("branch_filter", repo.BranchFilter),
+				)
 				continue
 			}
 
-			issueType := api.IssueDatabaseSchemaUpdate
-			if mi.Type == db.Data {
-				issueType = api.IssueDatabaseDataUpdate
+			pushedCommitList := change.Commits
+			if change.Truncated {
+				if change.Old == nil {
+					// Without the old head, the pushed range of a new branch can't be told apart from the
+					// existing history, so only the commits in the payload are processed.
+					log.Warn("Bitbucket push event of a new branch is truncated, only the commits in the payload are processed.",
+						zap.String("project", repo.Project.Name),
+						zap.String("ref", common.EscapeForLogging(ref)),
+					)
+				} else {
+					commitList, err := provider.FetchCommitRange(ctx, oauthCtx, repo.ExternalID, change.New.Target.Hash, change.Old.Target.Hash)
+					if err != nil {
+						return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch commits of truncated push to %s", change.New.Name)).SetInternal(err)
+					}
+					pushedCommitList = commitList
+				}
 			}
-			issueCreate := &api.IssueCreate{
-				ProjectID:     repo.ProjectID,
-				Name:          commit.Title,
-				Type:          issueType,
-				Description:   commit.Message,
-				AssigneeID:    api.SystemBotID,
-				CreateContext: createContext,
+
+			var commitList []vcs.Commit
+			// Bitbucket lists the commits from the newest, so we iterate in reverse to create the issues in the commit order.
+			for i := len(pushedCommitList) - 1; i >= 0; i-- {
+				commit := pushedCommitList[i]
+				addedList, modifiedList, err := provider.FetchCommitFileList(ctx, oauthCtx, repo.ExternalID, commit.Hash)
+				if err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch changed files of commit %s", commit.Hash)).SetInternal(err)
+				}
+				commitList = append(commitList, vcs.Commit{
+					ID:           commit.Hash,
+					Title:        commitTitle(commit.Message),
+					Message:      commit.Message,
+					CreatedTs:    commit.Date.Unix(),
+					URL:          commit.Links.HTML.Href,
+					AuthorName:   commit.AuthorName(),
+					AddedList:    addedList,
+					ModifiedList: modifiedList,
+				})
+			}
+			vcsPushEvent := vcs.PushEvent{
+				VCSType:            repo.VCS.Type,
+				BaseDirectory:      repo.BaseDirectory,
+				Ref:                ref,
+				RepositoryID:       pushEvent.Repository.FullName,
+				RepositoryURL:      pushEvent.Repository.Links.HTML.Href,
+				RepositoryFullPath: pushEvent.Repository.FullName,
+				AuthorName:         pushEvent.Actor.DisplayName,
 			}
-			issue, err := s.createIssue(ctx, issueCreate, api.SystemBotID)
+
+			messageList, err := s.createIssueFromPushEvent(ctx, repo, vcsPushEvent, commitList)
 			if err != nil {
-				errMsg := "Failed to create schema update issue"
-				if issueType == api.IssueDatabaseDataUpdate {
-					errMsg = "Failed to create data update issue"
-				}
-				return echo.NewHTTPError(http.StatusInternalServerError, errMsg).SetInternal(err)
+				return err
 			}
+			createdMessageList = append(createdMessageList, messageList...)
+		}
+		return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
+	})
+}
+
+// findWebhookRepository finds the repository by the webhook endpoint ID. The
+// returned error is an *echo.HTTPError.
+func (s *Server) findWebhookRepository(ctx context.Context, webhookEndpointID string) (*api.Repository, error) {
+	repo, err := s.store.GetRepository(ctx, &api.RepositoryFind{WebhookEndpointID: &webhookEndpointID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to respond webhook event for endpoint: %v", webhookEndpointID)).SetInternal(err)
+	}
+	if repo == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Endpoint not found: %v", webhookEndpointID))
+	}
+
+	if repo.VCS == nil {
+		err := fmt.Errorf("VCS not found for ID: %v", repo.VCSID)
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, err).SetInternal(err)
+	}
+	return repo, nil
+}
+
+// validateGitHubWebhookSignature256 returns true if the signature matches the
+// HMAC hex digest of the body using the SHA-256 hash function and the given key.
+// The signature is prefixed with "sha256=", which is also the format used by
+// Bitbucket.
+func validateGitHubWebhookSignature256(signature, key string, body []byte) bool {
+	const signaturePrefix = "sha256="
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return validateWebhookHMACSHA256(strings.TrimPrefix(signature, signaturePrefix), key, body)
+}
+
+// validateWebhookHMACSHA256 returns true if the hex encoded digest matches the
+// HMAC digest of the body using the SHA-256 hash function and the given key.
+func validateWebhookHMACSHA256(hexDigest, key string, body []byte) bool {
+	got, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return false
+	}
+
+	m := hmac.New(sha256.New, []byte(key))
+	// hash.Hash never returns an error on Write.
+	_, _ = m.Write(body)
+	return hmac.Equal(got, m.Sum(nil))
+}
+
+// isBranchFilterMatched returns true if the pushed ref matches the branch
+// filter of the repository. Like GitLab, an empty filter matches all branches
+// and wildcards are supported.
+func isBranchFilterMatched(branchFilter, ref string) bool {
+	if branchFilter == "" {
+		return true
+	}
+	branch := strings.TrimPrefix(ref, "refs/heads/")
+	matched, err := path.Match(branchFilter, branch)
+	if err != nil {
+		log.Warn("Invalid branch filter.", zap.String("branch_filter", branchFilter), zap.Error(err))
+		return false
+	}
+	return matched
+}
 
-			createdMessageList = append(createdMessageList, fmt.Sprintf("Created issue %q on adding %s", issue.Name, addedEscaped))
+// commitTitle returns the first line of the commit message as the title, for
+// providers that do not have a separate commit title.
+func commitTitle(message string) string {
+	if i := strings.Index(message, "\n"); i != -1 {
+		return message[:i]
+	}
+	return message
+}
+
+// parseWebhookCommitTimestamp parses the RFC 3339 commit timestamp in the push
+// event to Unix seconds. It returns 0 if the timestamp is malformed.
+func parseWebhookCommitTimestamp(commitID, timestamp string) int64 {
+	createdTime, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		log.Warn("Failed to parse commit timestamp.", zap.String("commit", common.EscapeForLogging(commitID)), zap.String("timestamp", common.EscapeForLogging(timestamp)), zap.Error(err))
+		return 0
+	}
+	return createdTime.Unix()
+}
+
+// createIssueFromPushEvent creates a schema or data update issue for each
+// migration file added in the commit list of the push event. For the project
+// with the SDL schema change type, it creates a schema update issue for each
+// schema file added or modified instead. It returns the list of messages
+// describing the created issues. The returned error is an *echo.HTTPError.
+func (s *Server) createIssueFromPushEvent(ctx context.Context, repo *api.Repository, baseVCSPushEvent vcs.PushEvent, commitList []vcs.Commit) ([]string, error) {
+	// For the SDL schema change type, the committed files are the desired schema files instead of the migration files.
+	sdl := repo.Project.SchemaChangeType == api.ProjectSchemaChangeTypeSDL
+	distinctFileList := dedupMigrationFilesFromCommitList(commitList)
+	if sdl {
+		distinctFileList = dedupSchemaFilesFromCommitList(commitList)
+	}
+	createdMessageList := []string{}
+	for _, item := range distinctFileList {
+		commit := item.commit
+		added := item.fileName
+		addedEscaped := common.EscapeForLogging(added)
+		log.Debug("Processing added file...",
+			zap.String("file", addedEscaped),
+			zap.String("commit", common.EscapeForLogging(commit.ID)),
+		)
+
+		if !strings.HasPrefix(addedEscaped, repo.BaseDirectory) {
+			log.Debug("Ignored committed file, not under base directory.", zap.String("file", addedEscaped), zap.String("base_directory", repo.BaseDirectory))
+			continue
+		}
+
+		// Ignore the schema file we auto generated to the repository.
+		if !sdl && isSkipGeneratedSchemaFile(repo, addedEscaped) {
+			log.Debug("Ignored generated latest schema file.", zap.String("file", addedEscaped))
+			continue
+		}
 
-			// Create a project activity after successfully creating the issue as the result of the push event
-			bytes, err := json.Marshal(api.ActivityProjectRepositoryPushPayload{
+		vcsPushEvent := baseVCSPushEvent
+		vcsPushEvent.FileCommit = vcs.FileCommit{
+			ID:         commit.ID,
+			Title:      commit.Title,
+			Message:    commit.Message,
+			CreatedTs:  commit.CreatedTs,
+			URL:        commit.URL,
+			AuthorName: commit.AuthorName,
+			Added:      addedEscaped,
+		}
+
+		// Create a WARNING project activity if committed file is ignored
+		var createIgnoredFileActivity = func(err error) {
+			log.Warn("Ignored committed file", zap.String("file", addedEscaped), zap.Error(err))
+			bytes, marshalErr := json.Marshal(api.ActivityProjectRepositoryPushPayload{
 				VCSPushEvent: vcsPushEvent,
-				IssueID:      issue.ID,
-				IssueName:    issue.Name,
 			})
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct activity payload").SetInternal(err)
+			if marshalErr != nil {
+				log.Warn("Failed to construct project activity payload to record ignored repository committed file", zap.Error(marshalErr))
+				return
 			}
 
 			activityCreate := &api.ActivityCreate{
 				CreatorID:   api.SystemBotID,
 				ContainerID: repo.ProjectID,
 				Type:        api.ActivityProjectRepositoryPush,
-				Level:       api.ActivityInfo,
-				Comment:     fmt.Sprintf("Created issue %q.", issue.Name),
+				Level:       api.ActivityWarn,
+				Comment:     fmt.Sprintf("Ignored committed file %q, %s.", addedEscaped, err.Error()),
 				Payload:     string(bytes),
 			}
-			if _, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{}); err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create project activity after creating issue from repository push event: %d", issue.ID)).SetInternal(err)
+			_, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{})
+			if err != nil {
+				log.Warn("Failed to create project activity to record ignored repository committed file", zap.Error(err))
 			}
 		}
 
-		if len(createdMessageList) == 0 {
-			msg := "Ignored push event. No applicable file found in the commit list."
-			log.Warn(msg,
-				zap.String("project", repo.Project.Name),
+		var mi *db.MigrationInfo
+		var err error
+		if sdl {
+			mi, err = db.ParseSchemaFileInfo(addedEscaped, filepath.Join(repo.BaseDirectory, repo.SchemaPathTemplate))
+			if err != nil {
+				log.Debug("Ignored committed file, not a schema file.", zap.String("file", addedEscaped), zap.Error(err))
+				continue
+			}
+		} else {
+			mi, err = db.ParseMigrationInfo(addedEscaped, filepath.Join(repo.BaseDirectory, repo.FilePathTemplate))
+			if err != nil {
+				createIgnoredFileActivity(err)
+				continue
+			}
+		}
+
+		// Retrieve sql by reading the file content
+		content, err := vcs.Get(repo.VCS.Type, vcs.ProviderConfig{}).ReadFileContent(
+			ctx,
+			common.OauthContext{
+				ClientID:     repo.VCS.ApplicationID,
+				ClientSecret: repo.VCS.Secret,
+				AccessToken:  repo.AccessToken,
+				RefreshToken: repo.RefreshToken,
+				Refresher:    s.refreshToken(ctx, repo.ID),
+			},
+			repo.VCS.InstanceURL,
+			repo.ExternalID,
+			addedEscaped,
+			commit.ID,
+		)
+		if err != nil {
+			createIgnoredFileActivity(err)
+			continue
+		}
+
+		// Create schema update issue.
+		var createContext string
+		description := commit.Message
+		if repo.Project.TenantMode == api.TenantModeTenant {
+			if !s.feature(api.FeatureMultiTenancy) {
+				return nil, echo.NewHTTPError(http.StatusForbidden, api.FeatureMultiTenancy.AccessErrorMessage())
+			}
+			// The project patch rejects the SDL schema change type for projects in tenant mode, and we check here as well
+			// so that the schema file is never applied to a single database bypassing the deployment configuration.
+			if sdl {
+				err = fmt.Errorf("the SDL schema change type is not supported for projects in tenant mode")
+			} else {
+				createContext, err = s.createTenantSchemaUpdateIssue(mi, vcsPushEvent, content)
+			}
+		} else if sdl {
+			var reviewComment string
+			createContext, reviewComment, err = s.createSDLSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, addedEscaped, content)
+			if err == nil && createContext == "" {
+				log.Debug("Ignored committed schema file, no schema change found.", zap.String("file", addedEscaped))
+				continue
+			}
+			if reviewComment != "" {
+				description = fmt.Sprintf("%s\n\n%s", description, reviewComment)
+			}
+		} else {
+			createContext, err = s.createSchemaUpdateIssue(ctx, repo, mi, vcsPushEvent, addedEscaped, content)
+		}
+		if err != nil {
+			createIgnoredFileActivity(err)
+			continue
+		}
+
+		issueType := api.IssueDatabaseSchemaUpdate
+		if mi.Type == db.Data {
+			issueType = api.IssueDatabaseDataUpdate
+		}
+		issueCreate := &api.IssueCreate{
+			ProjectID:     repo.ProjectID,
+			Name:          commit.Title,
+			Type:          issueType,
+			Description:   description,
+			AssigneeID:    api.SystemBotID,
+			CreateContext: createContext,
+		}
+		issue, err := s.createIssue(ctx, issueCreate, api.SystemBotID)
+		if err != nil {
+			errMsg := "Failed to create schema update issue"
+			if issueType == api.IssueDatabaseDataUpdate {
+				errMsg = "Failed to create data update issue"
+			}
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, errMsg).SetInternal(err)
+		}
+
+		createdMessageList = append(createdMessageList, fmt.Sprintf("Created issue %q on adding %s", issue.Name, addedEscaped))
+
+		// Create a project activity after successfully creating the issue as the result of the push event
+		bytes, err := json.Marshal(api.ActivityProjectRepositoryPushPayload{
+			VCSPushEvent: vcsPushEvent,
+			IssueID:      issue.ID,
+			IssueName:    issue.Name,
+		})
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to construct activity payload").SetInternal(err)
+		}
+
+		activityCreate := &api.ActivityCreate{
+			CreatorID:   api.SystemBotID,
+			ContainerID: repo.ProjectID,
+			Type:        api.ActivityProjectRepositoryPush,
+			Level:       api.ActivityInfo,
+			Comment:     fmt.Sprintf("Created issue %q.", issue.Name),
+			Payload:     string(bytes),
+		}
+		if _, err = s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{}); err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to create project activity after creating issue from repository push event: %d", issue.ID)).SetInternal(err)
+		}
+	}
+
+	if len(createdMessageList) == 0 {
+		msg := "Ignored push event. No applicable file found in the commit list."
+		log.Warn(msg,
+			zap.String("project", repo.Project.Name),
+		)
+	}
+	return createdMessageList, nil
+}
+
+// pullRequestReviewCommentTitle is the title the schema review comment on the
+// pull request starts with, which identifies the comment to update.
+const pullRequestReviewCommentTitle = "## Bytebase SQL Review"
+
+// pullRequestFileReview is the schema review result of a migration file
+// changed by a pull request against a database.
+type pullRequestFileReview struct {
+	filePath        string
+	databaseName    string
+	environmentName string
+	adviceList      []advisor.Advice
+}
+
+// reviewPullRequest reviews the migration files added or modified by the pull
+// request against the effective schema review policy of each target database,
+// and posts the advice back to the pull request as a comment. It
+// returns the list of messages describing the review. The returned error is an
+// *echo.HTTPError.
+func (s *Server) reviewPullRequest(ctx context.Context, repo *api.Repository, pullRequestEvent vcs.PullRequestEvent) ([]string, error) {
+	if !s.feature(api.FeatureSchemaReviewPolicy) {
+		log.Debug("Ignored pull request event, schema review policy feature is not available.",
+			zap.String("project", repo.Project.Name),
+		)
+		return nil, nil
+	}
+
+	// The webhooks of pull request events do not support branch filters, so we need to filter ourselves.
+	if !isBranchFilterMatched(repo.BranchFilter, pullRequestEvent.TargetBranch) {
+		log.Debug("Ignored pull request event, branch filter mismatch.",
+			zap.String("project", repo.Project.Name),
+			zap.String("target_branch", common.EscapeForLogging(pullRequestEvent.TargetBranch)),
+			zap.String("branch_filter", repo.BranchFilter),
+		)
+		return nil, nil
+	}
+
+	log.Debug("Processing pull request event...",
+		zap.String("project", repo.Project.Name),
+		zap.String("pull_request", common.EscapeForLogging(pullRequestEvent.ID)),
+	)
+
+	oauthCtx := common.OauthContext{
+		ClientID:     repo.VCS.ApplicationID,
+		ClientSecret: repo.VCS.Secret,
+		AccessToken:  repo.AccessToken,
+		RefreshToken: repo.RefreshToken,
+		Refresher:    s.refreshToken(ctx, repo.ID),
+	}
+	provider := vcs.Get(repo.VCS.Type, vcs.ProviderConfig{})
+	fileList, err := provider.ListPullRequestFile(ctx, oauthCtx, repo.VCS.InstanceURL, repo.ExternalID, pullRequestEvent.ID)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list files of pull request %s", pullRequestEvent.ID)).SetInternal(err)
+	}
+
+	var reviewList []pullRequestFileReview
+	for _, file := range fileList {
+		if file.IsDeleted {
+			continue
+		}
+		fileEscaped := common.EscapeForLogging(file.Path)
+		if !strings.HasPrefix(fileEscaped, repo.BaseDirectory) {
+			continue
+		}
+		// Ignore the schema file we auto generated to the repository.
+		if isSkipGeneratedSchemaFile(repo, fileEscaped) {
+			continue
+		}
+		mi, err := db.ParseMigrationInfo(fileEscaped, filepath.Join(repo.BaseDirectory, repo.FilePathTemplate))
+		if err != nil {
+			log.Debug("Ignored pull request file, not a migration file.", zap.String("file", fileEscaped), zap.Error(err))
+			continue
+		}
+
+		content, err := provider.ReadFileContent(ctx, oauthCtx, repo.VCS.InstanceURL, repo.ExternalID, fileEscaped, pullRequestEvent.HeadCommitID)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to read file %q of pull request %s", fileEscaped, pullRequestEvent.ID)).SetInternal(err)
+		}
+
+		databaseList, err := s.findPullRequestFileDatabaseList(ctx, repo, mi)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to find databases of file %q", fileEscaped)).SetInternal(err)
+		}
+		if len(databaseList) == 0 {
+			log.Debug("Ignored pull request file, no matching database in the project.", zap.String("file", fileEscaped))
+			continue
+		}
+
+		// Review the file once for each effective schema review policy.
+		policySet := make(map[int]bool)
+		for _, database := range databaseList {
+			if !api.IsSchemaReviewSupported(database.Instance.Engine, s.profile.Mode) {
+				continue
+			}
+			policyID, err := s.store.GetSchemaReviewPolicyIDByDatabase(ctx, database)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get schema review policy for database %q", database.Name)).SetInternal(err)
+			}
+			if policySet[policyID] {
+				continue
+			}
+			policySet[policyID] = true
+			dbType, err := api.ConvertToAdvisorDBType(database.Instance.Engine)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to convert db type %v into advisor db type", database.Instance.Engine)).SetInternal(err)
+			}
+			_, adviceList, err := s.sqlCheck(
+				ctx,
+				dbType,
+				database.CharacterSet,
+				database.Collation,
+				policyID,
+				content,
+				store.NewCatalog(&database.ID, s.store),
 			)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to check schema review policy for file %q", fileEscaped)).SetInternal(err)
+			}
+			reviewList = append(reviewList, pullRequestFileReview{
+				filePath:        fileEscaped,
+				databaseName:    database.Name,
+				environmentName: database.Instance.Environment.Name,
+				adviceList:      adviceList,
+			})
 		}
-		return c.String(http.StatusOK, strings.Join(createdMessageList, "\n"))
-	})
+	}
+
+	if len(reviewList) == 0 {
+		log.Debug("Ignored pull request event. No applicable file found in the pull request.",
+			zap.String("project", repo.Project.Name),
+		)
+		return nil, nil
+	}
+
+	// Update the review comment posted by the previous events of the pull request, so that the pull request keeps
+	// a single review comment rather than one per push.
+	comment := composePullRequestReviewComment(reviewList)
+	commentList, err := provider.ListPullRequestComment(ctx, oauthCtx, repo.VCS.InstanceURL, repo.ExternalID, pullRequestEvent.ID)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to list comments of pull request %s", pullRequestEvent.ID)).SetInternal(err)
+	}
+	if reviewComment := findPullRequestReviewComment(commentList); reviewComment != nil {
+		if err := provider.UpdatePullRequestComment(ctx, oauthCtx, repo.VCS.InstanceURL, repo.ExternalID, pullRequestEvent.ID, reviewComment.ID, comment); err != nil {
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update comment %s on pull request %s", reviewComment.ID, pullRequestEvent.ID)).SetInternal(err)
+		}
+	} else if err := provider.CreatePullRequestComment(ctx, oauthCtx, repo.VCS.InstanceURL, repo.ExternalID, pullRequestEvent.ID, comment); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to comment on pull request %s", pullRequestEvent.ID)).SetInternal(err)
+	}
+	return []string{fmt.Sprintf("Reviewed %d migration file(s) in pull request %s", len(reviewList), pullRequestEvent.ID)}, nil
+}
+
+// findPullRequestFileDatabaseList finds the databases in the project the
+// migration file targets.
+func (s *Server) findPullRequestFileDatabaseList(ctx context.Context, repo *api.Repository, mi *db.MigrationInfo) ([]*api.Database, error) {
+	databaseFind := &api.DatabaseFind{
+		ProjectID: &repo.ProjectID,
+	}
+	// The database name may be omitted in the file path template for the tenant mode project.
+	if mi.Database != "" {
+		databaseFind.Name = &mi.Database
+	}
+	databaseList, err := s.store.FindDatabase(ctx, databaseFind)
+	if err != nil {
+		return nil, err
+	}
+
+	var filteredDatabaseList []*api.Database
+	for _, database := range databaseList {
+		// Environment name comparison is case insensitive
+		if mi.Environment != "" && !strings.EqualFold(database.Instance.Environment.Name, mi.Environment) {
+			continue
+		}
+		filteredDatabaseList = append(filteredDatabaseList, database)
+	}
+	return filteredDatabaseList, nil
+}
+
+// findPullRequestReviewComment returns the latest schema review comment in the
+// comment list of the pull request, or nil if there is none.
+func findPullRequestReviewComment(commentList []*vcs.PullRequestComment) *vcs.PullRequestComment {
+	for i := len(commentList) - 1; i >= 0; i-- {
+		if strings.HasPrefix(commentList[i].Body, pullRequestReviewCommentTitle) {
+			return commentList[i]
+		}
+	}
+	return nil
+}
+
+// composePullRequestReviewComment composes the markdown comment of the schema
+// review results posted to the pull request.
+func composePullRequestReviewComment(reviewList []pullRequestFileReview) string {
+	var errorCount, warningCount int
+	var body strings.Builder
+	for _, review := range reviewList {
+		_, _ = fmt.Fprintf(&body, "\n### `%s`\n\nDatabase `%s` in environment %q\n\n", review.filePath, review.databaseName, review.environmentName)
+		_, _ = body.WriteString("| Status | Code | Title | Content |\n| --- | --- | --- | --- |\n")
+		for _, advice := range review.adviceList {
+			switch advice.Status {
+			case advisor.Error:
+				errorCount++
+			case advisor.Warn:
+				warningCount++
+			}
+			_, _ = fmt.Fprintf(&body, "| %s | %d | %s | %s |\n", advice.Status, advice.Code, escapeMarkdownTableCell(advice.Title), escapeMarkdownTableCell(advice.Content))
+		}
+	}
+
+	var comment strings.Builder
+	_, _ = comment.WriteString(pullRequestReviewCommentTitle + "\n\n")
+	switch {
+	case errorCount > 0:
+		_, _ = fmt.Fprintf(&comment, "Found %d error(s) and %d warning(s) in the migration files.\n", errorCount, warningCount)
+	case warningCount > 0:
+		_, _ = fmt.Fprintf(&comment, "Found %d warning(s) in the migration files.\n", warningCount)
+	default:
+		_, _ = comment.WriteString("All migration files passed the schema review.\n")
+	}
+	_, _ = comment.WriteString(body.String())
+	return comment.String()
+}
+
+// escapeMarkdownTableCell escapes the text to be put in a markdown table cell.
+func escapeMarkdownTableCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
 }
 
 // We are observing the push webhook event so that we will receive the event either when:
@@ -248,7 +1033,7 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 //
 // And both commits would include that added migration file. Since we create an issue per migration file,
 // we need to filter the commit list to prevent creating a duplicated issue. GitLab has a limitation to distinguish
-// whether the commit is a merge commit (https://gitlab.com/gitlab-org/gitlab/-/issues/30914), so we need to dedup
+// whether the commit is a merge commit (https://gitlab.com/gitlab-org/gitlab/-/issues/30914), and GitHub behaves the same way, so we need to dedup
 // ourselves. Below is the filtering algorithm:
 // 1. If we observe the same migration file multiple times, then we should use the latest migration file. This does not matter
 //    for change-based migration since a developer would always create different migration file with incremental names, while it
@@ -256,12 +1041,25 @@ func (s *Server) registerWebhookRoutes(g *echo.Group) {
 // 2. Maintain the relative commit order between different migration files. If migration file A happens before migration file B,
 //    then we should create an issue for migration file A first.
 type distinctFileItem struct {
-	createdTime time.Time
-	commit      gitlab.WebhookCommit
-	fileName    string
+	commit   vcs.Commit
+	fileName string
+}
+
+func dedupMigrationFilesFromCommitList(commitList []vcs.Commit) []distinctFileItem {
+	return dedupFilesFromCommitList(commitList, func(commit vcs.Commit) []string {
+		return commit.AddedList
+	})
+}
+
+// dedupSchemaFilesFromCommitList dedups the added or modified schema files for the SDL schema change type,
+// where the schema file name is always the same and we need to use the latest snapshot.
+func dedupSchemaFilesFromCommitList(commitList []vcs.Commit) []distinctFileItem {
+	return dedupFilesFromCommitList(commitList, func(commit vcs.Commit) []string {
+		return append(append([]string{}, commit.AddedList...), commit.ModifiedList...)
+	})
 }
 
-func dedupMigrationFilesFromCommitList(commitList []gitlab.WebhookCommit) []distinctFileItem {
+func dedupFilesFromCommitList(commitList []vcs.Commit, getFileList func(commit vcs.Commit) []string) []distinctFileItem {
 	// Use list instead of map because we need to maintain the relative commit order in the source branch.
 	var distinctFileList []distinctFileItem
 	for _, commit := range commitList {
@@ -270,23 +1068,17 @@ func dedupMigrationFilesFromCommitList(commitList []gitlab.WebhookCommit) []dist
 			zap.String("title", common.EscapeForLogging(commit.Title)),
 		)
 
-		createdTime, err := time.Parse(time.RFC3339, commit.Timestamp)
-		if err != nil {
-			log.Warn("Ignored commit, failed to parse commit timestamp.", zap.String("commit", common.EscapeForLogging(commit.ID)), zap.String("timestamp", common.EscapeForLogging(commit.Timestamp)), zap.Error(err))
-		}
-
-		for _, added := range commit.AddedList {
+		for _, added := range getFileList(commit) {
 			new := true
 			item := distinctFileItem{
-				createdTime: createdTime,
-				commit:      commit,
-				fileName:    added,
+				commit:   commit,
+				fileName: added,
 			}
 			for i, file := range distinctFileList {
 				// For the migration file with the same name, keep the one from the latest commit
 				if added == file.fileName {
 					new = false
-					if file.createdTime.Before(createdTime) {
+					if file.commit.CreatedTs < commit.CreatedTs {
 						distinctFileList[i] = item
 					}
 					break
@@ -301,7 +1093,136 @@ func dedupMigrationFilesFromCommitList(commitList []gitlab.WebhookCommit) []dist
 	return distinctFileList
 }
 
-func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, commit gitlab.WebhookCommit, added string, statement string) (string, error) {
+func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, added string, statement string) (string, error) {
+	filteredDatabaseList, err := s.findSchemaUpdateDatabaseList(ctx, repository, mi, added)
+	if err != nil {
+		return "", err
+	}
+
+	// Compose the new issue
+	m := &api.UpdateSchemaContext{
+		MigrationType: mi.Type,
+		VCSPushEvent:  &vcsPushEvent,
+	}
+	for _, database := range filteredDatabaseList {
+		m.DetailList = append(m.DetailList,
+			&api.UpdateSchemaDetail{
+				DatabaseID: database.ID,
+				Statement:  statement,
+			})
+	}
+	createContext, err := json.Marshal(m)
+	if err != nil {
+		return "", fmt.Errorf("failed to construct issue create context payload, error %v", err)
+	}
+	return string(createContext), nil
+}
+
+// createSDLSchemaUpdateIssue composes the issue create context for the committed desired schema file of the SDL schema change type.
+// For each matching database, the statement is diffed from the latest schema recorded in the migration history to the committed schema,
+// and it's reviewed against the effective schema review policy of the database.
+// It returns the empty create context if no database needs the schema change, and the markdown review comment if any statement is reviewed.
+func (s *Server) createSDLSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, added string, schema string) (string, string, error) {
+	filteredDatabaseList, err := s.findSchemaUpdateDatabaseList(ctx, repository, mi, added)
+	if err != nil {
+		return "", "", err
+	}
+
+	m := &api.UpdateSchemaContext{
+		MigrationType: mi.Type,
+		VCSPushEvent:  &vcsPushEvent,
+	}
+	var reviewList []pullRequestFileReview
+	for _, database := range filteredDatabaseList {
+		statement, err := s.diffDatabaseLatestSchema(ctx, database, schema)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to diff the schema of database %q, error %v", database.Name, err)
+		}
+		if statement == "" {
+			continue
+		}
+		m.DetailList = append(m.DetailList,
+			&api.UpdateSchemaDetail{
+				DatabaseID: database.ID,
+				Statement:  statement,
+			})
+
+		if !s.feature(api.FeatureSchemaReviewPolicy) || !api.IsSchemaReviewSupported(database.Instance.Engine, s.profile.Mode) {
+			continue
+		}
+		policyID, err := s.store.GetSchemaReviewPolicyIDByDatabase(ctx, database)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get schema review policy for database %q, error %v", database.Name, err)
+		}
+		dbType, err := api.ConvertToAdvisorDBType(database.Instance.Engine)
+		if err != nil {
+			return "", "", err
+		}
+		_, adviceList, err := s.sqlCheck(
+			ctx,
+			dbType,
+			database.CharacterSet,
+			database.Collation,
+			policyID,
+			statement,
+			store.NewCatalog(&database.ID, s.store),
+		)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to check schema review policy for database %q, error %v", database.Name, err)
+		}
+		reviewList = append(reviewList, pullRequestFileReview{
+			filePath:        added,
+			databaseName:    database.Name,
+			environmentName: database.Instance.Environment.Name,
+			adviceList:      adviceList,
+		})
+	}
+	if len(m.DetailList) == 0 {
+		return "", "", nil
+	}
+
+	createContext, err := json.Marshal(m)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to construct issue create context payload, error %v", err)
+	}
+	var reviewComment string
+	if len(reviewList) > 0 {
+		reviewComment = composePullRequestReviewComment(reviewList)
+	}
+	return string(createContext), reviewComment, nil
+}
+
+// diffDatabaseLatestSchema returns the statement to migrate the latest schema of the database recorded in the migration history to the given schema.
+// It returns empty statement if there is no schema change.
+func (s *Server) diffDatabaseLatestSchema(ctx context.Context, database *api.Database, schema string) (string, error) {
+	driver, err := getAdminDatabaseDriver(ctx, database.Instance, database.Name, s.pgInstanceDir)
+	if err != nil {
+		return "", err
+	}
+	defer driver.Close(ctx)
+
+	latestSchema, err := getLatestSchema(ctx, driver, database.Name)
+	if err != nil {
+		return "", err
+	}
+	oldSchema, err := differ.ParseSchema(db.Type(database.Instance.Engine), database.Name, latestSchema)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the latest schema, error %v", err)
+	}
+	newSchema, err := differ.ParseSchema(db.Type(database.Instance.Engine), database.Name, schema)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the committed schema, error %v", err)
+	}
+	statementList, err := differ.SchemaDiff(db.Type(database.Instance.Engine), oldSchema, newSchema)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(statementList, "\n"), nil
+}
+
+// findSchemaUpdateDatabaseList finds the databases in the project the committed file targets,
+// and rejects the ambiguous databases with the same name in an environment.
+func (s *Server) findSchemaUpdateDatabaseList(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, added string) ([]*api.Database, error) {
 	// Find matching database list
 	databaseFind := &api.DatabaseFind{
 		ProjectID: &repository.ProjectID,
@@ -309,9 +1230,9 @@ func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Re
 	}
 	databaseList, err := s.store.FindDatabase(ctx, databaseFind)
 	if err != nil {
-		return "", fmt.Errorf("failed to find database matching database %q referenced by the committed file", mi.Database)
+		return nil, fmt.Errorf("failed to find database matching database %q referenced by the committed file", mi.Database)
 	} else if len(databaseList) == 0 {
-		return "", fmt.Errorf("project with ID %d does not own database %q referenced by the committed file", repository.ProjectID, mi.Database)
+		return nil, fmt.Errorf("project with ID %d does not own database %q referenced by the committed file", repository.ProjectID, mi.Database)
 	}
 
 	// We support 3 patterns on how to organize the schema files.
@@ -335,7 +1256,7 @@ func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Re
 			}
 		}
 		if len(filteredDatabaseList) == 0 {
-			return "", fmt.Errorf("project does not contain committed file database %q for environment %q", mi.Database, mi.Environment)
+			return nil, fmt.Errorf("project does not contain committed file database %q for environment %q", mi.Database, mi.Environment)
 		}
 	} else {
 		filteredDatabaseList = databaseList
@@ -354,29 +1275,12 @@ func (s *Server) createSchemaUpdateIssue(ctx context.Context, repository *api.Re
 		}
 	}
 	if len(multipleDatabaseForSameEnv) > 0 {
-		return "", fmt.Errorf("ignored committed files with multiple ambiguous databases %s", strings.Join(multipleDatabaseForSameEnv, ", "))
-	}
-
-	// Compose the new issue
-	m := &api.UpdateSchemaContext{
-		MigrationType: mi.Type,
-		VCSPushEvent:  &vcsPushEvent,
+		return nil, fmt.Errorf("ignored committed files with multiple ambiguous databases %s", strings.Join(multipleDatabaseForSameEnv, ", "))
 	}
-	for _, database := range filteredDatabaseList {
-		m.DetailList = append(m.DetailList,
-			&api.UpdateSchemaDetail{
-				DatabaseID: database.ID,
-				Statement:  statement,
-			})
-	}
-	createContext, err := json.Marshal(m)
-	if err != nil {
-		return "", fmt.Errorf("failed to construct issue create context payload, error %v", err)
-	}
-	return string(createContext), nil
+	return filteredDatabaseList, nil
 }
 
-func (s *Server) createTenantSchemaUpdateIssue(ctx context.Context, repository *api.Repository, mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, commit gitlab.WebhookCommit, added string, statement string) (string, error) {
+func (s *Server) createTenantSchemaUpdateIssue(mi *db.MigrationInfo, vcsPushEvent vcs.PushEvent, statement string) (string, error) {
 	// We don't take environment for tenant mode project because the databases needing schema update are determined by database name and deployment configuration.
 	if mi.Environment != "" {
 		return "", fmt.Errorf("environment isn't accepted in schema update for tenant mode project")
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/log"
+	vcsPlugin "github.com/youzi-1122/bytebase/plugin/vcs"
+	"github.com/youzi-1122/bytebase/plugin/vcs/bitbucket"
+	"github.com/youzi-1122/bytebase/plugin/vcs/gitea"
+	"github.com/youzi-1122/bytebase/plugin/vcs/github"
+	"github.com/youzi-1122/bytebase/plugin/vcs/gitlab"
+	"go.uber.org/zap"
+)
+
+// subscribeWebhookPullRequestEvents patches the webhooks of the linked repositories to subscribe to the pull request
+// events along with the push events. The webhooks of the repositories linked before the pull request review was
+// introduced only subscribe to the push events. It runs once as a migration, and is marked done in a setting, so the
+// webhooks at the VCS providers are not overwritten on every start. The repositories failed to patch should be
+// re-linked, which creates the webhooks subscribing to the pull request events.
+func (s *Server) subscribeWebhookPullRequestEvents(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	name := api.SettingWebhookPullRequestEventsSubscribed
+	settingList, err := s.store.FindSetting(ctx, &api.SettingFind{Name: &name})
+	if err != nil {
+		log.Error("Failed to find setting", zap.String("setting", string(name)), zap.Error(err))
+		return
+	}
+	if len(settingList) > 0 {
+		return
+	}
+
+	repoList, err := s.store.FindRepository(ctx, &api.RepositoryFind{})
+	if err != nil {
+		log.Error("Failed to find repositories to subscribe the webhook pull request events", zap.Error(err))
+		return
+	}
+	for _, repo := range repoList {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := s.subscribeRepositoryWebhookPullRequestEvents(ctx, repo); err != nil {
+			log.Warn("Failed to subscribe the webhook pull request events, re-link the repository to receive the pull request events",
+				zap.String("project", repo.Project.Name),
+				zap.String("repository", repo.FullPath),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if _, err := s.store.CreateSettingIfNotExist(ctx, &api.SettingCreate{
+		CreatorID:   api.SystemBotID,
+		Name:        name,
+		Value:       "true",
+		Description: "The webhooks of the linked repositories are subscribed to the pull request events.",
+	}); err != nil {
+		log.Error("Failed to create setting", zap.String("setting", string(name)), zap.Error(err))
+	}
+}
+
+// subscribeRepositoryWebhookPullRequestEvents patches the webhook of the repository to subscribe to the push and the
+// pull request events.
+func (s *Server) subscribeRepositoryWebhookPullRequestEvents(ctx context.Context, repo *api.Repository) error {
+	var webhookPatchPayload []byte
+	var err error
+	switch repo.VCS.Type {
+	case vcsPlugin.GitLabSelfHost:
+		webhookPut := gitlab.WebhookPut{
+			URL:                    fmt.Sprintf("%s/%s/%s", repo.WebhookURLHost, gitLabWebhookPath, repo.WebhookEndpointID),
+			MergeRequestsEvents:    true,
+			PushEventsBranchFilter: repo.BranchFilter,
+		}
+		webhookPatchPayload, err = json.Marshal(webhookPut)
+	case vcsPlugin.GitHubCom:
+		webhookPatch := github.WebhookCreateOrUpdate{
+			Config: github.WebhookConfig{
+				URL:         fmt.Sprintf("%s/%s/%s", repo.WebhookURLHost, gitHubWebhookPath, repo.WebhookEndpointID),
+				ContentType: "json",
+				Secret:      repo.WebhookSecretToken,
+				InsecureSSL: "0",
+			},
+			Events: []string{string(github.WebhookPush), string(github.WebhookPullRequest)},
+			Active: true,
+		}
+		webhookPatchPayload, err = json.Marshal(webhookPatch)
+	case vcsPlugin.GiteaSelfHost:
+		webhookPatch := gitea.WebhookPatch{
+			Config: gitea.WebhookConfig{
+				URL:         fmt.Sprintf("%s/%s/%s", repo.WebhookURLHost, giteaWebhookPath, repo.WebhookEndpointID),
+				ContentType: "json",
+				Secret:      repo.WebhookSecretToken,
+			},
+			Events:       []string{string(gitea.WebhookPush), string(gitea.WebhookPullRequest)},
+			BranchFilter: repo.BranchFilter,
+		}
+		webhookPatchPayload, err = json.Marshal(webhookPatch)
+	case vcsPlugin.BitbucketOrg:
+		webhookPut := bitbucket.WebhookCreateOrUpdate{
+			Description: "Bytebase GitOps",
+			URL:         fmt.Sprintf("%s/%s/%s", repo.WebhookURLHost, bitbucketWebhookPath, repo.WebhookEndpointID),
+			Active:      true,
+			Secret:      repo.WebhookSecretToken,
+			Events:      []string{string(bitbucket.WebhookPush), string(bitbucket.WebhookPullRequestCreated), string(bitbucket.WebhookPullRequestUpdated)},
+		}
+		webhookPatchPayload, err = json.Marshal(webhookPut)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal request for patching webhook %s, error: %w", repo.ExternalWebhookID, err)
+	}
+
+	return vcsPlugin.Get(repo.VCS.Type, vcsPlugin.ProviderConfig{}).PatchWebhook(
+		ctx,
+		common.OauthContext{
+			ClientID:     repo.VCS.ApplicationID,
+			ClientSecret: repo.VCS.Secret,
+			AccessToken:  repo.AccessToken,
+			RefreshToken: repo.RefreshToken,
+			Refresher:    s.refreshToken(ctx, repo.ID),
+		},
+		repo.VCS.InstanceURL,
+		repo.ExternalID,
+		repo.ExternalWebhookID,
+		webhookPatchPayload,
+	)
+}
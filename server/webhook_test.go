@@ -1,11 +1,16 @@
 package server
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/youzi-1122/bytebase/plugin/vcs/gitlab"
 	"github.com/stretchr/testify/assert"
+	"github.com/youzi-1122/bytebase/plugin/advisor"
+	"github.com/youzi-1122/bytebase/plugin/vcs"
 )
 
 func TestDedupMigrationFiles(t *testing.T) {
@@ -18,26 +23,24 @@ func TestDedupMigrationFiles(t *testing.T) {
 
 	tests := []struct {
 		name       string
-		commitList []gitlab.WebhookCommit
+		commitList []vcs.Commit
 		want       []distinctFileItem
 	}{
 		{
 			name:       "Empty",
-			commitList: []gitlab.WebhookCommit{},
+			commitList: []vcs.Commit{},
 			want:       nil,
 		},
 		{
 			name: "Single commit, single file",
-			commitList: []gitlab.WebhookCommit{
+			commitList: []vcs.Commit{
 				{
-					ID:        "1",
-					Title:     "Commit 1",
-					Message:   "Update 1",
-					Timestamp: timestamp1,
-					URL:       "example.com",
-					Author: gitlab.WebhookCommitAuthor{
-						Name: "bob",
-					},
+					ID:         "1",
+					Title:      "Commit 1",
+					Message:    "Update 1",
+					CreatedTs:  time1.Unix(),
+					URL:        "example.com",
+					AuthorName: "bob",
 					AddedList: []string{
 						"v1.sql",
 					},
@@ -45,16 +48,13 @@ func TestDedupMigrationFiles(t *testing.T) {
 			},
 			want: []distinctFileItem{
 				{
-					createdTime: time1,
-					commit: gitlab.WebhookCommit{
-						ID:        "1",
-						Title:     "Commit 1",
-						Message:   "Update 1",
-						Timestamp: timestamp1,
-						URL:       "example.com",
-						Author: gitlab.WebhookCommitAuthor{
-							Name: "bob",
-						},
+					commit: vcs.Commit{
+						ID:         "1",
+						Title:      "Commit 1",
+						Message:    "Update 1",
+						CreatedTs:  time1.Unix(),
+						URL:        "example.com",
+						AuthorName: "bob",
 						AddedList: []string{
 							"v1.sql",
 						},
@@ -65,16 +65,14 @@ func TestDedupMigrationFiles(t *testing.T) {
 		},
 		{
 			name: "Single commit, multiple files",
-			commitList: []gitlab.WebhookCommit{
+			commitList: []vcs.Commit{
 				{
-					ID:        "1",
-					Title:     "Commit 1",
-					Message:   "Update 1",
-					Timestamp: timestamp1,
-					URL:       "example.com",
-					Author: gitlab.WebhookCommitAuthor{
-						Name: "bob",
-					},
+					ID:         "1",
+					Title:      "Commit 1",
+					Message:    "Update 1",
+					CreatedTs:  time1.Unix(),
+					URL:        "example.com",
+					AuthorName: "bob",
 					AddedList: []string{
 						"v1.sql",
 						"v2.sql",
@@ -83,16 +81,13 @@ func TestDedupMigrationFiles(t *testing.T) {
 			},
 			want: []distinctFileItem{
 				{
-					createdTime: time1,
-					commit: gitlab.WebhookCommit{
-						ID:        "1",
-						Title:     "Commit 1",
-						Message:   "Update 1",
-						Timestamp: timestamp1,
-						URL:       "example.com",
-						Author: gitlab.WebhookCommitAuthor{
-							Name: "bob",
-						},
+					commit: vcs.Commit{
+						ID:         "1",
+						Title:      "Commit 1",
+						Message:    "Update 1",
+						CreatedTs:  time1.Unix(),
+						URL:        "example.com",
+						AuthorName: "bob",
 						AddedList: []string{
 							"v1.sql",
 							"v2.sql",
@@ -101,16 +96,13 @@ func TestDedupMigrationFiles(t *testing.T) {
 					fileName: "v1.sql",
 				},
 				{
-					createdTime: time1,
-					commit: gitlab.WebhookCommit{
-						ID:        "1",
-						Title:     "Commit 1",
-						Message:   "Update 1",
-						Timestamp: timestamp1,
-						URL:       "example.com",
-						Author: gitlab.WebhookCommitAuthor{
-							Name: "bob",
-						},
+					commit: vcs.Commit{
+						ID:         "1",
+						Title:      "Commit 1",
+						Message:    "Update 1",
+						CreatedTs:  time1.Unix(),
+						URL:        "example.com",
+						AuthorName: "bob",
 						AddedList: []string{
 							"v1.sql",
 							"v2.sql",
@@ -122,29 +114,25 @@ func TestDedupMigrationFiles(t *testing.T) {
 		},
 		{
 			name: "Multi commits, single file",
-			commitList: []gitlab.WebhookCommit{
+			commitList: []vcs.Commit{
 				{
-					ID:        "1",
-					Title:     "Commit 1",
-					Message:   "Update 1",
-					Timestamp: timestamp1,
-					URL:       "example.com",
-					Author: gitlab.WebhookCommitAuthor{
-						Name: "bob",
-					},
+					ID:         "1",
+					Title:      "Commit 1",
+					Message:    "Update 1",
+					CreatedTs:  time1.Unix(),
+					URL:        "example.com",
+					AuthorName: "bob",
 					AddedList: []string{
 						"v1.sql",
 					},
 				},
 				{
-					ID:        "2",
-					Title:     "Merge branch",
-					Message:   "Merge update",
-					Timestamp: timestamp2,
-					URL:       "example.com",
-					Author: gitlab.WebhookCommitAuthor{
-						Name: "bob",
-					},
+					ID:         "2",
+					Title:      "Merge branch",
+					Message:    "Merge update",
+					CreatedTs:  time2.Unix(),
+					URL:        "example.com",
+					AuthorName: "bob",
 					AddedList: []string{
 						"v1.sql",
 					},
@@ -152,16 +140,13 @@ func TestDedupMigrationFiles(t *testing.T) {
 			},
 			want: []distinctFileItem{
 				{
-					createdTime: time2,
-					commit: gitlab.WebhookCommit{
-						ID:        "2",
-						Title:     "Merge branch",
-						Message:   "Merge update",
-						Timestamp: timestamp2,
-						URL:       "example.com",
-						Author: gitlab.WebhookCommitAuthor{
-							Name: "bob",
-						},
+					commit: vcs.Commit{
+						ID:         "2",
+						Title:      "Merge branch",
+						Message:    "Merge update",
+						CreatedTs:  time2.Unix(),
+						URL:        "example.com",
+						AuthorName: "bob",
 						AddedList: []string{
 							"v1.sql",
 						},
@@ -172,43 +157,37 @@ func TestDedupMigrationFiles(t *testing.T) {
 		},
 		{
 			name: "Multi commits, multi files",
-			commitList: []gitlab.WebhookCommit{
+			commitList: []vcs.Commit{
 				{
-					ID:        "1",
-					Title:     "Commit 1",
-					Message:   "Update 1",
-					Timestamp: timestamp1,
-					URL:       "example.com",
-					Author: gitlab.WebhookCommitAuthor{
-						Name: "bob",
-					},
+					ID:         "1",
+					Title:      "Commit 1",
+					Message:    "Update 1",
+					CreatedTs:  time1.Unix(),
+					URL:        "example.com",
+					AuthorName: "bob",
 					AddedList: []string{
 						"v1.sql",
 						"v2.sql",
 					},
 				},
 				{
-					ID:        "2",
-					Title:     "Commit 2",
-					Message:   "Update 2",
-					Timestamp: timestamp1,
-					URL:       "example.com",
-					Author: gitlab.WebhookCommitAuthor{
-						Name: "bob",
-					},
+					ID:         "2",
+					Title:      "Commit 2",
+					Message:    "Update 2",
+					CreatedTs:  time1.Unix(),
+					URL:        "example.com",
+					AuthorName: "bob",
 					AddedList: []string{
 						"v3.sql",
 					},
 				},
 				{
-					ID:        "3",
-					Title:     "Merge branch",
-					Message:   "Merge update",
-					Timestamp: timestamp3,
-					URL:       "example.com",
-					Author: gitlab.WebhookCommitAuthor{
-						Name: "bob",
-					},
+					ID:         "3",
+					Title:      "Merge branch",
+					Message:    "Merge update",
+					CreatedTs:  time3.Unix(),
+					URL:        "example.com",
+					AuthorName: "bob",
 					AddedList: []string{
 						"v1.sql",
 						"v2.sql",
@@ -218,16 +197,13 @@ func TestDedupMigrationFiles(t *testing.T) {
 			},
 			want: []distinctFileItem{
 				{
-					createdTime: time3,
-					commit: gitlab.WebhookCommit{
-						ID:        "3",
-						Title:     "Merge branch",
-						Message:   "Merge update",
-						Timestamp: timestamp3,
-						URL:       "example.com",
-						Author: gitlab.WebhookCommitAuthor{
-							Name: "bob",
-						},
+					commit: vcs.Commit{
+						ID:         "3",
+						Title:      "Merge branch",
+						Message:    "Merge update",
+						CreatedTs:  time3.Unix(),
+						URL:        "example.com",
+						AuthorName: "bob",
 						AddedList: []string{
 							"v1.sql",
 							"v2.sql",
@@ -237,16 +213,13 @@ func TestDedupMigrationFiles(t *testing.T) {
 					fileName: "v1.sql",
 				},
 				{
-					createdTime: time3,
-					commit: gitlab.WebhookCommit{
-						ID:        "3",
-						Title:     "Merge branch",
-						Message:   "Merge update",
-						Timestamp: timestamp3,
-						URL:       "example.com",
-						Author: gitlab.WebhookCommitAuthor{
-							Name: "bob",
-						},
+					commit: vcs.Commit{
+						ID:         "3",
+						Title:      "Merge branch",
+						Message:    "Merge update",
+						CreatedTs:  time3.Unix(),
+						URL:        "example.com",
+						AuthorName: "bob",
 						AddedList: []string{
 							"v1.sql",
 							"v2.sql",
@@ -256,16 +229,13 @@ func TestDedupMigrationFiles(t *testing.T) {
 					fileName: "v2.sql",
 				},
 				{
-					createdTime: time3,
-					commit: gitlab.WebhookCommit{
-						ID:        "3",
-						Title:     "Merge branch",
-						Message:   "Merge update",
-						Timestamp: timestamp3,
-						URL:       "example.com",
-						Author: gitlab.WebhookCommitAuthor{
-							Name: "bob",
-						},
+					commit: vcs.Commit{
+						ID:         "3",
+						Title:      "Merge branch",
+						Message:    "Merge update",
+						CreatedTs:  time3.Unix(),
+						URL:        "example.com",
+						AuthorName: "bob",
 						AddedList: []string{
 							"v1.sql",
 							"v2.sql",
@@ -284,3 +254,203 @@ func TestDedupMigrationFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestDedupSchemaFiles(t *testing.T) {
+	commit1 := vcs.Commit{
+		ID:        "1",
+		CreatedTs: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC).Unix(),
+		AddedList: []string{".db1__LATEST.sql"},
+	}
+	commit2 := vcs.Commit{
+		ID:           "2",
+		CreatedTs:    time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC).Unix(),
+		AddedList:    []string{".db2__LATEST.sql"},
+		ModifiedList: []string{".db1__LATEST.sql"},
+	}
+	got := dedupSchemaFilesFromCommitList([]vcs.Commit{commit1, commit2})
+	assert.Equal(t, []distinctFileItem{
+		{commit: commit2, fileName: ".db1__LATEST.sql"},
+		{commit: commit2, fileName: ".db2__LATEST.sql"},
+	}, got)
+}
+
+func TestValidateGitHubWebhookSignature256(t *testing.T) {
+	const (
+		key  = "secret"
+		body = `{"ref":"refs/heads/main"}`
+	)
+	m := hmac.New(sha256.New, []byte(key))
+	_, _ = m.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(m.Sum(nil))
+
+	tests := []struct {
+		name      string
+		signature string
+		key       string
+		body      string
+		want      bool
+	}{
+		{
+			name:      "Matched",
+			signature: signature,
+			key:       key,
+			body:      body,
+			want:      true,
+		},
+		{
+			name:      "Key mismatch",
+			signature: signature,
+			key:       "another secret",
+			body:      body,
+			want:      false,
+		},
+		{
+			name:      "Body tampered",
+			signature: signature,
+			key:       key,
+			body:      `{"ref":"refs/heads/feature"}`,
+			want:      false,
+		},
+		{
+			name:      "Missing prefix",
+			signature: strings.TrimPrefix(signature, "sha256="),
+			key:       key,
+			body:      body,
+			want:      false,
+		},
+		{
+			name:      "Empty signature",
+			signature: "",
+			key:       key,
+			body:      body,
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateGitHubWebhookSignature256(tt.signature, tt.key, []byte(tt.body))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIsBranchFilterMatched(t *testing.T) {
+	tests := []struct {
+		branchFilter string
+		ref          string
+		want         bool
+	}{
+		{
+			branchFilter: "",
+			ref:          "refs/heads/main",
+			want:         true,
+		},
+		{
+			branchFilter: "main",
+			ref:          "refs/heads/main",
+			want:         true,
+		},
+		{
+			branchFilter: "main",
+			ref:          "refs/heads/feature",
+			want:         false,
+		},
+		{
+			branchFilter: "release/*",
+			ref:          "refs/heads/release/1.0",
+			want:         true,
+		},
+		{
+			branchFilter: "release/*",
+			ref:          "refs/heads/main",
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		got := isBranchFilterMatched(tt.branchFilter, tt.ref)
+		assert.Equal(t, tt.want, got, "branch filter %q, ref %q", tt.branchFilter, tt.ref)
+	}
+}
+
+func TestComposePullRequestReviewComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		reviewList []pullRequestFileReview
+		want       string
+	}{
+		{
+			name: "passed",
+			reviewList: []pullRequestFileReview{
+				{
+					filePath:        "bytebase/prod/db1__1__migrate__init.sql",
+					databaseName:    "db1",
+					environmentName: "Prod",
+					adviceList: []advisor.Advice{
+						{Status: advisor.Success, Code: advisor.Ok, Title: "OK"},
+					},
+				},
+			},
+			want: "## Bytebase SQL Review\n\n" +
+				"All migration files passed the schema review.\n" +
+				"\n### `bytebase/prod/db1__1__migrate__init.sql`\n\nDatabase `db1` in environment \"Prod\"\n\n" +
+				"| Status | Code | Title | Content |\n| --- | --- | --- | --- |\n" +
+				"| SUCCESS | 0 | OK |  |\n",
+		},
+		{
+			name: "error and warning",
+			reviewList: []pullRequestFileReview{
+				{
+					filePath:        "bytebase/prod/db1__2__migrate__add_table.sql",
+					databaseName:    "db1",
+					environmentName: "Prod",
+					adviceList: []advisor.Advice{
+						{Status: advisor.Error, Code: advisor.TableNoPK, Title: "Require PK", Content: "Table `t` requires PRIMARY KEY"},
+						{Status: advisor.Warn, Code: advisor.NamingTableConventionMismatch, Title: "Mismatch | table naming", Content: "line 1\nline 2"},
+					},
+				},
+			},
+			want: "## Bytebase SQL Review\n\n" +
+				"Found 1 error(s) and 1 warning(s) in the migration files.\n" +
+				"\n### `bytebase/prod/db1__2__migrate__add_table.sql`\n\nDatabase `db1` in environment \"Prod\"\n\n" +
+				"| Status | Code | Title | Content |\n| --- | --- | --- | --- |\n" +
+				"| ERROR | 601 | Require PK | Table `t` requires PRIMARY KEY |\n" +
+				"| WARN | 301 | Mismatch \\| table naming | line 1<br>line 2 |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, composePullRequestReviewComment(tt.reviewList))
+		})
+	}
+}
+
+func TestFindPullRequestReviewComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		commentList []*vcs.PullRequestComment
+		want        *vcs.PullRequestComment
+	}{
+		{
+			name: "no review comment",
+			commentList: []*vcs.PullRequestComment{
+				{ID: "1", Body: "LGTM"},
+			},
+			want: nil,
+		},
+		{
+			name: "latest review comment",
+			commentList: []*vcs.PullRequestComment{
+				{ID: "1", Body: "## Bytebase SQL Review\n\nAll migration files passed the schema review.\n"},
+				{ID: "2", Body: "LGTM"},
+				{ID: "3", Body: "## Bytebase SQL Review\n\nFound 1 warning(s) in the migration files.\n"},
+				{ID: "4", Body: "> ## Bytebase SQL Review\n\nWhy?"},
+			},
+			want: &vcs.PullRequestComment{ID: "3", Body: "## Bytebase SQL Review\n\nFound 1 warning(s) in the migration files.\n"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, findPullRequestReviewComment(tt.commentList))
+		})
+	}
+}
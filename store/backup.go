@@ -6,9 +6,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/youzi-1122/bytebase/api"
 	"github.com/youzi-1122/bytebase/common"
+	"github.com/youzi-1122/bytebase/common/cron"
+	"github.com/youzi-1122/bytebase/common/log"
+	"go.uber.org/zap"
 )
 
 // backupRaw is the store model for an Backup.
@@ -83,8 +87,12 @@ type backupSettingRaw struct {
 	Enabled   bool
 	Hour      int
 	DayOfWeek int
+	Schedule  string
 	// HookURL is the callback url to be requested (using HTTP GET) after a successful backup.
-	HookURL string
+	HookURL            string
+	RetentionPolicy    string
+	VerificationPolicy string
+	PITRPolicy         string
 }
 
 // toBackupSetting creates an instance of BackupSetting based on the backupSettingRaw.
@@ -106,8 +114,12 @@ func (raw *backupSettingRaw) toBackupSetting() *api.BackupSetting {
 		Enabled:   raw.Enabled,
 		Hour:      raw.Hour,
 		DayOfWeek: raw.DayOfWeek,
+		Schedule:  raw.Schedule,
 		// HookURL is the callback url to be requested (using HTTP GET) after a successful backup.
-		HookURL: raw.HookURL,
+		HookURL:            raw.HookURL,
+		RetentionPolicy:    raw.RetentionPolicy,
+		VerificationPolicy: raw.VerificationPolicy,
+		PITRPolicy:         raw.PITRPolicy,
 	}
 }
 
@@ -170,6 +182,25 @@ func (s *Store) PatchBackup(ctx context.Context, patch *api.BackupPatch) (*api.B
 	return backup, nil
 }
 
+// DeleteBackup deletes an existing backup by ID.
+func (s *Store) DeleteBackup(ctx context.Context, delete *api.BackupDelete) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	if _, err := tx.PTx.ExecContext(ctx, `DELETE FROM backup WHERE id = $1`, delete.ID); err != nil {
+		return FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
 // GetBackupSettingByDatabaseID gets an instance of BackupSetting by ID
 func (s *Store) GetBackupSettingByDatabaseID(ctx context.Context, id int) (*api.BackupSetting, error) {
 	backupSettingRaw, err := s.getBackupSettingRaw(ctx, &api.BackupSettingFind{DatabaseID: &id})
@@ -199,6 +230,23 @@ func (s *Store) UpsertBackupSetting(ctx context.Context, upsert *api.BackupSetti
 	return backup, nil
 }
 
+// FindBackupSetting finds a list of backup setting instances.
+func (s *Store) FindBackupSetting(ctx context.Context, find *api.BackupSettingFind) ([]*api.BackupSetting, error) {
+	backupSettingRawList, err := s.findBackupSettingRaw(ctx, find)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find backup setting list with BackupSettingFind[%+v], error: %w", find, err)
+	}
+	var backupSettingList []*api.BackupSetting
+	for _, raw := range backupSettingRawList {
+		backupSetting, err := s.composeBackupSetting(ctx, raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose BackupSetting with backupSettingRaw[%+v], error: %w", raw, err)
+		}
+		backupSettingList = append(backupSettingList, backupSetting)
+	}
+	return backupSettingList, nil
+}
+
 // FindBackupSettingsMatch finds a list of backup setting instances with match conditions
 func (s *Store) FindBackupSettingsMatch(ctx context.Context, match *api.BackupSettingsMatch) ([]*api.BackupSetting, error) {
 	backupSettingRawList, err := s.findBackupSettingsMatchImpl(ctx, match)
@@ -349,20 +397,58 @@ func (s *Store) upsertBackupSettingRaw(ctx context.Context, upsert *api.BackupSe
 	if err != nil {
 		return nil, err
 	}
+	if upsert.Schedule != "" {
+		if _, err := api.ParseBackupSchedule(upsert.Schedule); err != nil {
+			return nil, &common.Error{Code: common.Invalid, Err: fmt.Errorf("invalid backup setting schedule, error: %w", err)}
+		}
+	}
 	// Backup plan policy check for backup setting mutation.
 	if backupPlanPolicy.Schedule != api.BackupPlanPolicyScheduleUnset {
 		if !upsert.Enabled {
 			return nil, &common.Error{Code: common.Invalid, Err: fmt.Errorf("backup setting should not be disabled for backup plan policy schedule %q", backupPlanPolicy.Schedule)}
 		}
-		switch backupPlanPolicy.Schedule {
-		case api.BackupPlanPolicyScheduleDaily:
+		switch {
+		case upsert.Schedule == "" && backupPlanPolicy.Schedule == api.BackupPlanPolicyScheduleDaily:
 			if upsert.DayOfWeek != -1 {
 				return nil, &common.Error{Code: common.Invalid, Err: fmt.Errorf("backup setting DayOfWeek should be unset for backup plan policy schedule %q", backupPlanPolicy.Schedule)}
 			}
-		case api.BackupPlanPolicyScheduleWeekly:
+		case upsert.Schedule == "" && backupPlanPolicy.Schedule == api.BackupPlanPolicyScheduleWeekly:
 			if upsert.DayOfWeek == -1 {
 				return nil, &common.Error{Code: common.Invalid, Err: fmt.Errorf("backup setting DayOfWeek should be set for backup plan policy schedule %q", backupPlanPolicy.Schedule)}
 			}
+		default:
+			// The cron expression schedule should take the backups at least as frequently as the policy.
+			satisfied, err := api.IsBackupScheduleSatisfied(upsert.GetCronExpression(), backupPlanPolicy.GetCronExpression(), time.Now())
+			if err != nil {
+				return nil, &common.Error{Code: common.Invalid, Err: err}
+			}
+			if !satisfied {
+				return nil, &common.Error{Code: common.Invalid, Err: fmt.Errorf("backup setting schedule %q is less frequent than backup plan policy schedule %q", upsert.GetCronExpression(), backupPlanPolicy.GetCronExpression())}
+			}
+		}
+	}
+
+	if upsert.RetentionPolicy != nil {
+		retentionPolicy, err := api.UnmarshalBackupRetentionPolicy(*upsert.RetentionPolicy)
+		if err != nil {
+			return nil, &common.Error{Code: common.Invalid, Err: err}
+		}
+		if err := retentionPolicy.Validate(); err != nil {
+			return nil, &common.Error{Code: common.Invalid, Err: err}
+		}
+	}
+	if upsert.VerificationPolicy != nil {
+		verificationPolicy, err := api.UnmarshalBackupVerificationPolicy(*upsert.VerificationPolicy)
+		if err != nil {
+			return nil, &common.Error{Code: common.Invalid, Err: err}
+		}
+		if err := verificationPolicy.Validate(); err != nil {
+			return nil, &common.Error{Code: common.Invalid, Err: err}
+		}
+	}
+	if upsert.PITRPolicy != nil {
+		if _, err := api.UnmarshalBackupPITRPolicy(*upsert.PITRPolicy); err != nil {
+			return nil, &common.Error{Code: common.Invalid, Err: err}
 		}
 	}
 
@@ -585,6 +671,17 @@ func (s *Store) getBackupSettingRaw(ctx context.Context, find *api.BackupSetting
 	return list[0], nil
 }
 
+// findBackupSettingRaw retrieves a list of backup settings based on find.
+func (s *Store) findBackupSettingRaw(ctx context.Context, find *api.BackupSettingFind) ([]*backupSettingRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	return s.findBackupSettingImpl(ctx, tx.PTx, find)
+}
+
 func (s *Store) findBackupSettingImpl(ctx context.Context, tx *sql.Tx, find *api.BackupSettingFind) ([]*backupSettingRaw, error) {
 	// Build WHERE clause.
 	where, args := []string{"1 = 1"}, []interface{}{}
@@ -606,7 +703,11 @@ func (s *Store) findBackupSettingImpl(ctx context.Context, tx *sql.Tx, find *api
 			enabled,
 			hour,
 			day_of_week,
-			hook_url
+			schedule,
+			hook_url,
+			retention_policy,
+			verification_policy,
+			pitr_policy
 		FROM backup_setting
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -630,7 +731,11 @@ func (s *Store) findBackupSettingImpl(ctx context.Context, tx *sql.Tx, find *api
 			&backupSettingRaw.Enabled,
 			&backupSettingRaw.Hour,
 			&backupSettingRaw.DayOfWeek,
+			&backupSettingRaw.Schedule,
 			&backupSettingRaw.HookURL,
+			&backupSettingRaw.RetentionPolicy,
+			&backupSettingRaw.VerificationPolicy,
+			&backupSettingRaw.PITRPolicy,
 		); err != nil {
 			return nil, FormatError(err)
 		}
@@ -655,15 +760,23 @@ func (s *Store) upsertBackupSettingImpl(ctx context.Context, tx *sql.Tx, upsert
 			enabled,
 			hour,
 			day_of_week,
-			hook_url
+			schedule,
+			hook_url,
+			retention_policy,
+			verification_policy,
+			pitr_policy
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::JSONB, '{}'), COALESCE($10::JSONB, '{}'), COALESCE($11::JSONB, '{}'))
 		ON CONFLICT(database_id) DO UPDATE SET
 				enabled = EXCLUDED.enabled,
 				hour = EXCLUDED.hour,
 				day_of_week = EXCLUDED.day_of_week,
-				hook_url = EXCLUDED.hook_url
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, enabled, hour, day_of_week, hook_url
+				schedule = EXCLUDED.schedule,
+				hook_url = EXCLUDED.hook_url,
+				retention_policy = COALESCE($9::JSONB, backup_setting.retention_policy),
+				verification_policy = COALESCE($10::JSONB, backup_setting.verification_policy),
+				pitr_policy = COALESCE($11::JSONB, backup_setting.pitr_policy)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, enabled, hour, day_of_week, schedule, hook_url, retention_policy, verification_policy, pitr_policy
 	`
 	var backupSettingRaw backupSettingRaw
 	if err := tx.QueryRowContext(ctx, query,
@@ -673,7 +786,11 @@ func (s *Store) upsertBackupSettingImpl(ctx context.Context, tx *sql.Tx, upsert
 		upsert.Enabled,
 		upsert.Hour,
 		upsert.DayOfWeek,
+		upsert.Schedule,
 		upsert.HookURL,
+		upsert.RetentionPolicy,
+		upsert.VerificationPolicy,
+		upsert.PITRPolicy,
 	).Scan(
 		&backupSettingRaw.ID,
 		&backupSettingRaw.CreatorID,
@@ -684,7 +801,11 @@ func (s *Store) upsertBackupSettingImpl(ctx context.Context, tx *sql.Tx, upsert
 		&backupSettingRaw.Enabled,
 		&backupSettingRaw.Hour,
 		&backupSettingRaw.DayOfWeek,
+		&backupSettingRaw.Schedule,
 		&backupSettingRaw.HookURL,
+		&backupSettingRaw.RetentionPolicy,
+		&backupSettingRaw.VerificationPolicy,
+		&backupSettingRaw.PITRPolicy,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -713,19 +834,14 @@ func (s *Store) findBackupSettingsMatchImpl(ctx context.Context, match *api.Back
 			enabled,
 			hour,
 			day_of_week,
-			hook_url
+			schedule,
+			hook_url,
+			retention_policy,
+			verification_policy,
+			pitr_policy
 		FROM backup_setting
-		WHERE
-			enabled = true
-			AND (
-				(hour = $1 AND day_of_week = $2)
-				OR
-				(hour = $3 AND day_of_week = -1)
-				OR
-				(hour = -1 AND day_of_week = $4)
-			)
+		WHERE enabled = true
 		`,
-		match.Hour, match.DayOfWeek, match.Hour, match.DayOfWeek,
 	)
 	if err != nil {
 		return nil, FormatError(err)
@@ -746,11 +862,27 @@ func (s *Store) findBackupSettingsMatchImpl(ctx context.Context, match *api.Back
 			&backupSettingRaw.Enabled,
 			&backupSettingRaw.Hour,
 			&backupSettingRaw.DayOfWeek,
+			&backupSettingRaw.Schedule,
 			&backupSettingRaw.HookURL,
+			&backupSettingRaw.RetentionPolicy,
+			&backupSettingRaw.VerificationPolicy,
+			&backupSettingRaw.PITRPolicy,
 		); err != nil {
 			return nil, FormatError(err)
 		}
 
+		// The cron expressions are matched here as they can't be evaluated by the database.
+		schedule, err := api.ParseBackupSchedule(backupSettingRaw.toBackupSetting().GetCronExpression())
+		if err != nil {
+			log.Warn("Skip backup setting with invalid schedule",
+				zap.Int("databaseID", backupSettingRaw.DatabaseID),
+				zap.String("schedule", backupSettingRaw.Schedule),
+				zap.Error(err))
+			continue
+		}
+		if cron.Last(schedule, time.Unix(match.StartTs, 0).UTC(), time.Unix(match.EndTs, 0).UTC()).IsZero() {
+			continue
+		}
 		backupSettingRawList = append(backupSettingRawList, &backupSettingRaw)
 	}
 	if err := rows.Err(); err != nil {
@@ -2,17 +2,19 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"github.com/youzi-1122/bytebase/api"
 	"github.com/youzi-1122/bytebase/plugin/advisor/catalog"
+	"github.com/youzi-1122/bytebase/plugin/db"
 )
 
 var (
 	_ catalog.Catalog = (*Catalog)(nil)
 )
 
-// Catalog is the database catalog.
+// Catalog is the database catalog backed by the synced schema.
 type Catalog struct {
 	databaseID *int
 	store      *Store
@@ -26,8 +28,8 @@ func NewCatalog(databaseID *int, store *Store) *Catalog {
 	}
 }
 
-// FindIndex finds the index by IndexFind. Implement the catalog.Catalog interface.
-func (c *Catalog) FindIndex(ctx context.Context, find *catalog.IndexFind) (*catalog.Index, error) {
+// FindTable finds the table by TableFind. Implement the catalog.Catalog interface.
+func (c *Catalog) FindTable(ctx context.Context, find *catalog.TableFind) (*catalog.Table, error) {
 	table, err := c.store.GetTable(ctx, &api.TableFind{
 		DatabaseID: c.databaseID,
 		Name:       &find.TableName,
@@ -35,33 +37,217 @@ func (c *Catalog) FindIndex(ctx context.Context, find *catalog.IndexFind) (*cata
 	if err != nil {
 		return nil, err
 	}
+	if table == nil {
+		return nil, nil
+	}
+
+	columnList, err := c.findColumnList(ctx, table, nil /* columnName */)
+	if err != nil {
+		return nil, err
+	}
+	indexList, err := c.findIndexList(ctx, table, nil /* indexName */)
+	if err != nil {
+		return nil, err
+	}
+	var constraintList []*catalog.Constraint
+	for _, index := range indexList {
+		if constraint := convertIndexToConstraint(index); constraint != nil {
+			constraintList = append(constraintList, constraint)
+		}
+	}
+
+	return &catalog.Table{
+		Name:           table.Name,
+		RowCount:       table.RowCount,
+		ColumnList:     columnList,
+		ConstraintList: constraintList,
+		IndexList:      indexList,
+	}, nil
+}
 
-	indexList, err := c.store.FindIndex(ctx, &api.IndexFind{
+// FindColumn finds the column by ColumnFind. Implement the catalog.Catalog interface.
+func (c *Catalog) FindColumn(ctx context.Context, find *catalog.ColumnFind) (*catalog.Column, error) {
+	table, err := c.store.GetTable(ctx, &api.TableFind{
 		DatabaseID: c.databaseID,
-		TableID:    &table.ID,
-		Name:       &find.IndexName,
+		Name:       &find.TableName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if table == nil {
+		return nil, nil
+	}
+
+	columnList, err := c.findColumnList(ctx, table, &find.ColumnName)
+	if err != nil {
+		return nil, err
+	}
+	if len(columnList) == 0 {
+		return nil, nil
+	}
+	return columnList[0], nil
+}
+
+// FindConstraint finds the constraint by ConstraintFind. Implement the catalog.Catalog interface.
+func (c *Catalog) FindConstraint(ctx context.Context, find *catalog.ConstraintFind) (*catalog.Constraint, error) {
+	index, err := c.FindIndex(ctx, &catalog.IndexFind{
+		TableName: find.TableName,
+		IndexName: find.ConstraintName,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if index == nil {
+		return nil, nil
+	}
+	return convertIndexToConstraint(index), nil
+}
+
+// FindIndex finds the index by IndexFind. Implement the catalog.Catalog interface.
+// The table name is optional, because the index name is unique in the PostgreSQL schema.
+func (c *Catalog) FindIndex(ctx context.Context, find *catalog.IndexFind) (*catalog.Index, error) {
+	indexFind := &api.IndexFind{
+		DatabaseID: c.databaseID,
+		Name:       &find.IndexName,
+	}
+	if find.TableName != "" {
+		table, err := c.store.GetTable(ctx, &api.TableFind{
+			DatabaseID: c.databaseID,
+			Name:       &find.TableName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if table == nil {
+			return nil, nil
+		}
+		indexFind.TableID = &table.ID
+	}
+
+	indexList, err := c.store.FindIndex(ctx, indexFind)
+	if err != nil {
+		return nil, err
+	}
 	if len(indexList) == 0 {
 		return nil, nil
 	}
 
-	sort.Slice(indexList, func(i, j int) bool {
-		return indexList[i].Position < indexList[j].Position
+	// The index with the same name may be in the different tables for MySQL, so we use the first one.
+	table, err := c.store.GetTable(ctx, &api.TableFind{
+		ID: &indexList[0].TableID,
 	})
+	if err != nil {
+		return nil, err
+	}
+	if table == nil {
+		return nil, nil
+	}
 
-	var columnExpressions []string
-	for _, index := range indexList {
-		columnExpressions = append(columnExpressions, index.Expression)
+	res, err := c.findIndexList(ctx, table, &find.IndexName)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
 	}
+	return res[0], nil
+}
 
-	return &catalog.Index{
-		Name:              indexList[0].Name,
-		TableName:         table.Name,
-		Type:              indexList[0].Type,
-		Unique:            indexList[0].Unique,
-		ColumnExpressions: columnExpressions,
-	}, nil
+// findColumnList returns the columns of the table in order.
+func (c *Catalog) findColumnList(ctx context.Context, table *api.Table, columnName *string) ([]*catalog.Column, error) {
+	rawList, err := c.store.FindColumn(ctx, &api.ColumnFind{
+		DatabaseID: &table.DatabaseID,
+		TableID:    &table.ID,
+		Name:       columnName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rawList, func(i, j int) bool {
+		return rawList[i].Position < rawList[j].Position
+	})
+
+	var columnList []*catalog.Column
+	for _, column := range rawList {
+		columnList = append(columnList, &catalog.Column{
+			Name:      column.Name,
+			TableName: table.Name,
+			Position:  column.Position,
+			Type:      column.Type,
+			Nullable:  column.Nullable,
+			Default:   column.Default,
+		})
+	}
+	return columnList, nil
+}
+
+// findIndexList returns the indexes of the table in the order of name.
+// The store has a record for each expression of an index, so we merge them by index name.
+func (c *Catalog) findIndexList(ctx context.Context, table *api.Table, indexName *string) ([]*catalog.Index, error) {
+	rawList, err := c.store.FindIndex(ctx, &api.IndexFind{
+		DatabaseID: &table.DatabaseID,
+		TableID:    &table.ID,
+		Name:       indexName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(rawList, func(i, j int) bool {
+		if rawList[i].Name != rawList[j].Name {
+			return rawList[i].Name < rawList[j].Name
+		}
+		return rawList[i].Position < rawList[j].Position
+	})
+
+	var engine db.Type
+	if table.Database != nil && table.Database.Instance != nil {
+		engine = table.Database.Instance.Engine
+	}
+
+	var indexList []*catalog.Index
+	for _, raw := range rawList {
+		if len(indexList) == 0 || indexList[len(indexList)-1].Name != raw.Name {
+			indexList = append(indexList, &catalog.Index{
+				Name:      raw.Name,
+				TableName: table.Name,
+				Type:      raw.Type,
+				Unique:    raw.Unique,
+				Primary:   isPrimaryIndex(engine, table.Name, raw.Name),
+			})
+		}
+		index := indexList[len(indexList)-1]
+		index.ColumnExpressions = append(index.ColumnExpressions, raw.Expression)
+	}
+	return indexList, nil
+}
+
+// isPrimaryIndex returns whether the index is the primary key.
+// The synced schema doesn't record the constraint type, so we use the primary key name of the engines.
+// For PostgreSQL, it only works if the primary key uses the default name.
+func isPrimaryIndex(engine db.Type, tableName string, indexName string) bool {
+	switch engine {
+	case db.MySQL, db.TiDB, db.MariaDB, db.OceanBase:
+		return indexName == "PRIMARY"
+	case db.Postgres:
+		return indexName == fmt.Sprintf("%s_pkey", tableName)
+	}
+	return false
+}
+
+func convertIndexToConstraint(index *catalog.Index) *catalog.Constraint {
+	var constraintType catalog.ConstraintType
+	switch {
+	case index.Primary:
+		constraintType = catalog.ConstraintTypePrimary
+	case index.Unique:
+		constraintType = catalog.ConstraintTypeUnique
+	default:
+		return nil
+	}
+	return &catalog.Constraint{
+		Name:       index.Name,
+		TableName:  index.TableName,
+		Type:       constraintType,
+		ColumnList: index.ColumnExpressions,
+	}
 }
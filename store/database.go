@@ -330,6 +330,9 @@ func (s *Store) createDatabaseRawTx(ctx context.Context, tx *sql.Tx, create *api
 			backupSettingUpsert.DayOfWeek = -1
 		case api.BackupPlanPolicyScheduleWeekly:
 			backupSettingUpsert.DayOfWeek = rand.Intn(7)
+		case api.BackupPlanPolicyScheduleCustom:
+			backupSettingUpsert.DayOfWeek = -1
+			backupSettingUpsert.Schedule = backupPlanPolicy.CronExpression
 		}
 		if _, err := s.upsertBackupSettingImpl(ctx, tx, backupSettingUpsert); err != nil {
 			return nil, err
@@ -0,0 +1,6 @@
+ALTER TABLE policy ALTER COLUMN environment_id DROP NOT NULL;
+ALTER TABLE policy ADD COLUMN project_id INTEGER REFERENCES project (id);
+ALTER TABLE policy ADD COLUMN database_id INTEGER REFERENCES db (id);
+ALTER TABLE policy ADD CONSTRAINT policy_resource_check CHECK (num_nonnulls(environment_id, project_id, database_id) = 1);
+CREATE UNIQUE INDEX idx_policy_unique_project_id_type ON policy(project_id, type);
+CREATE UNIQUE INDEX idx_policy_unique_database_id_type ON policy(database_id, type);
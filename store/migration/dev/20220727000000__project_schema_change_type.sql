@@ -0,0 +1 @@
+ALTER TABLE project ADD schema_change_type TEXT NOT NULL CHECK (schema_change_type IN ('DDL', 'SDL')) DEFAULT 'DDL';
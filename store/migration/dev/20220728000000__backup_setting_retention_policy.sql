@@ -0,0 +1 @@
+ALTER TABLE backup_setting ADD retention_policy JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE backup_setting ADD verification_policy JSONB NOT NULL DEFAULT '{}';
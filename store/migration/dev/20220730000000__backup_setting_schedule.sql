@@ -0,0 +1 @@
+ALTER TABLE backup_setting ADD schedule TEXT NOT NULL DEFAULT '';
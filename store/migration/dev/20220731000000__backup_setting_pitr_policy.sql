@@ -0,0 +1 @@
+ALTER TABLE backup_setting ADD pitr_policy JSONB NOT NULL DEFAULT '{}';
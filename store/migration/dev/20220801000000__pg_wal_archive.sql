@@ -0,0 +1,25 @@
+-- pg_wal_archive stores the WAL archiving of the Postgres instances for PITR.
+CREATE TABLE pg_wal_archive (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    -- slot_name is the physical replication slot on the instance retaining the WAL until it's archived.
+    slot_name TEXT NOT NULL,
+    -- failure_count is the number of the consecutive failed archiving rounds, and the slot is dropped after too many.
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_pg_wal_archive_unique_instance_id ON pg_wal_archive(instance_id);
+
+ALTER SEQUENCE pg_wal_archive_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_pg_wal_archive_updated_ts
+BEFORE
+UPDATE
+    ON pg_wal_archive FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,26 @@
+-- pg_wal_file stores the archived WAL files of the Postgres instances uploaded to the backup storage backend.
+CREATE TABLE pg_wal_file (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    -- name is the WAL segment or the timeline history file name.
+    name TEXT NOT NULL,
+    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS')),
+    path TEXT NOT NULL,
+    -- payload records the encoding, the checksum and the size of the file.
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE UNIQUE INDEX idx_pg_wal_file_unique_instance_id_name ON pg_wal_file(instance_id, name);
+
+ALTER SEQUENCE pg_wal_file_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_pg_wal_file_updated_ts
+BEFORE
+UPDATE
+    ON pg_wal_file FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
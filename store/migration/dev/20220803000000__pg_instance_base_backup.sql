@@ -0,0 +1,23 @@
+-- pg_instance_base_backup stores the base backups of the Postgres instances for PITR, which are shared by the backups
+-- of the databases on the instances.
+CREATE TABLE pg_instance_base_backup (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    -- payload records the storage backend, the path, the WAL location and the encoding of the base backup.
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_pg_instance_base_backup_instance_id ON pg_instance_base_backup(instance_id);
+
+ALTER SEQUENCE pg_instance_base_backup_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_pg_instance_base_backup_updated_ts
+BEFORE
+UPDATE
+    ON pg_instance_base_backup FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,14 @@
+-- Move the S3 secret access keys of the backup storage policies to their own settings, since the policies are readable
+-- by all the members.
+INSERT INTO setting (creator_id, updater_id, name, value, description)
+SELECT
+    1,
+    1,
+    'bb.backup.s3.secret-access-key.' || environment_id,
+    payload->'s3'->>'secretAccessKey',
+    'The secret access key of the S3 backup storage backend of environment ' || environment_id || '.'
+FROM policy
+WHERE type = 'bb.policy.backup-storage' AND environment_id IS NOT NULL AND COALESCE(payload->'s3'->>'secretAccessKey', '') != ''
+ON CONFLICT (name) DO UPDATE SET value = excluded.value;
+
+UPDATE policy SET payload = payload #- '{s3,secretAccessKey}' WHERE type = 'bb.policy.backup-storage' AND payload->'s3' ? 'secretAccessKey';
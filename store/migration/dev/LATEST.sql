@@ -125,33 +125,6 @@ UPDATE
     ON environment FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
--- Policy
--- policy stores the policies for each environment.
--- Policies are associated with environments. Since we may have policies not associated with environment later, we name the table policy.
-CREATE TABLE policy (
-    id SERIAL PRIMARY KEY,
-    row_status row_status NOT NULL DEFAULT 'NORMAL',
-    creator_id INTEGER NOT NULL REFERENCES principal (id),
-    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
-    updater_id INTEGER NOT NULL REFERENCES principal (id),
-    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
-    environment_id INTEGER NOT NULL REFERENCES environment (id),
-    type TEXT NOT NULL CHECK (type LIKE 'bb.policy.%'),
-    payload JSONB NOT NULL DEFAULT '{}'
-);
-
-CREATE INDEX idx_policy_environment_id ON policy(environment_id);
-
-CREATE UNIQUE INDEX idx_policy_unique_environment_id_type ON policy(environment_id, type);
-
-ALTER SEQUENCE policy_id_seq RESTART WITH 101;
-
-CREATE TRIGGER update_policy_updated_ts
-BEFORE
-UPDATE
-    ON policy FOR EACH ROW
-EXECUTE FUNCTION trigger_update_updated_ts();
-
 -- Project
 CREATE TABLE project (
     id SERIAL PRIMARY KEY,
@@ -168,8 +141,9 @@ CREATE TABLE project (
     -- db_name_template is only used when a project is in tenant mode.
     -- Empty value means {{DB_NAME}}.
     db_name_template TEXT NOT NULL,
-    role_provider TEXT NOT NULL CHECK (role_provider IN ('BYTEBASE', 'GITLAB_SELF_HOST', 'GITHUB_COM')) DEFAULT 'BYTEBASE',
-    schema_version_type TEXT NOT NULL CHECK (schema_version_type IN ('TIMESTAMP', 'SEMANTIC')) DEFAULT 'TIMESTAMP'
+    role_provider TEXT NOT NULL CHECK (role_provider IN ('BYTEBASE', 'GITLAB_SELF_HOST', 'GITHUB_COM', 'GITEA_SELF_HOST', 'BITBUCKET_ORG')) DEFAULT 'BYTEBASE',
+    schema_version_type TEXT NOT NULL CHECK (schema_version_type IN ('TIMESTAMP', 'SEMANTIC')) DEFAULT 'TIMESTAMP',
+    schema_change_type TEXT NOT NULL CHECK (schema_change_type IN ('DDL', 'SDL')) DEFAULT 'DDL'
 );
 
 CREATE UNIQUE INDEX idx_project_unique_key ON project(key);
@@ -218,7 +192,7 @@ CREATE TABLE project_member (
     project_id INTEGER NOT NULL REFERENCES project (id),
     role TEXT NOT NULL CHECK (role IN ('OWNER', 'DEVELOPER')),
     principal_id INTEGER NOT NULL REFERENCES principal (id),
-    role_provider TEXT NOT NULL CHECK (role_provider IN ('BYTEBASE', 'GITLAB_SELF_HOST', 'GITHUB_COM')) DEFAULT 'BYTEBASE',
+    role_provider TEXT NOT NULL CHECK (role_provider IN ('BYTEBASE', 'GITLAB_SELF_HOST', 'GITHUB_COM', 'GITEA_SELF_HOST', 'BITBUCKET_ORG')) DEFAULT 'BYTEBASE',
     -- payload is determined by the type of role_provider
     payload JSONB NOT NULL DEFAULT '{}'
 );
@@ -270,7 +244,7 @@ CREATE TABLE instance (
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     environment_id INTEGER NOT NULL REFERENCES environment (id),
     name TEXT NOT NULL,
-    engine TEXT NOT NULL CHECK (engine IN ('MYSQL', 'POSTGRES', 'TIDB', 'CLICKHOUSE', 'SNOWFLAKE', 'SQLITE')),
+    engine TEXT NOT NULL CHECK (engine IN ('MYSQL', 'POSTGRES', 'TIDB', 'CLICKHOUSE', 'SNOWFLAKE', 'SQLITE', 'MSSQL', 'ORACLE', 'MONGODB', 'REDIS', 'MARIADB', 'OCEANBASE')),
     engine_version TEXT NOT NULL DEFAULT '',
     host TEXT NOT NULL,
     port TEXT NOT NULL,
@@ -341,6 +315,41 @@ UPDATE
     ON db FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- Policy
+-- policy stores the policies for each environment, project or database.
+-- Each policy is associated with exactly one of them, and the schema review policy of a database is resolved
+-- with the precedence database > project > environment.
+CREATE TABLE policy (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    environment_id INTEGER REFERENCES environment (id),
+    project_id INTEGER REFERENCES project (id),
+    database_id INTEGER REFERENCES db (id),
+    type TEXT NOT NULL CHECK (type LIKE 'bb.policy.%'),
+    payload JSONB NOT NULL DEFAULT '{}',
+    CONSTRAINT policy_resource_check CHECK (num_nonnulls(environment_id, project_id, database_id) = 1)
+);
+
+CREATE INDEX idx_policy_environment_id ON policy(environment_id);
+
+CREATE UNIQUE INDEX idx_policy_unique_environment_id_type ON policy(environment_id, type);
+
+CREATE UNIQUE INDEX idx_policy_unique_project_id_type ON policy(project_id, type);
+
+CREATE UNIQUE INDEX idx_policy_unique_database_id_type ON policy(database_id, type);
+
+ALTER SEQUENCE policy_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_policy_updated_ts
+BEFORE
+UPDATE
+    ON policy FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- tbl stores the table for a particular database
 -- data is synced periodically from the instance
 CREATE TABLE tbl (
@@ -571,8 +580,16 @@ CREATE TABLE backup_setting (
     hour INTEGER NOT NULL CHECK (hour >= 0 AND hour <= 23),
     -- day_of_week can be -1 which is wildcard (daily automatic backup).
     day_of_week INTEGER NOT NULL CHECK (day_of_week >= -1 AND day_of_week <= 6),
+    -- schedule is the cron expression in UTC overriding the hour and the day_of_week if not empty.
+    schedule TEXT NOT NULL DEFAULT '',
     -- hook_url is the callback url to be requested after a successful backup.
-    hook_url TEXT NOT NULL
+    hook_url TEXT NOT NULL,
+    -- retention_policy is the retention policy for the backups of the database enforced by the backup runner.
+    retention_policy JSONB NOT NULL DEFAULT '{}',
+    -- verification_policy is the policy to verify the backups of the database by restoring them into a scratch database.
+    verification_policy JSONB NOT NULL DEFAULT '{}',
+    -- pitr_policy is the policy to archive the WAL of the Postgres instance continuously for PITR.
+    pitr_policy JSONB NOT NULL DEFAULT '{}'
 );
 
 CREATE UNIQUE INDEX idx_backup_setting_unique_database_id ON backup_setting(database_id);
@@ -585,6 +602,83 @@ UPDATE
     ON backup_setting FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- pg_wal_archive stores the WAL archiving of the Postgres instances for PITR.
+CREATE TABLE pg_wal_archive (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    -- slot_name is the physical replication slot on the instance retaining the WAL until it's archived.
+    slot_name TEXT NOT NULL,
+    -- failure_count is the number of the consecutive failed archiving rounds, and the slot is dropped after too many.
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX idx_pg_wal_archive_unique_instance_id ON pg_wal_archive(instance_id);
+
+ALTER SEQUENCE pg_wal_archive_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_pg_wal_archive_updated_ts
+BEFORE
+UPDATE
+    ON pg_wal_archive FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- pg_wal_file stores the archived WAL files of the Postgres instances uploaded to the backup storage backend.
+CREATE TABLE pg_wal_file (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    -- name is the WAL segment or the timeline history file name.
+    name TEXT NOT NULL,
+    storage_backend TEXT NOT NULL CHECK (storage_backend IN ('LOCAL', 'S3', 'GCS', 'OSS')),
+    path TEXT NOT NULL,
+    -- payload records the encoding, the checksum and the size of the file.
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE UNIQUE INDEX idx_pg_wal_file_unique_instance_id_name ON pg_wal_file(instance_id, name);
+
+ALTER SEQUENCE pg_wal_file_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_pg_wal_file_updated_ts
+BEFORE
+UPDATE
+    ON pg_wal_file FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- pg_instance_base_backup stores the base backups of the Postgres instances for PITR, which are shared by the backups
+-- of the databases on the instances.
+CREATE TABLE pg_instance_base_backup (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    -- payload records the storage backend, the path, the WAL location and the encoding of the base backup.
+    payload JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_pg_instance_base_backup_instance_id ON pg_instance_base_backup(instance_id);
+
+ALTER SEQUENCE pg_instance_base_backup_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_pg_instance_base_backup_updated_ts
+BEFORE
+UPDATE
+    ON pg_instance_base_backup FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -----------------------
 -- Pipeline related BEGIN
 -- pipeline table
@@ -871,7 +965,7 @@ CREATE TABLE vcs (
     updater_id INTEGER NOT NULL REFERENCES principal (id),
     updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
     name TEXT NOT NULL,
-    type TEXT NOT NULL CHECK (type IN ('GITLAB_SELF_HOST', 'GITHUB_COM')),
+    type TEXT NOT NULL CHECK (type IN ('GITLAB_SELF_HOST', 'GITHUB_COM', 'GITEA_SELF_HOST', 'BITBUCKET_ORG')),
     instance_url TEXT NOT NULL CHECK ((instance_url LIKE 'http://%' OR instance_url LIKE 'https://%') AND instance_url = rtrim(instance_url, '/')),
     api_url TEXT NOT NULL CHECK ((api_url LIKE 'http://%' OR api_url LIKE 'https://%') AND api_url = rtrim(api_url, '/')),
     application_id TEXT NOT NULL,
@@ -1085,7 +1179,7 @@ CREATE TABLE sheet (
     name TEXT NOT NULL,
     statement TEXT NOT NULL,
     visibility TEXT NOT NULL CHECK (visibility IN ('PRIVATE', 'PROJECT', 'PUBLIC')) DEFAULT 'PRIVATE',
-    source TEXT NOT NULL CHECK (source IN ('BYTEBASE', 'GITLAB_SELF_HOST', 'GITHUB_COM')) DEFAULT 'BYTEBASE',
+    source TEXT NOT NULL CHECK (source IN ('BYTEBASE', 'GITLAB_SELF_HOST', 'GITHUB_COM', 'GITEA_SELF_HOST', 'BITBUCKET_ORG')) DEFAULT 'BYTEBASE',
     type TEXT NOT NULL CHECK (type IN ('SQL')) DEFAULT 'SQL',
     payload JSONB NOT NULL DEFAULT '{}'
 );
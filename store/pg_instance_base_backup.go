@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common"
+)
+
+// CreatePgInstanceBaseBackup creates a base backup of a Postgres instance.
+func (s *Store) CreatePgInstanceBaseBackup(ctx context.Context, create *api.PgInstanceBaseBackupCreate) (*api.PgInstanceBaseBackup, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	baseBackup, err := createPgInstanceBaseBackupImpl(ctx, tx.PTx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return baseBackup, nil
+}
+
+// FindPgInstanceBaseBackup finds a list of the base backups of Postgres instances, in which the latest one comes first.
+func (s *Store) FindPgInstanceBaseBackup(ctx context.Context, find *api.PgInstanceBaseBackupFind) ([]*api.PgInstanceBaseBackup, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findPgInstanceBaseBackupImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// DeletePgInstanceBaseBackup deletes a base backup of a Postgres instance.
+func (s *Store) DeletePgInstanceBaseBackup(ctx context.Context, delete *api.PgInstanceBaseBackupDelete) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	if _, err := tx.PTx.ExecContext(ctx, `DELETE FROM pg_instance_base_backup WHERE id = $1`, delete.ID); err != nil {
+		return FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+func createPgInstanceBaseBackupImpl(ctx context.Context, tx *sql.Tx, create *api.PgInstanceBaseBackupCreate) (*api.PgInstanceBaseBackup, error) {
+	payload, err := json.Marshal(create.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal base backup payload, error: %w", err)
+	}
+	// Insert row into database.
+	query := `
+		INSERT INTO pg_instance_base_backup (
+			creator_id,
+			updater_id,
+			instance_id,
+			payload
+		)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_ts, updated_ts, instance_id, payload
+	`
+	var baseBackup api.PgInstanceBaseBackup
+	var baseBackupPayload []byte
+	if err := tx.QueryRowContext(ctx, query,
+		create.CreatorID,
+		create.CreatorID,
+		create.InstanceID,
+		payload,
+	).Scan(
+		&baseBackup.ID,
+		&baseBackup.CreatedTs,
+		&baseBackup.UpdatedTs,
+		&baseBackup.InstanceID,
+		&baseBackupPayload,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+	if err := json.Unmarshal(baseBackupPayload, &baseBackup.Payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal base backup payload, error: %w", err)
+	}
+	return &baseBackup, nil
+}
+
+func findPgInstanceBaseBackupImpl(ctx context.Context, tx *sql.Tx, find *api.PgInstanceBaseBackupFind) ([]*api.PgInstanceBaseBackup, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.InstanceID; v != nil {
+		where, args = append(where, fmt.Sprintf("instance_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			instance_id,
+			payload
+		FROM pg_instance_base_backup
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY created_ts DESC, id DESC
+		`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into baseBackupList.
+	var baseBackupList []*api.PgInstanceBaseBackup
+	for rows.Next() {
+		var baseBackup api.PgInstanceBaseBackup
+		var payload []byte
+		if err := rows.Scan(
+			&baseBackup.ID,
+			&baseBackup.CreatedTs,
+			&baseBackup.UpdatedTs,
+			&baseBackup.InstanceID,
+			&payload,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+		if err := json.Unmarshal(payload, &baseBackup.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal base backup payload, error: %w", err)
+		}
+
+		baseBackupList = append(baseBackupList, &baseBackup)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return baseBackupList, nil
+}
@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common"
+)
+
+// UpsertPgWALArchive upserts the WAL archiving of a Postgres instance.
+func (s *Store) UpsertPgWALArchive(ctx context.Context, upsert *api.PgWALArchiveUpsert) (*api.PgWALArchive, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	archive, err := upsertPgWALArchiveImpl(ctx, tx.PTx, upsert)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return archive, nil
+}
+
+// FindPgWALArchive finds a list of the WAL archiving of Postgres instances.
+func (s *Store) FindPgWALArchive(ctx context.Context, find *api.PgWALArchiveFind) ([]*api.PgWALArchive, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findPgWALArchiveImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// GetPgWALArchiveByInstanceID gets the WAL archiving of the Postgres instance, or nil if the instance is not archived.
+func (s *Store) GetPgWALArchiveByInstanceID(ctx context.Context, instanceID int) (*api.PgWALArchive, error) {
+	list, err := s.FindPgWALArchive(ctx, &api.PgWALArchiveFind{InstanceID: &instanceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WAL archive by instance ID %d, error: %w", instanceID, err)
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// DeletePgWALArchive deletes the WAL archiving of a Postgres instance.
+func (s *Store) DeletePgWALArchive(ctx context.Context, delete *api.PgWALArchiveDelete) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	if _, err := tx.PTx.ExecContext(ctx, `DELETE FROM pg_wal_archive WHERE instance_id = $1`, delete.InstanceID); err != nil {
+		return FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+func upsertPgWALArchiveImpl(ctx context.Context, tx *sql.Tx, upsert *api.PgWALArchiveUpsert) (*api.PgWALArchive, error) {
+	// Upsert row into database.
+	query := `
+		INSERT INTO pg_wal_archive (
+			creator_id,
+			updater_id,
+			instance_id,
+			slot_name,
+			failure_count,
+			last_error
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (instance_id) DO UPDATE SET
+			updater_id = excluded.updater_id,
+			slot_name = excluded.slot_name,
+			failure_count = excluded.failure_count,
+			last_error = excluded.last_error
+		RETURNING id, created_ts, updated_ts, instance_id, slot_name, failure_count, last_error
+	`
+	var archive api.PgWALArchive
+	if err := tx.QueryRowContext(ctx, query,
+		upsert.UpdaterID,
+		upsert.UpdaterID,
+		upsert.InstanceID,
+		upsert.SlotName,
+		upsert.FailureCount,
+		upsert.LastError,
+	).Scan(
+		&archive.ID,
+		&archive.CreatedTs,
+		&archive.UpdatedTs,
+		&archive.InstanceID,
+		&archive.SlotName,
+		&archive.FailureCount,
+		&archive.LastError,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+	return &archive, nil
+}
+
+func findPgWALArchiveImpl(ctx context.Context, tx *sql.Tx, find *api.PgWALArchiveFind) ([]*api.PgWALArchive, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.InstanceID; v != nil {
+		where, args = append(where, fmt.Sprintf("instance_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			instance_id,
+			slot_name,
+			failure_count,
+			last_error
+		FROM pg_wal_archive
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY instance_id ASC
+		`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into archiveList.
+	var archiveList []*api.PgWALArchive
+	for rows.Next() {
+		var archive api.PgWALArchive
+		if err := rows.Scan(
+			&archive.ID,
+			&archive.CreatedTs,
+			&archive.UpdatedTs,
+			&archive.InstanceID,
+			&archive.SlotName,
+			&archive.FailureCount,
+			&archive.LastError,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		archiveList = append(archiveList, &archive)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return archiveList, nil
+}
@@ -0,0 +1,183 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/youzi-1122/bytebase/api"
+	"github.com/youzi-1122/bytebase/common"
+)
+
+// UpsertPgWALFile upserts an archived WAL file of a Postgres instance.
+func (s *Store) UpsertPgWALFile(ctx context.Context, upsert *api.PgWALFileUpsert) (*api.PgWALFile, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	file, err := upsertPgWALFileImpl(ctx, tx.PTx, upsert)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return file, nil
+}
+
+// FindPgWALFile finds a list of the archived WAL files of Postgres instances, in the order of the file name.
+func (s *Store) FindPgWALFile(ctx context.Context, find *api.PgWALFileFind) ([]*api.PgWALFile, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	list, err := findPgWALFileImpl(ctx, tx.PTx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// DeletePgWALFile deletes an archived WAL file of a Postgres instance.
+func (s *Store) DeletePgWALFile(ctx context.Context, delete *api.PgWALFileDelete) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.PTx.Rollback()
+
+	if _, err := tx.PTx.ExecContext(ctx, `DELETE FROM pg_wal_file WHERE id = $1`, delete.ID); err != nil {
+		return FormatError(err)
+	}
+
+	if err := tx.PTx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+func upsertPgWALFileImpl(ctx context.Context, tx *sql.Tx, upsert *api.PgWALFileUpsert) (*api.PgWALFile, error) {
+	payload, err := json.Marshal(upsert.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal WAL file payload, error: %w", err)
+	}
+	// Upsert row into database.
+	query := `
+		INSERT INTO pg_wal_file (
+			creator_id,
+			updater_id,
+			instance_id,
+			name,
+			storage_backend,
+			path,
+			payload
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (instance_id, name) DO UPDATE SET
+			updater_id = excluded.updater_id,
+			storage_backend = excluded.storage_backend,
+			path = excluded.path,
+			payload = excluded.payload
+		RETURNING id, created_ts, updated_ts, instance_id, name, storage_backend, path, payload
+	`
+	var file api.PgWALFile
+	var filePayload []byte
+	if err := tx.QueryRowContext(ctx, query,
+		upsert.UpdaterID,
+		upsert.UpdaterID,
+		upsert.InstanceID,
+		upsert.Name,
+		upsert.StorageBackend,
+		upsert.Path,
+		payload,
+	).Scan(
+		&file.ID,
+		&file.CreatedTs,
+		&file.UpdatedTs,
+		&file.InstanceID,
+		&file.Name,
+		&file.StorageBackend,
+		&file.Path,
+		&filePayload,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+	if err := json.Unmarshal(filePayload, &file.Payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal WAL file payload, error: %w", err)
+	}
+	return &file, nil
+}
+
+func findPgWALFileImpl(ctx context.Context, tx *sql.Tx, find *api.PgWALFileFind) ([]*api.PgWALFile, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.InstanceID; v != nil {
+		where, args = append(where, fmt.Sprintf("instance_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			created_ts,
+			updated_ts,
+			instance_id,
+			name,
+			storage_backend,
+			path,
+			payload
+		FROM pg_wal_file
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY instance_id ASC, name ASC
+		`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into fileList.
+	var fileList []*api.PgWALFile
+	for rows.Next() {
+		var file api.PgWALFile
+		var payload []byte
+		if err := rows.Scan(
+			&file.ID,
+			&file.CreatedTs,
+			&file.UpdatedTs,
+			&file.InstanceID,
+			&file.Name,
+			&file.StorageBackend,
+			&file.Path,
+			&payload,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+		if err := json.Unmarshal(payload, &file.Payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal WAL file payload, error: %w", err)
+		}
+
+		fileList = append(fileList, &file)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return fileList, nil
+}
@@ -24,7 +24,10 @@ type policyRaw struct {
 	UpdatedTs int64
 
 	// Related fields
+	ResourceType  api.PolicyResourceType
 	EnvironmentID int
+	ProjectID     int
+	DatabaseID    int
 
 	// Domain specific fields
 	Type    api.PolicyType
@@ -45,7 +48,10 @@ func (raw *policyRaw) toPolicy() *api.Policy {
 		UpdatedTs: raw.UpdatedTs,
 
 		// Related fields
+		ResourceType:  raw.ResourceType,
 		EnvironmentID: raw.EnvironmentID,
+		ProjectID:     raw.ProjectID,
+		DatabaseID:    raw.DatabaseID,
 
 		// Domain specific fields
 		Type:    raw.Type,
@@ -94,8 +100,15 @@ func (s *Store) DeletePolicy(ctx context.Context, delete *api.PolicyDelete) erro
 	defer tx.PTx.Rollback()
 
 	find := &api.PolicyFind{
-		EnvironmentID: &delete.EnvironmentID,
-		Type:          &delete.Type,
+		Type: &delete.Type,
+	}
+	switch delete.ResourceType {
+	case api.PolicyResourceTypeProject:
+		find.ProjectID = &delete.ProjectID
+	case api.PolicyResourceTypeDatabase:
+		find.DatabaseID = &delete.DatabaseID
+	default:
+		find.EnvironmentID = &delete.EnvironmentID
 	}
 	policyRawList, err := findPolicyImpl(ctx, tx.PTx, find)
 	if err != nil {
@@ -109,7 +122,7 @@ func (s *Store) DeletePolicy(ctx context.Context, delete *api.PolicyDelete) erro
 		return &common.Error{Code: common.Invalid, Err: fmt.Errorf("failed to delete policy with PolicyDelete[%+v], expect 'ARCHIVED' row_status", delete)}
 	}
 
-	if err := deletePolicyImpl(ctx, tx.PTx, delete); err != nil {
+	if err := deletePolicyImpl(ctx, tx.PTx, policyRaw.ID); err != nil {
 		return FormatError(err)
 	}
 
@@ -164,6 +177,19 @@ func (s *Store) GetBackupPlanPolicyByEnvID(ctx context.Context, environmentID in
 	return api.UnmarshalBackupPlanPolicy(policy.Payload)
 }
 
+// GetBackupStoragePolicyByEnvID will get the backup storage policy for an environment.
+func (s *Store) GetBackupStoragePolicyByEnvID(ctx context.Context, environmentID int) (*api.BackupStoragePolicy, error) {
+	pType := api.PolicyTypeBackupStorage
+	policy, err := s.getPolicyRaw(ctx, &api.PolicyFind{
+		EnvironmentID: &environmentID,
+		Type:          &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.UnmarshalBackupStoragePolicy(policy.Payload)
+}
+
 // GetPipelineApprovalPolicy will get the pipeline approval policy for an environment.
 func (s *Store) GetPipelineApprovalPolicy(ctx context.Context, environmentID int) (*api.PipelineApprovalPolicy, error) {
 	pType := api.PolicyTypePipelineApproval
@@ -190,10 +216,10 @@ func (s *Store) GetNormalSchemaReviewPolicy(ctx context.Context, find *api.Polic
 		return nil, err
 	}
 	if policy.RowStatus == api.Archived {
-		return nil, &common.Error{Code: common.NotFound, Err: fmt.Errorf("schema review policy ID: %d for environment %d is archived", policy.ID, policy.EnvironmentID)}
+		return nil, &common.Error{Code: common.NotFound, Err: fmt.Errorf("schema review policy ID: %d for %s is archived", policy.ID, policy.resourceName())}
 	}
 	if policy.ID == api.DefaultPolicyID {
-		return nil, &common.Error{Code: common.NotFound, Err: fmt.Errorf("schema review policy ID: %d for environment %d not found", policy.ID, policy.EnvironmentID)}
+		return nil, &common.Error{Code: common.NotFound, Err: fmt.Errorf("schema review policy ID: %d for %s not found", policy.ID, policy.resourceName())}
 	}
 	return api.UnmarshalSchemaReviewPolicy(policy.Payload)
 }
@@ -211,6 +237,26 @@ func (s *Store) GetSchemaReviewPolicyIDByEnvID(ctx context.Context, environmentI
 	return policy.ID, nil
 }
 
+// GetSchemaReviewPolicyIDByDatabase will get the effective schema review policy ID for a database.
+// The policy is resolved with the precedence database > project > environment,
+// and the archived database or project policy falls through to the next one.
+func (s *Store) GetSchemaReviewPolicyIDByDatabase(ctx context.Context, database *api.Database) (int, error) {
+	pType := api.PolicyTypeSchemaReview
+	for _, find := range []*api.PolicyFind{
+		{DatabaseID: &database.ID, Type: &pType},
+		{ProjectID: &database.ProjectID, Type: &pType},
+	} {
+		policy, err := s.getPolicyRaw(ctx, find)
+		if err != nil {
+			return 0, err
+		}
+		if policy.ID != api.DefaultPolicyID && policy.RowStatus == api.Normal {
+			return policy.ID, nil
+		}
+	}
+	return s.GetSchemaReviewPolicyIDByEnvID(ctx, database.Instance.EnvironmentID)
+}
+
 //
 // private functions
 //
@@ -230,16 +276,31 @@ func (s *Store) composePolicy(ctx context.Context, raw *policyRaw) (*api.Policy,
 	}
 	policy.Updater = updater
 
-	env, err := s.GetEnvironmentByID(ctx, policy.EnvironmentID)
-	if err != nil {
-		return nil, err
+	switch policy.ResourceType {
+	case api.PolicyResourceTypeEnvironment:
+		env, err := s.GetEnvironmentByID(ctx, policy.EnvironmentID)
+		if err != nil {
+			return nil, err
+		}
+		policy.Environment = env
+	case api.PolicyResourceTypeProject:
+		project, err := s.GetProjectByID(ctx, policy.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		policy.Project = project
+	case api.PolicyResourceTypeDatabase:
+		database, err := s.GetDatabase(ctx, &api.DatabaseFind{ID: &policy.DatabaseID})
+		if err != nil {
+			return nil, err
+		}
+		policy.Database = database
 	}
-	policy.Environment = env
 
 	return policy, nil
 }
 
-// getPolicyRaw finds the policy for an environment.
+// getPolicyRaw finds the policy for an environment, a project or a database.
 // Returns ECONFLICT if finding more than 1 matching records.
 func (s *Store) getPolicyRaw(ctx context.Context, find *api.PolicyFind) (*policyRaw, error) {
 	// Validate policy type existence.
@@ -266,8 +327,18 @@ func (s *Store) getPolicyRaw(ctx context.Context, find *api.PolicyFind) (*policy
 			UpdaterID: api.SystemBotID,
 			Type:      *find.Type,
 		}
-		if find.EnvironmentID != nil {
-			ret.EnvironmentID = *find.EnvironmentID
+		switch {
+		case find.ProjectID != nil:
+			ret.ResourceType = api.PolicyResourceTypeProject
+			ret.ProjectID = *find.ProjectID
+		case find.DatabaseID != nil:
+			ret.ResourceType = api.PolicyResourceTypeDatabase
+			ret.DatabaseID = *find.DatabaseID
+		default:
+			ret.ResourceType = api.PolicyResourceTypeEnvironment
+			if find.EnvironmentID != nil {
+				ret.EnvironmentID = *find.EnvironmentID
+			}
 		}
 	} else if len(policyRawList) > 1 {
 		return nil, &common.Error{Code: common.Conflict, Err: fmt.Errorf("found %d policy with filter %+v, expect 1. ", len(policyRawList), find)}
@@ -293,9 +364,18 @@ func findPolicyImpl(ctx context.Context, tx *sql.Tx, find *api.PolicyFind) ([]*p
 	if v := find.ID; v != nil {
 		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := find.ResourceType; v != nil {
+		where = append(where, fmt.Sprintf("%s IS NOT NULL", getPolicyResourceColumn(*v)))
+	}
 	if v := find.EnvironmentID; v != nil {
 		where, args = append(where, fmt.Sprintf("environment_id = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := find.ProjectID; v != nil {
+		where, args = append(where, fmt.Sprintf("project_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
 	if v := find.Type; v != nil {
 		where, args = append(where, fmt.Sprintf("type = $%d", len(args)+1)), append(args, *v)
 	}
@@ -309,6 +389,8 @@ func findPolicyImpl(ctx context.Context, tx *sql.Tx, find *api.PolicyFind) ([]*p
 			updated_ts,
 			row_status,
 			environment_id,
+			project_id,
+			database_id,
 			type,
 			payload
 		FROM policy
@@ -324,6 +406,7 @@ func findPolicyImpl(ctx context.Context, tx *sql.Tx, find *api.PolicyFind) ([]*p
 	var policyRawList []*policyRaw
 	for rows.Next() {
 		var policyRaw policyRaw
+		var environmentID, projectID, databaseID sql.NullInt32
 		if err := rows.Scan(
 			&policyRaw.ID,
 			&policyRaw.CreatorID,
@@ -331,12 +414,15 @@ func findPolicyImpl(ctx context.Context, tx *sql.Tx, find *api.PolicyFind) ([]*p
 			&policyRaw.UpdaterID,
 			&policyRaw.UpdatedTs,
 			&policyRaw.RowStatus,
-			&policyRaw.EnvironmentID,
+			&environmentID,
+			&projectID,
+			&databaseID,
 			&policyRaw.Type,
 			&policyRaw.Payload,
 		); err != nil {
 			return nil, FormatError(err)
 		}
+		policyRaw.setResource(environmentID, projectID, databaseID)
 
 		policyRawList = append(policyRawList, &policyRaw)
 	}
@@ -347,7 +433,7 @@ func findPolicyImpl(ctx context.Context, tx *sql.Tx, find *api.PolicyFind) ([]*p
 	return policyRawList, nil
 }
 
-// upsertPolicyRaw sets a policy for an environment.
+// upsertPolicyRaw sets a policy for an environment, a project or a database.
 func (s *Store) upsertPolicyRaw(ctx context.Context, upsert *api.PolicyUpsert) (*policyRaw, error) {
 	// Validate policy.
 	if upsert.Type != "" && upsert.Payload != nil {
@@ -355,6 +441,12 @@ func (s *Store) upsertPolicyRaw(ctx context.Context, upsert *api.PolicyUpsert) (
 			return nil, &common.Error{Code: common.Invalid, Err: err}
 		}
 	}
+	if upsert.ResourceType == "" {
+		upsert.ResourceType = api.PolicyResourceTypeEnvironment
+	}
+	if err := api.ValidatePolicyResource(upsert.ResourceType, upsert.Type); err != nil {
+		return nil, &common.Error{Code: common.Invalid, Err: err}
+	}
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, FormatError(err)
@@ -373,7 +465,7 @@ func (s *Store) upsertPolicyRaw(ctx context.Context, upsert *api.PolicyUpsert) (
 	return policy, nil
 }
 
-// upsertPolicyImpl updates an existing policy by environment id and type.
+// upsertPolicyImpl updates an existing policy by the resource id and type.
 func upsertPolicyImpl(ctx context.Context, tx *sql.Tx, upsert *api.PolicyUpsert) (*policyRaw, error) {
 	// Upsert row into policy.
 	var set []string
@@ -397,25 +489,41 @@ func upsertPolicyImpl(ctx context.Context, tx *sql.Tx, upsert *api.PolicyUpsert)
 		upsert.RowStatus = &rowStatus
 	}
 
+	// Only the column of the resource type is set, and the others are NULL.
+	var environmentID, projectID, databaseID *int
+	switch upsert.ResourceType {
+	case api.PolicyResourceTypeProject:
+		projectID = &upsert.ProjectID
+	case api.PolicyResourceTypeDatabase:
+		databaseID = &upsert.DatabaseID
+	default:
+		environmentID = &upsert.EnvironmentID
+	}
+
 	query := fmt.Sprintf(`
 		INSERT INTO policy (
 			creator_id,
 			updater_id,
 			environment_id,
+			project_id,
+			database_id,
 			type,
 			payload,
 			row_status
 		)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT(environment_id, type) DO UPDATE SET
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT(%s, type) DO UPDATE SET
 			%s
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, row_status, environment_id, type, payload
-	`, strings.Join(set, ","))
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, row_status, environment_id, project_id, database_id, type, payload
+	`, getPolicyResourceColumn(upsert.ResourceType), strings.Join(set, ","))
 	var policyRaw policyRaw
+	var nullEnvironmentID, nullProjectID, nullDatabaseID sql.NullInt32
 	if err := tx.QueryRowContext(ctx, query,
 		upsert.UpdaterID,
 		upsert.UpdaterID,
-		upsert.EnvironmentID,
+		environmentID,
+		projectID,
+		databaseID,
 		upsert.Type,
 		upsert.Payload,
 		upsert.RowStatus,
@@ -426,7 +534,9 @@ func upsertPolicyImpl(ctx context.Context, tx *sql.Tx, upsert *api.PolicyUpsert)
 		&policyRaw.UpdaterID,
 		&policyRaw.UpdatedTs,
 		&policyRaw.RowStatus,
-		&policyRaw.EnvironmentID,
+		&nullEnvironmentID,
+		&nullProjectID,
+		&nullDatabaseID,
 		&policyRaw.Type,
 		&policyRaw.Payload,
 	); err != nil {
@@ -435,21 +545,58 @@ func upsertPolicyImpl(ctx context.Context, tx *sql.Tx, upsert *api.PolicyUpsert)
 		}
 		return nil, FormatError(err)
 	}
+	policyRaw.setResource(nullEnvironmentID, nullProjectID, nullDatabaseID)
 	return &policyRaw, nil
 }
 
-// deletePolicyImpl deletes an existing ARCHIVED policy by id and type.
-func deletePolicyImpl(ctx context.Context, tx *sql.Tx, delete *api.PolicyDelete) error {
+// deletePolicyImpl deletes an existing ARCHIVED policy by id.
+func deletePolicyImpl(ctx context.Context, tx *sql.Tx, id int) error {
 	// Remove row from policy.
 	if _, err := tx.ExecContext(ctx, `
 		DELETE FROM policy
-			WHERE environment_id = $1 AND type = $2 AND row_status = $3
+			WHERE id = $1 AND row_status = $2
 		`,
-		delete.EnvironmentID,
-		delete.Type,
+		id,
 		api.Archived,
 	); err != nil {
 		return FormatError(err)
 	}
 	return nil
 }
+
+// setResource sets the resource type and id by the nullable resource columns, and only one of them is valid.
+func (raw *policyRaw) setResource(environmentID, projectID, databaseID sql.NullInt32) {
+	switch {
+	case projectID.Valid:
+		raw.ResourceType = api.PolicyResourceTypeProject
+		raw.ProjectID = int(projectID.Int32)
+	case databaseID.Valid:
+		raw.ResourceType = api.PolicyResourceTypeDatabase
+		raw.DatabaseID = int(databaseID.Int32)
+	default:
+		raw.ResourceType = api.PolicyResourceTypeEnvironment
+		raw.EnvironmentID = int(environmentID.Int32)
+	}
+}
+
+// resourceName returns the name of the resource the policy is attached to, e.g. "environment 101".
+func (raw *policyRaw) resourceName() string {
+	switch raw.ResourceType {
+	case api.PolicyResourceTypeProject:
+		return fmt.Sprintf("project %d", raw.ProjectID)
+	case api.PolicyResourceTypeDatabase:
+		return fmt.Sprintf("database %d", raw.DatabaseID)
+	}
+	return fmt.Sprintf("environment %d", raw.EnvironmentID)
+}
+
+// getPolicyResourceColumn returns the column of the resource id for the resource type.
+func getPolicyResourceColumn(resourceType api.PolicyResourceType) string {
+	switch resourceType {
+	case api.PolicyResourceTypeProject:
+		return "project_id"
+	case api.PolicyResourceTypeDatabase:
+		return "database_id"
+	}
+	return "environment_id"
+}
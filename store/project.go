@@ -24,13 +24,14 @@ type projectRaw struct {
 	UpdatedTs int64
 
 	// Domain specific fields
-	Name           string
-	Key            string
-	WorkflowType   api.ProjectWorkflowType
-	Visibility     api.ProjectVisibility
-	TenantMode     api.ProjectTenantMode
-	DBNameTemplate string
-	RoleProvider   api.ProjectRoleProvider
+	Name             string
+	Key              string
+	WorkflowType     api.ProjectWorkflowType
+	Visibility       api.ProjectVisibility
+	TenantMode       api.ProjectTenantMode
+	DBNameTemplate   string
+	RoleProvider     api.ProjectRoleProvider
+	SchemaChangeType api.ProjectSchemaChangeType
 }
 
 // toProject creates an instance of Project based on the projectRaw.
@@ -45,13 +46,14 @@ func (raw *projectRaw) toProject() *api.Project {
 		UpdaterID: raw.UpdaterID,
 		UpdatedTs: raw.UpdatedTs,
 
-		Name:           raw.Name,
-		Key:            raw.Key,
-		WorkflowType:   raw.WorkflowType,
-		Visibility:     raw.Visibility,
-		TenantMode:     raw.TenantMode,
-		DBNameTemplate: raw.DBNameTemplate,
-		RoleProvider:   raw.RoleProvider,
+		Name:             raw.Name,
+		Key:              raw.Key,
+		WorkflowType:     raw.WorkflowType,
+		Visibility:       raw.Visibility,
+		TenantMode:       raw.TenantMode,
+		DBNameTemplate:   raw.DBNameTemplate,
+		RoleProvider:     raw.RoleProvider,
+		SchemaChangeType: raw.SchemaChangeType,
 	}
 }
 
@@ -321,7 +323,7 @@ func createProjectImpl(ctx context.Context, tx *sql.Tx, create *api.ProjectCreat
 			role_provider
 		)
 		VALUES ($1, $2, $3, $4, 'UI', 'PUBLIC', $5, $6, $7)
-		RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, name, key, workflow_type, visibility, tenant_mode, db_name_template, role_provider
+		RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, name, key, workflow_type, visibility, tenant_mode, db_name_template, role_provider, schema_change_type
 	`
 	var project projectRaw
 	if err := tx.QueryRowContext(ctx, query,
@@ -346,6 +348,7 @@ func createProjectImpl(ctx context.Context, tx *sql.Tx, create *api.ProjectCreat
 		&project.TenantMode,
 		&project.DBNameTemplate,
 		&project.RoleProvider,
+		&project.SchemaChangeType,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -382,7 +385,8 @@ func findProjectImpl(ctx context.Context, tx *sql.Tx, find *api.ProjectFind) ([]
 			visibility,
 			tenant_mode,
 			db_name_template,
-			role_provider
+			role_provider,
+			schema_change_type
 		FROM project
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -410,6 +414,7 @@ func findProjectImpl(ctx context.Context, tx *sql.Tx, find *api.ProjectFind) ([]
 			&project.TenantMode,
 			&project.DBNameTemplate,
 			&project.RoleProvider,
+			&project.SchemaChangeType,
 		); err != nil {
 			return nil, FormatError(err)
 		}
@@ -442,6 +447,9 @@ func patchProjectImpl(ctx context.Context, tx *sql.Tx, patch *api.ProjectPatch)
 	if v := patch.RoleProvider; v != nil {
 		set, args = append(set, fmt.Sprintf("role_provider = $%d", len(args)+1)), append(args, *v)
 	}
+	if v := patch.SchemaChangeType; v != nil {
+		set, args = append(set, fmt.Sprintf("schema_change_type = $%d", len(args)+1)), append(args, *v)
+	}
 
 	args = append(args, patch.ID)
 
@@ -451,7 +459,7 @@ func patchProjectImpl(ctx context.Context, tx *sql.Tx, patch *api.ProjectPatch)
 		UPDATE project
 		SET `+strings.Join(set, ", ")+`
 		WHERE id = $%d
-		RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, name, key, workflow_type, visibility, tenant_mode, db_name_template, role_provider
+		RETURNING id, row_status, creator_id, created_ts, updater_id, updated_ts, name, key, workflow_type, visibility, tenant_mode, db_name_template, role_provider, schema_change_type
 	`, len(args)),
 		args...,
 	).Scan(
@@ -468,6 +476,7 @@ func patchProjectImpl(ctx context.Context, tx *sql.Tx, patch *api.ProjectPatch)
 		&project.TenantMode,
 		&project.DBNameTemplate,
 		&project.RoleProvider,
+		&project.SchemaChangeType,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: fmt.Errorf("project ID not found: %d", patch.ID)}
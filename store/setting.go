@@ -50,11 +50,12 @@ func (raw *settingRaw) toSetting() *api.Setting {
 func (s *Store) CreateSettingIfNotExist(ctx context.Context, create *api.SettingCreate) (*api.Setting, error) {
 	settingRaw, err := s.createSettingRawIfNotExist(ctx, create)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Setting with SettingCreate[%+v], error: %w", create, err)
+		// The value is left out, since the setting may be a secret.
+		return nil, fmt.Errorf("failed to create Setting %q, error: %w", create.Name, err)
 	}
 	setting, err := s.composeSetting(ctx, settingRaw)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compose Setting with settingRaw[%+v], error: %w", settingRaw, err)
+		return nil, fmt.Errorf("failed to compose Setting %q, error: %w", settingRaw.Name, err)
 	}
 	return setting, nil
 }
@@ -69,7 +70,7 @@ func (s *Store) FindSetting(ctx context.Context, find *api.SettingFind) ([]*api.
 	for _, raw := range settingRawList {
 		setting, err := s.composeSetting(ctx, raw)
 		if err != nil {
-			return nil, fmt.Errorf("failed to compose Setting with settingRaw[%+v], error: %w", raw, err)
+			return nil, fmt.Errorf("failed to compose Setting %q, error: %w", raw.Name, err)
 		}
 		settingList = append(settingList, setting)
 	}
@@ -80,11 +81,12 @@ func (s *Store) FindSetting(ctx context.Context, find *api.SettingFind) ([]*api.
 func (s *Store) PatchSetting(ctx context.Context, patch *api.SettingPatch) (*api.Setting, error) {
 	settingRaw, err := s.patchSettingRaw(ctx, patch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to patch Setting with SettingPatch[%+v], error: %w", patch, err)
+		// The value is left out, since the setting may be a secret.
+		return nil, fmt.Errorf("failed to patch Setting %q, error: %w", patch.Name, err)
 	}
 	setting, err := s.composeSetting(ctx, settingRaw)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compose Setting with settingRaw[%+v], error: %w", settingRaw, err)
+		return nil, fmt.Errorf("failed to compose Setting %q, error: %w", settingRaw.Name, err)
 	}
 	return setting, nil
 }
@@ -172,10 +172,9 @@ func TestPITR(t *testing.T) {
 
 		t.Log("Create a full backup")
 		backup, err := ctl.createBackup(api.BackupCreate{
-			DatabaseID:     database.ID,
-			Name:           "first-backup",
-			Type:           api.BackupTypeManual,
-			StorageBackend: api.BackupStorageBackendLocal,
+			DatabaseID: database.ID,
+			Name:       "first-backup",
+			Type:       api.BackupTypeManual,
 		})
 		a.NoError(err)
 		err = ctl.waitBackup(database.ID, backup.ID)
@@ -259,10 +258,9 @@ func TestPITR(t *testing.T) {
 
 		t.Log("Create a full backup")
 		backup, err := ctl.createBackup(api.BackupCreate{
-			DatabaseID:     database.ID,
-			Name:           "first-backup",
-			Type:           api.BackupTypeManual,
-			StorageBackend: api.BackupStorageBackendLocal,
+			DatabaseID: database.ID,
+			Name:       "first-backup",
+			Type:       api.BackupTypeManual,
 		})
 		a.NoError(err)
 		err = ctl.waitBackup(database.ID, backup.ID)
@@ -352,10 +350,9 @@ func TestPITR(t *testing.T) {
 
 		t.Log("Create a full backup")
 		backup, err := ctl.createBackup(api.BackupCreate{
-			DatabaseID:     database.ID,
-			Name:           "first-backup",
-			Type:           api.BackupTypeManual,
-			StorageBackend: api.BackupStorageBackendLocal,
+			DatabaseID: database.ID,
+			Name:       "first-backup",
+			Type:       api.BackupTypeManual,
 		})
 		a.NoError(err)
 		err = ctl.waitBackup(database.ID, backup.ID)
@@ -452,10 +449,9 @@ func TestPITR(t *testing.T) {
 
 		t.Log("Create a full backup")
 		backup, err := ctl.createBackup(api.BackupCreate{
-			DatabaseID:     database.ID,
-			Name:           "first-backup",
-			Type:           api.BackupTypeManual,
-			StorageBackend: api.BackupStorageBackendLocal,
+			DatabaseID: database.ID,
+			Name:       "first-backup",
+			Type:       api.BackupTypeManual,
 		})
 		a.NoError(err)
 		err = ctl.waitBackup(database.ID, backup.ID)
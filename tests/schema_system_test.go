@@ -339,6 +339,107 @@ func TestSchemaSystem(t *testing.T) {
 		a.Equal(t.result, result)
 	}
 
+	// The project policy overrides the environment policy.
+	projectPolicyPayload, err := marshalSchemaReviewPolicy(&advisor.SchemaReviewPolicy{
+		Name: "Project",
+		RuleList: []*advisor.SchemaReviewRule{
+			{
+				Type:  advisor.SchemaRuleMySQLEngine,
+				Level: advisor.SchemaRuleLevelError,
+			},
+		},
+	})
+	a.NoError(err)
+	err = ctl.upsertPolicy(api.PolicyUpsert{
+		ResourceType: api.PolicyResourceTypeProject,
+		ProjectID:    project.ID,
+		Type:         api.PolicyTypeSchemaReview,
+		Payload:      &projectPolicyPayload,
+	})
+	a.NoError(err)
+
+	result := createIssueAndReturnSchemaReviewResult(a, ctl, database.ID, project.ID, project.Creator.ID, statements[1])
+	a.Equal([]api.TaskCheckResult{
+		{
+			Status:         api.TaskCheckStatusError,
+			Namespace:      api.AdvisorNamespace,
+			Code:           advisor.NotInnoDBEngine.Int(),
+			Title:          "engine.mysql.use-innodb",
+			Content:        fmt.Sprintf("%q doesn't use InnoDB engine", statements[1]),
+			StatementIndex: 1,
+			Line:           1,
+			Column:         1,
+		},
+	}, result)
+
+	// The database policy overrides the project policy.
+	databasePolicyPayload, err := marshalSchemaReviewPolicy(&advisor.SchemaReviewPolicy{
+		Name: "Database",
+		RuleList: []*advisor.SchemaReviewRule{
+			{
+				Type:  advisor.SchemaRuleTableNaming,
+				Level: advisor.SchemaRuleLevelWarning,
+			},
+		},
+	})
+	a.NoError(err)
+	err = ctl.upsertPolicy(api.PolicyUpsert{
+		ResourceType: api.PolicyResourceTypeDatabase,
+		DatabaseID:   database.ID,
+		Type:         api.PolicyTypeSchemaReview,
+		Payload:      &databasePolicyPayload,
+	})
+	a.NoError(err)
+
+	result = createIssueAndReturnSchemaReviewResult(a, ctl, database.ID, project.ID, project.Creator.ID, statements[1])
+	a.Equal([]api.TaskCheckResult{
+		{
+			Status:         api.TaskCheckStatusWarn,
+			Namespace:      api.AdvisorNamespace,
+			Code:           advisor.NamingTableConventionMismatch.Int(),
+			Title:          "naming.table",
+			Content:        "`userTable` mismatches table naming convention, naming format should be \"^[a-z]+(_[a-z]+)*$\"",
+			StatementIndex: 1,
+			Line:           1,
+			Column:         1,
+		},
+	}, result)
+
+	// Only the schema review policy can be attached to the project and the database.
+	approvalPolicyPayload := `{"value":"MANUAL_APPROVAL_NEVER"}`
+	err = ctl.upsertPolicy(api.PolicyUpsert{
+		ResourceType: api.PolicyResourceTypeProject,
+		ProjectID:    project.ID,
+		Type:         api.PolicyTypePipelineApproval,
+		Payload:      &approvalPolicyPayload,
+	})
+	a.Error(err)
+
+	// The archived overrides fall through to the environment policy.
+	overrideList := []api.PolicyDelete{
+		{ResourceType: api.PolicyResourceTypeDatabase, DatabaseID: database.ID, Type: api.PolicyTypeSchemaReview},
+		{ResourceType: api.PolicyResourceTypeProject, ProjectID: project.ID, Type: api.PolicyTypeSchemaReview},
+	}
+	for _, override := range overrideList {
+		disable := string(api.Archived)
+		err = ctl.upsertPolicy(api.PolicyUpsert{
+			ResourceType: override.ResourceType,
+			ProjectID:    override.ProjectID,
+			DatabaseID:   override.DatabaseID,
+			Type:         override.Type,
+			RowStatus:    &disable,
+		})
+		a.NoError(err)
+	}
+
+	result = createIssueAndReturnSchemaReviewResult(a, ctl, database.ID, project.ID, project.Creator.ID, statements[0])
+	a.Equal(tests[0].result, result)
+
+	for _, override := range overrideList {
+		err = ctl.deletePolicy(override)
+		a.NoError(err)
+	}
+
 	// disable the schema review policy
 	disable := string(api.Archived)
 	err = ctl.upsertPolicy(api.PolicyUpsert{
@@ -349,7 +450,7 @@ func TestSchemaSystem(t *testing.T) {
 	})
 	a.NoError(err)
 
-	result := createIssueAndReturnSchemaReviewResult(a, ctl, database.ID, project.ID, project.Creator.ID, statements[0])
+	result = createIssueAndReturnSchemaReviewResult(a, ctl, database.ID, project.ID, project.Creator.ID, statements[0])
 	a.Equal(noSchemaReviewPolicy, result)
 
 	// delete the schema review policy
@@ -185,10 +185,9 @@ func TestSchemaAndDataUpdate(t *testing.T) {
 
 	// Create a manual backup.
 	backup, err := ctl.createBackup(api.BackupCreate{
-		DatabaseID:     database.ID,
-		Name:           "name",
-		Type:           api.BackupTypeManual,
-		StorageBackend: api.BackupStorageBackendLocal,
+		DatabaseID: database.ID,
+		Name:       "name",
+		Type:       api.BackupTypeManual,
 	})
 	a.NoError(err)
 	err = ctl.waitBackup(backup.DatabaseID, backup.ID)
@@ -1332,7 +1332,7 @@ func (ctl *controller) upsertPolicy(policyUpsert api.PolicyUpsert) error {
 		return fmt.Errorf("failed to marshal policyUpsert, error: %w", err)
 	}
 
-	body, err := ctl.patch(fmt.Sprintf("/policy/environment/%d?type=%s", policyUpsert.EnvironmentID, policyUpsert.Type), buf)
+	body, err := ctl.patch(fmt.Sprintf("%s?type=%s", getPolicyResourcePath(policyUpsert.ResourceType, policyUpsert.EnvironmentID, policyUpsert.ProjectID, policyUpsert.DatabaseID), policyUpsert.Type), buf)
 	if err != nil {
 		return err
 	}
@@ -1346,13 +1346,24 @@ func (ctl *controller) upsertPolicy(policyUpsert api.PolicyUpsert) error {
 
 // deletePolicy deletes the archived policy.
 func (ctl *controller) deletePolicy(policyDelete api.PolicyDelete) error {
-	_, err := ctl.delete(fmt.Sprintf("/policy/environment/%d?type=%s", policyDelete.EnvironmentID, policyDelete.Type), new(bytes.Buffer))
+	_, err := ctl.delete(fmt.Sprintf("%s?type=%s", getPolicyResourcePath(policyDelete.ResourceType, policyDelete.EnvironmentID, policyDelete.ProjectID, policyDelete.DatabaseID), policyDelete.Type), new(bytes.Buffer))
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// getPolicyResourcePath returns the API path of the policy for the resource, and the environment is the default resource type.
+func getPolicyResourcePath(resourceType api.PolicyResourceType, environmentID, projectID, databaseID int) string {
+	switch resourceType {
+	case api.PolicyResourceTypeProject:
+		return fmt.Sprintf("/policy/project/%d", projectID)
+	case api.PolicyResourceTypeDatabase:
+		return fmt.Sprintf("/policy/database/%d", databaseID)
+	}
+	return fmt.Sprintf("/policy/environment/%d", environmentID)
+}
+
 // schemaReviewTaskCheckRunFinished will return schema review task check result for next task.
 // If the schema review task check is not done, return nil, false, nil.
 func (ctl *controller) schemaReviewTaskCheckRunFinished(issue *api.Issue) ([]api.TaskCheckResult, bool, error) {
@@ -1427,8 +1438,10 @@ func setDefaultSchemaReviewRulePayload(ruleTp advisor.SchemaReviewRuleType) (str
 	case advisor.SchemaRuleStatementRequireWhere:
 	case advisor.SchemaRuleStatementNoLeadingWildcardLike:
 	case advisor.SchemaRuleTableRequirePK:
+	case advisor.SchemaRuleTableNoDuplicateIndex:
 	case advisor.SchemaRuleColumnNotNull:
 	case advisor.SchemaRuleSchemaBackwardCompatibility:
+	case advisor.SchemaRuleColumnAutoIncrementMustUnsignedBigint:
 	case advisor.SchemaRuleTableNaming:
 		fallthrough
 	case advisor.SchemaRuleColumnNaming:
@@ -1447,6 +1460,42 @@ func setDefaultSchemaReviewRulePayload(ruleTp advisor.SchemaReviewRuleType) (str
 		payload, err = json.Marshal(advisor.NamingRulePayload{
 			Format: "^fk_{{referencing_table}}_{{referencing_column}}_{{referenced_table}}_{{referenced_column}}$",
 		})
+	case advisor.SchemaRuleStatementAffectedRowLimit:
+		payload, err = json.Marshal(advisor.NumberTypeRulePayload{
+			Number: 1000,
+		})
+	case advisor.SchemaRuleColumnMaximumVarcharLength:
+		payload, err = json.Marshal(advisor.NumberTypeRulePayload{
+			Number: 2560,
+		})
+	case advisor.SchemaRuleIndexTotalNumberLimit:
+		payload, err = json.Marshal(advisor.NumberTypeRulePayload{
+			Number: 5,
+		})
+	case advisor.SchemaRuleTableCommentConvention:
+		fallthrough
+	case advisor.SchemaRuleColumnCommentConvention:
+		payload, err = json.Marshal(advisor.CommentConventionRulePayload{
+			Required:  true,
+			MaxLength: 64,
+		})
+	case advisor.SchemaRuleColumnTypeDisallowList:
+		payload, err = json.Marshal(advisor.StringArrayTypeRulePayload{
+			List: []string{"ENUM", "FLOAT"},
+		})
+	case advisor.SchemaRuleCharsetAllowlist:
+		payload, err = json.Marshal(advisor.StringArrayTypeRulePayload{
+			List: []string{"utf8mb4", "UTF8"},
+		})
+	case advisor.SchemaRuleCollationAllowlist:
+		payload, err = json.Marshal(advisor.StringArrayTypeRulePayload{
+			List: []string{"utf8mb4_general_ci", "utf8mb4_0900_ai_ci"},
+		})
+	case advisor.SchemaRuleCustomExpression:
+		payload, err = json.Marshal(advisor.CustomExpressionRulePayload{
+			Title:      "Disallow JSON column",
+			Expression: "statementType == 'ADD_COLUMN' && columnType == 'json'",
+		})
 	case advisor.SchemaRuleRequiredColumn:
 		payload, err = json.Marshal(advisor.RequiredColumnRulePayload{
 			ColumnList: []string{
@@ -1527,6 +1576,11 @@ func prodTemplateSchemaReviewPolicy() (string, error) {
 		},
 	}
 
+	return marshalSchemaReviewPolicy(&policy)
+}
+
+// marshalSchemaReviewPolicy sets the default payload for the rules and returns the policy payload.
+func marshalSchemaReviewPolicy(policy *advisor.SchemaReviewPolicy) (string, error) {
 	for _, rule := range policy.RuleList {
 		payload, err := setDefaultSchemaReviewRulePayload(rule.Type)
 		if err != nil {